@@ -42,6 +42,48 @@ type ConfigSpec struct {
 	// +kubebuilder:default=prefixed
 	// +optional
 	Naming string `json:"naming"`
+	// KindAliases is a mapping of alias -> containerlab kind that is applied when processing
+	// Topology definitions. This exists to paper over naming drift between tools and users and
+	// containerlab kinds -- for example {"eos": "ceos", "iosv": "cisco_vios"}. Any node, kind, or
+	// defaults entry using an alias is rewritten to the containerlab kind (and a warning logged).
+	// +optional
+	KindAliases map[string]string `json:"kindAliases,omitempty"`
+	// KindDefaultImages is a mapping of containerlab kind -> image to use for nodes of that kind
+	// when the Topology definition does not provide an image for the node (either directly, or via
+	// the kinds or defaults sections).
+	// +optional
+	KindDefaultImages map[string]string `json:"kindDefaultImages,omitempty"`
+	// ConnectivityPorts holds the global overrides of the ports used for the connectivity between
+	// launcher pods, Topologies may override these individually.
+	// +optional
+	ConnectivityPorts ConnectivityPorts `json:"connectivityPorts,omitempty"`
+	// FeatureGates is a mapping of feature gate name -> enabled that overrides the default state of
+	// the named reconciler behaviors, this allows for enabling/disabling (newer) behaviors per
+	// cluster. Unknown gates are ignored (and a warning logged). The resolved gates are logged when
+	// the manager starts and are served on the "/featuregates" endpoint of the manager.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// NetworkPolicy holds the settings of the network policies clabernetes renders for the
+	// launchers of each Topology.
+	// +optional
+	NetworkPolicy ConfigNetworkPolicy `json:"networkPolicy,omitempty"`
+	// MgmtDNS holds the settings of the dns records of the management interface addresses of
+	// vrnetlab based nodes, which are not created unless a provider is configured.
+	// +optional
+	MgmtDNS ConfigMgmtDNS `json:"mgmtDNS,omitempty"`
+	// Metrics holds the settings of the scraping of the launcher metrics, nothing is rendered for
+	// it unless enabled.
+	// +optional
+	Metrics ConfigMetrics `json:"metrics,omitempty"`
+	// ImageScanning holds the settings of the pre-deploy image scanning gate, which is disabled
+	// unless a scanner is configured.
+	// +optional
+	ImageScanning ConfigImageScanning `json:"imageScanning,omitempty"`
+	// NativeKinds is a mapping of containerlab kind -> native mode settings for nodes of that kind,
+	// applied on top of the built-in native mode kind driver for kinds clabernetes knows about, or
+	// in place of one for kinds it does not know about.
+	// +optional
+	NativeKinds map[string]ConfigNativeKind `json:"nativeKinds,omitempty"`
 }
 
 // ConfigStatus is the status for a Config resource.
@@ -97,6 +97,19 @@ type ConfigDeployment struct {
 	// +optional
 	// +listType=atomic
 	ExtraEnv []k8scorev1.EnvVar `json:"extraEnv"`
+	// KVMKinds is a list of containerlab kinds that require kvm (i.e. vrnetlab/vm based kinds).
+	// When set, launchers for nodes of these kinds get a required node affinity for nodes that the
+	// clicker capability scan labelled as having kvm available (and get /dev/kvm mounted), while
+	// launchers for nodes of any other kind do not get /dev/kvm mounted at all. When unset
+	// /dev/kvm is mounted for all launchers and no kvm node affinity is set.
+	// +optional
+	// +listType=atomic
+	KVMKinds []string `json:"kvmKinds,omitempty"`
+	// PriorityClassName is the default PriorityClass of launcher pods, used for topologies that do
+	// not set a priority class (for all or the given node) themselves. Set it to a low priority
+	// class to make labs preemptible by other cluster workloads, or to a high one to protect them.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
 // ConfigImagePull holds configurations relevant to how clabernetes launcher pods handle pulling
@@ -140,4 +153,195 @@ type ConfigImagePull struct {
 	// in here in the event your cluster doesn't support the preferred image pull through option.
 	// +optional
 	DockerConfig string `json:"dockerConfig,omitempty"`
+	// PropagatedPullSecret is the name of a docker-registry (kubernetes.io/dockerconfigjson) secret
+	// in the clabernetes manager namespace that is copied into every namespace holding topologies.
+	// The copies are kept in sync with the source secret, are owned by the topologies of their
+	// namespace (so they are garbage collected along with the last topology in a namespace), and
+	// are set as image pull secret on all launcher pods -- this way launcher images can be pulled
+	// from a private registry in fresh namespaces without any further setup.
+	// +optional
+	PropagatedPullSecret string `json:"propagatedPullSecret,omitempty"`
+}
+
+// ConfigNetworkPolicy holds the settings of the network policies clabernetes renders for the
+// launchers of each Topology. The policy allows the launchers of a Topology to talk to each other,
+// the exposed ports of the launchers to be reached, and the launchers to reach the cluster dns, the
+// kubernetes api (and image registries) and the Topology ntp server -- everything else is denied.
+type ConfigNetworkPolicy struct {
+	// Renderer selects the flavor of network policy to render: "none" (the default) renders no
+	// policy at all, "kubernetes" renders a (vanilla) NetworkPolicy, and "cilium" renders a
+	// CiliumNetworkPolicy -- which, unlike a NetworkPolicy, can allow the kubernetes api by entity
+	// rather than by port. Switching renderers removes the policies of the previous renderer.
+	// +kubebuilder:validation:Enum=none;kubernetes;cilium
+	// +optional
+	Renderer string `json:"renderer,omitempty"`
+	// ExposedPortsCIDRs is a list of cidrs allowed to reach the exposed ports of the launchers,
+	// when unset the exposed ports can be reached from anywhere.
+	// +optional
+	// +listType=atomic
+	ExposedPortsCIDRs []string `json:"exposedPortsCIDRs,omitempty"`
+	// CiliumExposedPortsEntities is a list of cilium entities (for example "world", "host" or
+	// "remote-node") allowed to reach the exposed ports of the launchers with the cilium renderer,
+	// this is how node port traffic (which cilium may see as coming from a node rather than the
+	// client) is allowed. When both this and ExposedPortsCIDRs are unset the "all" entity is
+	// allowed.
+	// +optional
+	// +listType=atomic
+	CiliumExposedPortsEntities []string `json:"ciliumExposedPortsEntities,omitempty"`
+}
+
+// ConfigMgmtDNS holds the settings of the (optional) dns records clabernetes creates for the
+// addresses of the dedicated (multus) management interface of vrnetlab based nodes, so collectors
+// and users can address the nodes by stable names rather than by address.
+type ConfigMgmtDNS struct {
+	// Provider selects how the records are published: "none" (the default) creates no records,
+	// "externalDNS" renders a DNSEndpoint per Topology for ExternalDNS (the crd source of
+	// ExternalDNS must be enabled), and "coreDNS" renders a ConfigMap per Topology holding the
+	// records in hosts file format for the CoreDNS hosts plugin. Switching providers removes the
+	// records of the previous provider.
+	// +kubebuilder:validation:Enum=none;externalDNS;coreDNS
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// DomainTemplate is the go template rendering the (fully qualified) name of the record of a
+	// node, with the ".NodeName", ".TopologyName" and ".Namespace" of the node available, for
+	// example "{{ .NodeName }}.{{ .TopologyName }}.example.internal". When unset the records are
+	// named "<node>.<topology>.<namespace>.clab".
+	// +optional
+	DomainTemplate string `json:"domainTemplate,omitempty"`
+	// TTL is the ttl (in seconds) of the records, defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTL int64 `json:"ttl,omitempty"`
+}
+
+// ConfigMetrics holds the settings of the scraping of the metrics the launchers serve (the traffic
+// counters of their links) by the prometheus operator.
+type ConfigMetrics struct {
+	// PodMonitor, when true, renders a prometheus operator PodMonitor per Topology scraping the
+	// "metrics" port of its launcher pods, with the topology (and launcher node) attached to the
+	// metrics as "clab_topology" and "clab_launcher" labels. Leave this off in clusters without
+	// the prometheus operator crds.
+	// +optional
+	PodMonitor bool `json:"podMonitor,omitempty"`
+	// Labels holds key/value pairs that should be set as labels on the PodMonitors on top of the
+	// global labels -- typically whatever label the Prometheus podMonitorSelector selects on.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// ScrapeInterval is the interval the launcher metrics are scraped at, for example "30s", when
+	// unset the scrape interval of the Prometheus is used.
+	// +kubebuilder:validation:Pattern=`^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`
+	// +optional
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+}
+
+// ConfigImageScanning holds the settings of the (optional) pre-deploy image scanning gate -- when a
+// scanner is configured the image of each node is looked up in (or submitted to) the scanner before
+// the node is deployed, the results are recorded in the Topology status, and the policy decides if
+// nodes whose image fails the scan are deployed anyway (with a warning) or not at all.
+type ConfigImageScanning struct {
+	// Scanner selects the scanner to query: "none" (the default) disables image scanning, "trivy"
+	// submits images to a trivy scanner adapter (anything speaking the harbor pluggable scanner
+	// api, for example harbor-scanner-trivy), and "harbor" reads the scan overview of images hosted
+	// in a harbor registry (images hosted anywhere else can not be scanned with this scanner).
+	// +kubebuilder:validation:Enum=none;trivy;harbor
+	// +optional
+	Scanner string `json:"scanner,omitempty"`
+	// Endpoint is the base url of the scanner, for example "http://trivy-adapter.harbor:8080" or
+	// "https://harbor.example.com".
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Policy decides what happens with nodes whose image failed the scan, could not be scanned,
+	// or whose scan is not finished yet: "warn" (the default) deploys them anyway and reports the
+	// images in the "ImageScanFailed" condition, "block" does not deploy them until their image
+	// passes the scan (nodes that are already deployed are not updated to the blocked image).
+	// +kubebuilder:validation:Enum=warn;block
+	// +optional
+	Policy string `json:"policy,omitempty"`
+	// SeverityThreshold is the lowest vulnerability severity that fails the scan, defaults to
+	// "CRITICAL".
+	// +kubebuilder:validation:Enum=LOW;MEDIUM;HIGH;CRITICAL
+	// +optional
+	SeverityThreshold string `json:"severityThreshold,omitempty"`
+	// CredentialsSecret is the name of a secret in the clabernetes manager namespace holding the
+	// credentials for the scanner -- either a "token" key (sent as bearer token) or "username" and
+	// "password" keys (sent as basic auth). Optional, scanners that allow anonymous access do not
+	// need it.
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// ConfigNativeKind holds the native mode settings of a containerlab kind -- what the containerlab
+// kind driver would do for nodes of the kind in docker mode, but the native mode nos container has
+// to be told explicitly. Env, Command and Args are go templates, rendered with the node name
+// (".NodeName"), kind (".Kind"), type (".Type"), number of interfaces (".InterfaceCount") and the
+// env of the nos container (".Env", for example "{{ .Env.CONNECTION_MODE }}").
+type ConfigNativeKind struct {
+	// Vrnetlab sets the env every vrnetlab kind driver of containerlab sets: CONNECTION_MODE ("tc"
+	// unless the node sets another mode) and CLAB_INTFS (the number of interfaces of the node).
+	// +optional
+	Vrnetlab bool `json:"vrnetlab,omitempty"`
+	// Env holds env vars set on the nos container unless the node sets them.
+	// +optional
+	Env map[string]string `json:"env,omitempty"`
+	// StartupConfigPath is the path the startup-config of the node is mounted at in the nos
+	// container. The startup-config must come from the files from config map of the node, partial
+	// startup-configs are not mounted.
+	// +optional
+	StartupConfigPath string `json:"startupConfigPath,omitempty"`
+	// LicensePath is the path the license of the node is mounted at in the nos container, in place
+	// of the license path of the built-in driver of the kind. The license must come from the files
+	// from config map (or from secret) of the node.
+	// +optional
+	LicensePath string `json:"licensePath,omitempty"`
+	// Mounts holds files from the files from config map of the node to mount in the nos container.
+	// +optional
+	Mounts []ConfigNativeKindMount `json:"mounts,omitempty"`
+	// Command replaces the command of the nos container. The cmd/entrypoint of the node still win.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args replaces the args of the nos container. The cmd of the node still wins.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// StartupSeconds is the default startup probe time of nodes of the kind, for kinds that take
+	// longer to boot than the default startup probe allows for. Applies in docker mode as well.
+	// +optional
+	StartupSeconds int `json:"startupSeconds,omitempty"`
+	// MaxInterfaces is the maximum number of interfaces nodes of the kind support, in place of the
+	// built-in limit of the kind (if any). Topologies with nodes that have links on more interfaces
+	// than that fail to render instead of the nos silently ignoring the extra interfaces. Applies
+	// in docker mode as well.
+	// +optional
+	MaxInterfaces int `json:"maxInterfaces,omitempty"`
+	// Privileged runs the nos container privileged even if the launcher is not (not under sysbox).
+	// +optional
+	Privileged bool `json:"privileged,omitempty"`
+	// Capabilities are added to the nos container when it does not run privileged (not under
+	// sysbox).
+	// +optional
+	Capabilities []k8scorev1.Capability `json:"capabilities,omitempty"`
+	// InterfaceAliasPattern is a regular expression matching the interface aliases of the kind, its
+	// first capture group the port number -- interface names in links matching it are renamed to
+	// "eth<port + InterfaceOffset>", for example "^ge-0/0/(\d+)$" with an offset of 1 renames
+	// "ge-0/0/0" to "eth1". Aliases that would end up as eth0 (the management interface) or below
+	// are left alone.
+	// +optional
+	InterfaceAliasPattern string `json:"interfaceAliasPattern,omitempty"`
+	// InterfaceOffset is added to the port number captured by InterfaceAliasPattern.
+	// +optional
+	InterfaceOffset int `json:"interfaceOffset,omitempty"`
+	// Resources are the resources of the nos container of nodes of the kind, in place of the
+	// built-in sizing of the kind. Like the built-in sizing they only apply if neither the topology
+	// nor the resourcesByContainerlabKind of the global config set resources for the node.
+	// +optional
+	Resources *k8scorev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ConfigNativeKindMount is a file of the files from config map of a node (by its containerlab
+// file path, or just the base name of it) to mount at the given path in the nos container.
+type ConfigNativeKindMount struct {
+	// FilePath is the (containerlab) file path of the file in the files from config map of the
+	// node, or the base name of it.
+	FilePath string `json:"filePath"`
+	// MountPath is the path to mount the file at in the nos container.
+	MountPath string `json:"mountPath"`
 }
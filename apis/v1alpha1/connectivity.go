@@ -27,7 +27,78 @@ type ConnectivitySpec struct {
 }
 
 // ConnectivityStatus is the status for a Connectivity resource.
-type ConnectivityStatus struct{}
+type ConnectivityStatus struct {
+	// TunnelStatuses holds the state of the tunnels as reported by the launchers. The mapping is
+	// nodeName (i.e. srl1) -> statuses of the tunnels of that node. Each launcher only ever writes
+	// the entry of its own node.
+	// +optional
+	TunnelStatuses map[string][]TunnelStatus `json:"tunnelStatuses,omitempty"`
+}
+
+// TunnelStatus holds the state of a single (point-to-point) tunnel as reported by the launcher on
+// the local side of the tunnel.
+type TunnelStatus struct {
+	// LocalInterface is the local interface name of the tunnel.
+	LocalInterface string `json:"localInterface"`
+	// RemoteNode is the name of the node on the remote side of the tunnel.
+	RemoteNode string `json:"remoteNode"`
+	// RemoteInterface is the interface name on the remote side of the tunnel.
+	RemoteInterface string `json:"remoteInterface"`
+	// State is the state of the tunnel -- "up" if the tunnel was set up successfully, "down" if
+	// not, "unreachable" if the tunnel is set up but the remote launcher stopped answering
+	// liveness probes, and "simulated" if the topology uses the "loopback" connectivity flavor so
+	// the tunnel is never actually set up.
+	// +kubebuilder:validation:Enum=up;down;unreachable;simulated
+	State string `json:"state"`
+	// ResolvedDestination is the address the tunnel destination resolved to.
+	// +optional
+	ResolvedDestination string `json:"resolvedDestination,omitempty"`
+	// LastError is the last error the launcher encountered setting up the tunnel, if any.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// LastTransitionTime is the last time the state of the tunnel changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// SelfTest holds the result of the last data-plane self-test of the tunnel, if the self-test
+	// is enabled.
+	// +optional
+	SelfTest *TunnelSelfTestResult `json:"selfTest,omitempty"`
+	// Counters holds the traffic counters of the tunnel as last read by the launcher, if traffic
+	// counters are enabled.
+	// +optional
+	Counters *TunnelCounters `json:"counters,omitempty"`
+}
+
+// TunnelCounters holds the traffic counters of a tunnel as seen by the local node -- "rx" is the
+// traffic the node received over the link, "tx" the traffic the node sent over it.
+type TunnelCounters struct {
+	// RxBytes is the number of bytes the node received over the link.
+	RxBytes int64 `json:"rxBytes"`
+	// TxBytes is the number of bytes the node sent over the link.
+	TxBytes int64 `json:"txBytes"`
+	// RxPackets is the number of packets the node received over the link.
+	RxPackets int64 `json:"rxPackets"`
+	// TxPackets is the number of packets the node sent over the link.
+	TxPackets int64 `json:"txPackets"`
+	// LastUpdateTime is the time the counters were read.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// TunnelSelfTestResult holds the result of a data-plane self-test run by the launcher over the
+// cluster network path of a tunnel.
+type TunnelSelfTestResult struct {
+	// Latency is the average round trip time to the remote launcher, for example "412µs".
+	// +optional
+	Latency string `json:"latency,omitempty"`
+	// Throughput is the throughput achieved towards the remote launcher, for example
+	// "2345.67Mbit/s".
+	// +optional
+	Throughput string `json:"throughput,omitempty"`
+	// Error is the error that prevented the self-test from completing, if any.
+	// +optional
+	Error string `json:"error,omitempty"`
+	// LastTestTime is the time the self-test completed.
+	LastTestTime metav1.Time `json:"lastTestTime"`
+}
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
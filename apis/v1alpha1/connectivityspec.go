@@ -2,7 +2,7 @@ package v1alpha1
 
 // PointToPointTunnel holds information necessary for creating a tunnel between two interfaces on
 // different nodes of a clabernetes Topology. This connection can be established by using clab tools
-// (vxlan) or the experimental slurpeeth (tcp tunnel magic).
+// (vxlan/geneve) or the experimental slurpeeth (tcp tunnel magic).
 type PointToPointTunnel struct {
 	// TunnelID is the id number of the tunnel (vnid or segment id).
 	TunnelID int `json:"tunnelID"`
@@ -20,4 +20,70 @@ type PointToPointTunnel struct {
 	// can properly align tunnels (and ids!) between nodes; basically to know which tunnels are
 	// "paired up".
 	RemoteInterface string `json:"remoteInterface"`
+	// Connectivity is the connectivity flavor of this tunnel, only set if the link of the tunnel
+	// does not use the connectivity flavor of the topology (see the topology linkConnectivity).
+	// +kubebuilder:validation:Enum=vxlan;slurpeeth;geneve;gre
+	// +optional
+	Connectivity string `json:"connectivity,omitempty"`
+	// AdminDown indicates the link of this tunnel is administratively down -- the launcher sets
+	// the local interface of the tunnel down (and back up once this is cleared) without
+	// restarting the node.
+	// +optional
+	AdminDown bool `json:"adminDown,omitempty"`
+	// Impairment holds the (optional) impairments to apply to the traffic the local node sends over
+	// this tunnel.
+	// +optional
+	Impairment *LinkImpairment `json:"impairment,omitempty"`
+	// Bandwidth is the (optional) bandwidth to shape the traffic the local node sends over this
+	// tunnel to, for example "100mbit". Set from the "bandwidth" var of the containerlab link.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(bit|kbit|mbit|gbit|tbit|bps|kbps|mbps|gbps|tbps)$`
+	// +optional
+	Bandwidth string `json:"bandwidth,omitempty"`
+	// Capture holds the (optional) settings of the packet capture to run on the local interface of
+	// this tunnel.
+	// +optional
+	Capture *PacketCapture `json:"capture,omitempty"`
+}
+
+// LinkImpairment holds the impairments to apply (via tc netem) to the traffic a node sends over a
+// link. Any unset field is simply not applied.
+type LinkImpairment struct {
+	// Delay is the delay to add to each packet, for example "10ms".
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(us|ms|s)$`
+	// +optional
+	Delay string `json:"delay,omitempty"`
+	// Jitter is the (random) variation of the delay, for example "2ms".
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(us|ms|s)$`
+	// +optional
+	Jitter string `json:"jitter,omitempty"`
+	// Loss is the percentage of packets to drop, for example "0.5" or "0.5%".
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?%?$`
+	// +optional
+	Loss string `json:"loss,omitempty"`
+	// Rate limits the bandwidth of the link, for example "100mbit".
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?(bit|kbit|mbit|gbit|tbit|bps|kbps|mbps|gbps|tbps)$`
+	// +optional
+	Rate string `json:"rate,omitempty"`
+}
+
+// PacketCapture holds the settings of a packet capture (tcpdump) a launcher runs on a link. The
+// capture files are written to the launcher packet capture directory (or the packet capture
+// claim if one is configured).
+type PacketCapture struct {
+	// Filter is an (optional) tcpdump filter expression, for example "not arp".
+	// +optional
+	Filter string `json:"filter,omitempty"`
+	// RotateSeconds rotates the capture file every so many seconds.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RotateSeconds int `json:"rotateSeconds,omitempty"`
+	// RotateMegabytes rotates the capture file once it reaches so many megabytes.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RotateMegabytes int `json:"rotateMegabytes,omitempty"`
+	// MaxFiles limits the number of (rotated) capture files that are kept, once reached the oldest
+	// file is overwritten. Only has an effect if the capture is rotated.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFiles int `json:"maxFiles,omitempty"`
 }
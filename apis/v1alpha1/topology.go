@@ -45,6 +45,10 @@ type TopologySpec struct {
 	// images.
 	// +optional
 	ImagePull ImagePull `json:"imagePull"`
+	// Network holds configurations relevant to the networking of the launcher pods of a topology,
+	// such as extra /etc/hosts entries and static routes.
+	// +optional
+	Network *Network `json:"network,omitempty"`
 	// Naming tells the clabernetes controller how it should name resources it creates -- that is
 	// whether it should include the containerlab topology name as a prefix on resources spawned
 	// from this Topology or not; this includes the actual (containerlab) node Deployment(s), as
@@ -64,10 +68,103 @@ type TopologySpec struct {
 	// Connectivity defines the type of connectivity to use between nodes in the topology. The
 	// default behavior is to use vxlan tunnels, alternatively you can enable a more experimental
 	// "slurpeeth" connectivity flavor that stuffs traffic into tcp tunnels to avoid any vxlan mtu
-	// and/or fragmentation challenges, or "multus" to use multus cni for connectivity.
-	// +kubebuilder:validation:Enum=vxlan;slurpeeth;multus
+	// and/or fragmentation challenges, "geneve" to use geneve tunnels (much like vxlan, but using
+	// the geneve encapsulation), "wireguard" to carry the vxlan tunnels over wireguard so link
+	// traffic is encrypted on the cluster network, "gre" to use gretap tunnels directly between
+	// launcher pods for clusters that filter vxlan/udp traffic between nodes, "multus" to use
+	// multus cni for connectivity, or "loopback" to not set up any tunnels at all but report them
+	// as simulated -- this is only useful for exercising clabernetes itself in ci/e2e clusters.
+	// +kubebuilder:validation:Enum=vxlan;slurpeeth;geneve;wireguard;gre;multus;loopback
 	// +kubebuilder:default=vxlan
 	Connectivity string `json:"connectivity,omitempty"`
+	// LinkConnectivity is a list of per link overrides of the connectivity flavor, for example to
+	// use "slurpeeth" for the links crossing a firewalled boundary of the cluster and "vxlan" for
+	// all others. The launchers run the tunnels of each flavor side by side. Links can be moved
+	// between the flavors the topology already uses without restarting any nodes, the launchers
+	// pick up the changes via the Connectivity resource; using a flavor that is not used yet (or
+	// no longer using one) restarts the launchers. Link connectivity overrides are only supported
+	// with "vxlan", "slurpeeth", "geneve" or "gre" connectivity.
+	// +listType=atomic
+	// +optional
+	LinkConnectivity []TopologyLinkConnectivity `json:"linkConnectivity,omitempty"`
+	// ConnectivityPorts holds (optional) overrides of the ports used for the connectivity between
+	// the launcher pods of this topology, any unset port falls back to the global config.
+	// +optional
+	ConnectivityPorts *ConnectivityPorts `json:"connectivityPorts,omitempty"`
+	// WireGuardOverlayCIDR is the (ipv4) range the launchers of a topology using "wireguard"
+	// connectivity get their overlay addresses from, defaults to 10.254.0.0/16. Set this if the
+	// default range overlaps the pod, service or node networks of the cluster. Changing it
+	// re-addresses (and so restarts) all the launchers of the topology.
+	// +optional
+	WireGuardOverlayCIDR string `json:"wireGuardOverlayCIDR,omitempty"`
+	// DisabledLinks is a list of link endpoints, in containerlab "node:interface" notation, whose
+	// links are administratively down. Listing either endpoint of a link takes down the whole
+	// link -- the launchers on both sides set the interface down, so the nodes see the link go
+	// down, without restarting any nodes. Removing the endpoint from the list brings the link
+	// back up. Disabling links is not supported with "multus" connectivity.
+	// +listType=atomic
+	// +optional
+	DisabledLinks []string `json:"disabledLinks,omitempty"`
+	// LinkImpairments is a list of impairments (delay, jitter, loss, rate) to apply to the links of
+	// the topology. Impairments are applied by the launchers and can be changed at any time
+	// without restarting any nodes. Impairments are not supported with "slurpeeth" or "multus"
+	// connectivity.
+	// +listType=atomic
+	// +optional
+	LinkImpairments []TopologyLinkImpairment `json:"linkImpairments,omitempty"`
+	// PacketCaptures is a list of packet captures (tcpdump) the launchers run on the given link
+	// endpoints. Captures can be started, changed and stopped without restarting any nodes; the
+	// launchers pick up the changes via the Connectivity resource. Captures are not supported with
+	// "multus" connectivity.
+	// +listType=atomic
+	// +optional
+	PacketCaptures []TopologyPacketCapture `json:"packetCaptures,omitempty"`
+	// WireTaps is a list of link endpoints, in containerlab "node:interface" notation, whose links
+	// are routed through a "wire tap" pod -- a tiny pod the controller deploys in the tunnel path
+	// of the link that terminates the tunnels of both sides and passes the traffic between them as
+	// is. Listing either endpoint of a link taps the whole link. The tap pod has an interface per
+	// side of the link ("a" being the side of the alphabetically first endpoint, "b" the other),
+	// so you can capture or impair the traffic of the link inline (tcpdump, tc) without touching
+	// the nodes. Changing the wire taps recreates the tunnels of the tapped links, the nodes are
+	// not restarted. Wire taps are only supported with "vxlan" connectivity.
+	// +listType=atomic
+	// +optional
+	WireTaps []string `json:"wireTaps,omitempty"`
+	// SelfTest enables the (optional) data-plane self-test -- when set the launchers measure the
+	// latency and throughput of the cluster network path of each tunnel once the tunnels are up,
+	// and report the results in the tunnel statuses of the Connectivity resource. This helps
+	// telling apart node performance problems and cluster network bottlenecks. The self-test is
+	// not supported with "multus" connectivity.
+	// +optional
+	SelfTest *ConnectivitySelfTest `json:"selfTest,omitempty"`
+	// TrafficCounters enables (optional) per link traffic counters -- when set the launchers
+	// periodically read the byte and packet counters of the interfaces of their tunnels and report
+	// them in the tunnel statuses of the Connectivity resource, so you can confirm traffic actually
+	// flows over the emulated links. Traffic counters are not supported with "multus" connectivity.
+	// +optional
+	TrafficCounters *ConnectivityTrafficCounters `json:"trafficCounters,omitempty"`
+	// SlurpeethTLS enables (optional) mutual tls for the "slurpeeth" connectivity flavor -- when set
+	// the launchers wrap the slurpeeth tcp tunnels in tls and only accept tunnels from launchers
+	// presenting a certificate issued by the topology ca. Only relevant for "slurpeeth"
+	// connectivity.
+	// +optional
+	SlurpeethTLS *SlurpeethTLS `json:"slurpeethTLS,omitempty"`
+	// Schedule defines (optional) recurring time windows during which the topology should be
+	// active. Outside of these windows the topology is "suspended" -- its deployments are scaled
+	// to zero, but all other resources are left in place so the topology can be quickly resumed
+	// when the next window opens. When unset the topology is always active.
+	// +optional
+	Schedule *Schedule `json:"schedule,omitempty"`
+	// ClockSync holds (optional) clock synchronization settings for the topology -- a topology
+	// wide ntp server and/or passing the host clock through to qemu based nodes. Protocol and
+	// certificate labs tend to break when (virtual machine) nodes drift.
+	// +optional
+	ClockSync *ClockSync `json:"clockSync,omitempty"`
+	// Graph enables the containerlab graph style (interactive) visualization of the topology,
+	// served by the manager on "/graph/<namespace>/<name>" -- nodes, links and the live node
+	// readiness. Topologies that do not enable it are not served.
+	// +optional
+	Graph bool `json:"graph,omitempty"`
 }
 
 // TopologyStatus is the status for a Topology resource.
@@ -91,16 +188,131 @@ type TopologyStatus struct {
 	ExposedPorts map[string]*ExposedPorts `json:"exposedPorts"`
 	// NodeReadiness is a map of nodename to readiness status. The readiness status is as reported
 	// by the k8s startup/readiness probe (which is in turn managed by the status probe
-	// configuration of the topology). The possible values are "notready" and "ready", "unknown".
+	// configuration of the topology). The possible values are "notready" and "ready", "unknown",
+	// "preempted" (if the node pod was preempted or evicted), "suspended" (if the topology is
+	// suspended due to its schedule), "imageBlocked" (if the image scanning policy blocks the
+	// node image), and "waiting" (if the node is not deployed yet as nodes it waits for -- per its
+	// containerlab wait-for/stages -- are not ready yet).
 	NodeReadiness map[string]string `json:"nodeReadiness"`
+	// NodeTerminations is a map of nodename to the last termination of the node's launcher (or,
+	// in native mode, node) container as reported by kubernetes -- this is where you want to look
+	// to see why a node keeps restarting.
+	// +optional
+	NodeTerminations map[string]NodeTermination `json:"nodeTerminations,omitempty"`
+	// NodeDiskUsage is a mapping of nodes -> used percent of the docker data volume of their
+	// launcher, as last reported by the launchers. Only reported in docker mode.
+	// +optional
+	NodeDiskUsage map[string]int `json:"nodeDiskUsage,omitempty"`
+	// NodeVersions is a mapping of nodes -> versions of the launcher, containerlab, docker and
+	// kernel the node runs with, as last reported by the launchers -- handy to figure out which
+	// nodes (still) run with what after upgrading clabernetes or changing the containerlab version.
+	// +optional
+	NodeVersions map[string]NodeVersions `json:"nodeVersions,omitempty"`
+	// ImageScans is a mapping of node image -> result of the last scan of the image, only
+	// reported if image scanning is enabled in the global config.
+	// +optional
+	ImageScans map[string]ImageScan `json:"imageScans,omitempty"`
 	// TopologyReady indicates if all nodes in the topology have reported ready. This is duplicated
 	// from the conditions so we can easily snag it for print columns!
 	TopologyReady bool `json:"topologyReady"`
+	// Suspended indicates if the topology is currently suspended due to being outside of all of
+	// its configured schedule windows.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+	// NextActivation is the time at which a suspended topology will next become active again as
+	// determined by its schedule.
+	// +optional
+	NextActivation *metav1.Time `json:"nextActivation,omitempty"`
+	// Timeline is a (bounded) chronological list of the key events in the life of the topology --
+	// deployments being rendered and restarted by the controller, launcher terminations and tunnel
+	// state changes reported by the launchers, and node readiness changes reported by the probes.
+	// Only the most recent events are kept, oldest first.
+	// +listType=atomic
+	// +optional
+	Timeline []TimelineEvent `json:"timeline,omitempty"`
 	// Conditions is a list of conditions for the topology custom resource.
 	// +listType=atomic
 	Conditions []metav1.Condition `json:"conditions"`
 }
 
+// NodeTermination holds information about the last termination of a node's container.
+type NodeTermination struct {
+	// Container is the name of the container that terminated.
+	Container string `json:"container"`
+	// Reason is the termination reason -- one of the clabernetes launcher failure reasons
+	// ("ImagePullFailed", "KVMMissing", "TunnelSetupFailed", "ContainerlabDeployFailed",
+	// "LauncherFailed") or, if the container did not write a clabernetes termination message, the
+	// reason kubernetes reported (for example "OOMKilled" or "Error").
+	Reason string `json:"reason"`
+	// Message is the termination message of the container.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// ExitCode is the exit code of the container.
+	ExitCode int32 `json:"exitCode"`
+	// FinishedAt is the time the container terminated.
+	FinishedAt metav1.Time `json:"finishedAt"`
+}
+
+// NodeVersions holds the versions of the software a node runs with.
+type NodeVersions struct {
+	// Launcher is the clabernetes version of the launcher.
+	// +optional
+	Launcher string `json:"launcher,omitempty"`
+	// LauncherImage is the image id (digest) of the launcher image as reported by kubernetes.
+	// +optional
+	LauncherImage string `json:"launcherImage,omitempty"`
+	// Containerlab is the version of containerlab the launcher deploys the node with.
+	// +optional
+	Containerlab string `json:"containerlab,omitempty"`
+	// Docker is the version of the docker daemon of the launcher, only reported in docker mode.
+	// +optional
+	Docker string `json:"docker,omitempty"`
+	// Kernel is the release of the kernel of the (kubernetes) node the launcher runs on.
+	// +optional
+	Kernel string `json:"kernel,omitempty"`
+}
+
+// ImageScan holds the result of the scan of a node image.
+type ImageScan struct {
+	// Scanner is the scanner that scanned the image, as reported by the scanner if it does (for
+	// example "Trivy 0.50.1").
+	Scanner string `json:"scanner"`
+	// Digest is the digest of the scanned image, which is what was actually scanned -- this is
+	// what ties the (mutable) image tag to the scan result.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// Verdict is the verdict of the scan: "passed", "failed" (there are vulnerabilities at or above
+	// the severity threshold), "pending" (the scan is not finished yet) or "error" (the image could
+	// not be scanned).
+	// +kubebuilder:validation:Enum=passed;failed;pending;error
+	Verdict string `json:"verdict"`
+	// Vulnerabilities is a mapping of severity -> count of the vulnerabilities found in the image.
+	// +optional
+	Vulnerabilities map[string]int `json:"vulnerabilities,omitempty"`
+	// Message holds details about the verdict, for example why the image could not be scanned.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// ScannedAt is the time the scan result was retrieved from the scanner.
+	ScannedAt metav1.Time `json:"scannedAt"`
+}
+
+// TimelineEvent is a single event in the timeline of a topology.
+type TimelineEvent struct {
+	// Time is the time the event happened.
+	Time metav1.Time `json:"time"`
+	// Source is the component the event originates from -- "controller", "launcher" or "probe".
+	// +kubebuilder:validation:Enum=controller;launcher;probe
+	Source string `json:"source"`
+	// Node is the (containerlab) node the event relates to, if any.
+	// +optional
+	Node string `json:"node,omitempty"`
+	// Reason is a short, CamelCase, reason for the event, for example "DeploymentCreated".
+	Reason string `json:"reason"`
+	// Message is a human readable description of the event.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // TopologyList is a list of Topology objects.
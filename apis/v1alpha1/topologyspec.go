@@ -22,6 +22,24 @@ type FileFromConfigMap struct {
 	Mode string `json:"mode,omitempty"`
 }
 
+// FileFromSecret represents a file that you would like to mount (from a secret) in the launcher
+// pod for a given node -- for files that should not live in a configmap, like licenses.
+type FileFromSecret struct {
+	// FilePath is the path to mount the file.
+	FilePath string `json:"filePath"`
+	// SecretName is the name of the secret to mount.
+	SecretName string `json:"secretName"`
+	// SecretKey is the key in the secret to mount, if not specified the secret will be mounted
+	// without a sub-path.
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+	// Mode sets the file permissions when mounting the secret, see FileFromConfigMap.
+	// +kubebuilder:validation:Enum=read;execute
+	// +kubebuilder:default=read
+	// +optional
+	Mode string `json:"mode,omitempty"`
+}
+
 // FileFromURL represents a file that you would like to mount from a URL in the launcher pod for
 // a given node.
 type FileFromURL struct {
@@ -66,6 +84,135 @@ type Definition struct {
 	// Kne holds a valid kne topology.
 	// +optional
 	Kne string `json:"kne,omitempty"`
+	// InstanceOffset, when set, renders the containerlab topology as a go template before it is
+	// processed, so that each copy of the same lab stamped into a cluster (for example one per
+	// student in a classroom) gets its own ASNs, VLAN IDs and names -- see InstanceOffset.
+	// +optional
+	InstanceOffset *InstanceOffset `json:"instanceOffset,omitempty"`
+}
+
+// InstanceOffset holds the (numeric) offsets of an instance of a lab. The containerlab topology of
+// the Topology is rendered as a go template with these template functions:
+// "asn BASE" returns BASE + Instance * ASNStep, "vlan BASE" returns BASE + Instance * VLANStep,
+// "offset BASE STEP" returns BASE + Instance * STEP, and "name NAME" returns NAME-Instance. The
+// instance itself is available as ".Instance". Rendering fails if an ASN or VLAN ID ends up out of
+// range.
+type InstanceOffset struct {
+	// Instance is the (zero based) index of this instance of the lab.
+	// +kubebuilder:validation:Minimum=0
+	Instance int `json:"instance"`
+	// ASNStep is how far apart the ASNs of consecutive instances are, defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ASNStep int `json:"asnStep,omitempty"`
+	// VLANStep is how far apart the VLAN IDs of consecutive instances are, defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	VLANStep int `json:"vlanStep,omitempty"`
+}
+
+// ConnectivityPorts holds (optional) overrides of the ports used for the connectivity between
+// launcher pods. Any port left unset (zero) falls back to the global config value, and if that is
+// unset as well, to the clabernetes default for that port. Overriding the ports is mostly useful
+// when multiple clabernetes instances (or forks) share a cluster, or when network policies only
+// permit specific ports between nodes.
+type ConnectivityPorts struct {
+	// VXLAN is the udp destination port of vxlan tunnels (this is also the port of the vxlan
+	// tunnels carried over wireguard), defaults to 6784.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	VXLAN int32 `json:"vxlan,omitempty"`
+	// Slurpeeth is the tcp port slurpeeth listens on (and connects to), defaults to 4799.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Slurpeeth int32 `json:"slurpeeth,omitempty"`
+	// Geneve is the udp destination port of geneve tunnels, defaults to 7784.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Geneve int32 `json:"geneve,omitempty"`
+	// WireGuard is the udp port the launcher wireguard interface listens on, defaults to 4784.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	WireGuard int32 `json:"wireguard,omitempty"`
+}
+
+// TopologyLinkImpairment holds impairments to apply to one or more endpoints of the links in a
+// topology.
+type TopologyLinkImpairment struct {
+	// Endpoints is the list of link endpoints, in containerlab "node:interface" notation, to apply
+	// the impairment to. The impairment applies to the traffic the node sends out of the interface,
+	// so list both endpoints of a link to impair the link in both directions.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Endpoints []string `json:"endpoints"`
+	// LinkImpairment holds the actual impairment settings.
+	LinkImpairment `json:",inline"`
+}
+
+// TopologyLinkConnectivity sets the connectivity flavor of one or more links in a topology.
+type TopologyLinkConnectivity struct {
+	// Endpoints is the list of link endpoints, in containerlab "node:interface" notation, whose
+	// links use the given connectivity flavor. Listing either endpoint of a link sets the flavor of
+	// the whole link.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Endpoints []string `json:"endpoints"`
+	// Connectivity is the connectivity flavor the links use instead of the connectivity flavor of
+	// the topology.
+	// +kubebuilder:validation:Enum=vxlan;slurpeeth;geneve;gre
+	Connectivity string `json:"connectivity"`
+}
+
+// TopologyPacketCapture holds a packet capture to run on the given link endpoints of a Topology.
+type TopologyPacketCapture struct {
+	// Endpoints is the list of link endpoints to capture on, in "node:interface" form, for example
+	// "srl1:e1-1".
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Endpoints []string `json:"endpoints"`
+	// PacketCapture holds the settings of the capture.
+	PacketCapture `json:",inline"`
+}
+
+// ConnectivitySelfTest holds the settings of the launcher data-plane self-test. When enabled each
+// launcher measures the latency and throughput towards the launcher on the remote end of each of
+// its tunnels once the tunnels are up. The test runs between the launchers (not the nodes), over
+// the same cluster network path the tunnels take, so it shows what the cluster network can carry
+// independently of the nodes themselves.
+type ConnectivitySelfTest struct {
+	// DurationSeconds is how long the throughput part of the test runs for each tunnel.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=60
+	// +kubebuilder:default=5
+	// +optional
+	DurationSeconds int `json:"durationSeconds,omitempty"`
+}
+
+// ConnectivityTrafficCounters holds the settings of the launcher traffic counters. When enabled
+// each launcher reads the counters of the interfaces of its tunnels every interval and reports them
+// in the tunnel statuses of the Connectivity resource.
+type ConnectivityTrafficCounters struct {
+	// IntervalSeconds is how often the launchers read and report the counters.
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=3600
+	// +kubebuilder:default=60
+	// +optional
+	IntervalSeconds int `json:"intervalSeconds,omitempty"`
+}
+
+// SlurpeethTLS holds the configuration for mutual tls of the "slurpeeth" connectivity flavor.
+type SlurpeethTLS struct {
+	// SecretName is the (optional) name of a Secret in the namespace of the topology holding the
+	// ca certificate ("ca.crt") and the certificate ("tls.crt") and key ("tls.key") the launchers
+	// present to each other. The certificate must be valid for both server and client auth, the
+	// hostname is not verified. When unset the controller generates a ca and certificate for the
+	// topology.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
 }
 
 // Expose holds configurations relevant to how clabernetes exposes a topology.
@@ -114,6 +261,17 @@ type Expose struct {
 	// +kubebuilder:default=LoadBalancer
 	// +optional
 	ExposeType string `json:"exposeType,omitempty"`
+	// PortExposureMode configures how the launcher exposes the mapped ports of the containerlab
+	// node(s) on the launcher pod when running in docker mode:
+	// - containerlab: (default) ports are published by containerlab/docker port mappings.
+	// - nftables: the launcher strips the port mappings from the containerlab topology and instead
+	//         programs nftables dnat rules from the pod address/port to the node container address
+	//         and port. The rules are reconciled periodically so they follow the node container if
+	//         it is restarted and are removed when the launcher exits.
+	// This setting has no effect in native mode.
+	// +kubebuilder:validation:Enum=containerlab;nftables
+	// +optional
+	PortExposureMode string `json:"portExposureMode,omitempty"`
 	// UseNodeMgmtIpv4Address, when set to true, the controller will look up each node’s management
 	// IPv4 address (from the `mgmt-ipv4` field in your containerlab topology) and assign
 	// that address to `Service.spec.loadBalancerIP` on the corresponding LoadBalancer
@@ -132,6 +290,79 @@ type Expose struct {
 	UseNodeMgmtIpv6Address bool `json:"useNodeMgmtIpv6Address,omitempty"`
 }
 
+// Network holds configurations relevant to the networking of the launcher pods of a topology.
+// +kubebuilder:validation:XValidation:rule="!has(self.dnsPolicy) || self.dnsPolicy != 'None' || (has(self.dnsConfig) && has(self.dnsConfig.nameservers) && size(self.dnsConfig.nameservers) > 0)",message="dnsConfig nameservers are required with the None dnsPolicy"
+type Network struct {
+	// HostAliases is a mapping of nodeName (or "default") to host aliases (/etc/hosts entries) to
+	// set on the launcher pod(s). The "default" aliases are set on all launcher pods, node specific
+	// aliases are set in addition to those.
+	//
+	// Deprecated: use Deployment.HostAliases instead, this field is ignored once that one is set.
+	// +optional
+	HostAliases map[string][]k8scorev1.HostAlias `json:"hostAliases,omitempty"`
+	// StaticRoutes is a mapping of nodeName (or "default") to static routes the launcher adds to
+	// the pod network namespace before starting the node. The "default" routes are added on all
+	// launchers, node specific routes are added in addition to those -- a node specific route for
+	// the same destination as a "default" route replaces the "default" route.
+	// +optional
+	StaticRoutes map[string][]StaticRoute `json:"staticRoutes,omitempty"`
+	// ExternalRoutes is a list of external prefixes, for example the vpn ranges collectors and
+	// users reach the lab management network from, that the launchers of nodes with the dedicated
+	// (multus) management interface route via the gateway of the containerlab management network
+	// ("ipv4-gw"/"ipv6-gw" of the topology "mgmt" settings). Without these the replies of the nodes
+	// to those prefixes leave via the pod network rather than the management network. Prefixes of
+	// a family without a management gateway are ignored.
+	// +listType=atomic
+	// +optional
+	ExternalRoutes []string `json:"externalRoutes,omitempty"`
+	// Underlay configures a dedicated (multus attached) interface for the tunnel traffic between
+	// the launcher pods, rather than sending it over the pod network (eth0). Not supported with
+	// "multus" connectivity (there are no tunnels) or when using the host network.
+	// +optional
+	Underlay *Underlay `json:"underlay,omitempty"`
+	// DNSPolicy is the dns policy of the launcher pods, for example "None" to not inherit the
+	// cluster dns settings at all (in which case DNSConfig must be set) or "Default" to use the dns
+	// settings of the kubernetes node. When unset kubernetes defaults it to "ClusterFirst".
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	// +optional
+	DNSPolicy k8scorev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// DNSConfig holds extra nameservers, search domains and resolver options for the launcher
+	// pods, these are merged with the settings resulting from the DNSPolicy. With the native mode
+	// the nodes share the resolver configuration of the launcher pod, so this also applies to the
+	// nodes themselves.
+	// +optional
+	DNSConfig *k8scorev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+}
+
+// Underlay holds the configuration of a dedicated underlay interface for tunnel traffic. The
+// launcher pods get an additional interface attached from the given NetworkAttachmentDefinition,
+// and the launchers point their tunnels at the address of the remote launcher on that interface,
+// so the tunnel traffic is routed out of the underlay interface. The NetworkAttachmentDefinition
+// must assign an address to the interface (for example via "whereabouts" ipam), and must exist
+// before the topology is created.
+type Underlay struct {
+	// NetworkAttachmentDefinition is the name of the NetworkAttachmentDefinition to attach the
+	// underlay interface from, either just a name (for a NetworkAttachmentDefinition in the
+	// namespace of the topology) or in "namespace/name" form.
+	// +kubebuilder:validation:MinLength=1
+	NetworkAttachmentDefinition string `json:"networkAttachmentDefinition"`
+	// Interface is the name of the underlay interface in the launcher pods, defaults to
+	// "underlay0".
+	// +kubebuilder:validation:MaxLength=15
+	// +optional
+	Interface string `json:"interface,omitempty"`
+}
+
+// StaticRoute is a static route the launcher adds to the pod network namespace.
+type StaticRoute struct {
+	// Destination is the destination prefix of the route, for example "192.0.2.0/24".
+	Destination string `json:"destination"`
+	// Gateway is the (optional) next hop address of the route. When unset the route points at the
+	// default gateway of the pod.
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+}
+
 // Deployment holds configurations relevant to how clabernetes configures deployments that make
 // up a given topology.
 type Deployment struct {
@@ -154,6 +385,14 @@ type Deployment struct {
 	// HostNetwork, when true, sets the pod to use the host network.
 	// +optional
 	HostNetwork *bool `json:"hostNetwork"`
+	// Kind is the kind of workload the launchers run as, "Deployment" (the default) or
+	// "StatefulSet". StatefulSets give the launchers a stable identity and, with persistence
+	// enabled, claim their volume via a volume claim template rather than a separately managed
+	// PVC, which is the more natural fit for persistent nodes. Changing the kind re-deploys all
+	// nodes, and the nodes do not take their persisted data along.
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet
+	// +optional
+	Kind string `json:"kind,omitempty"`
 	// PrivilegedLauncher, when true, sets the launcher containers to privileged. Historically we
 	// tried very hard to *not* need to set privileged mode on pods, however the reality is it is
 	// much, much easier to get various network operating system images booting with this enabled,
@@ -172,6 +411,11 @@ type Deployment struct {
 	// to specify the sub path unless you are sure what you're doing!
 	// +optional
 	FilesFromConfigMap map[string][]FileFromConfigMap `json:"filesFromConfigMap"`
+	// FilesFromSecret is a mapping of FileFromSecret that define the secret/key and path on a
+	// launcher node that the file should be mounted to, just like FilesFromConfigMap. In native
+	// mode node licenses (and other files the kind drivers mount) are looked up here as well.
+	// +optional
+	FilesFromSecret map[string][]FileFromSecret `json:"filesFromSecret,omitempty"`
 	// FilesFromURL is a mapping of FileFromURL that define a URL at which to fetch a file, and path
 	// on a launcher node that the file should be downloaded to. This is useful for configs that are
 	// larger than the ConfigMap (etcd) 1Mb size limit.
@@ -223,6 +467,250 @@ type Deployment struct {
 	// +optional
 	// +listType=atomic
 	ExtraEnv []k8scorev1.EnvVar `json:"extraEnv"`
+	// ExtraInitContainers is a mapping of nodeName (or "default") to additional init containers to
+	// run in the launcher pod(s) ahead of the clabernetes setup init container -- for example to
+	// fetch images or licenses, warm caches or run custom pre-boot scripts. The "default" init
+	// containers run in all launcher pods followed by the node specific ones, a node specific init
+	// container with the same name as a "default" one replaces it. The containers are not
+	// validated here (to keep the crd at a sane size), the api server validates them along with
+	// the launcher deployment.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	// +optional
+	ExtraInitContainers map[string][]k8scorev1.Container `json:"extraInitContainers,omitempty"`
+	// ExtraLabels is a mapping of nodeName (or "default") to additional labels to set on the
+	// launcher deployment(s) and their pods, for example to hook up monitoring or cost allocation.
+	// The "default" labels are set for all nodes, node specific labels are merged on top of those.
+	// Labels clabernetes relies on to select the launcher pods can not be overridden.
+	// +optional
+	ExtraLabels map[string]map[string]string `json:"extraLabels,omitempty"`
+	// ExtraAnnotations is a mapping of nodeName (or "default") to additional annotations to set on
+	// the launcher deployment(s) and their pods, for example multus network annotations for nodes
+	// whose links clabernetes does not attach via multus. The "default" annotations are set for
+	// all nodes, node specific annotations are merged on top of those. Annotations clabernetes
+	// sets itself take precedence.
+	// +optional
+	ExtraAnnotations map[string]map[string]string `json:"extraAnnotations,omitempty"`
+	// HostAliases is a mapping of nodeName (or "default") to host aliases (/etc/hosts entries) to
+	// set on the launcher pod(s), for example so nodes resolve license servers or external
+	// collectors without any DNS changes. The "default" aliases are set on all launcher pods, node
+	// specific aliases are set in addition to those. This replaces the (deprecated) network
+	// HostAliases, which are ignored once this is set.
+	// +optional
+	HostAliases map[string][]k8scorev1.HostAlias `json:"hostAliases,omitempty"`
+	// PriorityClassName sets the PriorityClass for all launcher pods in this Topology. This allows
+	// you to make sure that "critical" labs outrank (and can preempt) batch or less important
+	// workloads in the cluster -- or of course the opposite, make lab pods preemptible. The
+	// PriorityClass must already exist in the cluster, clabernetes does not create it for you.
+	// When unset the default priority class of the global config (if any) is used.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// NodePriorityClassNames is a mapping of nodeName to PriorityClass name -- any value set here
+	// overrides the PriorityClassName setting for the given node.
+	// +optional
+	NodePriorityClassNames map[string]string `json:"nodePriorityClassNames,omitempty"`
+	// RuntimeClassName sets the RuntimeClass for all launcher pods in this Topology. This is useful
+	// for clusters that provide sandboxing runtimes such as sysbox, Kata Containers, or gVisor. When
+	// clabernetes detects one of these runtimes (by the RuntimeClass name containing "sysbox",
+	// "kata", or "gvisor"/"runsc") it adjusts the rendered pods accordingly -- for example, host
+	// device mounts are skipped for all of these runtimes, and sysbox launchers are never set to
+	// privileged since sysbox provides the "privileges" we need inside its user namespace.
+	// +optional
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+	// NodeRuntimeClassNames is a mapping of nodeName to RuntimeClass name -- any value set here
+	// overrides both the KindRuntimeClassNames and the RuntimeClassName settings for the given
+	// node.
+	// +optional
+	NodeRuntimeClassNames map[string]string `json:"nodeRuntimeClassNames,omitempty"`
+	// KindRuntimeClassNames is a mapping of containerlab kind to RuntimeClass name -- any value set
+	// here overrides the RuntimeClassName setting for all nodes of the given kind.
+	// +optional
+	KindRuntimeClassNames map[string]string `json:"kindRuntimeClassNames,omitempty"`
+	// TerminationMessagePolicy sets the termination message policy of the launcher (and, in native
+	// mode, node) containers. Launchers write their final fatal error to the termination message
+	// path so it shows up in `kubectl describe` and the topology status; the default of
+	// "FallbackToLogsOnError" additionally uses the tail of the container log as the termination
+	// message for containers that error out without writing one.
+	// +kubebuilder:validation:Enum=File;FallbackToLogsOnError
+	// +optional
+	TerminationMessagePolicy string `json:"terminationMessagePolicy,omitempty"`
+	// HostRequirements holds sysctl and kernel module requirements the launchers verify on the
+	// worker node before starting the node. Launchers always check the built-in requirements of
+	// well known kinds (for example the inotify limits SR Linux needs), this lets you add to (or
+	// disable) those.
+	// +optional
+	HostRequirements *HostRequirements `json:"hostRequirements,omitempty"`
+	// PacketCaptureClaimName is the name of an existing PersistentVolumeClaim to mount on all
+	// launchers of this Topology to store packet captures in -- if unset captures are written to
+	// the launcher container filesystem and are lost when the launcher pod goes away. Since all
+	// launchers mount the claim it should be ReadWriteMany unless all launchers run on one node.
+	// +optional
+	PacketCaptureClaimName string `json:"packetCaptureClaimName,omitempty"`
+	// DifferentialConfigPush enables pushing changes of the startup-config of running nodes rather
+	// than leaving them for the next restart of the node. When enabled the launchers of nodes of
+	// kinds that can merge configuration (ceos, srl and frr) watch their startup-config and, when
+	// it changes, push only the changed lines to the node via its cli. This only applies to
+	// startup-configs mounted from a ConfigMap (with a ConfigMapPath) via FilesFromConfigMap, and
+	// is not supported in native mode.
+	// +optional
+	DifferentialConfigPush bool `json:"differentialConfigPush,omitempty"`
+	// StartupConfigReapply makes changes of the (inline) startup-config of running nodes get pushed
+	// to the node by its launcher rather than restarting the node. When enabled a node whose
+	// (sub-topology) config changes in nothing but the content of its inline startup-config is not
+	// restarted, its launcher pushes the changed lines to the node via ssh (using the ssh or cli
+	// probe credentials, falling back to the node cli if there are none) instead. Nodes whose
+	// config did not change at all are not restarted either. Only kinds that can merge
+	// configuration (ceos, srl and frr) are supported, and this is not supported in native mode.
+	// +optional
+	StartupConfigReapply bool `json:"startupConfigReapply,omitempty"`
+	// DiskPressure holds the thresholds (used percent of the docker data volume of the launchers)
+	// at which the launchers warn about, clean up, and finally evict their node on disk pressure.
+	// Nested images and qcow2 overlays otherwise silently fill up the launcher volume and crash
+	// nodes. Launchers always monitor their volume, with the default thresholds when this is
+	// unset. Only relevant in docker mode.
+	// +optional
+	DiskPressure *DiskPressure `json:"diskPressure,omitempty"`
+	// ReadOnlyRootFilesystem is a hardening option that runs the nos containers of native mode
+	// nodes with a read only root filesystem -- only for kinds known to tolerate it (linux, frr and
+	// crpd), with empty dir volumes mounted on the paths those kinds need to write to. Nodes of other
+	// kinds, nodes in docker mode, and the launcher containers (which run docker and keep their state
+	// next to the launcher binary) keep their writable root filesystem.
+	// +optional
+	ReadOnlyRootFilesystem bool `json:"readOnlyRootFilesystem,omitempty"`
+	// NodeSecurityContexts is a mapping of nodeName (or "default") to the user, group and fsGroup
+	// the nos container of the node runs as -- some nos images (crpd, linux tools and the like) run
+	// just fine as non-root as long as their volumes are owned by the right group. Fields left
+	// unset fall back to the built-in defaults of the kind of the node, if it has any. This only
+	// applies to native mode nodes whose nos container does not need to run privileged; nodes that
+	// do keep running as root and are reported in the "NodeSecurityContextsInvalid" condition.
+	// +optional
+	NodeSecurityContexts map[string]NodeSecurityContext `json:"nodeSecurityContexts,omitempty"`
+	// SecurityContexts is a mapping of nodeName (or "default") to overrides of the pod and
+	// container security contexts of the launcher pod(s), for example to satisfy pod security
+	// admission or policy engines requiring a given fsGroup, supplemental groups or selinux
+	// labels. A node specific entry replaces the "default" entry. The overrides are applied on top
+	// of everything clabernetes renders (including the node security contexts), the container
+	// overrides apply to all containers clabernetes renders but not to extra init containers.
+	// Note that the launcher (and nos) containers generally need to run as root, clabernetes does
+	// not stop you from overriding that.
+	// +optional
+	SecurityContexts map[string]SecurityContextOverrides `json:"securityContexts,omitempty"`
+}
+
+// SecurityContextOverrides holds the overrides of the pod and container security contexts of a
+// launcher pod.
+type SecurityContextOverrides struct {
+	// Pod holds the overrides of the pod security context.
+	// +optional
+	Pod *PodSecurityContextOverrides `json:"pod,omitempty"`
+	// Container holds the overrides of the security context of each container.
+	// +optional
+	Container *ContainerSecurityContextOverrides `json:"container,omitempty"`
+}
+
+// PodSecurityContextOverrides holds the overrides of the pod security context of a launcher pod.
+type PodSecurityContextOverrides struct {
+	// RunAsUser is the uid to run the containers of the pod as.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+	// RunAsGroup is the gid to run the containers of the pod as.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+	// FSGroup is the gid that owns the volumes of the pod.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+	// SupplementalGroups is a list of gids added to the processes of each container of the pod.
+	// +listType=atomic
+	// +optional
+	SupplementalGroups []int64 `json:"supplementalGroups,omitempty"`
+	// SELinuxOptions are the selinux labels of the containers of the pod.
+	// +optional
+	SELinuxOptions *k8scorev1.SELinuxOptions `json:"seLinuxOptions,omitempty"`
+}
+
+// ContainerSecurityContextOverrides holds the overrides of the security context of the containers
+// of a launcher pod.
+type ContainerSecurityContextOverrides struct {
+	// RunAsUser is the uid to run the container as.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+	// RunAsGroup is the gid to run the container as.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+	// SELinuxOptions are the selinux labels of the container.
+	// +optional
+	SELinuxOptions *k8scorev1.SELinuxOptions `json:"seLinuxOptions,omitempty"`
+}
+
+// NodeSecurityContext holds the user, group and fsGroup the nos container of a node runs as.
+type NodeSecurityContext struct {
+	// RunAsUser is the uid the nos container runs as.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+	// RunAsGroup is the gid the nos container runs as.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+	// FSGroup is the gid that owns the volumes of the launcher pod of the node, so the nos can
+	// write to them when it does not run as root.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+}
+
+// DiskPressure holds the disk pressure thresholds of the launchers, each is the used percent of
+// the docker data volume of a launcher.
+type DiskPressure struct {
+	// WarnPercent is the usage at which the launcher reports its node as under disk pressure via
+	// the "NodesDiskPressure" condition of the topology, defaults to 80.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	WarnPercent int `json:"warnPercent,omitempty"`
+	// CleanupPercent is the usage at which the launcher removes unused (old) images and build
+	// cache from its docker daemon, defaults to 90.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CleanupPercent int `json:"cleanupPercent,omitempty"`
+	// EvictPercent is the usage at which, if cleaning up did not help, the launcher gives up with
+	// a "DiskPressure" termination reason -- the controller then deletes the launcher pod so the
+	// node is deployed again with a fresh volume. Defaults to 95.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	EvictPercent int `json:"evictPercent,omitempty"`
+}
+
+// HostRequirements holds the worker node (host OS) requirements a launcher verifies before
+// starting its node.
+type HostRequirements struct {
+	// Sysctls is a mapping of sysctl name (for example "fs.inotify.max_user_instances") to the
+	// minimum value the node requires. Values set here override the built-in requirement for the
+	// same sysctl.
+	// +optional
+	Sysctls map[string]int64 `json:"sysctls,omitempty"`
+	// KernelModules is a list of kernel modules that must be loaded on the worker node.
+	// +optional
+	// +listType=set
+	KernelModules []string `json:"kernelModules,omitempty"`
+	// DisableDefaults disables the built-in per kind requirements, so only the requirements set
+	// here are checked.
+	// +optional
+	DisableDefaults bool `json:"disableDefaults,omitempty"`
+	// Mode sets what a launcher does when a requirement is not met -- "enforce" (the default)
+	// fails the launcher with a "HostPreflightFailed" termination reason that is reported in the
+	// topology status, "warn" only logs the failed requirements.
+	// +kubebuilder:validation:Enum=enforce;warn
+	// +optional
+	Mode string `json:"mode,omitempty"`
 }
 
 // Scheduling holds information about how the launcher pod(s) should be configured with respect
@@ -239,6 +727,92 @@ type Scheduling struct {
 	// +listType=atomic
 	// +optional
 	Tolerations []k8scorev1.Toleration `json:"tolerations"`
+	// NodePins is a mapping of containerlab node name -> kubernetes (worker) node name that pins the
+	// launcher pod of the given node to the given worker, for example for nodes that must run on
+	// the worker that physical test equipment is attached to. The controller reports pins to
+	// workers that do not exist, are not schedulable, or do not have the allocatable resources for
+	// the launcher via the "NodePinsInvalid" condition.
+	// +optional
+	NodePins map[string]string `json:"nodePins,omitempty"`
+	// NodeOverrides is a mapping of nodeName to scheduling overrides for the launcher pod of the
+	// given node -- node selector labels set here take precedence over those of the KindOverrides
+	// and the NodeSelector settings, tolerations set here are added to the tolerations of both.
+	// +optional
+	NodeOverrides map[string]SchedulingOverrides `json:"nodeOverrides,omitempty"`
+	// KindOverrides is a mapping of containerlab kind to scheduling overrides for the launcher pods
+	// of all nodes of the given kind, for example to only schedule vrnetlab kinds on kvm capable
+	// workers -- node selector labels set here take precedence over those of the NodeSelector
+	// setting, tolerations set here are added to the Tolerations.
+	// +optional
+	KindOverrides map[string]SchedulingOverrides `json:"kindOverrides,omitempty"`
+}
+
+// SchedulingOverrides holds the node (or containerlab kind) specific scheduling settings that are
+// merged with the topology wide Scheduling settings.
+type SchedulingOverrides struct {
+	// NodeSelector holds node selector labels that are merged into the node selector of the
+	// launcher pod(s).
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations is a list of Tolerations that are added to the tolerations of the launcher
+	// pod(s).
+	// +listType=atomic
+	// +optional
+	Tolerations []k8scorev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// ClockSync holds the clock synchronization settings of a Topology.
+type ClockSync struct {
+	// NTPServer runs a topology wide ntp server (chrony) that is reachable via the "<topology>-ntp"
+	// service. The launchers point nodes whose kind they know how to configure at the ntp server
+	// by default.
+	// +optional
+	NTPServer bool `json:"ntpServer,omitempty"`
+	// Upstreams is the list of upstream ntp servers the topology ntp server syncs against. When
+	// unset the ntp server serves the clock of the kubernetes node it runs on, which keeps all
+	// nodes of the topology in sync with each other even in air-gapped clusters.
+	// +listType=atomic
+	// +optional
+	Upstreams []string `json:"upstreams,omitempty"`
+	// Image is the (chrony based) image to run the ntp server with, defaults to
+	// "docker.io/cturra/ntp:latest". The image must accept the upstream servers via the
+	// "NTP_SERVERS" env var.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// HostClock passes the host clock through to qemu based (vrnetlab) nodes so that the guest
+	// clock follows the clock of the launcher rather than drifting.
+	// +optional
+	HostClock bool `json:"hostClock,omitempty"`
+}
+
+// Schedule holds the set of time windows during which a Topology should be active.
+type Schedule struct {
+	// TimeZone is the IANA time zone name (for example "America/Los_Angeles") that the schedule
+	// windows are evaluated in. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Windows is the list of windows during which the Topology is active. If the current time does
+	// not fall in any of the windows the Topology is suspended.
+	// +listType=atomic
+	Windows []ScheduleWindow `json:"windows"`
+}
+
+// ScheduleWindow is a single recurring window of time during which a Topology is active.
+type ScheduleWindow struct {
+	// Days is the list of days of the week this window applies to. If unset the window applies to
+	// every day of the week. Note that the day is the day the window *starts* on -- windows that
+	// cross midnight end on the following day.
+	// +kubebuilder:validation:items:Enum=mon;tue;wed;thu;fri;sat;sun
+	// +listType=atomic
+	// +optional
+	Days []string `json:"days,omitempty"`
+	// Start is the start time of the window in 24 hour "HH:MM" format.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// End is the end time of the window in 24 hour "HH:MM" format. If End is earlier than (or the
+	// same as) Start the window ends on the following day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
 }
 
 // StatusProbes holds details about if the status probes are enabled and if so how they should be
@@ -287,6 +861,9 @@ type ProbeConfiguration struct {
 	// TCPProbeConfiguration defines a TCP probe.
 	// +optional
 	TCPProbeConfiguration *TCPProbeConfiguration `json:"tcpProbeConfiguration,omitempty"`
+	// CLIProbeConfiguration defines a CLI (command) probe.
+	// +optional
+	CLIProbeConfiguration *CLIProbeConfiguration `json:"cliProbeConfiguration,omitempty"`
 }
 
 // SSHProbeConfiguration defines a "ssh" probe -- the ssh probe just connects using standard go
@@ -314,6 +891,34 @@ type TCPProbeConfiguration struct {
 	Port int `json:"port"`
 }
 
+// CLIProbeConfiguration defines a "cli" probe -- the cli probe connects to the node via ssh, runs
+// a command (something like "show version") and reports true if the command succeeds (and, if
+// set, its output contains the expected output). Clabernetes ships with a default command and
+// (containerlab default) credentials for the common kinds, so for those kinds an empty cli probe
+// configuration is all you need. The probe is executed by the launcher and the result is placed
+// into /clabernetes/.nodestatus so the k8s probe can pick it up and reflect the status.
+type CLIProbeConfiguration struct {
+	// Command is the command to run on the node, if unset the default command for the kind of the
+	// node is used.
+	// +optional
+	Command string `json:"command,omitempty"`
+	// ExpectedOutput is a string the output of the command must contain for the probe to succeed,
+	// if unset (and not defaulted for the kind of the node) the command just needs to succeed.
+	// +optional
+	ExpectedOutput string `json:"expectedOutput,omitempty"`
+	// Username is the username to use for auth, if unset the default username for the kind of the
+	// node is used.
+	// +optional
+	Username string `json:"username,omitempty"`
+	// Password is the password to use for auth, if unset the default password for the kind of the
+	// node is used.
+	// +optional
+	Password string `json:"password,omitempty"`
+	// Port is an optional override (of course default is 22).
+	// +optional
+	Port int `json:"port,omitempty"`
+}
+
 // ImagePull holds configurations relevant to how clabernetes launcher pods handle pulling
 // images.
 type ImagePull struct {
@@ -27,6 +27,43 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CLIProbeConfiguration) DeepCopyInto(out *CLIProbeConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CLIProbeConfiguration.
+func (in *CLIProbeConfiguration) DeepCopy() *CLIProbeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CLIProbeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClockSync) DeepCopyInto(out *ClockSync) {
+	*out = *in
+	if in.Upstreams != nil {
+		in, out := &in.Upstreams, &out.Upstreams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClockSync.
+func (in *ClockSync) DeepCopy() *ClockSync {
+	if in == nil {
+		return nil
+	}
+	out := new(ClockSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
@@ -112,6 +149,11 @@ func (in *ConfigDeployment) DeepCopyInto(out *ConfigDeployment) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.KVMKinds != nil {
+		in, out := &in.KVMKinds, &out.KVMKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -141,6 +183,22 @@ func (in *ConfigImagePull) DeepCopy() *ConfigImagePull {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigImageScanning) DeepCopyInto(out *ConfigImageScanning) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigImageScanning.
+func (in *ConfigImageScanning) DeepCopy() *ConfigImageScanning {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigImageScanning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigList) DeepCopyInto(out *ConfigList) {
 	*out = *in
@@ -204,12 +262,174 @@ func (in *ConfigMetadata) DeepCopy() *ConfigMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMetrics) DeepCopyInto(out *ConfigMetrics) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMetrics.
+func (in *ConfigMetrics) DeepCopy() *ConfigMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMgmtDNS) DeepCopyInto(out *ConfigMgmtDNS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMgmtDNS.
+func (in *ConfigMgmtDNS) DeepCopy() *ConfigMgmtDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMgmtDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigNativeKind) DeepCopyInto(out *ConfigNativeKind) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]ConfigNativeKindMount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]v1.Capability, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigNativeKind.
+func (in *ConfigNativeKind) DeepCopy() *ConfigNativeKind {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigNativeKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigNativeKindMount) DeepCopyInto(out *ConfigNativeKindMount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigNativeKindMount.
+func (in *ConfigNativeKindMount) DeepCopy() *ConfigNativeKindMount {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigNativeKindMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigNetworkPolicy) DeepCopyInto(out *ConfigNetworkPolicy) {
+	*out = *in
+	if in.ExposedPortsCIDRs != nil {
+		in, out := &in.ExposedPortsCIDRs, &out.ExposedPortsCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CiliumExposedPortsEntities != nil {
+		in, out := &in.CiliumExposedPortsEntities, &out.CiliumExposedPortsEntities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigNetworkPolicy.
+func (in *ConfigNetworkPolicy) DeepCopy() *ConfigNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigSpec) DeepCopyInto(out *ConfigSpec) {
 	*out = *in
 	in.Metadata.DeepCopyInto(&out.Metadata)
 	out.ImagePull = in.ImagePull
 	in.Deployment.DeepCopyInto(&out.Deployment)
+	if in.KindAliases != nil {
+		in, out := &in.KindAliases, &out.KindAliases
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KindDefaultImages != nil {
+		in, out := &in.KindDefaultImages, &out.KindDefaultImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.ConnectivityPorts = in.ConnectivityPorts
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
+	out.MgmtDNS = in.MgmtDNS
+	in.Metrics.DeepCopyInto(&out.Metrics)
+	out.ImageScanning = in.ImageScanning
+	if in.NativeKinds != nil {
+		in, out := &in.NativeKinds, &out.NativeKinds
+		*out = make(map[string]ConfigNativeKind, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -245,7 +465,7 @@ func (in *Connectivity) DeepCopyInto(out *Connectivity) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -300,6 +520,38 @@ func (in *ConnectivityList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityPorts) DeepCopyInto(out *ConnectivityPorts) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityPorts.
+func (in *ConnectivityPorts) DeepCopy() *ConnectivityPorts {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityPorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivitySelfTest) DeepCopyInto(out *ConnectivitySelfTest) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivitySelfTest.
+func (in *ConnectivitySelfTest) DeepCopy() *ConnectivitySelfTest {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivitySelfTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectivitySpec) DeepCopyInto(out *ConnectivitySpec) {
 	*out = *in
@@ -317,7 +569,7 @@ func (in *ConnectivitySpec) DeepCopyInto(out *ConnectivitySpec) {
 					if (*in)[i] != nil {
 						in, out := &(*in)[i], &(*out)[i]
 						*out = new(PointToPointTunnel)
-						**out = **in
+						(*in).DeepCopyInto(*out)
 					}
 				}
 			}
@@ -340,6 +592,23 @@ func (in *ConnectivitySpec) DeepCopy() *ConnectivitySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectivityStatus) DeepCopyInto(out *ConnectivityStatus) {
 	*out = *in
+	if in.TunnelStatuses != nil {
+		in, out := &in.TunnelStatuses, &out.TunnelStatuses
+		*out = make(map[string][]TunnelStatus, len(*in))
+		for key, val := range *in {
+			var outVal []TunnelStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]TunnelStatus, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 	return
 }
 
@@ -353,9 +622,61 @@ func (in *ConnectivityStatus) DeepCopy() *ConnectivityStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityTrafficCounters) DeepCopyInto(out *ConnectivityTrafficCounters) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityTrafficCounters.
+func (in *ConnectivityTrafficCounters) DeepCopy() *ConnectivityTrafficCounters {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityTrafficCounters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerSecurityContextOverrides) DeepCopyInto(out *ContainerSecurityContextOverrides) {
+	*out = *in
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SELinuxOptions != nil {
+		in, out := &in.SELinuxOptions, &out.SELinuxOptions
+		*out = new(v1.SELinuxOptions)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerSecurityContextOverrides.
+func (in *ContainerSecurityContextOverrides) DeepCopy() *ContainerSecurityContextOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerSecurityContextOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Definition) DeepCopyInto(out *Definition) {
 	*out = *in
+	if in.InstanceOffset != nil {
+		in, out := &in.InstanceOffset, &out.InstanceOffset
+		*out = new(InstanceOffset)
+		**out = **in
+	}
 	return
 }
 
@@ -400,6 +721,21 @@ func (in *Deployment) DeepCopyInto(out *Deployment) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.FilesFromSecret != nil {
+		in, out := &in.FilesFromSecret, &out.FilesFromSecret
+		*out = make(map[string][]FileFromSecret, len(*in))
+		for key, val := range *in {
+			var outVal []FileFromSecret
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]FileFromSecret, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.FilesFromURL != nil {
 		in, out := &in.FilesFromURL, &out.FilesFromURL
 		*out = make(map[string][]FileFromURL, len(*in))
@@ -428,20 +764,150 @@ func (in *Deployment) DeepCopyInto(out *Deployment) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Deployment.
-func (in *Deployment) DeepCopy() *Deployment {
-	if in == nil {
-		return nil
+	if in.ExtraInitContainers != nil {
+		in, out := &in.ExtraInitContainers, &out.ExtraInitContainers
+		*out = make(map[string][]v1.Container, len(*in))
+		for key, val := range *in {
+			var outVal []v1.Container
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]v1.Container, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
 	}
-	out := new(Deployment)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+	if in.ExtraLabels != nil {
+		in, out := &in.ExtraLabels, &out.ExtraLabels
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ExtraAnnotations != nil {
+		in, out := &in.ExtraAnnotations, &out.ExtraAnnotations
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make(map[string][]v1.HostAlias, len(*in))
+		for key, val := range *in {
+			var outVal []v1.HostAlias
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]v1.HostAlias, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.NodePriorityClassNames != nil {
+		in, out := &in.NodePriorityClassNames, &out.NodePriorityClassNames
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeRuntimeClassNames != nil {
+		in, out := &in.NodeRuntimeClassNames, &out.NodeRuntimeClassNames
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KindRuntimeClassNames != nil {
+		in, out := &in.KindRuntimeClassNames, &out.KindRuntimeClassNames
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HostRequirements != nil {
+		in, out := &in.HostRequirements, &out.HostRequirements
+		*out = new(HostRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskPressure != nil {
+		in, out := &in.DiskPressure, &out.DiskPressure
+		*out = new(DiskPressure)
+		**out = **in
+	}
+	if in.NodeSecurityContexts != nil {
+		in, out := &in.NodeSecurityContexts, &out.NodeSecurityContexts
+		*out = make(map[string]NodeSecurityContext, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.SecurityContexts != nil {
+		in, out := &in.SecurityContexts, &out.SecurityContexts
+		*out = make(map[string]SecurityContextOverrides, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Deployment.
+func (in *Deployment) DeepCopy() *Deployment {
+	if in == nil {
+		return nil
+	}
+	out := new(Deployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskPressure) DeepCopyInto(out *DiskPressure) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskPressure.
+func (in *DiskPressure) DeepCopy() *DiskPressure {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskPressure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Expose) DeepCopyInto(out *Expose) {
 	*out = *in
 	return
@@ -499,6 +965,22 @@ func (in *FileFromConfigMap) DeepCopy() *FileFromConfigMap {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileFromSecret) DeepCopyInto(out *FileFromSecret) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileFromSecret.
+func (in *FileFromSecret) DeepCopy() *FileFromSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(FileFromSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileFromURL) DeepCopyInto(out *FileFromURL) {
 	*out = *in
@@ -515,6 +997,58 @@ func (in *FileFromURL) DeepCopy() *FileFromURL {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRequirements) DeepCopyInto(out *HostRequirements) {
+	*out = *in
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KernelModules != nil {
+		in, out := &in.KernelModules, &out.KernelModules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRequirements.
+func (in *HostRequirements) DeepCopy() *HostRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageScan) DeepCopyInto(out *ImageScan) {
+	*out = *in
+	if in.Vulnerabilities != nil {
+		in, out := &in.Vulnerabilities, &out.Vulnerabilities
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.ScannedAt.DeepCopyInto(&out.ScannedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageScan.
+func (in *ImageScan) DeepCopy() *ImageScan {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageScan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePull) DeepCopyInto(out *ImagePull) {
 	*out = *in
@@ -659,6 +1193,22 @@ func (in InsecureRegistries) DeepCopy() InsecureRegistries {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceOffset) DeepCopyInto(out *InstanceOffset) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceOffset.
+func (in *InstanceOffset) DeepCopy() *InstanceOffset {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceOffset)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinkEndpoint) DeepCopyInto(out *LinkEndpoint) {
 	*out = *in
@@ -676,152 +1226,413 @@ func (in *LinkEndpoint) DeepCopy() *LinkEndpoint {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Persistence) DeepCopyInto(out *Persistence) {
+func (in *LinkImpairment) DeepCopyInto(out *LinkImpairment) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Persistence.
-func (in *Persistence) DeepCopy() *Persistence {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkImpairment.
+func (in *LinkImpairment) DeepCopy() *LinkImpairment {
 	if in == nil {
 		return nil
 	}
-	out := new(Persistence)
+	out := new(LinkImpairment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PointToPointTunnel) DeepCopyInto(out *PointToPointTunnel) {
+func (in *Network) DeepCopyInto(out *Network) {
 	*out = *in
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make(map[string][]v1.HostAlias, len(*in))
+		for key, val := range *in {
+			var outVal []v1.HostAlias
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]v1.HostAlias, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.StaticRoutes != nil {
+		in, out := &in.StaticRoutes, &out.StaticRoutes
+		*out = make(map[string][]StaticRoute, len(*in))
+		for key, val := range *in {
+			var outVal []StaticRoute
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]StaticRoute, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ExternalRoutes != nil {
+		in, out := &in.ExternalRoutes, &out.ExternalRoutes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Underlay != nil {
+		in, out := &in.Underlay, &out.Underlay
+		*out = new(Underlay)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PointToPointTunnel.
-func (in *PointToPointTunnel) DeepCopy() *PointToPointTunnel {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
+func (in *Network) DeepCopy() *Network {
 	if in == nil {
 		return nil
 	}
-	out := new(PointToPointTunnel)
+	out := new(Network)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProbeConfiguration) DeepCopyInto(out *ProbeConfiguration) {
+func (in *NodeSecurityContext) DeepCopyInto(out *NodeSecurityContext) {
 	*out = *in
-	if in.SSHProbeConfiguration != nil {
-		in, out := &in.SSHProbeConfiguration, &out.SSHProbeConfiguration
-		*out = new(SSHProbeConfiguration)
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
 		**out = **in
 	}
-	if in.TCPProbeConfiguration != nil {
-		in, out := &in.TCPProbeConfiguration, &out.TCPProbeConfiguration
-		*out = new(TCPProbeConfiguration)
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeConfiguration.
-func (in *ProbeConfiguration) DeepCopy() *ProbeConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSecurityContext.
+func (in *NodeSecurityContext) DeepCopy() *NodeSecurityContext {
 	if in == nil {
 		return nil
 	}
-	out := new(ProbeConfiguration)
+	out := new(NodeSecurityContext)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReconcileHashes) DeepCopyInto(out *ReconcileHashes) {
+func (in *NodeTermination) DeepCopyInto(out *NodeTermination) {
 	*out = *in
-	if in.FilesFromURL != nil {
-		in, out := &in.FilesFromURL, &out.FilesFromURL
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileHashes.
-func (in *ReconcileHashes) DeepCopy() *ReconcileHashes {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTermination.
+func (in *NodeTermination) DeepCopy() *NodeTermination {
 	if in == nil {
 		return nil
 	}
-	out := new(ReconcileHashes)
+	out := new(NodeTermination)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in ResourceMap) DeepCopyInto(out *ResourceMap) {
-	{
-		in := &in
-		*out = make(ResourceMap, len(*in))
-		for key, val := range *in {
-			var outVal map[string]*v1.ResourceRequirements
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make(map[string]*v1.ResourceRequirements, len(*in))
-				for key, val := range *in {
-					var outVal *v1.ResourceRequirements
-					if val == nil {
-						(*out)[key] = nil
-					} else {
-						in, out := &val, &outVal
-						*out = new(v1.ResourceRequirements)
-						(*in).DeepCopyInto(*out)
-					}
-					(*out)[key] = outVal
-				}
-			}
-			(*out)[key] = outVal
-		}
-		return
-	}
+func (in *NodeVersions) DeepCopyInto(out *NodeVersions) {
+	*out = *in
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMap.
-func (in ResourceMap) DeepCopy() ResourceMap {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeVersions.
+func (in *NodeVersions) DeepCopy() *NodeVersions {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceMap)
+	out := new(NodeVersions)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SSHProbeConfiguration) DeepCopyInto(out *SSHProbeConfiguration) {
+func (in *PacketCapture) DeepCopyInto(out *PacketCapture) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHProbeConfiguration.
-func (in *SSHProbeConfiguration) DeepCopy() *SSHProbeConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCapture.
+func (in *PacketCapture) DeepCopy() *PacketCapture {
 	if in == nil {
 		return nil
 	}
-	out := new(SSHProbeConfiguration)
+	out := new(PacketCapture)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Scheduling) DeepCopyInto(out *Scheduling) {
+func (in *Persistence) DeepCopyInto(out *Persistence) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Persistence.
+func (in *Persistence) DeepCopy() *Persistence {
+	if in == nil {
+		return nil
+	}
+	out := new(Persistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityContextOverrides) DeepCopyInto(out *PodSecurityContextOverrides) {
+	*out = *in
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SupplementalGroups != nil {
+		in, out := &in.SupplementalGroups, &out.SupplementalGroups
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	if in.SELinuxOptions != nil {
+		in, out := &in.SELinuxOptions, &out.SELinuxOptions
+		*out = new(v1.SELinuxOptions)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityContextOverrides.
+func (in *PodSecurityContextOverrides) DeepCopy() *PodSecurityContextOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityContextOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PointToPointTunnel) DeepCopyInto(out *PointToPointTunnel) {
+	*out = *in
+	if in.Impairment != nil {
+		in, out := &in.Impairment, &out.Impairment
+		*out = new(LinkImpairment)
+		**out = **in
+	}
+	if in.Capture != nil {
+		in, out := &in.Capture, &out.Capture
+		*out = new(PacketCapture)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PointToPointTunnel.
+func (in *PointToPointTunnel) DeepCopy() *PointToPointTunnel {
+	if in == nil {
+		return nil
+	}
+	out := new(PointToPointTunnel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeConfiguration) DeepCopyInto(out *ProbeConfiguration) {
+	*out = *in
+	if in.SSHProbeConfiguration != nil {
+		in, out := &in.SSHProbeConfiguration, &out.SSHProbeConfiguration
+		*out = new(SSHProbeConfiguration)
+		**out = **in
+	}
+	if in.TCPProbeConfiguration != nil {
+		in, out := &in.TCPProbeConfiguration, &out.TCPProbeConfiguration
+		*out = new(TCPProbeConfiguration)
+		**out = **in
+	}
+	if in.CLIProbeConfiguration != nil {
+		in, out := &in.CLIProbeConfiguration, &out.CLIProbeConfiguration
+		*out = new(CLIProbeConfiguration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeConfiguration.
+func (in *ProbeConfiguration) DeepCopy() *ProbeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileHashes) DeepCopyInto(out *ReconcileHashes) {
+	*out = *in
+	if in.FilesFromURL != nil {
+		in, out := &in.FilesFromURL, &out.FilesFromURL
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileHashes.
+func (in *ReconcileHashes) DeepCopy() *ReconcileHashes {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileHashes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ResourceMap) DeepCopyInto(out *ResourceMap) {
+	{
+		in := &in
+		*out = make(ResourceMap, len(*in))
+		for key, val := range *in {
+			var outVal map[string]*v1.ResourceRequirements
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]*v1.ResourceRequirements, len(*in))
+				for key, val := range *in {
+					var outVal *v1.ResourceRequirements
+					if val == nil {
+						(*out)[key] = nil
+					} else {
+						in, out := &val, &outVal
+						*out = new(v1.ResourceRequirements)
+						(*in).DeepCopyInto(*out)
+					}
+					(*out)[key] = outVal
+				}
+			}
+			(*out)[key] = outVal
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMap.
+func (in ResourceMap) DeepCopy() ResourceMap {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMap)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHProbeConfiguration) DeepCopyInto(out *SSHProbeConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHProbeConfiguration.
+func (in *SSHProbeConfiguration) DeepCopy() *SSHProbeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHProbeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Schedule) DeepCopyInto(out *Schedule) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]ScheduleWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Schedule.
+func (in *Schedule) DeepCopy() *Schedule {
+	if in == nil {
+		return nil
+	}
+	out := new(Schedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleWindow) DeepCopyInto(out *ScheduleWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWindow.
+func (in *ScheduleWindow) DeepCopy() *ScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scheduling) DeepCopyInto(out *Scheduling) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]v1.Toleration, len(*in))
@@ -829,6 +1640,27 @@ func (in *Scheduling) DeepCopyInto(out *Scheduling) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodePins != nil {
+		in, out := &in.NodePins, &out.NodePins
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeOverrides != nil {
+		in, out := &in.NodeOverrides, &out.NodeOverrides
+		*out = make(map[string]SchedulingOverrides, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.KindOverrides != nil {
+		in, out := &in.KindOverrides, &out.KindOverrides
+		*out = make(map[string]SchedulingOverrides, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -842,6 +1674,94 @@ func (in *Scheduling) DeepCopy() *Scheduling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingOverrides) DeepCopyInto(out *SchedulingOverrides) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingOverrides.
+func (in *SchedulingOverrides) DeepCopy() *SchedulingOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityContextOverrides) DeepCopyInto(out *SecurityContextOverrides) {
+	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(PodSecurityContextOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(ContainerSecurityContextOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityContextOverrides.
+func (in *SecurityContextOverrides) DeepCopy() *SecurityContextOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityContextOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurpeethTLS) DeepCopyInto(out *SlurpeethTLS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurpeethTLS.
+func (in *SlurpeethTLS) DeepCopy() *SlurpeethTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurpeethTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticRoute) DeepCopyInto(out *StaticRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticRoute.
+func (in *StaticRoute) DeepCopy() *StaticRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusProbes) DeepCopyInto(out *StatusProbes) {
 	*out = *in
@@ -887,6 +1807,23 @@ func (in *TCPProbeConfiguration) DeepCopy() *TCPProbeConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimelineEvent) DeepCopyInto(out *TimelineEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimelineEvent.
+func (in *TimelineEvent) DeepCopy() *TimelineEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(TimelineEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Topology) DeepCopyInto(out *Topology) {
 	*out = *in
@@ -915,6 +1852,49 @@ func (in *Topology) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyLinkConnectivity) DeepCopyInto(out *TopologyLinkConnectivity) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyLinkConnectivity.
+func (in *TopologyLinkConnectivity) DeepCopy() *TopologyLinkConnectivity {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyLinkConnectivity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyLinkImpairment) DeepCopyInto(out *TopologyLinkImpairment) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.LinkImpairment = in.LinkImpairment
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyLinkImpairment.
+func (in *TopologyLinkImpairment) DeepCopy() *TopologyLinkImpairment {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyLinkImpairment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologyList) DeepCopyInto(out *TopologyList) {
 	*out = *in
@@ -948,14 +1928,102 @@ func (in *TopologyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyPacketCapture) DeepCopyInto(out *TopologyPacketCapture) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.PacketCapture = in.PacketCapture
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyPacketCapture.
+func (in *TopologyPacketCapture) DeepCopy() *TopologyPacketCapture {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyPacketCapture)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
 	*out = *in
-	out.Definition = in.Definition
+	in.Definition.DeepCopyInto(&out.Definition)
 	out.Expose = in.Expose
 	in.Deployment.DeepCopyInto(&out.Deployment)
 	in.StatusProbes.DeepCopyInto(&out.StatusProbes)
 	in.ImagePull.DeepCopyInto(&out.ImagePull)
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(Network)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LinkConnectivity != nil {
+		in, out := &in.LinkConnectivity, &out.LinkConnectivity
+		*out = make([]TopologyLinkConnectivity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConnectivityPorts != nil {
+		in, out := &in.ConnectivityPorts, &out.ConnectivityPorts
+		*out = new(ConnectivityPorts)
+		**out = **in
+	}
+	if in.DisabledLinks != nil {
+		in, out := &in.DisabledLinks, &out.DisabledLinks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LinkImpairments != nil {
+		in, out := &in.LinkImpairments, &out.LinkImpairments
+		*out = make([]TopologyLinkImpairment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PacketCaptures != nil {
+		in, out := &in.PacketCaptures, &out.PacketCaptures
+		*out = make([]TopologyPacketCapture, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WireTaps != nil {
+		in, out := &in.WireTaps, &out.WireTaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(Schedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelfTest != nil {
+		in, out := &in.SelfTest, &out.SelfTest
+		*out = new(ConnectivitySelfTest)
+		**out = **in
+	}
+	if in.TrafficCounters != nil {
+		in, out := &in.TrafficCounters, &out.TrafficCounters
+		*out = new(ConnectivityTrafficCounters)
+		**out = **in
+	}
+	if in.SlurpeethTLS != nil {
+		in, out := &in.SlurpeethTLS, &out.SlurpeethTLS
+		*out = new(SlurpeethTLS)
+		**out = **in
+	}
+	if in.ClockSync != nil {
+		in, out := &in.ClockSync, &out.ClockSync
+		*out = new(ClockSync)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1007,6 +2075,34 @@ func (in *TopologyStatus) DeepCopyInto(out *TopologyStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.NodeTerminations != nil {
+		in, out := &in.NodeTerminations, &out.NodeTerminations
+		*out = make(map[string]NodeTermination, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.NodeDiskUsage != nil {
+		in, out := &in.NodeDiskUsage, &out.NodeDiskUsage
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeVersions != nil {
+		in, out := &in.NodeVersions, &out.NodeVersions
+		*out = make(map[string]NodeVersions, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImageScans != nil {
+		in, out := &in.ImageScans, &out.ImageScans
+		*out = make(map[string]ImageScan, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -1014,6 +2110,17 @@ func (in *TopologyStatus) DeepCopyInto(out *TopologyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NextActivation != nil {
+		in, out := &in.NextActivation, &out.NextActivation
+		*out = (*in).DeepCopy()
+	}
+	if in.Timeline != nil {
+		in, out := &in.Timeline, &out.Timeline
+		*out = make([]TimelineEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1026,3 +2133,80 @@ func (in *TopologyStatus) DeepCopy() *TopologyStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TunnelCounters) DeepCopyInto(out *TunnelCounters) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TunnelCounters.
+func (in *TunnelCounters) DeepCopy() *TunnelCounters {
+	if in == nil {
+		return nil
+	}
+	out := new(TunnelCounters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TunnelSelfTestResult) DeepCopyInto(out *TunnelSelfTestResult) {
+	*out = *in
+	in.LastTestTime.DeepCopyInto(&out.LastTestTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TunnelSelfTestResult.
+func (in *TunnelSelfTestResult) DeepCopy() *TunnelSelfTestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(TunnelSelfTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TunnelStatus) DeepCopyInto(out *TunnelStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.SelfTest != nil {
+		in, out := &in.SelfTest, &out.SelfTest
+		*out = new(TunnelSelfTestResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Counters != nil {
+		in, out := &in.Counters, &out.Counters
+		*out = new(TunnelCounters)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TunnelStatus.
+func (in *TunnelStatus) DeepCopy() *TunnelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TunnelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Underlay) DeepCopyInto(out *Underlay) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Underlay.
+func (in *Underlay) DeepCopy() *Underlay {
+	if in == nil {
+		return nil
+	}
+	out := new(Underlay)
+	in.DeepCopyInto(out)
+	return out
+}
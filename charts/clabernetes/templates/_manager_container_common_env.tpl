@@ -15,6 +15,14 @@
   value: {{ .Values.manager.managerLogLevel }}
 - name: CONTROLLER_LOGGER_LEVEL
   value: {{ .Values.manager.controllerLogLevel }}
+- name: MANAGER_CACHE_STRATEGY
+  value: {{ .Values.manager.cache.strategy | default "filtered" }}
+- name: MANAGER_CACHE_UNFILTERED_KINDS
+  value: {{ join "," .Values.manager.cache.unfilteredKinds | quote }}
+- name: MANAGER_CACHE_NAMESPACES
+  value: {{ join "," .Values.manager.cache.namespaces | quote }}
+- name: MANAGER_NAMESPACE_SCOPED
+  value: {{ .Values.manager.namespaceScoped | default false | quote }}
 - name: LAUNCHER_IMAGE
   {{- if .Values.globalConfig.deployment.launcherImage }}
   value: {{ .Values.globalConfig.deployment.launcherImage }}
@@ -23,6 +23,9 @@
      {{- if not .Values.cleanupPods }}
      "--skipPodCleanup",
      {{- end }}
+     {{- if .Values.scanCapabilities }}
+     "--scanCapabilities",
+     {{- end }}
   ]
   env:
     - name: APP_NAME
@@ -0,0 +1,158 @@
+package cleanup
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	ctrlruntime "sigs.k8s.io/controller-runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultTimeout is the default time each cleanup stage waits for its resources to go away.
+	DefaultTimeout = 2 * time.Minute
+
+	pollInterval = 2 * time.Second
+)
+
+// Args holds arguments for the clabernetes "cleanup" process.
+type Args struct {
+	// Topology is the name of the topology to clean up.
+	Topology string
+	// Namespace is the namespace of the topology.
+	Namespace string
+	// Force clears finalizers of resources that are still around after the timeout and deletes
+	// launcher pods without a grace period.
+	Force bool
+	// Timeout is how long each cleanup stage waits for its resources to go away.
+	Timeout time.Duration
+	// ReportPath is the file the json cleanup report is written to, if unset the report is
+	// written to stdout.
+	ReportPath string
+}
+
+// StartClabernetes is a function that starts the clabernetes topology cleanup -- it deletes all
+// the resources of a topology in dependency order (launchers first, the topology itself last),
+// optionally clearing finalizers of anything that is stuck, and records what it did in a report.
+func StartClabernetes(args *Args) {
+	if clabernetesInstance != nil {
+		clabernetesutil.Panic("clabernetes instance already created...")
+	}
+
+	rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+
+	claberneteslogging.InitManager()
+
+	logManager := claberneteslogging.GetManager()
+
+	clabernetesLogger := logManager.MustRegisterAndGetLogger(
+		clabernetesconstants.Clabernetes,
+		clabernetesutil.GetEnvStrOrDefault(
+			clabernetesconstants.CleanupLoggerLevelEnv,
+			clabernetesconstants.Info,
+		),
+	)
+
+	ctx, _ := clabernetesutil.SignalHandledContext(clabernetesLogger.Criticalf)
+
+	if args.Timeout <= 0 {
+		args.Timeout = DefaultTimeout
+	}
+
+	clabernetesInstance = &clabernetes{
+		ctx:    ctx,
+		logger: clabernetesLogger,
+		args:   args,
+		report: &Report{
+			Topology:  args.Topology,
+			Namespace: args.Namespace,
+			Force:     args.Force,
+			Stages:    make([]*StageReport, 0),
+		},
+	}
+
+	err := clabernetesInstance.run()
+	if err != nil {
+		clabernetesLogger.Criticalf("topology cleanup failed, err: %s", err)
+
+		claberneteslogging.GetManager().Flush()
+
+		os.Exit(clabernetesconstants.ExitCodeError)
+	}
+
+	claberneteslogging.GetManager().Flush()
+}
+
+var clabernetesInstance *clabernetes //nolint:gochecknoglobals
+
+type clabernetes struct {
+	ctx context.Context
+
+	logger claberneteslogging.Instance
+
+	args *Args
+
+	client ctrlruntimeclient.Client
+
+	report *Report
+}
+
+func (c *clabernetes) run() error {
+	c.logger.Infof(
+		"starting cleanup of topology '%s/%s', force: %t",
+		c.args.Namespace,
+		c.args.Topology,
+		c.args.Force,
+	)
+
+	err := c.setup()
+	if err != nil {
+		return err
+	}
+
+	c.report.StartTime = time.Now().UTC()
+
+	cleanupErr := c.cleanup()
+
+	c.report.EndTime = time.Now().UTC()
+
+	if cleanupErr != nil {
+		c.report.Error = cleanupErr.Error()
+	}
+
+	err = c.writeReport()
+	if err != nil {
+		c.logger.Criticalf("failed writing cleanup report, err: %s", err)
+
+		if cleanupErr == nil {
+			return err
+		}
+	}
+
+	return cleanupErr
+}
+
+func (c *clabernetes) setup() error {
+	// works both in cluster (via the service account) and out of cluster (via the kubeconfig
+	// flag/env or the default kubeconfig) -- so users can run this from their machine as well
+	kubeConfig, err := ctrlruntime.GetConfig()
+	if err != nil {
+		c.logger.Criticalf("failed getting kubeconfig, err: %s", err)
+
+		return err
+	}
+
+	c.client, err = ctrlruntimeclient.New(kubeConfig, ctrlruntimeclient.Options{})
+	if err != nil {
+		c.logger.Criticalf("failed creating kube client, err: %s", err)
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,481 @@
+package cleanup
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	clabernetesapis "github.com/srl-labs/clabernetes/apis"
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// topologyKind is the kind of the topology custom resource.
+const topologyKind = "Topology"
+
+// stage is one step of the cleanup -- all resources of the given kind that belong to the
+// topology are deleted and waited on before moving on to the next stage.
+type stage struct {
+	name string
+	gvk  schema.GroupVersionKind
+	// optional stages are for kinds that may not be installed in the cluster at all.
+	optional bool
+}
+
+// stages are the cleanup stages in dependency order: launchers (and their pods) go first so
+// nothing holds on to the services, network attachments, configs and volumes deleted after them.
+// The topology itself is deleted last, once all its children are gone.
+var stages = []stage{ //nolint:gochecknoglobals
+	{
+		name: "deployments",
+		gvk:  schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+	},
+	{
+		name: "pods",
+		gvk:  schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+	},
+	{
+		name: "services",
+		gvk:  schema.GroupVersionKind{Version: "v1", Kind: "Service"},
+	},
+	{
+		name: "connectivities",
+		gvk:  clabernetesapisv1alpha1.SchemeGroupVersion.WithKind("Connectivity"),
+	},
+	{
+		name: "network-policies",
+		gvk: schema.GroupVersionKind{
+			Group:   "networking.k8s.io",
+			Version: "v1",
+			Kind:    "NetworkPolicy",
+		},
+	},
+	{
+		name: "cilium-network-policies",
+		gvk: schema.GroupVersionKind{
+			Group:   "cilium.io",
+			Version: "v2",
+			Kind:    "CiliumNetworkPolicy",
+		},
+		optional: true,
+	},
+	{
+		name: "network-attachment-definitions",
+		gvk: schema.GroupVersionKind{
+			Group:   "k8s.cni.cncf.io",
+			Version: "v1",
+			Kind:    "NetworkAttachmentDefinition",
+		},
+		optional: true,
+	},
+	{
+		name: "configmaps",
+		gvk:  schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+	},
+	{
+		name: "secrets",
+		gvk:  schema.GroupVersionKind{Version: "v1", Kind: "Secret"},
+	},
+	{
+		name: "persistent-volume-claims",
+		gvk:  schema.GroupVersionKind{Version: "v1", Kind: "PersistentVolumeClaim"},
+	},
+}
+
+func (c *clabernetes) cleanup() error {
+	topology, err := c.getTopology()
+	if err != nil {
+		return err
+	}
+
+	if topology == nil {
+		// half deleted topologies may be gone already while their children are not -- carry on
+		// and clean up whatever is still labeled for (or owned by) the topology
+		c.logger.Warnf(
+			"topology '%s/%s' does not exist, cleaning up orphaned resources only",
+			c.args.Namespace,
+			c.args.Topology,
+		)
+	} else {
+		err = c.pauseReconcile(topology)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, s := range stages {
+		err = c.runStage(s)
+		if err != nil {
+			return err
+		}
+	}
+
+	if topology != nil {
+		err = c.deleteTopology(topology)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(c.report.Remaining) > 0 {
+		return fmt.Errorf(
+			"%w: resources left behind: %s",
+			claberneteserrors.ErrCleanup,
+			strings.Join(c.report.Remaining, ", "),
+		)
+	}
+
+	c.logger.Infof("cleanup of topology '%s/%s' done", c.args.Namespace, c.args.Topology)
+
+	return nil
+}
+
+func (c *clabernetes) getTopology() (*unstructured.Unstructured, error) {
+	topology := &unstructured.Unstructured{}
+	topology.SetGroupVersionKind(
+		clabernetesapisv1alpha1.SchemeGroupVersion.WithKind(topologyKind),
+	)
+
+	err := c.client.Get(
+		c.ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: c.args.Namespace,
+			Name:      c.args.Topology,
+		},
+		topology,
+	)
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return topology, nil
+}
+
+// pauseReconcile sets the ignore reconcile label on the topology so the manager does not recreate
+// the resources we are deleting underneath it.
+func (c *clabernetes) pauseReconcile(topology *unstructured.Unstructured) error {
+	c.logger.Infof("setting %q label on topology", clabernetesconstants.LabelIgnoreReconcile)
+
+	patchBase := topology.DeepCopy()
+
+	labels := topology.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	labels[clabernetesconstants.LabelIgnoreReconcile] = "true"
+
+	topology.SetLabels(labels)
+
+	err := c.client.Patch(c.ctx, topology, ctrlruntimeclient.MergeFrom(patchBase))
+	if err != nil && !apimachineryerrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (c *clabernetes) runStage(s stage) error {
+	stageReport := &StageReport{
+		Name: s.name,
+	}
+
+	c.report.Stages = append(c.report.Stages, stageReport)
+
+	objs, err := c.listOwned(s.gvk)
+	if err != nil {
+		if s.optional && apimachinerymeta.IsNoMatchError(err) {
+			stageReport.Skipped = fmt.Sprintf("kind %s is not installed", s.gvk.Kind)
+
+			return nil
+		}
+
+		return err
+	}
+
+	if len(objs) == 0 {
+		return nil
+	}
+
+	c.logger.Infof("cleaning up %d %s", len(objs), s.name)
+
+	for idx := range objs {
+		obj := &objs[idx]
+
+		if hasOtherOwners(obj, c.args.Topology) &&
+			obj.GetLabels()[clabernetesconstants.LabelTopologyOwner] != c.args.Topology {
+			// shared resources (like propagated pull secrets) stay, they just lose the owner
+			// reference of this topology
+			err = c.releaseObj(obj)
+			if err != nil {
+				return err
+			}
+
+			stageReport.Released = append(stageReport.Released, obj.GetName())
+
+			continue
+		}
+
+		err = c.deleteObj(obj)
+		if err != nil {
+			return err
+		}
+
+		stageReport.Deleted = append(stageReport.Deleted, obj.GetName())
+	}
+
+	remaining, err := c.waitForDeletion(s.gvk, stageReport.Deleted)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) > 0 && c.args.Force {
+		for idx := range remaining {
+			err = c.clearFinalizers(&remaining[idx])
+			if err != nil {
+				return err
+			}
+
+			stageReport.FinalizersCleared = append(
+				stageReport.FinalizersCleared,
+				remaining[idx].GetName(),
+			)
+		}
+
+		remaining, err = c.waitForDeletion(s.gvk, stageReport.Deleted)
+		if err != nil {
+			return err
+		}
+	}
+
+	for idx := range remaining {
+		stageReport.Remaining = append(stageReport.Remaining, remaining[idx].GetName())
+		c.report.Remaining = append(
+			c.report.Remaining,
+			fmt.Sprintf("%s/%s", s.gvk.Kind, remaining[idx].GetName()),
+		)
+	}
+
+	return nil
+}
+
+func (c *clabernetes) deleteTopology(topology *unstructured.Unstructured) error {
+	stageReport := &StageReport{
+		Name: "topology",
+	}
+
+	c.report.Stages = append(c.report.Stages, stageReport)
+
+	c.logger.Info("deleting topology")
+
+	err := c.client.Delete(c.ctx, topology)
+	if err != nil && !apimachineryerrors.IsNotFound(err) {
+		return err
+	}
+
+	stageReport.Deleted = []string{topology.GetName()}
+
+	remaining, err := c.waitForTopologyDeletion()
+	if err != nil {
+		return err
+	}
+
+	if remaining != nil && c.args.Force {
+		err = c.clearFinalizers(remaining)
+		if err != nil {
+			return err
+		}
+
+		stageReport.FinalizersCleared = []string{remaining.GetName()}
+
+		remaining, err = c.waitForTopologyDeletion()
+		if err != nil {
+			return err
+		}
+	}
+
+	if remaining != nil {
+		stageReport.Remaining = []string{remaining.GetName()}
+		c.report.Remaining = append(
+			c.report.Remaining,
+			fmt.Sprintf("%s/%s", topologyKind, remaining.GetName()),
+		)
+	}
+
+	return nil
+}
+
+// listOwned lists the resources of the given kind in the namespace of the topology that belong to
+// the topology -- that carry its topology owner label or have an owner reference to it.
+func (c *clabernetes) listOwned(gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	objList := &unstructured.UnstructuredList{}
+	objList.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	err := c.client.List(c.ctx, objList, ctrlruntimeclient.InNamespace(c.args.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make([]unstructured.Unstructured, 0)
+
+	for idx := range objList.Items {
+		obj := objList.Items[idx]
+
+		if obj.GetLabels()[clabernetesconstants.LabelTopologyOwner] == c.args.Topology ||
+			slices.ContainsFunc(obj.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+				return isTopologyOwnerRef(ref, c.args.Topology)
+			}) {
+			owned = append(owned, obj)
+		}
+	}
+
+	return owned, nil
+}
+
+func (c *clabernetes) deleteObj(obj *unstructured.Unstructured) error {
+	c.logger.Debugf("deleting %s '%s/%s'", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+
+	deleteOpts := []ctrlruntimeclient.DeleteOption{
+		ctrlruntimeclient.PropagationPolicy(metav1.DeletePropagationBackground),
+	}
+
+	if c.args.Force && obj.GetKind() == "Pod" {
+		deleteOpts = append(deleteOpts, ctrlruntimeclient.GracePeriodSeconds(0))
+	}
+
+	err := c.client.Delete(c.ctx, obj, deleteOpts...)
+	if err != nil && !apimachineryerrors.IsNotFound(err) {
+		c.logger.Criticalf(
+			"failed deleting %s '%s/%s' error: %s",
+			obj.GetKind(),
+			obj.GetNamespace(),
+			obj.GetName(),
+			err,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (c *clabernetes) releaseObj(obj *unstructured.Unstructured) error {
+	c.logger.Debugf(
+		"removing topology owner reference from shared %s '%s/%s'",
+		obj.GetKind(),
+		obj.GetNamespace(),
+		obj.GetName(),
+	)
+
+	patchBase := obj.DeepCopy()
+
+	obj.SetOwnerReferences(
+		slices.DeleteFunc(obj.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+			return isTopologyOwnerRef(ref, c.args.Topology)
+		}),
+	)
+
+	err := c.client.Patch(c.ctx, obj, ctrlruntimeclient.MergeFrom(patchBase))
+	if err != nil && !apimachineryerrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (c *clabernetes) clearFinalizers(obj *unstructured.Unstructured) error {
+	c.logger.Warnf(
+		"clearing finalizers %v of stuck %s '%s/%s'",
+		obj.GetFinalizers(),
+		obj.GetKind(),
+		obj.GetNamespace(),
+		obj.GetName(),
+	)
+
+	patchBase := obj.DeepCopy()
+
+	obj.SetFinalizers(nil)
+
+	err := c.client.Patch(c.ctx, obj, ctrlruntimeclient.MergeFrom(patchBase))
+	if err != nil && !apimachineryerrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// waitForDeletion waits (up to the timeout) until none of the named resources of the given kind
+// exist anymore, returning the ones that still do.
+func (c *clabernetes) waitForDeletion(
+	gvk schema.GroupVersionKind,
+	names []string,
+) ([]unstructured.Unstructured, error) {
+	deadline := time.Now().Add(c.args.Timeout)
+
+	for {
+		objs, err := c.listOwned(gvk)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := slices.DeleteFunc(objs, func(obj unstructured.Unstructured) bool {
+			return !slices.Contains(names, obj.GetName())
+		})
+
+		if len(remaining) == 0 || time.Now().After(deadline) {
+			return remaining, nil
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return nil, c.ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (c *clabernetes) waitForTopologyDeletion() (*unstructured.Unstructured, error) {
+	deadline := time.Now().Add(c.args.Timeout)
+
+	for {
+		topology, err := c.getTopology()
+		if err != nil {
+			return nil, err
+		}
+
+		if topology == nil || time.Now().After(deadline) {
+			return topology, nil
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return nil, c.ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func isTopologyOwnerRef(ref metav1.OwnerReference, topologyName string) bool {
+	return ref.Kind == topologyKind &&
+		ref.Name == topologyName &&
+		strings.HasPrefix(ref.APIVersion, clabernetesapis.Group+"/")
+}
+
+func hasOtherOwners(obj *unstructured.Unstructured, topologyName string) bool {
+	return slices.ContainsFunc(obj.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+		return !isTopologyOwnerRef(ref, topologyName)
+	})
+}
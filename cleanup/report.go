@@ -0,0 +1,58 @@
+package cleanup
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+const reportFilePermissions = 0o644
+
+// Report is the record of a topology cleanup.
+type Report struct {
+	Topology  string         `json:"topology"`
+	Namespace string         `json:"namespace"`
+	Force     bool           `json:"force"`
+	StartTime time.Time      `json:"startTime"`
+	EndTime   time.Time      `json:"endTime"`
+	Stages    []*StageReport `json:"stages"`
+	// Remaining lists the resources (as kind/name) that were still around when the cleanup
+	// finished.
+	Remaining []string `json:"remaining,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// StageReport is the record of a single cleanup stage, that is, of one kind of resource.
+type StageReport struct {
+	Name string `json:"name"`
+	// Skipped holds the reason the stage was skipped, if it was (e.g. the kind is not installed
+	// in the cluster).
+	Skipped string `json:"skipped,omitempty"`
+	// Deleted lists the names of the resources that were deleted.
+	Deleted []string `json:"deleted,omitempty"`
+	// Released lists the names of the resources that are shared with other owners, for those
+	// only the owner reference of the topology is removed.
+	Released []string `json:"released,omitempty"`
+	// FinalizersCleared lists the names of the resources whose finalizers were cleared.
+	FinalizersCleared []string `json:"finalizersCleared,omitempty"`
+	// Remaining lists the names of the resources that were still around when the stage finished.
+	Remaining []string `json:"remaining,omitempty"`
+}
+
+func (c *clabernetes) writeReport() error {
+	report, err := json.MarshalIndent(c.report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if c.args.ReportPath == "" {
+		fmt.Println(string(report)) //nolint:forbidigo
+
+		return nil
+	}
+
+	c.logger.Infof("writing cleanup report to %q", c.args.ReportPath)
+
+	return os.WriteFile(c.args.ReportPath, report, reportFilePermissions)
+}
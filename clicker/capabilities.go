@@ -0,0 +1,94 @@
+package clicker
+
+import (
+	"strconv"
+	"strings"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+// capabilityScanScript is the worker script used when running in capability scan mode -- it
+// writes simple "key=value" lines to the termination log which we then read from the pod status
+// and turn into node labels. Its plain sh so it works with the default busybox worker image.
+const capabilityScanScript = `out=/dev/termination-log
+: > "$out"
+
+if [ -c /dev/kvm ]; then
+  echo "kvm=true" >> "$out"
+else
+  echo "kvm=false" >> "$out"
+fi
+
+nested=false
+for f in /sys/module/kvm_intel/parameters/nested /sys/module/kvm_amd/parameters/nested; do
+  if [ -r "$f" ]; then
+    case "$(cat "$f")" in
+      Y|y|1) nested=true ;;
+    esac
+  fi
+done
+echo "nestedVirt=$nested" >> "$out"
+
+hugepages=$(awk '/^HugePages_Total:/ {print $2}' /proc/meminfo)
+if [ -n "$hugepages" ] && [ "$hugepages" -gt 0 ]; then
+  echo "hugepages=true" >> "$out"
+else
+  echo "hugepages=false" >> "$out"
+fi
+
+echo "cpuFlags=$(awk -F': ' '/^flags/ {print $2; exit}' /proc/cpuinfo)" >> "$out"
+`
+
+const (
+	capabilityKVM        = "kvm"
+	capabilityNestedVirt = "nestedVirt"
+	capabilityHugepages  = "hugepages"
+	capabilityCPUFlags   = "cpuFlags"
+)
+
+// interestingCPUFlags returns the cpu flags we record as node labels -- recording all of them
+// would be a lot of noise on the nodes, so we only care about the ones that NOS images tend to
+// care about (virtualization extensions and the vector/crypto extensions some images require).
+func interestingCPUFlags() []string {
+	return []string{"vmx", "svm", "avx", "avx2", "avx512f", "aes", "sse4_2", "pdpe1gb"}
+}
+
+// capabilitiesToLabels parses the termination message of a capability scan worker pod into the
+// set of capability node labels.
+func capabilitiesToLabels(message string) map[string]string {
+	labels := map[string]string{}
+
+	for _, line := range strings.Split(message, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case capabilityKVM:
+			labels[clabernetesconstants.LabelCapabilityKVM] = boolLabelValue(value)
+		case capabilityNestedVirt:
+			labels[clabernetesconstants.LabelCapabilityNestedVirt] = boolLabelValue(value)
+		case capabilityHugepages:
+			labels[clabernetesconstants.LabelCapabilityHugepages] = boolLabelValue(value)
+		case capabilityCPUFlags:
+			presentFlags := map[string]bool{}
+
+			for _, flag := range strings.Fields(value) {
+				presentFlags[flag] = true
+			}
+
+			for _, flag := range interestingCPUFlags() {
+				labels[clabernetesconstants.LabelCapabilityCPUFlagPrefix+flag] = strconv.FormatBool(
+					presentFlags[flag],
+				)
+			}
+		}
+	}
+
+	return labels
+}
+
+func boolLabelValue(value string) string {
+	return strconv.FormatBool(strings.EqualFold(strings.TrimSpace(value), clabernetesconstants.True))
+}
@@ -2,9 +2,11 @@ package clicker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -32,6 +34,7 @@ type Args struct {
 	NodeSelector         string
 	SkipConfigMapCleanup bool
 	SkipPodsCleanup      bool
+	ScanCapabilities     bool
 }
 
 // StartClabernetes is a function that starts the clabernetes node clicker.
@@ -231,6 +234,16 @@ func (c *clabernetes) getSelfPod() (*k8scorev1.Pod, error) {
 	return pod, nil
 }
 
+// nodeDoneLabel returns the label that marks a node as already handled for the mode that we are
+// running in -- nodes that have this label are skipped unless the override nodes arg is set.
+func (c *clabernetes) nodeDoneLabel() string {
+	if c.args.ScanCapabilities {
+		return clabernetesconstants.LabelCapabilityScanned
+	}
+
+	return clabernetesconstants.LabelClickerNodeConfigured
+}
+
 func (c *clabernetes) getInvokeNodes() ([]k8scorev1.Node, error) {
 	listOptions := metav1.ListOptions{
 		LabelSelector: c.args.NodeSelector,
@@ -251,7 +264,7 @@ func (c *clabernetes) getInvokeNodes() ([]k8scorev1.Node, error) {
 	unconfiguredNodes := make([]k8scorev1.Node, 0)
 
 	for idx := range nodes {
-		_, ok := nodes[idx].Labels[clabernetesconstants.LabelClickerNodeConfigured]
+		_, ok := nodes[idx].Labels[c.nodeDoneLabel()]
 		if !ok {
 			// clicker configured label wasn't set, we know we need to run on this node
 			c.logger.Debugf(
@@ -274,13 +287,19 @@ func (c *clabernetes) getInvokeNodes() ([]k8scorev1.Node, error) {
 }
 
 func (c *clabernetes) buildConfigMap() *k8scorev1.ConfigMap {
+	script := getScript()
+
+	if c.args.ScanCapabilities {
+		script = capabilityScanScript
+	}
+
 	return &k8scorev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "clicker-",
 			Namespace:    c.namespace,
 		},
 		Data: map[string]string{
-			"script": getScript(),
+			"script": script,
 		},
 	}
 }
@@ -494,7 +513,13 @@ func (c *clabernetes) watchPods(
 
 			nodeName := strings.TrimPrefix(podName, fmt.Sprintf("%s-clicker-", c.appName))
 
-			err = c.updateNodeLabels(nodeName)
+			nodeLabels := map[string]string{}
+
+			if c.args.ScanCapabilities {
+				nodeLabels = capabilitiesToLabels(terminationMessage(eventPod))
+			}
+
+			err = c.updateNodeLabels(nodeName, nodeLabels)
 			if err != nil {
 				failChan <- podName
 			} else {
@@ -521,21 +546,52 @@ func (c *clabernetes) removePods(pods []*k8scorev1.Pod) {
 	}
 }
 
-func (c *clabernetes) updateNodeLabels(nodeName string) error {
-	patch := fmt.Sprintf(
-		`[{"op": "add", "path": "/metadata/labels/%s", "value": "%d"}]`,
-		// have to replace the slash in the label name for jsonpatch
-		strings.ReplaceAll(clabernetesconstants.LabelClickerNodeConfigured, "/", "~1"),
-		time.Now().Unix(),
-	)
+func terminationMessage(pod *k8scorev1.Pod) string {
+	for idx := range pod.Status.ContainerStatuses {
+		terminated := pod.Status.ContainerStatuses[idx].State.Terminated
+		if terminated != nil {
+			return terminated.Message
+		}
+	}
+
+	return ""
+}
+
+func (c *clabernetes) updateNodeLabels(nodeName string, extraLabels map[string]string) error {
+	nodeLabels := map[string]string{
+		c.nodeDoneLabel(): strconv.FormatInt(time.Now().Unix(), 10),
+	}
+
+	for k, v := range extraLabels {
+		nodeLabels[k] = v
+	}
+
+	patchOps := make([]map[string]string, 0, len(nodeLabels))
+
+	for k, v := range nodeLabels {
+		patchOps = append(
+			patchOps,
+			map[string]string{
+				"op": "add",
+				// have to replace the slash in the label name for jsonpatch
+				"path":  fmt.Sprintf("/metadata/labels/%s", strings.ReplaceAll(k, "/", "~1")),
+				"value": v,
+			},
+		)
+	}
+
+	patch, err := json.Marshal(patchOps)
+	if err != nil {
+		return err
+	}
 
-	_, err := c.kubeClient.CoreV1().
+	_, err = c.kubeClient.CoreV1().
 		Nodes().
 		Patch(
 			c.ctx,
 			nodeName,
 			apimachinerytypes.JSONPatchType,
-			[]byte(patch),
+			patch,
 			metav1.PatchOptions{},
 		)
 	if err != nil {
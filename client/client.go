@@ -0,0 +1,105 @@
+// Package client is a typed client for managing clabernetes topologies from go programs -- it wraps
+// the generated clabernetes clientset and a kubernetes clientset with helpers to build topologies,
+// wait for them to become ready, fetch the management endpoints of their nodes, and stream their
+// events. The generated clientset remains available (see Client.Clabernetes) for anything not
+// covered here.
+package client
+
+import (
+	"fmt"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesgeneratedclientset "github.com/srl-labs/clabernetes/generated/clientset"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	ctrlruntime "sigs.k8s.io/controller-runtime"
+)
+
+// Client manages clabernetes topologies.
+type Client struct {
+	clabernetesClient  clabernetesgeneratedclientset.Interface
+	kubeClient         kubernetes.Interface
+	inClusterDNSSuffix string
+}
+
+// Option is a functional option for a Client.
+type Option func(c *Client)
+
+// WithInClusterDNSSuffix sets the in cluster dns suffix used to build the in cluster addresses of
+// nodes, this should match the "inClusterDNSSuffix" of the clabernetes global config. Defaults to
+// "svc.cluster.local".
+func WithInClusterDNSSuffix(suffix string) Option {
+	return func(c *Client) {
+		c.inClusterDNSSuffix = suffix
+	}
+}
+
+// New returns a Client for the given clabernetes and kubernetes clientsets -- this is mostly
+// useful to pass in fake clientsets in tests, or to share clientsets that already exist.
+func New(
+	clabernetesClient clabernetesgeneratedclientset.Interface,
+	kubeClient kubernetes.Interface,
+	options ...Option,
+) *Client {
+	c := &Client{
+		clabernetesClient:  clabernetesClient,
+		kubeClient:         kubeClient,
+		inClusterDNSSuffix: clabernetesconstants.KubernetesDefaultInClusterDNSSuffix,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// NewForConfig returns a Client for the given rest config.
+func NewForConfig(kubeConfig *rest.Config, options ...Option) (*Client, error) {
+	clabernetesClient, err := clabernetesgeneratedclientset.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: failed creating clabernetes clientset, err: %w",
+			claberneteserrors.ErrClient,
+			err,
+		)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: failed creating kubernetes clientset, err: %w",
+			claberneteserrors.ErrClient,
+			err,
+		)
+	}
+
+	return New(clabernetesClient, kubeClient, options...), nil
+}
+
+// NewDefault returns a Client for the default kubeconfig -- the in cluster config when running in
+// a pod, otherwise the kubeconfig from the "--kubeconfig" flag, the KUBECONFIG env var, or
+// ~/.kube/config.
+func NewDefault(options ...Option) (*Client, error) {
+	kubeConfig, err := ctrlruntime.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: failed getting kubeconfig, err: %w",
+			claberneteserrors.ErrClient,
+			err,
+		)
+	}
+
+	return NewForConfig(kubeConfig, options...)
+}
+
+// Clabernetes returns the underlying (generated) clabernetes clientset.
+func (c *Client) Clabernetes() clabernetesgeneratedclientset.Interface {
+	return c.clabernetesClient
+}
+
+// Kubernetes returns the underlying kubernetes clientset.
+func (c *Client) Kubernetes() kubernetes.Interface {
+	return c.kubeClient
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeEndpoint holds the management endpoint(s) of a single node of a topology -- that is how to
+// reach the node via the service clabernetes exposes it with.
+type NodeEndpoint struct {
+	// Node is the (containerlab) name of the node.
+	Node string
+	// State is the readiness of the node as reported in the topology status, for example "ready"
+	// or "notready".
+	State string
+	// ServiceName is the name of the service exposing the node, empty if the node is not exposed.
+	ServiceName string
+	// ServiceType is the type of the service exposing the node.
+	ServiceType k8scorev1.ServiceType
+	// ClusterIP is the cluster ip of the service exposing the node, empty for headless services.
+	ClusterIP string
+	// InClusterAddress is the in cluster dns name of the service exposing the node.
+	InClusterAddress string
+	// LoadBalancerAddress is the address of the load balancer exposing the node, empty if the
+	// node is not exposed via a load balancer (or the load balancer has no address yet).
+	LoadBalancerAddress string
+	// TCPPorts are the tcp ports exposed for the node.
+	TCPPorts []int
+	// UDPPorts are the udp ports exposed for the node.
+	UDPPorts []int
+}
+
+// ResolveNodeEndpoints returns the endpoints of the nodes of the given topology, sorted by node
+// name, from the given expose services of the topology -- nodes that are not exposed (yet) are
+// included with only their state set. The dnsSuffix is the in cluster dns suffix of the cluster,
+// typically "svc.cluster.local".
+func ResolveNodeEndpoints(
+	topology *clabernetesapisv1alpha1.Topology,
+	services []k8scorev1.Service,
+	dnsSuffix string,
+) []NodeEndpoint {
+	nodes := map[string]struct{}{}
+
+	for nodeName := range topology.Status.Configs {
+		nodes[nodeName] = struct{}{}
+	}
+
+	for nodeName := range topology.Status.NodeReadiness {
+		nodes[nodeName] = struct{}{}
+	}
+
+	servicesByNode := map[string]*k8scorev1.Service{}
+
+	for idx := range services {
+		service := &services[idx]
+
+		if service.Labels[clabernetesconstants.LabelTopologyOwner] != topology.Name ||
+			service.Labels[clabernetesconstants.LabelTopologyServiceType] !=
+				clabernetesconstants.TopologyServiceTypeExpose {
+			continue
+		}
+
+		nodeName, ok := service.Labels[clabernetesconstants.LabelTopologyNode]
+		if !ok {
+			continue
+		}
+
+		servicesByNode[nodeName] = service
+		nodes[nodeName] = struct{}{}
+	}
+
+	endpoints := make([]NodeEndpoint, 0, len(nodes))
+
+	for nodeName := range nodes {
+		endpoint := NodeEndpoint{
+			Node:  nodeName,
+			State: topology.Status.NodeReadiness[nodeName],
+		}
+
+		exposedPorts := topology.Status.ExposedPorts[nodeName]
+		if exposedPorts != nil {
+			endpoint.LoadBalancerAddress = exposedPorts.LoadBalancerAddress
+			endpoint.TCPPorts = append([]int(nil), exposedPorts.TCPPorts...)
+			endpoint.UDPPorts = append([]int(nil), exposedPorts.UDPPorts...)
+		}
+
+		service, ok := servicesByNode[nodeName]
+		if ok {
+			resolveServiceEndpoint(&endpoint, service, dnsSuffix)
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Node < endpoints[j].Node
+	})
+
+	return endpoints
+}
+
+func resolveServiceEndpoint(endpoint *NodeEndpoint, service *k8scorev1.Service, dnsSuffix string) {
+	endpoint.ServiceName = service.Name
+	endpoint.ServiceType = service.Spec.Type
+	endpoint.InClusterAddress = fmt.Sprintf("%s.%s.%s", service.Name, service.Namespace, dnsSuffix)
+
+	if service.Spec.ClusterIP != k8scorev1.ClusterIPNone {
+		endpoint.ClusterIP = service.Spec.ClusterIP
+	}
+
+	if endpoint.LoadBalancerAddress == "" {
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				endpoint.LoadBalancerAddress = ingress.IP
+
+				break
+			}
+
+			if ingress.Hostname != "" {
+				endpoint.LoadBalancerAddress = ingress.Hostname
+
+				break
+			}
+		}
+	}
+
+	// the service is the source of truth for the ports, the topology status only lists them once
+	// the load balancer has an address
+	var tcpPorts, udpPorts []int
+
+	for _, port := range service.Spec.Ports {
+		switch port.Protocol {
+		case k8scorev1.ProtocolTCP, "":
+			tcpPorts = append(tcpPorts, int(port.Port))
+		case k8scorev1.ProtocolUDP:
+			udpPorts = append(udpPorts, int(port.Port))
+		}
+	}
+
+	if len(tcpPorts) > 0 || len(udpPorts) > 0 {
+		sort.Ints(tcpPorts)
+		sort.Ints(udpPorts)
+
+		endpoint.TCPPorts = tcpPorts
+		endpoint.UDPPorts = udpPorts
+	}
+}
+
+// NodeEndpoints returns the endpoints of the nodes of the topology with the given name in the
+// given namespace, see ResolveNodeEndpoints.
+func (c *Client) NodeEndpoints(
+	ctx context.Context,
+	namespace, name string,
+) ([]NodeEndpoint, error) {
+	topology, err := c.GetTopology(ctx, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: failed getting topology '%s/%s', err: %w",
+			claberneteserrors.ErrClient,
+			namespace,
+			name,
+			err,
+		)
+	}
+
+	services, err := c.kubeClient.CoreV1().Services(namespace).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: fmt.Sprintf(
+				"%s=%s,%s=%s",
+				clabernetesconstants.LabelTopologyOwner,
+				name,
+				clabernetesconstants.LabelTopologyServiceType,
+				clabernetesconstants.TopologyServiceTypeExpose,
+			),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: failed listing expose services of topology '%s/%s', err: %w",
+			claberneteserrors.ErrClient,
+			namespace,
+			name,
+			err,
+		)
+	}
+
+	return ResolveNodeEndpoints(topology, services.Items, c.inClusterDNSSuffix), nil
+}
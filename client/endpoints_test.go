@@ -0,0 +1,154 @@
+package client_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesclient "github.com/srl-labs/clabernetes/client"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testExposeService(
+	name, nodeName, clusterIP string,
+	serviceType k8scorev1.ServiceType,
+	ports ...k8scorev1.ServicePort,
+) k8scorev1.Service {
+	return k8scorev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "nswhatever",
+			Labels: map[string]string{
+				clabernetesconstants.LabelTopologyOwner:       "topo01",
+				clabernetesconstants.LabelTopologyNode:        nodeName,
+				clabernetesconstants.LabelTopologyServiceType: clabernetesconstants.TopologyServiceTypeExpose, //nolint:lll
+			},
+		},
+		Spec: k8scorev1.ServiceSpec{
+			Type:      serviceType,
+			ClusterIP: clusterIP,
+			Ports:     ports,
+		},
+	}
+}
+
+func TestResolveNodeEndpoints(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   clabernetesapisv1alpha1.TopologyStatus
+		services []k8scorev1.Service
+		expected []clabernetesclient.NodeEndpoint
+	}{
+		{
+			name: "not-exposed",
+			status: clabernetesapisv1alpha1.TopologyStatus{
+				NodeReadiness: map[string]string{"srl1": "notready"},
+			},
+			expected: []clabernetesclient.NodeEndpoint{
+				{
+					Node:  "srl1",
+					State: "notready",
+				},
+			},
+		},
+		{
+			name: "load-balancer",
+			status: clabernetesapisv1alpha1.TopologyStatus{
+				NodeReadiness: map[string]string{"srl1": "ready"},
+				ExposedPorts: map[string]*clabernetesapisv1alpha1.ExposedPorts{
+					"srl1": {
+						LoadBalancerAddress: "10.0.0.10",
+						TCPPorts:            []int{22},
+					},
+				},
+			},
+			services: []k8scorev1.Service{
+				testExposeService(
+					"topo01-srl1",
+					"srl1",
+					"10.96.0.20",
+					k8scorev1.ServiceTypeLoadBalancer,
+					k8scorev1.ServicePort{Protocol: k8scorev1.ProtocolTCP, Port: 830},
+					k8scorev1.ServicePort{Protocol: k8scorev1.ProtocolTCP, Port: 22},
+					k8scorev1.ServicePort{Protocol: k8scorev1.ProtocolUDP, Port: 161},
+				),
+			},
+			expected: []clabernetesclient.NodeEndpoint{
+				{
+					Node:                "srl1",
+					State:               "ready",
+					ServiceName:         "topo01-srl1",
+					ServiceType:         k8scorev1.ServiceTypeLoadBalancer,
+					ClusterIP:           "10.96.0.20",
+					InClusterAddress:    "topo01-srl1.nswhatever.svc.cluster.local",
+					LoadBalancerAddress: "10.0.0.10",
+					TCPPorts:            []int{22, 830},
+					UDPPorts:            []int{161},
+				},
+			},
+		},
+		{
+			name: "headless-and-foreign-services",
+			status: clabernetesapisv1alpha1.TopologyStatus{
+				NodeReadiness: map[string]string{"srl1": "ready", "srl2": "ready"},
+			},
+			services: []k8scorev1.Service{
+				testExposeService(
+					"topo01-srl2",
+					"srl2",
+					k8scorev1.ClusterIPNone,
+					k8scorev1.ServiceTypeClusterIP,
+					k8scorev1.ServicePort{Protocol: k8scorev1.ProtocolTCP, Port: 22},
+				),
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "something-else",
+						Namespace: "nswhatever",
+					},
+				},
+			},
+			expected: []clabernetesclient.NodeEndpoint{
+				{
+					Node:  "srl1",
+					State: "ready",
+				},
+				{
+					Node:             "srl2",
+					State:            "ready",
+					ServiceName:      "topo01-srl2",
+					ServiceType:      k8scorev1.ServiceTypeClusterIP,
+					InClusterAddress: "topo01-srl2.nswhatever.svc.cluster.local",
+					TCPPorts:         []int{22},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				topology := &clabernetesapisv1alpha1.Topology{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "topo01",
+						Namespace: "nswhatever",
+					},
+					Status: testCase.status,
+				}
+
+				actual := clabernetesclient.ResolveNodeEndpoints(
+					topology,
+					testCase.services,
+					clabernetesconstants.KubernetesDefaultInClusterDNSSuffix,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// EventInvolvesTopology returns true if the given event is about the given topology or one of the
+// resources clabernetes creates for it -- the topology itself, and the deployments, pods, services
+// and so on of its nodes, which are named after the topology and node (or just the node when the
+// topology removes the topology prefix).
+func EventInvolvesTopology(
+	event *k8scorev1.Event,
+	topology *clabernetesapisv1alpha1.Topology,
+) bool {
+	involvedObject := event.InvolvedObject
+
+	if involvedObject.Namespace != "" && involvedObject.Namespace != topology.Namespace {
+		return false
+	}
+
+	if involvedObject.Name == topology.Name {
+		return true
+	}
+
+	removePrefix := topology.Status.RemoveTopologyPrefix != nil &&
+		*topology.Status.RemoveTopologyPrefix
+
+	if !removePrefix {
+		return strings.HasPrefix(involvedObject.Name, topology.Name+"-")
+	}
+
+	for nodeName := range topology.Status.Configs {
+		if involvedObject.Name == nodeName ||
+			strings.HasPrefix(involvedObject.Name, nodeName+"-") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StreamEvents streams the events of the topology with the given name in the given namespace (see
+// EventInvolvesTopology) until the given context is done, the returned channel is closed once the
+// stream ends. Events that already happened are streamed first. The nodes of the topology are
+// resolved once when the stream starts, events of nodes added to the topology afterward are only
+// streamed if the topology uses the topology prefix.
+func (c *Client) StreamEvents(
+	ctx context.Context,
+	namespace, name string,
+) (<-chan k8scorev1.Event, error) {
+	topology, err := c.GetTopology(ctx, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: failed getting topology '%s/%s', err: %w",
+			claberneteserrors.ErrClient,
+			namespace,
+			name,
+			err,
+		)
+	}
+
+	watcher, err := c.kubeClient.CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: failed watching events in namespace '%s', err: %w",
+			claberneteserrors.ErrClient,
+			namespace,
+			err,
+		)
+	}
+
+	events := make(chan k8scorev1.Event)
+
+	go func() {
+		defer close(events)
+		defer watcher.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case watchEvent, ok := <-watcher.ResultChan():
+				if !ok {
+					return
+				}
+
+				if watchEvent.Type != watch.Added && watchEvent.Type != watch.Modified {
+					continue
+				}
+
+				event, ok := watchEvent.Object.(*k8scorev1.Event)
+				if !ok || !EventInvolvesTopology(event, topology) {
+					continue
+				}
+
+				select {
+				case events <- *event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
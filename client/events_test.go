@@ -0,0 +1,108 @@
+package client_test
+
+import (
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesclient "github.com/srl-labs/clabernetes/client"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEventInvolvesTopology(t *testing.T) {
+	cases := []struct {
+		name           string
+		removePrefix   bool
+		involvedObject k8scorev1.ObjectReference
+		expected       bool
+	}{
+		{
+			name: "topology",
+			involvedObject: k8scorev1.ObjectReference{
+				Kind:      "Topology",
+				Name:      "topo01",
+				Namespace: "nswhatever",
+			},
+			expected: true,
+		},
+		{
+			name: "node-pod",
+			involvedObject: k8scorev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      "topo01-srl1-7d9c8b6f5d-x2x9q",
+				Namespace: "nswhatever",
+			},
+			expected: true,
+		},
+		{
+			name: "other-namespace",
+			involvedObject: k8scorev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      "topo01-srl1-7d9c8b6f5d-x2x9q",
+				Namespace: "nsother",
+			},
+			expected: false,
+		},
+		{
+			name: "other-topology",
+			involvedObject: k8scorev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      "topo02-srl1-7d9c8b6f5d-x2x9q",
+				Namespace: "nswhatever",
+			},
+			expected: false,
+		},
+		{
+			name:         "remove-prefix-node-pod",
+			removePrefix: true,
+			involvedObject: k8scorev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      "srl1-7d9c8b6f5d-x2x9q",
+				Namespace: "nswhatever",
+			},
+			expected: true,
+		},
+		{
+			name:         "remove-prefix-other-pod",
+			removePrefix: true,
+			involvedObject: k8scorev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      "srl10-7d9c8b6f5d-x2x9q",
+				Namespace: "nswhatever",
+			},
+			expected: false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				topology := &clabernetesapisv1alpha1.Topology{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "topo01",
+						Namespace: "nswhatever",
+					},
+					Status: clabernetesapisv1alpha1.TopologyStatus{
+						RemoveTopologyPrefix: clabernetesutil.ToPointer(testCase.removePrefix),
+						Configs: map[string]string{
+							"srl1": "",
+							"srl2": "",
+						},
+					},
+				}
+
+				actual := clabernetesclient.EventInvolvesTopology(
+					&k8scorev1.Event{InvolvedObject: testCase.involvedObject},
+					topology,
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
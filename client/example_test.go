@@ -0,0 +1,71 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	clabernetesclient "github.com/srl-labs/clabernetes/client"
+)
+
+func Example() {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	client, err := clabernetesclient.NewDefault()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	topology := clabernetesclient.NewTopology(
+		"default",
+		"topo01",
+		testContainerlabDefinition,
+		clabernetesclient.WithExposeType("ClusterIP"),
+	)
+
+	_, err = client.CreateTopology(ctx, topology)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = client.WaitForReady(ctx, "default", "topo01", 5*time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	endpoints, err := client.NodeEndpoints(ctx, "default", "topo01")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, endpoint := range endpoints {
+		fmt.Printf("%s: %s %v\n", endpoint.Node, endpoint.InClusterAddress, endpoint.TCPPorts)
+	}
+}
+
+func ExampleClient_StreamEvents() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := clabernetesclient.NewDefault()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	events, err := client.StreamEvents(ctx, "default", "topo01")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for event := range events {
+		fmt.Printf(
+			"%s %s/%s: %s\n",
+			event.Type,
+			event.InvolvedObject.Kind,
+			event.InvolvedObject.Name,
+			event.Message,
+		)
+	}
+}
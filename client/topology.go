@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	"gopkg.in/yaml.v3"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const topologyReadyCondition = "TopologyReady"
+
+// TopologyOption is a functional option for a Topology built by NewTopology.
+type TopologyOption func(topology *clabernetesapisv1alpha1.Topology)
+
+// WithNativeMode runs the nodes of the topology in native mode (the nos in its own container of
+// the launcher pod rather than in docker in the launcher).
+func WithNativeMode() TopologyOption {
+	return func(topology *clabernetesapisv1alpha1.Topology) {
+		topology.Spec.Deployment.NativeMode = clabernetesutil.ToPointer(true)
+	}
+}
+
+// WithExposeType sets the type of the services exposing the nodes of the topology, one of "None",
+// "ClusterIP", "Headless" or "LoadBalancer" (the default).
+func WithExposeType(exposeType string) TopologyOption {
+	return func(topology *clabernetesapisv1alpha1.Topology) {
+		topology.Spec.Expose.ExposeType = exposeType
+	}
+}
+
+// WithDisableExpose disables exposing the nodes of the topology entirely.
+func WithDisableExpose() TopologyOption {
+	return func(topology *clabernetesapisv1alpha1.Topology) {
+		topology.Spec.Expose.DisableExpose = true
+	}
+}
+
+// WithConnectivity sets the connectivity flavor of the topology, for example "vxlan" (the default)
+// or "slurpeeth".
+func WithConnectivity(connectivity string) TopologyOption {
+	return func(topology *clabernetesapisv1alpha1.Topology) {
+		topology.Spec.Connectivity = connectivity
+	}
+}
+
+// WithLabels adds the given labels to the topology.
+func WithLabels(labels map[string]string) TopologyOption {
+	return func(topology *clabernetesapisv1alpha1.Topology) {
+		if topology.Labels == nil {
+			topology.Labels = map[string]string{}
+		}
+
+		for k, v := range labels {
+			topology.Labels[k] = v
+		}
+	}
+}
+
+// WithResources sets the resources of the launcher of the given node, or of all nodes that do not
+// have their own if nodeName is "default".
+func WithResources(nodeName string, resources k8scorev1.ResourceRequirements) TopologyOption {
+	return func(topology *clabernetesapisv1alpha1.Topology) {
+		if topology.Spec.Deployment.Resources == nil {
+			topology.Spec.Deployment.Resources = map[string]k8scorev1.ResourceRequirements{}
+		}
+
+		topology.Spec.Deployment.Resources[nodeName] = resources
+	}
+}
+
+// NewTopology returns a Topology with the given name and namespace for the given (raw) containerlab
+// definition, with the given options applied.
+func NewTopology(
+	namespace, name, containerlabDefinition string,
+	options ...TopologyOption,
+) *clabernetesapisv1alpha1.Topology {
+	topology := &clabernetesapisv1alpha1.Topology{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clabernetesapisv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "Topology",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: clabernetesapisv1alpha1.TopologySpec{
+			Definition: clabernetesapisv1alpha1.Definition{
+				Containerlab: containerlabDefinition,
+			},
+		},
+	}
+
+	for _, option := range options {
+		option(topology)
+	}
+
+	return topology
+}
+
+// NewTopologyFromConfig is NewTopology for a containerlab config built in go rather than a raw
+// containerlab definition.
+func NewTopologyFromConfig(
+	namespace, name string,
+	containerlabConfig *clabernetesutilcontainerlab.Config,
+	options ...TopologyOption,
+) (*clabernetesapisv1alpha1.Topology, error) {
+	containerlabDefinition, err := yaml.Marshal(containerlabConfig)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: failed marshaling containerlab config, err: %w",
+			claberneteserrors.ErrClient,
+			err,
+		)
+	}
+
+	return NewTopology(namespace, name, string(containerlabDefinition), options...), nil
+}
+
+// TopologyReady returns true if the given topology is ready -- that is all of its nodes report
+// ready and the "TopologyReady" condition of the topology is true.
+func TopologyReady(topology *clabernetesapisv1alpha1.Topology) bool {
+	return topology.Status.TopologyReady &&
+		apimachinerymeta.IsStatusConditionTrue(topology.Status.Conditions, topologyReadyCondition)
+}
+
+// CreateTopology creates the given topology.
+func (c *Client) CreateTopology(
+	ctx context.Context,
+	topology *clabernetesapisv1alpha1.Topology,
+) (*clabernetesapisv1alpha1.Topology, error) {
+	return c.clabernetesClient.ClabernetesV1alpha1().Topologies(topology.Namespace).Create(
+		ctx,
+		topology,
+		metav1.CreateOptions{},
+	)
+}
+
+// GetTopology returns the topology with the given name in the given namespace.
+func (c *Client) GetTopology(
+	ctx context.Context,
+	namespace, name string,
+) (*clabernetesapisv1alpha1.Topology, error) {
+	return c.clabernetesClient.ClabernetesV1alpha1().Topologies(namespace).Get(
+		ctx,
+		name,
+		metav1.GetOptions{},
+	)
+}
+
+// UpdateTopology updates the given topology, the topology must be a (modified) copy of the current
+// one as usual.
+func (c *Client) UpdateTopology(
+	ctx context.Context,
+	topology *clabernetesapisv1alpha1.Topology,
+) (*clabernetesapisv1alpha1.Topology, error) {
+	return c.clabernetesClient.ClabernetesV1alpha1().Topologies(topology.Namespace).Update(
+		ctx,
+		topology,
+		metav1.UpdateOptions{},
+	)
+}
+
+// DeleteTopology deletes the topology with the given name in the given namespace, kubernetes
+// garbage collects everything the topology owns.
+func (c *Client) DeleteTopology(ctx context.Context, namespace, name string) error {
+	return c.clabernetesClient.ClabernetesV1alpha1().Topologies(namespace).Delete(
+		ctx,
+		name,
+		metav1.DeleteOptions{},
+	)
+}
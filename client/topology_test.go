@@ -0,0 +1,153 @@
+package client_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesclient "github.com/srl-labs/clabernetes/client"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testContainerlabDefinition = `name: topo01
+topology:
+  nodes:
+    srl1:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+`
+
+func TestNewTopology(t *testing.T) {
+	cases := []struct {
+		name     string
+		options  []clabernetesclient.TopologyOption
+		expected clabernetesapisv1alpha1.TopologySpec
+		labels   map[string]string
+	}{
+		{
+			name: "simple",
+			expected: clabernetesapisv1alpha1.TopologySpec{
+				Definition: clabernetesapisv1alpha1.Definition{
+					Containerlab: testContainerlabDefinition,
+				},
+			},
+		},
+		{
+			name: "with-options",
+			options: []clabernetesclient.TopologyOption{
+				clabernetesclient.WithNativeMode(),
+				clabernetesclient.WithExposeType("ClusterIP"),
+				clabernetesclient.WithConnectivity("slurpeeth"),
+				clabernetesclient.WithLabels(map[string]string{"team": "netops"}),
+			},
+			expected: clabernetesapisv1alpha1.TopologySpec{
+				Definition: clabernetesapisv1alpha1.Definition{
+					Containerlab: testContainerlabDefinition,
+				},
+				Expose: clabernetesapisv1alpha1.Expose{
+					ExposeType: "ClusterIP",
+				},
+				Deployment: clabernetesapisv1alpha1.Deployment{
+					NativeMode: clabernetesutil.ToPointer(true),
+				},
+				Connectivity: "slurpeeth",
+			},
+			labels: map[string]string{"team": "netops"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetesclient.NewTopology(
+					"nswhatever",
+					"topo01",
+					testContainerlabDefinition,
+					testCase.options...,
+				)
+
+				if actual.Name != "topo01" || actual.Namespace != "nswhatever" {
+					clabernetestesthelper.FailOutput(t, actual.ObjectMeta, "nswhatever/topo01")
+				}
+
+				if !reflect.DeepEqual(actual.Labels, testCase.labels) {
+					clabernetestesthelper.FailOutput(t, actual.Labels, testCase.labels)
+				}
+
+				if !reflect.DeepEqual(actual.Spec, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual.Spec, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestTopologyReady(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   clabernetesapisv1alpha1.TopologyStatus
+		expected bool
+	}{
+		{
+			name:     "no-status",
+			expected: false,
+		},
+		{
+			name: "ready",
+			status: clabernetesapisv1alpha1.TopologyStatus{
+				TopologyReady: true,
+				Conditions: []metav1.Condition{
+					{
+						Type:   "TopologyReady",
+						Status: metav1.ConditionTrue,
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "condition-not-ready",
+			status: clabernetesapisv1alpha1.TopologyStatus{
+				TopologyReady: true,
+				Conditions: []metav1.Condition{
+					{
+						Type:   "TopologyReady",
+						Status: metav1.ConditionFalse,
+					},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "status-not-ready",
+			status: clabernetesapisv1alpha1.TopologyStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   "TopologyReady",
+						Status: metav1.ConditionTrue,
+					},
+				},
+			},
+			expected: false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetesclient.TopologyReady(&clabernetesapisv1alpha1.Topology{
+					Status: testCase.status,
+				})
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const defaultPollInterval = 5 * time.Second
+
+// WaitForReady polls the topology with the given name in the given namespace every pollInterval
+// (or every 5 seconds if pollInterval is zero) until it is ready (see TopologyReady), and returns
+// the ready topology. Use a context with a deadline to bound the wait -- when the context is done
+// before the topology is ready the error wraps both ErrClient and the context error. A topology
+// that does not exist (yet) is waited for like any other topology that is not ready.
+func (c *Client) WaitForReady(
+	ctx context.Context,
+	namespace, name string,
+	pollInterval time.Duration,
+) (*clabernetesapisv1alpha1.Topology, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		topology, err := c.GetTopology(ctx, namespace, name)
+
+		switch {
+		case err == nil:
+			if TopologyReady(topology) {
+				return topology, nil
+			}
+		case apimachineryerrors.IsNotFound(err):
+			// not created (yet), keep waiting
+		case ctx.Err() == nil:
+			return nil, fmt.Errorf(
+				"%w: failed getting topology '%s/%s', err: %w",
+				claberneteserrors.ErrClient,
+				namespace,
+				name,
+				err,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf(
+				"%w: topology '%s/%s' not ready, err: %w",
+				claberneteserrors.ErrClient,
+				namespace,
+				name,
+				ctx.Err(),
+			)
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package cli
 
 import (
+	"errors"
+	"os/exec"
+
+	clabernetescleanup "github.com/srl-labs/clabernetes/cleanup"
 	clabernetesclicker "github.com/srl-labs/clabernetes/clicker"
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
 	claberneteslauncher "github.com/srl-labs/clabernetes/launcher"
@@ -28,6 +32,38 @@ const (
 
 	// indicates that the clicker job should *not* cleanup the configmap it creates.
 	clickerSkipConfigMapCleanup = "skipConfigMapCleanup"
+
+	// indicates that the clicker job should run the built-in capability scan (kvm, nested virt,
+	// hugepages, cpu flags) on the nodes and record the results as node labels rather than
+	// running the user provided script.
+	clickerScanCapabilities = "scanCapabilities"
+
+	// indicates the topology the cleanup command should clean up.
+	cleanupTopology = "topology"
+
+	// indicates the namespace of the topology the cleanup command should clean up.
+	cleanupNamespace = "namespace"
+
+	// indicates that the cleanup command should clear the finalizers of resources that are stuck
+	// deleting (and delete launcher pods without grace period).
+	cleanupForce = "force"
+
+	// indicates how long each cleanup stage waits for its resources to be deleted.
+	cleanupTimeout = "timeout"
+
+	// indicates the file the cleanup report should be written to, stdout if unset.
+	cleanupReport = "report"
+
+	// indicates the (containerlab) node the node-shell should attach to, defaults to the node of
+	// the launcher the command is run in.
+	nodeShellNode = "node"
+
+	// indicates the doctor report should not be colored, it is never colored if stdout is not a
+	// terminal anyway.
+	doctorNoColor = "no-color"
+
+	// indicates the links stats should be printed as json rather than as a table.
+	linksStatsJSON = "json"
 )
 
 // Entrypoint returns the clabernetes manager entrypoint, kicking off one of the clabernetes
@@ -79,6 +115,172 @@ func Entrypoint() *cli.App {
 					return nil
 				},
 			},
+			{
+				Name:  "wire-tap",
+				Usage: "run a wire tap (for wire tap pods)",
+				Flags: []cli.Flag{},
+				Action: func(_ *cli.Context) error {
+					claberneteslauncher.StartWireTap()
+
+					return nil
+				},
+			},
+			{
+				Name: "node-shell",
+				Usage: "open a shell (or run a command) in the nested node container of a docker" +
+					" mode launcher",
+				ArgsUsage: "[command [args...]]",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     nodeShellNode,
+						Usage:    "the node to attach to, defaults to the node of this launcher",
+						Required: false,
+						Value:    "",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					err := claberneteslauncher.StartNodeShell(
+						&claberneteslauncher.NodeShellArgs{
+							NodeName: c.String(nodeShellNode),
+							Command:  c.Args().Slice(),
+						},
+					)
+
+					// pass the exit code of the nested command through (rather than panicking in
+					// main) so node-shell behaves just like a "normal" exec
+					var exitErr *exec.ExitError
+					if errors.As(err, &exitErr) {
+						return cli.Exit("", exitErr.ExitCode())
+					}
+
+					if err != nil {
+						return cli.Exit(err, 1)
+					}
+
+					return nil
+				},
+			},
+			{
+				Name: "doctor",
+				Usage: "run in-pod diagnostics (dns, kube api, tunnels, docker, devices) in a" +
+					" launcher and print a report",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:     doctorNoColor,
+						Usage:    "disable the colored report output",
+						Required: false,
+						Value:    false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					err := claberneteslauncher.StartDoctor(
+						&claberneteslauncher.DoctorArgs{
+							NoColor: c.Bool(doctorNoColor),
+						},
+					)
+					if err != nil {
+						// the report already says what failed, just set the exit code
+						return cli.Exit("", 1)
+					}
+
+					return nil
+				},
+			},
+			{
+				Name:  "links",
+				Usage: "inspect the links of the node(s) of a launcher",
+				Subcommands: []*cli.Command{
+					{
+						Name: "stats",
+						Usage: "print the traffic counters of the links by containerlab endpoint" +
+							" name",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:     linksStatsJSON,
+								Usage:    "print the stats as json",
+								Required: false,
+								Value:    false,
+							},
+						},
+						Action: func(c *cli.Context) error {
+							err := claberneteslauncher.StartLinksStats(
+								&claberneteslauncher.LinksStatsArgs{
+									JSON: c.Bool(linksStatsJSON),
+								},
+							)
+							if err != nil {
+								return cli.Exit(err, 1)
+							}
+
+							return nil
+						},
+					},
+					{
+						Name: "cleanup",
+						Usage: "delete the links the launcher created (the preStop hook of host" +
+							" network mode launchers)",
+						Flags: []cli.Flag{},
+						Action: func(_ *cli.Context) error {
+							err := claberneteslauncher.StartLinksCleanup()
+							if err != nil {
+								return cli.Exit(err, 1)
+							}
+
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name: "cleanup",
+				Usage: "delete a topology and all of its resources in dependency order," +
+					" optionally clearing stuck finalizers",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     cleanupTopology,
+						Usage:    "the name of the topology to clean up",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     cleanupNamespace,
+						Usage:    "the namespace of the topology to clean up",
+						Required: false,
+						Value:    "default",
+					},
+					&cli.BoolFlag{
+						Name: cleanupForce,
+						Usage: "clear the finalizers of resources that are still around after the" +
+							" timeout and delete launcher pods without grace period",
+						Required: false,
+						Value:    false,
+					},
+					&cli.DurationFlag{
+						Name:     cleanupTimeout,
+						Usage:    "how long each cleanup stage waits for its resources to go away",
+						Required: false,
+						Value:    clabernetescleanup.DefaultTimeout,
+					},
+					&cli.StringFlag{
+						Name:     cleanupReport,
+						Usage:    "the file to write the json cleanup report to, stdout if unset",
+						Required: false,
+						Value:    "",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					clabernetescleanup.StartClabernetes(
+						&clabernetescleanup.Args{
+							Topology:   c.String(cleanupTopology),
+							Namespace:  c.String(cleanupNamespace),
+							Force:      c.Bool(cleanupForce),
+							Timeout:    c.Duration(cleanupTimeout),
+							ReportPath: c.String(cleanupReport),
+						},
+					)
+
+					return nil
+				},
+			},
 			{
 				Name:  "clicker",
 				Usage: "run the node clicker",
@@ -111,6 +313,13 @@ func Entrypoint() *cli.App {
 						Required: false,
 						Value:    false,
 					},
+					&cli.BoolFlag{
+						Name: clickerScanCapabilities,
+						Usage: "indicates if the clicker should run the capability scan and label" +
+							" nodes with the results instead of running the configured script",
+						Required: false,
+						Value:    false,
+					},
 				},
 				Action: func(c *cli.Context) error {
 					clabernetesclicker.StartClabernetes(
@@ -119,6 +328,7 @@ func Entrypoint() *cli.App {
 							NodeSelector:         c.String(clickerNodeSelector),
 							SkipConfigMapCleanup: c.Bool(clickerSkipConfigMapCleanup),
 							SkipPodsCleanup:      c.Bool(clickerSkipPodCleanup),
+							ScanCapabilities:     c.Bool(clickerScanCapabilities),
 						},
 					)
 
@@ -33,6 +33,16 @@ type bootstrapConfig struct {
 	naming                      string
 	containerlabVersion         string
 	extraEnv                    []k8scorev1.EnvVar
+	kindAliases                 map[string]string
+	kindDefaultImages           map[string]string
+	kvmKinds                    []string
+	priorityClassName           string
+	featureGates                map[string]bool
+	networkPolicy               clabernetesapisv1alpha1.ConfigNetworkPolicy
+	imageScanning               clabernetesapisv1alpha1.ConfigImageScanning
+	mgmtDNS                     clabernetesapisv1alpha1.ConfigMgmtDNS
+	metrics                     clabernetesapisv1alpha1.ConfigMetrics
+	nativeKinds                 map[string]clabernetesapisv1alpha1.ConfigNativeKind
 }
 
 func bootstrapFromConfigMap( //nolint:gocyclo,funlen,gocognit
@@ -174,6 +184,84 @@ func bootstrapFromConfigMap( //nolint:gocyclo,funlen,gocognit
 		}
 	}
 
+	kindAliasesData, kindAliasesOk := inMap["kindAliases"]
+	if kindAliasesOk {
+		err := yaml.Unmarshal([]byte(kindAliasesData), &bc.kindAliases)
+		if err != nil {
+			outErrors = append(outErrors, err.Error())
+		}
+	}
+
+	kindDefaultImagesData, kindDefaultImagesOk := inMap["kindDefaultImages"]
+	if kindDefaultImagesOk {
+		err := yaml.Unmarshal([]byte(kindDefaultImagesData), &bc.kindDefaultImages)
+		if err != nil {
+			outErrors = append(outErrors, err.Error())
+		}
+	}
+
+	kvmKindsData, kvmKindsOk := inMap["kvmKinds"]
+	if kvmKindsOk {
+		err := yaml.Unmarshal([]byte(kvmKindsData), &bc.kvmKinds)
+		if err != nil {
+			outErrors = append(outErrors, err.Error())
+		}
+	}
+
+	priorityClassName, priorityClassNameOk := inMap["priorityClassName"]
+	if priorityClassNameOk {
+		bc.priorityClassName = priorityClassName
+	}
+
+	featureGatesData, featureGatesOk := inMap["featureGates"]
+	if featureGatesOk {
+		err := yaml.Unmarshal([]byte(featureGatesData), &bc.featureGates)
+		if err != nil {
+			outErrors = append(outErrors, err.Error())
+		}
+	}
+
+	networkPolicyData, networkPolicyOk := inMap["networkPolicy"]
+	if networkPolicyOk {
+		// sigs yaml as the struct only has json tags
+		err := sigsyaml.Unmarshal([]byte(networkPolicyData), &bc.networkPolicy)
+		if err != nil {
+			outErrors = append(outErrors, err.Error())
+		}
+	}
+
+	imageScanningData, imageScanningOk := inMap["imageScanning"]
+	if imageScanningOk {
+		err := sigsyaml.Unmarshal([]byte(imageScanningData), &bc.imageScanning)
+		if err != nil {
+			outErrors = append(outErrors, err.Error())
+		}
+	}
+
+	mgmtDNSData, mgmtDNSOk := inMap["mgmtDNS"]
+	if mgmtDNSOk {
+		err := sigsyaml.Unmarshal([]byte(mgmtDNSData), &bc.mgmtDNS)
+		if err != nil {
+			outErrors = append(outErrors, err.Error())
+		}
+	}
+
+	metricsData, metricsOk := inMap["metrics"]
+	if metricsOk {
+		err := sigsyaml.Unmarshal([]byte(metricsData), &bc.metrics)
+		if err != nil {
+			outErrors = append(outErrors, err.Error())
+		}
+	}
+
+	nativeKindsData, nativeKindsOk := inMap["nativeKinds"]
+	if nativeKindsOk {
+		err := sigsyaml.Unmarshal([]byte(nativeKindsData), &bc.nativeKinds)
+		if err != nil {
+			outErrors = append(outErrors, err.Error())
+		}
+	}
+
 	var err error
 
 	if len(outErrors) > 0 {
@@ -313,6 +401,83 @@ func mergeFromBootstrapConfigMerge( //nolint:gocyclo
 	if len(config.Spec.Deployment.ExtraEnv) == 0 {
 		config.Spec.Deployment.ExtraEnv = bootstrap.extraEnv
 	}
+
+	if len(config.Spec.Deployment.KVMKinds) == 0 {
+		config.Spec.Deployment.KVMKinds = bootstrap.kvmKinds
+	}
+
+	if config.Spec.Deployment.PriorityClassName == "" {
+		config.Spec.Deployment.PriorityClassName = bootstrap.priorityClassName
+	}
+
+	if len(bootstrap.kindAliases) > 0 && config.Spec.KindAliases == nil {
+		config.Spec.KindAliases = make(map[string]string)
+	}
+
+	for k, v := range bootstrap.kindAliases {
+		_, exists := config.Spec.KindAliases[k]
+		if exists {
+			continue
+		}
+
+		config.Spec.KindAliases[k] = v
+	}
+
+	if len(bootstrap.kindDefaultImages) > 0 && config.Spec.KindDefaultImages == nil {
+		config.Spec.KindDefaultImages = make(map[string]string)
+	}
+
+	for k, v := range bootstrap.kindDefaultImages {
+		_, exists := config.Spec.KindDefaultImages[k]
+		if exists {
+			continue
+		}
+
+		config.Spec.KindDefaultImages[k] = v
+	}
+
+	if len(bootstrap.featureGates) > 0 && config.Spec.FeatureGates == nil {
+		config.Spec.FeatureGates = make(map[string]bool)
+	}
+
+	for k, v := range bootstrap.featureGates {
+		_, exists := config.Spec.FeatureGates[k]
+		if exists {
+			continue
+		}
+
+		config.Spec.FeatureGates[k] = v
+	}
+
+	if config.Spec.NetworkPolicy.Renderer == "" {
+		config.Spec.NetworkPolicy = bootstrap.networkPolicy
+	}
+
+	if config.Spec.ImageScanning.Scanner == "" {
+		config.Spec.ImageScanning = bootstrap.imageScanning
+	}
+
+	if config.Spec.MgmtDNS.Provider == "" {
+		config.Spec.MgmtDNS = bootstrap.mgmtDNS
+	}
+
+	if !config.Spec.Metrics.PodMonitor && config.Spec.Metrics.ScrapeInterval == "" &&
+		len(config.Spec.Metrics.Labels) == 0 {
+		config.Spec.Metrics = bootstrap.metrics
+	}
+
+	if len(bootstrap.nativeKinds) > 0 && config.Spec.NativeKinds == nil {
+		config.Spec.NativeKinds = make(map[string]clabernetesapisv1alpha1.ConfigNativeKind)
+	}
+
+	for k, v := range bootstrap.nativeKinds {
+		_, exists := config.Spec.NativeKinds[k]
+		if exists {
+			continue
+		}
+
+		config.Spec.NativeKinds[k] = v
+	}
 }
 
 func mergeFromBootstrapConfigReplace(
@@ -341,7 +506,17 @@ func mergeFromBootstrapConfigReplace(
 			LauncherLogLevel:            bootstrap.launcherLogLevel,
 			ContainerlabVersion:         bootstrap.containerlabVersion,
 			ExtraEnv:                    bootstrap.extraEnv,
+			KVMKinds:                    bootstrap.kvmKinds,
+			PriorityClassName:           bootstrap.priorityClassName,
 		},
-		Naming: bootstrap.naming,
+		Naming:            bootstrap.naming,
+		KindAliases:       bootstrap.kindAliases,
+		KindDefaultImages: bootstrap.kindDefaultImages,
+		FeatureGates:      bootstrap.featureGates,
+		NetworkPolicy:     bootstrap.networkPolicy,
+		MgmtDNS:           bootstrap.mgmtDNS,
+		Metrics:           bootstrap.metrics,
+		ImageScanning:     bootstrap.imageScanning,
+		NativeKinds:       bootstrap.nativeKinds,
 	}
 }
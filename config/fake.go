@@ -2,7 +2,9 @@ package config
 
 import (
 	"maps"
+	"slices"
 
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
 	k8scorev1 "k8s.io/api/core/v1"
 )
@@ -16,6 +18,18 @@ func GetFakeManager() Manager {
 // fakeManager defined type alias to be used below.
 type fakeManager struct {
 	nodeSelectorsByImage map[string]map[string]string
+	kindAliases          map[string]string
+	kindDefaultImages    map[string]string
+	kvmKinds             []string
+	priorityClassName    string
+	connectivityPorts    clabernetesapisv1alpha1.ConnectivityPorts
+	propagatedPullSecret string
+	featureGates         map[string]bool
+	networkPolicy        clabernetesapisv1alpha1.ConfigNetworkPolicy
+	imageScanning        clabernetesapisv1alpha1.ConfigImageScanning
+	mgmtDNS              clabernetesapisv1alpha1.ConfigMgmtDNS
+	metrics              clabernetesapisv1alpha1.ConfigMetrics
+	nativeKinds          map[string]clabernetesapisv1alpha1.ConfigNativeKind
 }
 
 // FakeOption defined type alias to be used below.
@@ -25,6 +39,8 @@ type FakeOption func(*fakeManager)
 func NewFakeManager(opts ...FakeOption) Manager {
 	manager := &fakeManager{
 		nodeSelectorsByImage: make(map[string]map[string]string),
+		kindAliases:          make(map[string]string),
+		kindDefaultImages:    make(map[string]string),
 	}
 	for _, opt := range opts {
 		opt(manager)
@@ -46,6 +62,86 @@ func WithNodeSelectors(selectors map[string]map[string]string) FakeOption {
 	}
 }
 
+// WithKindAliases returns a fake manager to support kind aliases and kind default images.
+func WithKindAliases(aliases, defaultImages map[string]string) FakeOption {
+	return func(fm *fakeManager) {
+		fm.kindAliases = maps.Clone(aliases)
+		fm.kindDefaultImages = maps.Clone(defaultImages)
+	}
+}
+
+// WithKVMKinds returns a fake manager to support kvm kinds.
+func WithKVMKinds(kinds []string) FakeOption {
+	return func(fm *fakeManager) {
+		fm.kvmKinds = slices.Clone(kinds)
+	}
+}
+
+// WithPriorityClassName returns a fake manager with the given default priority class name.
+func WithPriorityClassName(name string) FakeOption {
+	return func(fm *fakeManager) {
+		fm.priorityClassName = name
+	}
+}
+
+// WithConnectivityPorts returns a fake manager with the given global connectivity port overrides.
+func WithConnectivityPorts(ports clabernetesapisv1alpha1.ConnectivityPorts) FakeOption {
+	return func(fm *fakeManager) {
+		fm.connectivityPorts = ports
+	}
+}
+
+// WithPropagatedPullSecret returns a fake manager with the given propagated pull secret name.
+func WithPropagatedPullSecret(name string) FakeOption {
+	return func(fm *fakeManager) {
+		fm.propagatedPullSecret = name
+	}
+}
+
+// WithFeatureGates returns a fake manager with the given feature gate overrides.
+func WithFeatureGates(featureGates map[string]bool) FakeOption {
+	return func(fm *fakeManager) {
+		fm.featureGates = maps.Clone(featureGates)
+	}
+}
+
+// WithNetworkPolicy returns a fake manager with the given network policy settings.
+func WithNetworkPolicy(networkPolicy clabernetesapisv1alpha1.ConfigNetworkPolicy) FakeOption {
+	return func(fm *fakeManager) {
+		fm.networkPolicy = *networkPolicy.DeepCopy()
+	}
+}
+
+// WithImageScanning returns a fake manager with the given image scanning settings.
+func WithImageScanning(imageScanning clabernetesapisv1alpha1.ConfigImageScanning) FakeOption {
+	return func(fm *fakeManager) {
+		fm.imageScanning = imageScanning
+	}
+}
+
+// WithMgmtDNS returns a fake manager with the given mgmt dns settings.
+func WithMgmtDNS(mgmtDNS clabernetesapisv1alpha1.ConfigMgmtDNS) FakeOption {
+	return func(fm *fakeManager) {
+		fm.mgmtDNS = mgmtDNS
+	}
+}
+
+// WithMetrics returns a fake manager with the given launcher metrics scraping settings.
+func WithMetrics(metrics clabernetesapisv1alpha1.ConfigMetrics) FakeOption {
+	return func(fm *fakeManager) {
+		fm.metrics = *metrics.DeepCopy()
+	}
+}
+
+// WithNativeKinds returns a fake manager with the given native kind settings.
+func WithNativeKinds(
+	nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind,
+) FakeOption {
+	return func(fm *fakeManager) {
+		fm.nativeKinds = ResolveNativeKinds(nativeKinds)
+	}
+}
+
 func (f fakeManager) Start() error {
 	return nil
 }
@@ -71,6 +167,12 @@ func (f fakeManager) GetResourcesForContainerlabKind(
 	return nil
 }
 
+func (f fakeManager) HasResourcesForContainerlabKind(containerlabKind string) bool {
+	_ = containerlabKind
+
+	return false
+}
+
 func (f fakeManager) GetNodeSelectorsByImage(
 	imageName string,
 ) map[string]string {
@@ -129,6 +231,14 @@ func (f fakeManager) GetExtraEnv() []k8scorev1.EnvVar {
 	return nil
 }
 
+func (f fakeManager) GetKVMKinds() []string {
+	return slices.Clone(f.kvmKinds)
+}
+
+func (f fakeManager) GetPriorityClassName() string {
+	return f.priorityClassName
+}
+
 func (f fakeManager) GetRemoveTopologyPrefix() bool {
 	return false
 }
@@ -136,3 +246,55 @@ func (f fakeManager) GetRemoveTopologyPrefix() bool {
 func (f fakeManager) GetContainerlabVersion() string {
 	return ""
 }
+
+func (f fakeManager) GetKindAliases() map[string]string {
+	return maps.Clone(f.kindAliases)
+}
+
+func (f fakeManager) GetKindDefaultImages() map[string]string {
+	return maps.Clone(f.kindDefaultImages)
+}
+
+func (f fakeManager) GetConnectivityPorts() clabernetesapisv1alpha1.ConnectivityPorts {
+	return f.connectivityPorts
+}
+
+func (f fakeManager) GetPropagatedPullSecret() string {
+	return f.propagatedPullSecret
+}
+
+func (f fakeManager) GetFeatureGates() map[string]bool {
+	featureGates, _ := ResolveFeatureGates(f.featureGates)
+
+	return featureGates
+}
+
+func (f fakeManager) IsFeatureGateEnabled(featureGate string) bool {
+	return f.GetFeatureGates()[featureGate]
+}
+
+func (f fakeManager) GetNetworkPolicy() clabernetesapisv1alpha1.ConfigNetworkPolicy {
+	networkPolicy := *f.networkPolicy.DeepCopy()
+
+	if networkPolicy.Renderer == "" {
+		networkPolicy.Renderer = clabernetesconstants.NetworkPolicyRendererNone
+	}
+
+	return networkPolicy
+}
+
+func (f fakeManager) GetImageScanning() clabernetesapisv1alpha1.ConfigImageScanning {
+	return ResolveImageScanning(f.imageScanning)
+}
+
+func (f fakeManager) GetMgmtDNS() clabernetesapisv1alpha1.ConfigMgmtDNS {
+	return ResolveMgmtDNS(f.mgmtDNS)
+}
+
+func (f fakeManager) GetMetrics() clabernetesapisv1alpha1.ConfigMetrics {
+	return *f.metrics.DeepCopy()
+}
+
+func (f fakeManager) GetNativeKinds() map[string]clabernetesapisv1alpha1.ConfigNativeKind {
+	return ResolveNativeKinds(f.nativeKinds)
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+// featureGateDefaults is the mapping of all known feature gates -> their default state.
+var featureGateDefaults = map[string]bool{ //nolint:gochecknoglobals
+	clabernetesconstants.FeatureGateCanonicalConfigHash:          true,
+	clabernetesconstants.FeatureGateNativeKindDrivers:            true,
+	clabernetesconstants.FeatureGateDiskPressureEviction:         true,
+	// off by default as flipping it renames the nads of deployed topologies and so restarts their
+	// launchers, enable it explicitly to opt in
+	clabernetesconstants.FeatureGateStableNetworkAttachmentNames: false,
+}
+
+// ResolveFeatureGates returns the state of all known feature gates with the given overrides (from
+// the global config) applied on top of the defaults, and the (sorted) names of any overrides for
+// gates that are not known, those are ignored.
+func ResolveFeatureGates(overrides map[string]bool) (map[string]bool, []string) {
+	resolved := maps.Clone(featureGateDefaults)
+
+	var unknown []string
+
+	for gate, enabled := range overrides {
+		if _, ok := featureGateDefaults[gate]; !ok {
+			unknown = append(unknown, gate)
+
+			continue
+		}
+
+		resolved[gate] = enabled
+	}
+
+	slices.Sort(unknown)
+
+	return resolved, unknown
+}
+
+// FormatFeatureGates returns the given feature gates as a (sorted) "Gate=true,Other=false" string,
+// the same format kubernetes components use for their feature gates flag.
+func FormatFeatureGates(featureGates map[string]bool) string {
+	formatted := make([]string, 0, len(featureGates))
+
+	for _, gate := range slices.Sorted(maps.Keys(featureGates)) {
+		formatted = append(formatted, fmt.Sprintf("%s=%t", gate, featureGates[gate]))
+	}
+
+	return strings.Join(formatted, ",")
+}
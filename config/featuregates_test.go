@@ -0,0 +1,83 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+func TestResolveFeatureGates(t *testing.T) {
+	cases := []struct {
+		name            string
+		overrides       map[string]bool
+		expectedGates   map[string]bool
+		expectedUnknown []string
+	}{
+		{
+			name:      "defaults",
+			overrides: nil,
+			expectedGates: map[string]bool{
+				clabernetesconstants.FeatureGateCanonicalConfigHash:          true,
+				clabernetesconstants.FeatureGateNativeKindDrivers:            true,
+				clabernetesconstants.FeatureGateDiskPressureEviction:         true,
+				clabernetesconstants.FeatureGateStableNetworkAttachmentNames: false,
+			},
+			expectedUnknown: nil,
+		},
+		{
+			name: "overrides",
+			overrides: map[string]bool{
+				clabernetesconstants.FeatureGateNativeKindDrivers: false,
+			},
+			expectedGates: map[string]bool{
+				clabernetesconstants.FeatureGateCanonicalConfigHash:          true,
+				clabernetesconstants.FeatureGateNativeKindDrivers:            false,
+				clabernetesconstants.FeatureGateDiskPressureEviction:         true,
+				clabernetesconstants.FeatureGateStableNetworkAttachmentNames: false,
+			},
+			expectedUnknown: nil,
+		},
+		{
+			name: "unknown",
+			overrides: map[string]bool{
+				"SomethingElse": true,
+				"Another":       false,
+				clabernetesconstants.FeatureGateDiskPressureEviction: false,
+			},
+			expectedGates: map[string]bool{
+				clabernetesconstants.FeatureGateCanonicalConfigHash:          true,
+				clabernetesconstants.FeatureGateNativeKindDrivers:            true,
+				clabernetesconstants.FeatureGateDiskPressureEviction:         false,
+				clabernetesconstants.FeatureGateStableNetworkAttachmentNames: false,
+			},
+			expectedUnknown: []string{"Another", "SomethingElse"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actualGates, actualUnknown := clabernetesconfig.ResolveFeatureGates(
+					testCase.overrides,
+				)
+				if !cmp.Equal(actualGates, testCase.expectedGates) {
+					t.Fatalf(
+						"actual and expected gates are not equal, diff: %s",
+						cmp.Diff(actualGates, testCase.expectedGates),
+					)
+				}
+
+				if !cmp.Equal(actualUnknown, testCase.expectedUnknown) {
+					t.Fatalf(
+						"actual and expected unknown gates are not equal, diff: %s",
+						cmp.Diff(actualUnknown, testCase.expectedUnknown),
+					)
+				}
+			})
+	}
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"slices"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
 	k8scorev1 "k8s.io/api/core/v1"
 )
@@ -65,6 +68,15 @@ func (m *manager) GetResourcesForContainerlabKind(
 	)
 }
 
+func (m *manager) HasResourcesForContainerlabKind(containerlabKind string) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	_, ok := m.config.Deployment.ResourcesByContainerlabKind[containerlabKind]
+
+	return ok
+}
+
 func (m *manager) GetNodeSelectorsByImage(
 	imageName string,
 ) map[string]string {
@@ -165,6 +177,20 @@ func (m *manager) GetExtraEnv() []k8scorev1.EnvVar {
 	return m.config.Deployment.ExtraEnv
 }
 
+func (m *manager) GetKVMKinds() []string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return slices.Clone(m.config.Deployment.KVMKinds)
+}
+
+func (m *manager) GetPriorityClassName() string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.config.Deployment.PriorityClassName
+}
+
 func (m *manager) GetRemoveTopologyPrefix() bool {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -178,3 +204,99 @@ func (m *manager) GetContainerlabVersion() string {
 
 	return m.config.Deployment.ContainerlabVersion
 }
+
+func (m *manager) GetKindAliases() map[string]string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	// we dont want to pass by ref, so make a new map
+	outKindAliases := make(map[string]string)
+
+	for k, v := range m.config.KindAliases {
+		outKindAliases[k] = v
+	}
+
+	return outKindAliases
+}
+
+func (m *manager) GetKindDefaultImages() map[string]string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	// we dont want to pass by ref, so make a new map
+	outKindDefaultImages := make(map[string]string)
+
+	for k, v := range m.config.KindDefaultImages {
+		outKindDefaultImages[k] = v
+	}
+
+	return outKindDefaultImages
+}
+
+func (m *manager) GetConnectivityPorts() clabernetesapisv1alpha1.ConnectivityPorts {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.config.ConnectivityPorts
+}
+
+func (m *manager) GetPropagatedPullSecret() string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.config.ImagePull.PropagatedPullSecret
+}
+
+func (m *manager) GetFeatureGates() map[string]bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	featureGates, _ := ResolveFeatureGates(m.config.FeatureGates)
+
+	return featureGates
+}
+
+func (m *manager) IsFeatureGateEnabled(featureGate string) bool {
+	return m.GetFeatureGates()[featureGate]
+}
+
+func (m *manager) GetNetworkPolicy() clabernetesapisv1alpha1.ConfigNetworkPolicy {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	networkPolicy := *m.config.NetworkPolicy.DeepCopy()
+
+	if networkPolicy.Renderer == "" {
+		networkPolicy.Renderer = clabernetesconstants.NetworkPolicyRendererNone
+	}
+
+	return networkPolicy
+}
+
+func (m *manager) GetImageScanning() clabernetesapisv1alpha1.ConfigImageScanning {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return ResolveImageScanning(m.config.ImageScanning)
+}
+
+func (m *manager) GetMgmtDNS() clabernetesapisv1alpha1.ConfigMgmtDNS {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return ResolveMgmtDNS(m.config.MgmtDNS)
+}
+
+func (m *manager) GetMetrics() clabernetesapisv1alpha1.ConfigMetrics {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return *m.config.Metrics.DeepCopy()
+}
+
+func (m *manager) GetNativeKinds() map[string]clabernetesapisv1alpha1.ConfigNativeKind {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return ResolveNativeKinds(m.config.NativeKinds)
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+// ResolveImageScanning returns the given image scanning settings with the defaults applied for
+// whatever is unset -- no scanner, the "warn" policy and the "CRITICAL" severity threshold.
+func ResolveImageScanning(
+	imageScanning clabernetesapisv1alpha1.ConfigImageScanning,
+) clabernetesapisv1alpha1.ConfigImageScanning {
+	if imageScanning.Scanner == "" {
+		imageScanning.Scanner = clabernetesconstants.ImageScannerNone
+	}
+
+	if imageScanning.Policy == "" {
+		imageScanning.Policy = clabernetesconstants.ImageScanPolicyWarn
+	}
+
+	imageScanning.SeverityThreshold = strings.ToUpper(imageScanning.SeverityThreshold)
+	if imageScanning.SeverityThreshold == "" {
+		imageScanning.SeverityThreshold = clabernetesconstants.ImageScanSeverityCritical
+	}
+
+	imageScanning.Endpoint = strings.TrimSuffix(imageScanning.Endpoint, "/")
+
+	return imageScanning
+}
@@ -120,6 +120,9 @@ type Manager interface { //nolint: interfacebloat
 		containerlabKind string,
 		containerlabType string,
 	) *k8scorev1.ResourceRequirements
+	// HasResourcesForContainerlabKind returns true if the global config has resources for the
+	// given containerlab kind (as opposed to falling back to the default resources).
+	HasResourcesForContainerlabKind(containerlabKind string) bool
 	// GetNodeSelectorsByImage returns the node selectors map for an image.
 	GetNodeSelectorsByImage(
 		imageName string,
@@ -157,6 +160,37 @@ type Manager interface { //nolint: interfacebloat
 	GetRemoveTopologyPrefix() bool
 	// GetContainerlabVersion returns the global config containerlab version.
 	GetContainerlabVersion() string
+	// GetKVMKinds returns the list of containerlab kinds that require kvm.
+	GetKVMKinds() []string
+	// GetPriorityClassName returns the default priority class name of launcher pods.
+	GetPriorityClassName() string
+	// GetKindAliases returns the mapping of kind alias -> containerlab kind.
+	GetKindAliases() map[string]string
+	// GetKindDefaultImages returns the mapping of containerlab kind -> default image.
+	GetKindDefaultImages() map[string]string
+	// GetConnectivityPorts returns the global connectivity port overrides.
+	GetConnectivityPorts() clabernetesapisv1alpha1.ConnectivityPorts
+	// GetPropagatedPullSecret returns the name of the pull secret (in the manager namespace) that
+	// should be propagated to all topology namespaces.
+	GetPropagatedPullSecret() string
+	// GetFeatureGates returns the state of all known feature gates -- the defaults with the
+	// overrides from the global config applied.
+	GetFeatureGates() map[string]bool
+	// IsFeatureGateEnabled returns true if the given feature gate is enabled.
+	IsFeatureGateEnabled(featureGate string) bool
+	// GetNetworkPolicy returns the network policy settings -- the renderer is resolved to "none" if
+	// it is unset.
+	GetNetworkPolicy() clabernetesapisv1alpha1.ConfigNetworkPolicy
+	// GetImageScanning returns the image scanning settings -- the scanner, policy and severity
+	// threshold are resolved to their defaults ("none", "warn" and "CRITICAL") if they are unset.
+	GetImageScanning() clabernetesapisv1alpha1.ConfigImageScanning
+	// GetMgmtDNS returns the mgmt dns settings -- the provider, domain template and ttl are
+	// resolved to their defaults if they are unset.
+	GetMgmtDNS() clabernetesapisv1alpha1.ConfigMgmtDNS
+	// GetMetrics returns the launcher metrics scraping settings.
+	GetMetrics() clabernetesapisv1alpha1.ConfigMetrics
+	// GetNativeKinds returns the native mode settings per (lowercase) containerlab kind.
+	GetNativeKinds() map[string]clabernetesapisv1alpha1.ConfigNativeKind
 }
 
 type manager struct {
@@ -191,6 +225,10 @@ func (m *manager) Start() error {
 			)
 
 			found = false
+
+			featureGates, _ := ResolveFeatureGates(nil)
+
+			m.logger.Infof("feature gates: %s", FormatFeatureGates(featureGates))
 		} else {
 			m.logger.Criticalf("encountered error fetching global config, err: %s", err)
 
@@ -250,6 +288,14 @@ func (m *manager) load(config *clabernetesapisv1alpha1.Config) {
 		}
 	}
 
+	featureGates, unknownFeatureGates := ResolveFeatureGates(newConfig.FeatureGates)
+
+	for _, unknownFeatureGate := range unknownFeatureGates {
+		m.logger.Warnf("ignoring unknown feature gate '%s'", unknownFeatureGate)
+	}
+
+	m.logger.Infof("feature gates: %s", FormatFeatureGates(featureGates))
+
 	m.config = newConfig
 }
 
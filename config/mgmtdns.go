@@ -0,0 +1,26 @@
+package config
+
+import (
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+// ResolveMgmtDNS returns the given mgmt dns settings with the defaults applied for whatever is
+// unset -- no provider, "<node>.<topology>.<namespace>.clab" records and a 300 second ttl.
+func ResolveMgmtDNS(
+	mgmtDNS clabernetesapisv1alpha1.ConfigMgmtDNS,
+) clabernetesapisv1alpha1.ConfigMgmtDNS {
+	if mgmtDNS.Provider == "" {
+		mgmtDNS.Provider = clabernetesconstants.MgmtDNSProviderNone
+	}
+
+	if mgmtDNS.DomainTemplate == "" {
+		mgmtDNS.DomainTemplate = clabernetesconstants.MgmtDNSDomainTemplateDefault
+	}
+
+	if mgmtDNS.TTL == 0 {
+		mgmtDNS.TTL = clabernetesconstants.MgmtDNSTTLDefault
+	}
+
+	return mgmtDNS
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+)
+
+// ResolveNativeKinds returns a copy of the given native kind settings keyed by the lowercase kind,
+// so they can be looked up the same way the built-in native mode kind drivers are.
+func ResolveNativeKinds(
+	nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind,
+) map[string]clabernetesapisv1alpha1.ConfigNativeKind {
+	outNativeKinds := make(map[string]clabernetesapisv1alpha1.ConfigNativeKind, len(nativeKinds))
+
+	for kind, nativeKind := range nativeKinds {
+		outNativeKinds[strings.ToLower(strings.TrimSpace(kind))] = *nativeKind.DeepCopy()
+	}
+
+	return outNativeKinds
+}
@@ -33,6 +33,37 @@ const (
 	// SlurpeethServicePort is the port number for slurpeeth that we use in the kubernetes service.
 	SlurpeethServicePort = 4799
 
+	// GeneveServicePort is the UDP destination port used for per-link Geneve tunnels. Note that
+	// this is *not* the IANA assigned 6081 -- for the same reasons outlined for the
+	// VXLANServicePort we use one of the ports that Arista cEOS allows by default.
+	GeneveServicePort = 7784
+
+	// WireGuardServicePort is the UDP port the launcher WireGuard interface listens on. As with
+	// the other tunnel ports we use one of the ports that Arista cEOS allows by default.
+	WireGuardServicePort = 4784
+
+	// WireGuardOverlayCIDR is the default range the launchers of a topology using wireguard
+	// connectivity get their overlay addresses from.
+	WireGuardOverlayCIDR = "10.254.0.0/16"
+
+	// SelfTestServicePort is the TCP port the launcher data-plane self-test server listens on when
+	// the self-test is enabled -- this is the port iperf uses by default.
+	SelfTestServicePort = 5201
+
+	// LivenessServicePort is the UDP port the launchers answer the tunnel liveness probes of their
+	// peers on.
+	LivenessServicePort = 5202
+
+	// MetricsServicePort is the TCP port the launchers serve their (prometheus) metrics -- the
+	// traffic counters of their links -- on.
+	MetricsServicePort = 9102
+
+	// MetricsPortName is the name of the launcher container port the metrics are served on.
+	MetricsPortName = "metrics"
+
+	// NTPServicePort is the UDP port of the (optional) topology ntp server service.
+	NTPServicePort = 123
+
 	// TCP is... TCP.
 	TCP = "TCP"
 
@@ -49,4 +80,33 @@ const (
 
 	// HostKeyword is the containerlab reserved keyword to define host links endpoints.
 	HostKeyword = "host"
+
+	// BridgeKind is the containerlab kind for (linux) bridges -- nodes of this kind are shared l2
+	// segments that any number of other nodes link to rather than "real" nodes.
+	BridgeKind = "bridge"
+
+	// WireTapSideA is the name of the side of a wire tap pod towards the alphabetically first
+	// endpoint of the tapped link, this is also the name of the interface in the wire tap pod
+	// that carries the traffic of that side.
+	WireTapSideA = "a"
+
+	// WireTapSideB is the name of the side of a wire tap pod towards the other endpoint of the
+	// tapped link, see WireTapSideA.
+	WireTapSideB = "b"
+)
+
+const (
+	// TimelineSourceController is the timeline event source for events originating from the
+	// controller itself.
+	TimelineSourceController = "controller"
+
+	// TimelineSourceLauncher is the timeline event source for events reported by the launchers.
+	TimelineSourceLauncher = "launcher"
+
+	// TimelineSourceProbe is the timeline event source for events reported by the status probes.
+	TimelineSourceProbe = "probe"
+
+	// TimelineMaxEvents is the number of events kept in a topology timeline, once reached the
+	// oldest events are dropped.
+	TimelineMaxEvents = 100
 )
@@ -40,6 +40,45 @@ const (
 	ManagerNamespaceEnv = "MANAGER_NAMESPACE"
 )
 
+const (
+	// ManagerCacheStrategyEnv is the env var that holds the strategy the manager scopes its
+	// (informer) cache with, one of the ManagerCacheStrategy values -- when unset the manager uses
+	// ManagerCacheStrategyFiltered.
+	ManagerCacheStrategyEnv = "MANAGER_CACHE_STRATEGY"
+
+	// ManagerCacheUnfilteredKindsEnv is the env var that holds the (comma separated) kinds the
+	// manager caches regardless of labels with the filtered cache strategy -- either just the kind
+	// ("ConfigMap") or the kind and its api group ("Deployment.apps").
+	ManagerCacheUnfilteredKindsEnv = "MANAGER_CACHE_UNFILTERED_KINDS"
+
+	// ManagerCacheNamespacesEnv is the env var that holds the (comma separated) namespaces the
+	// manager caches with the namespaced cache strategy, in addition to its own namespace.
+	ManagerCacheNamespacesEnv = "MANAGER_CACHE_NAMESPACES"
+
+	// ManagerNamespaceScopedEnv is the env var that, when "true", makes the manager watch and
+	// manage only topologies in its own namespace -- in this mode the manager only needs
+	// namespaced (Role) permissions, so it does not install crds (those must exist already), does
+	// not look at cluster nodes, and binds launchers to a launcher Role rather than ClusterRole.
+	ManagerNamespaceScopedEnv = "MANAGER_NAMESPACE_SCOPED"
+)
+
+const (
+	// ManagerCacheStrategyFiltered caches clabernetes resources in all namespaces, but any other
+	// objects only if they carry the clabernetes app label (plus the secrets in the manager
+	// namespace, and any kinds listed in ManagerCacheUnfilteredKindsEnv). Lowest memory use, but
+	// unlabeled objects (for example user ConfigMaps) are invisible to cached reads.
+	ManagerCacheStrategyFiltered = "filtered"
+
+	// ManagerCacheStrategyNamespaced caches all objects regardless of labels, but only in the
+	// namespaces listed in ManagerCacheNamespacesEnv (and the manager namespace) -- topologies in
+	// other namespaces are not seen at all.
+	ManagerCacheStrategyNamespaced = "namespaced"
+
+	// ManagerCacheStrategyUnfiltered caches all objects in all namespaces -- always correct, but
+	// uses the most memory on big clusters.
+	ManagerCacheStrategyUnfiltered = "unfiltered"
+)
+
 const (
 	// LauncherLoggerLevelEnv is the environment variable name that can be used to set the
 	// clabernetes launcher logger level.
@@ -93,6 +132,35 @@ const (
 	// should run (vxlan/slurpeeth).
 	LauncherConnectivityKind = "LAUNCHER_CONNECTIVITY_KIND"
 
+	// LauncherLinkConnectivityKindsEnv is the env var that holds the (comma separated) connectivity
+	// flavors the links of the topology use on top of the LauncherConnectivityKind (see the
+	// topology linkConnectivity) -- the launcher runs a connectivity manager for each of them.
+	LauncherLinkConnectivityKindsEnv = "LAUNCHER_LINK_CONNECTIVITY_KINDS"
+
+	// LauncherVXLANPortEnv is the env var that holds the vxlan port override for the launcher --
+	// when unset the launcher uses the default VXLANServicePort.
+	LauncherVXLANPortEnv = "LAUNCHER_VXLAN_PORT"
+
+	// LauncherSlurpeethPortEnv is the env var that holds the slurpeeth port override for the
+	// launcher -- when unset the launcher uses the default SlurpeethServicePort.
+	LauncherSlurpeethPortEnv = "LAUNCHER_SLURPEETH_PORT"
+
+	// LauncherGenevePortEnv is the env var that holds the geneve port override for the launcher --
+	// when unset the launcher uses the default GeneveServicePort.
+	LauncherGenevePortEnv = "LAUNCHER_GENEVE_PORT"
+
+	// LauncherWireGuardPortEnv is the env var that holds the wireguard port override for the
+	// launcher -- when unset the launcher uses the default WireGuardServicePort.
+	LauncherWireGuardPortEnv = "LAUNCHER_WIREGUARD_PORT"
+
+	// LauncherWireGuardOverlayCIDREnv is the env var that holds the wireguard overlay range
+	// override for the launcher -- when unset the launcher uses the default WireGuardOverlayCIDR.
+	LauncherWireGuardOverlayCIDREnv = "LAUNCHER_WIREGUARD_OVERLAY_CIDR"
+
+	// LauncherSlurpeethTLSEnv is the env var that tells the launcher to wrap the slurpeeth
+	// connectivity in mutual tls using the certificates mounted at LauncherSlurpeethTLSPath.
+	LauncherSlurpeethTLSEnv = "LAUNCHER_SLURPEETH_TLS"
+
 	// LauncherTunnelsFileEnv is an optional env var that points to a file containing the
 	// per-node tunnels (JSON array of PointToPointTunnel objects). This is used to avoid
 	// requiring Kubernetes API connectivity from inside the launcher at runtime (native mode
@@ -103,6 +171,62 @@ const (
 	// mode (sidecar).
 	LauncherNativeModeEnv = "LAUNCHER_NATIVE_MODE"
 
+	// LauncherPortExposureModeEnv is the env var that holds the port exposure mode of the launcher
+	// -- when unset ports are exposed via containerlab (docker) port mappings.
+	LauncherPortExposureModeEnv = "LAUNCHER_PORT_EXPOSURE_MODE"
+
+	// LauncherHostRequirementsEnv is the env var that holds the (json encoded) topology host
+	// requirements the launcher verifies before starting its node -- when unset the launcher only
+	// checks the built-in requirements for the kind of its node.
+	LauncherHostRequirementsEnv = "LAUNCHER_HOST_REQUIREMENTS"
+
+	// LauncherDiskPressureEnv is the env var that holds the (json encoded) topology disk pressure
+	// thresholds -- when unset the launcher uses the default thresholds.
+	LauncherDiskPressureEnv = "LAUNCHER_DISK_PRESSURE"
+
+	// LauncherStaticRoutesEnv is the env var that holds the (json encoded) static routes the
+	// launcher adds to the pod network namespace before starting its node.
+	LauncherStaticRoutesEnv = "LAUNCHER_STATIC_ROUTES"
+
+	// LauncherUnderlayInterfaceEnv is the env var that holds the name of the dedicated underlay
+	// interface the launcher sends its tunnel traffic over -- when unset tunnels use the pod
+	// network.
+	LauncherUnderlayInterfaceEnv = "LAUNCHER_UNDERLAY_INTERFACE"
+
+	// LauncherConfigPushFileEnv is the env var that holds the path of the (live updated)
+	// startup-config the launcher watches to push changes to its node -- when unset the
+	// differential config push is disabled.
+	LauncherConfigPushFileEnv = "LAUNCHER_CONFIG_PUSH_FILE"
+
+	// LauncherStartupConfigReapplyFileEnv is the env var that holds the path of the (live updated)
+	// sub-topology the launcher watches to push changes of the inline startup-config of its node
+	// -- when unset the startup-config reapply is disabled.
+	LauncherStartupConfigReapplyFileEnv = "LAUNCHER_STARTUP_CONFIG_REAPPLY_FILE"
+
+	// LauncherNTPServerEnv is the env var that holds the address of the topology ntp server the
+	// launcher points its node at -- when unset the topology has no ntp server.
+	LauncherNTPServerEnv = "LAUNCHER_NTP_SERVER"
+
+	// LauncherSelfTestDurationEnv is the env var that holds the duration (in seconds) of the
+	// throughput part of the data-plane self-test -- when unset the self-test is disabled.
+	LauncherSelfTestDurationEnv = "LAUNCHER_SELF_TEST_DURATION"
+
+	// LauncherTrafficCountersIntervalEnv is the env var that holds the interval (in seconds) at
+	// which the launcher reports the traffic counters of its tunnels -- when unset traffic counters
+	// are disabled.
+	LauncherTrafficCountersIntervalEnv = "LAUNCHER_TRAFFIC_COUNTERS_INTERVAL"
+
+	// LauncherConnectivityUpdateBatchWindowEnv is the env var that holds how long (in seconds) the
+	// launcher collects changes to its tunnels before applying them -- when unset the launcher
+	// uses a two second window.
+	LauncherConnectivityUpdateBatchWindowEnv = "LAUNCHER_CONNECTIVITY_UPDATE_BATCH_WINDOW"
+
+	// LauncherConnectivityUpdateJitterEnv is the env var that holds the upper bound (in seconds) of
+	// the random delay the launcher adds before applying changes to its tunnels, spreading the
+	// tunnel churn of a topology edit across the launchers -- when unset the launcher uses up to
+	// five seconds, zero disables the jitter.
+	LauncherConnectivityUpdateJitterEnv = "LAUNCHER_CONNECTIVITY_UPDATE_JITTER"
+
 	// LauncherContainerlabVersion is the env var that holds the possibly user specified version of
 	// containerlab to download and use in the launcher.
 	LauncherContainerlabVersion = "LAUNCHER_CONTAINERLAB_VERSION"
@@ -122,6 +246,30 @@ const (
 	// LauncherSSHProbePassword is the env var that holds the password to use in the ssh probe (if
 	// configured).
 	LauncherSSHProbePassword = "LAUNCHER_SSH_PROBE_PASSWORD" //nolint:gosec
+
+	// LauncherCLIProbeEnabled is the env var that tells the launcher to run the cli probe, any of
+	// the cli probe settings that are not set are defaulted based on the kind of the node.
+	LauncherCLIProbeEnabled = "LAUNCHER_CLI_PROBE_ENABLED"
+
+	// LauncherCLIProbeCommand is the env var that holds the command to run in the cli probe (if
+	// configured).
+	LauncherCLIProbeCommand = "LAUNCHER_CLI_PROBE_COMMAND"
+
+	// LauncherCLIProbeExpectedOutput is the env var that holds the output the cli probe command
+	// must contain (if configured).
+	LauncherCLIProbeExpectedOutput = "LAUNCHER_CLI_PROBE_EXPECTED_OUTPUT"
+
+	// LauncherCLIProbePort is the env var that holds the port to use in the cli probe (if
+	// configured).
+	LauncherCLIProbePort = "LAUNCHER_CLI_PROBE_PORT"
+
+	// LauncherCLIProbeUsername is the env var that holds the username to use in the cli probe (if
+	// configured).
+	LauncherCLIProbeUsername = "LAUNCHER_CLI_PROBE_USERNAME"
+
+	// LauncherCLIProbePassword is the env var that holds the password to use in the cli probe (if
+	// configured).
+	LauncherCLIProbePassword = "LAUNCHER_CLI_PROBE_PASSWORD" //nolint:gosec
 )
 
 const (
@@ -155,3 +303,27 @@ const (
 	// ClickerGlobalLabels -- see also ClickerGlobalAnnotations -- same thing just for labels.
 	ClickerGlobalLabels = "CLICKER_GLOBAL_LABELS"
 )
+
+const (
+	// CleanupLoggerLevelEnv is the environment variable name that can be used to set the cleanup
+	// logger level.
+	CleanupLoggerLevelEnv = "CLEANUP_LOGGER_LEVEL"
+)
+
+const (
+	// WireTapSideATunnelIDEnv is the env var that holds the vxlan id of the tunnel of the "a" side
+	// of a wire tap pod.
+	WireTapSideATunnelIDEnv = "WIRE_TAP_A_TUNNEL_ID"
+
+	// WireTapSideADestinationEnv is the env var that holds the destination (the fabric service of
+	// the launcher of the node) of the tunnel of the "a" side of a wire tap pod.
+	WireTapSideADestinationEnv = "WIRE_TAP_A_DESTINATION"
+
+	// WireTapSideBTunnelIDEnv is the env var that holds the vxlan id of the tunnel of the "b" side
+	// of a wire tap pod.
+	WireTapSideBTunnelIDEnv = "WIRE_TAP_B_TUNNEL_ID"
+
+	// WireTapSideBDestinationEnv is the env var that holds the destination of the tunnel of the
+	// "b" side of a wire tap pod.
+	WireTapSideBDestinationEnv = "WIRE_TAP_B_DESTINATION"
+)
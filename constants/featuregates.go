@@ -0,0 +1,21 @@
+package constants
+
+const (
+	// FeatureGateCanonicalConfigHash is the feature gate that controls whether the config hash of a
+	// topology is computed over the canonical form of its rendered sub-topologies -- when enabled
+	// definition updates that only reorder content do not restart nodes.
+	FeatureGateCanonicalConfigHash = "CanonicalConfigHash"
+
+	// FeatureGateNativeKindDrivers is the feature gate that controls whether the per kind native
+	// mode handlers (sr linux, sr os, vjunos, xrd, ...) prepare native mode nos containers.
+	FeatureGateNativeKindDrivers = "NativeKindDrivers"
+
+	// FeatureGateDiskPressureEviction is the feature gate that controls whether launcher pods that
+	// gave up due to disk pressure are evicted (deleted) by the controller.
+	FeatureGateDiskPressureEviction = "DiskPressureEviction"
+
+	// FeatureGateStableNetworkAttachmentNames is the feature gate that controls whether the multus
+	// network attachment definitions of links are named after the (sorted) endpoints of the link
+	// rather than the index of the link -- stable names survive links being added or removed.
+	FeatureGateStableNetworkAttachmentNames = "StableNetworkAttachmentNames"
+)
@@ -0,0 +1,39 @@
+package constants
+
+const (
+	// ImageScannerNone is the image scanner that disables image scanning.
+	ImageScannerNone = "none"
+
+	// ImageScannerTrivy is the image scanner that scans images with a trivy scanner adapter (a
+	// scanner speaking the harbor pluggable scanner api, like harbor-scanner-trivy).
+	ImageScannerTrivy = "trivy"
+
+	// ImageScannerHarbor is the image scanner that queries the scan overview of images hosted in a
+	// harbor registry.
+	ImageScannerHarbor = "harbor"
+
+	// ImageScanPolicyWarn is the image scanning policy that only reports images that failed the
+	// scan (or could not be scanned).
+	ImageScanPolicyWarn = "warn"
+
+	// ImageScanPolicyBlock is the image scanning policy that does not deploy nodes whose image
+	// failed the scan (or could not be scanned) yet.
+	ImageScanPolicyBlock = "block"
+
+	// ImageScanVerdictPassed is the verdict of an image without vulnerabilities at or above the
+	// severity threshold.
+	ImageScanVerdictPassed = "passed"
+
+	// ImageScanVerdictFailed is the verdict of an image with vulnerabilities at or above the
+	// severity threshold.
+	ImageScanVerdictFailed = "failed"
+
+	// ImageScanVerdictPending is the verdict of an image whose scan is not finished yet.
+	ImageScanVerdictPending = "pending"
+
+	// ImageScanVerdictError is the verdict of an image that could not be scanned.
+	ImageScanVerdictError = "error"
+
+	// ImageScanSeverityCritical is the default image scanning severity threshold.
+	ImageScanSeverityCritical = "CRITICAL"
+)
@@ -4,6 +4,9 @@ const (
 	// KubernetesConfigMap is a const to use for "configmap".
 	KubernetesConfigMap = "configmap"
 
+	// KubernetesSecret is a const to use for "secret".
+	KubernetesSecret = "secret"
+
 	// KubernetesService is a const to use for "service".
 	KubernetesService = "service"
 
@@ -12,6 +15,28 @@ const (
 
 	// KubernetesDeployment is a const to use for "deployment".
 	KubernetesDeployment = "deployment"
+
+	// KubernetesStatefulSet is a const to use for "statefulset".
+	KubernetesStatefulSet = "statefulset"
+
+	// KubernetesNetworkPolicy is a const to use for "networkpolicy".
+	KubernetesNetworkPolicy = "networkpolicy"
+
+	// KubernetesCiliumNetworkPolicy is a const to use for "ciliumnetworkpolicy".
+	KubernetesCiliumNetworkPolicy = "ciliumnetworkpolicy"
+
+	// KubernetesDNSEndpoint is a const to use for "dnsendpoint".
+	KubernetesDNSEndpoint = "dnsendpoint"
+
+	// KubernetesPodMonitor is a const to use for "podmonitor".
+	KubernetesPodMonitor = "podmonitor"
+)
+
+const (
+	// KubernetesKindDeployment/KubernetesKindStatefulSet are the kinds of workload launchers can
+	// run as.
+	KubernetesKindDeployment  = "Deployment"
+	KubernetesKindStatefulSet = "StatefulSet"
 )
 
 const (
@@ -44,4 +69,27 @@ const (
 	// LauncherCRISockPath is the path where, if configured, the CRI sock is mounted in launcher
 	// pods.
 	LauncherCRISockPath = "/clabernetes/.node"
+
+	// LauncherWireGuardPath is the path where the WireGuard private key of the node is mounted in
+	// launcher pods when using the "wireguard" connectivity flavor.
+	LauncherWireGuardPath = "/clabernetes/.wireguard"
+
+	// LauncherWireGuardPeersPath is the path where the WireGuard public keys and addresses of all
+	// the nodes are mounted in launcher pods when using the "wireguard" connectivity flavor.
+	LauncherWireGuardPeersPath = "/clabernetes/.wireguard-peers"
+
+	// LauncherSlurpeethTLSPath is the path where the slurpeeth tls ca, certificate and key are
+	// mounted in launcher pods when using "slurpeeth" connectivity with tls enabled.
+	LauncherSlurpeethTLSPath = "/clabernetes/.slurpeeth-tls"
+
+	// LauncherConfigPushPath is the path where the ConfigMap holding the startup-config of the node
+	// is (additionally) mounted in launcher pods when the differential config push is enabled --
+	// unlike the (sub path) startup-config mount this one is updated when the ConfigMap changes.
+	LauncherConfigPushPath = "/clabernetes/.config-push"
+
+	// LauncherLiveTopologyPath is the path where the ConfigMap holding the sub-topologies of the
+	// topology is (additionally) mounted in launcher pods when the startup-config reapply is
+	// enabled -- unlike the (sub path) topo.clab.yaml mount this one is updated when the ConfigMap
+	// changes.
+	LauncherLiveTopologyPath = "/clabernetes/.live-topology"
 )
@@ -29,6 +29,19 @@ const (
 	// is -- that is, it is either a "connectivity" service, or an "expose" service; note that
 	// this is strictly a clabernetes concept, obviously not a kubernetes one!
 	LabelTopologyServiceType = "clabernetes/topologyServiceType"
+
+	// LabelPropagatedPullSecret is the label set on image pull secrets that clabernetes copied into
+	// a topology namespace -- the value is the namespace of the source secret.
+	LabelPropagatedPullSecret = "clabernetes/propagatedPullSecret"
+
+	// LabelWireTapTopology is the label indicating the topology a wire tap pod (and its service)
+	// belongs to -- wire taps are not nodes, so they do not carry the topology owner label.
+	LabelWireTapTopology = "clabernetes/wireTapTopology"
+
+	// LabelMgmtDNS is the label set (to "true") on the resources holding the dns records of the
+	// management interfaces of the nodes of a topology, so the CoreDNS hosts configmaps of all
+	// topologies can be picked up with a single selector.
+	LabelMgmtDNS = "clabernetes/mgmtDNS"
 )
 
 const (
@@ -50,6 +63,23 @@ const (
 	LabelClickerNodeTarget = "clabernetes/clickerNodeTarget"
 )
 
+const (
+	// LabelCapabilityScanned is a label that is set on nodes that have been scanned via the
+	// clicker capability scan -- the value is the unix timestamp that the node was scanned.
+	LabelCapabilityScanned = "clabernetes/capabilityScanned"
+	// LabelCapabilityKVM indicates whether /dev/kvm is available on the node ("true"/"false").
+	LabelCapabilityKVM = "clabernetes/capabilityKvm"
+	// LabelCapabilityNestedVirt indicates whether nested virtualization is enabled in the kvm
+	// module on the node ("true"/"false").
+	LabelCapabilityNestedVirt = "clabernetes/capabilityNestedVirt"
+	// LabelCapabilityHugepages indicates whether the node has any hugepages allocated
+	// ("true"/"false").
+	LabelCapabilityHugepages = "clabernetes/capabilityHugepages"
+	// LabelCapabilityCPUFlagPrefix is the prefix for the labels indicating if a given cpu flag
+	// (i.e. "vmx", "avx2") is present on the node ("true"/"false").
+	LabelCapabilityCPUFlagPrefix = "clabernetes/capabilityCpu-"
+)
+
 const (
 	// LabelIgnoreReconcile indicates that controller should ignore reconciling a given topology.
 	// Note that this basically ignored during deletion since our controller doest do anything in
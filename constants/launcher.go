@@ -31,9 +31,56 @@ const (
 	// ConnectivitySlurpeeth is a constant for the slurpeeth connectivity flavor.
 	ConnectivitySlurpeeth = "slurpeeth"
 
+	// ConnectivityGeneve is a constant for the geneve connectivity flavor.
+	ConnectivityGeneve = "geneve"
+
+	// ConnectivityWireGuard is a constant for the wireguard connectivity flavor.
+	ConnectivityWireGuard = "wireguard"
+
+	// ConnectivityGRE is a constant for the gre (gretap) connectivity flavor.
+	ConnectivityGRE = "gre"
+
+	// PortExposureModeContainerlab is the (default) port exposure mode where containerlab/docker
+	// publishes the mapped ports of the node(s).
+	PortExposureModeContainerlab = "containerlab"
+
+	// PortExposureModeNFTables is the port exposure mode where the launcher programs nftables dnat
+	// rules for the mapped ports of the node(s).
+	PortExposureModeNFTables = "nftables"
+
 	// ConnectivityMultus is a constant for the multus connectivity flavor.
 	ConnectivityMultus = "multus"
 
+	// ConnectivityLoopback is a constant for the loopback connectivity flavor -- launchers do not
+	// set up any tunnels but report the tunnels of their nodes as simulated, meant for ci/e2e
+	// clusters without the privileges (or kernel modules) required to create tunnels.
+	ConnectivityLoopback = "loopback"
+
+	// UnderlayInterfaceDefault is the default name of the dedicated underlay interface of the
+	// launcher pods.
+	UnderlayInterfaceDefault = "underlay0"
+
+	// NTPServerImageDefault is the default image of the (optional) topology ntp server.
+	NTPServerImageDefault = "docker.io/cturra/ntp:latest"
+
+	// NTPServerLocalClock is the "upstream" the topology ntp server uses when no upstreams are
+	// configured -- the (chrony) local reference clock, meaning it serves its own clock.
+	NTPServerLocalClock = "127.127.1.1"
+
+	// QEMUHostClockArgs are the qemu args that make the guest clock follow the host clock.
+	QEMUHostClockArgs = "-rtc base=utc,clock=host,driftfix=slew"
+
+	// QEMUAdditionalArgsEnv is the env var vrnetlab images append to their qemu command line.
+	QEMUAdditionalArgsEnv = "QEMU_ADDITIONAL_ARGS"
+
+	// MultusNetworksAnnotation is the pod annotation holding the multus networks to attach to the
+	// pod.
+	MultusNetworksAnnotation = "k8s.v1.cni.cncf.io/networks"
+
+	// MultusNetworkStatusAnnotation is the pod annotation multus reports the status (interface
+	// names and addresses) of the attached networks in.
+	MultusNetworkStatusAnnotation = "k8s.v1.cni.cncf.io/network-status"
+
 	// NodeStatusFile is the file we write the node status to for launchers -- this is also used
 	// by the deployment for startup/liveness probes.
 	NodeStatusFile = "/clabernetes/.nodestatus"
@@ -57,4 +104,124 @@ const (
 	// NodeStatusDeploymentDisabled is reported in the topology.status.nodereadiness map when the
 	// parent topology has the "clabernetes/disableDeployments" label set.
 	NodeStatusDeploymentDisabled = "deploymentDisabled"
+
+	// NodeStatusPreempted is reported in the topology.status.nodereadiness map for nodes that are
+	// not ready because their pod was preempted by the scheduler or evicted by the kubelet.
+	NodeStatusPreempted = "preempted"
+
+	// NodeStatusSuspended is reported in the topology.status.nodereadiness map for nodes of a
+	// topology that is suspended due to its schedule.
+	NodeStatusSuspended = "suspended"
+
+	// NodeStatusStalled is reported in the topology.status.nodereadiness map for nodes whose
+	// launcher pod is running but has stopped renewing its heartbeat lease -- that is, the launcher
+	// is wedged rather than just waiting on a slow booting node.
+	NodeStatusStalled = "stalled"
+
+	// NodeStatusImageBlocked is reported in the topology.status.nodereadiness map for nodes that
+	// are not deployed because the image scanning policy blocks their image.
+	NodeStatusImageBlocked = "imageBlocked"
+
+	// NodeStatusWaiting is reported in the topology.status.nodereadiness map for nodes that are
+	// not deployed yet because nodes they depend on (containerlab wait-for/stages) are not ready.
+	NodeStatusWaiting = "waiting"
+
+	// LauncherPacketCaptureDir is the directory launchers write packet captures to (and where the
+	// packet capture claim is mounted if one is configured).
+	LauncherPacketCaptureDir = "/clabernetes/captures"
+
+	// LauncherPacketCaptureVolumeName is the name of the packet capture claim volume.
+	LauncherPacketCaptureVolumeName = "packet-captures"
+
+	// TerminationMessagePath is the path launchers write their final fatal error to so that it is
+	// surfaced in the pod (container) status.
+	TerminationMessagePath = "/dev/termination-log"
+
+	// TerminationReasonImagePull is the termination reason used when a launcher could not get the
+	// node image.
+	TerminationReasonImagePull = "ImagePullFailed"
+
+	// TerminationReasonKVMMissing is the termination reason used when a node requires kvm but
+	// /dev/kvm is not available to the launcher.
+	TerminationReasonKVMMissing = "KVMMissing"
+
+	// TerminationReasonHostPreflightFailed is the termination reason used when the worker node
+	// does not meet the sysctl/kernel module requirements of a node.
+	TerminationReasonHostPreflightFailed = "HostPreflightFailed"
+
+	// HostRequirementsModeEnforce is the host requirements mode that fails the launcher when a
+	// requirement is not met, this is the default.
+	HostRequirementsModeEnforce = "enforce"
+
+	// HostRequirementsModeWarn is the host requirements mode that only logs requirements that are
+	// not met.
+	HostRequirementsModeWarn = "warn"
+
+	// TerminationReasonTunnelFailed is the termination reason used when a launcher failed setting
+	// up (or tearing down) its tunnels.
+	TerminationReasonTunnelFailed = "TunnelSetupFailed"
+
+	// TerminationReasonContainerlabDeployFailed is the termination reason used when containerlab
+	// failed to deploy the node.
+	TerminationReasonContainerlabDeployFailed = "ContainerlabDeployFailed"
+
+	// TerminationReasonLauncherFailed is the catch-all termination reason for any other launcher
+	// failure.
+	TerminationReasonLauncherFailed = "LauncherFailed"
+
+	// TerminationReasonDockerFailed is the termination reason used when the (docker mode) docker
+	// daemon of the launcher stopped responding and the launcher watchdog could not bring it back.
+	TerminationReasonDockerFailed = "DockerDaemonFailed"
+
+	// TerminationReasonNodeContainerFailed is the termination reason used when the launcher
+	// watchdog found a container launched by containerlab no longer running.
+	TerminationReasonNodeContainerFailed = "NodeContainerFailed"
+
+	// WatchdogReasonDockerRestarted is the timeline event reason for docker daemon restarts done by
+	// the launcher watchdog.
+	WatchdogReasonDockerRestarted = "DockerDaemonRestarted"
+
+	// LauncherWatchdogRestartAnnotation is the heartbeat lease annotation holding the (json encoded
+	// timeline event of the) last in place restart done by the launcher watchdog.
+	LauncherWatchdogRestartAnnotation = "clabernetes/watchdogRestart"
+
+	// TerminationReasonDiskPressure is the termination reason used when the docker data volume of a
+	// launcher stayed above the disk pressure evict threshold even after cleaning up. The
+	// controller deletes (evicts) launcher pods that terminated for this reason so the node comes
+	// back with a fresh volume.
+	TerminationReasonDiskPressure = "DiskPressure"
+
+	// LauncherDiskUsageAnnotation is the heartbeat lease annotation holding the (json encoded) disk
+	// usage of the docker data volume of the launcher.
+	LauncherDiskUsageAnnotation = "clabernetes/diskUsage"
+
+	// LauncherVersionsAnnotation is the heartbeat lease annotation holding the (json encoded)
+	// versions of the launcher, containerlab, docker and kernel the launcher runs its node(s) with.
+	LauncherVersionsAnnotation = "clabernetes/versions"
+
+	// DiskPressureDefaultWarnPercent/CleanupPercent/EvictPercent are the disk pressure thresholds
+	// (used percent of the launcher docker data volume) used when the topology does not set them.
+	DiskPressureDefaultWarnPercent    = 80
+	DiskPressureDefaultCleanupPercent = 90
+	DiskPressureDefaultEvictPercent   = 95
+
+	// TunnelStateUp is the state reported in the connectivity status for tunnels that were set up
+	// successfully.
+	TunnelStateUp = "up"
+
+	// TunnelStateDown is the state reported in the connectivity status for tunnels that failed to
+	// be set up (or were found broken).
+	TunnelStateDown = "down"
+
+	// TunnelStateUnreachable is the state reported in the connectivity status for tunnels that are
+	// set up but whose remote launcher stopped answering liveness probes.
+	TunnelStateUnreachable = "unreachable"
+
+	// TunnelStateSimulated is the state reported in the connectivity status for the tunnels of
+	// launchers using the loopback connectivity flavor, those tunnels are never actually set up.
+	TunnelStateSimulated = "simulated"
+
+	// LauncherHeartbeatLeaseDurationSeconds is the duration of the launcher heartbeat lease, if a
+	// launcher does not renew its lease within this time it is considered stalled.
+	LauncherHeartbeatLeaseDurationSeconds = 60
 )
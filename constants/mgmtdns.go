@@ -0,0 +1,21 @@
+package constants
+
+const (
+	// MgmtDNSProviderNone is the mgmt dns provider that creates no records.
+	MgmtDNSProviderNone = "none"
+
+	// MgmtDNSProviderExternalDNS is the mgmt dns provider that renders DNSEndpoints for
+	// ExternalDNS.
+	MgmtDNSProviderExternalDNS = "externalDNS"
+
+	// MgmtDNSProviderCoreDNS is the mgmt dns provider that renders ConfigMaps holding the records
+	// in hosts file format for the CoreDNS hosts plugin.
+	MgmtDNSProviderCoreDNS = "coreDNS"
+
+	// MgmtDNSDomainTemplateDefault is the default template of the name of the mgmt dns record of a
+	// node.
+	MgmtDNSDomainTemplateDefault = "{{ .NodeName }}.{{ .TopologyName }}.{{ .Namespace }}.clab"
+
+	// MgmtDNSTTLDefault is the default ttl (in seconds) of the mgmt dns records.
+	MgmtDNSTTLDefault = 300
+)
@@ -0,0 +1,14 @@
+package constants
+
+const (
+	// NetworkPolicyRendererNone is the network policy renderer that renders no network policies.
+	NetworkPolicyRendererNone = "none"
+
+	// NetworkPolicyRendererKubernetes is the network policy renderer that renders (vanilla)
+	// kubernetes NetworkPolicies.
+	NetworkPolicyRendererKubernetes = "kubernetes"
+
+	// NetworkPolicyRendererCilium is the network policy renderer that renders
+	// CiliumNetworkPolicies.
+	NetworkPolicyRendererCilium = "cilium"
+)
@@ -29,4 +29,8 @@ const (
 	// PermissionsEveryoneRead is 0444 permissions for files/directories -- everyone has read
 	// permissions.
 	PermissionsEveryoneRead = 0o444
+
+	// PermissionsOwnerRead is 0400 permissions for files/directories -- only the owner has read
+	// permissions.
+	PermissionsOwnerRead = 0o400
 )
@@ -0,0 +1,38 @@
+package topology
+
+import (
+	"fmt"
+	"regexp"
+
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+const linkBandwidthVar = "bandwidth"
+
+// linkBandwidthPattern matches the tc rate syntax the launchers accept for link bandwidths, this
+// is the same pattern the connectivity crd validates the tunnel bandwidth with.
+var linkBandwidthPattern = regexp.MustCompile(
+	`^[0-9]+(\.[0-9]+)?(bit|kbit|mbit|gbit|tbit|bps|kbps|mbps|gbps|tbps)$`,
+)
+
+// LinkBandwidth returns the bandwidth set in the "bandwidth" var of the given containerlab link,
+// for example "100mbit", or an empty string if the link has no bandwidth set.
+func LinkBandwidth(link *clabernetesutilcontainerlab.LinkDefinition) (string, error) {
+	value, ok := link.Vars[linkBandwidthVar]
+	if !ok {
+		return "", nil
+	}
+
+	bandwidth, ok := value.(string)
+	if !ok || !linkBandwidthPattern.MatchString(bandwidth) {
+		return "", fmt.Errorf(
+			"%w: link %q has invalid bandwidth %v, expected a tc rate such as \"100mbit\"",
+			claberneteserrors.ErrParse,
+			link.Endpoints,
+			value,
+		)
+	}
+
+	return bandwidth, nil
+}
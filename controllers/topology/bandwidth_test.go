@@ -0,0 +1,75 @@
+package topology_test
+
+import (
+	"errors"
+	"testing"
+
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+func TestLinkBandwidth(t *testing.T) {
+	cases := []struct {
+		name          string
+		vars          map[string]any
+		expected      string
+		expectedError error
+	}{
+		{
+			name:          "simple",
+			vars:          nil,
+			expected:      "",
+			expectedError: nil,
+		},
+		{
+			name: "bandwidth",
+			vars: map[string]any{
+				"bandwidth": "1.5gbit",
+			},
+			expected:      "1.5gbit",
+			expectedError: nil,
+		},
+		{
+			name: "invalid-unit",
+			vars: map[string]any{
+				"bandwidth": "100mb",
+			},
+			expected:      "",
+			expectedError: claberneteserrors.ErrParse,
+		},
+		{
+			name: "not-a-string",
+			vars: map[string]any{
+				"bandwidth": 100,
+			},
+			expected:      "",
+			expectedError: claberneteserrors.ErrParse,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual, err := clabernetescontrollerstopology.LinkBandwidth(
+					&clabernetesutilcontainerlab.LinkDefinition{
+						LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+							Endpoints: []string{"srl1:e1-1", "srl2:e1-1"},
+							Vars:      testCase.vars,
+						},
+					},
+				)
+				if !errors.Is(err, testCase.expectedError) {
+					clabernetestesthelper.FailOutput(t, err, testCase.expectedError)
+				}
+
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
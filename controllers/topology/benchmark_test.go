@@ -0,0 +1,209 @@
+package topology_test
+
+import (
+	"sort"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	benchmarkSpines = 4
+	benchmarkLeaves = 196
+)
+
+// processedBenchmarkTopology returns the synthetic benchmark topology (200 nodes, 784 links) and
+// its processed reconcile data.
+func processedBenchmarkTopology(
+	b *testing.B,
+) (*clabernetesapisv1alpha1.Topology, *clabernetescontrollerstopology.ReconcileData) {
+	b.Helper()
+
+	topology := clabernetestesthelper.SyntheticTopology(
+		"benchmark",
+		benchmarkSpines,
+		benchmarkLeaves,
+	)
+
+	topology.UID = "benchmark"
+
+	reconcileData := processBenchmarkTopology(b, topology)
+
+	return topology, reconcileData
+}
+
+func processBenchmarkTopology(
+	b *testing.B,
+	topology *clabernetesapisv1alpha1.Topology,
+) *clabernetescontrollerstopology.ReconcileData {
+	b.Helper()
+
+	reconcileData, err := clabernetescontrollerstopology.NewReconcileData(topology)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	processor, err := clabernetescontrollerstopology.NewDefinitionProcessor(
+		&claberneteslogging.FakeInstance{},
+		topology,
+		reconcileData,
+		clabernetesconfig.GetFakeManager,
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	err = processor.Process()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	clabernetescontrollerstopology.AllocateTunnelIDs(
+		map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{},
+		reconcileData.ResolvedTunnels,
+	)
+
+	return reconcileData
+}
+
+// benchmarkOwnerReferences returns the owner references the "real" reconciler would have set on
+// resources it created for the given topology.
+func benchmarkOwnerReferences(
+	topology *clabernetesapisv1alpha1.Topology,
+) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion: clabernetesapisv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "Topology",
+			Name:       topology.GetName(),
+			UID:        topology.GetUID(),
+		},
+	}
+}
+
+func sortedNodeNames(reconcileData *clabernetescontrollerstopology.ReconcileData) []string {
+	nodeNames := make([]string, 0, len(reconcileData.ResolvedConfigs))
+
+	for nodeName := range reconcileData.ResolvedConfigs {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	sort.Strings(nodeNames)
+
+	return nodeNames
+}
+
+// BenchmarkDefinitionProcess measures processing the topology definition into sub-topologies and
+// computing (and allocating ids for) the tunnels between them.
+func BenchmarkDefinitionProcess(b *testing.B) {
+	topology := clabernetestesthelper.SyntheticTopology(
+		"benchmark",
+		benchmarkSpines,
+		benchmarkLeaves,
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		processBenchmarkTopology(b, topology)
+	}
+}
+
+func BenchmarkDeploymentRenderAll(b *testing.B) {
+	topology, reconcileData := processedBenchmarkTopology(b)
+
+	nodeNames := sortedNodeNames(reconcileData)
+
+	reconciler := clabernetescontrollerstopology.NewDeploymentReconciler(
+		&claberneteslogging.FakeInstance{},
+		"clabernetes",
+		"clabernetes",
+		"",
+		clabernetesconfig.GetFakeManager,
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		reconciler.RenderAll(topology, reconcileData.ResolvedConfigs, nodeNames)
+	}
+}
+
+func BenchmarkDeploymentConforms(b *testing.B) {
+	topology, reconcileData := processedBenchmarkTopology(b)
+
+	nodeNames := sortedNodeNames(reconcileData)
+
+	reconciler := clabernetescontrollerstopology.NewDeploymentReconciler(
+		&claberneteslogging.FakeInstance{},
+		"clabernetes",
+		"clabernetes",
+		"",
+		clabernetesconfig.GetFakeManager,
+	)
+
+	existing := reconciler.RenderAll(topology, reconcileData.ResolvedConfigs, nodeNames)
+	rendered := reconciler.RenderAll(topology, reconcileData.ResolvedConfigs, nodeNames)
+
+	for _, deployment := range existing {
+		deployment.OwnerReferences = benchmarkOwnerReferences(topology)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		for idx := range rendered {
+			if !reconciler.Conforms(existing[idx], rendered[idx], topology.GetUID()) {
+				b.Fatal("expected rendered deployment to conform")
+			}
+		}
+	}
+}
+
+func BenchmarkConnectivityRender(b *testing.B) {
+	topology, reconcileData := processedBenchmarkTopology(b)
+
+	reconciler := clabernetescontrollerstopology.NewConnectivityReconciler(
+		&claberneteslogging.FakeInstance{},
+		clabernetesconfig.GetFakeManager,
+	)
+
+	existing := reconciler.Render(topology, reconcileData.ResolvedTunnels)
+	existing.OwnerReferences = benchmarkOwnerReferences(topology)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		rendered := reconciler.Render(topology, reconcileData.ResolvedTunnels)
+
+		if !reconciler.Conforms(existing, rendered, topology.GetUID()) {
+			b.Fatal("expected rendered connectivity to conform")
+		}
+	}
+}
+
+// BenchmarkConfigsHash measures hashing the resolved sub-topologies, which we do every reconcile
+// to figure out if anything changed.
+func BenchmarkConfigsHash(b *testing.B) {
+	_, reconcileData := processedBenchmarkTopology(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		_, _, err := clabernetesutil.HashObjectYAML(reconcileData.ResolvedConfigs)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package topology
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	ntpServerComponent = "ntp-server"
+	ntpServerPortName  = "ntp"
+)
+
+func ntpServerName(owningTopologyName string) string {
+	return fmt.Sprintf("%s-ntp", owningTopologyName)
+}
+
+// ntpServerEnabled returns true if the given topology runs a topology wide ntp server.
+func ntpServerEnabled(owningTopology *clabernetesapisv1alpha1.Topology) bool {
+	return owningTopology.Spec.ClockSync != nil && owningTopology.Spec.ClockSync.NTPServer
+}
+
+// ntpServerSelectorLabels returns the labels selecting the ntp server pod of the given topology.
+func ntpServerSelectorLabels(owningTopology *clabernetesapisv1alpha1.Topology) map[string]string {
+	name := ntpServerName(owningTopology.GetName())
+
+	// note: no topology owner label here -- the deployment/service resolvers expect everything
+	// carrying that label to belong to a node of the topology
+	return map[string]string{
+		clabernetesconstants.LabelKubernetesName: name,
+		clabernetesconstants.LabelApp:            clabernetesconstants.Clabernetes,
+		clabernetesconstants.LabelName:           name,
+		clabernetesconstants.LabelComponent:      ntpServerComponent,
+	}
+}
+
+// ResolveNTPServerAddress returns the (in cluster) dns name of the ntp server service of the given
+// topology, or an empty string if the topology has no ntp server.
+func ResolveNTPServerAddress(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	configManagerGetter clabernetesconfig.ManagerGetterFunc,
+) string {
+	if !ntpServerEnabled(owningTopology) {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"%s.%s.%s",
+		ntpServerName(owningTopology.GetName()),
+		owningTopology.GetNamespace(),
+		configManagerGetter().GetInClusterDNSSuffix(),
+	)
+}
+
+// ClockSyncReconciler is a subcomponent of the "TopologyReconciler" but is exposed for testing
+// purposes. This is the component responsible for rendering/validating the deployment and service
+// of the (optional) topology wide ntp server.
+type ClockSyncReconciler struct {
+	log                 claberneteslogging.Instance
+	configManagerGetter clabernetesconfig.ManagerGetterFunc
+}
+
+// NewClockSyncReconciler returns an instance of ClockSyncReconciler.
+func NewClockSyncReconciler(
+	log claberneteslogging.Instance,
+	configManagerGetter clabernetesconfig.ManagerGetterFunc,
+) *ClockSyncReconciler {
+	return &ClockSyncReconciler{
+		log:                 log,
+		configManagerGetter: configManagerGetter,
+	}
+}
+
+func (r *ClockSyncReconciler) renderMetadata(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) (annotations, selectorLabels, labels map[string]string) {
+	annotations, globalLabels := r.configManagerGetter().GetAllMetadata()
+
+	selectorLabels = ntpServerSelectorLabels(owningTopology)
+
+	labels = map[string]string{}
+
+	for k, v := range selectorLabels {
+		labels[k] = v
+	}
+
+	for k, v := range globalLabels {
+		labels[k] = v
+	}
+
+	return annotations, selectorLabels, labels
+}
+
+// RenderDeployment renders the deployment of the ntp server of the given topology.
+func (r *ClockSyncReconciler) RenderDeployment(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) *k8sappsv1.Deployment {
+	annotations, selectorLabels, labels := r.renderMetadata(owningTopology)
+
+	clockSync := owningTopology.Spec.ClockSync
+
+	image := clockSync.Image
+	if image == "" {
+		image = clabernetesconstants.NTPServerImageDefault
+	}
+
+	upstreams := clockSync.Upstreams
+	if len(upstreams) == 0 {
+		upstreams = []string{clabernetesconstants.NTPServerLocalClock}
+	}
+
+	replicas := int32(1)
+	if owningTopology.Status.Suspended {
+		replicas = 0
+	}
+
+	return &k8sappsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ntpServerName(owningTopology.GetName()),
+			Namespace:   owningTopology.GetNamespace(),
+			Annotations: annotations,
+			Labels:      labels,
+		},
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas:             clabernetesutil.ToPointer(replicas),
+			RevisionHistoryLimit: clabernetesutil.ToPointer(int32(0)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			Template: k8scorev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+					Labels:      labels,
+				},
+				Spec: k8scorev1.PodSpec{
+					Containers: []k8scorev1.Container{
+						{
+							Name:  ntpServerPortName,
+							Image: image,
+							Ports: []k8scorev1.ContainerPort{
+								{
+									Name:          ntpServerPortName,
+									ContainerPort: clabernetesconstants.NTPServicePort,
+									Protocol:      clabernetesconstants.UDP,
+								},
+							},
+							Env: []k8scorev1.EnvVar{
+								{
+									Name:  "NTP_SERVERS",
+									Value: strings.Join(upstreams, ","),
+								},
+							},
+							ImagePullPolicy: k8scorev1.PullIfNotPresent,
+						},
+					},
+					RestartPolicy: k8scorev1.RestartPolicyAlways,
+				},
+			},
+		},
+	}
+}
+
+// RenderService renders the service of the ntp server of the given topology.
+func (r *ClockSyncReconciler) RenderService(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) *k8scorev1.Service {
+	annotations, selectorLabels, labels := r.renderMetadata(owningTopology)
+
+	return &k8scorev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ntpServerName(owningTopology.GetName()),
+			Namespace:   owningTopology.GetNamespace(),
+			Annotations: annotations,
+			Labels:      labels,
+		},
+		Spec: k8scorev1.ServiceSpec{
+			Ports: []k8scorev1.ServicePort{
+				{
+					Name:     ntpServerPortName,
+					Protocol: clabernetesconstants.UDP,
+					Port:     clabernetesconstants.NTPServicePort,
+					TargetPort: intstr.IntOrString{
+						IntVal: clabernetesconstants.NTPServicePort,
+					},
+				},
+			},
+			Selector: selectorLabels,
+			Type:     k8scorev1.ServiceTypeClusterIP,
+		},
+	}
+}
+
+// ConformsDeployment checks if the existingDeployment conforms with the renderedDeployment.
+func (r *ClockSyncReconciler) ConformsDeployment(
+	existingDeployment,
+	renderedDeployment *k8sappsv1.Deployment,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !reflect.DeepEqual(existingDeployment.Spec.Replicas, renderedDeployment.Spec.Replicas) {
+		return false
+	}
+
+	existingContainers := existingDeployment.Spec.Template.Spec.Containers
+	renderedContainers := renderedDeployment.Spec.Template.Spec.Containers
+
+	if len(existingContainers) != len(renderedContainers) {
+		return false
+	}
+
+	for idx := range renderedContainers {
+		if existingContainers[idx].Image != renderedContainers[idx].Image {
+			return false
+		}
+
+		if !reflect.DeepEqual(existingContainers[idx].Env, renderedContainers[idx].Env) {
+			return false
+		}
+	}
+
+	return clockSyncMetadataConforms(
+		existingDeployment.ObjectMeta,
+		renderedDeployment.ObjectMeta,
+		expectedOwnerUID,
+	)
+}
+
+// ConformsService checks if the existingService conforms with the renderedService.
+func (r *ClockSyncReconciler) ConformsService(
+	existingService,
+	renderedService *k8scorev1.Service,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !reflect.DeepEqual(existingService.Spec.Ports, renderedService.Spec.Ports) {
+		return false
+	}
+
+	if !reflect.DeepEqual(existingService.Spec.Selector, renderedService.Spec.Selector) {
+		return false
+	}
+
+	return clockSyncMetadataConforms(
+		existingService.ObjectMeta,
+		renderedService.ObjectMeta,
+		expectedOwnerUID,
+	)
+}
+
+func clockSyncMetadataConforms(
+	existingMeta,
+	renderedMeta metav1.ObjectMeta,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
+		existingMeta.Annotations,
+		renderedMeta.Annotations,
+	) {
+		return false
+	}
+
+	if !clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
+		existingMeta.Labels,
+		renderedMeta.Labels,
+	) {
+		return false
+	}
+
+	if len(existingMeta.OwnerReferences) != 1 {
+		// we should have only one owner reference, the topology
+		return false
+	}
+
+	return existingMeta.OwnerReferences[0].UID == expectedOwnerUID
+}
+
+// applyHostClock appends the qemu host clock args to the env of the qemu based (vrnetlab) nodes of
+// the given containerlab topology if the owning topology asks for host clock passthrough. Doing
+// this on the definition means the env ends up on the nodes in docker and in native mode alike.
+func (p *containerlabDefinitionProcessor) applyHostClock(
+	clabTopo *clabernetesutilcontainerlab.Topology,
+) {
+	clockSync := p.topology.Spec.ClockSync
+	if clockSync == nil || !clockSync.HostClock {
+		return
+	}
+
+	for nodeName, nodeDefinition := range clabTopo.Nodes {
+		if !strings.Contains(clabTopo.GetNodeImage(nodeName), "vrnetlab") {
+			continue
+		}
+
+		// node env is merged over kind and default env by containerlab, so start from whatever
+		// (most specific) args the node would otherwise end up with
+		existingArgs := clabTopo.Defaults.Env[clabernetesconstants.QEMUAdditionalArgsEnv]
+
+		nodeKind, _ := clabTopo.GetNodeKindType(nodeName)
+
+		kindDefinition, ok := clabTopo.Kinds[nodeKind]
+		if ok && kindDefinition.Env[clabernetesconstants.QEMUAdditionalArgsEnv] != "" {
+			existingArgs = kindDefinition.Env[clabernetesconstants.QEMUAdditionalArgsEnv]
+		}
+
+		if nodeDefinition.Env[clabernetesconstants.QEMUAdditionalArgsEnv] != "" {
+			existingArgs = nodeDefinition.Env[clabernetesconstants.QEMUAdditionalArgsEnv]
+		}
+
+		if nodeDefinition.Env == nil {
+			nodeDefinition.Env = map[string]string{}
+		}
+
+		if strings.Contains(existingArgs, "-rtc") {
+			// user brought their own clock settings, leave them be
+			continue
+		}
+
+		nodeDefinition.Env[clabernetesconstants.QEMUAdditionalArgsEnv] = strings.TrimSpace(
+			fmt.Sprintf("%s %s", existingArgs, clabernetesconstants.QEMUHostClockArgs),
+		)
+	}
+}
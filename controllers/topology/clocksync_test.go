@@ -0,0 +1,122 @@
+package topology_test
+
+import (
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveNTPServerAddress(t *testing.T) {
+	cases := []struct {
+		name      string
+		clockSync *clabernetesapisv1alpha1.ClockSync
+		expected  string
+	}{
+		{
+			name:      "unset",
+			clockSync: nil,
+			expected:  "",
+		},
+		{
+			name: "host-clock-only",
+			clockSync: &clabernetesapisv1alpha1.ClockSync{
+				HostClock: true,
+			},
+			expected: "",
+		},
+		{
+			name: "ntp-server",
+			clockSync: &clabernetesapisv1alpha1.ClockSync{
+				NTPServer: true,
+			},
+			expected: "test-clock-ntp.nowhere.svc.cluster.local",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-clock",
+						Namespace: "nowhere",
+					},
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						ClockSync: testCase.clockSync,
+					},
+				}
+
+				actual := clabernetescontrollerstopology.ResolveNTPServerAddress(
+					owningTopology,
+					clabernetesconfig.GetFakeManager,
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+// TestRenderClockSync ensures the ntp server deployment and service select the same pods and that
+// the ntp server falls back to serving its local clock when no upstreams are configured.
+func TestRenderClockSync(t *testing.T) {
+	reconciler := clabernetescontrollerstopology.NewClockSyncReconciler(
+		&claberneteslogging.FakeInstance{},
+		clabernetesconfig.GetFakeManager,
+	)
+
+	owningTopology := &clabernetesapisv1alpha1.Topology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-clock",
+			Namespace: "nowhere",
+		},
+		Spec: clabernetesapisv1alpha1.TopologySpec{
+			ClockSync: &clabernetesapisv1alpha1.ClockSync{
+				NTPServer: true,
+			},
+		},
+	}
+
+	renderedDeployment := reconciler.RenderDeployment(owningTopology)
+	renderedService := reconciler.RenderService(owningTopology)
+
+	if renderedDeployment.Name != "test-clock-ntp" {
+		clabernetestesthelper.FailOutput(t, renderedDeployment.Name, "test-clock-ntp")
+	}
+
+	for k, v := range renderedService.Spec.Selector {
+		if renderedDeployment.Spec.Template.Labels[k] != v {
+			clabernetestesthelper.FailOutput(t, renderedDeployment.Spec.Template.Labels[k], v)
+		}
+	}
+
+	container := renderedDeployment.Spec.Template.Spec.Containers[0]
+
+	if container.Image != "docker.io/cturra/ntp:latest" {
+		clabernetestesthelper.FailOutput(t, container.Image, "docker.io/cturra/ntp:latest")
+	}
+
+	if container.Env[0].Value != "127.127.1.1" {
+		clabernetestesthelper.FailOutput(t, container.Env[0].Value, "127.127.1.1")
+	}
+
+	owningTopology.Spec.ClockSync.Upstreams = []string{"time1.example.com", "time2.example.com"}
+
+	container = reconciler.RenderDeployment(owningTopology).Spec.Template.Spec.Containers[0]
+
+	if container.Env[0].Value != "time1.example.com,time2.example.com" {
+		clabernetestesthelper.FailOutput(
+			t,
+			container.Env[0].Value,
+			"time1.example.com,time2.example.com",
+		)
+	}
+}
@@ -0,0 +1,151 @@
+package topology
+
+import (
+	"maps"
+	"path/filepath"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+// ResolveConfigPushFile returns the FilesFromConfigMap entry that holds the startup-config of the
+// given node if the differential config push is enabled for the topology, or nil if it is not (or
+// the startup-config of the node does not come from a ConfigMap key). Only startup-configs mounted
+// from a ConfigMap key are considered since those are the only ones the launcher can see change.
+func ResolveConfigPushFile(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	nodeName string,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) *clabernetesapisv1alpha1.FileFromConfigMap {
+	if !owningTopology.Spec.Deployment.DifferentialConfigPush || ResolveNativeMode(owningTopology) {
+		return nil
+	}
+
+	nodeConfig, ok := clabernetesConfigs[nodeName]
+	if !ok || nodeConfig == nil || nodeConfig.Topology == nil {
+		return nil
+	}
+
+	nodeDef, ok := nodeConfig.Topology.Nodes[nodeName]
+	if !ok || nodeDef == nil {
+		return nil
+	}
+
+	startupConfigPath := strings.TrimSpace(nodeDef.StartupConfig)
+	if startupConfigPath == "" {
+		return nil
+	}
+
+	for _, fileFromConfigMap := range owningTopology.Spec.Deployment.FilesFromConfigMap[nodeName] {
+		if fileFromConfigMap.ConfigMapName == "" || fileFromConfigMap.ConfigMapPath == "" {
+			continue
+		}
+
+		if filepath.Clean(strings.TrimSpace(fileFromConfigMap.FilePath)) !=
+			filepath.Clean(startupConfigPath) {
+			continue
+		}
+
+		return &fileFromConfigMap
+	}
+
+	return nil
+}
+
+// ResolveStartupConfigReapply returns true if changes of the inline startup-config of the nodes of
+// the given topology are pushed to the running nodes by their launchers rather than restarting
+// the nodes.
+func ResolveStartupConfigReapply(owningTopology *clabernetesapisv1alpha1.Topology) bool {
+	return owningTopology.Spec.Deployment.StartupConfigReapply && !ResolveNativeMode(owningTopology)
+}
+
+// OnlyStartupConfigChanged returns true if the given previous and current (sub-topology) configs
+// of the given node differ in nothing but the content of the inline startup-config of the node --
+// or do not differ at all. A startup-config is inline when it holds the config itself rather than
+// the path of a file holding it, only inline startup-configs are part of the config and can be
+// reapplied by the launcher.
+func OnlyStartupConfigChanged(
+	previousConfig,
+	currentConfig *clabernetesutilcontainerlab.Config,
+	nodeName string,
+) bool {
+	previousStartupConfig, ok := nodeStartupConfig(previousConfig, nodeName)
+	if !ok {
+		return false
+	}
+
+	currentStartupConfig, ok := nodeStartupConfig(currentConfig, nodeName)
+	if !ok {
+		return false
+	}
+
+	if previousStartupConfig != currentStartupConfig &&
+		(!isInlineStartupConfig(previousStartupConfig) ||
+			!isInlineStartupConfig(currentStartupConfig)) {
+		return false
+	}
+
+	previousHash, err := HashResolvedConfigs(
+		map[string]*clabernetesutilcontainerlab.Config{
+			nodeName: withoutStartupConfig(previousConfig, nodeName),
+		},
+	)
+	if err != nil {
+		return false
+	}
+
+	currentHash, err := HashResolvedConfigs(
+		map[string]*clabernetesutilcontainerlab.Config{
+			nodeName: withoutStartupConfig(currentConfig, nodeName),
+		},
+	)
+	if err != nil {
+		return false
+	}
+
+	return previousHash == currentHash
+}
+
+func nodeStartupConfig(
+	config *clabernetesutilcontainerlab.Config,
+	nodeName string,
+) (string, bool) {
+	if config == nil || config.Topology == nil {
+		return "", false
+	}
+
+	nodeDef, ok := config.Topology.Nodes[nodeName]
+	if !ok || nodeDef == nil {
+		return "", false
+	}
+
+	return nodeDef.StartupConfig, true
+}
+
+// isInlineStartupConfig returns true if the given startup-config is inline config rather than a
+// path -- paths never contain newlines, while inline (block scalar) configs always end with one,
+// even if they are just a single line.
+func isInlineStartupConfig(startupConfig string) bool {
+	return strings.Contains(startupConfig, "\n")
+}
+
+// withoutStartupConfig returns a copy of the given config without the startup-config of the given
+// node, the given config is not modified.
+func withoutStartupConfig(
+	config *clabernetesutilcontainerlab.Config,
+	nodeName string,
+) *clabernetesutilcontainerlab.Config {
+	configCopy := *config
+	topologyCopy := *config.Topology
+
+	topologyCopy.Nodes = maps.Clone(config.Topology.Nodes)
+
+	nodeDefCopy := *config.Topology.Nodes[nodeName]
+	nodeDefCopy.StartupConfig = ""
+
+	topologyCopy.Nodes[nodeName] = &nodeDefCopy
+	configCopy.Topology = &topologyCopy
+
+	return &configCopy
+}
@@ -0,0 +1,214 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+func TestResolveConfigPushFile(t *testing.T) {
+	clabernetesConfigs := map[string]*clabernetesutilcontainerlab.Config{
+		"ceos1": {
+			Name: "ceos1",
+			Topology: &clabernetesutilcontainerlab.Topology{
+				Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+					"ceos1": {
+						Kind:          "ceos",
+						StartupConfig: "configs/ceos1.cfg",
+					},
+				},
+			},
+		},
+		"ceos2": {
+			Name: "ceos2",
+			Topology: &clabernetesutilcontainerlab.Topology{
+				Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+					"ceos2": {
+						Kind: "ceos",
+					},
+				},
+			},
+		},
+	}
+
+	startupConfigFile := clabernetesapisv1alpha1.FileFromConfigMap{
+		FilePath:      "configs/ceos1.cfg",
+		ConfigMapName: "startup-configs",
+		ConfigMapPath: "ceos1.cfg",
+	}
+
+	cases := []struct {
+		name     string
+		in       *clabernetesapisv1alpha1.Topology
+		nodeName string
+		expected *clabernetesapisv1alpha1.FileFromConfigMap
+	}{
+		{
+			name: "disabled",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						FilesFromConfigMap: map[string][]clabernetesapisv1alpha1.FileFromConfigMap{
+							"ceos1": {startupConfigFile},
+						},
+					},
+				},
+			},
+			nodeName: "ceos1",
+			expected: nil,
+		},
+		{
+			name: "enabled",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						DifferentialConfigPush: true,
+						FilesFromConfigMap: map[string][]clabernetesapisv1alpha1.FileFromConfigMap{
+							"ceos1": {
+								{
+									FilePath:      "license.key",
+									ConfigMapName: "licenses",
+									ConfigMapPath: "ceos.key",
+								},
+								startupConfigFile,
+							},
+						},
+					},
+				},
+			},
+			nodeName: "ceos1",
+			expected: &startupConfigFile,
+		},
+		{
+			name: "native-mode",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						DifferentialConfigPush: true,
+						NativeMode:             clabernetesutil.ToPointer(true),
+						FilesFromConfigMap: map[string][]clabernetesapisv1alpha1.FileFromConfigMap{
+							"ceos1": {startupConfigFile},
+						},
+					},
+				},
+			},
+			nodeName: "ceos1",
+			expected: nil,
+		},
+		{
+			name: "no-startup-config",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						DifferentialConfigPush: true,
+						FilesFromConfigMap: map[string][]clabernetesapisv1alpha1.FileFromConfigMap{
+							"ceos2": {startupConfigFile},
+						},
+					},
+				},
+			},
+			nodeName: "ceos2",
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveConfigPushFile(
+					testCase.in,
+					testCase.nodeName,
+					clabernetesConfigs,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestOnlyStartupConfigChanged(t *testing.T) {
+	nodeConfig := func(image, startupConfig string) *clabernetesutilcontainerlab.Config {
+		return &clabernetesutilcontainerlab.Config{
+			Name: "ceos1",
+			Topology: &clabernetesutilcontainerlab.Topology{
+				Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+					"ceos1": {
+						Kind:          "ceos",
+						Image:         image,
+						StartupConfig: startupConfig,
+					},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name           string
+		previousConfig *clabernetesutilcontainerlab.Config
+		currentConfig  *clabernetesutilcontainerlab.Config
+		expected       bool
+	}{
+		{
+			name:           "unchanged",
+			previousConfig: nodeConfig("ceos:4.32", "hostname ceos1\n"),
+			currentConfig:  nodeConfig("ceos:4.32", "hostname ceos1\n"),
+			expected:       true,
+		},
+		{
+			name:           "inline-startup-config-changed",
+			previousConfig: nodeConfig("ceos:4.32", "hostname ceos1\n"),
+			currentConfig:  nodeConfig("ceos:4.32", "hostname ceos1\nip routing\n"),
+			expected:       true,
+		},
+		{
+			name:           "startup-config-path-changed",
+			previousConfig: nodeConfig("ceos:4.32", "configs/ceos1.cfg"),
+			currentConfig:  nodeConfig("ceos:4.32", "configs/ceos1-new.cfg"),
+			expected:       false,
+		},
+		{
+			name:           "startup-config-path-to-inline",
+			previousConfig: nodeConfig("ceos:4.32", "configs/ceos1.cfg"),
+			currentConfig:  nodeConfig("ceos:4.32", "hostname ceos1\n"),
+			expected:       false,
+		},
+		{
+			name:           "image-and-startup-config-changed",
+			previousConfig: nodeConfig("ceos:4.32", "hostname ceos1\n"),
+			currentConfig:  nodeConfig("ceos:4.33", "hostname ceos1\nip routing\n"),
+			expected:       false,
+		},
+		{
+			name:           "no-previous-config",
+			previousConfig: nil,
+			currentConfig:  nodeConfig("ceos:4.32", "hostname ceos1\n"),
+			expected:       false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.OnlyStartupConfigChanged(
+					testCase.previousConfig,
+					testCase.currentConfig,
+					"ceos1",
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
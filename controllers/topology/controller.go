@@ -12,6 +12,7 @@ import (
 	k8scorev1 "k8s.io/api/core/v1"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
+	ctrlruntimebuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlruntimecontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	ctrlruntimehandler "sigs.k8s.io/controller-runtime/pkg/handler"
@@ -48,6 +49,7 @@ func NewController(
 			clabernetes.GetAppName(),
 			clabernetes.GetNamespace(),
 			clabernetes.GetClusterCRIKind(),
+			clabernetes.IsNamespaceScoped(),
 			clabernetesconfig.GetManager,
 		),
 	}
@@ -89,6 +91,33 @@ func (c *Controller) SetupWithManager(mgr ctrlruntime.Manager) error {
 				&clabernetesapisv1alpha1.Topology{},
 			),
 		).
+		// same goes for owned statefulsets, for topologies whose launchers run as statefulsets
+		Watches(
+			&k8sappsv1.StatefulSet{},
+			ctrlruntimehandler.EnqueueRequestForOwner(
+				mgr.GetScheme(),
+				mgr.GetRESTMapper(),
+				&clabernetesapisv1alpha1.Topology{},
+			),
+		).
+		// watch owned connectivity crs so we surface tunnel statuses reported by the launchers, but
+		// only when the tunnel states change -- not for every periodic self-test/counters report
+		Watches(
+			&clabernetesapisv1alpha1.Connectivity{},
+			ctrlruntimehandler.EnqueueRequestForOwner(
+				mgr.GetScheme(),
+				mgr.GetRESTMapper(),
+				&clabernetesapisv1alpha1.Topology{},
+			),
+			ctrlruntimebuilder.WithPredicates(ConnectivityPredicate()),
+		).
+		// watch the propagated pull secret so the copies in the topology namespaces stay in sync
+		Watches(
+			&k8scorev1.Secret{},
+			ctrlruntimehandler.EnqueueRequestsFromMapFunc(
+				c.enqueueForPropagatedPullSecret,
+			),
+		).
 		// watch our config cr too so we get any config updates handled
 		Watches(
 			&clabernetesapisv1alpha1.Config{},
@@ -126,3 +155,16 @@ func (c *Controller) enqueueForAll(
 
 	return requests
 }
+
+// enqueueForPropagatedPullSecret enqueues all Topology CRs for reconciliation if the given object is
+// the propagated image pull secret.
+func (c *Controller) enqueueForPropagatedPullSecret(
+	ctx context.Context,
+	obj ctrlruntimeclient.Object,
+) []ctrlruntimereconcile.Request {
+	if !c.TopologyReconciler.IsPropagatedPullSecretSource(obj) {
+		return nil
+	}
+
+	return c.enqueueForAll(ctx, obj)
+}
@@ -70,9 +70,19 @@ func buildNodeGroups(
 }
 
 func (p *containerlabDefinitionProcessor) Process() error {
+	rawContainerlabConfig, err := clabernetesutilcontainerlab.RenderInstanceOffset(
+		p.topology.Spec.Definition.Containerlab,
+		p.topology.Spec.Definition.InstanceOffset,
+	)
+	if err != nil {
+		p.logger.Criticalf("failed rendering containerlab config instance offset, error: %s", err)
+
+		return err
+	}
+
 	// load the containerlab topo from the CR to make sure its all good
 	containerlabConfig, err := clabernetesutilcontainerlab.LoadContainerlabConfig(
-		p.topology.Spec.Definition.Containerlab,
+		rawContainerlabConfig,
 	)
 	if err != nil {
 		p.logger.Criticalf("failed parsing containerlab config, error: %s", err)
@@ -80,6 +90,17 @@ func (p *containerlabDefinitionProcessor) Process() error {
 		return err
 	}
 
+	p.applyKindAliases(containerlabConfig.Topology)
+
+	p.applyHostClock(containerlabConfig.Topology)
+
+	p.applyNativeInterfaceNames(containerlabConfig.Topology)
+
+	err = p.validateInterfaceLimits(containerlabConfig.Topology)
+	if err != nil {
+		return err
+	}
+
 	// we may have *different defaults per "sub-topology" so we do a cheater "deep copy" by just
 	// marshalling here and unmarshalling per node in the process func :)
 	defaultsYAML, err := yaml.Marshal(containerlabConfig.Topology.Defaults)
@@ -93,6 +114,13 @@ func (p *containerlabDefinitionProcessor) Process() error {
 	// Build node groups for distributed systems (e.g., SR-SIM with network-mode: container:<name>)
 	nodeGroups, secondaryNodes := buildNodeGroups(containerlabConfig.Topology.Nodes)
 
+	// record the dependencies between nodes before the sub-topologies drop the ones on nodes that
+	// are not part of them
+	err = p.resolveNodeDependencies(containerlabConfig.Topology, secondaryNodes)
+	if err != nil {
+		return err
+	}
+
 	for nodeName := range containerlabConfig.Topology.Nodes {
 		// Skip secondary nodes - they will be processed as part of their primary's group
 		if _, isSecondary := secondaryNodes[nodeName]; isSecondary {
@@ -299,8 +327,11 @@ func insertMissingDefaultPorts(
 	return typedDefaultPorts
 }
 
+// processPorts allocates expose ports for the given default and node port definitions that do not
+// have one, inserting the auto exposed ports into the defaults first if autoExpose is true.
 func processPorts(
 	topologyDefaultPorts, topologyNodePorts []string,
+	autoExpose bool,
 ) (defaultPortsAsString, nodePortsAsString []string) {
 	typedDefaultPorts := typedPortsFromPortDefinitions(topologyDefaultPorts)
 	typedNodePorts := typedPortsFromPortDefinitions(topologyNodePorts)
@@ -310,7 +341,9 @@ func processPorts(
 		typedNodePorts,
 	)
 
-	typedDefaultPorts = insertMissingDefaultPorts(typedDefaultPorts, typedNodePorts)
+	if autoExpose {
+		typedDefaultPorts = insertMissingDefaultPorts(typedDefaultPorts, typedNodePorts)
+	}
 
 	defaultPortsAsString = make([]string, len(typedDefaultPorts))
 	nodePortsAsString = make([]string, len(typedNodePorts))
@@ -461,13 +494,22 @@ func buildNodesMapForGroup(
 
 		isSecondaryNode := parseNetworkModeContainer(nodeDefinition.NetworkMode) != ""
 
+		nodeKind, _ := ctx.containerlabConfig.Topology.GetNodeKindType(nodeName)
+
 		switch {
 		case isSecondaryNode:
 			nodeDefinition.Ports = []string{}
-		case !ctx.disableExpose && !ctx.disableAutoExpose:
+		case nodeKind == clabernetesconstants.BridgeKind:
+			// bridges have no container, so there is nothing to map ports to
+			ctx.deepCopiedDefaults.Ports = []string{}
+			nodeDefinition.Ports = []string{}
+		case !ctx.disableExpose:
+			// with auto expose disabled we still honor the ports the user asked for, only the
+			// auto exposed ports are left out
 			defaultPorts, nodePorts := processPorts(
 				ctx.containerlabConfig.Topology.Defaults.Ports,
-				nodeDefinition.Ports,
+				resolveNodePortDefinitions(ctx.containerlabConfig.Topology, nodeName, nodeKind),
+				!ctx.disableAutoExpose,
 			)
 
 			ctx.deepCopiedDefaults.Ports = defaultPorts
@@ -476,12 +518,36 @@ func buildNodesMapForGroup(
 			nodeDefinition.Ports = []string{}
 		}
 
+		// containerlab fails to deploy nodes waiting for nodes it does not know about, waiting for
+		// nodes in other sub-topologies is taken care of by gating their deployments instead
+		nodeDefinition.PruneDependencies(ctx.groupNodesSet.Contains)
+
 		nodesMap[nodeName] = nodeDefinition
 	}
 
 	return nodesMap
 }
 
+// resolveNodePortDefinitions returns the port definitions of the given node -- like containerlab
+// does, the ports of the node's kind apply if the node itself has none.
+func resolveNodePortDefinitions(
+	topology *clabernetesutilcontainerlab.Topology,
+	nodeName,
+	nodeKind string,
+) []string {
+	nodeDefinition := topology.Nodes[nodeName]
+	if len(nodeDefinition.Ports) > 0 {
+		return nodeDefinition.Ports
+	}
+
+	kindDefinition, ok := topology.Kinds[nodeKind]
+	if !ok || kindDefinition == nil {
+		return nodeDefinition.Ports
+	}
+
+	return kindDefinition.Ports
+}
+
 // collectKindsForGroup collects all kinds used by nodes in the group.
 func collectKindsForGroup(
 	topology *clabernetesutilcontainerlab.Topology,
@@ -747,6 +813,13 @@ func (p *containerlabDefinitionProcessor) processLinkForGroup(
 		return nil
 	}
 
+	bandwidth, err := LinkBandwidth(link)
+	if err != nil {
+		p.logger.Critical(err.Error())
+
+		return err
+	}
+
 	destinationNodeName := uninterestingEndpoint.NodeName
 	if remotePrimary, isSecondary := secondaryNodes[uninterestingEndpoint.NodeName]; isSecondary {
 		destinationNodeName = remotePrimary
@@ -766,6 +839,7 @@ func (p *containerlabDefinitionProcessor) processLinkForGroup(
 			),
 			LocalInterface:  interestingEndpoint.InterfaceName,
 			RemoteInterface: uninterestingEndpoint.InterfaceName,
+			Bandwidth:       bandwidth,
 		},
 	)
 
@@ -0,0 +1,68 @@
+package topology
+
+import (
+	"slices"
+
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+// HashResolvedConfigs returns the hash of the canonical form of the given resolved (per node)
+// containerlab configs. The configs are parsed from the topology definition so key order, quoting
+// and comments in the definition are already gone, the canonical form additionally puts the links
+// of each config, and the endpoints of each link, in a stable order -- this way definition updates
+// that only reorder content do not change the hash and so do not restart any nodes. The given
+// configs are not modified.
+func HashResolvedConfigs(
+	configs map[string]*clabernetesutilcontainerlab.Config,
+) (string, error) {
+	canonicalConfigs := make(map[string]*clabernetesutilcontainerlab.Config, len(configs))
+
+	for nodeName, config := range configs {
+		if config == nil || config.Topology == nil {
+			canonicalConfigs[nodeName] = config
+
+			continue
+		}
+
+		canonicalConfig := *config
+		canonicalTopology := *config.Topology
+
+		if config.Topology.Links != nil {
+			canonicalTopology.Links = make(
+				[]*clabernetesutilcontainerlab.LinkDefinition,
+				len(config.Topology.Links),
+			)
+
+			for idx, link := range config.Topology.Links {
+				canonicalTopology.Links[idx] = canonicalLink(link)
+			}
+
+			canonicalTopology.SortLinks()
+		}
+
+		canonicalConfig.Topology = &canonicalTopology
+		canonicalConfigs[nodeName] = &canonicalConfig
+	}
+
+	_, configHash, err := clabernetesutil.HashObjectYAML(canonicalConfigs)
+
+	return configHash, err
+}
+
+// canonicalLink returns a copy of the given link with its endpoints sorted -- the order the
+// endpoints of a link are written in does not change the link.
+func canonicalLink(
+	link *clabernetesutilcontainerlab.LinkDefinition,
+) *clabernetesutilcontainerlab.LinkDefinition {
+	if link == nil || link.Endpoints == nil {
+		return link
+	}
+
+	canonical := *link
+	canonical.Endpoints = slices.Clone(link.Endpoints)
+
+	slices.Sort(canonical.Endpoints)
+
+	return &canonical
+}
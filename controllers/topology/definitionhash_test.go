@@ -0,0 +1,243 @@
+package topology_test
+
+import (
+	"slices"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+const hashResolvedConfigsBaseDefinition = `---
+name: topo01
+topology:
+  nodes:
+    srl1:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+    srl2:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+  links:
+    - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+    - endpoints: ["srl1:e1-2", "srl2:e1-2"]
+`
+
+func TestHashResolvedConfigs(t *testing.T) {
+	cases := []struct {
+		name          string
+		definition    string
+		expectedEqual bool
+	}{
+		{
+			name:          "identical",
+			definition:    hashResolvedConfigsBaseDefinition,
+			expectedEqual: true,
+		},
+		{
+			name: "key-order-and-quoting",
+			definition: `---
+topology:
+  links:
+    - endpoints: [srl1:e1-1, srl2:e1-1]
+    - endpoints: ['srl1:e1-2', 'srl2:e1-2']
+  nodes:
+    srl2:
+      image: "ghcr.io/nokia/srlinux"
+      kind: srl
+    srl1:
+      image: ghcr.io/nokia/srlinux
+      kind: "srl"
+name: "topo01"
+`,
+			expectedEqual: true,
+		},
+		{
+			name: "link-order",
+			definition: `---
+name: topo01
+topology:
+  nodes:
+    srl1:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+    srl2:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+  links:
+    - endpoints: ["srl1:e1-2", "srl2:e1-2"]
+    - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+`,
+			expectedEqual: true,
+		},
+		{
+			name: "endpoint-order",
+			definition: `---
+name: topo01
+topology:
+  nodes:
+    srl1:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+    srl2:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+  links:
+    - endpoints: ["srl2:e1-1", "srl1:e1-1"]
+    - endpoints: ["srl1:e1-2", "srl2:e1-2"]
+`,
+			expectedEqual: true,
+		},
+		{
+			name: "semantic-change",
+			definition: `---
+name: topo01
+topology:
+  nodes:
+    srl1:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+    srl2:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+  links:
+    - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+    - endpoints: ["srl1:e1-3", "srl2:e1-2"]
+`,
+			expectedEqual: false,
+		},
+	}
+
+	baseConfig, err := clabernetesutilcontainerlab.LoadContainerlabConfig(
+		hashResolvedConfigsBaseDefinition,
+	)
+	if err != nil {
+		t.Fatalf("failed loading base definition, error: %s", err)
+	}
+
+	baseHash, err := clabernetescontrollerstopology.HashResolvedConfigs(
+		map[string]*clabernetesutilcontainerlab.Config{"topo01": baseConfig},
+	)
+	if err != nil {
+		t.Fatalf("failed hashing base definition, error: %s", err)
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				config, err := clabernetesutilcontainerlab.LoadContainerlabConfig(
+					testCase.definition,
+				)
+				if err != nil {
+					t.Fatalf("failed loading definition, error: %s", err)
+				}
+
+				originalLinks := append(
+					[]*clabernetesutilcontainerlab.LinkDefinition{},
+					config.Topology.Links...,
+				)
+
+				originalEndpoints := make([][]string, len(config.Topology.Links))
+				for idx, link := range config.Topology.Links {
+					originalEndpoints[idx] = append([]string{}, link.Endpoints...)
+				}
+
+				actualHash, err := clabernetescontrollerstopology.HashResolvedConfigs(
+					map[string]*clabernetesutilcontainerlab.Config{"topo01": config},
+				)
+				if err != nil {
+					t.Fatalf("failed hashing definition, error: %s", err)
+				}
+
+				if (actualHash == baseHash) != testCase.expectedEqual {
+					clabernetestesthelper.FailOutput(t, actualHash, baseHash)
+				}
+
+				for idx := range originalLinks {
+					if config.Topology.Links[idx] != originalLinks[idx] ||
+						!slices.Equal(config.Topology.Links[idx].Endpoints, originalEndpoints[idx]) {
+						t.Fatal("hashing resolved configs modified the given configs")
+					}
+				}
+			})
+	}
+}
+
+// TestDetermineNodesNeedingRestartLegacyHash ensures that topologies last reconciled with the
+// legacy (non canonical) config hash are not restarted just because the hash format changed.
+func TestDetermineNodesNeedingRestartLegacyHash(t *testing.T) {
+	cases := []struct {
+		name            string
+		previousHash    string
+		expectedRestart bool
+	}{
+		{
+			name:            "canonical-hash-unchanged",
+			previousHash:    "canonical",
+			expectedRestart: false,
+		},
+		{
+			name:            "legacy-hash-unchanged",
+			previousHash:    "legacy",
+			expectedRestart: false,
+		},
+		{
+			name:            "changed",
+			previousHash:    "something-else",
+			expectedRestart: true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				config, err := clabernetesutilcontainerlab.LoadContainerlabConfig(
+					hashResolvedConfigsBaseDefinition,
+				)
+				if err != nil {
+					t.Fatalf("failed loading definition, error: %s", err)
+				}
+
+				reconcileData, err := clabernetescontrollerstopology.NewReconcileData(
+					&clabernetesapisv1alpha1.Topology{},
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reconcileData.PreviousHashes.Config = testCase.previousHash
+				reconcileData.ResolvedHashes.Config = "canonical"
+				reconcileData.LegacyConfigHash = "legacy"
+				reconcileData.PreviousConfigs["srl1"] = config
+				reconcileData.ResolvedConfigs["srl1"] = config
+
+				reconciler := clabernetescontrollerstopology.NewDeploymentReconciler(
+					&claberneteslogging.FakeInstance{},
+					"clabernetes",
+					"clabernetes",
+					"",
+					clabernetesconfig.GetFakeManager,
+				)
+
+				reconciler.DetermineNodesNeedingRestart(
+					&clabernetesapisv1alpha1.Topology{},
+					reconcileData,
+				)
+
+				actualRestart := reconcileData.NodesNeedingReboot.Contains("srl1")
+				if actualRestart != testCase.expectedRestart {
+					clabernetestesthelper.FailOutput(t, actualRestart, testCase.expectedRestart)
+				}
+			})
+	}
+}
@@ -0,0 +1,115 @@
+package topology
+
+import (
+	"maps"
+	"slices"
+
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+// applyKindAliases rewrites any kinds in the given containerlab topology that are aliases (as
+// configured in the global config) to their actual containerlab kind, and then sets the global
+// config default image for any nodes that otherwise would have no image at all. Tools (netlab) and
+// users often use kind names that drift from the containerlab kinds (eos vs ceos and the like), so
+// this lets operators smooth that over without having to touch every topology.
+func (p *containerlabDefinitionProcessor) applyKindAliases(
+	clabTopo *clabernetesutilcontainerlab.Topology,
+) {
+	configManager := p.configManagerGetter()
+
+	kindAliases := configManager.GetKindAliases()
+
+	resolveKind := func(kind, context string) string {
+		resolvedKind, ok := kindAliases[kind]
+		if !ok || resolvedKind == kind {
+			return kind
+		}
+
+		p.logger.Warnf(
+			"%s uses kind alias %q, resolving to containerlab kind %q",
+			context,
+			kind,
+			resolvedKind,
+		)
+
+		return resolvedKind
+	}
+
+	if clabTopo.Defaults != nil && clabTopo.Defaults.Kind != "" {
+		clabTopo.Defaults.Kind = resolveKind(clabTopo.Defaults.Kind, "topology defaults")
+	}
+
+	// iterate over a snapshot of the kinds since we re-key the map as we go
+	for _, kindName := range slices.Sorted(maps.Keys(clabTopo.Kinds)) {
+		kindDefinition := clabTopo.Kinds[kindName]
+
+		resolvedKind := resolveKind(kindName, "topology kinds entry")
+		if resolvedKind == kindName {
+			continue
+		}
+
+		delete(clabTopo.Kinds, kindName)
+
+		if _, exists := clabTopo.Kinds[resolvedKind]; exists {
+			p.logger.Warnf(
+				"topology kinds has entries for both alias %q and kind %q, ignoring the alias entry",
+				kindName,
+				resolvedKind,
+			)
+
+			continue
+		}
+
+		clabTopo.Kinds[resolvedKind] = kindDefinition
+	}
+
+	for nodeName, nodeDefinition := range clabTopo.Nodes {
+		if nodeDefinition == nil || nodeDefinition.Kind == "" {
+			continue
+		}
+
+		nodeDefinition.Kind = resolveKind(nodeDefinition.Kind, "node "+nodeName)
+	}
+
+	kindDefaultImages := configManager.GetKindDefaultImages()
+	if len(kindDefaultImages) == 0 {
+		return
+	}
+
+	for nodeName, nodeDefinition := range clabTopo.Nodes {
+		if nodeDefinition == nil || nodeDefinition.Image != "" {
+			continue
+		}
+
+		nodeKind := nodeDefinition.Kind
+
+		if clabTopo.Defaults != nil {
+			if clabTopo.Defaults.Image != "" {
+				continue
+			}
+
+			if nodeKind == "" {
+				nodeKind = clabTopo.Defaults.Kind
+			}
+		}
+
+		kindDefinition := clabTopo.Kinds[nodeKind]
+		if kindDefinition != nil && kindDefinition.Image != "" {
+			continue
+		}
+
+		image, ok := kindDefaultImages[nodeKind]
+		if !ok {
+			continue
+		}
+
+		p.logger.Infof(
+			"node %q of kind %q has no image, using global default image %q",
+			nodeName,
+			nodeKind,
+			image,
+		)
+
+		nodeDefinition.Image = image
+	}
+}
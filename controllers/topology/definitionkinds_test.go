@@ -0,0 +1,157 @@
+package topology_test
+
+import (
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestDefinitionProcessKindAliases ensures that kind aliases from the global config are resolved
+// to their containerlab kinds and that default images are only applied to nodes with no image.
+func TestDefinitionProcessKindAliases(t *testing.T) {
+	cases := []struct {
+		name          string
+		containerlab  string
+		expectedKinds []string
+		expectedNodes map[string]struct {
+			kind  string
+			image string
+		}
+	}{
+		{
+			name: "aliases-and-default-images",
+			containerlab: `---
+    name: test
+    topology:
+      nodes:
+        eos1:
+          kind: eos
+        eos2:
+          kind: eos
+          image: my.registry/ceos:4.32.0F
+        srl1:
+          kind: srl
+      links:
+        - endpoints: ["eos1:eth1", "eos2:eth1"]
+        - endpoints: ["eos1:eth2", "srl1:e1-1"]
+`,
+			expectedNodes: map[string]struct {
+				kind  string
+				image string
+			}{
+				"eos1": {kind: "ceos", image: "ceos:latest"},
+				"eos2": {kind: "ceos", image: "my.registry/ceos:4.32.0F"},
+				"srl1": {kind: "srl", image: ""},
+			},
+		},
+		{
+			name: "empty-node-and-kind-definitions",
+			containerlab: `---
+    name: test
+    topology:
+      defaults:
+        kind: eos
+      kinds:
+        eos:
+        srl:
+        linux:
+      nodes:
+        eos1:
+        eos2:
+          kind: eos
+      links:
+        - endpoints: ["eos1:eth1", "eos2:eth1"]
+`,
+			expectedKinds: []string{"ceos"},
+			expectedNodes: map[string]struct {
+				kind  string
+				image string
+			}{
+				"eos1": {kind: "", image: "ceos:latest"},
+				"eos2": {kind: "ceos", image: "ceos:latest"},
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				topology := &clabernetesapisv1alpha1.Topology{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "process-containerlab-definition-kind-aliases-test",
+						Namespace: "clabernetes",
+					},
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						Definition: clabernetesapisv1alpha1.Definition{
+							Containerlab: testCase.containerlab,
+						},
+					},
+				}
+
+				reconcileData := &clabernetescontrollerstopology.ReconcileData{
+					Kind:            "containerlab",
+					ResolvedHashes:  clabernetesapisv1alpha1.ReconcileHashes{},
+					ResolvedConfigs: map[string]*clabernetesutilcontainerlab.Config{},
+					ResolvedTunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{},
+				}
+
+				processor, err := clabernetescontrollerstopology.NewDefinitionProcessor(
+					&claberneteslogging.FakeInstance{},
+					topology,
+					reconcileData,
+					func() clabernetesconfig.Manager {
+						return clabernetesconfig.NewFakeManager(
+							clabernetesconfig.WithKindAliases(
+								map[string]string{"eos": "ceos"},
+								map[string]string{"ceos": "ceos:latest"},
+							),
+						)
+					},
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = processor.Process()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for nodeName, expected := range testCase.expectedNodes {
+					resolvedConfig, ok := reconcileData.ResolvedConfigs[nodeName]
+					if !ok {
+						t.Fatalf("expected resolved config for node %q", nodeName)
+					}
+
+					nodeDefinition := resolvedConfig.Topology.Nodes[nodeName]
+
+					if nodeDefinition.Kind != expected.kind {
+						clabernetestesthelper.FailOutput(t, nodeDefinition.Kind, expected.kind)
+					}
+
+					if nodeDefinition.Image != expected.image {
+						clabernetestesthelper.FailOutput(t, nodeDefinition.Image, expected.image)
+					}
+
+					for _, expectedKind := range testCase.expectedKinds {
+						if _, ok = resolvedConfig.Topology.Kinds[expectedKind]; !ok {
+							clabernetestesthelper.FailOutput(
+								t,
+								resolvedConfig.Topology.Kinds,
+								testCase.expectedKinds,
+							)
+						}
+					}
+				}
+			})
+	}
+}
@@ -0,0 +1,219 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resolveNodeDependencies records the dependencies ("wait-for" lists and stage "wait-for"s) of the
+// nodes of the given containerlab topology in the reconcile data -- keyed by the (primary) node
+// that owns the deployment, as that is what clabernetes can sequence. Dependencies between nodes
+// of the same deployment are left to containerlab, dependencies on nodes that do not exist are
+// ignored. Returns an error if the dependencies form a cycle, as the nodes would never start.
+func (p *containerlabDefinitionProcessor) resolveNodeDependencies(
+	topology *clabernetesutilcontainerlab.Topology,
+	secondaryNodes map[string]string,
+) error {
+	deploymentNode := func(nodeName string) string {
+		primaryNodeName, isSecondary := secondaryNodes[nodeName]
+		if isSecondary {
+			return primaryNodeName
+		}
+
+		return nodeName
+	}
+
+	dependencies := map[string]clabernetesutil.StringSet{}
+
+	for nodeName := range topology.Nodes {
+		owner := deploymentNode(nodeName)
+
+		for _, dependency := range topology.GetNodeDependencies(nodeName) {
+			if _, ok := topology.Nodes[dependency]; !ok {
+				p.logger.Warnf(
+					"node %q waits for node %q which does not exist, ignoring",
+					nodeName,
+					dependency,
+				)
+
+				continue
+			}
+
+			dependencyOwner := deploymentNode(dependency)
+			if dependencyOwner == owner {
+				continue
+			}
+
+			if dependencies[owner] == nil {
+				dependencies[owner] = clabernetesutil.NewStringSet()
+			}
+
+			dependencies[owner].Add(dependencyOwner)
+		}
+	}
+
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	nodeDependencies := make(map[string][]string, len(dependencies))
+
+	for nodeName, nodeDependenciesSet := range dependencies {
+		sortedDependencies := nodeDependenciesSet.Items()
+
+		slices.Sort(sortedDependencies)
+
+		nodeDependencies[nodeName] = sortedDependencies
+	}
+
+	cycle := FindNodeDependencyCycle(nodeDependencies)
+	if len(cycle) > 0 {
+		msg := fmt.Sprintf("node dependencies form a cycle: %s", strings.Join(cycle, " -> "))
+
+		p.logger.Critical(msg)
+
+		return fmt.Errorf("%w: %s", claberneteserrors.ErrParse, msg)
+	}
+
+	p.reconcileData.NodeDependencies = nodeDependencies
+
+	return nil
+}
+
+// FindNodeDependencyCycle returns the nodes of a dependency cycle in the given mapping of node ->
+// nodes it depends on, starting and ending with the same node, or nil if there is no cycle.
+func FindNodeDependencyCycle(nodeDependencies map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := map[string]int{}
+
+	var path []string
+
+	var visit func(nodeName string) []string
+
+	visit = func(nodeName string) []string {
+		switch state[nodeName] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(path, nodeName)
+
+			return append(slices.Clone(path[start:]), nodeName)
+		}
+
+		state[nodeName] = visiting
+		path = append(path, nodeName)
+
+		for _, dependency := range nodeDependencies[nodeName] {
+			cycle := visit(dependency)
+			if cycle != nil {
+				return cycle
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[nodeName] = visited
+
+		return nil
+	}
+
+	// sorted so the reported cycle is stable between reconciles
+	nodeNames := make([]string, 0, len(nodeDependencies))
+	for nodeName := range nodeDependencies {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	slices.Sort(nodeNames)
+
+	for _, nodeName := range nodeNames {
+		if state[nodeName] != unvisited {
+			continue
+		}
+
+		cycle := visit(nodeName)
+		if cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+// ResolveWaitingNodes returns the given (not yet deployed) nodes that have to wait for any of the
+// nodes they depend on to become ready before they can be deployed.
+func ResolveWaitingNodes(
+	nodeNames []string,
+	nodeDependencies map[string][]string,
+	readyNodes clabernetesutil.StringSet,
+) []string {
+	var waitingNodes []string
+
+	for _, nodeName := range nodeNames {
+		for _, dependency := range nodeDependencies[nodeName] {
+			if !readyNodes.Contains(dependency) {
+				waitingNodes = append(waitingNodes, nodeName)
+
+				break
+			}
+		}
+	}
+
+	return waitingNodes
+}
+
+// resolveWaitingNodes returns the set of the given missing nodes that are not deployed (yet) as
+// (some of) the nodes they depend on are not ready -- once those become ready the deployment
+// change triggers another reconcile that deploys the waiting nodes. Suspended topologies never
+// have ready nodes, so nothing waits while suspended.
+func (r *Reconciler) resolveWaitingNodes(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+	launchers *clabernetesutil.ObjectDiffer[ctrlruntimeclient.Object],
+	missingNodes []string,
+) clabernetesutil.StringSet {
+	waitingNodes := clabernetesutil.NewStringSet()
+
+	if len(reconcileData.NodeDependencies) == 0 || owningTopology.Status.Suspended {
+		return waitingNodes
+	}
+
+	readyNodes := clabernetesutil.NewStringSet()
+
+	// a failed list only means nodes are not considered ready by their pods this time around
+	topologyPods, _ := r.listTopologyPods(ctx, owningTopology)
+
+	for nodeName, launcher := range launchers.Current {
+		if launcherReadyReplicas(launcher) == 1 || isNodePodReady(topologyPods[nodeName]) {
+			readyNodes.Add(nodeName)
+		}
+	}
+
+	for _, nodeName := range ResolveWaitingNodes(
+		missingNodes,
+		reconcileData.NodeDependencies,
+		readyNodes,
+	) {
+		r.Log.Infof(
+			"not deploying node %q yet, waiting for node(s) %q to be ready",
+			nodeName,
+			reconcileData.NodeDependencies[nodeName],
+		)
+
+		waitingNodes.Add(nodeName)
+	}
+
+	return waitingNodes
+}
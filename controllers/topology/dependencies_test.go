@@ -0,0 +1,137 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+)
+
+func TestFindNodeDependencyCycle(t *testing.T) {
+	cases := []struct {
+		name             string
+		nodeDependencies map[string][]string
+		expected         []string
+	}{
+		{
+			name:             "no-dependencies",
+			nodeDependencies: nil,
+			expected:         nil,
+		},
+		{
+			name: "chain",
+			nodeDependencies: map[string][]string{
+				"srl3": {"srl2"},
+				"srl2": {"srl1"},
+			},
+			expected: nil,
+		},
+		{
+			name: "diamond",
+			nodeDependencies: map[string][]string{
+				"srl4": {"srl2", "srl3"},
+				"srl3": {"srl1"},
+				"srl2": {"srl1"},
+			},
+			expected: nil,
+		},
+		{
+			name: "cycle",
+			nodeDependencies: map[string][]string{
+				"srl1": {"srl3"},
+				"srl2": {"srl1"},
+				"srl3": {"srl2"},
+			},
+			expected: []string{"srl1", "srl3", "srl2", "srl1"},
+		},
+		{
+			name: "cycle-behind-dependency",
+			nodeDependencies: map[string][]string{
+				"srl1": {"srl2"},
+				"srl2": {"srl3"},
+				"srl3": {"srl2"},
+			},
+			expected: []string{"srl2", "srl3", "srl2"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.FindNodeDependencyCycle(
+					testCase.nodeDependencies,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestResolveWaitingNodes(t *testing.T) {
+	cases := []struct {
+		name             string
+		nodeNames        []string
+		nodeDependencies map[string][]string
+		readyNodes       []string
+		expected         []string
+	}{
+		{
+			name:             "no-dependencies",
+			nodeNames:        []string{"srl1", "srl2"},
+			nodeDependencies: nil,
+			readyNodes:       nil,
+			expected:         nil,
+		},
+		{
+			name:      "dependency-not-ready",
+			nodeNames: []string{"srl1", "srl2"},
+			nodeDependencies: map[string][]string{
+				"srl2": {"srl1"},
+			},
+			readyNodes: nil,
+			expected:   []string{"srl2"},
+		},
+		{
+			name:      "dependency-ready",
+			nodeNames: []string{"srl2"},
+			nodeDependencies: map[string][]string{
+				"srl2": {"srl1"},
+			},
+			readyNodes: []string{"srl1"},
+			expected:   nil,
+		},
+		{
+			name:      "some-dependencies-ready",
+			nodeNames: []string{"srl3", "srl4"},
+			nodeDependencies: map[string][]string{
+				"srl3": {"srl1", "srl2"},
+				"srl4": {"srl1"},
+			},
+			readyNodes: []string{"srl1"},
+			expected:   []string{"srl3"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveWaitingNodes(
+					testCase.nodeNames,
+					testCase.nodeDependencies,
+					clabernetesutil.NewStringSetWithValues(testCase.readyNodes...),
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
@@ -33,6 +33,10 @@ const (
 	probeDefaultStartupFailureThreshold = 40
 )
 
+// persistenceVolumeName is the name of the volume the containerlab directory of persistent nodes
+// is mounted from.
+const persistenceVolumeName = "containerlab-directory-persistence"
+
 func sanitizeLinuxIfName(raw string) string {
 	// Linux interface names must be <= 15 bytes and cannot contain '/'.
 	s := strings.TrimSpace(raw)
@@ -107,11 +111,12 @@ func NewDeploymentReconciler(
 
 // Resolve accepts a mapping of clabernetes configs and a list of deployments that are -- by owner
 // reference and/or labels -- associated with the topology. It returns a ObjectDiffer object
-// that contains the missing, extra, and current deployments for the topology.
+// that contains the missing, extra, and current deployments for the topology. If the launchers of
+// the topology run as statefulsets all deployments are extraneous.
 func (r *DeploymentReconciler) Resolve(
 	ownedDeployments *k8sappsv1.DeploymentList,
 	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
-	_ *clabernetesapisv1alpha1.Topology,
+	owningTopology *clabernetesapisv1alpha1.Topology,
 ) (*clabernetesutil.ObjectDiffer[*k8sappsv1.Deployment], error) {
 	deployments := &clabernetesutil.ObjectDiffer[*k8sappsv1.Deployment]{
 		Current: map[string]*k8sappsv1.Deployment{},
@@ -138,6 +143,12 @@ func (r *DeploymentReconciler) Resolve(
 		deployments.Current[nodeName] = &ownedDeployments.Items[i]
 	}
 
+	if ResolveLauncherKind(owningTopology) == clabernetesconstants.KubernetesKindStatefulSet {
+		deployments.SetExtra(nil)
+
+		return deployments, nil
+	}
+
 	allNodes := make([]string, len(clabernetesConfigs))
 
 	var nodeIdx int
@@ -180,6 +191,40 @@ func (r *DeploymentReconciler) Render(
 	)
 
 	r.renderDeploymentScheduling(
+		deployment,
+		nodeName,
+		owningTopology,
+		clabernetesConfigs,
+	)
+
+	r.renderDeploymentNodePin(
+		deployment,
+		nodeName,
+		owningTopology,
+	)
+
+	r.renderDeploymentPriorityClass(
+		deployment,
+		nodeName,
+		owningTopology,
+	)
+
+	r.renderDeploymentRuntimeClass(
+		deployment,
+		nodeName,
+		owningTopology,
+		clabernetesConfigs,
+	)
+
+	r.renderDeploymentImagePullSecrets(deployment)
+
+	r.renderDeploymentHostAliases(
+		deployment,
+		nodeName,
+		owningTopology,
+	)
+
+	r.renderDeploymentDNS(
 		deployment,
 		owningTopology,
 	)
@@ -210,6 +255,13 @@ func (r *DeploymentReconciler) Render(
 		clabernetesConfigs,
 	)
 
+	r.renderDeploymentContainerPorts(
+		deployment,
+		nodeName,
+		owningTopology,
+		clabernetesConfigs,
+	)
+
 	r.renderDeploymentMultus(
 		deployment,
 		owningTopology,
@@ -217,6 +269,23 @@ func (r *DeploymentReconciler) Render(
 		clabernetesConfigs,
 	)
 
+	r.renderDeploymentUnderlay(
+		deployment,
+		owningTopology,
+	)
+
+	r.renderDeploymentWireGuard(
+		deployment,
+		nodeName,
+		owningTopologyName,
+		owningTopology,
+	)
+
+	r.renderDeploymentSlurpeethTLS(
+		deployment,
+		owningTopology,
+	)
+
 	r.renderDeploymentContainerEnv(
 		deployment,
 		nodeName,
@@ -225,6 +294,20 @@ func (r *DeploymentReconciler) Render(
 		clabernetesConfigs,
 	)
 
+	r.renderDeploymentConfigPush(
+		deployment,
+		nodeName,
+		owningTopology,
+		clabernetesConfigs,
+	)
+
+	r.renderDeploymentStartupConfigReapply(
+		deployment,
+		nodeName,
+		configVolumeName,
+		owningTopology,
+	)
+
 	r.renderDeploymentContainerResources(
 		deployment,
 		nodeName,
@@ -245,17 +328,58 @@ func (r *DeploymentReconciler) Render(
 		owningTopology,
 	)
 
+	r.renderDeploymentNativePrivileges(
+		deployment,
+		nodeName,
+		owningTopology,
+		clabernetesConfigs,
+	)
+
+	r.renderDeploymentReadOnlyRootFilesystem(
+		deployment,
+		nodeName,
+		owningTopology,
+		clabernetesConfigs,
+	)
+
+	r.renderDeploymentNodeSecurityContext(
+		deployment,
+		nodeName,
+		owningTopology,
+		clabernetesConfigs,
+	)
+
+	r.renderDeploymentSecurityContextOverrides(
+		deployment,
+		nodeName,
+		owningTopology,
+	)
+
 	r.renderDeploymentContainerStatus(
 		deployment,
 		nodeName,
 		owningTopology,
+		clabernetesConfigs,
 	)
 
-	r.renderDeploymentDevices(
+	r.renderDeploymentNativeHealthcheck(
+		deployment,
+		nodeName,
+		owningTopology,
+		clabernetesConfigs,
+	)
+
+	r.renderDeploymentLinkCleanup(
 		deployment,
 		owningTopology,
 	)
 
+	r.renderDeploymentDevices(
+		deployment,
+		nodeName,
+		clabernetesConfigs,
+	)
+
 	r.renderDeploymentPersistence(
 		deployment,
 		nodeName,
@@ -263,6 +387,12 @@ func (r *DeploymentReconciler) Render(
 		owningTopology,
 	)
 
+	r.renderDeploymentExtraInitContainers(
+		deployment,
+		nodeName,
+		owningTopology,
+	)
+
 	return deployment
 }
 
@@ -319,6 +449,13 @@ func (r *DeploymentReconciler) Conforms( //nolint: gocyclo
 		return false
 	}
 
+	if !reflect.DeepEqual(
+		existingDeployment.Spec.Template.Spec.Affinity,
+		renderedDeployment.Spec.Template.Spec.Affinity,
+	) {
+		return false
+	}
+
 	if !reflect.DeepEqual(
 		existingDeployment.Spec.Template.Spec.Volumes,
 		renderedDeployment.Spec.Template.Spec.Volumes,
@@ -333,6 +470,20 @@ func (r *DeploymentReconciler) Conforms( //nolint: gocyclo
 		return false
 	}
 
+	if !clabernetesutilkubernetes.ContainersEqual(
+		existingDeployment.Spec.Template.Spec.InitContainers,
+		renderedDeployment.Spec.Template.Spec.InitContainers,
+	) {
+		return false
+	}
+
+	if !podSecurityContextsEqual(
+		existingDeployment.Spec.Template.Spec.SecurityContext,
+		renderedDeployment.Spec.Template.Spec.SecurityContext,
+	) {
+		return false
+	}
+
 	if !reflect.DeepEqual(
 		existingDeployment.Spec.Template.Spec.ServiceAccountName,
 		renderedDeployment.Spec.Template.Spec.ServiceAccountName,
@@ -347,6 +498,49 @@ func (r *DeploymentReconciler) Conforms( //nolint: gocyclo
 		return false
 	}
 
+	if existingDeployment.Spec.Template.Spec.PriorityClassName !=
+		renderedDeployment.Spec.Template.Spec.PriorityClassName {
+		return false
+	}
+
+	if len(existingDeployment.Spec.Template.Spec.ImagePullSecrets) != 0 ||
+		len(renderedDeployment.Spec.Template.Spec.ImagePullSecrets) != 0 {
+		if !reflect.DeepEqual(
+			existingDeployment.Spec.Template.Spec.ImagePullSecrets,
+			renderedDeployment.Spec.Template.Spec.ImagePullSecrets,
+		) {
+			return false
+		}
+	}
+
+	if len(existingDeployment.Spec.Template.Spec.HostAliases) != 0 ||
+		len(renderedDeployment.Spec.Template.Spec.HostAliases) != 0 {
+		if !reflect.DeepEqual(
+			existingDeployment.Spec.Template.Spec.HostAliases,
+			renderedDeployment.Spec.Template.Spec.HostAliases,
+		) {
+			return false
+		}
+	}
+
+	if deploymentDNSPolicy(existingDeployment) != deploymentDNSPolicy(renderedDeployment) {
+		return false
+	}
+
+	if !reflect.DeepEqual(
+		existingDeployment.Spec.Template.Spec.DNSConfig,
+		renderedDeployment.Spec.Template.Spec.DNSConfig,
+	) {
+		return false
+	}
+
+	if !reflect.DeepEqual(
+		existingDeployment.Spec.Template.Spec.RuntimeClassName,
+		renderedDeployment.Spec.Template.Spec.RuntimeClassName,
+	) {
+		return false
+	}
+
 	if !clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
 		existingDeployment.ObjectMeta.Annotations,
 		renderedDeployment.ObjectMeta.Annotations,
@@ -388,10 +582,23 @@ func (r *DeploymentReconciler) Conforms( //nolint: gocyclo
 	return true
 }
 
+// deploymentDNSPolicy returns the dns policy of the given deployment, accounting for the api server
+// defaulting an unset policy to "ClusterFirst".
+func deploymentDNSPolicy(deployment *k8sappsv1.Deployment) k8scorev1.DNSPolicy {
+	if deployment.Spec.Template.Spec.DNSPolicy == "" {
+		return k8scorev1.DNSClusterFirst
+	}
+
+	return deployment.Spec.Template.Spec.DNSPolicy
+}
+
 // DetermineNodesNeedingRestart accepts reconcile data (which contains the previous and current
 // rendered sub-topologies) and updates the reconcile data NodesNeedingReboot set with each node
-// that needs restarting due to configuration changes.
+// that needs restarting due to configuration changes. With the startup-config reapply enabled for
+// the given topology, nodes whose config changed in nothing but their inline startup-config are
+// left running.
 func (r *DeploymentReconciler) DetermineNodesNeedingRestart(
+	owningTopology *clabernetesapisv1alpha1.Topology,
 	reconcileData *ReconcileData,
 ) {
 	// When the rendered containerlab config changes, we have to restart nodes so
@@ -401,17 +608,36 @@ func (r *DeploymentReconciler) DetermineNodesNeedingRestart(
 	// differences can cause endless restart loops.
 	//
 	// The config hash already captures real changes (and is stable), so use it as
-	// the gate for restarts and restart all existing nodes when it changes.
-	if reconcileData.PreviousHashes.Config == reconcileData.ResolvedHashes.Config {
+	// the gate for restarts and restart all existing nodes when it changes. A previous hash that
+	// matches the legacy (non canonical) hash means the topology was last reconciled before the
+	// canonical hash existed and nothing changed since, so we just record the new hash instead.
+	if reconcileData.PreviousHashes.Config == reconcileData.ResolvedHashes.Config ||
+		reconcileData.PreviousHashes.Config == reconcileData.LegacyConfigHash {
 		return
 	}
 
-	for nodeName := range reconcileData.ResolvedConfigs {
-		_, nodeExistedBefore := reconcileData.PreviousConfigs[nodeName]
+	reapplyStartupConfig := ResolveStartupConfigReapply(owningTopology)
+
+	for nodeName, currentConfig := range reconcileData.ResolvedConfigs {
+		previousConfig, nodeExistedBefore := reconcileData.PreviousConfigs[nodeName]
 		if !nodeExistedBefore {
 			continue
 		}
 
+		// with the startup-config reapply enabled we can tell nodes whose config did not change
+		// (or only in their startup-config, which the launcher pushes to the node) from the ones
+		// that really need restarting -- anything unexpected just restarts the node as usual
+		if reapplyStartupConfig &&
+			OnlyStartupConfigChanged(previousConfig, currentConfig, nodeName) {
+			r.log.Debugf(
+				"node %q config changed in nothing but its startup-config (if at all), not"+
+					" restarting it",
+				nodeName,
+			)
+
+			continue
+		}
+
 		reconcileData.NodesNeedingReboot.Add(nodeName)
 	}
 }
@@ -425,6 +651,12 @@ func (r *DeploymentReconciler) renderDeploymentBase(
 ) *k8sappsv1.Deployment {
 	annotations, globalLabels := r.configManagerGetter().GetAllMetadata()
 
+	// extra annotations go in first, so anything clabernetes sets while rendering the rest of the
+	// deployment wins over them
+	for k, v := range ResolveExtraAnnotations(owningTopology, nodeName) {
+		annotations[k] = v
+	}
+
 	selectorLabels := map[string]string{
 		clabernetesconstants.LabelKubernetesName: name,
 		clabernetesconstants.LabelApp:            clabernetesconstants.Clabernetes,
@@ -443,6 +675,14 @@ func (r *DeploymentReconciler) renderDeploymentBase(
 		labels[k] = v
 	}
 
+	for k, v := range ResolveExtraLabels(owningTopology, nodeName) {
+		if _, ok := selectorLabels[k]; ok {
+			continue
+		}
+
+		labels[k] = v
+	}
+
 	deployment := &k8sappsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
@@ -479,6 +719,11 @@ func (r *DeploymentReconciler) renderDeploymentBase(
 		},
 	}
 
+	if owningTopology.Status.Suspended {
+		// outside of the topology schedule windows, scale to zero but leave everything else be
+		deployment.Spec.Replicas = clabernetesutil.ToPointer(int32(0))
+	}
+
 	if ResolveNativeMode(owningTopology) {
 		deployment.Spec.Template.Spec.ShareProcessNamespace = clabernetesutil.ToPointer(true)
 	}
@@ -492,10 +737,22 @@ func (r *DeploymentReconciler) renderDeploymentBase(
 
 func (r *DeploymentReconciler) renderDeploymentScheduling(
 	deployment *k8sappsv1.Deployment,
+	nodeName string,
 	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
 ) {
 	tolerations := owningTopology.Spec.Deployment.Scheduling.Tolerations
 
+	_, overrideTolerations := ResolveSchedulingOverrides(
+		owningTopology,
+		nodeName,
+		deploymentNodeKind(nodeName, clabernetesConfigs),
+	)
+	if len(overrideTolerations) > 0 {
+		// clone so we dont append to (and so mutate) the tolerations of the topology spec
+		tolerations = append(slices.Clone(tolerations), overrideTolerations...)
+	}
+
 	deployment.Spec.Template.Spec.Tolerations = tolerations
 	if owningTopology.Spec.Deployment.Scheduling.Affinity != nil {
 		deployment.Spec.Template.Spec.Affinity = owningTopology.Spec.Deployment.Scheduling.Affinity
@@ -549,85 +806,248 @@ func (r *DeploymentReconciler) renderDeploymentScheduling(
 	}
 }
 
-func (r *DeploymentReconciler) renderDeploymentVolumes( //nolint:funlen
+func (r *DeploymentReconciler) renderDeploymentPriorityClass(
 	deployment *k8sappsv1.Deployment,
-	nodeName,
-	configVolumeName,
-	owningTopologyName string,
+	nodeName string,
 	owningTopology *clabernetesapisv1alpha1.Topology,
-) []k8scorev1.VolumeMount {
-	volumes := []k8scorev1.Volume{
-		{
-			Name: configVolumeName,
-			VolumeSource: k8scorev1.VolumeSource{
-				ConfigMap: &k8scorev1.ConfigMapVolumeSource{
-					LocalObjectReference: k8scorev1.LocalObjectReference{
-						Name: owningTopologyName,
-					},
-					DefaultMode: clabernetesutil.ToPointer(
-						int32(clabernetesconstants.PermissionsEveryoneReadWriteOwnerExecute),
-					),
-				},
-			},
-		},
-		{
-			Name: "docker",
-			VolumeSource: k8scorev1.VolumeSource{
-				EmptyDir: &k8scorev1.EmptyDirVolumeSource{},
-			},
-		},
+) {
+	priorityClassName, ok := owningTopology.Spec.Deployment.NodePriorityClassNames[nodeName]
+	if !ok {
+		priorityClassName = owningTopology.Spec.Deployment.PriorityClassName
 	}
 
-	volumeMountsFromCommonSpec := make([]k8scorev1.VolumeMount, 0)
+	if priorityClassName == "" {
+		// fall back to the global default (if any)
+		priorityClassName = r.configManagerGetter().GetPriorityClassName()
+	}
 
-	criPath, criSubPath := r.renderDeploymentVolumesGetCRISockPath(owningTopology)
+	deployment.Spec.Template.Spec.PriorityClassName = priorityClassName
+}
 
-	if criPath != "" && criSubPath != "" {
-		volumes = append(
-			volumes,
-			k8scorev1.Volume{
-				Name: "cri-sock",
-				VolumeSource: k8scorev1.VolumeSource{
-					HostPath: &k8scorev1.HostPathVolumeSource{
-						Path: criPath,
-						Type: clabernetesutil.ToPointer(k8scorev1.HostPathType("")),
-					},
-				},
-			},
-		)
+// renderDeploymentImagePullSecrets sets the propagated pull secret (if any is configured) as image
+// pull secret of the launcher pod -- the pull secret reconciler makes sure it exists in the
+// namespace of the topology.
+func (r *DeploymentReconciler) renderDeploymentImagePullSecrets(
+	deployment *k8sappsv1.Deployment,
+) {
+	pullSecretName := r.configManagerGetter().GetPropagatedPullSecret()
+	if pullSecretName == "" {
+		return
+	}
 
-		volumeMountsFromCommonSpec = append(
-			volumeMountsFromCommonSpec,
-			k8scorev1.VolumeMount{
-				Name:     "cri-sock",
-				ReadOnly: true,
-				MountPath: fmt.Sprintf(
-					"%s/%s",
-					clabernetesconstants.LauncherCRISockPath,
-					criSubPath,
-				),
-				SubPath: criSubPath,
-			},
-		)
+	deployment.Spec.Template.Spec.ImagePullSecrets = []k8scorev1.LocalObjectReference{
+		{
+			Name: pullSecretName,
+		},
 	}
+}
 
-	dockerDaemonConfigSecret := owningTopology.Spec.ImagePull.DockerDaemonConfig
-	if dockerDaemonConfigSecret == "" {
-		dockerDaemonConfigSecret = r.configManagerGetter().GetDockerDaemonConfig()
+func (r *DeploymentReconciler) renderDeploymentHostAliases(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) {
+	hostAliases := ResolveHostAliases(owningTopology, nodeName)
+	if len(hostAliases) == 0 {
+		return
 	}
 
-	if dockerDaemonConfigSecret != "" {
-		volumes = append(
-			volumes,
-			k8scorev1.Volume{
-				Name: "docker-daemon-config",
-				VolumeSource: k8scorev1.VolumeSource{
-					Secret: &k8scorev1.SecretVolumeSource{
-						SecretName: dockerDaemonConfigSecret,
-						DefaultMode: clabernetesutil.ToPointer(
-							int32(clabernetesconstants.PermissionsEveryoneReadWriteOwnerExecute),
-						),
-					},
+	deployment.Spec.Template.Spec.HostAliases = hostAliases
+}
+
+func (r *DeploymentReconciler) renderDeploymentDNS(
+	deployment *k8sappsv1.Deployment,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) {
+	if owningTopology.Spec.Network == nil {
+		return
+	}
+
+	deployment.Spec.Template.Spec.DNSPolicy = owningTopology.Spec.Network.DNSPolicy
+	deployment.Spec.Template.Spec.DNSConfig = owningTopology.Spec.Network.DNSConfig.DeepCopy()
+}
+
+// renderDeploymentExtraInitContainers puts the extra init containers of the node ahead of the
+// clabernetes setup init container. The fields the api server defaults are defaulted here already,
+// otherwise the deployment would never conform.
+func (r *DeploymentReconciler) renderDeploymentExtraInitContainers(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) {
+	extraInitContainers := ResolveExtraInitContainers(owningTopology, nodeName)
+	if len(extraInitContainers) == 0 {
+		return
+	}
+
+	for idx := range extraInitContainers {
+		defaultContainer(&extraInitContainers[idx])
+	}
+
+	deployment.Spec.Template.Spec.InitContainers = append(
+		extraInitContainers,
+		deployment.Spec.Template.Spec.InitContainers...,
+	)
+}
+
+// defaultContainer sets the (commonly set) fields of the given user provided container the api
+// server defaults if they are unset.
+func defaultContainer(container *k8scorev1.Container) {
+	if container.TerminationMessagePath == "" {
+		container.TerminationMessagePath = k8scorev1.TerminationMessagePathDefault
+	}
+
+	if container.TerminationMessagePolicy == "" {
+		container.TerminationMessagePolicy = k8scorev1.TerminationMessageReadFile
+	}
+
+	if container.ImagePullPolicy == "" {
+		container.ImagePullPolicy = k8scorev1.PullIfNotPresent
+
+		if !strings.Contains(container.Image, "@") {
+			imageName := container.Image[strings.LastIndex(container.Image, "/")+1:]
+
+			_, tag, hasTag := strings.Cut(imageName, ":")
+			if !hasTag || tag == "latest" {
+				container.ImagePullPolicy = k8scorev1.PullAlways
+			}
+		}
+	}
+
+	for idx := range container.Ports {
+		if container.Ports[idx].Protocol == "" {
+			container.Ports[idx].Protocol = k8scorev1.ProtocolTCP
+		}
+	}
+}
+
+func (r *DeploymentReconciler) renderDeploymentRuntimeClass(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) {
+	runtimeClassName := ResolveRuntimeClassName(
+		owningTopology,
+		nodeName,
+		deploymentNodeKind(nodeName, clabernetesConfigs),
+	)
+	if runtimeClassName == "" {
+		return
+	}
+
+	deployment.Spec.Template.Spec.RuntimeClassName = clabernetesutil.ToPointer(runtimeClassName)
+
+	if resolveRuntimeSandbox(runtimeClassName) == runtimeSandboxSysbox {
+		// sysbox pods need a user namespace; cri-o handles this via annotation, containerd
+		// (w/ sysbox) just ignores it so no harm setting it regardless of the cri
+		deployment.Spec.Template.ObjectMeta.Annotations["io.kubernetes.cri-o.userns-mode"] =
+			"auto:size=65536"
+	}
+}
+
+// deploymentNodeKind returns the containerlab kind of the given node, or an empty string if there
+// is no config for the node.
+func deploymentNodeKind(
+	nodeName string,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) string {
+	if clabernetesConfigs[nodeName] == nil {
+		return ""
+	}
+
+	nodeKind, _ := clabernetesConfigs[nodeName].Topology.GetNodeKindType(nodeName)
+
+	return nodeKind
+}
+
+func (r *DeploymentReconciler) getRuntimeSandbox(deployment *k8sappsv1.Deployment) string {
+	if deployment.Spec.Template.Spec.RuntimeClassName == nil {
+		return ""
+	}
+
+	return resolveRuntimeSandbox(*deployment.Spec.Template.Spec.RuntimeClassName)
+}
+
+func (r *DeploymentReconciler) renderDeploymentVolumes( //nolint:funlen
+	deployment *k8sappsv1.Deployment,
+	nodeName,
+	configVolumeName,
+	owningTopologyName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) []k8scorev1.VolumeMount {
+	volumes := []k8scorev1.Volume{
+		{
+			Name: configVolumeName,
+			VolumeSource: k8scorev1.VolumeSource{
+				ConfigMap: &k8scorev1.ConfigMapVolumeSource{
+					LocalObjectReference: k8scorev1.LocalObjectReference{
+						Name: owningTopologyName,
+					},
+					DefaultMode: clabernetesutil.ToPointer(
+						int32(clabernetesconstants.PermissionsEveryoneReadWriteOwnerExecute),
+					),
+				},
+			},
+		},
+		{
+			Name: "docker",
+			VolumeSource: k8scorev1.VolumeSource{
+				EmptyDir: &k8scorev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+
+	volumeMountsFromCommonSpec := make([]k8scorev1.VolumeMount, 0)
+
+	criPath, criSubPath := r.renderDeploymentVolumesGetCRISockPath(owningTopology)
+
+	if criPath != "" && criSubPath != "" {
+		volumes = append(
+			volumes,
+			k8scorev1.Volume{
+				Name: "cri-sock",
+				VolumeSource: k8scorev1.VolumeSource{
+					HostPath: &k8scorev1.HostPathVolumeSource{
+						Path: criPath,
+						Type: clabernetesutil.ToPointer(k8scorev1.HostPathType("")),
+					},
+				},
+			},
+		)
+
+		volumeMountsFromCommonSpec = append(
+			volumeMountsFromCommonSpec,
+			k8scorev1.VolumeMount{
+				Name:     "cri-sock",
+				ReadOnly: true,
+				MountPath: fmt.Sprintf(
+					"%s/%s",
+					clabernetesconstants.LauncherCRISockPath,
+					criSubPath,
+				),
+				SubPath: criSubPath,
+			},
+		)
+	}
+
+	dockerDaemonConfigSecret := owningTopology.Spec.ImagePull.DockerDaemonConfig
+	if dockerDaemonConfigSecret == "" {
+		dockerDaemonConfigSecret = r.configManagerGetter().GetDockerDaemonConfig()
+	}
+
+	if dockerDaemonConfigSecret != "" {
+		volumes = append(
+			volumes,
+			k8scorev1.Volume{
+				Name: "docker-daemon-config",
+				VolumeSource: k8scorev1.VolumeSource{
+					Secret: &k8scorev1.SecretVolumeSource{
+						SecretName: dockerDaemonConfigSecret,
+						DefaultMode: clabernetesutil.ToPointer(
+							int32(clabernetesconstants.PermissionsEveryoneReadWriteOwnerExecute),
+						),
+					},
 				},
 			},
 		)
@@ -673,6 +1093,29 @@ func (r *DeploymentReconciler) renderDeploymentVolumes( //nolint:funlen
 		)
 	}
 
+	packetCaptureClaimName := owningTopology.Spec.Deployment.PacketCaptureClaimName
+	if packetCaptureClaimName != "" {
+		volumes = append(
+			volumes,
+			k8scorev1.Volume{
+				Name: clabernetesconstants.LauncherPacketCaptureVolumeName,
+				VolumeSource: k8scorev1.VolumeSource{
+					PersistentVolumeClaim: &k8scorev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: packetCaptureClaimName,
+					},
+				},
+			},
+		)
+
+		volumeMountsFromCommonSpec = append(
+			volumeMountsFromCommonSpec,
+			k8scorev1.VolumeMount{
+				Name:      clabernetesconstants.LauncherPacketCaptureVolumeName,
+				MountPath: clabernetesconstants.LauncherPacketCaptureDir,
+			},
+		)
+	}
+
 	volumesFromConfigMaps := make([]clabernetesapisv1alpha1.FileFromConfigMap, 0)
 
 	volumesFromConfigMaps = append(
@@ -739,11 +1182,73 @@ func (r *DeploymentReconciler) renderDeploymentVolumes( //nolint:funlen
 		)
 	}
 
+	for _, podVolume := range owningTopology.Spec.Deployment.FilesFromSecret[nodeName] {
+		volumeName := fileFromSecretVolumeName(podVolume)
+
+		var mode *int32
+
+		switch podVolume.Mode {
+		case clabernetesconstants.FileModeRead:
+			mode = clabernetesutil.ToPointer(
+				int32(clabernetesconstants.PermissionsEveryoneRead),
+			)
+		case clabernetesconstants.FileModeExecute:
+			mode = clabernetesutil.ToPointer(
+				int32(clabernetesconstants.PermissionsEveryoneReadExecute),
+			)
+		default:
+			mode = nil
+		}
+
+		volumes = append(
+			volumes,
+			k8scorev1.Volume{
+				Name: volumeName,
+				VolumeSource: k8scorev1.VolumeSource{
+					Secret: &k8scorev1.SecretVolumeSource{
+						SecretName:  podVolume.SecretName,
+						DefaultMode: mode,
+					},
+				},
+			},
+		)
+
+		var mountPath string
+		// mount relative paths under /clabernetes, and absolute paths as is
+		if strings.HasPrefix(podVolume.FilePath, "/") {
+			mountPath = podVolume.FilePath
+		} else {
+			mountPath = fmt.Sprintf("/clabernetes/%s", podVolume.FilePath)
+		}
+
+		volumeMountsFromCommonSpec = append(
+			volumeMountsFromCommonSpec,
+			k8scorev1.VolumeMount{
+				Name:      volumeName,
+				ReadOnly:  true,
+				MountPath: mountPath,
+				SubPath:   podVolume.SecretKey,
+			},
+		)
+	}
+
 	deployment.Spec.Template.Spec.Volumes = volumes
 
 	return volumeMountsFromCommonSpec
 }
 
+// fileFromSecretVolumeName returns the name of the volume of the given file from secret -- prefixed
+// so it does not collide with the volume of a file from a config map of the same name and path.
+func fileFromSecretVolumeName(fileFromSecret clabernetesapisv1alpha1.FileFromSecret) string {
+	return clabernetesutilkubernetes.EnforceDNSLabelConvention(
+		clabernetesutilkubernetes.SafeConcatNameKubernetes(
+			"secret",
+			fileFromSecret.SecretName,
+			fileFromSecret.SecretKey,
+		),
+	)
+}
+
 func (r *DeploymentReconciler) renderDeploymentVolumesGetCRISockPath(
 	owningTopology *clabernetesapisv1alpha1.Topology,
 ) (path, subPath string) {
@@ -809,6 +1314,16 @@ func (r *DeploymentReconciler) renderDeploymentContainer(
 		imagePullPolicy = r.configManagerGetter().GetLauncherImagePullPolicy()
 	}
 
+	terminationMessagePolicy := owningTopology.Spec.Deployment.TerminationMessagePolicy
+	if terminationMessagePolicy == "" {
+		terminationMessagePolicy = string(k8scorev1.TerminationMessageFallbackToLogsOnError)
+	}
+
+	connectivityPorts := ResolveConnectivityPorts(
+		owningTopology,
+		r.configManagerGetter().GetConnectivityPorts(),
+	)
+
 	launcherContainer := k8scorev1.Container{
 		Name:       nodeName,
 		WorkingDir: "/clabernetes",
@@ -817,12 +1332,27 @@ func (r *DeploymentReconciler) renderDeploymentContainer(
 		Ports: []k8scorev1.ContainerPort{
 			{
 				Name:          clabernetesconstants.ConnectivityVXLAN,
-				ContainerPort: clabernetesconstants.VXLANServicePort,
+				ContainerPort: connectivityPorts.VXLAN,
 				Protocol:      clabernetesconstants.UDP,
 			},
 			{
 				Name:          clabernetesconstants.ConnectivitySlurpeeth,
-				ContainerPort: clabernetesconstants.SlurpeethServicePort,
+				ContainerPort: connectivityPorts.Slurpeeth,
+				Protocol:      clabernetesconstants.TCP,
+			},
+			{
+				Name:          clabernetesconstants.ConnectivityGeneve,
+				ContainerPort: connectivityPorts.Geneve,
+				Protocol:      clabernetesconstants.UDP,
+			},
+			{
+				Name:          clabernetesconstants.ConnectivityWireGuard,
+				ContainerPort: connectivityPorts.WireGuard,
+				Protocol:      clabernetesconstants.UDP,
+			},
+			{
+				Name:          clabernetesconstants.MetricsPortName,
+				ContainerPort: clabernetesconstants.MetricsServicePort,
 				Protocol:      clabernetesconstants.TCP,
 			},
 		},
@@ -851,8 +1381,8 @@ func (r *DeploymentReconciler) renderDeploymentContainer(
 				MountPath: "/var/lib/docker",
 			},
 		},
-		TerminationMessagePath:   "/dev/termination-log",
-		TerminationMessagePolicy: "File",
+		TerminationMessagePath:   clabernetesconstants.TerminationMessagePath,
+		TerminationMessagePolicy: k8scorev1.TerminationMessagePolicy(terminationMessagePolicy),
 		ImagePullPolicy:          k8scorev1.PullPolicy(imagePullPolicy),
 	}
 
@@ -898,8 +1428,8 @@ func (r *DeploymentReconciler) renderDeploymentContainer(
 				MountPath: "/clabernetes",
 			},
 		},
-		TerminationMessagePath:   "/dev/termination-log",
-		TerminationMessagePolicy: "File",
+		TerminationMessagePath:   clabernetesconstants.TerminationMessagePath,
+		TerminationMessagePolicy: k8scorev1.TerminationMessagePolicy(terminationMessagePolicy),
 		ImagePullPolicy:          k8scorev1.PullPolicy(imagePullPolicy),
 	}
 
@@ -1136,6 +1666,26 @@ func (r *DeploymentReconciler) renderDeploymentContainer(
 			}
 			slices.Sort(setenvKeys)
 			var cmd strings.Builder
+
+			// Write the interface mapping rendered from the links so the linux interfaces come up
+			// as the front panel interfaces their names stand for, rather than relying on INTFTYPE
+			// matching them -- unless the user mounted their own mapping file.
+			intfMappingPath := "/mnt/flash/EosIntfMapping.json"
+			if _, ok := existingMounts[intfMappingPath]; !ok {
+				intfMapping, ok := RenderCEOSIntfMapping(
+					nodeName,
+					clabernetesConfigs[nodeName].Topology,
+				)
+				if ok {
+					fmt.Fprintf(
+						&cmd,
+						"mkdir -p /mnt/flash; printf '%%s\\n' '%s' > %s; ",
+						intfMapping,
+						intfMappingPath,
+					)
+				}
+			}
+
 			cmd.WriteString("exec /sbin/init ")
 			for _, k := range setenvKeys {
 				cmd.WriteString("systemd.setenv=")
@@ -1529,6 +2079,29 @@ exec ./iol.bin "$IOL_PID" -e "$slots" -s 0 -c config.txt -n 1024
 `, nodeName, pid, nodeName))}
 		}
 
+		// the built-in kind drivers can be disabled, the native kind settings of the global config
+		// apply regardless
+		applyNativeKind(
+			&nativeNode{
+				log:            r.log,
+				deployment:     deployment,
+				container:      &nosContainer,
+				owningTopology: owningTopology,
+				nodeName:       nodeName,
+				nodeDefinition: nodeDef,
+				topology:       clabernetesConfigs[nodeName].Topology,
+				resources: r.ResolveLauncherResources(
+					owningTopology,
+					clabernetesConfigs,
+					nodeName,
+				),
+			},
+			r.configManagerGetter().IsFeatureGateEnabled(
+				clabernetesconstants.FeatureGateNativeKindDrivers,
+			),
+			r.configManagerGetter().GetNativeKinds(),
+		)
+
 		// Best-effort support for bind mounts in native mode.
 		//
 		// In "classic" (non-native) mode, containerlab/Docker handles common node requirements for
@@ -1716,11 +2289,15 @@ func (r *DeploymentReconciler) renderDeploymentMultus(
 
 	var networkNames []string
 
-	for idx := range nodeConfig.Topology.Links {
-		// we use the index from the original links slice as the unique ID for the NAD
-		// this assumes the link order is stable, which it should be since it comes from
-		// the same topology object.
-		nadName := fmt.Sprintf("%s-l%d", topologyName, idx)
+	stableNames := r.configManagerGetter().IsFeatureGateEnabled(
+		clabernetesconstants.FeatureGateStableNetworkAttachmentNames,
+	)
+
+	for idx, link := range nodeConfig.Topology.Links {
+		// with stable names the NAD is named after the link endpoints, so adding or removing
+		// other links does not rename (and so re-plumb) this one; legacy names use the index of
+		// the link in the sub-topology.
+		nadName := NetworkAttachmentDefinitionName(topologyName, link, idx, stableNames)
 		networkNames = append(networkNames, nadName)
 	}
 
@@ -1732,8 +2309,9 @@ func (r *DeploymentReconciler) renderDeploymentMultus(
 	// for simplicity we'll just use the short format if possible, but let's do the json one
 	// to be explicit and future-proof.
 	type multusNet struct {
-		Name      string `json:"name"`
-		Namespace string `json:"namespace,omitempty"`
+		Name      string   `json:"name"`
+		Namespace string   `json:"namespace,omitempty"`
+		IPs       []string `json:"ips,omitempty"`
 	}
 
 	multusNets := make([]multusNet, len(networkNames))
@@ -1751,11 +2329,18 @@ func (r *DeploymentReconciler) renderDeploymentMultus(
 	//
 	// This NetworkAttachmentDefinition is installed by Skyforge Helm as:
 	//   kube-system/vrnetlab-mgmt
-	if node, ok := nodeConfig.Topology.Nodes[nodeName]; ok {
-		switch strings.TrimSpace(node.Kind) {
-		case "cisco_iol", "vios", "viosl2", "vr-n9kv", "asav", "vmx", "sros", "csr":
-			multusNets = append(multusNets, multusNet{Name: "vrnetlab-mgmt", Namespace: "kube-system"})
-		}
+	//
+	// The containerlab mgmt address of the node (if any) is requested on this interface, this
+	// requires the ipam of the attachment to support static ips.
+	if usesMgmtAttachment(owningTopology, nodeConfig, nodeName) {
+		multusNets = append(
+			multusNets,
+			multusNet{
+				Name:      vrnetlabMgmtNetworkName,
+				Namespace: vrnetlabMgmtNetworkNamespace,
+				IPs:       ResolveMgmtAddresses(owningTopology, nodeConfig, nodeName),
+			},
+		)
 	}
 
 	multusNetsJSON, err := json.Marshal(multusNets)
@@ -1769,27 +2354,271 @@ func (r *DeploymentReconciler) renderDeploymentMultus(
 		deployment.Spec.Template.Annotations = make(map[string]string)
 	}
 
-	deployment.Spec.Template.Annotations["k8s.v1.cni.cncf.io/networks"] = string(multusNetsJSON)
+	deployment.Spec.Template.Annotations[clabernetesconstants.MultusNetworksAnnotation] = string(
+		multusNetsJSON,
+	)
 }
 
-func (r *DeploymentReconciler) renderDeploymentNative(
+func (r *DeploymentReconciler) renderDeploymentUnderlay(
 	deployment *k8sappsv1.Deployment,
-	nodeName,
-	configVolumeName string,
-	volumeMountsFromCommonSpec []k8scorev1.VolumeMount,
 	owningTopology *clabernetesapisv1alpha1.Topology,
-	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
 ) {
-	if !ResolveNativeMode(owningTopology) {
+	underlay := ResolveUnderlay(owningTopology)
+	if underlay == nil {
+		if owningTopology.Spec.Network != nil && owningTopology.Spec.Network.Underlay != nil {
+			r.log.Warnf(
+				"ignoring underlay for topology %s, underlay is not supported with %q"+
+					" connectivity or the host network",
+				owningTopology.Name,
+				owningTopology.Spec.Connectivity,
+			)
+		}
+
 		return
 	}
 
-	// When ShareProcessNamespace is enabled, the "native" NOS container may not end up as PID 1.
-	// Systemd-based NOS images (like Arista cEOS) require being PID 1 and will crash otherwise.
-	//
-	// Disable process namespace sharing for these nodes while still allowing native mode.
-	if clabernetesConfigs != nil && clabernetesConfigs[nodeName] != nil {
-		if node, ok := clabernetesConfigs[nodeName].Topology.Nodes[nodeName]; ok {
+	type multusNet struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace,omitempty"`
+		Interface string `json:"interface"`
+	}
+
+	underlayNet := multusNet{
+		Name:      underlay.NetworkAttachmentDefinition,
+		Interface: underlay.Interface,
+	}
+
+	namespace, name, ok := strings.Cut(underlay.NetworkAttachmentDefinition, "/")
+	if ok {
+		underlayNet.Namespace = namespace
+		underlayNet.Name = name
+	}
+
+	multusNetsJSON, err := json.Marshal([]multusNet{underlayNet})
+	if err != nil {
+		r.log.Criticalf("failed marshaling underlay network to json, error: %s", err)
+
+		return
+	}
+
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = make(map[string]string)
+	}
+
+	deployment.Spec.Template.Annotations[clabernetesconstants.MultusNetworksAnnotation] = string(
+		multusNetsJSON,
+	)
+}
+
+func (r *DeploymentReconciler) renderDeploymentWireGuard(
+	deployment *k8sappsv1.Deployment,
+	nodeName,
+	owningTopologyName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) {
+	if owningTopology.Spec.Connectivity != clabernetesconstants.ConnectivityWireGuard {
+		return
+	}
+
+	// only mount this nodes private key -- all nodes get all the public keys/addresses though
+	// since they need those to set up their peers. The peers secret is mounted as a whole so that
+	// nodes coming and going only update the mounted files rather than the pod spec (which would
+	// restart every launcher).
+	deployment.Spec.Template.Spec.Volumes = append(
+		deployment.Spec.Template.Spec.Volumes,
+		k8scorev1.Volume{
+			Name: "wireguard",
+			VolumeSource: k8scorev1.VolumeSource{
+				Secret: &k8scorev1.SecretVolumeSource{
+					SecretName: wireGuardSecretName(owningTopologyName),
+					Items: []k8scorev1.KeyToPath{
+						{
+							Key:  wireGuardSecretKey(nodeName, wireGuardPrivateKeySuffix),
+							Path: wireGuardPrivateKeySuffix,
+						},
+					},
+					DefaultMode: clabernetesutil.ToPointer(
+						int32(clabernetesconstants.PermissionsOwnerRead),
+					),
+				},
+			},
+		},
+		k8scorev1.Volume{
+			Name: "wireguard-peers",
+			VolumeSource: k8scorev1.VolumeSource{
+				Secret: &k8scorev1.SecretVolumeSource{
+					SecretName: wireGuardPeersSecretName(owningTopologyName),
+					DefaultMode: clabernetesutil.ToPointer(
+						int32(clabernetesconstants.PermissionsOwnerRead),
+					),
+				},
+			},
+		},
+	)
+
+	launcherContainer := r.getLauncherContainer(deployment)
+
+	launcherContainer.VolumeMounts = append(
+		launcherContainer.VolumeMounts,
+		k8scorev1.VolumeMount{
+			Name:      "wireguard",
+			ReadOnly:  true,
+			MountPath: clabernetesconstants.LauncherWireGuardPath,
+		},
+		k8scorev1.VolumeMount{
+			Name:      "wireguard-peers",
+			ReadOnly:  true,
+			MountPath: clabernetesconstants.LauncherWireGuardPeersPath,
+		},
+	)
+}
+
+func (r *DeploymentReconciler) renderDeploymentSlurpeethTLS(
+	deployment *k8sappsv1.Deployment,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) {
+	if !slurpeethTLSEnabled(owningTopology) {
+		return
+	}
+
+	deployment.Spec.Template.Spec.Volumes = append(
+		deployment.Spec.Template.Spec.Volumes,
+		k8scorev1.Volume{
+			Name: "slurpeeth-tls",
+			VolumeSource: k8scorev1.VolumeSource{
+				Secret: &k8scorev1.SecretVolumeSource{
+					SecretName: resolveSlurpeethTLSSecretName(owningTopology),
+					Items: []k8scorev1.KeyToPath{
+						{
+							Key:  slurpeethTLSCAKey,
+							Path: slurpeethTLSCAKey,
+						},
+						{
+							Key:  slurpeethTLSCertKey,
+							Path: slurpeethTLSCertKey,
+						},
+						{
+							Key:  slurpeethTLSKeyKey,
+							Path: slurpeethTLSKeyKey,
+						},
+					},
+					DefaultMode: clabernetesutil.ToPointer(
+						int32(clabernetesconstants.PermissionsOwnerRead),
+					),
+				},
+			},
+		},
+	)
+
+	launcherContainer := r.getLauncherContainer(deployment)
+
+	launcherContainer.VolumeMounts = append(
+		launcherContainer.VolumeMounts,
+		k8scorev1.VolumeMount{
+			Name:      "slurpeeth-tls",
+			ReadOnly:  true,
+			MountPath: clabernetesconstants.LauncherSlurpeethTLSPath,
+		},
+	)
+}
+
+func (r *DeploymentReconciler) renderDeploymentConfigPush(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) {
+	configPushFile := ResolveConfigPushFile(owningTopology, nodeName, clabernetesConfigs)
+	if configPushFile == nil {
+		return
+	}
+
+	// the startup-config ConfigMap volume already exists (see renderDeploymentVolumes), but that
+	// is mounted with a sub path so never sees updates -- mount the whole ConfigMap again so the
+	// launcher can watch the startup-config change
+	volumeName := clabernetesutilkubernetes.EnforceDNSLabelConvention(
+		clabernetesutilkubernetes.SafeConcatNameKubernetes(
+			configPushFile.ConfigMapName,
+			configPushFile.ConfigMapPath,
+		),
+	)
+
+	launcherContainer := r.getLauncherContainer(deployment)
+
+	launcherContainer.VolumeMounts = append(
+		launcherContainer.VolumeMounts,
+		k8scorev1.VolumeMount{
+			Name:      volumeName,
+			ReadOnly:  true,
+			MountPath: clabernetesconstants.LauncherConfigPushPath,
+		},
+	)
+
+	launcherContainer.Env = append(
+		launcherContainer.Env,
+		k8scorev1.EnvVar{
+			Name: clabernetesconstants.LauncherConfigPushFileEnv,
+			Value: filepath.Join(
+				clabernetesconstants.LauncherConfigPushPath,
+				configPushFile.ConfigMapPath,
+			),
+		},
+	)
+}
+
+func (r *DeploymentReconciler) renderDeploymentStartupConfigReapply(
+	deployment *k8sappsv1.Deployment,
+	nodeName,
+	configVolumeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) {
+	if !ResolveStartupConfigReapply(owningTopology) {
+		return
+	}
+
+	// topo.clab.yaml is mounted with a sub path so never sees updates -- mount the whole config
+	// ConfigMap again so the launcher can watch the startup-config of its node change
+	launcherContainer := r.getLauncherContainer(deployment)
+
+	launcherContainer.VolumeMounts = append(
+		launcherContainer.VolumeMounts,
+		k8scorev1.VolumeMount{
+			Name:      configVolumeName,
+			ReadOnly:  true,
+			MountPath: clabernetesconstants.LauncherLiveTopologyPath,
+		},
+	)
+
+	launcherContainer.Env = append(
+		launcherContainer.Env,
+		k8scorev1.EnvVar{
+			Name: clabernetesconstants.LauncherStartupConfigReapplyFileEnv,
+			Value: filepath.Join(
+				clabernetesconstants.LauncherLiveTopologyPath,
+				nodeName,
+			),
+		},
+	)
+}
+
+func (r *DeploymentReconciler) renderDeploymentNative(
+	deployment *k8sappsv1.Deployment,
+	nodeName,
+	configVolumeName string,
+	volumeMountsFromCommonSpec []k8scorev1.VolumeMount,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) {
+	if !ResolveNativeMode(owningTopology) {
+		return
+	}
+
+	// When ShareProcessNamespace is enabled, the "native" NOS container may not end up as PID 1.
+	// Systemd-based NOS images (like Arista cEOS) require being PID 1 and will crash otherwise.
+	//
+	// Disable process namespace sharing for these nodes while still allowing native mode.
+	if clabernetesConfigs != nil && clabernetesConfigs[nodeName] != nil {
+		if node, ok := clabernetesConfigs[nodeName].Topology.Nodes[nodeName]; ok {
 			switch node.Kind {
 			case "ceos", "eos":
 				deployment.Spec.Template.Spec.ShareProcessNamespace = nil
@@ -1809,6 +2638,70 @@ func (r *DeploymentReconciler) renderDeploymentNative(
 	)
 }
 
+// renderDeploymentContainerPorts declares the (exposed) containerlab ports of the node as container
+// ports of the container named after the node. In native mode that is the nos container, which
+// listens on the destination ports in the pod network namespace directly. Otherwise it is the
+// launcher container, in which docker publishes the expose ports -- on all addresses, so they can
+// be reached on the pod ip (and so via the expose service).
+func (r *DeploymentReconciler) renderDeploymentContainerPorts(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) {
+	if owningTopology.Spec.Expose.DisableExpose {
+		return
+	}
+
+	nativeMode := ResolveNativeMode(owningTopology)
+
+	connectivityPorts := ResolveConnectivityPorts(
+		owningTopology,
+		r.configManagerGetter().GetConnectivityPorts(),
+	)
+
+	var containerPorts []k8scorev1.ContainerPort
+
+	for _, portDefinition := range nodePortDefinitions(clabernetesConfigs[nodeName], nodeName) {
+		typedPort, err := clabernetesutilcontainerlab.ProcessPortDefinition(portDefinition)
+		if err != nil {
+			continue
+		}
+
+		protocol := k8scorev1.Protocol(typedPort.Protocol)
+
+		port := int32(typedPort.ExposePort) //nolint: gosec
+		if nativeMode {
+			port = int32(typedPort.DestinationPort) //nolint: gosec
+		}
+
+		if connectivityPortInUse(connectivityPorts, protocol, port) {
+			continue
+		}
+
+		containerPorts = append(containerPorts, k8scorev1.ContainerPort{
+			ContainerPort: port,
+			Protocol:      protocol,
+		})
+	}
+
+	if len(containerPorts) == 0 {
+		return
+	}
+
+	for i := range deployment.Spec.Template.Spec.Containers {
+		if deployment.Spec.Template.Spec.Containers[i].Name != nodeName {
+			continue
+		}
+
+		// the launcher container already declares the connectivity ports
+		deployment.Spec.Template.Spec.Containers[i].Ports = append(
+			deployment.Spec.Template.Spec.Containers[i].Ports,
+			containerPorts...,
+		)
+	}
+}
+
 func (r *DeploymentReconciler) getLauncherContainer(
 	deployment *k8sappsv1.Deployment,
 ) *k8scorev1.Container {
@@ -1821,6 +2714,84 @@ func (r *DeploymentReconciler) getLauncherContainer(
 	return &deployment.Spec.Template.Spec.Containers[0]
 }
 
+// renderConnectivityPortsEnv returns the launcher env vars for any connectivity ports that do not
+// use their default value -- the launcher falls back to the defaults if the vars are not set.
+func renderConnectivityPortsEnv(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	configManager clabernetesconfig.Manager,
+) []k8scorev1.EnvVar {
+	connectivityPorts := ResolveConnectivityPorts(
+		owningTopology,
+		configManager.GetConnectivityPorts(),
+	)
+
+	var envs []k8scorev1.EnvVar
+
+	for _, port := range []struct {
+		envName     string
+		port        int32
+		defaultPort int32
+	}{
+		{
+			envName:     clabernetesconstants.LauncherVXLANPortEnv,
+			port:        connectivityPorts.VXLAN,
+			defaultPort: clabernetesconstants.VXLANServicePort,
+		},
+		{
+			envName:     clabernetesconstants.LauncherSlurpeethPortEnv,
+			port:        connectivityPorts.Slurpeeth,
+			defaultPort: clabernetesconstants.SlurpeethServicePort,
+		},
+		{
+			envName:     clabernetesconstants.LauncherGenevePortEnv,
+			port:        connectivityPorts.Geneve,
+			defaultPort: clabernetesconstants.GeneveServicePort,
+		},
+		{
+			envName:     clabernetesconstants.LauncherWireGuardPortEnv,
+			port:        connectivityPorts.WireGuard,
+			defaultPort: clabernetesconstants.WireGuardServicePort,
+		},
+	} {
+		if port.port == port.defaultPort {
+			continue
+		}
+
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  port.envName,
+				Value: strconv.Itoa(int(port.port)),
+			},
+		)
+	}
+
+	return envs
+}
+
+// renderWireGuardOverlayEnv returns the launcher env var for the wireguard overlay range if the
+// topology uses wireguard connectivity with a non default range. An invalid range is skipped here,
+// the wireguard secret reconcile already fails (and so surfaces) it.
+func renderWireGuardOverlayEnv(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) []k8scorev1.EnvVar {
+	if owningTopology.Spec.Connectivity != clabernetesconstants.ConnectivityWireGuard {
+		return nil
+	}
+
+	overlayPrefix, err := ResolveWireGuardOverlayPrefix(owningTopology)
+	if err != nil || overlayPrefix.String() == clabernetesconstants.WireGuardOverlayCIDR {
+		return nil
+	}
+
+	return []k8scorev1.EnvVar{
+		{
+			Name:  clabernetesconstants.LauncherWireGuardOverlayCIDREnv,
+			Value: overlayPrefix.String(),
+		},
+	}
+}
+
 func (r *DeploymentReconciler) renderDeploymentContainerEnv( //nolint: funlen
 	deployment *k8sappsv1.Deployment,
 	nodeName,
@@ -1844,7 +2815,10 @@ func (r *DeploymentReconciler) renderDeploymentContainerEnv( //nolint: funlen
 	}
 
 	nodeImage := clabernetesConfigs[nodeName].Topology.GetNodeImage(nodeName)
-	if nodeImage == "" {
+	nodeKind, _ := clabernetesConfigs[nodeName].Topology.GetNodeKindType(nodeName)
+
+	// bridges have no image, so only complain if this is an actual node
+	if nodeImage == "" && nodeKind != clabernetesconstants.BridgeKind {
 		r.log.Warnf(
 			"could not parse image for node %q, topology in question printined in debug log",
 			nodeName,
@@ -1942,6 +2916,21 @@ func (r *DeploymentReconciler) renderDeploymentContainerEnv( //nolint: funlen
 		},
 	}
 
+	envs = append(envs, renderConnectivityPortsEnv(owningTopology, r.configManagerGetter())...)
+
+	envs = append(envs, renderWireGuardOverlayEnv(owningTopology)...)
+
+	linkConnectivityKinds := ResolveLinkConnectivityKinds(owningTopology)
+	if len(linkConnectivityKinds) > 0 {
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherLinkConnectivityKindsEnv,
+				Value: strings.Join(linkConnectivityKinds, ","),
+			},
+		)
+	}
+
 	if ResolveNativeMode(owningTopology) {
 		envs = append(
 			envs,
@@ -1975,6 +2964,151 @@ func (r *DeploymentReconciler) renderDeploymentContainerEnv( //nolint: funlen
 		)
 	}
 
+	if owningTopology.Spec.Expose.PortExposureMode == clabernetesconstants.PortExposureModeNFTables {
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherPortExposureModeEnv,
+				Value: clabernetesconstants.PortExposureModeNFTables,
+			},
+		)
+	}
+
+	if owningTopology.Spec.Deployment.HostRequirements != nil {
+		hostRequirements, err := json.Marshal(owningTopology.Spec.Deployment.HostRequirements)
+		if err != nil {
+			r.log.Warnf("failed marshaling host requirements, error: %s", err)
+		} else {
+			envs = append(
+				envs,
+				k8scorev1.EnvVar{
+					Name:  clabernetesconstants.LauncherHostRequirementsEnv,
+					Value: string(hostRequirements),
+				},
+			)
+		}
+	}
+
+	if owningTopology.Spec.Deployment.DiskPressure != nil {
+		diskPressure, err := json.Marshal(owningTopology.Spec.Deployment.DiskPressure)
+		if err != nil {
+			r.log.Warnf("failed marshaling disk pressure thresholds, error: %s", err)
+		} else {
+			envs = append(
+				envs,
+				k8scorev1.EnvVar{
+					Name:  clabernetesconstants.LauncherDiskPressureEnv,
+					Value: string(diskPressure),
+				},
+			)
+		}
+	}
+
+	if owningTopology.Spec.SelfTest != nil &&
+		owningTopology.Spec.Connectivity != clabernetesconstants.ConnectivityMultus {
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherSelfTestDurationEnv,
+				Value: strconv.Itoa(owningTopology.Spec.SelfTest.DurationSeconds),
+			},
+		)
+	}
+
+	if owningTopology.Spec.TrafficCounters != nil &&
+		owningTopology.Spec.Connectivity != clabernetesconstants.ConnectivityMultus {
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherTrafficCountersIntervalEnv,
+				Value: strconv.Itoa(owningTopology.Spec.TrafficCounters.IntervalSeconds),
+			},
+		)
+	}
+
+	staticRoutes := ResolveStaticRoutes(owningTopology, nodeName)
+
+	if nodeConfig, ok := clabernetesConfigs[nodeName]; ok {
+		externalRoutes, skipped := ResolveExternalRoutes(owningTopology, nodeConfig, nodeName)
+		if len(skipped) > 0 {
+			r.log.Warnf(
+				"ignoring external routes %q for node %q, invalid or no mgmt gateway of their family",
+				skipped,
+				nodeName,
+			)
+		}
+
+		// external routes are for the management network, they win over static routes for the
+		// same destination
+		staticRoutes = slices.DeleteFunc(
+			staticRoutes,
+			func(staticRoute clabernetesapisv1alpha1.StaticRoute) bool {
+				return slices.ContainsFunc(
+					externalRoutes,
+					func(externalRoute clabernetesapisv1alpha1.StaticRoute) bool {
+						return externalRoute.Destination == staticRoute.Destination
+					},
+				)
+			},
+		)
+
+		staticRoutes = append(staticRoutes, externalRoutes...)
+	}
+
+	if len(staticRoutes) > 0 && ResolveHostNetwork(owningTopology) {
+		// the pod network namespace *is* the host network namespace, we are not going to go
+		// messing with the routes of the worker node
+		r.log.Warnf(
+			"ignoring static routes for node %q, topology uses the host network",
+			nodeName,
+		)
+	} else if len(staticRoutes) > 0 {
+		staticRoutesJSON, err := json.Marshal(staticRoutes)
+		if err != nil {
+			r.log.Warnf("failed marshaling static routes, error: %s", err)
+		} else {
+			envs = append(
+				envs,
+				k8scorev1.EnvVar{
+					Name:  clabernetesconstants.LauncherStaticRoutesEnv,
+					Value: string(staticRoutesJSON),
+				},
+			)
+		}
+	}
+
+	underlay := ResolveUnderlay(owningTopology)
+	if underlay != nil {
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherUnderlayInterfaceEnv,
+				Value: underlay.Interface,
+			},
+		)
+	}
+
+	ntpServerAddress := ResolveNTPServerAddress(owningTopology, r.configManagerGetter)
+	if ntpServerAddress != "" {
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherNTPServerEnv,
+				Value: ntpServerAddress,
+			},
+		)
+	}
+
+	if slurpeethTLSEnabled(owningTopology) {
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherSlurpeethTLSEnv,
+				Value: clabernetesconstants.True,
+			},
+		)
+	}
+
 	if len(owningTopology.Spec.ImagePull.InsecureRegistries) > 0 {
 		envs = append(
 			envs,
@@ -2026,27 +3160,41 @@ func (r *DeploymentReconciler) renderDeploymentContainerResources(
 	owningTopology *clabernetesapisv1alpha1.Topology,
 	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
 ) {
+	resources := r.ResolveLauncherResources(owningTopology, clabernetesConfigs, nodeName)
+
+	if resources != nil {
+		r.getLauncherContainer(deployment).Resources = *resources
+	}
+
+	r.renderDeploymentNativeKindResources(
+		deployment,
+		nodeName,
+		owningTopology,
+		clabernetesConfigs,
+	)
+}
+
+// ResolveLauncherResources returns the resources of the launcher container of the given node, or
+// nil if there are none -- node specific resources take precedence over the topology "default"
+// resources, which in turn take precedence over the global per containerlab kind resources.
+func (r *DeploymentReconciler) ResolveLauncherResources(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	nodeName string,
+) *k8scorev1.ResourceRequirements {
 	nodeResources, nodeResourcesOk := owningTopology.Spec.Deployment.Resources[nodeName]
 	if nodeResourcesOk {
-		r.getLauncherContainer(deployment).Resources = nodeResources
-
-		return
+		return &nodeResources
 	}
 
 	defaultResources, defaultResourcesOk := owningTopology.Spec.Deployment.Resources[clabernetesconstants.Default] //nolint:lll
 	if defaultResourcesOk {
-		r.getLauncherContainer(deployment).Resources = defaultResources
-
-		return
+		return &defaultResources
 	}
 
-	resources := r.configManagerGetter().GetResourcesForContainerlabKind(
+	return r.configManagerGetter().GetResourcesForContainerlabKind(
 		clabernetesConfigs[nodeName].Topology.GetNodeKindType(nodeName),
 	)
-
-	if resources != nil {
-		r.getLauncherContainer(deployment).Resources = *resources
-	}
 }
 
 func (r *DeploymentReconciler) renderDeploymentNodeSelectors(
@@ -2062,6 +3210,15 @@ func (r *DeploymentReconciler) renderDeploymentNodeSelectors(
 		maps.Copy(nodeSelectors, owningTopology.Spec.Deployment.Scheduling.NodeSelector)
 	}
 
+	// the node/kind specific overrides are the most specific, so they win over both of the above
+	overrideNodeSelectors, _ := ResolveSchedulingOverrides(
+		owningTopology,
+		nodeName,
+		deploymentNodeKind(nodeName, clabernetesConfigs),
+	)
+
+	maps.Copy(nodeSelectors, overrideNodeSelectors)
+
 	deployment.Spec.Template.Spec.NodeSelector = nodeSelectors
 }
 
@@ -2070,6 +3227,20 @@ func (r *DeploymentReconciler) renderDeploymentContainerPrivileges(
 	nodeName string,
 	owningTopology *clabernetesapisv1alpha1.Topology,
 ) {
+	if r.getRuntimeSandbox(deployment) == runtimeSandboxSysbox {
+		// sysbox gives us a "real" root in the user namespace of the pod (with all capabilities
+		// that go along with that), and does not allow privileged containers anyway, so we just
+		// run everything as (namespaced) root w/out any extra privileges/capabilities
+		for i := range deployment.Spec.Template.Spec.Containers {
+			deployment.Spec.Template.Spec.Containers[i].SecurityContext = &k8scorev1.SecurityContext{
+				Privileged: clabernetesutil.ToPointer(false),
+				RunAsUser:  clabernetesutil.ToPointer(int64(0)),
+			}
+		}
+
+		return
+	}
+
 	if ResolveGlobalVsTopologyBool(
 		r.configManagerGetter().GetPrivilegedLauncher(),
 		owningTopology.Spec.Deployment.PrivilegedLauncher,
@@ -2145,10 +3316,33 @@ func (r *DeploymentReconciler) renderDeploymentContainerPrivileges(
 	}
 }
 
+// renderDeploymentLinkCleanup sets a preStop hook on the launcher container of launchers running
+// in host network mode that deletes the links (veth pairs, tunnel interfaces, bridges) the launcher
+// created -- in host network mode those live in the host network namespace rather than the pod
+// network namespace, so they would outlive the pod otherwise. Links of pods that got killed too
+// hard for the hook to run are cleaned up by the next launcher of the node on startup.
+func (r *DeploymentReconciler) renderDeploymentLinkCleanup(
+	deployment *k8sappsv1.Deployment,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) {
+	if !ResolveHostNetwork(owningTopology) {
+		return
+	}
+
+	r.getLauncherContainer(deployment).Lifecycle = &k8scorev1.Lifecycle{
+		PreStop: &k8scorev1.LifecycleHandler{
+			Exec: &k8scorev1.ExecAction{
+				Command: []string{"/clabernetes/manager", "links", "cleanup"},
+			},
+		},
+	}
+}
+
 func (r *DeploymentReconciler) renderDeploymentContainerStatus(
 	deployment *k8sappsv1.Deployment,
 	nodeName string,
 	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
 ) {
 	if !owningTopology.Spec.StatusProbes.Enabled {
 		return
@@ -2165,7 +3359,8 @@ func (r *DeploymentReconciler) renderDeploymentContainerStatus(
 	}
 
 	if nodeProbeConfiguration.SSHProbeConfiguration == nil &&
-		nodeProbeConfiguration.TCPProbeConfiguration == nil {
+		nodeProbeConfiguration.TCPProbeConfiguration == nil &&
+		nodeProbeConfiguration.CLIProbeConfiguration == nil {
 		r.log.Warnf("node %q has no status probe configurations, skipping...", nodeName)
 
 		return
@@ -2175,8 +3370,20 @@ func (r *DeploymentReconciler) renderDeploymentContainerStatus(
 	// time (plus the 60s initial delay) for 15ish min startup time...
 	failureThresholds := probeDefaultStartupFailureThreshold
 
-	if nodeProbeConfiguration.StartupSeconds != 0 {
-		failureThresholds = nodeProbeConfiguration.StartupSeconds / probePeriodSeconds
+	startupSeconds := nodeProbeConfiguration.StartupSeconds
+
+	if startupSeconds == 0 && clabernetesConfigs[nodeName] != nil {
+		// slow booting kinds get a longer default startup time
+		nodeKind, _ := clabernetesConfigs[nodeName].Topology.GetNodeKindType(nodeName)
+
+		startupSeconds = ResolveKindStartupSeconds(
+			nodeKind,
+			r.configManagerGetter().GetNativeKinds(),
+		)
+	}
+
+	if startupSeconds != 0 {
+		failureThresholds = startupSeconds / probePeriodSeconds
 	}
 
 	// startup probe delays the start of the readiness probe -- this gives us time for the nos to
@@ -2252,20 +3459,108 @@ func (r *DeploymentReconciler) renderDeploymentContainerStatus(
 		}
 	}
 
+	if nodeProbeConfiguration.CLIProbeConfiguration != nil {
+		probeEnvVars = append(
+			probeEnvVars,
+			renderCLIProbeEnv(nodeProbeConfiguration.CLIProbeConfiguration)...,
+		)
+	}
+
 	r.getLauncherContainer(deployment).Env = append(
 		r.getLauncherContainer(deployment).Env,
 		probeEnvVars...,
 	)
 }
 
-func (r *DeploymentReconciler) renderDeploymentDevices(
+// renderCLIProbeEnv returns the launcher env vars for the given cli probe configuration -- only the
+// settings that are actually set are rendered, the launcher defaults the rest based on the kind of
+// the node.
+func renderCLIProbeEnv(
+	cliProbeConfiguration *clabernetesapisv1alpha1.CLIProbeConfiguration,
+) []k8scorev1.EnvVar {
+	envs := []k8scorev1.EnvVar{
+		{
+			Name:  clabernetesconstants.LauncherCLIProbeEnabled,
+			Value: clabernetesconstants.True,
+		},
+	}
+
+	for _, setting := range []struct {
+		envName string
+		value   string
+	}{
+		{
+			envName: clabernetesconstants.LauncherCLIProbeCommand,
+			value:   cliProbeConfiguration.Command,
+		},
+		{
+			envName: clabernetesconstants.LauncherCLIProbeExpectedOutput,
+			value:   cliProbeConfiguration.ExpectedOutput,
+		},
+		{
+			envName: clabernetesconstants.LauncherCLIProbeUsername,
+			value:   cliProbeConfiguration.Username,
+		},
+		{
+			envName: clabernetesconstants.LauncherCLIProbePassword,
+			value:   cliProbeConfiguration.Password,
+		},
+	} {
+		if setting.value == "" {
+			continue
+		}
+
+		envs = append(envs, k8scorev1.EnvVar{Name: setting.envName, Value: setting.value})
+	}
+
+	if cliProbeConfiguration.Port != 0 {
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherCLIProbePort,
+				Value: strconv.Itoa(cliProbeConfiguration.Port),
+			},
+		)
+	}
+
+	return envs
+}
+
+func (r *DeploymentReconciler) renderDeploymentDevices( //nolint:funlen
 	deployment *k8sappsv1.Deployment,
-	owningTopology *clabernetesapisv1alpha1.Topology,
+	nodeName string,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
 ) {
 	// Even in privileged mode, device nodes like /dev/kvm are not guaranteed to
 	// exist in the container filesystem unless explicitly mounted. KVM-backed NOS
 	// images (IOL/VIOS/vrnetlab/etc.) require /dev/kvm, and network OSes often need
 	// /dev/net/tun. Provide these device mounts consistently for all containers.
+	//
+	// Sandboxing runtimes (sysbox/kata/gvisor) do not pass host device nodes through to the pod
+	// (or flat out refuse to start the pod with them), so skip the mounts in that case.
+	if r.getRuntimeSandbox(deployment) != "" {
+		return
+	}
+
+	// when the global config lists the kinds that need kvm, only those get /dev/kvm (and get
+	// scheduled onto nodes that the clicker capability scan found kvm on), rather than blindly
+	// mounting /dev/kvm everywhere and hoping for the best
+	mountKVM := true
+
+	kvmKinds := r.configManagerGetter().GetKVMKinds()
+	if len(kvmKinds) > 0 {
+		var nodeKind string
+
+		if clabernetesConfigs[nodeName] != nil {
+			nodeKind, _ = clabernetesConfigs[nodeName].Topology.GetNodeKindType(nodeName)
+		}
+
+		mountKVM = slices.Contains(kvmKinds, nodeKind)
+
+		if mountKVM {
+			r.renderDeploymentKVMAffinity(deployment)
+		}
+	}
 
 	ensureVolume := func(name, hostPath string) {
 		for _, v := range deployment.Spec.Template.Spec.Volumes {
@@ -2287,7 +3582,10 @@ func (r *DeploymentReconciler) renderDeploymentDevices(
 		)
 	}
 
-	ensureVolume("dev-kvm", "/dev/kvm")
+	if mountKVM {
+		ensureVolume("dev-kvm", "/dev/kvm")
+	}
+
 	ensureVolume("dev-fuse", "/dev/fuse")
 	ensureVolume("dev-net-tun", "/dev/net/tun")
 
@@ -2311,12 +3609,90 @@ func (r *DeploymentReconciler) renderDeploymentDevices(
 	}
 
 	for i := range deployment.Spec.Template.Spec.Containers {
-		ensureMount(&deployment.Spec.Template.Spec.Containers[i], "dev-kvm", "/dev/kvm")
+		if mountKVM {
+			ensureMount(&deployment.Spec.Template.Spec.Containers[i], "dev-kvm", "/dev/kvm")
+		}
+
 		ensureMount(&deployment.Spec.Template.Spec.Containers[i], "dev-fuse", "/dev/fuse")
 		ensureMount(&deployment.Spec.Template.Spec.Containers[i], "dev-net-tun", "/dev/net/tun")
 	}
 }
 
+// renderDeploymentKVMAffinity adds a required node affinity for nodes labelled (by the clicker
+// capability scan) as having kvm available.
+func (r *DeploymentReconciler) renderDeploymentKVMAffinity(deployment *k8sappsv1.Deployment) {
+	addRequiredNodeAffinity(
+		deployment,
+		k8scorev1.NodeSelectorRequirement{
+			Key:      clabernetesconstants.LabelCapabilityKVM,
+			Operator: k8scorev1.NodeSelectorOpIn,
+			Values:   []string{clabernetesconstants.True},
+		},
+	)
+}
+
+// renderDeploymentNodePin adds a required node affinity for the worker the node is pinned to (if
+// any). This is done via affinity rather than setting the pod node name so that the pod still goes
+// through the scheduler -- that way taints, resources, and the like are still respected and the
+// pod just stays pending (with a useful event) if it can not run on the worker.
+func (r *DeploymentReconciler) renderDeploymentNodePin(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) {
+	workerName, ok := owningTopology.Spec.Deployment.Scheduling.NodePins[nodeName]
+	if !ok || workerName == "" {
+		return
+	}
+
+	addRequiredNodeAffinity(
+		deployment,
+		k8scorev1.NodeSelectorRequirement{
+			Key:      k8scorev1.LabelHostname,
+			Operator: k8scorev1.NodeSelectorOpIn,
+			Values:   []string{workerName},
+		},
+	)
+}
+
+// addRequiredNodeAffinity adds the given requirement to the required node affinity of the
+// deployment. Node selector terms are ORed, so the requirement is added to every existing term (or
+// a new term if there are none).
+func addRequiredNodeAffinity(
+	deployment *k8sappsv1.Deployment,
+	requirement k8scorev1.NodeSelectorRequirement,
+) {
+	// the affinity may be the one from the topology spec, dont mutate that!
+	affinity := deployment.Spec.Template.Spec.Affinity.DeepCopy()
+	if affinity == nil {
+		affinity = &k8scorev1.Affinity{}
+	}
+
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &k8scorev1.NodeAffinity{}
+	}
+
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution =
+			&k8scorev1.NodeSelector{}
+	}
+
+	nodeSelector := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+
+	if len(nodeSelector.NodeSelectorTerms) == 0 {
+		nodeSelector.NodeSelectorTerms = []k8scorev1.NodeSelectorTerm{{}}
+	}
+
+	for idx := range nodeSelector.NodeSelectorTerms {
+		nodeSelector.NodeSelectorTerms[idx].MatchExpressions = append(
+			nodeSelector.NodeSelectorTerms[idx].MatchExpressions,
+			requirement,
+		)
+	}
+
+	deployment.Spec.Template.Spec.Affinity = affinity
+}
+
 func (r *DeploymentReconciler) renderDeploymentPersistence(
 	deployment *k8sappsv1.Deployment,
 	nodeName,
@@ -2327,12 +3703,10 @@ func (r *DeploymentReconciler) renderDeploymentPersistence(
 		return
 	}
 
-	volumeName := "containerlab-directory-persistence"
-
 	deployment.Spec.Template.Spec.Volumes = append(
 		deployment.Spec.Template.Spec.Volumes,
 		k8scorev1.Volume{
-			Name: volumeName,
+			Name: persistenceVolumeName,
 			VolumeSource: k8scorev1.VolumeSource{
 				PersistentVolumeClaim: &k8scorev1.PersistentVolumeClaimVolumeSource{
 					ClaimName: fmt.Sprintf("%s-%s", owningTopologyName, nodeName),
@@ -2345,7 +3719,7 @@ func (r *DeploymentReconciler) renderDeploymentPersistence(
 	r.getLauncherContainer(deployment).VolumeMounts = append(
 		r.getLauncherContainer(deployment).VolumeMounts,
 		k8scorev1.VolumeMount{
-			Name:      volumeName,
+			Name:      persistenceVolumeName,
 			ReadOnly:  false,
 			MountPath: fmt.Sprintf("/clabernetes/clab-clabernetes-%s", nodeName),
 		},
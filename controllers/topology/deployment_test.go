@@ -102,7 +102,7 @@ func TestResolveDeployment(t *testing.T) {
 				got, err := reconciler.Resolve(
 					testCase.ownedDeployments,
 					testCase.clabernetesConfigs,
-					nil,
+					&clabernetesapisv1alpha1.Topology{},
 				)
 				if err != nil {
 					t.Fatal(err)
@@ -610,6 +610,393 @@ func TestRenderDeployment(t *testing.T) {
 			nodeName:            "srl1",
 			configManagerGetter: clabernetesconfig.GetFakeManager,
 		},
+		{
+			name: "scheduling-overrides",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						Scheduling: clabernetesapisv1alpha1.Scheduling{
+							NodeSelector: map[string]string{
+								"somelabel": "somevalue",
+							},
+							Tolerations: []k8scorev1.Toleration{
+								{
+									Key:      "sometaintkey",
+									Operator: "Equal",
+									Value:    "sometaintvalue",
+									Effect:   "NoSchedule",
+								},
+							},
+							KindOverrides: map[string]clabernetesapisv1alpha1.SchedulingOverrides{
+								"srl": {
+									NodeSelector: map[string]string{
+										"somelabel":  "kindvalue",
+										"kindlabel":  "kindvalue",
+										"otherlabel": "kindvalue",
+									},
+									Tolerations: []k8scorev1.Toleration{
+										{
+											Key:      "kindtaintkey",
+											Operator: "Exists",
+											Effect:   "NoSchedule",
+										},
+									},
+								},
+								"ceos": {
+									NodeSelector: map[string]string{
+										"ceoslabel": "ceosvalue",
+									},
+								},
+							},
+							NodeOverrides: map[string]clabernetesapisv1alpha1.SchedulingOverrides{
+								"srl1": {
+									NodeSelector: map[string]string{
+										"otherlabel": "nodevalue",
+									},
+									Tolerations: []k8scorev1.Toleration{
+										{
+											Key:      "nodetaintkey",
+											Operator: "Exists",
+											Effect:   "NoExecute",
+										},
+									},
+								},
+							},
+						},
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+		   name: test
+		   topology:
+		     nodes:
+		       srl1:
+		         kind: srl
+		         image: ghcr.io/nokia/srlinux
+		`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "extra-init-containers",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						ExtraInitContainers: map[string][]k8scorev1.Container{
+							"default": {
+								{
+									Name:    "fetch-license",
+									Image:   "busybox",
+									Command: []string{"wget", "-O", "/license/srl.key"},
+								},
+								{
+									Name:  "warm-cache",
+									Image: "registry.example.com/cache-warmer:1.2.3",
+								},
+							},
+							"srl1": {
+								{
+									Name:  "warm-cache",
+									Image: "registry.example.com/cache-warmer@sha256:abc",
+									Ports: []k8scorev1.ContainerPort{
+										{
+											ContainerPort: 8080,
+										},
+									},
+								},
+							},
+						},
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+		   name: test
+		   topology:
+		     nodes:
+		       srl1:
+		         kind: srl
+		         image: ghcr.io/nokia/srlinux
+		`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "dns-overrides",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Network: &clabernetesapisv1alpha1.Network{
+						DNSPolicy: k8scorev1.DNSNone,
+						DNSConfig: &k8scorev1.PodDNSConfig{
+							Nameservers: []string{"192.0.2.53"},
+							Searches:    []string{"lab.example.com"},
+							Options: []k8scorev1.PodDNSConfigOption{
+								{
+									Name:  "ndots",
+									Value: clabernetesutil.ToPointer("2"),
+								},
+							},
+						},
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+		   name: test
+		   topology:
+		     nodes:
+		       srl1:
+		         kind: srl
+		         image: ghcr.io/nokia/srlinux
+		`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "host-network",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						HostNetwork: clabernetesutil.ToPointer(true),
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+		   name: test
+		   topology:
+		     nodes:
+		       srl1:
+		         kind: srl
+		         image: ghcr.io/nokia/srlinux
+		`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "security-context-overrides",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						SecurityContexts: map[string]clabernetesapisv1alpha1.SecurityContextOverrides{
+							"default": {
+								Pod: &clabernetesapisv1alpha1.PodSecurityContextOverrides{
+									FSGroup: clabernetesutil.ToPointer(int64(2000)),
+								},
+							},
+							"srl1": {
+								Pod: &clabernetesapisv1alpha1.PodSecurityContextOverrides{
+									FSGroup:            clabernetesutil.ToPointer(int64(3000)),
+									SupplementalGroups: []int64{4000, 4001},
+									SELinuxOptions: &k8scorev1.SELinuxOptions{
+										Type: "spc_t",
+									},
+								},
+								Container: &clabernetesapisv1alpha1.ContainerSecurityContextOverrides{
+									SELinuxOptions: &k8scorev1.SELinuxOptions{
+										Level: "s0:c123,c456",
+									},
+								},
+							},
+						},
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+		   name: test
+		   topology:
+		     nodes:
+		       srl1:
+		         kind: srl
+		         image: ghcr.io/nokia/srlinux
+		`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "extra-labels-and-annotations",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						ExtraLabels: map[string]map[string]string{
+							"default": {
+								"cost-center": "lab",
+								"team":        "netops",
+							},
+							"srl1": {
+								"team":                     "fabric",
+								"clabernetes/topologyNode": "not-srl1",
+							},
+						},
+						ExtraAnnotations: map[string]map[string]string{
+							"default": {
+								"prometheus.io/scrape": "true",
+							},
+							"srl1": {
+								"k8s.v1.cni.cncf.io/networks": "kube-system/oob",
+							},
+						},
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+		   name: test
+		   topology:
+		     nodes:
+		       srl1:
+		         kind: srl
+		         image: ghcr.io/nokia/srlinux
+		`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
 		{
 			name: "remove-prefix",
 			owningTopology: &clabernetesapisv1alpha1.Topology{
@@ -618,31 +1005,784 @@ func TestRenderDeployment(t *testing.T) {
 					Namespace: "clabernetes",
 				},
 				Spec: clabernetesapisv1alpha1.TopologySpec{
-					Connectivity: clabernetesconstants.ConnectivitySlurpeeth,
+					Connectivity: clabernetesconstants.ConnectivitySlurpeeth,
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+		   name: test
+		   topology:
+		     nodes:
+		       srl1:
+		         kind: srl
+		         image: ghcr.io/nokia/srlinux
+		`,
+					},
+				},
+				Status: clabernetesapisv1alpha1.TopologyStatus{
+					// to set naming for test purposes we need to update the *status* of the topo
+					// since this is done very early in the rec
+					RemoveTopologyPrefix: clabernetesutil.ToPointer(true),
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "custom-clab-version",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						ContainerlabVersion: "0.51.1",
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+		   name: test
+		   topology:
+		     nodes:
+		       srl1:
+		         kind: srl
+		         image: ghcr.io/nokia/srlinux
+		`,
+					},
+				},
+				Status: clabernetesapisv1alpha1.TopologyStatus{
+					// to set naming for test purposes we need to update the *status* of the topo
+					// since this is done very early in the rec
+					RemoveTopologyPrefix: clabernetesutil.ToPointer(true),
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "priority-class",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						PriorityClassName: "lab-batch",
+						NodePriorityClassNames: map[string]string{
+							"srl1": "lab-critical",
+						},
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+		   name: test
+		   topology:
+		     nodes:
+		       srl1:
+		         kind: srl
+		         image: ghcr.io/nokia/srlinux
+		`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "runtime-class-sysbox",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						RuntimeClassName: "kata",
+						KindRuntimeClassNames: map[string]string{
+							"srl": "sysbox-runc",
+						},
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+		   name: test
+		   topology:
+		     nodes:
+		       srl1:
+		         kind: srl
+		         image: ghcr.io/nokia/srlinux
+		`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "simple-node-selectors",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test-node-selectors",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName: "srl1",
+			configManagerGetter: func() clabernetesconfig.Manager {
+				selectors := map[string]map[string]string{
+					"internal.io/nokia_sros*": {"node-flavour": "baremetal"},
+					"ghcr.io/nokia/srlinux*":  {"node-flavour": "amd64"},
+					"default":                 {"node-flavour": "arm64"},
+				}
+
+				return clabernetesconfig.NewFakeManager(
+					clabernetesconfig.WithNodeSelectors(selectors),
+				)
+			},
+		},
+		{
+			name: "kvm-kinds",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName: "srl1",
+			configManagerGetter: func() clabernetesconfig.Manager {
+				return clabernetesconfig.NewFakeManager(
+					clabernetesconfig.WithKVMKinds([]string{"srl"}),
+				)
+			},
+		},
+		{
+			name: "connectivity-ports",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					ConnectivityPorts: &clabernetesapisv1alpha1.ConnectivityPorts{
+						Slurpeeth: 4800,
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName: "srl1",
+			configManagerGetter: func() clabernetesconfig.Manager {
+				return clabernetesconfig.NewFakeManager(
+					clabernetesconfig.WithConnectivityPorts(
+						clabernetesapisv1alpha1.ConnectivityPorts{
+							VXLAN: 8472,
+						},
+					),
+				)
+			},
+		},
+		{
+			name: "host-requirements",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						HostRequirements: &clabernetesapisv1alpha1.HostRequirements{
+							Sysctls: map[string]int64{
+								"fs.inotify.max_user_instances": 256,
+							},
+							KernelModules: []string{"ip_gre"},
+							Mode:          clabernetesconstants.HostRequirementsModeWarn,
+						},
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName: "srl1",
+			configManagerGetter: func() clabernetesconfig.Manager {
+				return clabernetesconfig.NewFakeManager()
+			},
+		},
+		{
+			name: "self-test",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					SelfTest: &clabernetesapisv1alpha1.ConnectivitySelfTest{
+						DurationSeconds: 10,
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName: "srl1",
+			configManagerGetter: func() clabernetesconfig.Manager {
+				return clabernetesconfig.NewFakeManager()
+			},
+		},
+		{
+			name: "network",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Network: &clabernetesapisv1alpha1.Network{
+						HostAliases: map[string][]k8scorev1.HostAlias{
+							"default": {
+								{
+									IP:        "192.0.2.10",
+									Hostnames: []string{"license.lab.example"},
+								},
+							},
+							"srl1": {
+								{
+									IP:        "192.0.2.20",
+									Hostnames: []string{"syslog.lab.example"},
+								},
+							},
+						},
+						StaticRoutes: map[string][]clabernetesapisv1alpha1.StaticRoute{
+							"default": {
+								{Destination: "192.0.2.0/24"},
+							},
+							"srl1": {
+								{Destination: "198.51.100.0/24", Gateway: "10.0.0.1"},
+							},
+						},
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName: "srl1",
+			configManagerGetter: func() clabernetesconfig.Manager {
+				return clabernetesconfig.NewFakeManager()
+			},
+		},
+		{
+			name: "slurpeeth-tls",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivitySlurpeeth,
+					SlurpeethTLS: &clabernetesapisv1alpha1.SlurpeethTLS{},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName: "srl1",
+			configManagerGetter: func() clabernetesconfig.Manager {
+				return clabernetesconfig.NewFakeManager()
+			},
+		},
+		{
+			name: "node-pin",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						Scheduling: clabernetesapisv1alpha1.Scheduling{
+							NodePins: map[string]string{
+								"srl1": "worker-1",
+							},
+						},
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName: "srl1",
+			configManagerGetter: func() clabernetesconfig.Manager {
+				return clabernetesconfig.NewFakeManager()
+			},
+		},
+		{
+			name: "packet-capture-claim",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						PacketCaptureClaimName: "captures",
+					},
 					Definition: clabernetesapisv1alpha1.Definition{
 						Containerlab: `---
-		   name: test
-		   topology:
-		     nodes:
-		       srl1:
-		         kind: srl
-		         image: ghcr.io/nokia/srlinux
-		`,
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
 					},
 				},
-				Status: clabernetesapisv1alpha1.TopologyStatus{
-					// to set naming for test purposes we need to update the *status* of the topo
-					// since this is done very early in the rec
-					RemoveTopologyPrefix: clabernetesutil.ToPointer(true),
-				},
 			},
 			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
 				"srl1": {
 					Name:   "srl1",
 					Prefix: clabernetesutil.ToPointer(""),
 					Topology: &clabernetesutilcontainerlab.Topology{
-						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
-						Kinds:    nil,
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
 						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
 							"srl1": {
 								Kind:  "srl",
@@ -654,35 +1794,98 @@ func TestRenderDeployment(t *testing.T) {
 					Debug: false,
 				},
 			},
-			nodeName:            "srl1",
-			configManagerGetter: clabernetesconfig.GetFakeManager,
+			nodeName: "srl1",
+			configManagerGetter: func() clabernetesconfig.Manager {
+				return clabernetesconfig.NewFakeManager()
+			},
 		},
 		{
-			name: "custom-clab-version",
+			name: "cli-probe",
 			owningTopology: &clabernetesapisv1alpha1.Topology{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "render-deployment-test",
 					Namespace: "clabernetes",
 				},
 				Spec: clabernetesapisv1alpha1.TopologySpec{
-					Deployment: clabernetesapisv1alpha1.Deployment{
-						ContainerlabVersion: "0.51.1",
+					StatusProbes: clabernetesapisv1alpha1.StatusProbes{
+						Enabled: true,
+						ProbeConfiguration: clabernetesapisv1alpha1.ProbeConfiguration{
+							CLIProbeConfiguration: &clabernetesapisv1alpha1.CLIProbeConfiguration{
+								Password: "hunter2",
+								Port:     2222,
+							},
+						},
 					},
 					Definition: clabernetesapisv1alpha1.Definition{
 						Containerlab: `---
-		   name: test
-		   topology:
-		     nodes:
-		       srl1:
-		         kind: srl
-		         image: ghcr.io/nokia/srlinux
-		`,
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
 					},
 				},
-				Status: clabernetesapisv1alpha1.TopologyStatus{
-					// to set naming for test purposes we need to update the *status* of the topo
-					// since this is done very early in the rec
-					RemoveTopologyPrefix: clabernetesutil.ToPointer(true),
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName: "srl1",
+			configManagerGetter: func() clabernetesconfig.Manager {
+				return clabernetesconfig.NewFakeManager()
+			},
+		},
+		{
+			name: "propagated-pull-secret",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
 				},
 			},
 			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
@@ -690,8 +1893,25 @@ func TestRenderDeployment(t *testing.T) {
 					Name:   "srl1",
 					Prefix: clabernetesutil.ToPointer(""),
 					Topology: &clabernetesutilcontainerlab.Topology{
-						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
-						Kinds:    nil,
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
 						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
 							"srl1": {
 								Kind:  "srl",
@@ -703,18 +1923,21 @@ func TestRenderDeployment(t *testing.T) {
 					Debug: false,
 				},
 			},
-			nodeName:            "srl1",
-			configManagerGetter: clabernetesconfig.GetFakeManager,
+			nodeName: "srl1",
+			configManagerGetter: func() clabernetesconfig.Manager {
+				return clabernetesconfig.NewFakeManager(
+					clabernetesconfig.WithPropagatedPullSecret("registry-credentials"),
+				)
+			},
 		},
 		{
-			name: "simple-node-selectors",
+			name: "global-priority-class",
 			owningTopology: &clabernetesapisv1alpha1.Topology{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "render-deployment-test-node-selectors",
+					Name:      "render-deployment-test",
 					Namespace: "clabernetes",
 				},
 				Spec: clabernetesapisv1alpha1.TopologySpec{
-					Connectivity: clabernetesconstants.ConnectivityVXLAN,
 					Definition: clabernetesapisv1alpha1.Definition{
 						Containerlab: `---
     name: test
@@ -764,14 +1987,8 @@ func TestRenderDeployment(t *testing.T) {
 			},
 			nodeName: "srl1",
 			configManagerGetter: func() clabernetesconfig.Manager {
-				selectors := map[string]map[string]string{
-					"internal.io/nokia_sros*": {"node-flavour": "baremetal"},
-					"ghcr.io/nokia/srlinux*":  {"node-flavour": "amd64"},
-					"default":                 {"node-flavour": "arm64"},
-				}
-
 				return clabernetesconfig.NewFakeManager(
-					clabernetesconfig.WithNodeSelectors(selectors),
+					clabernetesconfig.WithPriorityClassName("lab-batch"),
 				)
 			},
 		},
@@ -986,6 +2203,76 @@ func TestDeploymentConforms(t *testing.T) {
 			ownerUID: apimachinerytypes.UID("clabernetes-testing"),
 			conforms: false,
 		},
+		{
+			name: "mismatched-priority-class",
+			existing: &k8sappsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							UID: apimachinerytypes.UID("clabernetes-testing"),
+						},
+					},
+				},
+				Spec: k8sappsv1.DeploymentSpec{
+					Template: k8scorev1.PodTemplateSpec{
+						Spec: k8scorev1.PodSpec{
+							PriorityClassName: "lab-batch",
+						},
+					},
+				},
+			},
+			rendered: &k8sappsv1.Deployment{
+				Spec: k8sappsv1.DeploymentSpec{
+					Template: k8scorev1.PodTemplateSpec{
+						Spec: k8scorev1.PodSpec{
+							PriorityClassName: "lab-critical",
+						},
+					},
+				},
+			},
+			ownerUID: apimachinerytypes.UID("clabernetes-testing"),
+			conforms: false,
+		},
+		{
+			name: "mismatched-host-aliases",
+			existing: &k8sappsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							UID: apimachinerytypes.UID("clabernetes-testing"),
+						},
+					},
+				},
+				Spec: k8sappsv1.DeploymentSpec{
+					Template: k8scorev1.PodTemplateSpec{
+						Spec: k8scorev1.PodSpec{
+							HostAliases: []k8scorev1.HostAlias{
+								{
+									IP:        "192.0.2.10",
+									Hostnames: []string{"license.lab.example"},
+								},
+							},
+						},
+					},
+				},
+			},
+			rendered: &k8sappsv1.Deployment{
+				Spec: k8sappsv1.DeploymentSpec{
+					Template: k8scorev1.PodTemplateSpec{
+						Spec: k8scorev1.PodSpec{
+							HostAliases: []k8scorev1.HostAlias{
+								{
+									IP:        "192.0.2.11",
+									Hostnames: []string{"license.lab.example"},
+								},
+							},
+						},
+					},
+				},
+			},
+			ownerUID: apimachinerytypes.UID("clabernetes-testing"),
+			conforms: false,
+		},
 
 		// object meta annotations
 
@@ -0,0 +1,172 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	k8scoordinationv1 "k8s.io/api/coordination/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	conditionNodesDiskPressure = "NodesDiskPressure"
+	reasonDiskPressure         = "DiskPressure"
+
+	timelineReasonNodeEvicted = "NodeEvicted"
+)
+
+// LauncherDiskUsage returns the used percent of the docker data volume the launcher published on
+// the given launcher heartbeat lease, and false if there is none (or it can not be decoded).
+func LauncherDiskUsage(lease *k8scoordinationv1.Lease) (int, bool) {
+	rawUsage, ok := lease.Annotations[clabernetesconstants.LauncherDiskUsageAnnotation]
+	if !ok {
+		return 0, false
+	}
+
+	usage, err := strconv.Atoi(strings.TrimSpace(rawUsage))
+	if err != nil || usage < 0 || usage > 100 {
+		return 0, false
+	}
+
+	return usage, true
+}
+
+// ResolveDiskPressureWarnPercent returns the used percent of the launcher docker data volume at
+// which nodes of the given topology are considered to be under disk pressure.
+func ResolveDiskPressureWarnPercent(owningTopology *clabernetesapisv1alpha1.Topology) int {
+	diskPressure := owningTopology.Spec.Deployment.DiskPressure
+	if diskPressure == nil || diskPressure.WarnPercent <= 0 {
+		return clabernetesconstants.DiskPressureDefaultWarnPercent
+	}
+
+	return diskPressure.WarnPercent
+}
+
+// reconcileNodeDiskPressure records the docker data volume usage the launcher of the given node
+// published on its heartbeat lease, and evicts (deletes) launcher pods that gave up due to disk
+// pressure -- restarting the launcher container does not help as the volume outlives the
+// container, a new pod gets the node a fresh volume (unless the DiskPressureEviction feature
+// gate is disabled).
+func (r *Reconciler) reconcileNodeDiskPressure(
+	ctx context.Context,
+	reconcileData *ReconcileData,
+	nodeName string,
+	pods []k8scorev1.Pod,
+) {
+	evict := r.configManagerGetter().IsFeatureGateEnabled(
+		clabernetesconstants.FeatureGateDiskPressureEviction,
+	)
+
+	for i := range pods {
+		pod := &pods[i]
+
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		termination := LastContainerTermination(pod)
+		if evict && termination != nil &&
+			termination.Reason == clabernetesconstants.TerminationReasonDiskPressure {
+			r.Log.Warnf(
+				"launcher pod %q of node %q gave up due to disk pressure, evicting it",
+				pod.Name,
+				nodeName,
+			)
+
+			err := r.Client.Delete(ctx, pod)
+			if err != nil {
+				r.Log.Warnf("failed deleting launcher pod %q, error: %s", pod.Name, err)
+			} else {
+				recordControllerTimelineEvent(
+					reconcileData,
+					nodeName,
+					timelineReasonNodeEvicted,
+					fmt.Sprintf(
+						"launcher pod %s evicted due to disk pressure: %s",
+						pod.Name,
+						termination.Message,
+					),
+				)
+			}
+
+			continue
+		}
+
+		if pod.Status.Phase != k8scorev1.PodRunning {
+			continue
+		}
+
+		lease := &k8scoordinationv1.Lease{}
+
+		err := r.Client.Get(
+			ctx,
+			apimachinerytypes.NamespacedName{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+			},
+			lease,
+		)
+		if err != nil {
+			continue
+		}
+
+		usage, ok := LauncherDiskUsage(lease)
+		if !ok {
+			continue
+		}
+
+		reconcileData.NodeDiskUsage[nodeName] = usage
+	}
+}
+
+// reconcileDiskPressureCondition sets (or clears) the "NodesDiskPressure" condition on the topology
+// listing the nodes whose launcher docker data volume is at or above the warn threshold.
+func (r *Reconciler) reconcileDiskPressureCondition(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) {
+	warnPercent := ResolveDiskPressureWarnPercent(owningTopology)
+
+	var pressuredNodes []string
+
+	for nodeName, usage := range reconcileData.NodeDiskUsage {
+		if usage >= warnPercent {
+			pressuredNodes = append(
+				pressuredNodes,
+				fmt.Sprintf("%s (%d%%)", nodeName, usage),
+			)
+		}
+	}
+
+	if len(pressuredNodes) == 0 {
+		apimachinerymeta.RemoveStatusCondition(
+			&owningTopology.Status.Conditions,
+			conditionNodesDiskPressure,
+		)
+
+		return
+	}
+
+	slices.Sort(pressuredNodes)
+
+	r.Log.Warnf("node(s) %q launcher(s) are under disk pressure", pressuredNodes)
+
+	apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+		Type:   conditionNodesDiskPressure,
+		Status: "True",
+		Reason: reasonDiskPressure,
+		Message: fmt.Sprintf(
+			"launcher docker data volume(s) of node(s) %s are at or above %d%% used",
+			strings.Join(pressuredNodes, ", "),
+			warnPercent,
+		),
+	})
+}
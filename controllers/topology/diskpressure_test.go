@@ -0,0 +1,74 @@
+package topology_test
+
+import (
+	"testing"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	k8scoordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLauncherDiskUsage(t *testing.T) {
+	cases := []struct {
+		name          string
+		annotations   map[string]string
+		expectedUsage int
+		expectedOk    bool
+	}{
+		{
+			name:          "no-annotation",
+			annotations:   nil,
+			expectedUsage: 0,
+			expectedOk:    false,
+		},
+		{
+			name: "usage",
+			annotations: map[string]string{
+				clabernetesconstants.LauncherDiskUsageAnnotation: "87",
+			},
+			expectedUsage: 87,
+			expectedOk:    true,
+		},
+		{
+			name: "garbage",
+			annotations: map[string]string{
+				clabernetesconstants.LauncherDiskUsageAnnotation: "lots",
+			},
+			expectedUsage: 0,
+			expectedOk:    false,
+		},
+		{
+			name: "out-of-range",
+			annotations: map[string]string{
+				clabernetesconstants.LauncherDiskUsageAnnotation: "101",
+			},
+			expectedUsage: 0,
+			expectedOk:    false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actualUsage, actualOk := clabernetescontrollerstopology.LauncherDiskUsage(
+					&k8scoordinationv1.Lease{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: testCase.annotations,
+						},
+					},
+				)
+				if actualOk != testCase.expectedOk {
+					clabernetestesthelper.FailOutput(t, actualOk, testCase.expectedOk)
+				}
+
+				if actualUsage != testCase.expectedUsage {
+					clabernetestesthelper.FailOutput(t, actualUsage, testCase.expectedUsage)
+				}
+			})
+	}
+}
@@ -0,0 +1,146 @@
+package topology
+
+import (
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+)
+
+// the docker healthcheck defaults, containerlab leaves anything not set in the topology up to
+// docker so we do the same.
+const (
+	healthcheckDefaultIntervalSeconds = 30
+	healthcheckDefaultTimeoutSeconds  = 30
+	healthcheckDefaultRetries         = 3
+
+	healthcheckTestNone     = "NONE"
+	healthcheckTestCmd      = "CMD"
+	healthcheckTestCmdShell = "CMD-SHELL"
+)
+
+// healthcheckCommand returns the exec command for the given docker style healthcheck test --
+// ["CMD", args...] is executed as is, ["CMD-SHELL", command] via a shell. Tests without either
+// prefix are treated as a shell command. Returns nil if the test is empty or disabled ("NONE").
+func healthcheckCommand(test []string) []string {
+	if len(test) == 0 {
+		return nil
+	}
+
+	switch strings.ToUpper(test[0]) {
+	case healthcheckTestNone:
+		return nil
+	case healthcheckTestCmd:
+		if len(test) == 1 {
+			return nil
+		}
+
+		return test[1:]
+	case healthcheckTestCmdShell:
+		if len(test) == 1 {
+			return nil
+		}
+
+		return []string{"/bin/sh", "-c", strings.Join(test[1:], " ")}
+	default:
+		return []string{"/bin/sh", "-c", strings.Join(test, " ")}
+	}
+}
+
+// HealthcheckProbes translates the given containerlab healthcheck into kubernetes probes -- a
+// startup probe covering the healthcheck start period (nil if there is none), and a probe to be
+// used for both liveness and readiness. Both are nil if the healthcheck is unset or disabled.
+// Every probe field is set explicitly so the rendered probes compare equal to the api server
+// defaulted ones.
+func HealthcheckProbes(
+	healthcheck *clabernetesutilcontainerlab.HealthcheckConfig,
+) (startupProbe, probe *k8scorev1.Probe) {
+	if healthcheck == nil {
+		return nil, nil
+	}
+
+	command := healthcheckCommand(healthcheck.Test)
+	if command == nil {
+		return nil, nil
+	}
+
+	intervalSeconds := healthcheck.Interval
+	if intervalSeconds <= 0 {
+		intervalSeconds = healthcheckDefaultIntervalSeconds
+	}
+
+	timeoutSeconds := healthcheck.Timeout
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = healthcheckDefaultTimeoutSeconds
+	}
+
+	retries := healthcheck.Retries
+	if retries <= 0 {
+		retries = healthcheckDefaultRetries
+	}
+
+	probe = &k8scorev1.Probe{
+		ProbeHandler: k8scorev1.ProbeHandler{
+			Exec: &k8scorev1.ExecAction{
+				Command: command,
+			},
+		},
+		TimeoutSeconds:   int32(timeoutSeconds),  //nolint:gosec
+		PeriodSeconds:    int32(intervalSeconds), //nolint:gosec
+		SuccessThreshold: 1,
+		FailureThreshold: int32(retries), //nolint:gosec
+	}
+
+	if healthcheck.StartPeriod <= 0 {
+		return nil, probe
+	}
+
+	// docker does not count failures during the start period, so give the startup probe the
+	// whole start period (rounded up to whole intervals) plus the usual retries
+	startPeriodIntervals := (healthcheck.StartPeriod + intervalSeconds - 1) / intervalSeconds
+
+	startupProbe = probe.DeepCopy()
+	startupProbe.FailureThreshold = int32(startPeriodIntervals + retries) //nolint:gosec
+
+	return startupProbe, probe
+}
+
+// renderDeploymentNativeHealthcheck translates the containerlab healthcheck of native mode nodes
+// into startup/liveness/readiness probes on their nos container. Without native mode the nos runs
+// in docker in the launcher which takes care of the healthcheck itself.
+func (r *DeploymentReconciler) renderDeploymentNativeHealthcheck(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) {
+	if !ResolveNativeMode(owningTopology) {
+		return
+	}
+
+	nodeConfig, ok := clabernetesConfigs[nodeName]
+	if !ok || nodeConfig.Topology == nil {
+		return
+	}
+
+	startupProbe, probe := HealthcheckProbes(nodeConfig.Topology.GetNodeHealthcheck(nodeName))
+	if probe == nil {
+		return
+	}
+
+	r.log.Debugf("translating containerlab healthcheck of node %q into probes", nodeName)
+
+	for i := range deployment.Spec.Template.Spec.Containers {
+		container := &deployment.Spec.Template.Spec.Containers[i]
+
+		if container.Name != nodeName {
+			continue
+		}
+
+		container.StartupProbe = startupProbe
+		container.LivenessProbe = probe
+		container.ReadinessProbe = probe.DeepCopy()
+	}
+}
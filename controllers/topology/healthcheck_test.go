@@ -0,0 +1,109 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8scorev1 "k8s.io/api/core/v1"
+)
+
+func TestHealthcheckProbes(t *testing.T) {
+	cases := []struct {
+		name                 string
+		healthcheck          *clabernetesutilcontainerlab.HealthcheckConfig
+		expectedStartupProbe *k8scorev1.Probe
+		expectedProbe        *k8scorev1.Probe
+	}{
+		{
+			name:                 "no-healthcheck",
+			healthcheck:          nil,
+			expectedStartupProbe: nil,
+			expectedProbe:        nil,
+		},
+		{
+			name: "none",
+			healthcheck: &clabernetesutilcontainerlab.HealthcheckConfig{
+				Test: []string{"NONE"},
+			},
+			expectedStartupProbe: nil,
+			expectedProbe:        nil,
+		},
+		{
+			name: "cmd-defaults",
+			healthcheck: &clabernetesutilcontainerlab.HealthcheckConfig{
+				Test: []string{"CMD", "cat", "/etc/os-release"},
+			},
+			expectedStartupProbe: nil,
+			expectedProbe: &k8scorev1.Probe{
+				ProbeHandler: k8scorev1.ProbeHandler{
+					Exec: &k8scorev1.ExecAction{
+						Command: []string{"cat", "/etc/os-release"},
+					},
+				},
+				TimeoutSeconds:   30,
+				PeriodSeconds:    30,
+				SuccessThreshold: 1,
+				FailureThreshold: 3,
+			},
+		},
+		{
+			name: "cmd-shell-start-period",
+			healthcheck: &clabernetesutilcontainerlab.HealthcheckConfig{
+				Test:        []string{"CMD-SHELL", "vtysh -c 'show version'"},
+				StartPeriod: 45,
+				Retries:     5,
+				Interval:    10,
+				Timeout:     5,
+			},
+			expectedStartupProbe: &k8scorev1.Probe{
+				ProbeHandler: k8scorev1.ProbeHandler{
+					Exec: &k8scorev1.ExecAction{
+						Command: []string{"/bin/sh", "-c", "vtysh -c 'show version'"},
+					},
+				},
+				TimeoutSeconds:   5,
+				PeriodSeconds:    10,
+				SuccessThreshold: 1,
+				FailureThreshold: 10,
+			},
+			expectedProbe: &k8scorev1.Probe{
+				ProbeHandler: k8scorev1.ProbeHandler{
+					Exec: &k8scorev1.ExecAction{
+						Command: []string{"/bin/sh", "-c", "vtysh -c 'show version'"},
+					},
+				},
+				TimeoutSeconds:   5,
+				PeriodSeconds:    10,
+				SuccessThreshold: 1,
+				FailureThreshold: 5,
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actualStartupProbe, actualProbe := clabernetescontrollerstopology.HealthcheckProbes(
+					testCase.healthcheck,
+				)
+
+				if !reflect.DeepEqual(actualStartupProbe, testCase.expectedStartupProbe) {
+					clabernetestesthelper.FailOutput(
+						t,
+						actualStartupProbe,
+						testCase.expectedStartupProbe,
+					)
+				}
+
+				if !reflect.DeepEqual(actualProbe, testCase.expectedProbe) {
+					clabernetestesthelper.FailOutput(t, actualProbe, testCase.expectedProbe)
+				}
+			})
+	}
+}
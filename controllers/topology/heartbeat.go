@@ -0,0 +1,131 @@
+package topology
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	k8scoordinationv1 "k8s.io/api/coordination/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	conditionNodesStalled = "NodesStalled"
+	// stalledCheckRequeue is how often we requeue topologies that are not (yet) ready so that we
+	// notice launchers that stop renewing their heartbeat lease.
+	stalledCheckRequeue = clabernetesconstants.LauncherHeartbeatLeaseDurationSeconds * time.Second
+)
+
+// LauncherLeaseExpired returns true if the given launcher heartbeat lease has not been renewed
+// within its lease duration as of the given time.
+func LauncherLeaseExpired(lease *k8scoordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil {
+		return true
+	}
+
+	leaseDuration := time.Duration(
+		clabernetesconstants.LauncherHeartbeatLeaseDurationSeconds,
+	) * time.Second
+
+	if lease.Spec.LeaseDurationSeconds != nil {
+		leaseDuration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+
+	return now.After(lease.Spec.RenewTime.Add(leaseDuration))
+}
+
+// isNodeLauncherStalled returns true if any of the given running launcher pods of a node has a
+// heartbeat lease that has expired -- meaning the launcher is running but wedged. Launchers that
+// have not (yet) created a lease are never considered stalled.
+func (r *Reconciler) isNodeLauncherStalled(
+	ctx context.Context,
+	pods []k8scorev1.Pod,
+) bool {
+	now := time.Now()
+
+	for i := range pods {
+		if pods[i].Status.Phase != k8scorev1.PodRunning {
+			continue
+		}
+
+		lease := &k8scoordinationv1.Lease{}
+
+		err := r.Client.Get(
+			ctx,
+			apimachinerytypes.NamespacedName{
+				Namespace: pods[i].Namespace,
+				Name:      pods[i].Name,
+			},
+			lease,
+		)
+		if err != nil {
+			continue
+		}
+
+		if LauncherLeaseExpired(lease, now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LauncherWatchdogRestart returns the last in place restart the launcher watchdog published on the
+// given launcher heartbeat lease, or nil if there is none (or it can not be decoded).
+func LauncherWatchdogRestart(
+	lease *k8scoordinationv1.Lease,
+) *clabernetesapisv1alpha1.TimelineEvent {
+	rawEvent, ok := lease.Annotations[clabernetesconstants.LauncherWatchdogRestartAnnotation]
+	if !ok {
+		return nil
+	}
+
+	event := &clabernetesapisv1alpha1.TimelineEvent{}
+
+	err := json.Unmarshal([]byte(rawEvent), event)
+	if err != nil || event.Reason == "" || event.Time.IsZero() {
+		return nil
+	}
+
+	return event
+}
+
+// reconcileWatchdogRestarts records the last in place restart the launcher watchdog of the given
+// node did (if any) in the timeline of the topology. Restarts that made the launcher exit are
+// recorded as node terminations, this only covers the ones the launcher recovered from.
+func (r *Reconciler) reconcileWatchdogRestarts(
+	ctx context.Context,
+	reconcileData *ReconcileData,
+	nodeName string,
+	pods []k8scorev1.Pod,
+) {
+	for i := range pods {
+		lease := &k8scoordinationv1.Lease{}
+
+		err := r.Client.Get(
+			ctx,
+			apimachinerytypes.NamespacedName{
+				Namespace: pods[i].Namespace,
+				Name:      pods[i].Name,
+			},
+			lease,
+		)
+		if err != nil {
+			continue
+		}
+
+		event := LauncherWatchdogRestart(lease)
+		if event == nil {
+			continue
+		}
+
+		// we know which node the lease belongs to, no need to trust the launcher on that
+		event.Node = nodeName
+		event.Source = clabernetesconstants.TimelineSourceLauncher
+
+		reconcileData.RecordTimelineEvent(*event)
+	}
+}
@@ -0,0 +1,144 @@
+package topology_test
+
+import (
+	"testing"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	k8scoordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLauncherLeaseExpired(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		lease    *k8scoordinationv1.Lease
+		expected bool
+	}{
+		{
+			name:     "never-renewed",
+			lease:    &k8scoordinationv1.Lease{},
+			expected: true,
+		},
+		{
+			name: "recently-renewed",
+			lease: &k8scoordinationv1.Lease{
+				Spec: k8scoordinationv1.LeaseSpec{
+					RenewTime: clabernetesutil.ToPointer(
+						metav1.NewMicroTime(now.Add(-10 * time.Second)),
+					),
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "expired-default-duration",
+			lease: &k8scoordinationv1.Lease{
+				Spec: k8scoordinationv1.LeaseSpec{
+					RenewTime: clabernetesutil.ToPointer(
+						metav1.NewMicroTime(now.Add(-2 * time.Minute)),
+					),
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "within-lease-duration",
+			lease: &k8scoordinationv1.Lease{
+				Spec: k8scoordinationv1.LeaseSpec{
+					RenewTime: clabernetesutil.ToPointer(
+						metav1.NewMicroTime(now.Add(-2 * time.Minute)),
+					),
+					LeaseDurationSeconds: clabernetesutil.ToPointer(int32(300)),
+				},
+			},
+			expected: false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.LauncherLeaseExpired(testCase.lease, now)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestLauncherWatchdogRestart(t *testing.T) {
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		expected    *clabernetesapisv1alpha1.TimelineEvent
+	}{
+		{
+			name:        "no-annotation",
+			annotations: nil,
+			expected:    nil,
+		},
+		{
+			name: "invalid-annotation",
+			annotations: map[string]string{
+				clabernetesconstants.LauncherWatchdogRestartAnnotation: "not json",
+			},
+			expected: nil,
+		},
+		{
+			name: "restart",
+			annotations: map[string]string{
+				clabernetesconstants.LauncherWatchdogRestartAnnotation: `{"time":"2024-01-01T12:00:00Z","source":"launcher","node":"srl1","reason":"DockerDaemonRestarted","message":"docker daemon stopped responding and was restarted"}`, //nolint:lll
+			},
+			expected: &clabernetesapisv1alpha1.TimelineEvent{
+				Time:    metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)),
+				Source:  clabernetesconstants.TimelineSourceLauncher,
+				Node:    "srl1",
+				Reason:  clabernetesconstants.WatchdogReasonDockerRestarted,
+				Message: "docker daemon stopped responding and was restarted",
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.LauncherWatchdogRestart(
+					&k8scoordinationv1.Lease{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: testCase.annotations,
+						},
+					},
+				)
+
+				if testCase.expected == nil {
+					if actual != nil {
+						clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+					}
+
+					return
+				}
+
+				if actual == nil ||
+					!actual.Time.Equal(&testCase.expected.Time) ||
+					actual.Source != testCase.expected.Source ||
+					actual.Node != testCase.expected.Node ||
+					actual.Reason != testCase.expected.Reason ||
+					actual.Message != testCase.expected.Message {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
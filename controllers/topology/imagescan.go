@@ -0,0 +1,461 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	conditionImageScanFailed = "ImageScanFailed"
+	reasonImageScanFailed    = "ImageScanFailed"
+
+	// finished scans are trusted this long before the scanner is asked again -- scanners update
+	// their vulnerability databases, and tags move, so results do not stay valid forever.
+	imageScanResultTTL = 6 * time.Hour
+
+	// how soon to ask the scanner again about pending (or failed to scan) images.
+	imageScanRetryInterval = 30 * time.Second
+
+	dockerHubRegistry = "docker.io"
+)
+
+// imageScanSeverities holds the vulnerability severities (as reported by the scanners) by rank.
+var imageScanSeverities = map[string]int{ //nolint:gochecknoglobals
+	"UNKNOWN":  0,
+	"LOW":      1,
+	"MEDIUM":   2, //nolint:mnd
+	"HIGH":     3, //nolint:mnd
+	"CRITICAL": 4, //nolint:mnd
+}
+
+// ImageReference is a parsed (and normalized, that is, with the default registry, namespace and
+// tag filled in) image reference.
+type ImageReference struct {
+	// Registry is the registry host of the image, for example "ghcr.io" or "docker.io".
+	Registry string
+	// Repository is the repository of the image within the registry, for example
+	// "nokia/srlinux" or "library/alpine".
+	Repository string
+	// Tag is the tag of the image, empty if the image is referenced by digest only.
+	Tag string
+	// Digest is the digest of the image, if the image is referenced by digest.
+	Digest string
+}
+
+// Reference returns the digest of the image if it is referenced by digest, otherwise its tag.
+func (i *ImageReference) Reference() string {
+	if i.Digest != "" {
+		return i.Digest
+	}
+
+	return i.Tag
+}
+
+// String returns the full (normalized) image reference.
+func (i *ImageReference) String() string {
+	image := i.Registry + "/" + i.Repository
+
+	if i.Tag != "" {
+		image += ":" + i.Tag
+	}
+
+	if i.Digest != "" {
+		image += "@" + i.Digest
+	}
+
+	return image
+}
+
+// ParseImageReference parses the given image reference the way docker does -- the first path
+// component is the registry only if it looks like a host (has a "." or ":", or is "localhost"),
+// images without registry are docker hub images, and docker hub images without namespace are in
+// the "library" namespace. Images without tag or digest get the "latest" tag.
+func ParseImageReference(image string) (*ImageReference, error) {
+	image = strings.TrimSpace(image)
+	if image == "" {
+		return nil, fmt.Errorf("%w: empty image reference", claberneteserrors.ErrParse)
+	}
+
+	ref := &ImageReference{}
+
+	image, ref.Digest, _ = strings.Cut(image, "@")
+
+	lastSlash := strings.LastIndex(image, "/")
+
+	if lastColon := strings.LastIndex(image, ":"); lastColon > lastSlash {
+		ref.Tag = image[lastColon+1:]
+		image = image[:lastColon]
+	}
+
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	firstComponent, rest, hasSlash := strings.Cut(image, "/")
+	if hasSlash && (strings.ContainsAny(firstComponent, ".:") || firstComponent == "localhost") {
+		ref.Registry = firstComponent
+		ref.Repository = rest
+	} else {
+		ref.Registry = dockerHubRegistry
+		ref.Repository = image
+	}
+
+	if ref.Registry == dockerHubRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+
+	if ref.Repository == "" {
+		return nil, fmt.Errorf(
+			"%w: image reference %q has no repository",
+			claberneteserrors.ErrParse,
+			image,
+		)
+	}
+
+	return ref, nil
+}
+
+// ImageScanVerdict returns the verdict for an image with the given (severity -> count)
+// vulnerabilities: "failed" if there are vulnerabilities at or above the given severity threshold,
+// otherwise "passed", along with a message summarizing the vulnerabilities at or above the
+// threshold.
+func ImageScanVerdict(vulnerabilities map[string]int, severityThreshold string) (string, string) {
+	threshold, ok := imageScanSeverities[strings.ToUpper(severityThreshold)]
+	if !ok {
+		threshold = imageScanSeverities[clabernetesconstants.ImageScanSeverityCritical]
+	}
+
+	var findings []string
+
+	for _, severity := range slices.Sorted(maps.Keys(vulnerabilities)) {
+		count := vulnerabilities[severity]
+
+		rank, known := imageScanSeverities[strings.ToUpper(severity)]
+		if !known || rank < threshold || count == 0 {
+			continue
+		}
+
+		findings = append(findings, fmt.Sprintf("%d %s", count, strings.ToUpper(severity)))
+	}
+
+	if len(findings) == 0 {
+		return clabernetesconstants.ImageScanVerdictPassed, fmt.Sprintf(
+			"no vulnerabilities at or above %s",
+			strings.ToUpper(severityThreshold),
+		)
+	}
+
+	return clabernetesconstants.ImageScanVerdictFailed, fmt.Sprintf(
+		"%s vulnerabilities",
+		strings.Join(findings, ", "),
+	)
+}
+
+// ImageScanReconciler is a subcomponent of the "TopologyReconciler" but is exposed for testing
+// purposes. This is the component responsible for the (optional) pre-deploy image scanning gate --
+// it has the globally configured scanner scan the images of the nodes of a topology, records the
+// results, and decides (per the configured policy) which nodes may not be deployed (yet).
+type ImageScanReconciler struct {
+	log                 claberneteslogging.Instance
+	client              ctrlruntimeclient.Client
+	configManagerGetter clabernetesconfig.ManagerGetterFunc
+	managerNamespace    string
+	httpClient          *http.Client
+	scanRequests        *scanRequests
+}
+
+// NewImageScanReconciler returns an instance of ImageScanReconciler.
+func NewImageScanReconciler(
+	log claberneteslogging.Instance,
+	client ctrlruntimeclient.Client,
+	configManagerGetter clabernetesconfig.ManagerGetterFunc,
+	managerNamespace string,
+) *ImageScanReconciler {
+	return &ImageScanReconciler{
+		log:                 log,
+		client:              client,
+		configManagerGetter: configManagerGetter,
+		managerNamespace:    managerNamespace,
+		httpClient:          newImageScannerHTTPClient(),
+		scanRequests: &scanRequests{
+			ids: map[string]string{},
+		},
+	}
+}
+
+func (r *ImageScanReconciler) scanner(
+	ctx context.Context,
+	imageScanning clabernetesapisv1alpha1.ConfigImageScanning,
+) (imageScanner, error) {
+	auth := &imageScannerAuth{}
+
+	if imageScanning.CredentialsSecret != "" {
+		secret := &k8scorev1.Secret{}
+
+		err := r.client.Get(
+			ctx,
+			apimachinerytypes.NamespacedName{
+				Namespace: r.managerNamespace,
+				Name:      imageScanning.CredentialsSecret,
+			},
+			secret,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: failed getting image scanner credentials secret %q, error: %w",
+				claberneteserrors.ErrImageScan,
+				imageScanning.CredentialsSecret,
+				err,
+			)
+		}
+
+		auth.token = string(secret.Data["token"])
+		auth.username = string(secret.Data["username"])
+		auth.password = string(secret.Data["password"])
+	}
+
+	switch imageScanning.Scanner {
+	case clabernetesconstants.ImageScannerHarbor:
+		return &harborImageScanner{
+			endpoint:   imageScanning.Endpoint,
+			auth:       auth,
+			httpClient: r.httpClient,
+		}, nil
+	case clabernetesconstants.ImageScannerTrivy:
+		return &trivyImageScanner{
+			endpoint:     imageScanning.Endpoint,
+			auth:         auth,
+			httpClient:   r.httpClient,
+			scanRequests: r.scanRequests,
+		}, nil
+	default:
+		return nil, fmt.Errorf(
+			"%w: unknown image scanner %q",
+			claberneteserrors.ErrImageScan,
+			imageScanning.Scanner,
+		)
+	}
+}
+
+// scanImage returns the scan result of the given image -- the previous result is reused as long as
+// it is a finished scan that is not older than imageScanResultTTL (the verdict is re-evaluated
+// though, as the severity threshold may have changed), otherwise the scanner is asked.
+func (r *ImageScanReconciler) scanImage(
+	ctx context.Context,
+	scanner imageScanner,
+	scannerErr error,
+	severityThreshold string,
+	image string,
+	previous *clabernetesapisv1alpha1.ImageScan,
+) clabernetesapisv1alpha1.ImageScan {
+	now := time.Now()
+
+	if previous != nil &&
+		(previous.Verdict == clabernetesconstants.ImageScanVerdictPassed ||
+			previous.Verdict == clabernetesconstants.ImageScanVerdictFailed) &&
+		now.Sub(previous.ScannedAt.Time) < imageScanResultTTL {
+		result := *previous.DeepCopy()
+
+		result.Verdict, result.Message = ImageScanVerdict(
+			result.Vulnerabilities,
+			severityThreshold,
+		)
+
+		return result
+	}
+
+	result := clabernetesapisv1alpha1.ImageScan{
+		ScannedAt: metav1.NewTime(now.Truncate(time.Second)),
+	}
+
+	if scannerErr != nil {
+		result.Verdict = clabernetesconstants.ImageScanVerdictError
+		result.Message = scannerErr.Error()
+
+		return result
+	}
+
+	imageReference, err := ParseImageReference(image)
+	if err != nil {
+		result.Verdict = clabernetesconstants.ImageScanVerdictError
+		result.Message = err.Error()
+
+		return result
+	}
+
+	report, err := scanner.scan(ctx, imageReference)
+
+	switch {
+	case err != nil:
+		r.log.Warnf("failed scanning image %q, error: %s", image, err)
+
+		result.Verdict = clabernetesconstants.ImageScanVerdictError
+		result.Message = err.Error()
+	case report.pending:
+		result.Verdict = clabernetesconstants.ImageScanVerdictPending
+		result.Message = "scan is not finished yet"
+	default:
+		result.Scanner = report.scanner
+		result.Digest = report.digest
+		result.Vulnerabilities = report.vulnerabilities
+		result.Verdict, result.Message = ImageScanVerdict(
+			report.vulnerabilities,
+			severityThreshold,
+		)
+	}
+
+	return result
+}
+
+// Reconcile scans the images of the nodes of the given topology with the globally configured
+// scanner and records the results in the reconcile data, along with the nodes the image scanning
+// policy does not allow to be deployed. It returns how soon the topology should be reconciled
+// again to pick up pending scans, or zero if there are none.
+func (r *ImageScanReconciler) Reconcile(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) time.Duration {
+	imageScanning := r.configManagerGetter().GetImageScanning()
+	if imageScanning.Scanner == clabernetesconstants.ImageScannerNone {
+		return 0
+	}
+
+	nodeImages := map[string][]string{}
+
+	for nodeName, nodeConfig := range reconcileData.ResolvedConfigs {
+		if nodeConfig == nil || nodeConfig.Topology == nil {
+			continue
+		}
+
+		image := nodeConfig.Topology.GetNodeImage(nodeName)
+		if image == "" {
+			continue
+		}
+
+		nodeImages[image] = append(nodeImages[image], nodeName)
+	}
+
+	// only build the scanner (that is, get the credentials) once per reconcile, and only if there
+	// are images to scan at all
+	var (
+		scanner    imageScanner
+		scannerErr error
+	)
+
+	if len(nodeImages) > 0 {
+		scanner, scannerErr = r.scanner(ctx, imageScanning)
+	}
+
+	var requeueAfter time.Duration
+
+	for _, image := range slices.Sorted(maps.Keys(nodeImages)) {
+		var previous *clabernetesapisv1alpha1.ImageScan
+
+		if previousResult, ok := owningTopology.Status.ImageScans[image]; ok {
+			previous = &previousResult
+		}
+
+		result := r.scanImage(
+			ctx,
+			scanner,
+			scannerErr,
+			imageScanning.SeverityThreshold,
+			image,
+			previous,
+		)
+
+		reconcileData.ImageScans[image] = result
+
+		if result.Verdict == clabernetesconstants.ImageScanVerdictPassed {
+			continue
+		}
+
+		if result.Verdict == clabernetesconstants.ImageScanVerdictPending ||
+			result.Verdict == clabernetesconstants.ImageScanVerdictError {
+			requeueAfter = imageScanRetryInterval
+		}
+
+		if imageScanning.Policy == clabernetesconstants.ImageScanPolicyBlock {
+			for _, nodeName := range nodeImages[image] {
+				reconcileData.ImageBlockedNodes.Add(nodeName)
+			}
+		}
+	}
+
+	return requeueAfter
+}
+
+// reconcileImageScanCondition sets (or clears) the "ImageScanFailed" condition on the topology
+// listing the images that did not pass the scan (yet), and whether their nodes are deployed anyway
+// or blocked.
+func (r *Reconciler) reconcileImageScanCondition(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) {
+	var failedImages []string
+
+	for _, image := range slices.Sorted(maps.Keys(reconcileData.ImageScans)) {
+		result := reconcileData.ImageScans[image]
+
+		if result.Verdict == clabernetesconstants.ImageScanVerdictPassed {
+			continue
+		}
+
+		failedImages = append(
+			failedImages,
+			fmt.Sprintf("%s: %s (%s)", image, result.Verdict, result.Message),
+		)
+	}
+
+	if len(failedImages) == 0 {
+		apimachinerymeta.RemoveStatusCondition(
+			&owningTopology.Status.Conditions,
+			conditionImageScanFailed,
+		)
+
+		return
+	}
+
+	outcome := "nodes are deployed anyway per the image scanning policy"
+
+	if reconcileData.ImageBlockedNodes.Len() > 0 {
+		blockedNodes := reconcileData.ImageBlockedNodes.Items()
+
+		slices.Sort(blockedNodes)
+
+		outcome = fmt.Sprintf(
+			"blocked node(s) %s per the image scanning policy",
+			strings.Join(blockedNodes, ", "),
+		)
+	}
+
+	r.Log.Warnf("image(s) %q did not pass the image scan, %s", failedImages, outcome)
+
+	apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+		Type:   conditionImageScanFailed,
+		Status: "True",
+		Reason: reasonImageScanFailed,
+		Message: fmt.Sprintf(
+			"image(s) did not pass the scan: %s; %s",
+			strings.Join(failedImages, "; "),
+			outcome,
+		),
+	})
+}
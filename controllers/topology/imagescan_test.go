@@ -0,0 +1,142 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestParseImageReference(t *testing.T) {
+	cases := []struct {
+		name     string
+		image    string
+		expected *clabernetescontrollerstopology.ImageReference
+	}{
+		{
+			name:  "docker-hub-official",
+			image: "alpine",
+			expected: &clabernetescontrollerstopology.ImageReference{
+				Registry:   "docker.io",
+				Repository: "library/alpine",
+				Tag:        "latest",
+			},
+		},
+		{
+			name:  "docker-hub-namespaced",
+			image: "frrouting/frr:v8.4.0",
+			expected: &clabernetescontrollerstopology.ImageReference{
+				Registry:   "docker.io",
+				Repository: "frrouting/frr",
+				Tag:        "v8.4.0",
+			},
+		},
+		{
+			name:  "registry",
+			image: "ghcr.io/nokia/srlinux:23.10.1",
+			expected: &clabernetescontrollerstopology.ImageReference{
+				Registry:   "ghcr.io",
+				Repository: "nokia/srlinux",
+				Tag:        "23.10.1",
+			},
+		},
+		{
+			name:  "registry-port",
+			image: "harbor.lab:8443/nos/ceos",
+			expected: &clabernetescontrollerstopology.ImageReference{
+				Registry:   "harbor.lab:8443",
+				Repository: "nos/ceos",
+				Tag:        "latest",
+			},
+		},
+		{
+			name:  "digest",
+			image: "localhost/vr-sros@sha256:abcd",
+			expected: &clabernetescontrollerstopology.ImageReference{
+				Registry:   "localhost",
+				Repository: "vr-sros",
+				Digest:     "sha256:abcd",
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual, err := clabernetescontrollerstopology.ParseImageReference(testCase.image)
+				if err != nil {
+					t.Fatalf("failed parsing image reference, error: %s", err)
+				}
+
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestImageScanVerdict(t *testing.T) {
+	cases := []struct {
+		name              string
+		vulnerabilities   map[string]int
+		severityThreshold string
+		expectedVerdict   string
+		expectedMessage   string
+	}{
+		{
+			name:              "clean",
+			vulnerabilities:   nil,
+			severityThreshold: "CRITICAL",
+			expectedVerdict:   clabernetesconstants.ImageScanVerdictPassed,
+			expectedMessage:   "no vulnerabilities at or above CRITICAL",
+		},
+		{
+			name: "below-threshold",
+			vulnerabilities: map[string]int{
+				"HIGH":   3,
+				"MEDIUM": 10,
+			},
+			severityThreshold: "CRITICAL",
+			expectedVerdict:   clabernetesconstants.ImageScanVerdictPassed,
+			expectedMessage:   "no vulnerabilities at or above CRITICAL",
+		},
+		{
+			name: "at-and-above-threshold",
+			vulnerabilities: map[string]int{
+				"CRITICAL": 1,
+				"HIGH":     3,
+				"MEDIUM":   10,
+				"UNKNOWN":  2,
+			},
+			severityThreshold: "high",
+			expectedVerdict:   clabernetesconstants.ImageScanVerdictFailed,
+			expectedMessage:   "1 CRITICAL, 3 HIGH vulnerabilities",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actualVerdict, actualMessage := clabernetescontrollerstopology.ImageScanVerdict(
+					testCase.vulnerabilities,
+					testCase.severityThreshold,
+				)
+
+				if actualVerdict != testCase.expectedVerdict {
+					clabernetestesthelper.FailOutput(t, actualVerdict, testCase.expectedVerdict)
+				}
+
+				if actualMessage != testCase.expectedMessage {
+					clabernetestesthelper.FailOutput(t, actualMessage, testCase.expectedMessage)
+				}
+			})
+	}
+}
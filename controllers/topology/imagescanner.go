@@ -0,0 +1,480 @@
+package topology
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+const (
+	imageScannerHTTPTimeout = 30 * time.Second
+
+	// the pluggable scanner api (and harbor) report vulnerabilities in this report format.
+	vulnerabilityReportMimeType = "application/vnd.security.vulnerability.report; version=1.1"
+	scanRequestMimeType         = "application/vnd.scanner.adapter.scan.request+json; version=1.0"
+
+	harborScanStatusSuccess = "Success"
+	harborScanStatusError   = "Error"
+	harborScanStatusStopped = "Stopped"
+)
+
+// imageScanReport is what an imageScanner knows about an image -- pending is true if the scan of
+// the image is not finished yet, in which case there is nothing else in the report.
+type imageScanReport struct {
+	scanner         string
+	digest          string
+	pending         bool
+	vulnerabilities map[string]int
+}
+
+// imageScanner is a client of an image scanner.
+type imageScanner interface {
+	// scan returns the scan report of the given image, submitting the image for scanning first if
+	// the scanner requires that (or has not scanned the image yet).
+	scan(ctx context.Context, image *ImageReference) (*imageScanReport, error)
+}
+
+// imageScannerAuth is the (optional) authorization the image scanners send along with their
+// requests, either a bearer token or basic auth credentials.
+type imageScannerAuth struct {
+	token    string
+	username string
+	password string
+}
+
+func (a *imageScannerAuth) apply(req *http.Request) {
+	switch {
+	case a.token != "":
+		req.Header.Set("Authorization", "Bearer "+a.token)
+	case a.username != "":
+		req.SetBasicAuth(a.username, a.password)
+	}
+}
+
+func newImageScannerHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: imageScannerHTTPTimeout,
+		// the scanner adapter answers "report not ready yet" with a 302 *without* a location, so
+		// never follow redirects, we want to see them
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func doImageScannerRequest(
+	ctx context.Context,
+	httpClient *http.Client,
+	auth *imageScannerAuth,
+	method,
+	requestURL string,
+	body []byte,
+	headers map[string]string,
+) (*http.Response, []byte, error) {
+	var bodyReader io.Reader = http.NoBody
+
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bodyReader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	auth.apply(req)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer resp.Body.Close() //nolint
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, respBody, nil
+}
+
+// harborImageScanner reads the scan overview harbor keeps for the artifacts in its registry --
+// harbor scans images (with whatever scanner is configured in harbor) on push, or when asked to,
+// so this only works for images hosted in the harbor registry at the scanner endpoint.
+type harborImageScanner struct {
+	endpoint   string
+	auth       *imageScannerAuth
+	httpClient *http.Client
+}
+
+type harborScanSummary struct {
+	Total   int            `json:"total"`
+	Summary map[string]int `json:"summary"`
+}
+
+type harborScanner struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harborNativeReportSummary struct {
+	ScanStatus string             `json:"scan_status"` //nolint:tagliatelle
+	Summary    *harborScanSummary `json:"summary"`
+	Scanner    *harborScanner     `json:"scanner"`
+}
+
+type harborArtifact struct {
+	Digest       string                               `json:"digest"`
+	ScanOverview map[string]harborNativeReportSummary `json:"scan_overview"` //nolint:tagliatelle
+}
+
+func (s *harborImageScanner) artifactURL(image *ImageReference) (string, error) {
+	endpointURL, err := url.Parse(s.endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.EqualFold(endpointURL.Host, image.Registry) {
+		return "", fmt.Errorf(
+			"%w: image is not hosted in the harbor registry %q",
+			claberneteserrors.ErrImageScan,
+			endpointURL.Host,
+		)
+	}
+
+	project, repository, ok := strings.Cut(image.Repository, "/")
+	if !ok {
+		return "", fmt.Errorf(
+			"%w: image repository %q has no harbor project",
+			claberneteserrors.ErrImageScan,
+			image.Repository,
+		)
+	}
+
+	// harbor wants the slashes of (nested) repository names double escaped
+	return fmt.Sprintf(
+		"%s/api/v2.0/projects/%s/repositories/%s/artifacts/%s",
+		s.endpoint,
+		url.PathEscape(project),
+		url.PathEscape(url.PathEscape(repository)),
+		url.PathEscape(image.Reference()),
+	), nil
+}
+
+func (s *harborImageScanner) scan(
+	ctx context.Context,
+	image *ImageReference,
+) (*imageScanReport, error) {
+	artifactURL, err := s.artifactURL(image)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, err := doImageScannerRequest(
+		ctx,
+		s.httpClient,
+		s.auth,
+		http.MethodGet,
+		artifactURL+"?with_scan_overview=true",
+		nil,
+		map[string]string{
+			"Accept":                   "application/json",
+			"X-Accept-Vulnerabilities": vulnerabilityReportMimeType,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(
+			"%w: harbor returned status code %d getting artifact",
+			claberneteserrors.ErrImageScan,
+			resp.StatusCode,
+		)
+	}
+
+	artifact := &harborArtifact{}
+
+	err = json.Unmarshal(body, artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	overview, ok := artifact.ScanOverview[vulnerabilityReportMimeType]
+	if !ok {
+		// never scanned, ask harbor to do so
+		return s.requestScan(ctx, artifactURL)
+	}
+
+	switch overview.ScanStatus {
+	case harborScanStatusSuccess:
+	case harborScanStatusError, harborScanStatusStopped:
+		return nil, fmt.Errorf(
+			"%w: harbor scan of the image finished with status %q",
+			claberneteserrors.ErrImageScan,
+			overview.ScanStatus,
+		)
+	default:
+		return &imageScanReport{pending: true}, nil
+	}
+
+	report := &imageScanReport{
+		scanner:         "Harbor",
+		digest:          artifact.Digest,
+		vulnerabilities: map[string]int{},
+	}
+
+	if overview.Scanner != nil {
+		report.scanner = strings.TrimSpace(overview.Scanner.Name + " " + overview.Scanner.Version)
+	}
+
+	if overview.Summary != nil {
+		for severity, count := range overview.Summary.Summary {
+			report.vulnerabilities[strings.ToUpper(severity)] += count
+		}
+	}
+
+	return report, nil
+}
+
+func (s *harborImageScanner) requestScan(
+	ctx context.Context,
+	artifactURL string,
+) (*imageScanReport, error) {
+	resp, _, err := doImageScannerRequest(
+		ctx,
+		s.httpClient,
+		s.auth,
+		http.MethodPost,
+		artifactURL+"/scan",
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// conflict means a scan is already running
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusConflict {
+		return nil, fmt.Errorf(
+			"%w: harbor returned status code %d requesting scan",
+			claberneteserrors.ErrImageScan,
+			resp.StatusCode,
+		)
+	}
+
+	return &imageScanReport{pending: true}, nil
+}
+
+// trivyImageScanner submits images to a trivy scanner adapter via the harbor pluggable scanner
+// api: a scan request is submitted for an image and its report fetched once it is ready. The ids of
+// the submitted scan requests are kept (in memory) until their report is fetched, so an image is
+// submitted only once even though the report is fetched over multiple reconciles.
+type trivyImageScanner struct {
+	endpoint     string
+	auth         *imageScannerAuth
+	httpClient   *http.Client
+	scanRequests *scanRequests
+}
+
+// scanRequests is the (image -> scan request id) record of the scan requests submitted to a trivy
+// scanner adapter.
+type scanRequests struct {
+	lock sync.Mutex
+	ids  map[string]string
+}
+
+func (s *scanRequests) get(image string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.ids[image]
+}
+
+func (s *scanRequests) set(image, id string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if id == "" {
+		delete(s.ids, image)
+
+		return
+	}
+
+	s.ids[image] = id
+}
+
+type trivyScanRequest struct {
+	Registry trivyScanRequestRegistry `json:"registry"`
+	Artifact trivyScanRequestArtifact `json:"artifact"`
+}
+
+type trivyScanRequestRegistry struct {
+	URL string `json:"url"`
+}
+
+type trivyScanRequestArtifact struct {
+	Repository string `json:"repository"`
+	Tag        string `json:"tag,omitempty"`
+	Digest     string `json:"digest,omitempty"`
+}
+
+type trivyScanResponse struct {
+	ID string `json:"id"`
+}
+
+type trivyVulnerabilityReport struct {
+	Artifact struct {
+		Digest string `json:"digest"`
+	} `json:"artifact"`
+	Scanner struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"scanner"`
+	Vulnerabilities []struct {
+		Severity string `json:"severity"`
+	} `json:"vulnerabilities"`
+}
+
+func (s *trivyImageScanner) scan(
+	ctx context.Context,
+	image *ImageReference,
+) (*imageScanReport, error) {
+	imageName := image.String()
+
+	scanRequestID := s.scanRequests.get(imageName)
+	if scanRequestID == "" {
+		id, err := s.requestScan(ctx, image)
+		if err != nil {
+			return nil, err
+		}
+
+		s.scanRequests.set(imageName, id)
+
+		return &imageScanReport{pending: true}, nil
+	}
+
+	resp, body, err := doImageScannerRequest(
+		ctx,
+		s.httpClient,
+		s.auth,
+		http.MethodGet,
+		fmt.Sprintf("%s/api/v1/scan/%s/report", s.endpoint, url.PathEscape(scanRequestID)),
+		nil,
+		map[string]string{"Accept": vulnerabilityReportMimeType},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusFound:
+		// report is not ready yet
+		return &imageScanReport{pending: true}, nil
+	default:
+		// the scan failed or the adapter forgot about it (restarted?), either way submit the image
+		// again next time
+		s.scanRequests.set(imageName, "")
+
+		return nil, fmt.Errorf(
+			"%w: scanner adapter returned status code %d getting scan report",
+			claberneteserrors.ErrImageScan,
+			resp.StatusCode,
+		)
+	}
+
+	s.scanRequests.set(imageName, "")
+
+	vulnerabilityReport := &trivyVulnerabilityReport{}
+
+	err = json.Unmarshal(body, vulnerabilityReport)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &imageScanReport{
+		scanner: strings.TrimSpace(
+			vulnerabilityReport.Scanner.Name + " " + vulnerabilityReport.Scanner.Version,
+		),
+		digest:          vulnerabilityReport.Artifact.Digest,
+		vulnerabilities: map[string]int{},
+	}
+
+	for _, vulnerability := range vulnerabilityReport.Vulnerabilities {
+		report.vulnerabilities[strings.ToUpper(vulnerability.Severity)]++
+	}
+
+	return report, nil
+}
+
+func (s *trivyImageScanner) requestScan(
+	ctx context.Context,
+	image *ImageReference,
+) (string, error) {
+	scanRequest, err := json.Marshal(trivyScanRequest{
+		Registry: trivyScanRequestRegistry{
+			URL: "https://" + image.Registry,
+		},
+		Artifact: trivyScanRequestArtifact{
+			Repository: image.Repository,
+			Tag:        image.Tag,
+			Digest:     image.Digest,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resp, body, err := doImageScannerRequest(
+		ctx,
+		s.httpClient,
+		s.auth,
+		http.MethodPost,
+		s.endpoint+"/api/v1/scan",
+		scanRequest,
+		map[string]string{"Content-Type": scanRequestMimeType},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf(
+			"%w: scanner adapter returned status code %d submitting scan request",
+			claberneteserrors.ErrImageScan,
+			resp.StatusCode,
+		)
+	}
+
+	scanResponse := &trivyScanResponse{}
+
+	err = json.Unmarshal(body, scanResponse)
+	if err != nil {
+		return "", err
+	}
+
+	if scanResponse.ID == "" {
+		return "", fmt.Errorf(
+			"%w: scanner adapter returned no scan request id",
+			claberneteserrors.ErrImageScan,
+		)
+	}
+
+	return scanResponse.ID, nil
+}
@@ -0,0 +1,35 @@
+package topology
+
+import (
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+)
+
+// ApplyLinkImpairments sets the impairment of each of the given tunnels based on the link
+// impairments of the owning topology -- tunnels whose local endpoint is not listed in any of the
+// link impairments have their impairment cleared. When an endpoint is listed multiple times the
+// last listing wins. It returns a sorted list of the impairment endpoints that did not match any
+// tunnel so the caller can let the user know.
+func ApplyLinkImpairments(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	tunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+) []string {
+	impairments := map[string]clabernetesapisv1alpha1.LinkImpairment{}
+
+	for _, linkImpairment := range owningTopology.Spec.LinkImpairments {
+		for _, endpoint := range linkImpairment.Endpoints {
+			impairments[endpoint] = linkImpairment.LinkImpairment
+		}
+	}
+
+	return applyEndpointSettings(
+		tunnels,
+		impairments,
+		false,
+		func(
+			tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+			impairment *clabernetesapisv1alpha1.LinkImpairment,
+		) {
+			tunnel.Impairment = impairment
+		},
+	)
+}
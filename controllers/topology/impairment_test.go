@@ -0,0 +1,117 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestApplyLinkImpairments(t *testing.T) {
+	cases := []struct {
+		name                string
+		linkImpairments     []clabernetesapisv1alpha1.TopologyLinkImpairment
+		expectedImpairments map[string]*clabernetesapisv1alpha1.LinkImpairment
+		expectedUnmatched   []string
+	}{
+		{
+			name:            "no-impairments-clears-existing",
+			linkImpairments: nil,
+			expectedImpairments: map[string]*clabernetesapisv1alpha1.LinkImpairment{
+				"srl1": nil,
+				"srl2": nil,
+			},
+			expectedUnmatched: nil,
+		},
+		{
+			name: "single-endpoint",
+			linkImpairments: []clabernetesapisv1alpha1.TopologyLinkImpairment{
+				{
+					Endpoints: []string{"srl2:e1-1"},
+					LinkImpairment: clabernetesapisv1alpha1.LinkImpairment{
+						Delay:  "10ms",
+						Jitter: "2ms",
+						Loss:   "0.5",
+					},
+				},
+			},
+			expectedImpairments: map[string]*clabernetesapisv1alpha1.LinkImpairment{
+				"srl1": nil,
+				"srl2": {
+					Delay:  "10ms",
+					Jitter: "2ms",
+					Loss:   "0.5",
+				},
+			},
+			expectedUnmatched: nil,
+		},
+		{
+			name: "both-endpoints-and-unmatched",
+			linkImpairments: []clabernetesapisv1alpha1.TopologyLinkImpairment{
+				{
+					Endpoints: []string{"srl1:e1-1", "srl2:e1-1", "srl3:e1-1"},
+					LinkImpairment: clabernetesapisv1alpha1.LinkImpairment{
+						Rate: "100mbit",
+					},
+				},
+				{
+					Endpoints: []string{"srl1:e1-2"},
+					LinkImpairment: clabernetesapisv1alpha1.LinkImpairment{
+						Loss: "1%",
+					},
+				},
+			},
+			expectedImpairments: map[string]*clabernetesapisv1alpha1.LinkImpairment{
+				"srl1": {
+					Rate: "100mbit",
+				},
+				"srl2": {
+					Rate: "100mbit",
+				},
+			},
+			expectedUnmatched: []string{"srl1:e1-2", "srl3:e1-1"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						LinkImpairments: testCase.linkImpairments,
+					},
+				}
+
+				tunnels := linkTestTunnels()
+
+				// an impairment that is no longer desired must be cleared
+				tunnels["srl1"][0].Impairment = &clabernetesapisv1alpha1.LinkImpairment{
+					Delay: "100ms",
+				}
+
+				actualUnmatched := clabernetescontrollerstopology.ApplyLinkImpairments(
+					owningTopology,
+					tunnels,
+				)
+				if !reflect.DeepEqual(actualUnmatched, testCase.expectedUnmatched) {
+					clabernetestesthelper.FailOutput(
+						t,
+						actualUnmatched,
+						testCase.expectedUnmatched,
+					)
+				}
+
+				for nodeName, expectedImpairment := range testCase.expectedImpairments {
+					actualImpairment := tunnels[nodeName][0].Impairment
+					if !reflect.DeepEqual(actualImpairment, expectedImpairment) {
+						clabernetestesthelper.FailOutput(t, actualImpairment, expectedImpairment)
+					}
+				}
+			})
+	}
+}
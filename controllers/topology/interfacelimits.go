@@ -0,0 +1,134 @@
+package topology
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+// kindMaxInterfaces holds the built-in maximum number of (data) interfaces per (lowercase)
+// containerlab kind, for kinds whose nos only ever sees a fixed number of interfaces and silently
+// ignores any past that once booted. IOL has 16 slots of 4 ports with Ethernet0/0 being the
+// management interface, vios has 16 nics.
+var kindMaxInterfaces = map[string]int{ //nolint:gochecknoglobals
+	"iol":          63,
+	"cisco_iol":    63,
+	"vios":         16,
+	"vr-vios":      16,
+	"cisco_vios":   16,
+	"viosl2":       16,
+	"vr-viosl2":    16,
+	"cisco_viosl2": 16,
+}
+
+// ResolveKindMaxInterfaces returns the maximum number of interfaces nodes of the given kind support
+// -- the one of the native kind settings of the global config if they set one, otherwise the
+// built-in one of the kind, zero (no limit) if the kind has neither.
+func ResolveKindMaxInterfaces(
+	kind string,
+	nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind,
+) int {
+	nativeKind, ok := lookupConfigNativeKind(nativeKinds, kind)
+	if ok && nativeKind.MaxInterfaces > 0 {
+		return nativeKind.MaxInterfaces
+	}
+
+	return kindMaxInterfaces[normalizeNativeKind(kind)]
+}
+
+// CountNodeInterfaces returns the number of distinct interfaces each node of the given topology
+// has links on, including single ended (dummy) links.
+func CountNodeInterfaces(topology *clabernetesutilcontainerlab.Topology) map[string]int {
+	nodeInterfaces := map[string]clabernetesutil.StringSet{}
+
+	addInterface := func(nodeName, interfaceName string) {
+		if nodeName == "" || interfaceName == "" {
+			return
+		}
+
+		if nodeInterfaces[nodeName] == nil {
+			nodeInterfaces[nodeName] = clabernetesutil.NewStringSet()
+		}
+
+		nodeInterfaces[nodeName].Add(interfaceName)
+	}
+
+	for _, link := range topology.Links {
+		for _, endpoint := range link.Endpoints {
+			nodeName, interfaceName, _ := strings.Cut(endpoint, ":")
+
+			addInterface(nodeName, interfaceName)
+		}
+
+		if link.Endpoint != nil {
+			addInterface(
+				strings.TrimSpace(link.Endpoint["node"]),
+				strings.TrimSpace(link.Endpoint["interface"]),
+			)
+		}
+	}
+
+	interfaceCounts := make(map[string]int, len(nodeInterfaces))
+
+	for nodeName, interfaceNames := range nodeInterfaces {
+		interfaceCounts[nodeName] = interfaceNames.Len()
+	}
+
+	return interfaceCounts
+}
+
+// validateInterfaceLimits returns an error if any node of the given topology has links on more
+// interfaces than its kind supports (see ResolveKindMaxInterfaces) -- the nos would boot just fine
+// and quietly ignore the extra interfaces, leaving links that never pass any traffic.
+func (p *containerlabDefinitionProcessor) validateInterfaceLimits(
+	topology *clabernetesutilcontainerlab.Topology,
+) error {
+	nativeKinds := p.configManagerGetter().GetNativeKinds()
+
+	var violations []string
+
+	for nodeName, interfaceCount := range CountNodeInterfaces(topology) {
+		if _, ok := topology.Nodes[nodeName]; !ok {
+			continue
+		}
+
+		nodeKind, _ := topology.GetNodeKindType(nodeName)
+
+		maxInterfaces := ResolveKindMaxInterfaces(nodeKind, nativeKinds)
+		if maxInterfaces == 0 || interfaceCount <= maxInterfaces {
+			continue
+		}
+
+		violations = append(
+			violations,
+			fmt.Sprintf(
+				"node %q of kind %q has %d interfaces but the kind supports at most %d",
+				nodeName,
+				nodeKind,
+				interfaceCount,
+				maxInterfaces,
+			),
+		)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	slices.Sort(violations)
+
+	msg := fmt.Sprintf(
+		"%s -- spread the links of these nodes over more nodes (or raise the maxInterfaces of "+
+			"the kind in the native kinds of the global config if the nos supports more)",
+		strings.Join(violations, "; "),
+	)
+
+	p.logger.Critical(msg)
+
+	return fmt.Errorf("%w: %s", claberneteserrors.ErrInvalidData, msg)
+}
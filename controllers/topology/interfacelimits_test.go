@@ -0,0 +1,190 @@
+package topology_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// interfaceLimitsTopology returns a containerlab topology of a node of the given kind with links
+// to a linux node on the given number of interfaces.
+func interfaceLimitsTopology(kind string, interfaceCount int) string {
+	var links strings.Builder
+
+	for idx := 1; idx <= interfaceCount; idx++ {
+		links.WriteString(
+			fmt.Sprintf("        - endpoints: [\"r1:eth%d\", \"host1:eth%d\"]\n", idx, idx),
+		)
+	}
+
+	return fmt.Sprintf(`---
+    name: test
+    topology:
+      nodes:
+        r1:
+          kind: %s
+          image: some-nos:latest
+        host1:
+          kind: linux
+          image: alpine:latest
+      links:
+%s`, kind, links.String())
+}
+
+func TestDefinitionProcessInterfaceLimits(t *testing.T) {
+	cases := []struct {
+		name           string
+		kind           string
+		interfaceCount int
+		nativeKinds    map[string]clabernetesapisv1alpha1.ConfigNativeKind
+		expectErr      bool
+	}{
+		{
+			name:           "within-built-in-limit",
+			kind:           "cisco_vios",
+			interfaceCount: 16,
+		},
+		{
+			name:           "exceeds-built-in-limit",
+			kind:           "cisco_vios",
+			interfaceCount: 17,
+			expectErr:      true,
+		},
+		{
+			name:           "exceeds-built-in-limit-alias",
+			kind:           "vios",
+			interfaceCount: 20,
+			expectErr:      true,
+		},
+		{
+			name:           "no-limit",
+			kind:           "nokia_srlinux",
+			interfaceCount: 70,
+		},
+		{
+			name:           "global-config-raises-limit",
+			kind:           "cisco_vios",
+			interfaceCount: 20,
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"cisco_vios": {MaxInterfaces: 24},
+			},
+		},
+		{
+			name:           "global-config-sets-limit",
+			kind:           "nokia_srlinux",
+			interfaceCount: 9,
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"nokia_srlinux": {MaxInterfaces: 8},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				topology := &clabernetesapisv1alpha1.Topology{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "process-containerlab-definition-interface-limits-test",
+						Namespace: "clabernetes",
+					},
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						Definition: clabernetesapisv1alpha1.Definition{
+							Containerlab: interfaceLimitsTopology(
+								testCase.kind,
+								testCase.interfaceCount,
+							),
+						},
+					},
+				}
+
+				reconcileData := &clabernetescontrollerstopology.ReconcileData{
+					Kind:            "containerlab",
+					ResolvedHashes:  clabernetesapisv1alpha1.ReconcileHashes{},
+					ResolvedConfigs: map[string]*clabernetesutilcontainerlab.Config{},
+					ResolvedTunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{},
+				}
+
+				processor, err := clabernetescontrollerstopology.NewDefinitionProcessor(
+					&claberneteslogging.FakeInstance{},
+					topology,
+					reconcileData,
+					func() clabernetesconfig.Manager {
+						return clabernetesconfig.NewFakeManager(
+							clabernetesconfig.WithNativeKinds(testCase.nativeKinds),
+						)
+					},
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = processor.Process()
+
+				if testCase.expectErr {
+					if !errors.Is(err, claberneteserrors.ErrInvalidData) {
+						clabernetestesthelper.FailOutput(t, err, claberneteserrors.ErrInvalidData)
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+		)
+	}
+}
+
+func TestCountNodeInterfaces(t *testing.T) {
+	topology := &clabernetesutilcontainerlab.Topology{
+		Links: []*clabernetesutilcontainerlab.LinkDefinition{
+			{
+				LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+					Endpoints: []string{"r1:eth1", "r2:eth1"},
+				},
+			},
+			{
+				LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+					Endpoints: []string{"r1:eth2", "r2:eth2"},
+				},
+			},
+			{
+				LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+					Endpoints: []string{"r1:eth2", "host:r1-eth2"},
+				},
+			},
+			{
+				Type:     "dummy",
+				Endpoint: map[string]string{"node": "r2", "interface": "eth3"},
+			},
+		},
+	}
+
+	actual := clabernetescontrollerstopology.CountNodeInterfaces(topology)
+	expected := map[string]int{"r1": 2, "r2": 3, "host": 1}
+
+	if len(actual) != len(expected) {
+		clabernetestesthelper.FailOutput(t, actual, expected)
+	}
+
+	for nodeName, interfaceCount := range expected {
+		if actual[nodeName] != interfaceCount {
+			clabernetestesthelper.FailOutput(t, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package topology
+
+import (
+	"slices"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+// linkConnectivityKinds are the connectivity flavors that can be mixed within a topology -- the
+// flavors whose tunnels are independent of each other. Wireguard needs the topology wide overlay
+// (and its keys), multus does not use tunnels at all.
+var linkConnectivityKinds = []string{ //nolint:gochecknoglobals
+	clabernetesconstants.ConnectivityVXLAN,
+	clabernetesconstants.ConnectivitySlurpeeth,
+	clabernetesconstants.ConnectivityGeneve,
+	clabernetesconstants.ConnectivityGRE,
+}
+
+// topologyConnectivityKind returns the connectivity flavor of the given topology, the crd defaults
+// it to vxlan but objects that never went through the api server may not have it set.
+func topologyConnectivityKind(owningTopology *clabernetesapisv1alpha1.Topology) string {
+	if owningTopology.Spec.Connectivity == "" {
+		return clabernetesconstants.ConnectivityVXLAN
+	}
+
+	return owningTopology.Spec.Connectivity
+}
+
+// linkConnectivityEnabled returns true if the given topology has any link connectivity overrides
+// and its connectivity flavor can be mixed with others.
+func linkConnectivityEnabled(owningTopology *clabernetesapisv1alpha1.Topology) bool {
+	if len(owningTopology.Spec.LinkConnectivity) == 0 {
+		return false
+	}
+
+	return slices.Contains(linkConnectivityKinds, topologyConnectivityKind(owningTopology))
+}
+
+// ResolveLinkConnectivityKinds returns the sorted connectivity flavors the link connectivity
+// overrides of the given topology use on top of the connectivity flavor of the topology itself --
+// the launchers run a connectivity manager for each of them.
+func ResolveLinkConnectivityKinds(owningTopology *clabernetesapisv1alpha1.Topology) []string {
+	if !linkConnectivityEnabled(owningTopology) {
+		return nil
+	}
+
+	var kinds []string
+
+	for _, linkConnectivity := range owningTopology.Spec.LinkConnectivity {
+		if linkConnectivity.Connectivity == topologyConnectivityKind(owningTopology) ||
+			!slices.Contains(linkConnectivityKinds, linkConnectivity.Connectivity) {
+			continue
+		}
+
+		kinds = append(kinds, linkConnectivity.Connectivity)
+	}
+
+	slices.Sort(kinds)
+
+	return slices.Compact(kinds)
+}
+
+// ApplyLinkConnectivity sets the connectivity flavor of each of the given tunnels based on the link
+// connectivity overrides of the owning topology -- a tunnel gets the flavor of the override listing
+// either its local or its remote endpoint. If both endpoints of a link are listed the endpoint that
+// sorts first wins, so both sides of a link always end up with the same flavor. Tunnels using the
+// flavor of the topology have their flavor cleared. It returns a sorted list of the override
+// endpoints that did not match any tunnel so the caller can let the user know.
+func ApplyLinkConnectivity(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	tunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+) []string {
+	endpointKinds := map[string]string{}
+
+	if linkConnectivityEnabled(owningTopology) {
+		for _, linkConnectivity := range owningTopology.Spec.LinkConnectivity {
+			for _, endpoint := range linkConnectivity.Endpoints {
+				endpointKinds[endpoint] = linkConnectivity.Connectivity
+			}
+		}
+	}
+
+	return applyEndpointSettings(
+		tunnels,
+		endpointKinds,
+		true,
+		func(tunnel *clabernetesapisv1alpha1.PointToPointTunnel, kind *string) {
+			tunnel.Connectivity = ""
+
+			if kind != nil && *kind != topologyConnectivityKind(owningTopology) {
+				tunnel.Connectivity = *kind
+			}
+		},
+	)
+}
@@ -0,0 +1,150 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestApplyLinkConnectivity(t *testing.T) {
+	cases := []struct {
+		name                 string
+		connectivity         string
+		linkConnectivity     []clabernetesapisv1alpha1.TopologyLinkConnectivity
+		expectedConnectivity map[string]string
+		expectedKinds        []string
+		expectedUnmatched    []string
+	}{
+		{
+			name:             "no-link-connectivity",
+			linkConnectivity: nil,
+			expectedConnectivity: map[string]string{
+				"srl1": "",
+				"srl2": "",
+			},
+		},
+		{
+			name: "single-endpoint-moves-link",
+			linkConnectivity: []clabernetesapisv1alpha1.TopologyLinkConnectivity{
+				{
+					Endpoints:    []string{"srl2:e1-1"},
+					Connectivity: clabernetesconstants.ConnectivityGRE,
+				},
+			},
+			expectedConnectivity: map[string]string{
+				"srl1": clabernetesconstants.ConnectivityGRE,
+				"srl2": clabernetesconstants.ConnectivityGRE,
+			},
+			expectedKinds: []string{clabernetesconstants.ConnectivityGRE},
+		},
+		{
+			name: "conflicting-endpoints-first-endpoint-wins",
+			linkConnectivity: []clabernetesapisv1alpha1.TopologyLinkConnectivity{
+				{
+					Endpoints:    []string{"srl2:e1-1"},
+					Connectivity: clabernetesconstants.ConnectivityGRE,
+				},
+				{
+					Endpoints:    []string{"srl1:e1-1", "srl3:e1-1"},
+					Connectivity: clabernetesconstants.ConnectivityGeneve,
+				},
+			},
+			expectedConnectivity: map[string]string{
+				"srl1": clabernetesconstants.ConnectivityGeneve,
+				"srl2": clabernetesconstants.ConnectivityGeneve,
+			},
+			expectedKinds: []string{
+				clabernetesconstants.ConnectivityGeneve,
+				clabernetesconstants.ConnectivityGRE,
+			},
+			expectedUnmatched: []string{"srl3:e1-1"},
+		},
+		{
+			name:         "topology-flavor-is-cleared",
+			connectivity: clabernetesconstants.ConnectivitySlurpeeth,
+			linkConnectivity: []clabernetesapisv1alpha1.TopologyLinkConnectivity{
+				{
+					Endpoints:    []string{"srl1:e1-1"},
+					Connectivity: clabernetesconstants.ConnectivitySlurpeeth,
+				},
+			},
+			expectedConnectivity: map[string]string{
+				"srl1": "",
+				"srl2": "",
+			},
+		},
+		{
+			name:         "unsupported-connectivity",
+			connectivity: clabernetesconstants.ConnectivityWireGuard,
+			linkConnectivity: []clabernetesapisv1alpha1.TopologyLinkConnectivity{
+				{
+					Endpoints:    []string{"srl1:e1-1"},
+					Connectivity: clabernetesconstants.ConnectivityGRE,
+				},
+			},
+			expectedConnectivity: map[string]string{
+				"srl1": "",
+				"srl2": "",
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						Connectivity:     testCase.connectivity,
+						LinkConnectivity: testCase.linkConnectivity,
+					},
+				}
+
+				tunnels := linkTestTunnels()
+
+				// stale flavors from a previous reconcile must not stick around
+				tunnels["srl1"][0].Connectivity = clabernetesconstants.ConnectivitySlurpeeth
+
+				actualUnmatched := clabernetescontrollerstopology.ApplyLinkConnectivity(
+					owningTopology,
+					tunnels,
+				)
+
+				if !reflect.DeepEqual(actualUnmatched, testCase.expectedUnmatched) {
+					clabernetestesthelper.FailOutput(
+						t,
+						actualUnmatched,
+						testCase.expectedUnmatched,
+					)
+				}
+
+				actualConnectivity := map[string]string{}
+
+				for nodeName, nodeTunnels := range tunnels {
+					actualConnectivity[nodeName] = nodeTunnels[0].Connectivity
+				}
+
+				if !reflect.DeepEqual(actualConnectivity, testCase.expectedConnectivity) {
+					clabernetestesthelper.FailOutput(
+						t,
+						actualConnectivity,
+						testCase.expectedConnectivity,
+					)
+				}
+
+				actualKinds := clabernetescontrollerstopology.ResolveLinkConnectivityKinds(
+					owningTopology,
+				)
+
+				if !reflect.DeepEqual(actualKinds, testCase.expectedKinds) {
+					clabernetestesthelper.FailOutput(t, actualKinds, testCase.expectedKinds)
+				}
+			})
+	}
+}
@@ -0,0 +1,30 @@
+package topology
+
+import (
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+)
+
+// ApplyDisabledLinks sets the admin down flag of each of the given tunnels based on the disabled
+// links of the owning topology -- a tunnel is admin down if either its local or its remote endpoint
+// is listed, so both sides of a link always go down (and come back up) together. It returns a
+// sorted list of the disabled endpoints that did not match any tunnel so the caller can let the
+// user know.
+func ApplyDisabledLinks(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	tunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+) []string {
+	disabledEndpoints := map[string]bool{}
+
+	for _, endpoint := range owningTopology.Spec.DisabledLinks {
+		disabledEndpoints[endpoint] = true
+	}
+
+	return applyEndpointSettings(
+		tunnels,
+		disabledEndpoints,
+		true,
+		func(tunnel *clabernetesapisv1alpha1.PointToPointTunnel, disabled *bool) {
+			tunnel.AdminDown = disabled != nil
+		},
+	)
+}
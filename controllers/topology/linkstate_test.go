@@ -0,0 +1,85 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestApplyDisabledLinks(t *testing.T) {
+	cases := []struct {
+		name              string
+		disabledLinks     []string
+		expectedAdminDown map[string]bool
+		expectedUnmatched []string
+	}{
+		{
+			name:          "no-disabled-links-clears-existing",
+			disabledLinks: nil,
+			expectedAdminDown: map[string]bool{
+				"srl1": false,
+				"srl2": false,
+			},
+			expectedUnmatched: nil,
+		},
+		{
+			name:          "single-endpoint-disables-both-sides",
+			disabledLinks: []string{"srl2:e1-1"},
+			expectedAdminDown: map[string]bool{
+				"srl1": true,
+				"srl2": true,
+			},
+			expectedUnmatched: nil,
+		},
+		{
+			name:          "both-endpoints-and-unmatched",
+			disabledLinks: []string{"srl1:e1-1", "srl2:e1-1", "srl3:e1-1", "srl1:e1-2"},
+			expectedAdminDown: map[string]bool{
+				"srl1": true,
+				"srl2": true,
+			},
+			expectedUnmatched: []string{"srl1:e1-2", "srl3:e1-1"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						DisabledLinks: testCase.disabledLinks,
+					},
+				}
+
+				tunnels := linkTestTunnels()
+
+				// a link that is no longer disabled must come back up
+				tunnels["srl1"][0].AdminDown = true
+
+				actualUnmatched := clabernetescontrollerstopology.ApplyDisabledLinks(
+					owningTopology,
+					tunnels,
+				)
+				if !reflect.DeepEqual(actualUnmatched, testCase.expectedUnmatched) {
+					clabernetestesthelper.FailOutput(
+						t,
+						actualUnmatched,
+						testCase.expectedUnmatched,
+					)
+				}
+
+				for nodeName, expectedAdminDown := range testCase.expectedAdminDown {
+					actualAdminDown := tunnels[nodeName][0].AdminDown
+					if actualAdminDown != expectedAdminDown {
+						clabernetestesthelper.FailOutput(t, actualAdminDown, expectedAdminDown)
+					}
+				}
+			})
+	}
+}
@@ -0,0 +1,385 @@
+package topology
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"strings"
+	"text/template"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	mgmtDNSHostsKey = "hosts"
+
+	mgmtDNSRecordTypeA    = "A"
+	mgmtDNSRecordTypeAAAA = "AAAA"
+)
+
+// DNSEndpointGVK is the group/version/kind of ExternalDNS dns endpoints.
+var DNSEndpointGVK = schema.GroupVersionKind{ //nolint:gochecknoglobals
+	Group:   "externaldns.k8s.io",
+	Version: "v1alpha1",
+	Kind:    "DNSEndpoint",
+}
+
+// dnsEndpointSpec is the subset of the ExternalDNS dns endpoint spec clabernetes renders.
+type dnsEndpointSpec struct {
+	Endpoints []dnsEndpoint `json:"endpoints"`
+}
+
+type dnsEndpoint struct {
+	DNSName    string   `json:"dnsName"`
+	RecordType string   `json:"recordType"`
+	RecordTTL  int64    `json:"recordTTL,omitempty"`
+	Targets    []string `json:"targets"`
+}
+
+// MgmtDNSRecord is the dns record of the management interface of a node.
+type MgmtDNSRecord struct {
+	// NodeName is the (containerlab) name of the node.
+	NodeName string
+	// DNSName is the fully qualified name of the record, as rendered from the domain template.
+	DNSName string
+	// Addresses are the addresses (without prefix length) of the management interface of the
+	// node.
+	Addresses []string
+}
+
+// mgmtDNSName returns the name of the mgmt dns configmap of the given topology.
+func mgmtDNSName(owningTopologyName string) string {
+	return fmt.Sprintf("%s-mgmt-dns", owningTopologyName)
+}
+
+// mgmtNetworkStatusName is the name multus reports the dedicated management interface of vrnetlab
+// based nodes by in the network status annotation of the launcher pods.
+func mgmtNetworkStatusName() string {
+	return fmt.Sprintf("%s/%s", vrnetlabMgmtNetworkNamespace, vrnetlabMgmtNetworkName)
+}
+
+// ResolveAllocatedMgmtAddresses returns the addresses multus allocated on the dedicated management
+// interface of the launcher of the given pod, as reported in its network status annotation.
+func ResolveAllocatedMgmtAddresses(pod *k8scorev1.Pod) []string {
+	networkStatusJSON := pod.Annotations[clabernetesconstants.MultusNetworkStatusAnnotation]
+	if networkStatusJSON == "" {
+		return nil
+	}
+
+	var networkStatuses []struct {
+		Name string   `json:"name"`
+		IPs  []string `json:"ips"`
+	}
+
+	err := json.Unmarshal([]byte(networkStatusJSON), &networkStatuses)
+	if err != nil {
+		return nil
+	}
+
+	for _, networkStatus := range networkStatuses {
+		if networkStatus.Name == mgmtNetworkStatusName() {
+			return networkStatus.IPs
+		}
+	}
+
+	return nil
+}
+
+// renderMgmtDNSName renders the name of the mgmt dns record of the given node from the given
+// template.
+func renderMgmtDNSName(
+	domainTemplate *template.Template,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	nodeName string,
+) (string, error) {
+	var rendered bytes.Buffer
+
+	err := domainTemplate.Execute(
+		&rendered,
+		map[string]string{
+			"NodeName":     nodeName,
+			"TopologyName": owningTopology.GetName(),
+			"Namespace":    owningTopology.GetNamespace(),
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(rendered.String()), ".")), nil
+}
+
+// ResolveMgmtDNSRecords returns the (sorted by name) mgmt dns records of the nodes of the given
+// topology that get the dedicated management interface. The addresses of a node are the ones
+// multus reported as allocated (see ResolveAllocatedMgmtAddresses) if there are any, otherwise the
+// containerlab mgmt addresses requested for the node (see ResolveMgmtAddresses) -- nodes without
+// either get no record (yet). An error is returned if the domain template is invalid.
+func ResolveMgmtDNSRecords(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	allocatedAddresses map[string][]string,
+	mgmtDNS clabernetesapisv1alpha1.ConfigMgmtDNS,
+) ([]MgmtDNSRecord, error) {
+	domainTemplate, err := template.New("mgmtDNS").
+		Option("missingkey=error").
+		Parse(mgmtDNS.DomainTemplate)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: invalid mgmt dns domain template %q, error: %w",
+			claberneteserrors.ErrReconcile,
+			mgmtDNS.DomainTemplate,
+			err,
+		)
+	}
+
+	var records []MgmtDNSRecord
+
+	for nodeName, nodeConfig := range clabernetesConfigs {
+		if !usesMgmtAttachment(owningTopology, nodeConfig, nodeName) {
+			continue
+		}
+
+		var addresses []string
+
+		for _, address := range allocatedAddresses[nodeName] {
+			if net.ParseIP(address) != nil {
+				addresses = append(addresses, address)
+			}
+		}
+
+		if len(addresses) == 0 {
+			for _, address := range ResolveMgmtAddresses(owningTopology, nodeConfig, nodeName) {
+				ip, _, _ := net.ParseCIDR(address)
+
+				addresses = append(addresses, ip.String())
+			}
+		}
+
+		if len(addresses) == 0 {
+			continue
+		}
+
+		dnsName, err := renderMgmtDNSName(domainTemplate, owningTopology, nodeName)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: failed rendering mgmt dns domain template %q for node %q, error: %w",
+				claberneteserrors.ErrReconcile,
+				mgmtDNS.DomainTemplate,
+				nodeName,
+				err,
+			)
+		}
+
+		slices.Sort(addresses)
+
+		records = append(records, MgmtDNSRecord{
+			NodeName:  nodeName,
+			DNSName:   dnsName,
+			Addresses: slices.Compact(addresses),
+		})
+	}
+
+	slices.SortFunc(records, func(a, b MgmtDNSRecord) int {
+		return strings.Compare(a.DNSName, b.DNSName)
+	})
+
+	return records, nil
+}
+
+// MgmtDNSReconciler is a subcomponent of the "TopologyReconciler" but is exposed for testing
+// purposes. This is the component responsible for rendering/validating the dns records of the
+// management interfaces of the vrnetlab based nodes of a topology -- an ExternalDNS DNSEndpoint or
+// a CoreDNS hosts ConfigMap, depending on the provider selected in the global config.
+type MgmtDNSReconciler struct {
+	log                 claberneteslogging.Instance
+	configManagerGetter clabernetesconfig.ManagerGetterFunc
+}
+
+// NewMgmtDNSReconciler returns an instance of MgmtDNSReconciler.
+func NewMgmtDNSReconciler(
+	log claberneteslogging.Instance,
+	configManagerGetter clabernetesconfig.ManagerGetterFunc,
+) *MgmtDNSReconciler {
+	return &MgmtDNSReconciler{
+		log:                 log,
+		configManagerGetter: configManagerGetter,
+	}
+}
+
+func (r *MgmtDNSReconciler) renderMetadata(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	name string,
+) metav1.ObjectMeta {
+	owningTopologyName := owningTopology.GetName()
+
+	annotations, globalLabels := r.configManagerGetter().GetAllMetadata()
+
+	labels := map[string]string{
+		clabernetesconstants.LabelApp:           clabernetesconstants.Clabernetes,
+		clabernetesconstants.LabelName:          name,
+		clabernetesconstants.LabelTopologyOwner: owningTopologyName,
+		clabernetesconstants.LabelTopologyKind:  GetTopologyKind(owningTopology),
+		clabernetesconstants.LabelMgmtDNS:       clabernetesconstants.True,
+	}
+
+	for k, v := range globalLabels {
+		labels[k] = v
+	}
+
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   owningTopology.GetNamespace(),
+		Annotations: annotations,
+		Labels:      labels,
+	}
+}
+
+// Render returns the rendered CoreDNS hosts configmap holding the given mgmt dns records of the
+// given topology -- one "<address> <name>" line per address, ready for the CoreDNS hosts plugin.
+func (r *MgmtDNSReconciler) Render(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	records []MgmtDNSRecord,
+) *k8scorev1.ConfigMap {
+	var hosts strings.Builder
+
+	for _, record := range records {
+		for _, address := range record.Addresses {
+			_, _ = fmt.Fprintf(&hosts, "%s %s\n", address, record.DNSName)
+		}
+	}
+
+	return &k8scorev1.ConfigMap{
+		ObjectMeta: r.renderMetadata(owningTopology, mgmtDNSName(owningTopology.GetName())),
+		Data: map[string]string{
+			mgmtDNSHostsKey: hosts.String(),
+		},
+	}
+}
+
+// RenderDNSEndpoint returns the rendered ExternalDNS dns endpoint holding the given mgmt dns
+// records of the given topology -- an "A" and/or "AAAA" endpoint per record.
+func (r *MgmtDNSReconciler) RenderDNSEndpoint(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	records []MgmtDNSRecord,
+) *unstructured.Unstructured {
+	ttl := r.configManagerGetter().GetMgmtDNS().TTL
+
+	spec := dnsEndpointSpec{
+		Endpoints: []dnsEndpoint{},
+	}
+
+	for _, record := range records {
+		targets := map[string][]string{}
+
+		for _, address := range record.Addresses {
+			recordType := mgmtDNSRecordTypeA
+			if strings.Contains(address, ":") {
+				recordType = mgmtDNSRecordTypeAAAA
+			}
+
+			targets[recordType] = append(targets[recordType], address)
+		}
+
+		for _, recordType := range []string{mgmtDNSRecordTypeA, mgmtDNSRecordTypeAAAA} {
+			if len(targets[recordType]) == 0 {
+				continue
+			}
+
+			spec.Endpoints = append(spec.Endpoints, dnsEndpoint{
+				DNSName:    record.DNSName,
+				RecordType: recordType,
+				RecordTTL:  ttl,
+				Targets:    targets[recordType],
+			})
+		}
+	}
+
+	metadata := r.renderMetadata(owningTopology, owningTopology.GetName())
+
+	endpoint := &unstructured.Unstructured{}
+	endpoint.SetGroupVersionKind(DNSEndpointGVK)
+	endpoint.SetName(metadata.Name)
+	endpoint.SetNamespace(metadata.Namespace)
+	endpoint.SetAnnotations(metadata.Annotations)
+	endpoint.SetLabels(metadata.Labels)
+
+	// round trip the spec through json so it holds the same (plain json) types as the spec of an
+	// existing endpoint read from the cluster -- otherwise ConformsDNSEndpoint could never be happy
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		r.log.Criticalf("failed marshaling dns endpoint spec, error: %s", err)
+
+		return endpoint
+	}
+
+	var specMap map[string]any
+
+	err = json.Unmarshal(specBytes, &specMap)
+	if err != nil {
+		r.log.Criticalf("failed unmarshaling dns endpoint spec, error: %s", err)
+
+		return endpoint
+	}
+
+	endpoint.Object["spec"] = specMap
+
+	return endpoint
+}
+
+// Conforms checks if the existing mgmt dns configmap conforms with the rendered mgmt dns
+// configmap.
+func (r *MgmtDNSReconciler) Conforms(
+	existingConfigMap,
+	renderedConfigMap *k8scorev1.ConfigMap,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !reflect.DeepEqual(existingConfigMap.Data, renderedConfigMap.Data) {
+		return false
+	}
+
+	return clockSyncMetadataConforms(
+		existingConfigMap.ObjectMeta,
+		renderedConfigMap.ObjectMeta,
+		expectedOwnerUID,
+	)
+}
+
+// ConformsDNSEndpoint checks if the existing dns endpoint conforms with the rendered dns endpoint.
+func (r *MgmtDNSReconciler) ConformsDNSEndpoint(
+	existingDNSEndpoint,
+	renderedDNSEndpoint *unstructured.Unstructured,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !reflect.DeepEqual(
+		existingDNSEndpoint.Object["spec"],
+		renderedDNSEndpoint.Object["spec"],
+	) {
+		return false
+	}
+
+	return clockSyncMetadataConforms(
+		metav1.ObjectMeta{
+			Annotations:     existingDNSEndpoint.GetAnnotations(),
+			Labels:          existingDNSEndpoint.GetLabels(),
+			OwnerReferences: existingDNSEndpoint.GetOwnerReferences(),
+		},
+		metav1.ObjectMeta{
+			Annotations: renderedDNSEndpoint.GetAnnotations(),
+			Labels:      renderedDNSEndpoint.GetLabels(),
+		},
+		expectedOwnerUID,
+	)
+}
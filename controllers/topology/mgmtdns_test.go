@@ -0,0 +1,203 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveMgmtDNSRecords(t *testing.T) {
+	cases := []struct {
+		name               string
+		connectivity       string
+		allocatedAddresses map[string][]string
+		domainTemplate     string
+		expected           []clabernetescontrollerstopology.MgmtDNSRecord
+		expectErr          bool
+	}{
+		{
+			name:         "no-records-without-multus",
+			connectivity: clabernetesconstants.ConnectivityVXLAN,
+			expected:     nil,
+		},
+		{
+			name:         "requested-address-default-template",
+			connectivity: clabernetesconstants.ConnectivityMultus,
+			expected: []clabernetescontrollerstopology.MgmtDNSRecord{
+				{
+					NodeName:  "iol1",
+					DNSName:   "iol1.lab3.nowhere.clab",
+					Addresses: []string{"192.168.121.101"},
+				},
+			},
+		},
+		{
+			name:         "allocated-addresses-win",
+			connectivity: clabernetesconstants.ConnectivityMultus,
+			allocatedAddresses: map[string][]string{
+				"iol1": {"2001:db8::65", "192.168.121.201", "192.168.121.201"},
+				"srl1": {"192.168.121.202"},
+			},
+			domainTemplate: "{{ .NodeName }}.{{ .TopologyName }}.Example.Internal.",
+			expected: []clabernetescontrollerstopology.MgmtDNSRecord{
+				{
+					NodeName:  "iol1",
+					DNSName:   "iol1.lab3.example.internal",
+					Addresses: []string{"192.168.121.201", "2001:db8::65"},
+				},
+			},
+		},
+		{
+			name:           "invalid-template",
+			connectivity:   clabernetesconstants.ConnectivityMultus,
+			domainTemplate: "{{ .NodeName }}.{{ .Lab }}",
+			expectErr:      true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "lab3",
+						Namespace: "nowhere",
+					},
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						Connectivity: testCase.connectivity,
+					},
+				}
+
+				actual, err := clabernetescontrollerstopology.ResolveMgmtDNSRecords(
+					owningTopology,
+					mgmtNetworkTestConfigs(
+						&clabernetesutilcontainerlab.MgmtNet{IPv4Subnet: "192.168.121.0/24"},
+					),
+					testCase.allocatedAddresses,
+					clabernetesconfig.ResolveMgmtDNS(
+						clabernetesapisv1alpha1.ConfigMgmtDNS{
+							DomainTemplate: testCase.domainTemplate,
+						},
+					),
+				)
+				if (err != nil) != testCase.expectErr {
+					t.Fatalf("expected error %t, but got error: %v", testCase.expectErr, err)
+				}
+
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestResolveAllocatedMgmtAddresses(t *testing.T) {
+	pod := &k8scorev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				clabernetesconstants.MultusNetworkStatusAnnotation: `[
+  {"name": "cilium", "interface": "eth0", "ips": ["10.0.0.5"]},
+  {"name": "kube-system/vrnetlab-mgmt", "interface": "net1", "ips": ["192.168.121.201"]}
+]`,
+			},
+		},
+	}
+
+	actual := clabernetescontrollerstopology.ResolveAllocatedMgmtAddresses(pod)
+
+	expected := []string{"192.168.121.201"}
+
+	if !reflect.DeepEqual(actual, expected) {
+		clabernetestesthelper.FailOutput(t, actual, expected)
+	}
+}
+
+// TestRenderMgmtDNS ensures the rendered mgmt dns records conform with themselves once they have
+// been round tripped (as they would be when read back from the cluster), and no longer do once the
+// records change.
+func TestRenderMgmtDNS(t *testing.T) {
+	reconciler := clabernetescontrollerstopology.NewMgmtDNSReconciler(
+		&claberneteslogging.FakeInstance{},
+		clabernetesconfig.GetFakeManager,
+	)
+
+	owningTopology := &clabernetesapisv1alpha1.Topology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lab3",
+			Namespace: "nowhere",
+			UID:       "abc",
+		},
+	}
+
+	records := []clabernetescontrollerstopology.MgmtDNSRecord{
+		{
+			NodeName:  "iol1",
+			DNSName:   "iol1.lab3.example.internal",
+			Addresses: []string{"192.168.121.201", "2001:db8::65"},
+		},
+	}
+
+	existingConfigMap := reconciler.Render(owningTopology, records)
+	existingConfigMap.OwnerReferences = []metav1.OwnerReference{{UID: "abc"}}
+
+	expectedHosts := "192.168.121.201 iol1.lab3.example.internal\n" +
+		"2001:db8::65 iol1.lab3.example.internal\n"
+
+	if existingConfigMap.Data["hosts"] != expectedHosts {
+		clabernetestesthelper.FailOutput(t, existingConfigMap.Data["hosts"], expectedHosts)
+	}
+
+	existingDNSEndpoint := reconciler.RenderDNSEndpoint(owningTopology, records)
+	existingDNSEndpoint.SetOwnerReferences([]metav1.OwnerReference{{UID: "abc"}})
+
+	endpoints, _ := existingDNSEndpoint.Object["spec"].(map[string]any)["endpoints"].([]any)
+	if len(endpoints) != 2 {
+		t.Fatalf("expected an A and an AAAA endpoint, got: %v", endpoints)
+	}
+
+	if !reconciler.Conforms(
+		existingConfigMap,
+		reconciler.Render(owningTopology, records),
+		"abc",
+	) {
+		t.Fatalf("expected rendered mgmt dns configmap to conform with itself")
+	}
+
+	if !reconciler.ConformsDNSEndpoint(
+		existingDNSEndpoint,
+		reconciler.RenderDNSEndpoint(owningTopology, records),
+		"abc",
+	) {
+		t.Fatalf("expected rendered dns endpoint to conform with itself")
+	}
+
+	records[0].Addresses = []string{"192.168.121.202"}
+
+	if reconciler.Conforms(
+		existingConfigMap,
+		reconciler.Render(owningTopology, records),
+		"abc",
+	) {
+		t.Fatalf("expected mgmt dns configmap with new addresses to not conform")
+	}
+
+	if reconciler.ConformsDNSEndpoint(
+		existingDNSEndpoint,
+		reconciler.RenderDNSEndpoint(owningTopology, records),
+		"abc",
+	) {
+		t.Fatalf("expected dns endpoint with new addresses to not conform")
+	}
+}
@@ -0,0 +1,319 @@
+package topology
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	conditionMgmtNetworkIgnored = "MgmtNetworkIgnored"
+	reasonMgmtNetworkIgnored    = "MgmtNetworkUnsupported"
+
+	// vrnetlabMgmtNetworkName/Namespace is the network attachment definition of the dedicated
+	// management interface of vrnetlab based nodes.
+	vrnetlabMgmtNetworkName      = "vrnetlab-mgmt"
+	vrnetlabMgmtNetworkNamespace = "kube-system"
+)
+
+// usesMgmtAttachment returns true if the given node gets the dedicated (multus) management
+// interface -- only vrnetlab based nodes in topologies using multus connectivity do.
+func usesMgmtAttachment(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	nodeConfig *clabernetesutilcontainerlab.Config,
+	nodeName string,
+) bool {
+	if owningTopology.Spec.Connectivity != clabernetesconstants.ConnectivityMultus {
+		return false
+	}
+
+	node, ok := nodeConfig.Topology.Nodes[nodeName]
+	if !ok {
+		return false
+	}
+
+	switch strings.TrimSpace(node.Kind) {
+	case "cisco_iol", "vios", "viosl2", "vr-n9kv", "asav", "vmx", "sros", "csr":
+		return true
+	default:
+		return false
+	}
+}
+
+// mgmtAddressCIDR returns the given (containerlab) mgmt address in cidr notation -- addresses
+// without a prefix length get the one of the given mgmt subnet. It returns an empty string if the
+// address is invalid or there is no prefix length to be had.
+func mgmtAddressCIDR(address, subnet string) string {
+	if address == "" {
+		return ""
+	}
+
+	if strings.Contains(address, "/") {
+		_, _, err := net.ParseCIDR(address)
+		if err != nil {
+			return ""
+		}
+
+		return address
+	}
+
+	if net.ParseIP(address) == nil {
+		return ""
+	}
+
+	_, subnetNet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return ""
+	}
+
+	prefixLength, _ := subnetNet.Mask.Size()
+
+	return fmt.Sprintf("%s/%d", address, prefixLength)
+}
+
+// ResolveMgmtAddresses returns the addresses (in cidr notation) to request on the management
+// interface of the given node -- the "mgmt-ipv4"/"mgmt-ipv6" of the node (as netlab generates
+// them for example) with the prefix length of the containerlab mgmt subnet. This only returns
+// addresses for nodes that get the dedicated management interface, the pod network interface is
+// addressed by the cluster cni and can't be told what to do.
+func ResolveMgmtAddresses(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	nodeConfig *clabernetesutilcontainerlab.Config,
+	nodeName string,
+) []string {
+	if !usesMgmtAttachment(owningTopology, nodeConfig, nodeName) {
+		return nil
+	}
+
+	mgmt := nodeConfig.Mgmt
+	if mgmt == nil {
+		mgmt = &clabernetesutilcontainerlab.MgmtNet{}
+	}
+
+	node := nodeConfig.Topology.Nodes[nodeName]
+
+	var addresses []string
+
+	for _, address := range []string{
+		mgmtAddressCIDR(node.MgmtIPv4, mgmt.IPv4Subnet),
+		mgmtAddressCIDR(node.MgmtIPv6, mgmt.IPv6Subnet),
+	} {
+		if address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
+
+// ResolveExternalRoutes returns the static routes the launcher of the given node adds for the
+// external prefixes of the topology (see Network.ExternalRoutes) -- each prefix is routed via the
+// containerlab mgmt gateway of its family. Only nodes that get the dedicated management interface
+// get these, the second return value holds the prefixes that were skipped as they are invalid or
+// there is no gateway of their family.
+func ResolveExternalRoutes(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	nodeConfig *clabernetesutilcontainerlab.Config,
+	nodeName string,
+) (routes []clabernetesapisv1alpha1.StaticRoute, skipped []string) {
+	if owningTopology.Spec.Network == nil || len(owningTopology.Spec.Network.ExternalRoutes) == 0 ||
+		!usesMgmtAttachment(owningTopology, nodeConfig, nodeName) {
+		return nil, nil
+	}
+
+	mgmt := nodeConfig.Mgmt
+	if mgmt == nil {
+		mgmt = &clabernetesutilcontainerlab.MgmtNet{}
+	}
+
+	for _, externalRoute := range owningTopology.Spec.Network.ExternalRoutes {
+		destination, err := netip.ParsePrefix(strings.TrimSpace(externalRoute))
+		if err != nil {
+			skipped = append(skipped, externalRoute)
+
+			continue
+		}
+
+		gateway := mgmt.IPv4Gw
+		if destination.Addr().Is6() {
+			gateway = mgmt.IPv6Gw
+		}
+
+		if gateway == "" {
+			skipped = append(skipped, externalRoute)
+
+			continue
+		}
+
+		routes = append(routes, clabernetesapisv1alpha1.StaticRoute{
+			Destination: destination.Masked().String(),
+			Gateway:     gateway,
+		})
+	}
+
+	return routes, skipped
+}
+
+// IgnoredMgmtSettings returns a sorted list of the containerlab mgmt settings of the topology that
+// clabernetes can not honor. In docker mode containerlab sets up the mgmt network in each launcher
+// so everything is honored, in native mode the nodes live on the pod network, so the only things
+// that are honored are node addresses on the dedicated management interface (see
+// ResolveMgmtAddresses), the gateways the external routes use (see ResolveExternalRoutes) and node
+// addresses used as load balancer addresses of the expose services.
+func IgnoredMgmtSettings(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) []string {
+	if !ResolveNativeMode(owningTopology) {
+		return nil
+	}
+
+	var ignored []string
+
+	var mgmt *clabernetesutilcontainerlab.MgmtNet
+
+	var subnetsUsed bool
+
+	gatewaysUsed := map[string]bool{}
+
+	for nodeName, nodeConfig := range clabernetesConfigs {
+		if nodeConfig.Mgmt != nil {
+			// every sub-topology carries the mgmt settings of the original topology
+			mgmt = nodeConfig.Mgmt
+		}
+
+		node, ok := nodeConfig.Topology.Nodes[nodeName]
+		if !ok {
+			continue
+		}
+
+		externalRoutes, _ := ResolveExternalRoutes(owningTopology, nodeConfig, nodeName)
+
+		for _, externalRoute := range externalRoutes {
+			if strings.Contains(externalRoute.Destination, ":") {
+				gatewaysUsed["ipv6-gw"] = true
+			} else {
+				gatewaysUsed["ipv4-gw"] = true
+			}
+		}
+
+		honored := ResolveMgmtAddresses(owningTopology, nodeConfig, nodeName)
+
+		for setting, address := range map[string]string{
+			"mgmt-ipv4": node.MgmtIPv4,
+			"mgmt-ipv6": node.MgmtIPv6,
+		} {
+			if address == "" || mgmtAddressExposed(owningTopology, setting) {
+				continue
+			}
+
+			if slices.Contains(honored, mgmtAddressCIDR(address, mgmtSubnet(mgmt, setting))) {
+				subnetsUsed = true
+
+				continue
+			}
+
+			ignored = append(ignored, fmt.Sprintf("node %s %s", nodeName, setting))
+		}
+	}
+
+	if mgmt != nil {
+		for setting, value := range map[string]string{
+			"network":    mgmt.Network,
+			"ipv4-gw":    mgmt.IPv4Gw,
+			"ipv4-range": mgmt.IPv4Range,
+			"ipv6-gw":    mgmt.IPv6Gw,
+			"ipv6-range": mgmt.IPv6Range,
+		} {
+			if value != "" && !gatewaysUsed[setting] {
+				ignored = append(ignored, setting)
+			}
+		}
+
+		if !subnetsUsed {
+			if mgmt.IPv4Subnet != "" {
+				ignored = append(ignored, "ipv4-subnet")
+			}
+
+			if mgmt.IPv6Subnet != "" {
+				ignored = append(ignored, "ipv6-subnet")
+			}
+		}
+
+		if mgmt.MTU != 0 {
+			ignored = append(ignored, "mtu")
+		}
+
+		if mgmt.ExternalAccess != nil {
+			ignored = append(ignored, "external-access")
+		}
+	}
+
+	slices.Sort(ignored)
+
+	return ignored
+}
+
+// mgmtAddressExposed returns true if the given node mgmt address setting is used as the load
+// balancer address of the expose service of the node.
+func mgmtAddressExposed(owningTopology *clabernetesapisv1alpha1.Topology, setting string) bool {
+	if setting == "mgmt-ipv6" {
+		return owningTopology.Spec.Expose.UseNodeMgmtIpv6Address
+	}
+
+	return owningTopology.Spec.Expose.UseNodeMgmtIpv4Address
+}
+
+func mgmtSubnet(mgmt *clabernetesutilcontainerlab.MgmtNet, setting string) string {
+	if mgmt == nil {
+		return ""
+	}
+
+	if setting == "mgmt-ipv6" {
+		return mgmt.IPv6Subnet
+	}
+
+	return mgmt.IPv4Subnet
+}
+
+// reconcileMgmtNetworkCondition sets (or clears) the "MgmtNetworkIgnored" condition on the topology
+// so that mgmt settings that can not be honored are not silently dropped.
+func (r *Reconciler) reconcileMgmtNetworkCondition(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) {
+	ignored := IgnoredMgmtSettings(owningTopology, reconcileData.ResolvedConfigs)
+
+	if len(ignored) == 0 {
+		if apimachinerymeta.RemoveStatusCondition(
+			&owningTopology.Status.Conditions,
+			conditionMgmtNetworkIgnored,
+		) {
+			reconcileData.ShouldUpdateResource = true
+		}
+
+		return
+	}
+
+	r.Log.Warnf("containerlab mgmt setting(s) %q are not supported in native mode", ignored)
+
+	if apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+		Type:   conditionMgmtNetworkIgnored,
+		Status: "True",
+		Reason: reasonMgmtNetworkIgnored,
+		Message: fmt.Sprintf(
+			"containerlab mgmt setting(s) not supported in native mode, ignoring: %s",
+			strings.Join(ignored, ", "),
+		),
+	}) {
+		reconcileData.ShouldUpdateResource = true
+	}
+}
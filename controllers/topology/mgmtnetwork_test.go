@@ -0,0 +1,262 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+func mgmtNetworkTestConfigs(
+	mgmt *clabernetesutilcontainerlab.MgmtNet,
+) map[string]*clabernetesutilcontainerlab.Config {
+	return map[string]*clabernetesutilcontainerlab.Config{
+		"iol1": {
+			Name: "topo-iol1",
+			Mgmt: mgmt,
+			Topology: &clabernetesutilcontainerlab.Topology{
+				Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+				Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+					"iol1": {
+						Kind:     "cisco_iol",
+						MgmtIPv4: "192.168.121.101",
+					},
+				},
+			},
+		},
+		"srl1": {
+			Name: "topo-srl1",
+			Mgmt: mgmt,
+			Topology: &clabernetesutilcontainerlab.Topology{
+				Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+				Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+					"srl1": {
+						Kind:     "nokia_srlinux",
+						MgmtIPv4: "192.168.121.102",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestResolveMgmtAddresses(t *testing.T) {
+	cases := []struct {
+		name         string
+		connectivity string
+		mgmt         *clabernetesutilcontainerlab.MgmtNet
+		nodeName     string
+		expected     []string
+	}{
+		{
+			name:         "no-mgmt-attachment-without-multus",
+			connectivity: "vxlan",
+			mgmt:         &clabernetesutilcontainerlab.MgmtNet{IPv4Subnet: "192.168.121.0/24"},
+			nodeName:     "iol1",
+			expected:     nil,
+		},
+		{
+			name:         "no-mgmt-attachment-for-kind",
+			connectivity: "multus",
+			mgmt:         &clabernetesutilcontainerlab.MgmtNet{IPv4Subnet: "192.168.121.0/24"},
+			nodeName:     "srl1",
+			expected:     nil,
+		},
+		{
+			name:         "prefix-from-subnet",
+			connectivity: "multus",
+			mgmt:         &clabernetesutilcontainerlab.MgmtNet{IPv4Subnet: "192.168.121.0/24"},
+			nodeName:     "iol1",
+			expected:     []string{"192.168.121.101/24"},
+		},
+		{
+			name:         "no-subnet",
+			connectivity: "multus",
+			mgmt:         nil,
+			nodeName:     "iol1",
+			expected:     nil,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						Connectivity: testCase.connectivity,
+					},
+				}
+
+				configs := mgmtNetworkTestConfigs(testCase.mgmt)
+
+				actual := clabernetescontrollerstopology.ResolveMgmtAddresses(
+					owningTopology,
+					configs[testCase.nodeName],
+					testCase.nodeName,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestResolveExternalRoutes(t *testing.T) {
+	cases := []struct {
+		name            string
+		connectivity    string
+		mgmt            *clabernetesutilcontainerlab.MgmtNet
+		externalRoutes  []string
+		nodeName        string
+		expected        []clabernetesapisv1alpha1.StaticRoute
+		expectedSkipped []string
+	}{
+		{
+			name:           "no-mgmt-attachment-for-kind",
+			connectivity:   "multus",
+			mgmt:           &clabernetesutilcontainerlab.MgmtNet{IPv4Gw: "192.168.121.1"},
+			externalRoutes: []string{"10.8.0.0/16"},
+			nodeName:       "srl1",
+			expected:       nil,
+		},
+		{
+			name:           "via-mgmt-gateway",
+			connectivity:   "multus",
+			mgmt:           &clabernetesutilcontainerlab.MgmtNet{IPv4Gw: "192.168.121.1"},
+			externalRoutes: []string{"10.8.0.0/16", "172.16.1.7/24"},
+			nodeName:       "iol1",
+			expected: []clabernetesapisv1alpha1.StaticRoute{
+				{
+					Destination: "10.8.0.0/16",
+					Gateway:     "192.168.121.1",
+				},
+				{
+					Destination: "172.16.1.0/24",
+					Gateway:     "192.168.121.1",
+				},
+			},
+		},
+		{
+			name:            "no-gateway-of-family",
+			connectivity:    "multus",
+			mgmt:            &clabernetesutilcontainerlab.MgmtNet{IPv4Gw: "192.168.121.1"},
+			externalRoutes:  []string{"2001:db8::/32", "not-a-prefix"},
+			nodeName:        "iol1",
+			expected:        nil,
+			expectedSkipped: []string{"2001:db8::/32", "not-a-prefix"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						Connectivity: testCase.connectivity,
+						Network: &clabernetesapisv1alpha1.Network{
+							ExternalRoutes: testCase.externalRoutes,
+						},
+					},
+				}
+
+				configs := mgmtNetworkTestConfigs(testCase.mgmt)
+
+				actual, actualSkipped := clabernetescontrollerstopology.ResolveExternalRoutes(
+					owningTopology,
+					configs[testCase.nodeName],
+					testCase.nodeName,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+
+				if !reflect.DeepEqual(actualSkipped, testCase.expectedSkipped) {
+					clabernetestesthelper.FailOutput(t, actualSkipped, testCase.expectedSkipped)
+				}
+			})
+	}
+}
+
+func TestIgnoredMgmtSettings(t *testing.T) {
+	cases := []struct {
+		name         string
+		nativeMode   bool
+		connectivity string
+		mgmt         *clabernetesutilcontainerlab.MgmtNet
+		expected     []string
+	}{
+		{
+			name:         "docker-mode-honors-everything",
+			nativeMode:   false,
+			connectivity: "vxlan",
+			mgmt: &clabernetesutilcontainerlab.MgmtNet{
+				IPv4Subnet: "192.168.121.0/24",
+				MTU:        1400,
+			},
+			expected: nil,
+		},
+		{
+			name:         "native-mode-pod-network",
+			nativeMode:   true,
+			connectivity: "vxlan",
+			mgmt: &clabernetesutilcontainerlab.MgmtNet{
+				IPv4Subnet: "192.168.121.0/24",
+				IPv4Gw:     "192.168.121.1",
+				MTU:        1400,
+			},
+			expected: []string{
+				"ipv4-gw",
+				"ipv4-subnet",
+				"mtu",
+				"node iol1 mgmt-ipv4",
+				"node srl1 mgmt-ipv4",
+			},
+		},
+		{
+			name:         "native-mode-mgmt-attachment",
+			nativeMode:   true,
+			connectivity: "multus",
+			mgmt: &clabernetesutilcontainerlab.MgmtNet{
+				IPv4Subnet: "192.168.121.0/24",
+			},
+			expected: []string{
+				"node srl1 mgmt-ipv4",
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						Connectivity: testCase.connectivity,
+						Deployment: clabernetesapisv1alpha1.Deployment{
+							NativeMode: clabernetesutil.ToPointer(testCase.nativeMode),
+						},
+					},
+				}
+
+				actual := clabernetescontrollerstopology.IgnoredMgmtSettings(
+					owningTopology,
+					mgmtNetworkTestConfigs(testCase.mgmt),
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
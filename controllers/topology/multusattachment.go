@@ -0,0 +1,351 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	conditionMultusAttachmentFailed = "MultusAttachmentFailed"
+	reasonMultusAttachmentFailed    = "MultusAttachmentFailed"
+
+	timelineReasonMultusAttachmentRetried = "MultusAttachmentRetried"
+
+	eventReasonFailedCreatePodSandBox = "FailedCreatePodSandBox"
+
+	// multusFailureMessageMaxLen is how much of the (often very long, nested) cni error we put in
+	// the condition message.
+	multusFailureMessageMaxLen = 256
+)
+
+// multusMissingNADPattern matches the multus error for a pod referencing a NAD that does not
+// exist, capturing the NAD name and namespace.
+var multusMissingNADPattern = regexp.MustCompile(
+	`cannot find a network-attachment-definition \(([^)]+)\) in namespace \(([^)]+)\)`,
+)
+
+// multusDelegateFailurePattern matches the multus error for a delegate cni plugin failing to
+// attach the network of a NAD, capturing the NAD name.
+var multusDelegateFailurePattern = regexp.MustCompile(`error adding container to network "([^"]+)"`)
+
+// MultusAttachmentFailure is a multus (or delegate cni) failure attaching a network to a pod parsed
+// out of a pod sandbox creation failure event.
+type MultusAttachmentFailure struct {
+	// NetworkAttachmentDefinition is the name of the offending NAD, empty if the failure could not
+	// be pinned to one.
+	NetworkAttachmentDefinition string
+	// Namespace is the namespace of the offending NAD, empty if the failure did not say.
+	Namespace string
+	// Missing is true if the failure is due to the NAD not existing.
+	Missing bool
+	// Message is the (truncated) failure message.
+	Message string
+}
+
+// ParseMultusAttachmentFailure parses the message of a pod sandbox creation failure event and
+// returns the multus attachment failure it describes, or nil if it is not a multus failure at all.
+func ParseMultusAttachmentFailure(message string) *MultusAttachmentFailure {
+	failure := &MultusAttachmentFailure{
+		Message: message,
+	}
+
+	if len(failure.Message) > multusFailureMessageMaxLen {
+		failure.Message = failure.Message[:multusFailureMessageMaxLen] + "..."
+	}
+
+	match := multusMissingNADPattern.FindStringSubmatch(message)
+	if match != nil {
+		failure.Missing = true
+		failure.NetworkAttachmentDefinition = match[1]
+		failure.Namespace = match[2]
+
+		return failure
+	}
+
+	match = multusDelegateFailurePattern.FindStringSubmatch(message)
+	if match != nil {
+		failure.NetworkAttachmentDefinition = match[1]
+
+		return failure
+	}
+
+	if strings.Contains(strings.ToLower(message), "multus") {
+		return failure
+	}
+
+	return nil
+}
+
+// multusAttachmentLink returns the (endpoints of the) link of the given node config the given NAD
+// was rendered for, or an empty string if the NAD is not one of ours (e.g. the mgmt or underlay
+// attachment).
+func multusAttachmentLink(
+	nodeConfig *clabernetesutilcontainerlab.Config,
+	nadName string,
+	stableNames bool,
+) string {
+	if nodeConfig == nil || nodeConfig.Topology == nil {
+		return ""
+	}
+
+	// the nad name may be namespaced ("namespace/name"), ours never are
+	if _, name, ok := strings.Cut(nadName, "/"); ok {
+		nadName = name
+	}
+
+	for idx, link := range nodeConfig.Topology.Links {
+		if NetworkAttachmentDefinitionName(nodeConfig.Name, link, idx, stableNames) == nadName {
+			return strings.Join(link.Endpoints, " <-> ")
+		}
+	}
+
+	return ""
+}
+
+// describeMultusAttachmentFailure returns a human friendly description of the given failure.
+func describeMultusAttachmentFailure(failure *MultusAttachmentFailure, link string) string {
+	nad := failure.NetworkAttachmentDefinition
+	if nad == "" {
+		return "multus attachment failed: " + failure.Message
+	}
+
+	if failure.Namespace != "" && !strings.Contains(nad, "/") {
+		nad = failure.Namespace + "/" + nad
+	}
+
+	if link != "" {
+		nad = fmt.Sprintf("%s (link %s)", nad, link)
+	}
+
+	if failure.Missing {
+		return fmt.Sprintf(
+			"network attachment definition %s does not exist, the pod is retried once it does",
+			nad,
+		)
+	}
+
+	return fmt.Sprintf("attaching network %s failed: %s", nad, failure.Message)
+}
+
+// networkAttachmentDefinitionExists returns true if the NAD named in the given failure exists --
+// the NAD namespace defaults to the namespace of the pod that failed to attach it.
+func (r *Reconciler) networkAttachmentDefinitionExists(
+	ctx context.Context,
+	failure *MultusAttachmentFailure,
+	podNamespace string,
+) bool {
+	namespace, name, ok := strings.Cut(failure.NetworkAttachmentDefinition, "/")
+	if !ok {
+		namespace, name = podNamespace, failure.NetworkAttachmentDefinition
+
+		if failure.Namespace != "" {
+			namespace = failure.Namespace
+		}
+	}
+
+	nad := &unstructured.Unstructured{}
+	nad.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "k8s.cni.cncf.io",
+		Version: "v1",
+		Kind:    "NetworkAttachmentDefinition",
+	})
+
+	err := r.reader.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: namespace,
+			Name:      name,
+		},
+		nad,
+	)
+	if err != nil {
+		if !apimachineryerrors.IsNotFound(err) {
+			r.Log.Warnf(
+				"failed getting network attachment definition %s/%s, error: %s",
+				namespace,
+				name,
+				err,
+			)
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// lastPodSandboxFailure returns the most recent pod sandbox creation failure event of the given
+// pod, or nil if there is none.
+func (r *Reconciler) lastPodSandboxFailure(
+	ctx context.Context,
+	pod *k8scorev1.Pod,
+) *k8scorev1.Event {
+	events := &k8scorev1.EventList{}
+
+	// events are not cached (and we would not want them to be), so go straight to the api
+	err := r.reader.List(
+		ctx,
+		events,
+		ctrlruntimeclient.InNamespace(pod.Namespace),
+		ctrlruntimeclient.MatchingFields{
+			"involvedObject.kind": "Pod",
+			"involvedObject.name": pod.Name,
+			"involvedObject.uid":  string(pod.UID),
+			"reason":              eventReasonFailedCreatePodSandBox,
+		},
+	)
+	if err != nil {
+		r.Log.Warnf("failed listing events for pod %q, error: %s", pod.Name, err)
+
+		return nil
+	}
+
+	var last *k8scorev1.Event
+
+	for i := range events.Items {
+		if last == nil || eventTime(&events.Items[i]).After(eventTime(last)) {
+			last = &events.Items[i]
+		}
+	}
+
+	return last
+}
+
+// eventTime returns the time the given event was last seen at.
+func eventTime(event *k8scorev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// reconcileNodeMultusAttachment checks the pending launcher pods of the given node that attach
+// multus networks for sandbox creation failures caused by multus, recording the failures so they
+// are surfaced in the "MultusAttachmentFailed" condition. Pods that failed because a NAD did not
+// exist (yet) are deleted once it does, so they are recreated right away rather than waiting out
+// the kubelet's sandbox creation backoff.
+func (r *Reconciler) reconcileNodeMultusAttachment(
+	ctx context.Context,
+	reconcileData *ReconcileData,
+	nodeName string,
+	pods []k8scorev1.Pod,
+) {
+	stableNames := r.configManagerGetter().IsFeatureGateEnabled(
+		clabernetesconstants.FeatureGateStableNetworkAttachmentNames,
+	)
+
+	for i := range pods {
+		pod := &pods[i]
+
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != k8scorev1.PodPending {
+			continue
+		}
+
+		if _, ok := pod.Annotations[clabernetesconstants.MultusNetworksAnnotation]; !ok {
+			continue
+		}
+
+		event := r.lastPodSandboxFailure(ctx, pod)
+		if event == nil {
+			continue
+		}
+
+		failure := ParseMultusAttachmentFailure(event.Message)
+		if failure == nil {
+			continue
+		}
+
+		if failure.Missing && r.networkAttachmentDefinitionExists(ctx, failure, pod.Namespace) {
+			r.Log.Infof(
+				"network attachment definition %q for node %q now exists, retrying pod %q",
+				failure.NetworkAttachmentDefinition,
+				nodeName,
+				pod.Name,
+			)
+
+			err := r.Client.Delete(ctx, pod)
+			if err != nil {
+				r.Log.Warnf("failed deleting pod %q, error: %s", pod.Name, err)
+			} else {
+				recordControllerTimelineEvent(
+					reconcileData,
+					nodeName,
+					timelineReasonMultusAttachmentRetried,
+					fmt.Sprintf(
+						"pod %s recreated as network attachment definition %s now exists",
+						pod.Name,
+						failure.NetworkAttachmentDefinition,
+					),
+				)
+			}
+
+			continue
+		}
+
+		reconcileData.MultusAttachmentFailures[nodeName] = describeMultusAttachmentFailure(
+			failure,
+			multusAttachmentLink(
+				reconcileData.ResolvedConfigs[nodeName],
+				failure.NetworkAttachmentDefinition,
+				stableNames,
+			),
+		)
+	}
+}
+
+// reconcileMultusAttachmentCondition sets (or clears) the "MultusAttachmentFailed" condition on
+// the topology listing the nodes whose pods can not be created due to multus attachment failures.
+func (r *Reconciler) reconcileMultusAttachmentCondition(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) {
+	if len(reconcileData.MultusAttachmentFailures) == 0 {
+		if apimachinerymeta.RemoveStatusCondition(
+			&owningTopology.Status.Conditions,
+			conditionMultusAttachmentFailed,
+		) {
+			reconcileData.ShouldUpdateResource = true
+		}
+
+		return
+	}
+
+	failures := make([]string, 0, len(reconcileData.MultusAttachmentFailures))
+
+	for nodeName, failure := range reconcileData.MultusAttachmentFailures {
+		failures = append(failures, fmt.Sprintf("node %s: %s", nodeName, failure))
+	}
+
+	slices.Sort(failures)
+
+	r.Log.Warnf("multus attachment(s) failed: %q", failures)
+
+	if apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+		Type:    conditionMultusAttachmentFailed,
+		Status:  "True",
+		Reason:  reasonMultusAttachmentFailed,
+		Message: strings.Join(failures, "; "),
+	}) {
+		reconcileData.ShouldUpdateResource = true
+	}
+}
@@ -0,0 +1,71 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestParseMultusAttachmentFailure(t *testing.T) {
+	cases := []struct {
+		name     string
+		message  string
+		expected *clabernetescontrollerstopology.MultusAttachmentFailure
+	}{
+		{
+			name: "missing-nad",
+			message: "Failed to create pod sandbox: rpc error: code = Unknown desc = failed to " +
+				"setup network for sandbox: plugin type=\"multus\" failed (add): Multus: " +
+				"[lab/srl1-abc/1234]: error loading k8s delegates k8s args: " +
+				"TryLoadPodDelegates: error in getting k8s network for pod: " +
+				"GetNetworkDelegates: failed getting the delegate: getKubernetesDelegate: " +
+				"cannot find a network-attachment-definition (srl1-l0) in namespace (lab): " +
+				"network-attachment-definitions.k8s.cni.cncf.io \"srl1-l0\" not found",
+			expected: &clabernetescontrollerstopology.MultusAttachmentFailure{
+				NetworkAttachmentDefinition: "srl1-l0",
+				Namespace:                   "lab",
+				Missing:                     true,
+			},
+		},
+		{
+			name: "delegate-failure",
+			message: "plugin type=\"multus\" failed (add): [lab/srl1-abc/1234:srl1-l0]: " +
+				"error adding container to network \"srl1-l0\": failed to create bridge",
+			expected: &clabernetescontrollerstopology.MultusAttachmentFailure{
+				NetworkAttachmentDefinition: "srl1-l0",
+			},
+		},
+		{
+			name:     "multus-unspecific",
+			message:  "plugin type=\"multus\" failed (add): Multus: error getting pod",
+			expected: &clabernetescontrollerstopology.MultusAttachmentFailure{},
+		},
+		{
+			name:     "not-multus",
+			message:  "failed to setup network for sandbox: plugin type=\"calico\" failed (add)",
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ParseMultusAttachmentFailure(
+					testCase.message,
+				)
+				if actual != nil {
+					// the message is just passed through (truncated), not interesting here
+					actual.Message = ""
+				}
+
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
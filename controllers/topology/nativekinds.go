@@ -0,0 +1,462 @@
+package topology
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	k8scorev1 "k8s.io/api/core/v1"
+)
+
+// nativeKindApplyFunc prepares the nos container of a native mode node the way the containerlab
+// kind driver of the kind of the node would prepare the node in docker mode.
+type nativeKindApplyFunc func(n *nativeNode)
+
+// nativeKindDefaults holds what the controller needs to know about a kind besides how to prepare
+// the nos container of its nodes.
+type nativeKindDefaults struct {
+	// startupSeconds is the default startup probe time of the kind, for kinds that take (much)
+	// longer to boot than the default startup probe allows for -- this applies in docker mode too
+	startupSeconds int
+	// privileged is true for kinds whose nos containers need to run privileged even if the
+	// launcher does not, the unprivileged capability set is not enough for them
+	privileged bool
+	// capabilities are the capabilities the nos containers of the kind need on top of whatever the
+	// launcher gets when they do not run privileged
+	capabilities []k8scorev1.Capability
+	// licensePath is where the nos of the kind reads its license from, the license of the node
+	// (if it has one) is mounted there before the apply func of the kind runs
+	licensePath string
+	// interfaceName resolves the interface aliases of the kind to the linux interface names the
+	// nos expects (see ResolveNativeInterfaceName), nil if the kind uses the names as is
+	interfaceName func(interfaceName string) string
+}
+
+// nativeKindDriver is a registered native mode kind driver.
+type nativeKindDriver struct {
+	apply    nativeKindApplyFunc
+	defaults nativeKindDefaults
+}
+
+// nativeKindRegistry holds the native mode kind drivers by (lowercase) containerlab kind.
+type nativeKindRegistry struct {
+	drivers map[string]*nativeKindDriver
+}
+
+func newNativeKindRegistry() *nativeKindRegistry {
+	return &nativeKindRegistry{
+		drivers: map[string]*nativeKindDriver{},
+	}
+}
+
+// Register registers the given apply func and defaults as the native mode driver of the given kind
+// and any aliases of it (for example the "vr-" and vendor prefixed names of the same kind),
+// replacing whatever was registered for them before.
+func (r *nativeKindRegistry) Register(
+	kind string,
+	apply nativeKindApplyFunc,
+	defaults nativeKindDefaults,
+	aliases ...string,
+) {
+	driver := &nativeKindDriver{
+		apply:    apply,
+		defaults: defaults,
+	}
+
+	for _, registeredKind := range append([]string{kind}, aliases...) {
+		r.drivers[normalizeNativeKind(registeredKind)] = driver
+	}
+}
+
+// get returns the native mode driver of the given kind, if there is one.
+func (r *nativeKindRegistry) get(kind string) (*nativeKindDriver, bool) {
+	driver, ok := r.drivers[normalizeNativeKind(kind)]
+
+	return driver, ok
+}
+
+// nativeKindDrivers holds the built-in native mode kind drivers, kinds without one can be set up
+// via the native kinds of the global config.
+var nativeKindDrivers = newBuiltinNativeKindRegistry() //nolint:gochecknoglobals
+
+func newBuiltinNativeKindRegistry() *nativeKindRegistry {
+	registry := newNativeKindRegistry()
+
+	registry.Register(
+		"srl",
+		applyNativeSRLinux,
+		nativeKindDefaults{
+			licensePath:   "/opt/srlinux/etc/license.key",
+			interfaceName: resolveSRLinuxInterfaceName,
+		},
+		"nokia_srlinux",
+	)
+	registry.Register(
+		"vr-sros",
+		applyNativeSROS,
+		nativeKindDefaults{
+			licensePath:   "/tftpboot/license.txt",
+			interfaceName: resolveSROSInterfaceName,
+		},
+		"vr-nokia_sros", "nokia_sros",
+	)
+	registry.Register(
+		"juniper_vjunosswitch",
+		applyNativeVJunos,
+		nativeKindDefaults{interfaceName: resolveVJunosInterfaceName},
+		"juniper_vjunosrouter", "juniper_vjunosevolved",
+	)
+	registry.Register(
+		"xrd",
+		applyCiscoXRd,
+		nativeKindDefaults{interfaceName: resolveCiscoXRdInterfaceName},
+		"cisco_xrd",
+	)
+	registry.Register(
+		"vr-csr",
+		applyCiscoCSR,
+		nativeKindDefaults{interfaceName: resolveCiscoCSRInterfaceName},
+		"vr-cisco_csr1000v", "cisco_csr1000v", "cisco_c8000v",
+	)
+	registry.Register(
+		"vr-n9kv",
+		applyCiscoN9kv,
+		nativeKindDefaults{
+			startupSeconds: 1800, //nolint:mnd
+			interfaceName:  resolveCiscoN9kvInterfaceName,
+		},
+		"cisco_n9kv",
+	)
+	registry.Register(
+		"vr-veos",
+		applyAristaVEOS,
+		nativeKindDefaults{interfaceName: resolveAristaVEOSInterfaceName},
+		"vr-arista_veos", "arista_veos",
+	)
+	registry.Register(
+		"sonic-vs",
+		applySONiCVS,
+		nativeKindDefaults{
+			privileged:    true,
+			interfaceName: resolveSONiCVSInterfaceName,
+		},
+	)
+	registry.Register(
+		"crpd",
+		applyJuniperCRPD,
+		nativeKindDefaults{
+			// crpd programs the kernel fib (and needs raw sockets for the routing protocols)
+			capabilities: []k8scorev1.Capability{"NET_ADMIN", "NET_RAW", "SYS_ADMIN"},
+		},
+		"juniper_crpd",
+	)
+	registry.Register(
+		"cvx",
+		applyCumulusCVX,
+		nativeKindDefaults{privileged: true},
+	)
+	registry.Register(
+		"fortinet_fortigate",
+		applyFortinetFortiGate,
+		nativeKindDefaults{
+			licensePath:   "/config/license.lic",
+			interfaceName: resolveFortinetFortiGateInterfaceName,
+		},
+	)
+	registry.Register(
+		"vr-pan",
+		applyPaloAltoPANOS,
+		nativeKindDefaults{
+			startupSeconds: 2400, //nolint:mnd
+			interfaceName:  resolvePaloAltoPANOSInterfaceName,
+		},
+		"vr-paloalto_panos", "paloalto_panos",
+	)
+	registry.Register(
+		"vr-ros",
+		applyMikroTikROS,
+		nativeKindDefaults{interfaceName: resolveMikroTikROSInterfaceName},
+		"vr-mikrotik_ros", "mikrotik_ros",
+	)
+
+	return registry
+}
+
+func normalizeNativeKind(kind string) string {
+	return strings.ToLower(strings.TrimSpace(kind))
+}
+
+// lookupConfigNativeKind returns the native kind settings of the global config for the given kind,
+// if there are any.
+func lookupConfigNativeKind(
+	nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind,
+	kind string,
+) (*clabernetesapisv1alpha1.ConfigNativeKind, bool) {
+	nativeKind, ok := nativeKinds[normalizeNativeKind(kind)]
+	if !ok {
+		return nil, false
+	}
+
+	return &nativeKind, true
+}
+
+// ResolveKindStartupSeconds returns the default startup probe time of the given kind -- the one of
+// the native kind settings of the global config if they set one, otherwise the one of the
+// built-in driver of the kind, zero if the kind has none.
+func ResolveKindStartupSeconds(
+	kind string,
+	nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind,
+) int {
+	nativeKind, ok := lookupConfigNativeKind(nativeKinds, kind)
+	if ok && nativeKind.StartupSeconds > 0 {
+		return nativeKind.StartupSeconds
+	}
+
+	driver, ok := nativeKindDrivers.get(kind)
+	if !ok {
+		return 0
+	}
+
+	return driver.defaults.startupSeconds
+}
+
+// resolveNativeKindPrivileges returns if the nos container of nodes of the given kind needs to run
+// privileged, and the capabilities it needs otherwise -- from the built-in driver of the kind (if
+// builtinDrivers is true) and the native kind settings of the global config.
+func resolveNativeKindPrivileges(
+	kind string,
+	builtinDrivers bool,
+	nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind,
+) (bool, []k8scorev1.Capability) {
+	var privileged bool
+
+	var capabilities []k8scorev1.Capability
+
+	if driver, ok := nativeKindDrivers.get(kind); ok && builtinDrivers {
+		privileged = driver.defaults.privileged
+		capabilities = append(capabilities, driver.defaults.capabilities...)
+	}
+
+	if nativeKind, ok := lookupConfigNativeKind(nativeKinds, kind); ok {
+		privileged = privileged || nativeKind.Privileged
+		capabilities = append(capabilities, nativeKind.Capabilities...)
+	}
+
+	return privileged, capabilities
+}
+
+// resolveConfigNativeInterfaceName renames the given interface name with the interface alias
+// pattern of the given native kind settings. Returns false if the settings have no (valid)
+// pattern, or the interface name does not match it.
+func resolveConfigNativeInterfaceName(
+	nativeKind *clabernetesapisv1alpha1.ConfigNativeKind,
+	interfaceName string,
+) (string, bool) {
+	if nativeKind.InterfaceAliasPattern == "" {
+		return "", false
+	}
+
+	pattern, err := regexp.Compile(nativeKind.InterfaceAliasPattern)
+	if err != nil || pattern.NumSubexp() < 1 {
+		return "", false
+	}
+
+	match := pattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return "", false
+	}
+
+	port, err := strconv.Atoi(match[1])
+	if err != nil || port+nativeKind.InterfaceOffset < 1 {
+		// eth0 is the management interface, it can not be linked
+		return "", false
+	}
+
+	return "eth" + strconv.Itoa(port+nativeKind.InterfaceOffset), true
+}
+
+// applyNativeKind mounts the license of the given node where its kind expects it (see
+// ResolveNativeLicensePath), then applies the built-in native mode driver of the kind of the node
+// (if there is one and builtinDrivers is true), and the native kind settings of the global config
+// for the kind (if there are any).
+func applyNativeKind(
+	n *nativeNode,
+	builtinDrivers bool,
+	nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind,
+) {
+	nodeKind, _ := n.topology.GetNodeKindType(n.nodeName)
+
+	licensePath := ResolveNativeLicensePath(nodeKind, builtinDrivers, nativeKinds)
+	if licensePath != "" {
+		n.mountLicense(licensePath)
+	}
+
+	if driver, ok := nativeKindDrivers.get(nodeKind); ok && builtinDrivers {
+		driver.apply(n)
+	}
+
+	if nativeKind, ok := lookupConfigNativeKind(nativeKinds, nodeKind); ok {
+		n.applyConfigNativeKind(nativeKind)
+	}
+}
+
+// ResolveNativeLicensePath returns the path the license of native mode nodes of the given kind is
+// mounted at in the nos container -- the one of the native kind settings of the global config if
+// they set one, otherwise the one of the built-in driver of the kind (if builtinDrivers is true).
+// Returns an empty string if the license of nodes of the kind is not mounted.
+func ResolveNativeLicensePath(
+	kind string,
+	builtinDrivers bool,
+	nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind,
+) string {
+	nativeKind, ok := lookupConfigNativeKind(nativeKinds, kind)
+	if ok && nativeKind.LicensePath != "" {
+		return nativeKind.LicensePath
+	}
+
+	driver, ok := nativeKindDrivers.get(kind)
+	if !ok || !builtinDrivers {
+		return ""
+	}
+
+	return driver.defaults.licensePath
+}
+
+// nativeKindTemplateData is what the env, command and args of native kind settings are rendered
+// with.
+type nativeKindTemplateData struct {
+	NodeName       string
+	Kind           string
+	Type           string
+	InterfaceCount int
+	Env            map[string]string
+}
+
+// applyConfigNativeKind applies the given native kind settings of the global config to the nos
+// container of the node.
+func (n *nativeNode) applyConfigNativeKind(nativeKind *clabernetesapisv1alpha1.ConfigNativeKind) {
+	if nativeKind.Vrnetlab {
+		n.applyVrnetlabDefaults()
+	}
+
+	if nativeKind.StartupConfigPath != "" {
+		n.mountStartupConfig(nativeKind.StartupConfigPath, false)
+	}
+
+	for _, mount := range nativeKind.Mounts {
+		if !n.mountNativeKindFile(mount.FilePath, mount.MountPath) {
+			n.log.Warnf(
+				"node %q file %q not found in files from config map or secret, not mounting"+
+					" it at %q",
+				n.nodeName,
+				mount.FilePath,
+				mount.MountPath,
+			)
+		}
+	}
+
+	nodeKind, nodeType := n.topology.GetNodeKindType(n.nodeName)
+
+	data := nativeKindTemplateData{
+		NodeName:       n.nodeName,
+		Kind:           nodeKind,
+		Type:           nodeType,
+		InterfaceCount: n.interfaceCount(),
+		Env:            map[string]string{},
+	}
+
+	for _, envVar := range n.container.Env {
+		data.Env[strings.TrimSpace(envVar.Name)] = envVar.Value
+	}
+
+	envKeys := make([]string, 0, len(nativeKind.Env))
+	for key := range nativeKind.Env {
+		envKeys = append(envKeys, key)
+	}
+
+	// sorted so the rendered container (and so the deployment) does not change between reconciles
+	slices.Sort(envKeys)
+
+	for _, key := range envKeys {
+		n.defaultEnv(key, n.renderNativeKindTemplate(nativeKind.Env[key], &data))
+	}
+
+	for _, envVar := range n.container.Env {
+		data.Env[strings.TrimSpace(envVar.Name)] = envVar.Value
+	}
+
+	if len(nativeKind.Command) > 0 {
+		n.container.Command = n.renderNativeKindTemplates(nativeKind.Command, &data)
+	}
+
+	if len(nativeKind.Args) > 0 {
+		n.container.Args = n.renderNativeKindTemplates(nativeKind.Args, &data)
+	}
+}
+
+// mountNativeKindFile mounts the file the node has in its files from config map (or from secret)
+// at the given file path -- or, if there is none, the one whose base name is the given file path --
+// at the given mount path in the nos container. Returns false if the node has no such file.
+func (n *nativeNode) mountNativeKindFile(filePath, mountPath string) bool {
+	if n.mountNodeFile(filePath, mountPath) {
+		return true
+	}
+
+	for _, nodeFilePath := range n.nodeFilePaths() {
+		if filepath.Base(nodeFilePath) == strings.TrimSpace(filePath) {
+			return n.mountNodeFile(nodeFilePath, mountPath)
+		}
+	}
+
+	return false
+}
+
+// renderNativeKindTemplates renders each of the given native kind setting templates.
+func (n *nativeNode) renderNativeKindTemplates(
+	raw []string,
+	data *nativeKindTemplateData,
+) []string {
+	rendered := make([]string, len(raw))
+
+	for idx := range raw {
+		rendered[idx] = n.renderNativeKindTemplate(raw[idx], data)
+	}
+
+	return rendered
+}
+
+// renderNativeKindTemplate renders the given native kind setting template, templates that fail to
+// parse or render are used as is (with a warning).
+func (n *nativeNode) renderNativeKindTemplate(raw string, data *nativeKindTemplateData) string {
+	if !strings.Contains(raw, "{{") {
+		return raw
+	}
+
+	t, err := template.New("nativeKind").Option("missingkey=zero").Parse(raw)
+	if err != nil {
+		n.log.Warnf("node %q native kind template %q is invalid, err: %s", n.nodeName, raw, err)
+
+		return raw
+	}
+
+	var rendered bytes.Buffer
+
+	err = t.Execute(&rendered, data)
+	if err != nil {
+		n.log.Warnf(
+			"node %q native kind template %q failed to render, err: %s",
+			n.nodeName,
+			raw,
+			err,
+		)
+
+		return raw
+	}
+
+	return rendered.String()
+}
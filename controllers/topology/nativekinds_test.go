@@ -0,0 +1,159 @@
+package topology_test
+
+import (
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestResolveKindStartupSeconds(t *testing.T) {
+	cases := []struct {
+		name        string
+		kind        string
+		nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind
+		expected    int
+	}{
+		{
+			name:     "builtin-kind",
+			kind:     "cisco_n9kv",
+			expected: 1800,
+		},
+		{
+			name:     "builtin-kind-alias",
+			kind:     "vr-pan",
+			expected: 2400,
+		},
+		{
+			name:     "builtin-kind-without-startup-seconds",
+			kind:     "srl",
+			expected: 0,
+		},
+		{
+			name:     "unknown-kind",
+			kind:     "vr-ftosv",
+			expected: 0,
+		},
+		{
+			name: "config-kind",
+			kind: "vr-ftosv",
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"vr-ftosv": {
+					StartupSeconds: 1200,
+				},
+			},
+			expected: 1200,
+		},
+		{
+			name: "config-kind-overrides-builtin",
+			kind: "vr-n9kv",
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"vr-n9kv": {
+					StartupSeconds: 3600,
+				},
+			},
+			expected: 3600,
+		},
+		{
+			name: "config-kind-without-startup-seconds",
+			kind: "vr-n9kv",
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"vr-n9kv": {
+					Vrnetlab: true,
+				},
+			},
+			expected: 1800,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveKindStartupSeconds(
+					testCase.kind,
+					testCase.nativeKinds,
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestResolveNativeLicensePath(t *testing.T) {
+	cases := []struct {
+		name           string
+		kind           string
+		builtinDrivers bool
+		nativeKinds    map[string]clabernetesapisv1alpha1.ConfigNativeKind
+		expected       string
+	}{
+		{
+			name:           "builtin-kind",
+			kind:           "nokia_srlinux",
+			builtinDrivers: true,
+			expected:       "/opt/srlinux/etc/license.key",
+		},
+		{
+			name:           "builtin-kind-alias",
+			kind:           "vr-sros",
+			builtinDrivers: true,
+			expected:       "/tftpboot/license.txt",
+		},
+		{
+			name:           "builtin-drivers-disabled",
+			kind:           "nokia_srlinux",
+			builtinDrivers: false,
+			expected:       "",
+		},
+		{
+			name:           "builtin-kind-without-license",
+			kind:           "cisco_n9kv",
+			builtinDrivers: true,
+			expected:       "",
+		},
+		{
+			name:           "config-kind",
+			kind:           "vr-ftosv",
+			builtinDrivers: false,
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"vr-ftosv": {
+					LicensePath: "/config/license.txt",
+				},
+			},
+			expected: "/config/license.txt",
+		},
+		{
+			name:           "config-kind-overrides-builtin",
+			kind:           "srl",
+			builtinDrivers: true,
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"srl": {
+					LicensePath: "/etc/opt/srlinux/license.key",
+				},
+			},
+			expected: "/etc/opt/srlinux/license.key",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveNativeLicensePath(
+					testCase.kind,
+					testCase.builtinDrivers,
+					testCase.nativeKinds,
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
@@ -0,0 +1,1247 @@
+package topology
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// nativeStagingVolumeName/Path is the (empty dir) volume files are staged in for nos
+	// containers that need to copy them into place on boot rather than having them mounted where
+	// they end up -- for example configs the nos writes back to.
+	nativeStagingVolumeName = "clabernetes-native"
+	nativeStagingPath       = "/clabernetes-native"
+)
+
+// srlInterfaceAliasPattern matches the "ethernet-1/1" (and breakout "ethernet-1/3/1") style
+// interface aliases containerlab accepts for sr linux nodes.
+var srlInterfaceAliasPattern = regexp.MustCompile(`^ethernet-(\d+)/(\d+)(?:/(\d+))?$`)
+
+// junosInterfaceAliasPattern matches the "ge-0/0/2" style interface aliases containerlab accepts
+// for vjunos nodes -- the first port (0) is eth1 as eth0 is the management interface.
+var junosInterfaceAliasPattern = regexp.MustCompile(`^(?:ge|xe|et)-0/0/(\d+)$`)
+
+// srosInterfaceAliasPattern matches the "1/1/3" (and connector "1/1/c3/1") style interface aliases
+// containerlab accepts for sr os nodes -- ports of the first mda of the first card.
+var srosInterfaceAliasPattern = regexp.MustCompile(`^1/1/(?:c(\d+)/1|(\d+))$`)
+
+// xrdInterfaceAliasPattern matches the "Gi0/0/0/1" (and "GigabitEthernet0/0/0/1") style interface
+// aliases for xrd nodes -- the linux interfaces xrd maps into xr are named "Gi0-0-0-1".
+var xrdInterfaceAliasPattern = regexp.MustCompile(`^(?:Gi|GigabitEthernet)0/0/0/(\d+)$`)
+
+// csrInterfaceAliasPattern matches the "Gi2" (and "GigabitEthernet2") style interface aliases
+// containerlab accepts for csr1000v/c8000v nodes -- GigabitEthernet1 is the management interface,
+// so GigabitEthernet2 is eth1.
+var csrInterfaceAliasPattern = regexp.MustCompile(`^(?:Gi|GigabitEthernet)(\d+)$`)
+
+// n9kvInterfaceAliasPattern matches the "Ethernet1/1" (and "Eth1/1") style interface aliases
+// containerlab accepts for n9kv nodes -- Ethernet1/1 is eth1.
+var n9kvInterfaceAliasPattern = regexp.MustCompile(`^(?:Eth|Ethernet)1/(\d+)$`)
+
+// veosInterfaceAliasPattern matches the "Ethernet1" (and "Et1") style interface aliases for veos
+// nodes -- Ethernet1 is eth1.
+var veosInterfaceAliasPattern = regexp.MustCompile(`^(?:Et|Ethernet)(\d+)$`)
+
+// sonicInterfaceAliasPattern matches the "Ethernet0" style interface aliases for sonic-vs nodes --
+// the sonic-vs port config has a port every four lanes, Ethernet0 is eth1, Ethernet4 is eth2 etc.
+var sonicInterfaceAliasPattern = regexp.MustCompile(`^Ethernet(\d+)$`)
+
+// fortigateInterfaceAliasPattern matches the "port2" style interface aliases for fortigate nodes --
+// port1 is the management interface, so port2 is eth1.
+var fortigateInterfaceAliasPattern = regexp.MustCompile(`^port(\d+)$`)
+
+// panosInterfaceAliasPattern matches the "ethernet1/1" style interface aliases for pan-os nodes --
+// ethernet1/1 is eth1.
+var panosInterfaceAliasPattern = regexp.MustCompile(`^ethernet1/(\d+)$`)
+
+// mikrotikInterfaceAliasPattern matches the "ether2" style interface aliases for mikrotik ros
+// nodes -- ether1 is the management interface, so ether2 is eth1.
+var mikrotikInterfaceAliasPattern = regexp.MustCompile(`^ether(\d+)$`)
+
+// panosBootstrapFiles maps the (base) names of the pan-os bootstrap package files to their path
+// in the bootstrap package.
+var panosBootstrapFiles = map[string]string{ //nolint:gochecknoglobals
+	"init-cfg.txt":  "config/init-cfg.txt",
+	"bootstrap.xml": "config/bootstrap.xml",
+	"authcodes":     "license/authcodes",
+}
+
+// ceosInterfacePattern matches the interface names used for ceos nodes in links -- containerlab
+// style "eth1", netlab style "et1", and eos style "Ethernet1" or "Ethernet1/1" (or "eth1_1") for
+// modular front panels -- capturing the port (and lane) numbers.
+var ceosInterfacePattern = regexp.MustCompile(
+	`(?i)^(?:ethernet|eth|et)(\d+)(?:[/_-](\d+))?(?:[/_-](\d+))?$`,
+)
+
+// ceosIntfMapping is the cEOS-lab interface mapping file (/mnt/flash/EosIntfMapping.json) that
+// tells ceos which eos interface each linux interface is.
+type ceosIntfMapping struct {
+	ManagementIntf map[string]string `json:"ManagementIntf"`
+	EthernetIntf   map[string]string `json:"EthernetIntf"`
+}
+
+// nativeNode holds the nos container of a native mode node (and the deployment it lives in). The
+// per kind native mode handlers use it to replicate what the containerlab kind driver would do for
+// the node in docker mode.
+type nativeNode struct {
+	log            claberneteslogging.Instance
+	deployment     *k8sappsv1.Deployment
+	container      *k8scorev1.Container
+	owningTopology *clabernetesapisv1alpha1.Topology
+	nodeName       string
+	nodeDefinition *clabernetesutilcontainerlab.NodeDefinition
+	topology       *clabernetesutilcontainerlab.Topology
+	// resources are the resources of the launcher of the node (if any), the nos container does
+	// not get them, but some kinds need (some of) them on the nos container as well
+	resources *k8scorev1.ResourceRequirements
+}
+
+// hasMount returns true if the nos container already has something mounted at the given path.
+func (n *nativeNode) hasMount(mountPath string) bool {
+	for _, volumeMount := range n.container.VolumeMounts {
+		if strings.TrimSpace(volumeMount.MountPath) == mountPath {
+			return true
+		}
+	}
+
+	return false
+}
+
+// upsertEnv sets the given env var on the nos container, replacing any existing value.
+func (n *nativeNode) upsertEnv(key, value string) {
+	for idx := range n.container.Env {
+		if strings.TrimSpace(n.container.Env[idx].Name) == key {
+			n.container.Env[idx].Value = value
+
+			return
+		}
+	}
+
+	n.container.Env = append(n.container.Env, k8scorev1.EnvVar{Name: key, Value: value})
+}
+
+// defaultEnv sets the given env var on the nos container unless it is set already -- env the
+// containerlab kind driver sets by default can be overridden via the node env.
+func (n *nativeNode) defaultEnv(key, value string) {
+	for idx := range n.container.Env {
+		if strings.TrimSpace(n.container.Env[idx].Name) == key {
+			return
+		}
+	}
+
+	n.container.Env = append(n.container.Env, k8scorev1.EnvVar{Name: key, Value: value})
+}
+
+// env returns the value of the given env var of the nos container, or an empty string.
+func (n *nativeNode) env(key string) string {
+	for idx := range n.container.Env {
+		if strings.TrimSpace(n.container.Env[idx].Name) == key {
+			return n.container.Env[idx].Value
+		}
+	}
+
+	return ""
+}
+
+// interfaces returns the names of the link endpoints (interfaces) the node has, in link order.
+func (n *nativeNode) interfaces() []string {
+	var interfaceNames []string
+
+	for _, link := range n.topology.Links {
+		for _, endpoint := range link.Endpoints {
+			nodeName, interfaceName, _ := strings.Cut(endpoint, ":")
+			if nodeName == n.nodeName {
+				interfaceNames = append(interfaceNames, interfaceName)
+			}
+		}
+
+		if link.Endpoint != nil && link.Endpoint["node"] == n.nodeName {
+			interfaceNames = append(interfaceNames, link.Endpoint["interface"])
+		}
+	}
+
+	return interfaceNames
+}
+
+// interfaceCount returns the number of link endpoints (interfaces) the node has.
+func (n *nativeNode) interfaceCount() int {
+	return len(n.interfaces())
+}
+
+// addEmptyDir mounts a (new) empty dir volume with the given name at the given path in the nos
+// container, unless something is mounted there already.
+func (n *nativeNode) addEmptyDir(volumeName, mountPath string, medium k8scorev1.StorageMedium) {
+	if n.hasMount(mountPath) {
+		return
+	}
+
+	volumeExists := false
+
+	for _, volume := range n.deployment.Spec.Template.Spec.Volumes {
+		if volume.Name == volumeName {
+			volumeExists = true
+
+			break
+		}
+	}
+
+	if !volumeExists {
+		n.deployment.Spec.Template.Spec.Volumes = append(
+			n.deployment.Spec.Template.Spec.Volumes,
+			k8scorev1.Volume{
+				Name: volumeName,
+				VolumeSource: k8scorev1.VolumeSource{
+					EmptyDir: &k8scorev1.EmptyDirVolumeSource{
+						Medium: medium,
+					},
+				},
+			},
+		)
+	}
+
+	n.container.VolumeMounts = append(
+		n.container.VolumeMounts,
+		k8scorev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: mountPath,
+		},
+	)
+}
+
+// nodeFilePathMatches returns true if the given (containerlab) file path of a file of the node is
+// the given file path -- containerlab resolves paths relative to the topology file, so
+// "license.key" and "./license.key" are the same file.
+func nodeFilePathMatches(nodeFilePath, filePath string) bool {
+	nodeFilePath = strings.TrimSpace(nodeFilePath)
+
+	return nodeFilePath != "" && filepath.Clean(nodeFilePath) == filepath.Clean(filePath)
+}
+
+// mountNodeFile mounts the file the node has in its files from config map (or from secret) at the
+// given (containerlab) file path at the given mount path in the nos container. Files like
+// startup-configs and licenses end up in the launcher at the path containerlab expects them at, the
+// nos container needs them wherever the nos looks for them. Returns false if the node has no such
+// file.
+func (n *nativeNode) mountNodeFile(filePath, mountPath string) bool {
+	filePath = strings.TrimSpace(filePath)
+	if filePath == "" {
+		return false
+	}
+
+	if n.hasMount(mountPath) {
+		return true
+	}
+
+	for _, fileFromConfigMap := range n.owningTopology.Spec.Deployment.FilesFromConfigMap[n.nodeName] {
+		if strings.TrimSpace(fileFromConfigMap.ConfigMapName) == "" ||
+			strings.TrimSpace(fileFromConfigMap.ConfigMapPath) == "" ||
+			!nodeFilePathMatches(fileFromConfigMap.FilePath, filePath) {
+			continue
+		}
+
+		n.container.VolumeMounts = append(
+			n.container.VolumeMounts,
+			k8scorev1.VolumeMount{
+				Name: clabernetesutilkubernetes.EnforceDNSLabelConvention(
+					clabernetesutilkubernetes.SafeConcatNameKubernetes(
+						fileFromConfigMap.ConfigMapName,
+						fileFromConfigMap.ConfigMapPath,
+					),
+				),
+				ReadOnly:  true,
+				MountPath: mountPath,
+				SubPath:   fileFromConfigMap.ConfigMapPath,
+			},
+		)
+
+		return true
+	}
+
+	for _, fileFromSecret := range n.owningTopology.Spec.Deployment.FilesFromSecret[n.nodeName] {
+		if strings.TrimSpace(fileFromSecret.SecretName) == "" ||
+			strings.TrimSpace(fileFromSecret.SecretKey) == "" ||
+			!nodeFilePathMatches(fileFromSecret.FilePath, filePath) {
+			continue
+		}
+
+		n.container.VolumeMounts = append(
+			n.container.VolumeMounts,
+			k8scorev1.VolumeMount{
+				Name:      fileFromSecretVolumeName(fileFromSecret),
+				ReadOnly:  true,
+				MountPath: mountPath,
+				SubPath:   fileFromSecret.SecretKey,
+			},
+		)
+
+		return true
+	}
+
+	return false
+}
+
+// nodeFilePaths returns the (containerlab) file paths of the files the node has in its files from
+// config map and from secret.
+func (n *nativeNode) nodeFilePaths() []string {
+	var filePaths []string
+
+	for _, fileFromConfigMap := range n.owningTopology.Spec.Deployment.FilesFromConfigMap[n.nodeName] {
+		filePaths = append(filePaths, fileFromConfigMap.FilePath)
+	}
+
+	for _, fileFromSecret := range n.owningTopology.Spec.Deployment.FilesFromSecret[n.nodeName] {
+		filePaths = append(filePaths, fileFromSecret.FilePath)
+	}
+
+	return filePaths
+}
+
+// mountLicense mounts the license of the node (if it has one) at the given path in the nos
+// container. Kind drivers get this for free by registering the path their nos reads the license
+// from (see nativeKindDefaults), only kinds that need to do more with the license call this.
+func (n *nativeNode) mountLicense(mountPath string) {
+	license := n.topology.GetNodeLicense(n.nodeName)
+	if license != "" && !n.mountNodeFile(license, mountPath) {
+		n.log.Warnf(
+			"node %q license %q not found in files from config map or secret, not mounting"+
+				" license",
+			n.nodeName,
+			license,
+		)
+	}
+}
+
+// mountStartupConfig mounts the startup-config of the node (if it has one) at the given path in
+// the nos container. Partial startup-configs are only mounted if the kind can merge them.
+func (n *nativeNode) mountStartupConfig(mountPath string, partialSupported bool) {
+	startupConfig := strings.TrimSpace(n.nodeDefinition.StartupConfig)
+
+	switch {
+	case startupConfig == "":
+	case !partialSupported && strings.Contains(filepath.Base(startupConfig), ".partial"):
+		n.log.Warnf(
+			"node %q startup-config %q is a partial config, partial configs are not supported in"+
+				" native mode for this kind, booting without it",
+			n.nodeName,
+			startupConfig,
+		)
+	case !n.mountNodeFile(startupConfig, mountPath):
+		n.log.Warnf(
+			"node %q startup-config %q not found in files from config map, booting without it",
+			n.nodeName,
+			startupConfig,
+		)
+	}
+}
+
+// applyVrnetlabDefaults sets the env every vrnetlab kind driver of containerlab sets -- the
+// connection mode (how the vm is wired up to the container interfaces) and the number of
+// interfaces vrnetlab waits for before booting the vm. The node can pick its own connection mode,
+// other than macvtap which can not work in a pod.
+func (n *nativeNode) applyVrnetlabDefaults() {
+	n.defaultEnv("CONNECTION_MODE", "tc")
+
+	if n.env("CONNECTION_MODE") == "macvtap" {
+		// macvtap needs tap devices from the host, the pod does not get those
+		n.log.Warnf(
+			"node %q connection mode 'macvtap' is not supported in native mode, using 'tc'",
+			n.nodeName,
+		)
+
+		n.upsertEnv("CONNECTION_MODE", "tc")
+	}
+
+	n.defaultEnv("CLAB_INTFS", strconv.Itoa(n.interfaceCount()))
+}
+
+// ResolveNativeInterfaceName returns the (linux) interface name a nos of the given kind expects
+// for the given containerlab interface name -- in docker mode containerlab translates interface
+// aliases itself, in native mode the launcher creates the interfaces with the names in the
+// containerlab links as is, so the names need to be right to begin with. The interface alias
+// pattern of the native kind settings of the global config (if any) for the kind wins over the
+// aliases the built-in driver of the kind knows about.
+func ResolveNativeInterfaceName(
+	kind, interfaceName string,
+	nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind,
+) string {
+	if nativeKind, ok := lookupConfigNativeKind(nativeKinds, kind); ok {
+		resolvedInterfaceName, resolved := resolveConfigNativeInterfaceName(
+			nativeKind,
+			interfaceName,
+		)
+		if resolved {
+			return resolvedInterfaceName
+		}
+	}
+
+	driver, ok := nativeKindDrivers.get(kind)
+	if !ok || driver.defaults.interfaceName == nil {
+		return interfaceName
+	}
+
+	return driver.defaults.interfaceName(interfaceName)
+}
+
+// resolveSRLinuxInterfaceName resolves the interface aliases of sr linux nodes.
+func resolveSRLinuxInterfaceName(interfaceName string) string {
+	match := srlInterfaceAliasPattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return interfaceName
+	}
+
+	if match[3] != "" {
+		return fmt.Sprintf("e%s-%s-%s", match[1], match[2], match[3])
+	}
+
+	return fmt.Sprintf("e%s-%s", match[1], match[2])
+}
+
+// resolveSROSInterfaceName resolves the interface aliases of sr os nodes.
+func resolveSROSInterfaceName(interfaceName string) string {
+	match := srosInterfaceAliasPattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return interfaceName
+	}
+
+	return "eth" + match[1] + match[2]
+}
+
+// resolveVJunosInterfaceName resolves the interface aliases of vjunos nodes.
+func resolveVJunosInterfaceName(interfaceName string) string {
+	match := junosInterfaceAliasPattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return interfaceName
+	}
+
+	port, _ := strconv.Atoi(match[1])
+
+	return fmt.Sprintf("eth%d", port+1)
+}
+
+// resolveCiscoXRdInterfaceName resolves the interface aliases of xrd nodes.
+func resolveCiscoXRdInterfaceName(interfaceName string) string {
+	match := xrdInterfaceAliasPattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return interfaceName
+	}
+
+	return "Gi0-0-0-" + match[1]
+}
+
+// resolveCiscoCSRInterfaceName resolves the interface aliases of csr1000v/c8000v nodes.
+func resolveCiscoCSRInterfaceName(interfaceName string) string {
+	match := csrInterfaceAliasPattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return interfaceName
+	}
+
+	port, _ := strconv.Atoi(match[1])
+	if port < 2 { //nolint:mnd
+		// GigabitEthernet1 is the management interface, it can not be linked
+		return interfaceName
+	}
+
+	return fmt.Sprintf("eth%d", port-1)
+}
+
+// resolveCiscoN9kvInterfaceName resolves the interface aliases of n9kv nodes.
+func resolveCiscoN9kvInterfaceName(interfaceName string) string {
+	match := n9kvInterfaceAliasPattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return interfaceName
+	}
+
+	return "eth" + match[1]
+}
+
+// resolveAristaVEOSInterfaceName resolves the interface aliases of veos nodes.
+func resolveAristaVEOSInterfaceName(interfaceName string) string {
+	match := veosInterfaceAliasPattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return interfaceName
+	}
+
+	return "eth" + match[1]
+}
+
+// resolveSONiCVSInterfaceName resolves the interface aliases of sonic-vs nodes.
+func resolveSONiCVSInterfaceName(interfaceName string) string {
+	match := sonicInterfaceAliasPattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return interfaceName
+	}
+
+	lane, _ := strconv.Atoi(match[1])
+	if lane%4 != 0 {
+		return interfaceName
+	}
+
+	return fmt.Sprintf("eth%d", lane/4+1) //nolint:mnd
+}
+
+// resolveFortinetFortiGateInterfaceName resolves the interface aliases of fortigate nodes.
+func resolveFortinetFortiGateInterfaceName(interfaceName string) string {
+	match := fortigateInterfaceAliasPattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return interfaceName
+	}
+
+	port, _ := strconv.Atoi(match[1])
+	if port < 2 { //nolint:mnd
+		// port1 is the management interface, it can not be linked
+		return interfaceName
+	}
+
+	return fmt.Sprintf("eth%d", port-1)
+}
+
+// resolvePaloAltoPANOSInterfaceName resolves the interface aliases of pan-os nodes.
+func resolvePaloAltoPANOSInterfaceName(interfaceName string) string {
+	match := panosInterfaceAliasPattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return interfaceName
+	}
+
+	return "eth" + match[1]
+}
+
+// resolveMikroTikROSInterfaceName resolves the interface aliases of mikrotik ros nodes.
+func resolveMikroTikROSInterfaceName(interfaceName string) string {
+	match := mikrotikInterfaceAliasPattern.FindStringSubmatch(interfaceName)
+	if match == nil {
+		return interfaceName
+	}
+
+	port, _ := strconv.Atoi(match[1])
+	if port < 2 { //nolint:mnd
+		// ether1 is the management interface, it can not be linked
+		return interfaceName
+	}
+
+	return fmt.Sprintf("eth%d", port-1)
+}
+
+// applyNativeInterfaceNames rewrites the interface names of the link endpoints of the given
+// containerlab topology to the names the nos of each node expects (see ResolveNativeInterfaceName)
+// -- this is only done in native mode, in docker mode containerlab takes care of this.
+func (p *containerlabDefinitionProcessor) applyNativeInterfaceNames(
+	clabTopo *clabernetesutilcontainerlab.Topology,
+) {
+	if !ResolveNativeMode(p.topology) {
+		return
+	}
+
+	nativeKinds := p.configManagerGetter().GetNativeKinds()
+
+	resolve := func(nodeName, interfaceName string) string {
+		nodeKind, _ := clabTopo.GetNodeKindType(nodeName)
+
+		resolvedInterfaceName := ResolveNativeInterfaceName(nodeKind, interfaceName, nativeKinds)
+		if resolvedInterfaceName != interfaceName {
+			p.logger.Debugf(
+				"node %q interface %q is named %q in native mode",
+				nodeName,
+				interfaceName,
+				resolvedInterfaceName,
+			)
+		}
+
+		return resolvedInterfaceName
+	}
+
+	for _, link := range clabTopo.Links {
+		for idx, endpoint := range link.Endpoints {
+			nodeName, interfaceName, ok := strings.Cut(endpoint, ":")
+			if !ok {
+				continue
+			}
+
+			link.Endpoints[idx] = fmt.Sprintf("%s:%s", nodeName, resolve(nodeName, interfaceName))
+		}
+
+		if link.Endpoint != nil && link.Endpoint["node"] != "" {
+			link.Endpoint["interface"] = resolve(link.Endpoint["node"], link.Endpoint["interface"])
+		}
+	}
+}
+
+// applyNativeSRLinux replicates the containerlab sr linux kind driver: sr linux runs its own
+// init (sr_linux), wants a handful of sysctls set, and reads its license and config from fixed
+// locations. Json startup-configs are copied into place before sr linux boots (sr linux writes
+// its config back, so it can not be mounted read only), cli ("set" style) startup-configs are
+// applied with sr_cli once the management server is up, just like containerlab does.
+func applyNativeSRLinux(n *nativeNode) {
+	n.upsertEnv("SRLINUX", "1")
+
+	startupConfigPath := nativeStagingPath + "/startup-config"
+
+	startupConfig := strings.TrimSpace(n.nodeDefinition.StartupConfig)
+	if startupConfig != "" {
+		n.addEmptyDir(nativeStagingVolumeName, nativeStagingPath, "")
+
+		if !n.mountNodeFile(startupConfig, startupConfigPath) {
+			n.log.Warnf(
+				"node %q startup-config %q not found in files from config map, booting without it",
+				n.nodeName,
+				startupConfig,
+			)
+		}
+	}
+
+	_, nodeType := n.topology.GetNodeKindType(n.nodeName)
+	if nodeType != "" {
+		n.log.Warnf(
+			"node %q has type %q, sr linux types are not supported in native mode, booting the"+
+				" default chassis",
+			n.nodeName,
+			nodeType,
+		)
+	}
+
+	n.container.Command = []string{"bash", "-c", strings.TrimSpace(fmt.Sprintf(`
+for setting in net.ipv4.ip_forward=0 net.ipv6.conf.all.disable_ipv6=0 \
+  net.ipv6.conf.all.accept_dad=0 net.ipv6.conf.default.accept_dad=0 \
+  net.ipv6.conf.all.autoconf=0 net.ipv6.conf.default.autoconf=0; do
+  sysctl -w "$setting" >/dev/null 2>&1 || echo "[clabernetes] failed setting sysctl $setting"
+done
+
+startup_config="%[1]s"
+if [ -f "$startup_config" ] && [ ! -f /etc/opt/srlinux/config.json ]; then
+  if head -c 64 "$startup_config" | grep -q '{'; then
+    mkdir -p /etc/opt/srlinux
+    cp "$startup_config" /etc/opt/srlinux/config.json
+  else
+    (
+      for attempt in $(seq 1 120); do
+        if /opt/srlinux/bin/sr_cli -d -c "info from state system app-management application mgmt_server state" 2>/dev/null | grep -q running; then
+          /opt/srlinux/bin/sr_cli -ed --post "commit save" < "$startup_config" && break
+        fi
+        sleep 5
+      done
+    ) &
+  fi
+fi
+
+touch /.dockerenv
+exec /opt/srlinux/bin/sr_linux
+`, startupConfigPath))}
+}
+
+// applyNativeSROS replicates the containerlab sr os (vrnetlab) kind driver: vrnetlab is told how to
+// wire the vm up (tc connection mode), what to boot (the variant, from the node type, defaulting to
+// "sr-1" like containerlab does -- distributed variants get their line card vms from it as well),
+// and how many interfaces to wait for; the license and (full) startup-config are mounted where
+// vrnetlab hands them to the vm via tftp. The vrnetlab entrypoint is left alone, only its args are
+// set, so a node cmd still overrides them.
+func applyNativeSROS(n *nativeNode) {
+	n.applyVrnetlabDefaults()
+
+	n.mountStartupConfig("/tftpboot/config.txt", false)
+
+	_, variant := n.topology.GetNodeKindType(n.nodeName)
+	if variant == "" {
+		variant = "sr-1"
+	}
+
+	n.container.Args = []string{
+		"--trace",
+		"--connection-mode",
+		n.env("CONNECTION_MODE"),
+		"--hostname",
+		n.nodeName,
+		"--variant",
+		variant,
+	}
+}
+
+// applyNativeVJunos replicates the containerlab vjunos (vrnetlab) kind drivers: the startup-config
+// is mounted where vrnetlab looks for it when it generates the config drive the vm boots with, and
+// vrnetlab is given the credentials, hostname and connection mode containerlab gives it. vjunos
+// needs kvm (which all native mode nos containers get); if the launcher resources of the node ask
+// for hugepages the nos container gets them as well and qemu is told to back the vm memory with
+// them.
+func applyNativeVJunos(n *nativeNode) {
+	n.applyVrnetlabDefaults()
+
+	n.mountStartupConfig("/config/startup-config.cfg", false)
+
+	n.applyHugepages()
+
+	n.container.Args = []string{
+		"--username",
+		"admin",
+		"--password",
+		"admin@123",
+		"--hostname",
+		n.nodeName,
+		"--connection-mode",
+		n.env("CONNECTION_MODE"),
+		"--trace",
+	}
+}
+
+// applyCiscoXRd replicates the containerlab xrd kind driver: xrd (control plane) is a container,
+// not a vm, that is told which linux interfaces to map into xr (and as what) via env -- the
+// management interface is always eth0, the data interfaces are rendered from the links of the
+// sub-topology -- and boots with the startup-config as its first boot config. xr keeps its state
+// in /xr-storage, that gets an empty dir so it survives container restarts. The sysctls xr wants
+// in its network namespace are set before handing over to the image init.
+func applyCiscoXRd(n *nativeNode) {
+	n.defaultEnv(
+		"XR_MGMT_INTERFACES",
+		"linux:eth0,xr_name=Mg0/RP0/CPU0/0,chksum,snoop_v4,snoop_v6",
+	)
+
+	xrInterfaces := make([]string, 0)
+
+	for _, interfaceName := range n.interfaces() {
+		xrInterfaces = append(
+			xrInterfaces,
+			fmt.Sprintf(
+				"linux:%s,xr_name=%s",
+				interfaceName,
+				strings.ReplaceAll(interfaceName, "-", "/"),
+			),
+		)
+	}
+
+	n.defaultEnv("XR_INTERFACES", strings.Join(xrInterfaces, ";"))
+
+	startupConfigPath := "/etc/xrd/first-boot.cfg"
+
+	n.mountStartupConfig(startupConfigPath, false)
+
+	if n.hasMount(startupConfigPath) {
+		n.defaultEnv("XR_FIRST_BOOT_CONFIG", startupConfigPath)
+	}
+
+	n.addEmptyDir("xr-storage", "/xr-storage", "")
+
+	n.container.Command = []string{"bash", "-c", strings.TrimSpace(`
+for setting in net.ipv4.ip_forward=0 net.ipv6.conf.all.disable_ipv6=0 \
+  net.ipv6.conf.default.disable_ipv6=0 net.ipv4.conf.all.rp_filter=0; do
+  sysctl -w "$setting" >/dev/null 2>&1 || echo "[clabernetes] failed setting sysctl $setting"
+done
+
+exec /sbin/init
+`)}
+}
+
+// applyCiscoCSR replicates the containerlab csr1000v/c8000v (vrnetlab) kind drivers: the startup-
+// config is mounted where vrnetlab picks it up and applies it once the vm booted, and vrnetlab is
+// given the credentials, hostname and connection mode containerlab gives it. Ssh (22) and netconf
+// (830) are served by vrnetlab on the pod, both are part of the default (auto) exposed ports.
+// Unlike the launcher the nos container gets no resources, as the vm needs 4GB of memory (plus
+// qemu overhead) and a cpu to boot at all the nos container requests those unless the node sets
+// its own.
+func applyCiscoCSR(n *nativeNode) {
+	n.applyVrnetlabDefaults()
+
+	n.mountStartupConfig("/config/startup-config.cfg", false)
+
+	n.defaultResourceRequests("1", "5Gi")
+
+	n.container.Args = []string{
+		"--username",
+		"admin",
+		"--password",
+		"admin",
+		"--hostname",
+		n.nodeName,
+		"--connection-mode",
+		n.env("CONNECTION_MODE"),
+		"--trace",
+	}
+}
+
+// applyCiscoN9kv replicates the containerlab n9kv (vrnetlab) kind driver: the startup-config is
+// mounted where vrnetlab picks it up and applies it once the vm booted, and vrnetlab is given the
+// credentials, hostname and connection mode containerlab gives it. n9kv is big: it needs kvm (which
+// all native mode nos containers get), its vm wants 8GB of memory (plus qemu overhead) and a
+// couple cpus, those are requested unless the node sets its own, and it backs the vm memory with
+// hugepages if the launcher resources of the node ask for them. It also boots slowly, that is
+// taken care of by the longer default startup probe time of the kind.
+func applyCiscoN9kv(n *nativeNode) {
+	n.applyVrnetlabDefaults()
+
+	n.mountStartupConfig("/config/startup-config.cfg", false)
+
+	n.defaultResourceRequests("2", "10Gi")
+
+	n.applyHugepages()
+
+	n.container.Args = []string{
+		"--username",
+		"admin",
+		"--password",
+		"admin",
+		"--hostname",
+		n.nodeName,
+		"--connection-mode",
+		n.env("CONNECTION_MODE"),
+		"--trace",
+	}
+}
+
+// applyAristaVEOS replicates the containerlab veos (vrnetlab) kind driver: the vm boots eos via
+// aboot, which vrnetlab drives over the serial console -- cancelling zerotouch provisioning so eos
+// does not wait for a ztp server -- before it applies the startup-config, mounted where vrnetlab
+// picks it up. Vrnetlab is given the credentials, hostname and connection mode containerlab gives
+// it. The vm wants 2GB of memory (plus qemu overhead) and a cpu, those are requested unless the
+// node sets its own.
+func applyAristaVEOS(n *nativeNode) {
+	n.applyVrnetlabDefaults()
+
+	n.mountStartupConfig("/config/startup-config.cfg", false)
+
+	n.defaultResourceRequests("1", "3Gi")
+
+	n.container.Args = []string{
+		"--username",
+		"admin",
+		"--password",
+		"admin",
+		"--hostname",
+		n.nodeName,
+		"--connection-mode",
+		n.env("CONNECTION_MODE"),
+		"--trace",
+	}
+}
+
+// applyFortinetFortiGate replicates the containerlab fortigate (vrnetlab) kind driver: the
+// startup-config and license are mounted where vrnetlab picks them up -- the license is uploaded
+// to the vm on first boot, the startup-config applied once the vm is up -- and vrnetlab is given
+// the credentials, hostname and connection mode containerlab gives it. Ssh (22) and https (443)
+// management are proxied to port1 of the vm by vrnetlab, both are part of the default (auto)
+// exposed ports. The vm wants 2GB of memory (plus qemu overhead) and a cpu, those are requested
+// unless the node sets its own.
+func applyFortinetFortiGate(n *nativeNode) {
+	n.applyVrnetlabDefaults()
+
+	n.mountStartupConfig("/config/startup-config.cfg", false)
+	n.defaultResourceRequests("1", "3Gi")
+
+	n.container.Args = []string{
+		"--username",
+		"admin",
+		"--password",
+		"admin",
+		"--hostname",
+		n.nodeName,
+		"--connection-mode",
+		n.env("CONNECTION_MODE"),
+		"--trace",
+	}
+}
+
+// applyPaloAltoPANOS replicates the containerlab pan-os (vrnetlab) kind driver: the startup-config
+// is mounted where vrnetlab picks it up and applies it once the vm is up, and vrnetlab is given the
+// credentials, hostname and connection mode containerlab gives it. Bootstrap package files of the
+// node (init-cfg.txt, bootstrap.xml and authcodes, by base name) are mounted in the bootstrap
+// package layout under /config/bootstrap, for images that build the bootstrap cdrom from there.
+// The management plane (ssh on 22, https on 443) is proxied to the vm by vrnetlab, both are part
+// of the default (auto) exposed ports. Pan-os is big and slow: the vm wants 6GB of memory and a
+// couple cpus, those are requested unless the node sets its own, and the kind gets a longer default
+// startup probe time.
+func applyPaloAltoPANOS(n *nativeNode) {
+	n.applyVrnetlabDefaults()
+
+	n.mountStartupConfig("/config/startup-config.cfg", false)
+
+	for _, filePath := range n.nodeFilePaths() {
+		bootstrapPath, ok := panosBootstrapFiles[filepath.Base(filePath)]
+		if !ok {
+			continue
+		}
+
+		n.mountNodeFile(filePath, "/config/bootstrap/"+bootstrapPath)
+	}
+
+	n.defaultResourceRequests("2", "7Gi")
+
+	n.container.Args = []string{
+		"--username",
+		"admin",
+		"--password",
+		"Admin@123",
+		"--hostname",
+		n.nodeName,
+		"--connection-mode",
+		n.env("CONNECTION_MODE"),
+		"--trace",
+	}
+}
+
+// applyMikroTikROS replicates the containerlab mikrotik ros (chr, vrnetlab) kind driver: the
+// startup-config is mounted as the config.auto.rsc the vm fetches (and runs) over tftp on boot, and
+// vrnetlab is given the credentials, hostname and connection mode containerlab gives it. Ssh (22)
+// and the api (8728/8729) are proxied to ether1 of the vm by vrnetlab. Chr is small, it is happy
+// with half a cpu and 256MB of memory (plus qemu overhead), those are requested unless the node
+// sets its own.
+func applyMikroTikROS(n *nativeNode) {
+	n.applyVrnetlabDefaults()
+
+	n.mountStartupConfig("/ftpboot/config.auto.rsc", false)
+
+	n.defaultResourceRequests("500m", "512Mi")
+
+	n.container.Args = []string{
+		"--username",
+		"admin",
+		"--password",
+		"admin",
+		"--hostname",
+		n.nodeName,
+		"--connection-mode",
+		n.env("CONNECTION_MODE"),
+		"--trace",
+	}
+}
+
+// applySONiCVS replicates the containerlab sonic-vs kind driver: the startup-config is the sonic
+// config db, it is copied into place (/etc/sonic/config_db.json) before supervisord starts the
+// sonic services as sonic writes it back on "config save" so it can not be mounted read only. The
+// nos container runs privileged (see renderDeploymentNativePrivileges) as the sonic services (and
+// the virtual asic) need it.
+func applySONiCVS(n *nativeNode) {
+	startupConfigPath := nativeStagingPath + "/startup-config"
+
+	startupConfig := strings.TrimSpace(n.nodeDefinition.StartupConfig)
+	if startupConfig != "" {
+		n.addEmptyDir(nativeStagingVolumeName, nativeStagingPath, "")
+
+		if !n.mountNodeFile(startupConfig, startupConfigPath) {
+			n.log.Warnf(
+				"node %q startup-config %q not found in files from config map, booting without it",
+				n.nodeName,
+				startupConfig,
+			)
+		}
+	}
+
+	n.container.Command = []string{"bash", "-c", strings.TrimSpace(fmt.Sprintf(`
+startup_config="%[1]s"
+if [ -f "$startup_config" ]; then
+  mkdir -p /etc/sonic
+  cp "$startup_config" /etc/sonic/config_db.json
+fi
+
+exec /usr/local/bin/supervisord
+`, startupConfigPath))}
+}
+
+// applyJuniperCRPD replicates the containerlab crpd kind driver: crpd is a routing daemon in a
+// container, not a vm, that keeps its config in /config and its logs in /var/log -- both get an
+// empty dir so commits survive container restarts. The startup-config is copied to
+// /config/juniper.conf (crpd writes it back on commit, so it can not be mounted read only) unless
+// the node already has a config from a previous boot, the license is copied to
+// /config/license.conf and added once the cli is up, as containerlab does post deploy. Crpd needs
+// the capabilities it is registered with (see renderDeploymentNativePrivileges) to program the
+// kernel.
+func applyJuniperCRPD(n *nativeNode) {
+	n.addEmptyDir("crpd-config", "/config", "")
+	n.addEmptyDir("crpd-varlog", "/var/log", "")
+
+	startupConfigPath := nativeStagingPath + "/startup-config"
+	licensePath := nativeStagingPath + "/license"
+
+	startupConfig := strings.TrimSpace(n.nodeDefinition.StartupConfig)
+	license := n.topology.GetNodeLicense(n.nodeName)
+
+	if startupConfig != "" || license != "" {
+		n.addEmptyDir(nativeStagingVolumeName, nativeStagingPath, "")
+	}
+
+	n.mountStartupConfig(startupConfigPath, false)
+	n.mountLicense(licensePath)
+
+	n.defaultResourceRequests("500m", "1Gi")
+
+	n.container.Command = []string{"bash", "-c", strings.TrimSpace(fmt.Sprintf(`
+startup_config="%[1]s"
+if [ -f "$startup_config" ] && ! ls /config/juniper.conf* >/dev/null 2>&1; then
+  cp "$startup_config" /config/juniper.conf
+fi
+
+license="%[2]s"
+if [ -f "$license" ]; then
+  cp "$license" /config/license.conf
+  (
+    until cli -c "request system license add /config/license.conf" >/dev/null 2>&1; do
+      sleep 5
+    done
+  ) &
+fi
+
+exec /sbin/runit-init.sh
+`, startupConfigPath, licensePath))}
+}
+
+// applyCumulusCVX replicates the containerlab cvx kind driver: cumulus vx boots systemd, so the
+// nos container runs privileged (see renderDeploymentNativePrivileges) and gets memory backed
+// /run and /run/lock. The startup-config is copied into place before systemd starts -- nvue
+// ("- set:" yaml) configs to /etc/nvue.d/startup.yaml, anything else is taken to be an ifupdown2
+// interfaces file. Without a startup-config an interfaces file bringing up the interfaces of the
+// node is rendered (see RenderCVXInterfaces). Either way eth0 is the pod interface the launcher
+// shares, it must not be moved into the management vrf (as the cumulus default does) or the
+// launcher loses its connectivity.
+func applyCumulusCVX(n *nativeNode) {
+	n.addEmptyDir("cvx-run", "/run", k8scorev1.StorageMediumMemory)
+	n.addEmptyDir("cvx-run-lock", "/run/lock", k8scorev1.StorageMediumMemory)
+
+	startupConfigPath := nativeStagingPath + "/startup-config"
+
+	if strings.TrimSpace(n.nodeDefinition.StartupConfig) != "" {
+		n.addEmptyDir(nativeStagingVolumeName, nativeStagingPath, "")
+	}
+
+	n.mountStartupConfig(startupConfigPath, false)
+
+	n.defaultResourceRequests("500m", "1Gi")
+
+	n.container.Command = []string{"bash", "-c", strings.TrimSpace(fmt.Sprintf(`
+startup_config="%[1]s"
+if [ -f "$startup_config" ]; then
+  if grep -q '^- *set:' "$startup_config"; then
+    mkdir -p /etc/nvue.d
+    cp "$startup_config" /etc/nvue.d/startup.yaml
+  else
+    cp "$startup_config" /etc/network/interfaces
+  fi
+else
+  cat > /etc/network/interfaces <<'CLABERNETES_EOF'
+%[2]s
+CLABERNETES_EOF
+fi
+
+exec /sbin/init
+`, startupConfigPath, RenderCVXInterfaces(n.nodeName, n.topology)))}
+}
+
+// RenderCVXInterfaces renders the (ifupdown2) interfaces file cumulus vx nodes boot with when they
+// have no startup-config: the loopback and the (swp) interfaces the node is linked with are
+// brought up, eth0 -- the pod interface -- is left alone.
+func RenderCVXInterfaces(
+	nodeName string,
+	topology *clabernetesutilcontainerlab.Topology,
+) string {
+	n := &nativeNode{nodeName: nodeName, topology: topology}
+
+	interfaceNames := n.interfaces()
+
+	slices.Sort(interfaceNames)
+
+	var rendered strings.Builder
+
+	rendered.WriteString("# rendered by clabernetes, eth0 is the pod interface, leave it be\n")
+	rendered.WriteString("auto lo\niface lo inet loopback\n")
+
+	for _, interfaceName := range slices.Compact(interfaceNames) {
+		fmt.Fprintf(&rendered, "\nauto %[1]s\niface %[1]s\n", interfaceName)
+	}
+
+	return strings.TrimSpace(rendered.String())
+}
+
+// renderDeploymentNativePrivileges sets the nos container of native mode nodes of kinds that need
+// it privileged, and adds the capabilities the nos containers of kinds that need extra ones are
+// missing (see resolveNativeKindPrivileges). This runs after the container privileges are rendered
+// as those replace the container security contexts wholesale, and does nothing under sysbox (which
+// does not allow privileged containers, but gives the container all capabilities in its user
+// namespace).
+func (r *DeploymentReconciler) renderDeploymentNativePrivileges(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) {
+	if !ResolveNativeMode(owningTopology) ||
+		r.getRuntimeSandbox(deployment) == runtimeSandboxSysbox {
+		return
+	}
+
+	nodeConfig, ok := clabernetesConfigs[nodeName]
+	if !ok {
+		return
+	}
+
+	nodeKind, _ := nodeConfig.Topology.GetNodeKindType(nodeName)
+
+	privileged, capabilities := resolveNativeKindPrivileges(
+		nodeKind,
+		r.configManagerGetter().IsFeatureGateEnabled(
+			clabernetesconstants.FeatureGateNativeKindDrivers,
+		),
+		r.configManagerGetter().GetNativeKinds(),
+	)
+
+	if !privileged && len(capabilities) == 0 {
+		return
+	}
+
+	for i := range deployment.Spec.Template.Spec.Containers {
+		container := &deployment.Spec.Template.Spec.Containers[i]
+
+		if container.Name != nodeName {
+			continue
+		}
+
+		if container.SecurityContext == nil {
+			container.SecurityContext = &k8scorev1.SecurityContext{}
+		}
+
+		if privileged {
+			container.SecurityContext.Privileged = clabernetesutil.ToPointer(true)
+		}
+
+		if container.SecurityContext.Privileged != nil && *container.SecurityContext.Privileged {
+			// privileged containers have every capability already
+			continue
+		}
+
+		if container.SecurityContext.Capabilities == nil {
+			container.SecurityContext.Capabilities = &k8scorev1.Capabilities{}
+		}
+
+		for _, capability := range capabilities {
+			if !slices.Contains(container.SecurityContext.Capabilities.Add, capability) {
+				container.SecurityContext.Capabilities.Add = append(
+					container.SecurityContext.Capabilities.Add,
+					capability,
+				)
+			}
+		}
+	}
+}
+
+// RenderCEOSIntfMapping renders the cEOS-lab interface mapping file (EosIntfMapping.json) for the
+// given node from the links of its sub-topology: each (linux) interface the node is linked with
+// maps to the eos front panel interface its name stands for ("et1" -> "Ethernet1", "Ethernet1/2"
+// -> "Ethernet1/2"), eth0 is the management interface. The linux names are the (sanitized) names
+// the launcher creates the interfaces with. Returns false if there are no interfaces to map.
+func RenderCEOSIntfMapping(
+	nodeName string,
+	topology *clabernetesutilcontainerlab.Topology,
+) (string, bool) {
+	mapping := ceosIntfMapping{
+		ManagementIntf: map[string]string{"eth0": "Management0"},
+		EthernetIntf:   map[string]string{},
+	}
+
+	n := &nativeNode{nodeName: nodeName, topology: topology}
+
+	for _, interfaceName := range n.interfaces() {
+		match := ceosInterfacePattern.FindStringSubmatch(strings.TrimSpace(interfaceName))
+		if match == nil || (match[1] == "0" && match[2] == "") {
+			continue
+		}
+
+		eosInterfaceName := "Ethernet" + match[1]
+
+		for _, lane := range match[2:] {
+			if lane != "" {
+				eosInterfaceName += "/" + lane
+			}
+		}
+
+		mapping.EthernetIntf[sanitizeLinuxIfName(interfaceName)] = eosInterfaceName
+	}
+
+	if len(mapping.EthernetIntf) == 0 {
+		return "", false
+	}
+
+	renderedMapping, err := json.Marshal(mapping)
+	if err != nil {
+		return "", false
+	}
+
+	return string(renderedMapping), true
+}
+
+// defaultResourceRequests sets the given cpu and memory requests on the nos container for each of
+// the two that has neither a request nor a limit set yet.
+func (n *nativeNode) defaultResourceRequests(cpu, memory string) {
+	for resourceName, quantity := range map[k8scorev1.ResourceName]string{
+		k8scorev1.ResourceCPU:    cpu,
+		k8scorev1.ResourceMemory: memory,
+	} {
+		_, hasRequest := n.container.Resources.Requests[resourceName]
+		_, hasLimit := n.container.Resources.Limits[resourceName]
+
+		if hasRequest || hasLimit {
+			continue
+		}
+
+		if n.container.Resources.Requests == nil {
+			n.container.Resources.Requests = k8scorev1.ResourceList{}
+		}
+
+		n.container.Resources.Requests[resourceName] = resource.MustParse(quantity)
+	}
+}
+
+// applyHugepages copies any hugepages resources of the launcher of the node to the nos container,
+// mounts a hugepages backed empty dir at /dev/hugepages, and tells qemu (via the vrnetlab qemu
+// additional args) to back the vm memory with it. Nothing is done if the launcher resources do not
+// ask for hugepages, kubernetes only hands out hugepages to containers that ask for them.
+func (n *nativeNode) applyHugepages() {
+	if n.resources == nil {
+		return
+	}
+
+	var hasHugepages bool
+
+	for resourceName, quantity := range n.resources.Limits {
+		if !strings.HasPrefix(string(resourceName), k8scorev1.ResourceHugePagesPrefix) {
+			continue
+		}
+
+		hasHugepages = true
+
+		if n.container.Resources.Limits == nil {
+			n.container.Resources.Limits = k8scorev1.ResourceList{}
+		}
+
+		if n.container.Resources.Requests == nil {
+			n.container.Resources.Requests = k8scorev1.ResourceList{}
+		}
+
+		// hugepages requests must equal the limits
+		n.container.Resources.Limits[resourceName] = quantity
+		n.container.Resources.Requests[resourceName] = quantity
+	}
+
+	if !hasHugepages {
+		return
+	}
+
+	n.addEmptyDir("hugepages", "/dev/hugepages", k8scorev1.StorageMediumHugePages)
+
+	qemuArgs := n.env(clabernetesconstants.QEMUAdditionalArgsEnv)
+	if strings.Contains(qemuArgs, "-mem-path") {
+		// user brought their own memory backend, leave it be
+		return
+	}
+
+	n.upsertEnv(
+		clabernetesconstants.QEMUAdditionalArgsEnv,
+		strings.TrimSpace(qemuArgs+" -mem-path /dev/hugepages"),
+	)
+}
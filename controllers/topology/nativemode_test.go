@@ -0,0 +1,400 @@
+package topology_test
+
+import (
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+func TestResolveNativeInterfaceName(t *testing.T) {
+	cases := []struct {
+		name          string
+		kind          string
+		interfaceName string
+		nativeKinds   map[string]clabernetesapisv1alpha1.ConfigNativeKind
+		expected      string
+	}{
+		{
+			name:          "srl-alias",
+			kind:          "srl",
+			interfaceName: "ethernet-1/1",
+			expected:      "e1-1",
+		},
+		{
+			name:          "srl-alias-breakout",
+			kind:          "nokia_srlinux",
+			interfaceName: "ethernet-1/3/2",
+			expected:      "e1-3-2",
+		},
+		{
+			name:          "srl-linux-name",
+			kind:          "srl",
+			interfaceName: "e1-10",
+			expected:      "e1-10",
+		},
+		{
+			name:          "sros-alias",
+			kind:          "nokia_sros",
+			interfaceName: "1/1/3",
+			expected:      "eth3",
+		},
+		{
+			name:          "sros-alias-connector",
+			kind:          "vr-sros",
+			interfaceName: "1/1/c12/1",
+			expected:      "eth12",
+		},
+		{
+			name:          "sros-other-slot",
+			kind:          "vr-sros",
+			interfaceName: "1/2/1",
+			expected:      "1/2/1",
+		},
+		{
+			name:          "vjunos-alias",
+			kind:          "juniper_vjunosswitch",
+			interfaceName: "ge-0/0/0",
+			expected:      "eth1",
+		},
+		{
+			name:          "vjunos-evolved-alias",
+			kind:          "juniper_vjunosevolved",
+			interfaceName: "et-0/0/11",
+			expected:      "eth12",
+		},
+		{
+			name:          "xrd-alias",
+			kind:          "cisco_xrd",
+			interfaceName: "Gi0/0/0/2",
+			expected:      "Gi0-0-0-2",
+		},
+		{
+			name:          "xrd-long-alias",
+			kind:          "xrd",
+			interfaceName: "GigabitEthernet0/0/0/0",
+			expected:      "Gi0-0-0-0",
+		},
+		{
+			name:          "xrd-linux-name",
+			kind:          "cisco_xrd",
+			interfaceName: "Gi0-0-0-1",
+			expected:      "Gi0-0-0-1",
+		},
+		{
+			name:          "csr-alias",
+			kind:          "cisco_csr1000v",
+			interfaceName: "Gi2",
+			expected:      "eth1",
+		},
+		{
+			name:          "c8000v-alias",
+			kind:          "cisco_c8000v",
+			interfaceName: "GigabitEthernet5",
+			expected:      "eth4",
+		},
+		{
+			name:          "csr-management-interface",
+			kind:          "vr-csr",
+			interfaceName: "Gi1",
+			expected:      "Gi1",
+		},
+		{
+			name:          "n9kv-alias",
+			kind:          "cisco_n9kv",
+			interfaceName: "Ethernet1/3",
+			expected:      "eth3",
+		},
+		{
+			name:          "n9kv-short-alias",
+			kind:          "vr-n9kv",
+			interfaceName: "Eth1/12",
+			expected:      "eth12",
+		},
+		{
+			name:          "veos-alias",
+			kind:          "arista_veos",
+			interfaceName: "Ethernet2",
+			expected:      "eth2",
+		},
+		{
+			name:          "veos-short-alias",
+			kind:          "vr-veos",
+			interfaceName: "Et7",
+			expected:      "eth7",
+		},
+		{
+			name:          "sonic-alias",
+			kind:          "sonic-vs",
+			interfaceName: "Ethernet0",
+			expected:      "eth1",
+		},
+		{
+			name:          "sonic-alias-lanes",
+			kind:          "sonic-vs",
+			interfaceName: "Ethernet12",
+			expected:      "eth4",
+		},
+		{
+			name:          "sonic-alias-not-a-port",
+			kind:          "sonic-vs",
+			interfaceName: "Ethernet2",
+			expected:      "Ethernet2",
+		},
+		{
+			name:          "fortigate-alias",
+			kind:          "fortinet_fortigate",
+			interfaceName: "port3",
+			expected:      "eth2",
+		},
+		{
+			name:          "fortigate-management-interface",
+			kind:          "fortinet_fortigate",
+			interfaceName: "port1",
+			expected:      "port1",
+		},
+		{
+			name:          "panos-alias",
+			kind:          "paloalto_panos",
+			interfaceName: "ethernet1/4",
+			expected:      "eth4",
+		},
+		{
+			name:          "mikrotik-alias",
+			kind:          "vr-ros",
+			interfaceName: "ether3",
+			expected:      "eth2",
+		},
+		{
+			name:          "mikrotik-management-interface",
+			kind:          "mikrotik_ros",
+			interfaceName: "ether1",
+			expected:      "ether1",
+		},
+		{
+			name:          "crpd-linux-name",
+			kind:          "juniper_crpd",
+			interfaceName: "eth1",
+			expected:      "eth1",
+		},
+		{
+			name:          "other-kind",
+			kind:          "ceos",
+			interfaceName: "ethernet-1/1",
+			expected:      "ethernet-1/1",
+		},
+		{
+			name:          "config-kind-alias",
+			kind:          "VR-FTOSV",
+			interfaceName: "ethernet1/1/3",
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"vr-ftosv": {
+					InterfaceAliasPattern: `^ethernet1/1/(\d+)$`,
+				},
+			},
+			expected: "eth3",
+		},
+		{
+			name:          "config-kind-alias-offset",
+			kind:          "juniper_vsrx",
+			interfaceName: "ge-0/0/0",
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"juniper_vsrx": {
+					InterfaceAliasPattern: `^ge-0/0/(\d+)$`,
+					InterfaceOffset:       1,
+				},
+			},
+			expected: "eth1",
+		},
+		{
+			name:          "config-kind-alias-management",
+			kind:          "juniper_vsrx",
+			interfaceName: "fxp0",
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"juniper_vsrx": {
+					InterfaceAliasPattern: `^fxp(\d+)$`,
+				},
+			},
+			expected: "fxp0",
+		},
+		{
+			name:          "config-kind-overrides-builtin",
+			kind:          "srl",
+			interfaceName: "ethernet-1/1",
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"srl": {
+					InterfaceAliasPattern: `^ethernet-1/(\d+)$`,
+				},
+			},
+			expected: "eth1",
+		},
+		{
+			name:          "config-kind-no-match-falls-back-to-builtin",
+			kind:          "srl",
+			interfaceName: "ethernet-1/3/2",
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"srl": {
+					InterfaceAliasPattern: `^ethernet-1/(\d+)$`,
+				},
+			},
+			expected: "e1-3-2",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveNativeInterfaceName(
+					testCase.kind,
+					testCase.interfaceName,
+					testCase.nativeKinds,
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestRenderCEOSIntfMapping(t *testing.T) {
+	cases := []struct {
+		name       string
+		links      []*clabernetesutilcontainerlab.LinkDefinition
+		expected   string
+		expectedOk bool
+	}{
+		{
+			name:       "no-links",
+			links:      nil,
+			expected:   "",
+			expectedOk: false,
+		},
+		{
+			name: "netlab-names",
+			links: []*clabernetesutilcontainerlab.LinkDefinition{
+				{
+					LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+						Endpoints: []string{"ceos1:et1", "ceos2:et1"},
+					},
+				},
+				{
+					LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+						Endpoints: []string{"ceos1:et2", "ceos3:et1"},
+					},
+				},
+			},
+			expected: `{"ManagementIntf":{"eth0":"Management0"},` +
+				`"EthernetIntf":{"et1":"Ethernet1","et2":"Ethernet2"}}`,
+			expectedOk: true,
+		},
+		{
+			name: "modular-names",
+			links: []*clabernetesutilcontainerlab.LinkDefinition{
+				{
+					LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+						Endpoints: []string{"ceos1:Ethernet1/2", "ceos2:eth1"},
+					},
+				},
+				{
+					LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+						Endpoints: []string{"ceos2:eth2", "ceos1:eth3_1"},
+					},
+				},
+			},
+			expected: `{"ManagementIntf":{"eth0":"Management0"},` +
+				`"EthernetIntf":{"Ethernet1-2":"Ethernet1/2","eth3_1":"Ethernet3/1"}}`,
+			expectedOk: true,
+		},
+		{
+			name: "unmappable-names",
+			links: []*clabernetesutilcontainerlab.LinkDefinition{
+				{
+					LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+						Endpoints: []string{"ceos1:foo", "ceos2:eth1"},
+					},
+				},
+			},
+			expected:   "",
+			expectedOk: false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual, actualOk := clabernetescontrollerstopology.RenderCEOSIntfMapping(
+					"ceos1",
+					&clabernetesutilcontainerlab.Topology{
+						Links: testCase.links,
+					},
+				)
+				if actualOk != testCase.expectedOk {
+					clabernetestesthelper.FailOutput(t, actualOk, testCase.expectedOk)
+				}
+
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestRenderCVXInterfaces(t *testing.T) {
+	cases := []struct {
+		name     string
+		links    []*clabernetesutilcontainerlab.LinkDefinition
+		expected string
+	}{
+		{
+			name:  "no-links",
+			links: nil,
+			expected: "# rendered by clabernetes, eth0 is the pod interface, leave it be\n" +
+				"auto lo\niface lo inet loopback",
+		},
+		{
+			name: "links",
+			links: []*clabernetesutilcontainerlab.LinkDefinition{
+				{
+					LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+						Endpoints: []string{"cvx1:swp2", "cvx2:swp1"},
+					},
+				},
+				{
+					LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+						Endpoints: []string{"cvx2:swp2", "cvx1:swp1"},
+					},
+				},
+			},
+			expected: "# rendered by clabernetes, eth0 is the pod interface, leave it be\n" +
+				"auto lo\niface lo inet loopback\n\n" +
+				"auto swp1\niface swp1\n\n" +
+				"auto swp2\niface swp2",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.RenderCVXInterfaces(
+					"cvx1",
+					&clabernetesutilcontainerlab.Topology{
+						Links: testCase.links,
+					},
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
@@ -0,0 +1,150 @@
+package topology
+
+import (
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// nativeKindSizing is the cpu and memory the nos container of a kind requests by default.
+type nativeKindSizing struct {
+	cpu    string
+	memory string
+}
+
+// nativeKindSizings holds the built-in cpu/memory requests of the nos container per (lowercase)
+// containerlab kind -- roughly what the nos (or its vm, plus qemu overhead) needs to boot and run
+// a handful of protocols. Kinds whose built-in native mode driver sizes the nos container itself
+// (csr, n9kv, veos, fortigate, pan-os and ros) are not listed here.
+var nativeKindSizings = map[string]nativeKindSizing{ //nolint:gochecknoglobals
+	"srl":                   {cpu: "1", memory: "2Gi"},
+	"nokia_srlinux":         {cpu: "1", memory: "2Gi"},
+	"ceos":                  {cpu: "1", memory: "2Gi"},
+	"arista_ceos":           {cpu: "1", memory: "2Gi"},
+	"crpd":                  {cpu: "500m", memory: "1Gi"},
+	"juniper_crpd":          {cpu: "500m", memory: "1Gi"},
+	"xrd":                   {cpu: "1", memory: "2Gi"},
+	"cisco_xrd":             {cpu: "1", memory: "2Gi"},
+	"sonic-vs":              {cpu: "1", memory: "2Gi"},
+	"cvx":                   {cpu: "500m", memory: "1Gi"},
+	"iol":                   {cpu: "500m", memory: "1Gi"},
+	"cisco_iol":             {cpu: "500m", memory: "1Gi"},
+	"vios":                  {cpu: "1", memory: "1Gi"},
+	"vr-vios":               {cpu: "1", memory: "1Gi"},
+	"cisco_vios":            {cpu: "1", memory: "1Gi"},
+	"vr-sros":               {cpu: "1", memory: "4Gi"},
+	"vr-nokia_sros":         {cpu: "1", memory: "4Gi"},
+	"nokia_sros":            {cpu: "1", memory: "4Gi"},
+	"vr-vmx":                {cpu: "2", memory: "6Gi"},
+	"vr-juniper_vmx":        {cpu: "2", memory: "6Gi"},
+	"juniper_vmx":           {cpu: "2", memory: "6Gi"},
+	"vr-vqfx":               {cpu: "2", memory: "4Gi"},
+	"vr-juniper_vqfx":       {cpu: "2", memory: "4Gi"},
+	"juniper_vqfx":          {cpu: "2", memory: "4Gi"},
+	"juniper_vjunosswitch":  {cpu: "2", memory: "5Gi"},
+	"juniper_vjunosrouter":  {cpu: "2", memory: "5Gi"},
+	"juniper_vjunosevolved": {cpu: "4", memory: "8Gi"},
+	"vr-xrv9k":              {cpu: "2", memory: "16Gi"},
+	"vr-cisco_xrv9k":        {cpu: "2", memory: "16Gi"},
+	"cisco_xrv9k":           {cpu: "2", memory: "16Gi"},
+}
+
+// ResolveNativeKindResources returns the default resources of the nos container of native mode
+// nodes of the given kind -- the resources of the native kind settings of the global config if
+// they set any, otherwise the built-in cpu/memory requests of the kind. Returns nil if there are
+// neither.
+func ResolveNativeKindResources(
+	kind string,
+	nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind,
+) *k8scorev1.ResourceRequirements {
+	nativeKind, ok := lookupConfigNativeKind(nativeKinds, kind)
+	if ok && nativeKind.Resources != nil {
+		return nativeKind.Resources.DeepCopy()
+	}
+
+	sizing, ok := nativeKindSizings[normalizeNativeKind(kind)]
+	if !ok {
+		return nil
+	}
+
+	return &k8scorev1.ResourceRequirements{
+		Requests: k8scorev1.ResourceList{
+			k8scorev1.ResourceCPU:    resource.MustParse(sizing.cpu),
+			k8scorev1.ResourceMemory: resource.MustParse(sizing.memory),
+		},
+	}
+}
+
+// renderDeploymentNativeKindResources gives the nos container of native mode nodes the default
+// resources of their kind (see ResolveNativeKindResources) when neither the topology nor the
+// resourcesByContainerlabKind of the global config set resources for the node -- the launcher
+// resources only ever apply to the launcher container, without this qemu heavy nodes are scheduled
+// as if they need nothing at all. Resources of the global config replace whatever the nos container
+// has, the built-in requests only fill in cpu/memory the container does not request or limit yet
+// (as the built-in driver of the kind may have sized it already).
+func (r *DeploymentReconciler) renderDeploymentNativeKindResources(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) {
+	if !ResolveNativeMode(owningTopology) {
+		return
+	}
+
+	_, nodeResourcesOk := owningTopology.Spec.Deployment.Resources[nodeName]
+	_, defaultResourcesOk := owningTopology.Spec.Deployment.Resources[clabernetesconstants.Default]
+
+	if nodeResourcesOk || defaultResourcesOk {
+		return
+	}
+
+	nodeKind, _ := clabernetesConfigs[nodeName].Topology.GetNodeKindType(nodeName)
+
+	if r.configManagerGetter().HasResourcesForContainerlabKind(nodeKind) {
+		return
+	}
+
+	nativeKinds := r.configManagerGetter().GetNativeKinds()
+
+	resources := ResolveNativeKindResources(nodeKind, nativeKinds)
+	if resources == nil {
+		return
+	}
+
+	// resources of the global config are taken as is, the built-in ones only fill in the gaps
+	nativeKind, ok := lookupConfigNativeKind(nativeKinds, nodeKind)
+	replace := ok && nativeKind.Resources != nil
+
+	for i := range deployment.Spec.Template.Spec.Containers {
+		container := &deployment.Spec.Template.Spec.Containers[i]
+
+		if container.Name != nodeName {
+			continue
+		}
+
+		if replace {
+			container.Resources = *resources
+
+			continue
+		}
+
+		for resourceName, quantity := range resources.Requests {
+			_, hasRequest := container.Resources.Requests[resourceName]
+			_, hasLimit := container.Resources.Limits[resourceName]
+
+			if hasRequest || hasLimit {
+				continue
+			}
+
+			if container.Resources.Requests == nil {
+				container.Resources.Requests = k8scorev1.ResourceList{}
+			}
+
+			container.Resources.Requests[resourceName] = quantity
+		}
+	}
+}
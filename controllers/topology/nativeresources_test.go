@@ -0,0 +1,96 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	k8scorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestResolveNativeKindResources(t *testing.T) {
+	cases := []struct {
+		name        string
+		kind        string
+		nativeKinds map[string]clabernetesapisv1alpha1.ConfigNativeKind
+		expected    *k8scorev1.ResourceRequirements
+	}{
+		{
+			name: "builtin-kind",
+			kind: "ceos",
+			expected: &k8scorev1.ResourceRequirements{
+				Requests: k8scorev1.ResourceList{
+					k8scorev1.ResourceCPU:    resource.MustParse("1"),
+					k8scorev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		},
+		{
+			name: "builtin-kind-alias-mixed-case",
+			kind: "Cisco_IOL",
+			expected: &k8scorev1.ResourceRequirements{
+				Requests: k8scorev1.ResourceList{
+					k8scorev1.ResourceCPU:    resource.MustParse("500m"),
+					k8scorev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		},
+		{
+			name:     "unknown-kind",
+			kind:     "linux",
+			expected: nil,
+		},
+		{
+			name: "config-kind-overrides-builtin",
+			kind: "vr-vmx",
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"vr-vmx": {
+					Resources: &k8scorev1.ResourceRequirements{
+						Limits: k8scorev1.ResourceList{
+							k8scorev1.ResourceMemory: resource.MustParse("12Gi"),
+						},
+					},
+				},
+			},
+			expected: &k8scorev1.ResourceRequirements{
+				Limits: k8scorev1.ResourceList{
+					k8scorev1.ResourceMemory: resource.MustParse("12Gi"),
+				},
+			},
+		},
+		{
+			name: "config-kind-without-resources",
+			kind: "vr-vmx",
+			nativeKinds: map[string]clabernetesapisv1alpha1.ConfigNativeKind{
+				"vr-vmx": {
+					Vrnetlab: true,
+				},
+			},
+			expected: &k8scorev1.ResourceRequirements{
+				Requests: k8scorev1.ResourceList{
+					k8scorev1.ResourceCPU:    resource.MustParse("2"),
+					k8scorev1.ResourceMemory: resource.MustParse("6Gi"),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveNativeKindResources(
+					testCase.kind,
+					testCase.nativeKinds,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
-	"strconv"
+	"slices"
 	"strings"
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
@@ -29,22 +29,88 @@ func bridgeNameForNAD(nadName string) string {
 	return "br-" + hex.EncodeToString(sum[:])[:11] // 14 chars total
 }
 
-func linkSubnetForNAD(nadName string) string {
-	// Try to derive a deterministic /24 per link index to avoid duplicate IPs
-	// across multiple interfaces in the same pod.
-	//
-	// NAD names are expected to end with "-l<index>".
-	i := strings.LastIndex(nadName, "-l")
-	if i >= 0 && i+2 < len(nadName) {
-		if idx, err := strconv.Atoi(nadName[i+2:]); err == nil {
-			// 1..254 are safe /24 third-octets in 169.254.X.0/24
-			if idx >= 0 && idx <= 253 {
-				return fmt.Sprintf("169.254.%d.0/24", idx+1)
+// stableNADNameMarker separates the sub-topology name and the link hash in stable NAD names.
+const stableNADNameMarker = "-link-"
+
+// NetworkAttachmentDefinitionName returns the name of the NAD of the link at the given index of the
+// (sub-topology) config with the given name. Stable names are derived from the sorted endpoints of
+// the link ("<config>-link-<hash>") so they survive other links being added or removed, legacy
+// names are index based ("<config>-l<index>") and so shift whenever a link is inserted.
+func NetworkAttachmentDefinitionName(
+	configName string,
+	link *clabernetesutilcontainerlab.LinkDefinition,
+	idx int,
+	stable bool,
+) string {
+	if !stable || link == nil {
+		return fmt.Sprintf("%s-l%d", configName, idx)
+	}
+
+	endpoints := slices.Clone(link.Endpoints)
+	slices.Sort(endpoints)
+
+	sum := sha1.Sum([]byte(strings.Join(endpoints, ","))) //nolint:gosec // non-crypto identifier
+
+	return configName + stableNADNameMarker + hex.EncodeToString(sum[:])[:10]
+}
+
+const (
+	// stableLinkSubnets is the number of /28s in 169.254.1.0-169.254.254.255.
+	stableLinkSubnets = 4064
+	// legacyLinkSubnets is the number of /24s in 169.254.1.0-169.254.254.255.
+	legacyLinkSubnets = 254
+	// fallbackLinkSubnet is a wide link-local range (still isolated per bridge) for links that do
+	// not fit in the per link subnets.
+	fallbackLinkSubnet = "169.254.0.0/16"
+)
+
+// NetworkAttachmentDefinitionSubnets returns the ipam subnet of each NAD of the given
+// (sub-topology) configs, keyed by NAD name. The links of a config all end up as interfaces of the
+// same pod, so each of them gets its own subnet to avoid duplicate ips in the pod -- a /24 per link
+// index for legacy names, and a /28 per rank of the (sorted) stable names of the config otherwise.
+// Ranks shift as links are added or removed, but so do the networks of the pod, which then
+// restarts anyway.
+func NetworkAttachmentDefinitionSubnets(
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	stable bool,
+) map[string]string {
+	subnets := map[string]string{}
+
+	for _, nodeConfig := range clabernetesConfigs {
+		if nodeConfig == nil || nodeConfig.Topology == nil {
+			continue
+		}
+
+		var stableNADNames []string
+
+		for idx, link := range nodeConfig.Topology.Links {
+			nadName := NetworkAttachmentDefinitionName(nodeConfig.Name, link, idx, stable)
+
+			if !stable {
+				subnets[nadName] = fallbackLinkSubnet
+
+				if idx < legacyLinkSubnets {
+					subnets[nadName] = fmt.Sprintf("169.254.%d.0/24", idx+1)
+				}
+
+				continue
+			}
+
+			stableNADNames = append(stableNADNames, nadName)
+		}
+
+		slices.Sort(stableNADNames)
+
+		for rank, nadName := range slices.Compact(stableNADNames) {
+			subnets[nadName] = fallbackLinkSubnet
+
+			if rank < stableLinkSubnets {
+				subnets[nadName] = fmt.Sprintf("169.254.%d.%d/28", rank/16+1, rank%16*16)
 			}
 		}
 	}
-	// Fallback: a wide link-local range (still isolated per bridge).
-	return "169.254.0.0/16"
+
+	return subnets
 }
 
 // NetworkAttachmentDefinitionReconciler is a subcomponent of the "TopologyReconciler" but is
@@ -83,15 +149,18 @@ func (r *NetworkAttachmentDefinitionReconciler) Resolve(
 		nadDiffer.Current[ownedNADs.Items[i].GetName()] = &ownedNADs.Items[i]
 	}
 
-	// link names are topologyName-l<index>
-	// we need to find all unique links from all node configs
+	// we need to find all unique links from all node configs, NADs left over from the other
+	// naming scheme (when the stable names feature gate is flipped) show up as extra and are
+	// removed, the deployments are re-rendered with the new names at the same time
+	stableNames := r.configManagerGetter().IsFeatureGateEnabled(
+		clabernetesconstants.FeatureGateStableNetworkAttachmentNames,
+	)
+
 	allLinks := make(map[string]struct{})
 
 	for _, nodeConfig := range clabernetesConfigs {
-		topologyName := nodeConfig.Name
-
-		for idx := range nodeConfig.Topology.Links {
-			nadName := fmt.Sprintf("%s-l%d", topologyName, idx)
+		for idx, link := range nodeConfig.Topology.Links {
+			nadName := NetworkAttachmentDefinitionName(nodeConfig.Name, link, idx, stableNames)
 			allLinks[nadName] = struct{}{}
 		}
 	}
@@ -109,10 +178,24 @@ func (r *NetworkAttachmentDefinitionReconciler) Resolve(
 	return nadDiffer, nil
 }
 
+// ResolveSubnets returns the ipam subnet of each NAD of the given configs, keyed by NAD name, see
+// NetworkAttachmentDefinitionSubnets.
+func (r *NetworkAttachmentDefinitionReconciler) ResolveSubnets(
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) map[string]string {
+	return NetworkAttachmentDefinitionSubnets(
+		clabernetesConfigs,
+		r.configManagerGetter().IsFeatureGateEnabled(
+			clabernetesconstants.FeatureGateStableNetworkAttachmentNames,
+		),
+	)
+}
+
 // RenderAll returns a slice of rendered NADs for the given topology.
 func (r *NetworkAttachmentDefinitionReconciler) RenderAll(
 	owningTopology *clabernetesapisv1alpha1.Topology,
 	nadNames []string,
+	subnets map[string]string,
 ) []*unstructured.Unstructured {
 	nads := make([]*unstructured.Unstructured, len(nadNames))
 
@@ -120,16 +203,18 @@ func (r *NetworkAttachmentDefinitionReconciler) RenderAll(
 		nads[idx] = r.Render(
 			owningTopology,
 			nadName,
+			subnets[nadName],
 		)
 	}
 
 	return nads
 }
 
-// Render returns a rendered NAD for the given topology/link.
+// Render returns a rendered NAD for the given topology/link using the given ipam subnet.
 func (r *NetworkAttachmentDefinitionReconciler) Render(
 	owningTopology *clabernetesapisv1alpha1.Topology,
-	nadName string,
+	nadName,
+	subnet string,
 ) *unstructured.Unstructured {
 	owningTopologyName := owningTopology.GetName()
 
@@ -155,7 +240,11 @@ func (r *NetworkAttachmentDefinitionReconciler) Render(
 	// We use a short, deterministic bridge name to stay under the Linux 15-byte
 	// interface name limit.
 	bridgeName := bridgeNameForNAD(nadName)
-	subnet := linkSubnetForNAD(nadName)
+
+	if subnet == "" {
+		subnet = fallbackLinkSubnet
+	}
+
 	config := map[string]any{
 		"cniVersion": "0.3.1",
 		"name":       nadName,
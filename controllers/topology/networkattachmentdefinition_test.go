@@ -0,0 +1,216 @@
+package topology_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNetworkAttachmentDefinitionName(t *testing.T) {
+	link := &clabernetesutilcontainerlab.LinkDefinition{
+		LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+			Endpoints: []string{"srl1:e1-1", "host:srl2-e1-1"},
+		},
+	}
+
+	swappedLink := &clabernetesutilcontainerlab.LinkDefinition{
+		LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+			Endpoints: []string{"host:srl2-e1-1", "srl1:e1-1"},
+		},
+	}
+
+	stableName := clabernetescontrollerstopology.NetworkAttachmentDefinitionName(
+		"clabernetes-srl1",
+		link,
+		0,
+		true,
+	)
+
+	cases := []struct {
+		name     string
+		link     *clabernetesutilcontainerlab.LinkDefinition
+		idx      int
+		stable   bool
+		expected string
+	}{
+		{
+			name:     "legacy",
+			link:     link,
+			idx:      3,
+			stable:   false,
+			expected: "clabernetes-srl1-l3",
+		},
+		{
+			name:     "stable-ignores-index",
+			link:     link,
+			idx:      7,
+			stable:   true,
+			expected: stableName,
+		},
+		{
+			name:     "stable-ignores-endpoint-order",
+			link:     swappedLink,
+			idx:      1,
+			stable:   true,
+			expected: stableName,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.NetworkAttachmentDefinitionName(
+					"clabernetes-srl1",
+					testCase.link,
+					testCase.idx,
+					testCase.stable,
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+
+	otherLinkName := clabernetescontrollerstopology.NetworkAttachmentDefinitionName(
+		"clabernetes-srl1",
+		&clabernetesutilcontainerlab.LinkDefinition{
+			LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+				Endpoints: []string{"srl1:e1-2", "host:srl2-e1-2"},
+			},
+		},
+		0,
+		true,
+	)
+	if otherLinkName == stableName {
+		t.Fatalf("different links got the same stable name %q", stableName)
+	}
+}
+
+func TestNetworkAttachmentDefinitionSubnets(t *testing.T) {
+	links := make([]*clabernetesutilcontainerlab.LinkDefinition, 300)
+
+	for idx := range links {
+		links[idx] = &clabernetesutilcontainerlab.LinkDefinition{
+			LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+				Endpoints: []string{
+					fmt.Sprintf("srl1:e1-%d", idx+1),
+					fmt.Sprintf("host:srl1-e1-%d", idx+1),
+				},
+			},
+		}
+	}
+
+	configs := map[string]*clabernetesutilcontainerlab.Config{
+		"srl1": {
+			Name:     "clabernetes-srl1",
+			Topology: &clabernetesutilcontainerlab.Topology{Links: links},
+		},
+	}
+
+	for _, stable := range []bool{true, false} {
+		t.Run(
+			fmt.Sprintf("stable-%t", stable),
+			func(t *testing.T) {
+				subnets := clabernetescontrollerstopology.NetworkAttachmentDefinitionSubnets(
+					configs,
+					stable,
+				)
+
+				if len(subnets) != len(links) {
+					clabernetestesthelper.FailOutput(t, len(subnets), len(links))
+				}
+
+				seen := map[string]string{}
+
+				for nadName, subnet := range subnets {
+					// legacy names only have a /24 each for the first 254 links, the rest share
+					// the fallback range like they always did
+					if subnet == "169.254.0.0/16" && !stable {
+						continue
+					}
+
+					if otherNADName, ok := seen[subnet]; ok {
+						t.Fatalf(
+							"nads %q and %q share subnet %q",
+							nadName,
+							otherNADName,
+							subnet,
+						)
+					}
+
+					seen[subnet] = nadName
+				}
+
+				again := clabernetescontrollerstopology.NetworkAttachmentDefinitionSubnets(
+					configs,
+					stable,
+				)
+				if !reflect.DeepEqual(subnets, again) {
+					t.Fatal("expected subnets to be deterministic")
+				}
+			})
+	}
+}
+
+// TestNetworkAttachmentDefinitionResolveUpgrade ensures the nads of an already deployed topology
+// (named by link index, as before the stable names gate existed) are left alone with the default
+// feature gates, so upgrading does not rename them and restart the launchers.
+func TestNetworkAttachmentDefinitionResolveUpgrade(t *testing.T) {
+	configs := map[string]*clabernetesutilcontainerlab.Config{
+		"srl1": {
+			Name: "clabernetes-srl1",
+			Topology: &clabernetesutilcontainerlab.Topology{
+				Links: []*clabernetesutilcontainerlab.LinkDefinition{
+					{
+						LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+							Endpoints: []string{"srl1:e1-1", "host:srl1-e1-1"},
+						},
+					},
+					{
+						LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+							Endpoints: []string{"srl1:e1-2", "host:srl1-e1-2"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	deployedNADs := &unstructured.UnstructuredList{}
+
+	for _, nadName := range []string{"clabernetes-srl1-l0", "clabernetes-srl1-l1"} {
+		nad := unstructured.Unstructured{}
+		nad.SetName(nadName)
+
+		deployedNADs.Items = append(deployedNADs.Items, nad)
+	}
+
+	reconciler := clabernetescontrollerstopology.NewNetworkAttachmentDefinitionReconciler(
+		&claberneteslogging.FakeInstance{},
+		clabernetesconfig.GetFakeManager,
+	)
+
+	actual, err := reconciler.Resolve(deployedNADs, configs, &clabernetesapisv1alpha1.Topology{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actual.Missing) != 0 {
+		clabernetestesthelper.FailOutput(t, actual.Missing, []string{})
+	}
+
+	if len(actual.Extra) != 0 {
+		clabernetestesthelper.FailOutput(t, len(actual.Extra), 0)
+	}
+}
@@ -0,0 +1,499 @@
+package topology
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	k8scorev1 "k8s.io/api/core/v1"
+	k8snetworkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	networkPolicyDNSPort = 53
+
+	networkPolicyKubeDNSLabel      = "k8s-app"
+	networkPolicyKubeDNSLabelValue = "kube-dns"
+	networkPolicyKubeDNSNamespace  = "kube-system"
+
+	// ciliumNamespaceLabel is the label cilium identities carry the namespace of the pod in, used
+	// to select pods outside the namespace of the policy.
+	ciliumNamespaceLabel = "k8s:io.kubernetes.pod.namespace"
+
+	ciliumEntityAll           = "all"
+	ciliumEntityKubeAPIServer = "kube-apiserver"
+	ciliumEntityWorld         = "world"
+)
+
+// networkPolicyAPIPorts are the (tcp) ports the launchers may reach anywhere -- the kubernetes api
+// (443 for the service, 6443 for the api servers behind it) and image registries. A vanilla network
+// policy can not select the kubernetes api any other way.
+var networkPolicyAPIPorts = []int{443, 6443} //nolint:gochecknoglobals
+
+// CiliumNetworkPolicyGVK is the group/version/kind of cilium network policies.
+var CiliumNetworkPolicyGVK = schema.GroupVersionKind{ //nolint:gochecknoglobals
+	Group:   "cilium.io",
+	Version: "v2",
+	Kind:    "CiliumNetworkPolicy",
+}
+
+// ciliumNetworkPolicySpec is the subset of the cilium network policy spec clabernetes renders.
+type ciliumNetworkPolicySpec struct {
+	EndpointSelector metav1.LabelSelector `json:"endpointSelector"`
+	Ingress          []ciliumPolicyRule   `json:"ingress"`
+	Egress           []ciliumPolicyRule   `json:"egress"`
+}
+
+type ciliumPolicyRule struct {
+	FromEndpoints []metav1.LabelSelector `json:"fromEndpoints,omitempty"`
+	FromEntities  []string               `json:"fromEntities,omitempty"`
+	FromCIDR      []string               `json:"fromCIDR,omitempty"`
+	ToEndpoints   []metav1.LabelSelector `json:"toEndpoints,omitempty"`
+	ToEntities    []string               `json:"toEntities,omitempty"`
+	ToPorts       []ciliumPortRule       `json:"toPorts,omitempty"`
+}
+
+type ciliumPortRule struct {
+	Ports []ciliumPortProtocol `json:"ports"`
+}
+
+type ciliumPortProtocol struct {
+	Port     string `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// NetworkPolicyReconciler is a subcomponent of the "TopologyReconciler" but is exposed for testing
+// purposes. This is the component responsible for rendering/validating the network policy of the
+// launchers of a topology -- a vanilla NetworkPolicy or a CiliumNetworkPolicy, depending on the
+// renderer selected in the global config.
+type NetworkPolicyReconciler struct {
+	log                 claberneteslogging.Instance
+	configManagerGetter clabernetesconfig.ManagerGetterFunc
+}
+
+// NewNetworkPolicyReconciler returns an instance of NetworkPolicyReconciler.
+func NewNetworkPolicyReconciler(
+	log claberneteslogging.Instance,
+	configManagerGetter clabernetesconfig.ManagerGetterFunc,
+) *NetworkPolicyReconciler {
+	return &NetworkPolicyReconciler{
+		log:                 log,
+		configManagerGetter: configManagerGetter,
+	}
+}
+
+func (r *NetworkPolicyReconciler) renderMetadata(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) metav1.ObjectMeta {
+	owningTopologyName := owningTopology.GetName()
+
+	annotations, globalLabels := r.configManagerGetter().GetAllMetadata()
+
+	labels := map[string]string{
+		clabernetesconstants.LabelApp:           clabernetesconstants.Clabernetes,
+		clabernetesconstants.LabelName:          owningTopologyName,
+		clabernetesconstants.LabelTopologyOwner: owningTopologyName,
+		clabernetesconstants.LabelTopologyKind:  GetTopologyKind(owningTopology),
+	}
+
+	for k, v := range globalLabels {
+		labels[k] = v
+	}
+
+	return metav1.ObjectMeta{
+		Name:        owningTopologyName,
+		Namespace:   owningTopology.GetNamespace(),
+		Annotations: annotations,
+		Labels:      labels,
+	}
+}
+
+// launcherSelectorLabels returns the labels selecting the launcher pods of the given topology.
+func launcherSelectorLabels(owningTopology *clabernetesapisv1alpha1.Topology) map[string]string {
+	return map[string]string{
+		clabernetesconstants.LabelTopologyOwner: owningTopology.GetName(),
+	}
+}
+
+// resolveExposedPorts returns the (sorted, unique) tcp and udp ports exposed by any node of the
+// topology.
+func resolveExposedPorts(reconcileData *ReconcileData) (tcpPorts, udpPorts []int) {
+	for _, exposedPorts := range reconcileData.ResolvedExposedPorts {
+		if exposedPorts == nil {
+			continue
+		}
+
+		tcpPorts = append(tcpPorts, exposedPorts.TCPPorts...)
+		udpPorts = append(udpPorts, exposedPorts.UDPPorts...)
+	}
+
+	slices.Sort(tcpPorts)
+	slices.Sort(udpPorts)
+
+	return slices.Compact(tcpPorts), slices.Compact(udpPorts)
+}
+
+func networkPolicyPorts(
+	protocol k8scorev1.Protocol,
+	ports []int,
+) []k8snetworkingv1.NetworkPolicyPort {
+	policyPorts := make([]k8snetworkingv1.NetworkPolicyPort, len(ports))
+
+	for idx, port := range ports {
+		policyPorts[idx] = k8snetworkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &intstr.IntOrString{IntVal: int32(port)}, //nolint:gosec
+		}
+	}
+
+	return policyPorts
+}
+
+func ciliumPorts(protocol string, ports []int) []ciliumPortProtocol {
+	policyPorts := make([]ciliumPortProtocol, len(ports))
+
+	for idx, port := range ports {
+		policyPorts[idx] = ciliumPortProtocol{
+			Port:     strconv.Itoa(port),
+			Protocol: protocol,
+		}
+	}
+
+	return policyPorts
+}
+
+// wireTapPeerSelectorLabels returns the labels selecting all the wire tap pods of the given
+// topology.
+func wireTapPeerSelectorLabels(owningTopology *clabernetesapisv1alpha1.Topology) map[string]string {
+	return map[string]string{
+		clabernetesconstants.LabelWireTapTopology: owningTopology.GetName(),
+	}
+}
+
+// Render returns the rendered (vanilla) network policy for the launchers of the given topology.
+// Launchers may talk to each other (and the wire taps of the topology) freely (tunnels, liveness
+// probes), anyone (or the configured cidrs) may reach their exposed ports, and they may reach the
+// cluster dns, the topology ntp server (if any) and the kubernetes api/image registries (by port,
+// see networkPolicyAPIPorts).
+func (r *NetworkPolicyReconciler) Render(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) *k8snetworkingv1.NetworkPolicy {
+	networkPolicyConfig := r.configManagerGetter().GetNetworkPolicy()
+
+	launcherPeers := []k8snetworkingv1.NetworkPolicyPeer{
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: launcherSelectorLabels(owningTopology),
+			},
+		},
+	}
+
+	if len(reconcileData.WireTaps) > 0 {
+		launcherPeers = append(launcherPeers, k8snetworkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: wireTapPeerSelectorLabels(owningTopology),
+			},
+		})
+	}
+
+	ingress := []k8snetworkingv1.NetworkPolicyIngressRule{
+		{
+			From: launcherPeers,
+		},
+	}
+
+	tcpPorts, udpPorts := resolveExposedPorts(reconcileData)
+
+	if len(tcpPorts) > 0 || len(udpPorts) > 0 {
+		exposedPortsRule := k8snetworkingv1.NetworkPolicyIngressRule{
+			Ports: append(
+				networkPolicyPorts(k8scorev1.ProtocolTCP, tcpPorts),
+				networkPolicyPorts(k8scorev1.ProtocolUDP, udpPorts)...,
+			),
+		}
+
+		for _, cidr := range networkPolicyConfig.ExposedPortsCIDRs {
+			exposedPortsRule.From = append(
+				exposedPortsRule.From,
+				k8snetworkingv1.NetworkPolicyPeer{
+					IPBlock: &k8snetworkingv1.IPBlock{
+						CIDR: cidr,
+					},
+				},
+			)
+		}
+
+		ingress = append(ingress, exposedPortsRule)
+	}
+
+	egress := []k8snetworkingv1.NetworkPolicyEgressRule{
+		{
+			To: launcherPeers,
+		},
+		{
+			To: []k8snetworkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"kubernetes.io/metadata.name": networkPolicyKubeDNSNamespace,
+						},
+					},
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							networkPolicyKubeDNSLabel: networkPolicyKubeDNSLabelValue,
+						},
+					},
+				},
+			},
+			Ports: append(
+				networkPolicyPorts(k8scorev1.ProtocolUDP, []int{networkPolicyDNSPort}),
+				networkPolicyPorts(k8scorev1.ProtocolTCP, []int{networkPolicyDNSPort})...,
+			),
+		},
+	}
+
+	if ntpServerEnabled(owningTopology) {
+		egress = append(
+			egress,
+			k8snetworkingv1.NetworkPolicyEgressRule{
+				To: []k8snetworkingv1.NetworkPolicyPeer{
+					{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: ntpServerSelectorLabels(owningTopology),
+						},
+					},
+				},
+				Ports: networkPolicyPorts(
+					k8scorev1.ProtocolUDP,
+					[]int{clabernetesconstants.NTPServicePort},
+				),
+			},
+		)
+	}
+
+	egress = append(
+		egress,
+		k8snetworkingv1.NetworkPolicyEgressRule{
+			Ports: networkPolicyPorts(k8scorev1.ProtocolTCP, networkPolicyAPIPorts),
+		},
+	)
+
+	return &k8snetworkingv1.NetworkPolicy{
+		ObjectMeta: r.renderMetadata(owningTopology),
+		Spec: k8snetworkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: launcherSelectorLabels(owningTopology),
+			},
+			Ingress: ingress,
+			Egress:  egress,
+			PolicyTypes: []k8snetworkingv1.PolicyType{
+				k8snetworkingv1.PolicyTypeIngress,
+				k8snetworkingv1.PolicyTypeEgress,
+			},
+		},
+	}
+}
+
+// RenderCilium returns the rendered cilium network policy for the launchers of the given topology.
+// It allows the same as the vanilla policy (see Render), but the kubernetes api is allowed by
+// entity, only image registries (the "world" entity) are allowed by port, and the exposed ports
+// can be opened up to cilium entities -- node port traffic cilium sees as coming from a node for
+// example.
+func (r *NetworkPolicyReconciler) RenderCilium(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) *unstructured.Unstructured {
+	networkPolicyConfig := r.configManagerGetter().GetNetworkPolicy()
+
+	launcherEndpoints := []metav1.LabelSelector{
+		{
+			MatchLabels: launcherSelectorLabels(owningTopology),
+		},
+	}
+
+	if len(reconcileData.WireTaps) > 0 {
+		launcherEndpoints = append(launcherEndpoints, metav1.LabelSelector{
+			MatchLabels: wireTapPeerSelectorLabels(owningTopology),
+		})
+	}
+
+	spec := ciliumNetworkPolicySpec{
+		EndpointSelector: metav1.LabelSelector{
+			MatchLabels: launcherSelectorLabels(owningTopology),
+		},
+		Ingress: []ciliumPolicyRule{
+			{
+				FromEndpoints: launcherEndpoints,
+			},
+		},
+		Egress: []ciliumPolicyRule{
+			{
+				ToEndpoints: launcherEndpoints,
+			},
+			{
+				ToEndpoints: []metav1.LabelSelector{
+					{
+						MatchLabels: map[string]string{
+							ciliumNamespaceLabel:      networkPolicyKubeDNSNamespace,
+							networkPolicyKubeDNSLabel: networkPolicyKubeDNSLabelValue,
+						},
+					},
+				},
+				ToPorts: []ciliumPortRule{
+					{
+						Ports: ciliumPorts("ANY", []int{networkPolicyDNSPort}),
+					},
+				},
+			},
+			{
+				ToEntities: []string{ciliumEntityKubeAPIServer},
+			},
+		},
+	}
+
+	tcpPorts, udpPorts := resolveExposedPorts(reconcileData)
+
+	if len(tcpPorts) > 0 || len(udpPorts) > 0 {
+		exposedPorts := []ciliumPortRule{
+			{
+				Ports: append(
+					ciliumPorts(clabernetesconstants.TCP, tcpPorts),
+					ciliumPorts(clabernetesconstants.UDP, udpPorts)...,
+				),
+			},
+		}
+
+		entities := networkPolicyConfig.CiliumExposedPortsEntities
+		if len(entities) == 0 && len(networkPolicyConfig.ExposedPortsCIDRs) == 0 {
+			entities = []string{ciliumEntityAll}
+		}
+
+		if len(entities) > 0 {
+			spec.Ingress = append(spec.Ingress, ciliumPolicyRule{
+				FromEntities: entities,
+				ToPorts:      exposedPorts,
+			})
+		}
+
+		if len(networkPolicyConfig.ExposedPortsCIDRs) > 0 {
+			spec.Ingress = append(spec.Ingress, ciliumPolicyRule{
+				FromCIDR: networkPolicyConfig.ExposedPortsCIDRs,
+				ToPorts:  exposedPorts,
+			})
+		}
+	}
+
+	if ntpServerEnabled(owningTopology) {
+		spec.Egress = append(spec.Egress, ciliumPolicyRule{
+			ToEndpoints: []metav1.LabelSelector{
+				{
+					MatchLabels: ntpServerSelectorLabels(owningTopology),
+				},
+			},
+			ToPorts: []ciliumPortRule{
+				{
+					Ports: ciliumPorts(
+						clabernetesconstants.UDP,
+						[]int{clabernetesconstants.NTPServicePort},
+					),
+				},
+			},
+		})
+	}
+
+	spec.Egress = append(spec.Egress, ciliumPolicyRule{
+		ToEntities: []string{ciliumEntityWorld},
+		ToPorts: []ciliumPortRule{
+			{
+				Ports: ciliumPorts(clabernetesconstants.TCP, networkPolicyAPIPorts[:1]),
+			},
+		},
+	})
+
+	metadata := r.renderMetadata(owningTopology)
+
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(CiliumNetworkPolicyGVK)
+	policy.SetName(metadata.Name)
+	policy.SetNamespace(metadata.Namespace)
+	policy.SetAnnotations(metadata.Annotations)
+	policy.SetLabels(metadata.Labels)
+
+	// round trip the spec through json so it holds the same (plain json) types as the spec of an
+	// existing policy read from the cluster -- otherwise Conforms could never be happy
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		r.log.Criticalf("failed marshaling cilium network policy spec, error: %s", err)
+
+		return policy
+	}
+
+	var specMap map[string]any
+
+	err = json.Unmarshal(specBytes, &specMap)
+	if err != nil {
+		r.log.Criticalf("failed unmarshaling cilium network policy spec, error: %s", err)
+
+		return policy
+	}
+
+	policy.Object["spec"] = specMap
+
+	return policy
+}
+
+// Conforms checks if the existing network policy conforms with the rendered network policy.
+func (r *NetworkPolicyReconciler) Conforms(
+	existingNetworkPolicy,
+	renderedNetworkPolicy *k8snetworkingv1.NetworkPolicy,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !reflect.DeepEqual(existingNetworkPolicy.Spec, renderedNetworkPolicy.Spec) {
+		return false
+	}
+
+	return clockSyncMetadataConforms(
+		existingNetworkPolicy.ObjectMeta,
+		renderedNetworkPolicy.ObjectMeta,
+		expectedOwnerUID,
+	)
+}
+
+// ConformsCilium checks if the existing cilium network policy conforms with the rendered cilium
+// network policy.
+func (r *NetworkPolicyReconciler) ConformsCilium(
+	existingNetworkPolicy,
+	renderedNetworkPolicy *unstructured.Unstructured,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !reflect.DeepEqual(
+		existingNetworkPolicy.Object["spec"],
+		renderedNetworkPolicy.Object["spec"],
+	) {
+		return false
+	}
+
+	return clockSyncMetadataConforms(
+		metav1.ObjectMeta{
+			Annotations:     existingNetworkPolicy.GetAnnotations(),
+			Labels:          existingNetworkPolicy.GetLabels(),
+			OwnerReferences: existingNetworkPolicy.GetOwnerReferences(),
+		},
+		metav1.ObjectMeta{
+			Annotations: renderedNetworkPolicy.GetAnnotations(),
+			Labels:      renderedNetworkPolicy.GetLabels(),
+		},
+		expectedOwnerUID,
+	)
+}
@@ -0,0 +1,220 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderNetworkPolicy(t *testing.T) {
+	cases := []struct {
+		name                  string
+		networkPolicy         clabernetesapisv1alpha1.ConfigNetworkPolicy
+		clockSync             *clabernetesapisv1alpha1.ClockSync
+		exposedPorts          map[string]*clabernetesapisv1alpha1.ExposedPorts
+		expectedIngressRules  int
+		expectedExposedPeers  []string
+		expectedEgressRules   int
+		expectedExposedTCPUDP [2]int
+	}{
+		{
+			name:                 "no-exposed-ports",
+			exposedPorts:         map[string]*clabernetesapisv1alpha1.ExposedPorts{},
+			expectedIngressRules: 1,
+			expectedEgressRules:  3,
+		},
+		{
+			name: "exposed-ports-anywhere",
+			exposedPorts: map[string]*clabernetesapisv1alpha1.ExposedPorts{
+				"srl1": {
+					TCPPorts: []int{22, 57400},
+					UDPPorts: []int{161},
+				},
+				"srl2": {
+					TCPPorts: []int{22, 830},
+				},
+			},
+			expectedIngressRules:  2,
+			expectedExposedPeers:  nil,
+			expectedEgressRules:   3,
+			expectedExposedTCPUDP: [2]int{3, 1},
+		},
+		{
+			name: "exposed-ports-cidrs",
+			networkPolicy: clabernetesapisv1alpha1.ConfigNetworkPolicy{
+				ExposedPortsCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+			},
+			exposedPorts: map[string]*clabernetesapisv1alpha1.ExposedPorts{
+				"srl1": {
+					TCPPorts: []int{22},
+				},
+			},
+			expectedIngressRules:  2,
+			expectedExposedPeers:  []string{"10.0.0.0/8", "192.168.0.0/16"},
+			expectedEgressRules:   3,
+			expectedExposedTCPUDP: [2]int{1, 0},
+		},
+		{
+			name: "ntp-server",
+			clockSync: &clabernetesapisv1alpha1.ClockSync{
+				NTPServer: true,
+			},
+			exposedPorts:         map[string]*clabernetesapisv1alpha1.ExposedPorts{},
+			expectedIngressRules: 1,
+			expectedEgressRules:  4,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				reconciler := clabernetescontrollerstopology.NewNetworkPolicyReconciler(
+					&claberneteslogging.FakeInstance{},
+					func() clabernetesconfig.Manager {
+						return clabernetesconfig.NewFakeManager(
+							clabernetesconfig.WithNetworkPolicy(testCase.networkPolicy),
+						)
+					},
+				)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-policy",
+						Namespace: "nowhere",
+					},
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						ClockSync: testCase.clockSync,
+					},
+				}
+
+				actual := reconciler.Render(
+					owningTopology,
+					&clabernetescontrollerstopology.ReconcileData{
+						ResolvedExposedPorts: testCase.exposedPorts,
+					},
+				)
+
+				expectedSelector := map[string]string{
+					clabernetesconstants.LabelTopologyOwner: "test-policy",
+				}
+
+				if !reflect.DeepEqual(actual.Spec.PodSelector.MatchLabels, expectedSelector) {
+					clabernetestesthelper.FailOutput(
+						t,
+						actual.Spec.PodSelector.MatchLabels,
+						expectedSelector,
+					)
+				}
+
+				if len(actual.Spec.Ingress) != testCase.expectedIngressRules {
+					clabernetestesthelper.FailOutput(
+						t,
+						len(actual.Spec.Ingress),
+						testCase.expectedIngressRules,
+					)
+				}
+
+				if len(actual.Spec.Egress) != testCase.expectedEgressRules {
+					clabernetestesthelper.FailOutput(
+						t,
+						len(actual.Spec.Egress),
+						testCase.expectedEgressRules,
+					)
+				}
+
+				if testCase.expectedIngressRules < 2 {
+					return
+				}
+
+				exposedRule := actual.Spec.Ingress[1]
+
+				var actualPeers []string
+
+				for _, peer := range exposedRule.From {
+					actualPeers = append(actualPeers, peer.IPBlock.CIDR)
+				}
+
+				if !reflect.DeepEqual(actualPeers, testCase.expectedExposedPeers) {
+					clabernetestesthelper.FailOutput(t, actualPeers, testCase.expectedExposedPeers)
+				}
+
+				var actualTCPUDP [2]int
+
+				for _, port := range exposedRule.Ports {
+					if string(*port.Protocol) == clabernetesconstants.TCP {
+						actualTCPUDP[0]++
+					} else {
+						actualTCPUDP[1]++
+					}
+				}
+
+				if actualTCPUDP != testCase.expectedExposedTCPUDP {
+					clabernetestesthelper.FailOutput(
+						t,
+						actualTCPUDP,
+						testCase.expectedExposedTCPUDP,
+					)
+				}
+			})
+	}
+}
+
+// TestRenderCiliumNetworkPolicyConforms ensures a rendered cilium network policy conforms with
+// itself once it has been round tripped (as it would be when read back from the cluster).
+func TestRenderCiliumNetworkPolicyConforms(t *testing.T) {
+	reconciler := clabernetescontrollerstopology.NewNetworkPolicyReconciler(
+		&claberneteslogging.FakeInstance{},
+		clabernetesconfig.GetFakeManager,
+	)
+
+	owningTopology := &clabernetesapisv1alpha1.Topology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-policy",
+			Namespace: "nowhere",
+			UID:       "abc",
+		},
+	}
+
+	reconcileData := &clabernetescontrollerstopology.ReconcileData{
+		ResolvedExposedPorts: map[string]*clabernetesapisv1alpha1.ExposedPorts{
+			"srl1": {
+				TCPPorts: []int{22},
+			},
+		},
+	}
+
+	existing := reconciler.RenderCilium(owningTopology, reconcileData)
+	existing.SetOwnerReferences([]metav1.OwnerReference{
+		{
+			UID: "abc",
+		},
+	})
+
+	if existing.GetKind() != "CiliumNetworkPolicy" {
+		clabernetestesthelper.FailOutput(t, existing.GetKind(), "CiliumNetworkPolicy")
+	}
+
+	rendered := reconciler.RenderCilium(owningTopology, reconcileData)
+
+	if !reconciler.ConformsCilium(existing, rendered, "abc") {
+		t.Fatalf("expected rendered cilium network policy to conform with itself")
+	}
+
+	reconcileData.ResolvedExposedPorts["srl1"].TCPPorts = []int{22, 830}
+
+	rendered = reconciler.RenderCilium(owningTopology, reconcileData)
+
+	if reconciler.ConformsCilium(existing, rendered, "abc") {
+		t.Fatalf("expected cilium network policy with new exposed ports to not conform")
+	}
+}
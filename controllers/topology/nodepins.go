@@ -0,0 +1,166 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	conditionNodePinsInvalid = "NodePinsInvalid"
+	reasonNodePinsInvalid    = "InvalidNodePins"
+)
+
+// NodePinProblems returns a sorted list of the reasons the given worker can not run a launcher with
+// the given resource requests -- the worker being cordoned, not being ready, or not having enough
+// allocatable resources. Note that this only checks the allocatable resources of the worker, not
+// what is left of them after whatever else is already running there.
+func NodePinProblems(worker *k8scorev1.Node, requests k8scorev1.ResourceList) []string {
+	var problems []string
+
+	if worker.Spec.Unschedulable {
+		problems = append(problems, "is cordoned")
+	}
+
+	ready := false
+
+	for _, condition := range worker.Status.Conditions {
+		if condition.Type == k8scorev1.NodeReady {
+			ready = condition.Status == k8scorev1.ConditionTrue
+
+			break
+		}
+	}
+
+	if !ready {
+		problems = append(problems, "is not ready")
+	}
+
+	for resourceName, requested := range requests {
+		allocatable, ok := worker.Status.Allocatable[resourceName]
+		if !ok || allocatable.Cmp(requested) < 0 {
+			problems = append(
+				problems,
+				fmt.Sprintf(
+					"has %s allocatable %s but the launcher requests %s",
+					allocatable.String(),
+					resourceName,
+					requested.String(),
+				),
+			)
+		}
+	}
+
+	slices.Sort(problems)
+
+	return problems
+}
+
+// reconcileNodePinsCondition sets (or clears) the "NodePinsInvalid" condition on the topology based
+// on whether the workers the nodes are pinned to exist and can run the launchers of those nodes.
+func (r *Reconciler) reconcileNodePinsCondition(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) error {
+	if r.namespaceScoped {
+		// nodes are cluster scoped and so out of reach for a namespace scoped manager -- pins are
+		// still applied to the launchers, just not validated up front; drop any condition left
+		// over from when the manager was cluster scoped so it does not linger forever
+		if apimachinerymeta.RemoveStatusCondition(
+			&owningTopology.Status.Conditions,
+			conditionNodePinsInvalid,
+		) {
+			reconcileData.ShouldUpdateResource = true
+		}
+
+		return nil
+	}
+
+	var invalidPins []string
+
+	for nodeName, workerName := range owningTopology.Spec.Deployment.Scheduling.NodePins {
+		if _, ok := reconcileData.ResolvedConfigs[nodeName]; !ok {
+			invalidPins = append(
+				invalidPins,
+				fmt.Sprintf("%s: node does not exist in the topology", nodeName),
+			)
+
+			continue
+		}
+
+		worker := &k8scorev1.Node{}
+
+		err := r.Client.Get(ctx, apimachinerytypes.NamespacedName{Name: workerName}, worker)
+		if err != nil {
+			if !apimachineryerrors.IsNotFound(err) {
+				return err
+			}
+
+			invalidPins = append(
+				invalidPins,
+				fmt.Sprintf("%s: worker %q does not exist", nodeName, workerName),
+			)
+
+			continue
+		}
+
+		var requests k8scorev1.ResourceList
+
+		resources := r.DeploymentReconciler.ResolveLauncherResources(
+			owningTopology,
+			reconcileData.ResolvedConfigs,
+			nodeName,
+		)
+		if resources != nil {
+			requests = resources.Requests
+		}
+
+		problems := NodePinProblems(worker, requests)
+		if len(problems) > 0 {
+			invalidPins = append(
+				invalidPins,
+				fmt.Sprintf(
+					"%s: worker %q %s",
+					nodeName,
+					workerName,
+					strings.Join(problems, ", "),
+				),
+			)
+		}
+	}
+
+	if len(invalidPins) == 0 {
+		if apimachinerymeta.RemoveStatusCondition(
+			&owningTopology.Status.Conditions,
+			conditionNodePinsInvalid,
+		) {
+			reconcileData.ShouldUpdateResource = true
+		}
+
+		return nil
+	}
+
+	slices.Sort(invalidPins)
+
+	r.Log.Warnf("invalid node pin(s) %q, pinned launchers may not schedule", invalidPins)
+
+	if apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+		Type:    conditionNodePinsInvalid,
+		Status:  "True",
+		Reason:  reasonNodePinsInvalid,
+		Message: fmt.Sprintf("invalid node pin(s): %s", strings.Join(invalidPins, "; ")),
+	}) {
+		reconcileData.ShouldUpdateResource = true
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	k8scorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func nodePinTestWorker(unschedulable, ready bool) *k8scorev1.Node {
+	readyStatus := k8scorev1.ConditionFalse
+	if ready {
+		readyStatus = k8scorev1.ConditionTrue
+	}
+
+	return &k8scorev1.Node{
+		Spec: k8scorev1.NodeSpec{
+			Unschedulable: unschedulable,
+		},
+		Status: k8scorev1.NodeStatus{
+			Conditions: []k8scorev1.NodeCondition{
+				{
+					Type:   k8scorev1.NodeReady,
+					Status: readyStatus,
+				},
+			},
+			Allocatable: k8scorev1.ResourceList{
+				k8scorev1.ResourceCPU:    resource.MustParse("4"),
+				k8scorev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+}
+
+func TestNodePinProblems(t *testing.T) {
+	cases := []struct {
+		name     string
+		worker   *k8scorev1.Node
+		requests k8scorev1.ResourceList
+		expected []string
+	}{
+		{
+			name:     "simple",
+			worker:   nodePinTestWorker(false, true),
+			requests: nil,
+			expected: nil,
+		},
+		{
+			name:   "fits",
+			worker: nodePinTestWorker(false, true),
+			requests: k8scorev1.ResourceList{
+				k8scorev1.ResourceCPU:    resource.MustParse("4"),
+				k8scorev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+			expected: nil,
+		},
+		{
+			name:     "cordoned-not-ready",
+			worker:   nodePinTestWorker(true, false),
+			requests: nil,
+			expected: []string{"is cordoned", "is not ready"},
+		},
+		{
+			name:   "insufficient-resources",
+			worker: nodePinTestWorker(false, true),
+			requests: k8scorev1.ResourceList{
+				k8scorev1.ResourceCPU:     resource.MustParse("8"),
+				k8scorev1.ResourceMemory:  resource.MustParse("2Gi"),
+				"devices.kubevirt.io/kvm": resource.MustParse("1"),
+			},
+			expected: []string{
+				"has 0 allocatable devices.kubevirt.io/kvm but the launcher requests 1",
+				"has 4 allocatable cpu but the launcher requests 8",
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.NodePinProblems(
+					testCase.worker,
+					testCase.requests,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
@@ -0,0 +1,35 @@
+package topology
+
+import (
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+)
+
+// ApplyPacketCaptures sets the packet capture of each of the given tunnels based on the packet
+// captures of the owning topology -- tunnels whose local endpoint is not listed in any of the
+// packet captures have their capture cleared (which stops any running capture). When an endpoint
+// is listed multiple times the last listing wins. It returns a sorted list of the capture
+// endpoints that did not match any tunnel so the caller can let the user know.
+func ApplyPacketCaptures(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	tunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+) []string {
+	captures := map[string]clabernetesapisv1alpha1.PacketCapture{}
+
+	for _, packetCapture := range owningTopology.Spec.PacketCaptures {
+		for _, endpoint := range packetCapture.Endpoints {
+			captures[endpoint] = packetCapture.PacketCapture
+		}
+	}
+
+	return applyEndpointSettings(
+		tunnels,
+		captures,
+		false,
+		func(
+			tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+			capture *clabernetesapisv1alpha1.PacketCapture,
+		) {
+			tunnel.Capture = capture
+		},
+	)
+}
@@ -0,0 +1,117 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestApplyPacketCaptures(t *testing.T) {
+	cases := []struct {
+		name              string
+		packetCaptures    []clabernetesapisv1alpha1.TopologyPacketCapture
+		expectedCaptures  map[string]*clabernetesapisv1alpha1.PacketCapture
+		expectedUnmatched []string
+	}{
+		{
+			name:           "no-captures-clears-existing",
+			packetCaptures: nil,
+			expectedCaptures: map[string]*clabernetesapisv1alpha1.PacketCapture{
+				"srl1": nil,
+				"srl2": nil,
+			},
+			expectedUnmatched: nil,
+		},
+		{
+			name: "single-endpoint",
+			packetCaptures: []clabernetesapisv1alpha1.TopologyPacketCapture{
+				{
+					Endpoints: []string{"srl2:e1-1"},
+					PacketCapture: clabernetesapisv1alpha1.PacketCapture{
+						Filter:          "tcp port 179",
+						RotateMegabytes: 10,
+						MaxFiles:        5,
+					},
+				},
+			},
+			expectedCaptures: map[string]*clabernetesapisv1alpha1.PacketCapture{
+				"srl1": nil,
+				"srl2": {
+					Filter:          "tcp port 179",
+					RotateMegabytes: 10,
+					MaxFiles:        5,
+				},
+			},
+			expectedUnmatched: nil,
+		},
+		{
+			name: "both-endpoints-and-unmatched",
+			packetCaptures: []clabernetesapisv1alpha1.TopologyPacketCapture{
+				{
+					Endpoints: []string{"srl1:e1-1", "srl2:e1-1", "srl3:e1-1"},
+					PacketCapture: clabernetesapisv1alpha1.PacketCapture{
+						RotateSeconds: 300,
+					},
+				},
+				{
+					Endpoints: []string{"srl1:e1-2"},
+					PacketCapture: clabernetesapisv1alpha1.PacketCapture{
+						Filter: "arp",
+					},
+				},
+			},
+			expectedCaptures: map[string]*clabernetesapisv1alpha1.PacketCapture{
+				"srl1": {
+					RotateSeconds: 300,
+				},
+				"srl2": {
+					RotateSeconds: 300,
+				},
+			},
+			expectedUnmatched: []string{"srl1:e1-2", "srl3:e1-1"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						PacketCaptures: testCase.packetCaptures,
+					},
+				}
+
+				tunnels := linkTestTunnels()
+
+				// a capture that is no longer desired must be cleared
+				tunnels["srl1"][0].Capture = &clabernetesapisv1alpha1.PacketCapture{
+					Filter: "icmp",
+				}
+
+				actualUnmatched := clabernetescontrollerstopology.ApplyPacketCaptures(
+					owningTopology,
+					tunnels,
+				)
+				if !reflect.DeepEqual(actualUnmatched, testCase.expectedUnmatched) {
+					clabernetestesthelper.FailOutput(
+						t,
+						actualUnmatched,
+						testCase.expectedUnmatched,
+					)
+				}
+
+				for nodeName, expectedCapture := range testCase.expectedCaptures {
+					actualCapture := tunnels[nodeName][0].Capture
+					if !reflect.DeepEqual(actualCapture, expectedCapture) {
+						clabernetestesthelper.FailOutput(t, actualCapture, expectedCapture)
+					}
+				}
+			})
+	}
+}
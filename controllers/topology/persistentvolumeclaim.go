@@ -69,7 +69,9 @@ func (r *PersistentVolumeClaimReconciler) Resolve(
 		pvcs.Current[nodeName] = &ownedPVCs.Items[i]
 	}
 
-	persistenceEnabled := owningTopology.Spec.Deployment.Persistence.Enabled
+	// launchers running as statefulsets claim their volumes via the statefulset claim templates
+	persistenceEnabled := owningTopology.Spec.Deployment.Persistence.Enabled &&
+		ResolveLauncherKind(owningTopology) != clabernetesconstants.KubernetesKindStatefulSet
 
 	if persistenceEnabled {
 		allNodes := make([]string, len(clabernetesConfigs))
@@ -0,0 +1,197 @@
+package topology
+
+import (
+	"encoding/json"
+	"reflect"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	podMonitorMetricsPath = "/metrics"
+
+	// podMonitorTopologyLabel/podMonitorLauncherLabel are the labels the topology and the
+	// launcher (node) of the scraped launcher are attached to the launcher metrics as.
+	podMonitorTopologyLabel = "clab_topology"
+	podMonitorLauncherLabel = "clab_launcher"
+
+	// podMonitorPodLabelPrefix is the prefix of the meta labels prometheus exposes the labels of
+	// scraped pods as.
+	podMonitorPodLabelPrefix = "__meta_kubernetes_pod_label_"
+)
+
+// PodMonitorGVK is the group/version/kind of prometheus operator pod monitors.
+var PodMonitorGVK = schema.GroupVersionKind{ //nolint:gochecknoglobals
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PodMonitor",
+}
+
+// podMonitorSpec is the subset of the prometheus operator pod monitor spec clabernetes renders.
+type podMonitorSpec struct {
+	Selector            metav1.LabelSelector `json:"selector"`
+	PodMetricsEndpoints []podMetricsEndpoint `json:"podMetricsEndpoints"`
+}
+
+type podMetricsEndpoint struct {
+	Port        string                 `json:"port"`
+	Path        string                 `json:"path"`
+	Interval    string                 `json:"interval,omitempty"`
+	Relabelings []podMonitorRelabeling `json:"relabelings"`
+}
+
+type podMonitorRelabeling struct {
+	SourceLabels []string `json:"sourceLabels"`
+	TargetLabel  string   `json:"targetLabel"`
+}
+
+// podMonitorMetaLabel returns the prometheus meta label the given pod label is exposed as -- label
+// names can only hold letters, digits and underscores, everything else is replaced.
+func podMonitorMetaLabel(podLabel string) string {
+	metaLabel := []rune(podLabel)
+
+	for idx, r := range metaLabel {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			metaLabel[idx] = '_'
+		}
+	}
+
+	return podMonitorPodLabelPrefix + string(metaLabel)
+}
+
+// PodMonitorReconciler is a subcomponent of the "TopologyReconciler" but is exposed for testing
+// purposes. This is the component responsible for rendering/validating the prometheus operator pod
+// monitor scraping the metrics of the launchers of a topology.
+type PodMonitorReconciler struct {
+	log                 claberneteslogging.Instance
+	configManagerGetter clabernetesconfig.ManagerGetterFunc
+}
+
+// NewPodMonitorReconciler returns an instance of PodMonitorReconciler.
+func NewPodMonitorReconciler(
+	log claberneteslogging.Instance,
+	configManagerGetter clabernetesconfig.ManagerGetterFunc,
+) *PodMonitorReconciler {
+	return &PodMonitorReconciler{
+		log:                 log,
+		configManagerGetter: configManagerGetter,
+	}
+}
+
+// Render returns the rendered pod monitor for the launchers of the given topology -- it scrapes
+// the metrics port of all launchers of the topology and attaches the topology and the launcher
+// (node) to the metrics, on top of the namespace and pod labels prometheus attaches anyway.
+func (r *PodMonitorReconciler) Render(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) *unstructured.Unstructured {
+	owningTopologyName := owningTopology.GetName()
+
+	metricsConfig := r.configManagerGetter().GetMetrics()
+
+	annotations, globalLabels := r.configManagerGetter().GetAllMetadata()
+
+	labels := map[string]string{
+		clabernetesconstants.LabelApp:           clabernetesconstants.Clabernetes,
+		clabernetesconstants.LabelName:          owningTopologyName,
+		clabernetesconstants.LabelTopologyOwner: owningTopologyName,
+		clabernetesconstants.LabelTopologyKind:  GetTopologyKind(owningTopology),
+	}
+
+	for k, v := range globalLabels {
+		labels[k] = v
+	}
+
+	for k, v := range metricsConfig.Labels {
+		labels[k] = v
+	}
+
+	spec := podMonitorSpec{
+		Selector: metav1.LabelSelector{
+			MatchLabels: launcherSelectorLabels(owningTopology),
+		},
+		PodMetricsEndpoints: []podMetricsEndpoint{
+			{
+				Port:     clabernetesconstants.MetricsPortName,
+				Path:     podMonitorMetricsPath,
+				Interval: metricsConfig.ScrapeInterval,
+				Relabelings: []podMonitorRelabeling{
+					{
+						SourceLabels: []string{
+							podMonitorMetaLabel(clabernetesconstants.LabelTopologyOwner),
+						},
+						TargetLabel: podMonitorTopologyLabel,
+					},
+					{
+						SourceLabels: []string{
+							podMonitorMetaLabel(clabernetesconstants.LabelTopologyNode),
+						},
+						TargetLabel: podMonitorLauncherLabel,
+					},
+				},
+			},
+		},
+	}
+
+	podMonitor := &unstructured.Unstructured{}
+	podMonitor.SetGroupVersionKind(PodMonitorGVK)
+	podMonitor.SetName(owningTopologyName)
+	podMonitor.SetNamespace(owningTopology.GetNamespace())
+	podMonitor.SetAnnotations(annotations)
+	podMonitor.SetLabels(labels)
+
+	// round trip the spec through json so it holds the same (plain json) types as the spec of an
+	// existing pod monitor read from the cluster -- otherwise Conforms could never be happy
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		r.log.Criticalf("failed marshaling pod monitor spec, error: %s", err)
+
+		return podMonitor
+	}
+
+	var specMap map[string]any
+
+	err = json.Unmarshal(specBytes, &specMap)
+	if err != nil {
+		r.log.Criticalf("failed unmarshaling pod monitor spec, error: %s", err)
+
+		return podMonitor
+	}
+
+	podMonitor.Object["spec"] = specMap
+
+	return podMonitor
+}
+
+// Conforms checks if the existing pod monitor conforms with the rendered pod monitor.
+func (r *PodMonitorReconciler) Conforms(
+	existingPodMonitor,
+	renderedPodMonitor *unstructured.Unstructured,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !reflect.DeepEqual(
+		existingPodMonitor.Object["spec"],
+		renderedPodMonitor.Object["spec"],
+	) {
+		return false
+	}
+
+	return clockSyncMetadataConforms(
+		metav1.ObjectMeta{
+			Annotations:     existingPodMonitor.GetAnnotations(),
+			Labels:          existingPodMonitor.GetLabels(),
+			OwnerReferences: existingPodMonitor.GetOwnerReferences(),
+		},
+		metav1.ObjectMeta{
+			Annotations: renderedPodMonitor.GetAnnotations(),
+			Labels:      renderedPodMonitor.GetLabels(),
+		},
+		expectedOwnerUID,
+	)
+}
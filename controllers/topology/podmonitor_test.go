@@ -0,0 +1,143 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderPodMonitor(t *testing.T) {
+	cases := []struct {
+		name             string
+		metrics          clabernetesapisv1alpha1.ConfigMetrics
+		expectedLabel    string
+		expectedEndpoint map[string]any
+	}{
+		{
+			name: "simple",
+			metrics: clabernetesapisv1alpha1.ConfigMetrics{
+				PodMonitor: true,
+			},
+			expectedEndpoint: map[string]any{
+				"port": "metrics",
+				"path": "/metrics",
+				"relabelings": []any{
+					map[string]any{
+						"sourceLabels": []any{
+							"__meta_kubernetes_pod_label_clabernetes_topologyOwner",
+						},
+						"targetLabel": "clab_topology",
+					},
+					map[string]any{
+						"sourceLabels": []any{
+							"__meta_kubernetes_pod_label_clabernetes_topologyNode",
+						},
+						"targetLabel": "clab_launcher",
+					},
+				},
+			},
+		},
+		{
+			name: "labels-and-interval",
+			metrics: clabernetesapisv1alpha1.ConfigMetrics{
+				PodMonitor:     true,
+				Labels:         map[string]string{"release": "kube-prometheus-stack"},
+				ScrapeInterval: "30s",
+			},
+			expectedLabel: "kube-prometheus-stack",
+			expectedEndpoint: map[string]any{
+				"port":     "metrics",
+				"path":     "/metrics",
+				"interval": "30s",
+				"relabelings": []any{
+					map[string]any{
+						"sourceLabels": []any{
+							"__meta_kubernetes_pod_label_clabernetes_topologyOwner",
+						},
+						"targetLabel": "clab_topology",
+					},
+					map[string]any{
+						"sourceLabels": []any{
+							"__meta_kubernetes_pod_label_clabernetes_topologyNode",
+						},
+						"targetLabel": "clab_launcher",
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				reconciler := clabernetescontrollerstopology.NewPodMonitorReconciler(
+					&claberneteslogging.FakeInstance{},
+					func() clabernetesconfig.Manager {
+						return clabernetesconfig.NewFakeManager(
+							clabernetesconfig.WithMetrics(testCase.metrics),
+						)
+					},
+				)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-monitor",
+						Namespace: "nowhere",
+						UID:       "abc",
+					},
+				}
+
+				actual := reconciler.Render(owningTopology)
+
+				if actual.GetKind() != "PodMonitor" {
+					clabernetestesthelper.FailOutput(t, actual.GetKind(), "PodMonitor")
+				}
+
+				if actual.GetLabels()["release"] != testCase.expectedLabel {
+					clabernetestesthelper.FailOutput(
+						t,
+						actual.GetLabels()["release"],
+						testCase.expectedLabel,
+					)
+				}
+
+				spec, _ := actual.Object["spec"].(map[string]any)
+
+				expectedSelector := map[string]any{
+					"matchLabels": map[string]any{
+						clabernetesconstants.LabelTopologyOwner: "test-monitor",
+					},
+				}
+
+				if !reflect.DeepEqual(spec["selector"], expectedSelector) {
+					clabernetestesthelper.FailOutput(t, spec["selector"], expectedSelector)
+				}
+
+				expectedEndpoints := []any{testCase.expectedEndpoint}
+
+				if !reflect.DeepEqual(spec["podMetricsEndpoints"], expectedEndpoints) {
+					clabernetestesthelper.FailOutput(
+						t,
+						spec["podMetricsEndpoints"],
+						expectedEndpoints,
+					)
+				}
+
+				actual.SetOwnerReferences([]metav1.OwnerReference{{UID: "abc"}})
+
+				if !reconciler.Conforms(actual, reconciler.Render(owningTopology), "abc") {
+					t.Fatalf("expected rendered pod monitor to conform with itself")
+				}
+			})
+	}
+}
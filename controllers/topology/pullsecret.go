@@ -0,0 +1,264 @@
+package topology
+
+import (
+	"context"
+	"reflect"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimeutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// PullSecretReconciler is a subcomponent of the "TopologyReconciler" but is exposed for testing
+// purposes. This is the component responsible for copying the (globally configured) propagated
+// image pull secret from the manager namespace into the namespace of a given topology. Just like
+// the launcher service account the copy is owned by all topologies in the namespace, so it is
+// garbage collected once the last topology in the namespace is removed.
+type PullSecretReconciler struct {
+	log                 claberneteslogging.Instance
+	client              ctrlruntimeclient.Client
+	configManagerGetter clabernetesconfig.ManagerGetterFunc
+	managerNamespace    string
+}
+
+// NewPullSecretReconciler returns an instance of PullSecretReconciler.
+func NewPullSecretReconciler(
+	log claberneteslogging.Instance,
+	client ctrlruntimeclient.Client,
+	configManagerGetter clabernetesconfig.ManagerGetterFunc,
+	managerNamespace string,
+) *PullSecretReconciler {
+	return &PullSecretReconciler{
+		log:                 log,
+		client:              client,
+		configManagerGetter: configManagerGetter,
+		managerNamespace:    managerNamespace,
+	}
+}
+
+// IsSource returns true if the given object is the (source) pull secret that is propagated to the
+// topology namespaces.
+func (r *PullSecretReconciler) IsSource(obj ctrlruntimeclient.Object) bool {
+	pullSecretName := r.configManagerGetter().GetPropagatedPullSecret()
+
+	return pullSecretName != "" &&
+		obj.GetNamespace() == r.managerNamespace &&
+		obj.GetName() == pullSecretName
+}
+
+// Reconcile copies the propagated pull secret into the namespace of the given topology (or updates
+// the existing copy). This is a no-op if no pull secret is to be propagated or if the topology
+// lives in the manager namespace, the source secret is already there after all.
+func (r *PullSecretReconciler) Reconcile(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) error {
+	pullSecretName := r.configManagerGetter().GetPropagatedPullSecret()
+	if pullSecretName == "" {
+		return nil
+	}
+
+	namespace := owningTopology.Namespace
+
+	if namespace == r.managerNamespace {
+		return nil
+	}
+
+	r.log.Debugf(
+		"reconciling propagated pull secret %q in namespace %q",
+		pullSecretName,
+		namespace,
+	)
+
+	sourceSecret := &k8scorev1.Secret{}
+
+	err := r.client.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: r.managerNamespace,
+			Name:      pullSecretName,
+		},
+		sourceSecret,
+	)
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			// nothing we can do about it, but also no reason to not deploy the topology -- maybe
+			// the launcher images are public after all
+			r.log.Warnf(
+				"propagated pull secret %q not found in manager namespace %q, not propagating",
+				pullSecretName,
+				r.managerNamespace,
+			)
+
+			return nil
+		}
+
+		return err
+	}
+
+	existingSecret := &k8scorev1.Secret{}
+
+	err = r.client.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: namespace,
+			Name:      pullSecretName,
+		},
+		existingSecret,
+	)
+	if err != nil {
+		if !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+
+		existingSecret = nil
+	}
+
+	if existingSecret != nil &&
+		existingSecret.Labels[clabernetesconstants.LabelPropagatedPullSecret] == "" {
+		r.log.Warnf(
+			"secret %q in namespace %q was not created by clabernetes, not overwriting it with"+
+				" the propagated pull secret",
+			pullSecretName,
+			namespace,
+		)
+
+		return nil
+	}
+
+	renderedSecret := r.Render(owningTopology, sourceSecret, existingSecret)
+
+	err = ctrlruntimeutil.SetOwnerReference(owningTopology, renderedSecret, r.client.Scheme())
+	if err != nil {
+		r.log.Criticalf(
+			"failed rendering propagated pull secret for namespace %q, error: %s",
+			namespace,
+			err,
+		)
+
+		return err
+	}
+
+	if existingSecret == nil {
+		r.log.Infof(
+			"no propagated pull secret found in namespace %q, creating...",
+			namespace,
+		)
+
+		err = r.client.Create(ctx, renderedSecret)
+		if err != nil {
+			r.log.Criticalf(
+				"failed creating propagated pull secret in namespace %q, error: %s",
+				namespace,
+				err,
+			)
+		}
+
+		return err
+	}
+
+	if r.Conforms(existingSecret, renderedSecret, owningTopology.UID) {
+		r.log.Debugf(
+			"propagated pull secret in namespace %q conforms, nothing to do",
+			namespace,
+		)
+
+		return nil
+	}
+
+	err = r.client.Update(ctx, renderedSecret)
+	if err != nil {
+		r.log.Criticalf(
+			"failed updating propagated pull secret in namespace %q, error: %s",
+			namespace,
+			err,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// Render renders the copy of the given source pull secret for the namespace of the given topology.
+// Exported for easy testing.
+func (r *PullSecretReconciler) Render(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	sourceSecret *k8scorev1.Secret,
+	existingSecret *k8scorev1.Secret,
+) *k8scorev1.Secret {
+	annotations, globalLabels := r.configManagerGetter().GetAllMetadata()
+
+	labels := map[string]string{
+		clabernetesconstants.LabelApp:                  clabernetesconstants.Clabernetes,
+		clabernetesconstants.LabelPropagatedPullSecret: sourceSecret.Namespace,
+	}
+
+	for k, v := range globalLabels {
+		labels[k] = v
+	}
+
+	renderedSecret := &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        sourceSecret.Name,
+			Namespace:   owningTopology.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Type: sourceSecret.Type,
+		Data: sourceSecret.Data,
+	}
+
+	// just like the service account, the copy is owned by all topologies in the namespace, so make
+	// sure to retain the existing owners
+	if existingSecret != nil {
+		renderedSecret.OwnerReferences = existingSecret.GetOwnerReferences()
+	}
+
+	return renderedSecret
+}
+
+// Conforms returns true if an existing propagated pull secret conforms with the rendered one.
+func (r *PullSecretReconciler) Conforms(
+	existingSecret,
+	renderedSecret *k8scorev1.Secret,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if existingSecret.Type != renderedSecret.Type {
+		return false
+	}
+
+	if !reflect.DeepEqual(existingSecret.Data, renderedSecret.Data) {
+		return false
+	}
+
+	if !clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
+		existingSecret.ObjectMeta.Annotations,
+		renderedSecret.ObjectMeta.Annotations,
+	) {
+		return false
+	}
+
+	if !clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
+		existingSecret.ObjectMeta.Labels,
+		renderedSecret.ObjectMeta.Labels,
+	) {
+		return false
+	}
+
+	for _, ownerRef := range existingSecret.OwnerReferences {
+		if ownerRef.UID == expectedOwnerUID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,123 @@
+package topology_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const renderPullSecretTestName = "pullsecret/render-pullsecret"
+
+func TestRenderPullSecret(t *testing.T) {
+	sourceSecret := &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "registry-credentials",
+			Namespace: "clabernetes",
+			Labels: map[string]string{
+				"some": "label",
+			},
+		},
+		Type: k8scorev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			k8scorev1.DockerConfigJsonKey: []byte(`{"auths":{"registry.example.com":{}}}`),
+		},
+	}
+
+	cases := []struct {
+		name           string
+		owningTopology *clabernetesapisv1alpha1.Topology
+		existingSecret *k8scorev1.Secret
+	}{
+		{
+			name: "simple",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-pullsecret-test",
+					Namespace: "lab",
+				},
+			},
+		},
+		{
+			name: "simple-existing-pullsecret",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-pullsecret-test",
+					Namespace: "lab",
+				},
+			},
+			existingSecret: &k8scorev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "registry-credentials",
+					Namespace: "lab",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "clabernetes.containerlab.dev/v1alpha1",
+							Kind:       "Topology",
+							Name:       "other-topology",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				reconciler := clabernetescontrollerstopology.NewPullSecretReconciler(
+					&claberneteslogging.FakeInstance{},
+					nil,
+					clabernetesconfig.GetFakeManager,
+					"clabernetes",
+				)
+
+				got := reconciler.Render(
+					testCase.owningTopology,
+					sourceSecret,
+					testCase.existingSecret,
+				)
+
+				if *clabernetestesthelper.Update {
+					clabernetestesthelper.WriteTestFixtureJSON(
+						t,
+						fmt.Sprintf(
+							"golden/%s/%s.json",
+							renderPullSecretTestName,
+							testCase.name,
+						),
+						got,
+					)
+				}
+
+				var want k8scorev1.Secret
+
+				err := json.Unmarshal(
+					clabernetestesthelper.ReadTestFixtureFile(
+						t,
+						fmt.Sprintf(
+							"golden/%s/%s.json",
+							renderPullSecretTestName,
+							testCase.name,
+						),
+					),
+					&want,
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				clabernetestesthelper.MarshaledEqual(t, got, want)
+			})
+	}
+}
@@ -0,0 +1,133 @@
+package topology
+
+import (
+	"fmt"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+)
+
+// readOnlyRootFilesystemWritablePaths is a mapping of the kinds (frr being "linux" nodes running
+// an frr image) whose nos containers can run with a read only root filesystem -> the paths that
+// need to stay writable for them.
+var readOnlyRootFilesystemWritablePaths = map[string][]string{ //nolint:gochecknoglobals
+	"linux": {"/tmp", "/var/tmp", "/run"},
+	"frr": {
+		"/tmp",
+		"/var/tmp",
+		"/run",
+		"/var/run/frr",
+		"/var/lib/frr",
+		"/var/log/frr",
+	},
+	"crpd":         {"/tmp", "/var/tmp", "/run", "/config", "/var/log"},
+	"juniper_crpd": {"/tmp", "/var/tmp", "/run", "/config", "/var/log"},
+}
+
+// ResolveReadOnlyRootFilesystemPaths returns the paths that need to be writable for the nos
+// container of the given node to run with a read only root filesystem, and false if the node
+// should not run with a read only root filesystem -- the topology does not ask for it, the node
+// does not run in native mode, or its kind is not known to tolerate it.
+func ResolveReadOnlyRootFilesystemPaths(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	nodeName string,
+) ([]string, bool) {
+	if !owningTopology.Spec.Deployment.ReadOnlyRootFilesystem || !ResolveNativeMode(owningTopology) {
+		return nil, false
+	}
+
+	nodeConfig, ok := clabernetesConfigs[nodeName]
+	if !ok {
+		return nil, false
+	}
+
+	nodeKind, _ := nodeConfig.Topology.GetNodeKindType(nodeName)
+
+	nodeKind = strings.ToLower(strings.TrimSpace(nodeKind))
+
+	if nodeKind == "linux" &&
+		strings.Contains(strings.ToLower(nodeConfig.Topology.GetNodeImage(nodeName)), "frr") {
+		nodeKind = "frr"
+	}
+
+	writablePaths, ok := readOnlyRootFilesystemWritablePaths[nodeKind]
+
+	return writablePaths, ok
+}
+
+// renderDeploymentReadOnlyRootFilesystem sets the nos container of native mode nodes of kinds that
+// tolerate it to run with a read only root filesystem, and mounts empty dirs on the paths the kind
+// needs to write to (unless something is mounted there already). This runs after the container
+// privileges are rendered as those replace the container security contexts wholesale.
+func (r *DeploymentReconciler) renderDeploymentReadOnlyRootFilesystem(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) {
+	writablePaths, ok := ResolveReadOnlyRootFilesystemPaths(
+		owningTopology,
+		clabernetesConfigs,
+		nodeName,
+	)
+	if !ok {
+		if owningTopology.Spec.Deployment.ReadOnlyRootFilesystem {
+			r.log.Debugf(
+				"node %q does not support a read only root filesystem, leaving it writable",
+				nodeName,
+			)
+		}
+
+		return
+	}
+
+	for i := range deployment.Spec.Template.Spec.Containers {
+		container := &deployment.Spec.Template.Spec.Containers[i]
+
+		if container.Name != nodeName {
+			continue
+		}
+
+		if container.SecurityContext == nil {
+			container.SecurityContext = &k8scorev1.SecurityContext{}
+		}
+
+		container.SecurityContext.ReadOnlyRootFilesystem = clabernetesutil.ToPointer(true)
+
+		existingMounts := map[string]struct{}{}
+		for _, volumeMount := range container.VolumeMounts {
+			existingMounts[strings.TrimSpace(volumeMount.MountPath)] = struct{}{}
+		}
+
+		for idx, writablePath := range writablePaths {
+			if _, exists := existingMounts[writablePath]; exists {
+				continue
+			}
+
+			volumeName := fmt.Sprintf("writable-%d", idx)
+
+			deployment.Spec.Template.Spec.Volumes = append(
+				deployment.Spec.Template.Spec.Volumes,
+				k8scorev1.Volume{
+					Name: volumeName,
+					VolumeSource: k8scorev1.VolumeSource{
+						EmptyDir: &k8scorev1.EmptyDirVolumeSource{},
+					},
+				},
+			)
+
+			container.VolumeMounts = append(
+				container.VolumeMounts,
+				k8scorev1.VolumeMount{
+					Name:      volumeName,
+					MountPath: writablePath,
+				},
+			)
+		}
+	}
+}
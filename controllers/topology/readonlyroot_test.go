@@ -0,0 +1,145 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+func TestResolveReadOnlyRootFilesystemPaths(t *testing.T) {
+	clabernetesConfigs := map[string]*clabernetesutilcontainerlab.Config{
+		"host1": {
+			Name: "host1",
+			Topology: &clabernetesutilcontainerlab.Topology{
+				Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+				Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+					"host1": {
+						Kind:  "linux",
+						Image: "alpine:latest",
+					},
+				},
+			},
+		},
+		"frr1": {
+			Name: "frr1",
+			Topology: &clabernetesutilcontainerlab.Topology{
+				Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+				Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+					"frr1": {
+						Kind:  "linux",
+						Image: "quay.io/frrouting/frr:10.1.0",
+					},
+				},
+			},
+		},
+		"srl1": {
+			Name: "srl1",
+			Topology: &clabernetesutilcontainerlab.Topology{
+				Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+				Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+					"srl1": {
+						Kind: "nokia_srlinux",
+					},
+				},
+			},
+		},
+	}
+
+	nativeTopology := &clabernetesapisv1alpha1.Topology{
+		Spec: clabernetesapisv1alpha1.TopologySpec{
+			Deployment: clabernetesapisv1alpha1.Deployment{
+				NativeMode:             clabernetesutil.ToPointer(true),
+				ReadOnlyRootFilesystem: true,
+			},
+		},
+	}
+
+	cases := []struct {
+		name          string
+		in            *clabernetesapisv1alpha1.Topology
+		nodeName      string
+		expectedPaths []string
+		expectedOk    bool
+	}{
+		{
+			name: "disabled",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						NativeMode: clabernetesutil.ToPointer(true),
+					},
+				},
+			},
+			nodeName:      "host1",
+			expectedPaths: nil,
+			expectedOk:    false,
+		},
+		{
+			name: "docker-mode",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						ReadOnlyRootFilesystem: true,
+					},
+				},
+			},
+			nodeName:      "host1",
+			expectedPaths: nil,
+			expectedOk:    false,
+		},
+		{
+			name:          "linux",
+			in:            nativeTopology,
+			nodeName:      "host1",
+			expectedPaths: []string{"/tmp", "/var/tmp", "/run"},
+			expectedOk:    true,
+		},
+		{
+			name:     "frr",
+			in:       nativeTopology,
+			nodeName: "frr1",
+			expectedPaths: []string{
+				"/tmp",
+				"/var/tmp",
+				"/run",
+				"/var/run/frr",
+				"/var/lib/frr",
+				"/var/log/frr",
+			},
+			expectedOk: true,
+		},
+		{
+			name:          "unsupported-kind",
+			in:            nativeTopology,
+			nodeName:      "srl1",
+			expectedPaths: nil,
+			expectedOk:    false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actualPaths, actualOk := clabernetescontrollerstopology.ResolveReadOnlyRootFilesystemPaths(
+					testCase.in,
+					clabernetesConfigs,
+					testCase.nodeName,
+				)
+				if actualOk != testCase.expectedOk {
+					clabernetestesthelper.FailOutput(t, actualOk, testCase.expectedOk)
+				}
+
+				if !reflect.DeepEqual(actualPaths, testCase.expectedPaths) {
+					clabernetestesthelper.FailOutput(t, actualPaths, testCase.expectedPaths)
+				}
+			})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
 )
 
@@ -59,6 +60,15 @@ func (c *Controller) Reconcile(
 	// reconcile the naming -- we *must* do this to ensure that our status field is set!
 	c.TopologyReconciler.ReconcileNaming(topology, reconcileData)
 
+	// reconcile the schedule before anything else so deployments are rendered w/ the correct
+	// (suspended or not) replica count
+	requeueAfter, err := c.TopologyReconciler.ReconcileSchedule(topology, reconcileData)
+	if err != nil {
+		c.BaseController.Log.Criticalf("failed reconciling topology schedule, error: %s", err)
+
+		return ctrlruntime.Result{}, err
+	}
+
 	err = c.processDefinition(topology, reconcileData)
 	if err != nil {
 		c.BaseController.Log.Criticalf("failed processing topology definition, error: %s", err)
@@ -66,6 +76,13 @@ func (c *Controller) Reconcile(
 		return ctrlruntime.Result{}, err
 	}
 
+	// scan the node images before reconciling the deployments, the image scanning policy may not
+	// allow some nodes to be deployed (yet)
+	imageScanRequeueAfter := c.TopologyReconciler.ReconcileImageScans(ctx, topology, reconcileData)
+	if imageScanRequeueAfter > 0 && (requeueAfter == 0 || requeueAfter > imageScanRequeueAfter) {
+		requeueAfter = imageScanRequeueAfter
+	}
+
 	err = c.reconcileResources(ctx, topology, reconcileData)
 	if err != nil {
 		return ctrlruntime.Result{}, err
@@ -99,9 +116,34 @@ func (c *Controller) Reconcile(
 		}
 	}
 
+	if !reconcileData.TopologyReady && len(reconcileData.NodeStatuses) > 0 &&
+		!topology.Status.Suspended && (requeueAfter == 0 || requeueAfter > stalledCheckRequeue) {
+		// we dont watch launcher heartbeat leases (they are renewed far too often to be worth
+		// reconciling on), so requeue not ready topologies to notice any stalled launchers
+		requeueAfter = stalledCheckRequeue
+	}
+
+	if apimachinerymeta.IsStatusConditionTrue(
+		topology.Status.Conditions,
+		conditionNodesDiskPressure,
+	) && !topology.Status.Suspended && (requeueAfter == 0 || requeueAfter > stalledCheckRequeue) {
+		// same deal for the disk usage the launchers publish on their leases, keep checking nodes
+		// under disk pressure so the condition (and status) follow the usage
+		requeueAfter = stalledCheckRequeue
+	}
+
+	if apimachinerymeta.IsStatusConditionTrue(
+		topology.Status.Conditions,
+		conditionMultusAttachmentFailed,
+	) && (requeueAfter == 0 || requeueAfter > stalledCheckRequeue) {
+		// and to notice missing network attachment definitions showing up so the pods waiting on
+		// them can be retried
+		requeueAfter = stalledCheckRequeue
+	}
+
 	c.BaseController.LogReconcileCompleteSuccess(req)
 
-	return ctrlruntime.Result{}, nil
+	return ctrlruntime.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (c *Controller) reconcileResources(
@@ -137,6 +179,60 @@ func (c *Controller) reconcileResources(
 		return err
 	}
 
+	err = c.TopologyReconciler.ReconcileWireTaps(
+		ctx,
+		topology,
+		reconcileData,
+	)
+	if err != nil {
+		c.BaseController.Log.Criticalf(
+			"failed reconciling clabernetes wire tap resources, error: %s",
+			err,
+		)
+
+		return err
+	}
+
+	err = c.TopologyReconciler.ReconcileWireGuardSecret(
+		ctx,
+		topology,
+		reconcileData,
+	)
+	if err != nil {
+		c.BaseController.Log.Criticalf(
+			"failed reconciling clabernetes wireguard secret, error: %s",
+			err,
+		)
+
+		return err
+	}
+
+	err = c.TopologyReconciler.ReconcileSlurpeethTLSSecret(
+		ctx,
+		topology,
+	)
+	if err != nil {
+		c.BaseController.Log.Criticalf(
+			"failed reconciling clabernetes slurpeeth tls secret, error: %s",
+			err,
+		)
+
+		return err
+	}
+
+	err = c.TopologyReconciler.ReconcileClockSync(
+		ctx,
+		topology,
+	)
+	if err != nil {
+		c.BaseController.Log.Criticalf(
+			"failed reconciling clabernetes clock sync resources, error: %s",
+			err,
+		)
+
+		return err
+	}
+
 	err = c.TopologyReconciler.ReconcileNetworkAttachmentDefinitions(
 		ctx,
 		topology,
@@ -161,6 +257,47 @@ func (c *Controller) reconcileResources(
 		return err
 	}
 
+	err = c.TopologyReconciler.ReconcileNetworkPolicy(
+		ctx,
+		topology,
+		reconcileData,
+	)
+	if err != nil {
+		c.BaseController.Log.Criticalf(
+			"failed reconciling clabernetes network policy, error: %s",
+			err,
+		)
+
+		return err
+	}
+
+	err = c.TopologyReconciler.ReconcileMgmtDNS(
+		ctx,
+		topology,
+		reconcileData,
+	)
+	if err != nil {
+		c.BaseController.Log.Criticalf(
+			"failed reconciling clabernetes mgmt dns records, error: %s",
+			err,
+		)
+
+		return err
+	}
+
+	err = c.TopologyReconciler.ReconcilePodMonitor(
+		ctx,
+		topology,
+	)
+	if err != nil {
+		c.BaseController.Log.Criticalf(
+			"failed reconciling clabernetes pod monitor, error: %s",
+			err,
+		)
+
+		return err
+	}
+
 	err = c.TopologyReconciler.ReconcilePersistentVolumeClaim(
 		ctx,
 		topology,
@@ -2,6 +2,7 @@ package topology
 
 import (
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
 	clabernetesutil "github.com/srl-labs/clabernetes/util"
 	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
 	"gopkg.in/yaml.v3"
@@ -18,17 +19,44 @@ type ReconcileData struct {
 	PreviousConfigs      map[string]*clabernetesutilcontainerlab.Config
 	ResolvedConfigs      map[string]*clabernetesutilcontainerlab.Config
 	ResolvedConfigsBytes []byte
+	// LegacyConfigHash is the (plain, non canonical) hash of the resolved configs, the config hash
+	// of topologies last reconciled before the canonical config hash existed.
+	LegacyConfigHash string
 
 	ResolvedTunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel
 
+	// WireTaps holds the (sorted) names of the wire taps of the topology, their tunnels are in the
+	// resolved tunnels under the same names.
+	WireTaps []string
+
 	ResolvedExposedPorts map[string]*clabernetesapisv1alpha1.ExposedPorts
 
+	NodeDependencies map[string][]string
+
 	PreviousNodeStatuses map[string]string
 	NodeStatuses         map[string]string
 	TopologyReady        bool
 
+	PreviousNodeTerminations map[string]clabernetesapisv1alpha1.NodeTermination
+	NodeTerminations         map[string]clabernetesapisv1alpha1.NodeTermination
+
+	PreviousNodeDiskUsage map[string]int
+	NodeDiskUsage         map[string]int
+
+	PreviousNodeVersions map[string]clabernetesapisv1alpha1.NodeVersions
+	NodeVersions         map[string]clabernetesapisv1alpha1.NodeVersions
+
+	MultusAttachmentFailures map[string]string
+
 	NodesNeedingReboot clabernetesutil.StringSet
 
+	PreviousImageScans map[string]clabernetesapisv1alpha1.ImageScan
+	ImageScans         map[string]clabernetesapisv1alpha1.ImageScan
+	ImageBlockedNodes  clabernetesutil.StringSet
+
+	PreviousTimeline []clabernetesapisv1alpha1.TimelineEvent
+	TimelineEvents   []clabernetesapisv1alpha1.TimelineEvent
+
 	ShouldUpdateResource bool
 }
 
@@ -54,6 +82,23 @@ func NewReconcileData(
 		PreviousNodeStatuses: owningTopology.Status.NodeReadiness,
 		NodeStatuses:         make(map[string]string),
 		NodesNeedingReboot:   clabernetesutil.NewStringSet(),
+
+		PreviousNodeTerminations: owningTopology.Status.NodeTerminations,
+		NodeTerminations:         make(map[string]clabernetesapisv1alpha1.NodeTermination),
+
+		PreviousNodeDiskUsage: owningTopology.Status.NodeDiskUsage,
+		NodeDiskUsage:         make(map[string]int),
+
+		PreviousNodeVersions: owningTopology.Status.NodeVersions,
+		NodeVersions:         make(map[string]clabernetesapisv1alpha1.NodeVersions),
+
+		MultusAttachmentFailures: make(map[string]string),
+
+		PreviousImageScans: owningTopology.Status.ImageScans,
+		ImageScans:         make(map[string]clabernetesapisv1alpha1.ImageScan),
+		ImageBlockedNodes:  clabernetesutil.NewStringSet(),
+
+		PreviousTimeline: owningTopology.Status.Timeline,
 	}
 
 	for nodeName, nodeConfig := range status.Configs {
@@ -91,7 +136,16 @@ func (r *ReconcileData) SetStatus(
 	}
 
 	owningTopologyStatus.NodeReadiness = r.NodeStatuses
+	owningTopologyStatus.NodeTerminations = r.NodeTerminations
+	owningTopologyStatus.NodeDiskUsage = r.NodeDiskUsage
+	owningTopologyStatus.NodeVersions = r.NodeVersions
+	owningTopologyStatus.ImageScans = r.ImageScans
 	owningTopologyStatus.TopologyReady = r.TopologyReady
+	owningTopologyStatus.Timeline = MergeTimeline(
+		r.PreviousTimeline,
+		r.TimelineEvents,
+		clabernetesconstants.TimelineMaxEvents,
+	)
 
 	return nil
 }
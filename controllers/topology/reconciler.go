@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"slices"
+	"strings"
 	"time"
 
 	clabernetesapis "github.com/srl-labs/clabernetes/apis"
@@ -16,6 +17,7 @@ import (
 	clabernetesutil "github.com/srl-labs/clabernetes/util"
 	k8sappsv1 "k8s.io/api/apps/v1"
 	k8scorev1 "k8s.io/api/core/v1"
+	k8snetworkingv1 "k8s.io/api/networking/v1"
 	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
 	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,6 +28,11 @@ import (
 	ctrlruntimeutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+const (
+	conditionNodesPreempted = "NodesPreempted"
+	podStatusReasonEvicted  = "Evicted"
+)
+
 // Reconciler (TopologyReconciler) is the base clabernetes topology reconciler that is embedded in
 // all clabernetes topology controllers, it provides common methods for reconciling the
 // common/standard resources that represent a clabernetes object (configmap, deployments,
@@ -34,11 +41,28 @@ type Reconciler struct {
 	Log    claberneteslogging.Instance
 	Client ctrlruntimeclient.Client
 
+	// reader is an uncached reader, used for things we do not want to cache (watch) like events
+	reader ctrlruntimeclient.Reader
+
+	// namespaceScoped is true when the manager only has permissions in its own namespace, so
+	// cluster scoped resources (like nodes) are off limits
+	namespaceScoped bool
+
 	serviceAccountReconciler *ServiceAccountReconciler
 	roleBindingReconciler    *RoleBindingReconciler
+	pullSecretReconciler     *PullSecretReconciler
 	configMapReconciler      *ConfigMapReconciler
 	connectivityReconciler   *ConnectivityReconciler
 	nadReconciler            *NetworkAttachmentDefinitionReconciler
+	wireGuardReconciler      *WireGuardSecretReconciler
+	slurpeethTLSReconciler   *SlurpeethTLSSecretReconciler
+	clockSyncReconciler      *ClockSyncReconciler
+	wireTapReconciler        *WireTapReconciler
+	networkPolicyReconciler  *NetworkPolicyReconciler
+	mgmtDNSReconciler        *MgmtDNSReconciler
+	podMonitorReconciler     *PodMonitorReconciler
+
+	configManagerGetter clabernetesconfig.ManagerGetterFunc
 
 	// these ones are exposed for testing purposes. no reason to not expose them really anyway so
 	// no big deal. not exposing the others at this point since there isnt a reason to (yet, but
@@ -47,6 +71,8 @@ type Reconciler struct {
 	ServiceExposeReconciler         *ServiceExposeReconciler
 	PersistentVolumeClaimReconciler *PersistentVolumeClaimReconciler
 	DeploymentReconciler            *DeploymentReconciler
+	StatefulSetReconciler           *StatefulSetReconciler
+	ImageScanReconciler             *ImageScanReconciler
 }
 
 // NewReconciler creates a new generic Reconciler (TopologyReconciler).
@@ -57,11 +83,32 @@ func NewReconciler(
 	managerAppName,
 	managerNamespace,
 	criKind string,
+	namespaceScoped bool,
 	configManagerGetter clabernetesconfig.ManagerGetterFunc,
 ) *Reconciler {
+	if reader == nil {
+		reader = client
+	}
+
+	pvcReconciler := NewPersistentVolumeClaimReconciler(
+		log,
+		configManagerGetter,
+	)
+
+	deploymentReconciler := NewDeploymentReconciler(
+		log,
+		managerAppName,
+		managerNamespace,
+		criKind,
+		configManagerGetter,
+	)
+
 	return &Reconciler{
-		Log:    log,
-		Client: client,
+		Log:                 log,
+		Client:              client,
+		reader:              reader,
+		namespaceScoped:     namespaceScoped,
+		configManagerGetter: configManagerGetter,
 		serviceAccountReconciler: NewServiceAccountReconciler(
 			log,
 			client,
@@ -73,6 +120,13 @@ func NewReconciler(
 			client,
 			configManagerGetter,
 			managerAppName,
+			namespaceScoped,
+		),
+		pullSecretReconciler: NewPullSecretReconciler(
+			log,
+			client,
+			configManagerGetter,
+			managerNamespace,
 		),
 		configMapReconciler: NewConfigMapReconciler(
 			log,
@@ -86,6 +140,34 @@ func NewReconciler(
 			log,
 			configManagerGetter,
 		),
+		wireGuardReconciler: NewWireGuardSecretReconciler(
+			log,
+			configManagerGetter,
+		),
+		slurpeethTLSReconciler: NewSlurpeethTLSSecretReconciler(
+			log,
+			configManagerGetter,
+		),
+		clockSyncReconciler: NewClockSyncReconciler(
+			log,
+			configManagerGetter,
+		),
+		wireTapReconciler: NewWireTapReconciler(
+			log,
+			configManagerGetter,
+		),
+		networkPolicyReconciler: NewNetworkPolicyReconciler(
+			log,
+			configManagerGetter,
+		),
+		mgmtDNSReconciler: NewMgmtDNSReconciler(
+			log,
+			configManagerGetter,
+		),
+		podMonitorReconciler: NewPodMonitorReconciler(
+			log,
+			configManagerGetter,
+		),
 		ServiceFabricReconciler: NewServiceFabricReconciler(
 			log,
 			configManagerGetter,
@@ -94,16 +176,18 @@ func NewReconciler(
 			log,
 			configManagerGetter,
 		),
-		PersistentVolumeClaimReconciler: NewPersistentVolumeClaimReconciler(
+		PersistentVolumeClaimReconciler: pvcReconciler,
+		DeploymentReconciler:            deploymentReconciler,
+		StatefulSetReconciler: NewStatefulSetReconciler(
 			log,
-			configManagerGetter,
+			deploymentReconciler,
+			pvcReconciler,
 		),
-		DeploymentReconciler: NewDeploymentReconciler(
+		ImageScanReconciler: NewImageScanReconciler(
 			log,
-			managerAppName,
-			managerNamespace,
-			criKind,
+			client,
 			configManagerGetter,
+			managerNamespace,
 		),
 	}
 }
@@ -126,6 +210,11 @@ func (r *Reconciler) ReconcileNamespaceResources(
 		return err
 	}
 
+	err = r.ReconcilePullSecret(ctx, owningTopology)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -156,6 +245,76 @@ func (r *Reconciler) ReconcileNaming(
 	}
 }
 
+// ReconcileSchedule resolves if the Topology should currently be active or suspended per its
+// schedule and updates (if needed) the Topology status to reflect this. It returns the duration
+// until the next schedule transition so the controller can requeue the Topology at that time, or
+// zero if there is no schedule (or no upcoming transition).
+func (r *Reconciler) ReconcileSchedule(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) (time.Duration, error) {
+	now := time.Now()
+
+	active, nextTransition, err := ResolveSchedule(owningTopology, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var nextActivation *metav1.Time
+
+	if !active && !nextTransition.IsZero() {
+		nextActivation = clabernetesutil.ToPointer(
+			metav1.NewTime(nextTransition.Truncate(time.Second)),
+		)
+	}
+
+	if owningTopology.Status.Suspended != !active ||
+		!owningTopology.Status.NextActivation.Equal(nextActivation) {
+		if active {
+			r.Log.Info("topology schedule window is open, activating topology")
+		} else {
+			r.Log.Infof(
+				"topology is outside of all schedule windows, suspending until %s",
+				nextTransition,
+			)
+		}
+
+		owningTopology.Status.Suspended = !active
+		owningTopology.Status.NextActivation = nextActivation
+
+		reconcileData.ShouldUpdateResource = true
+	}
+
+	if nextTransition.IsZero() {
+		return 0, nil
+	}
+
+	return nextTransition.Sub(now), nil
+}
+
+// ReconcileImageScans runs the (optional) pre-deploy image scanning gate for the Topology: the
+// images of its nodes are scanned with the globally configured scanner, the results recorded in the
+// Topology status, and the nodes the image scanning policy does not allow to be deployed (yet) are
+// recorded in the reconcile data. It returns how soon the Topology should be requeued to pick up
+// pending scans, or zero if there are none.
+func (r *Reconciler) ReconcileImageScans(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) time.Duration {
+	requeueAfter := r.ImageScanReconciler.Reconcile(ctx, owningTopology, reconcileData)
+
+	r.reconcileImageScanCondition(owningTopology, reconcileData)
+
+	// empty vs nil maps are not deep equal, but we dont care about that difference here
+	if (len(reconcileData.ImageScans) != 0 || len(reconcileData.PreviousImageScans) != 0) &&
+		!reflect.DeepEqual(reconcileData.ImageScans, reconcileData.PreviousImageScans) {
+		reconcileData.ShouldUpdateResource = true
+	}
+
+	return requeueAfter
+}
+
 // ReconcileServiceAccount reconciles the service account for the given namespace -- note that there
 // is only *one* service account per namespace, but its simply reconciled each time a Topology is
 // reconciled to make life easy. This and the RoleBinding are the only resources we need to worry
@@ -180,6 +339,22 @@ func (r *Reconciler) ReconcileRoleBinding(
 	return r.roleBindingReconciler.Reconcile(ctx, owningTopology)
 }
 
+// ReconcilePullSecret reconciles the copy of the propagated image pull secret (if any is
+// configured) for the given namespace -- like the ServiceAccount there is only *one* per
+// namespace and it is owned by all Topologies in the namespace.
+func (r *Reconciler) ReconcilePullSecret(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) error {
+	return r.pullSecretReconciler.Reconcile(ctx, owningTopology)
+}
+
+// IsPropagatedPullSecretSource returns true if the given object is the image pull secret that is
+// propagated to all topology namespaces.
+func (r *Reconciler) IsPropagatedPullSecretSource(obj ctrlruntimeclient.Object) bool {
+	return r.pullSecretReconciler.IsSource(obj)
+}
+
 // ReconcileConfigMap reconciles the primary configmap containing clabernetes configs, tunnel
 // information, pull secret information, and perhaps more in the future.
 func (r *Reconciler) ReconcileConfigMap(
@@ -196,6 +371,17 @@ func (r *Reconciler) ReconcileConfigMap(
 		return err
 	}
 
+	reconcileData.LegacyConfigHash = configHash
+
+	if r.configManagerGetter().IsFeatureGateEnabled(
+		clabernetesconstants.FeatureGateCanonicalConfigHash,
+	) {
+		configHash, err = HashResolvedConfigs(reconcileData.ResolvedConfigs)
+		if err != nil {
+			return err
+		}
+	}
+
 	reconcileData.ResolvedConfigsBytes = configBytes
 	reconcileData.ResolvedHashes.Config = configHash
 
@@ -290,6 +476,70 @@ func (r *Reconciler) ReconcileConnectivity(
 		Name:      owningTopology.GetName(),
 	}
 
+	unmatchedImpairments := ApplyLinkImpairments(owningTopology, reconcileData.ResolvedTunnels)
+	if len(unmatchedImpairments) > 0 {
+		r.Log.Warnf(
+			"link impairment endpoint(s) %q do not match any link in the topology, ignoring",
+			unmatchedImpairments,
+		)
+	}
+
+	unmatchedCaptures := ApplyPacketCaptures(owningTopology, reconcileData.ResolvedTunnels)
+	if len(unmatchedCaptures) > 0 {
+		r.Log.Warnf(
+			"packet capture endpoint(s) %q do not match any link in the topology, ignoring",
+			unmatchedCaptures,
+		)
+	}
+
+	unmatchedDisabledLinks := ApplyDisabledLinks(owningTopology, reconcileData.ResolvedTunnels)
+	if len(unmatchedDisabledLinks) > 0 {
+		r.Log.Warnf(
+			"disabled link endpoint(s) %q do not match any link in the topology, ignoring",
+			unmatchedDisabledLinks,
+		)
+	}
+
+	if len(owningTopology.Spec.LinkConnectivity) > 0 && !linkConnectivityEnabled(owningTopology) {
+		r.Log.Warnf(
+			"link connectivity overrides are not supported with %q connectivity, ignoring",
+			owningTopology.Spec.Connectivity,
+		)
+	}
+
+	unmatchedLinkConnectivity := ApplyLinkConnectivity(
+		owningTopology,
+		reconcileData.ResolvedTunnels,
+	)
+	if len(unmatchedLinkConnectivity) > 0 {
+		r.Log.Warnf(
+			"link connectivity endpoint(s) %q do not match any link in the topology, ignoring",
+			unmatchedLinkConnectivity,
+		)
+	}
+
+	if len(owningTopology.Spec.WireTaps) > 0 && !wireTapsEnabled(owningTopology) {
+		r.Log.Warnf(
+			"wire taps %q are not supported with %q connectivity, ignoring",
+			owningTopology.Spec.WireTaps,
+			owningTopology.Spec.Connectivity,
+		)
+	}
+
+	var unmatchedWireTaps []string
+
+	reconcileData.WireTaps, unmatchedWireTaps = ApplyWireTaps(
+		owningTopology,
+		reconcileData.ResolvedTunnels,
+		r.configManagerGetter,
+	)
+	if len(unmatchedWireTaps) > 0 {
+		r.Log.Warnf(
+			"wire tap endpoint(s) %q do not match any vxlan link in the topology, ignoring",
+			unmatchedWireTaps,
+		)
+	}
+
 	renderedConnectivity := r.connectivityReconciler.Render(
 		owningTopology,
 		reconcileData.ResolvedTunnels,
@@ -313,6 +563,21 @@ func (r *Reconciler) ReconcileConnectivity(
 		reconcileData.ResolvedTunnels,
 	)
 
+	// carry over whatever the launchers have reported so we dont clobber it when updating the spec
+	renderedConnectivity.Status = pruneConnectivityStatus(
+		existingConnectivity.Status,
+		reconcileData.ResolvedTunnels,
+	)
+
+	r.reconcileTunnelsDownCondition(owningTopology, reconcileData, renderedConnectivity.Status)
+
+	for _, event := range TunnelTimelineEvents(
+		renderedConnectivity.Status,
+		reconcileData.ResolvedTunnels,
+	) {
+		reconcileData.RecordTimelineEvent(event)
+	}
+
 	if err != nil {
 		// get error was not found, we need to create
 		return r.createObj(
@@ -328,7 +593,7 @@ func (r *Reconciler) ReconcileConnectivity(
 		existingConnectivity,
 		renderedConnectivity,
 		owningTopology.GetUID(),
-	) {
+	) && reflect.DeepEqual(existingConnectivity.Status, renderedConnectivity.Status) {
 		return nil
 	}
 
@@ -340,102 +605,1067 @@ func (r *Reconciler) ReconcileConnectivity(
 	return r.updateObj(ctx, renderedConnectivity, clabernetesapis.Connectivity)
 }
 
-// ReconcileServices reconciles all the services for a clabernetes Topology.
-func (r *Reconciler) ReconcileServices(
+// ReconcileWireGuardSecret reconciles the secrets holding the wireguard keys and overlay addresses
+// for the launchers of a Topology -- this is only relevant for Topologies using the "wireguard"
+// connectivity flavor.
+func (r *Reconciler) ReconcileWireGuardSecret(
 	ctx context.Context,
 	owningTopology *clabernetesapisv1alpha1.Topology,
 	reconcileData *ReconcileData,
 ) error {
-	err := r.ReconcileServiceFabric(
+	if owningTopology.Spec.Connectivity != clabernetesconstants.ConnectivityWireGuard {
+		return nil
+	}
+
+	existingSecret, err := r.getWireGuardSecret(
 		ctx,
 		owningTopology,
-		reconcileData,
+		wireGuardSecretName(owningTopology.GetName()),
 	)
 	if err != nil {
-		r.Log.Criticalf(
-			"failed reconciling clabernetes fabric services, error: %s", err,
-		)
+		return err
+	}
+
+	nodeNames := make([]string, 0, len(reconcileData.ResolvedConfigs))
+
+	for nodeName := range reconcileData.ResolvedConfigs {
+		nodeNames = append(nodeNames, nodeName)
+	}
 
+	renderedSecret, err := r.wireGuardReconciler.Render(
+		owningTopology,
+		existingSecret,
+		nodeNames,
+	)
+	if err != nil {
 		return err
 	}
 
-	err = r.ReconcileServicesExpose(
+	err = r.applyWireGuardSecret(ctx, owningTopology, existingSecret, renderedSecret)
+	if err != nil {
+		return err
+	}
+
+	existingPeersSecret, err := r.getWireGuardSecret(
 		ctx,
 		owningTopology,
-		reconcileData,
+		wireGuardPeersSecretName(owningTopology.GetName()),
 	)
 	if err != nil {
-		r.Log.Criticalf(
-			"failed reconciling clabernetes expose services, error: %s", err,
-		)
-
 		return err
 	}
 
-	return nil
+	return r.applyWireGuardSecret(
+		ctx,
+		owningTopology,
+		existingPeersSecret,
+		r.wireGuardReconciler.RenderPeers(owningTopology, renderedSecret),
+	)
 }
 
-// ReconcileServiceFabric reconciles the service used for "fabric" (inter node) connectivity.
-func (r *Reconciler) ReconcileServiceFabric(
+// getWireGuardSecret returns the wireguard secret with the given name, or nil if it does not
+// exist (yet).
+func (r *Reconciler) getWireGuardSecret(
 	ctx context.Context,
 	owningTopology *clabernetesapisv1alpha1.Topology,
-	reconcileData *ReconcileData,
-) error {
-	serviceTypeName := fmt.Sprintf("fabric %s", clabernetesconstants.KubernetesService)
+	name string,
+) (*k8scorev1.Secret, error) {
+	existingSecret := &k8scorev1.Secret{}
 
-	services, err := ReconcileResolve(
+	err := r.Client.Get(
 		ctx,
-		r,
-		&k8scorev1.Service{},
-		&k8scorev1.ServiceList{},
-		serviceTypeName,
-		owningTopology,
-		reconcileData.ResolvedConfigs,
-		r.ServiceFabricReconciler.Resolve,
+		apimachinerytypes.NamespacedName{
+			Namespace: owningTopology.GetNamespace(),
+			Name:      name,
+		},
+		existingSecret,
 	)
 	if err != nil {
-		return err
+		if apimachineryerrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
 	}
 
-	r.Log.Info("pruning extraneous fabric services")
+	return existingSecret, nil
+}
 
-	for _, extraService := range services.Extra {
-		err = r.deleteObj(
+// applyWireGuardSecret creates the given rendered wireguard secret, or updates the existing one
+// if it does not conform with it.
+func (r *Reconciler) applyWireGuardSecret(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	existingSecret,
+	renderedSecret *k8scorev1.Secret,
+) error {
+	if existingSecret == nil {
+		return r.createObj(
 			ctx,
-			extraService,
-			serviceTypeName,
+			owningTopology,
+			renderedSecret,
+			clabernetesconstants.KubernetesSecret,
 		)
-		if err != nil {
+	}
+
+	if r.wireGuardReconciler.Conforms(
+		existingSecret,
+		renderedSecret,
+		owningTopology.GetUID(),
+	) {
+		return nil
+	}
+
+	err := ctrlruntimeutil.SetOwnerReference(owningTopology, renderedSecret, r.Client.Scheme())
+	if err != nil {
+		return err
+	}
+
+	renderedSecret.ResourceVersion = existingSecret.ResourceVersion
+
+	return r.updateObj(ctx, renderedSecret, clabernetesconstants.KubernetesSecret)
+}
+
+// ReconcileSlurpeethTLSSecret reconciles the secret holding the ca and certificate the launchers
+// of a Topology use to wrap "slurpeeth" connectivity in mutual tls -- this is only relevant for
+// Topologies using "slurpeeth" connectivity with tls enabled and without a user provided secret.
+func (r *Reconciler) ReconcileSlurpeethTLSSecret(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) error {
+	if !slurpeethTLSEnabled(owningTopology) || owningTopology.Spec.SlurpeethTLS.SecretName != "" {
+		return nil
+	}
+
+	namespacedName := apimachinerytypes.NamespacedName{
+		Namespace: owningTopology.GetNamespace(),
+		Name:      slurpeethTLSSecretName(owningTopology.GetName()),
+	}
+
+	existingSecret := &k8scorev1.Secret{}
+
+	err := r.Client.Get(
+		ctx,
+		namespacedName,
+		existingSecret,
+	)
+	if err != nil {
+		if !apimachineryerrors.IsNotFound(err) {
 			return err
 		}
-	}
 
-	r.Log.Info("creating missing fabric services")
+		existingSecret = nil
+	}
 
-	renderedMissingServices := r.ServiceFabricReconciler.RenderAll(
+	renderedSecret, err := r.slurpeethTLSReconciler.Render(
 		owningTopology,
-		services.Missing,
+		existingSecret,
 	)
+	if err != nil {
+		return err
+	}
 
-	for _, renderedMissingService := range renderedMissingServices {
-		err = r.createObj(
+	if existingSecret == nil {
+		return r.createObj(
 			ctx,
 			owningTopology,
-			renderedMissingService,
-			serviceTypeName,
+			renderedSecret,
+			clabernetesconstants.KubernetesSecret,
 		)
-		if err != nil {
-			return err
-		}
 	}
 
-	r.Log.Info("enforcing desired state on fabric services")
+	if r.slurpeethTLSReconciler.Conforms(
+		existingSecret,
+		renderedSecret,
+		owningTopology.GetUID(),
+	) {
+		return nil
+	}
 
-	for existingCurrentServiceNodeName, existingCurrentService := range services.Current {
-		renderedCurrentService := r.ServiceFabricReconciler.Render(
-			owningTopology,
-			existingCurrentServiceNodeName,
-		)
+	err = ctrlruntimeutil.SetOwnerReference(owningTopology, renderedSecret, r.Client.Scheme())
+	if err != nil {
+		return err
+	}
+
+	renderedSecret.ResourceVersion = existingSecret.ResourceVersion
+
+	return r.updateObj(ctx, renderedSecret, clabernetesconstants.KubernetesSecret)
+}
+
+// ReconcileClockSync reconciles the deployment and service of the (optional) topology wide ntp
+// server -- they are created when the topology asks for an ntp server and removed once it no
+// longer does.
+func (r *Reconciler) ReconcileClockSync(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) error {
+	namespacedName := apimachinerytypes.NamespacedName{
+		Namespace: owningTopology.GetNamespace(),
+		Name:      ntpServerName(owningTopology.GetName()),
+	}
+
+	existingDeployment := &k8sappsv1.Deployment{}
+
+	err := r.getObj(
+		ctx,
+		existingDeployment,
+		namespacedName,
+		clabernetesconstants.KubernetesDeployment,
+	)
+	if err != nil {
+		if !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+
+		existingDeployment = nil
+	}
+
+	existingService := &k8scorev1.Service{}
+
+	err = r.getObj(ctx, existingService, namespacedName, clabernetesconstants.KubernetesService)
+	if err != nil {
+		if !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+
+		existingService = nil
+	}
+
+	if !ntpServerEnabled(owningTopology) {
+		if existingDeployment != nil {
+			err = r.deleteObj(ctx, existingDeployment, clabernetesconstants.KubernetesDeployment)
+			if err != nil {
+				return err
+			}
+		}
+
+		if existingService != nil {
+			return r.deleteObj(ctx, existingService, clabernetesconstants.KubernetesService)
+		}
+
+		return nil
+	}
+
+	renderedDeployment := r.clockSyncReconciler.RenderDeployment(owningTopology)
+
+	switch {
+	case existingDeployment == nil:
+		err = r.createObj(
+			ctx,
+			owningTopology,
+			renderedDeployment,
+			clabernetesconstants.KubernetesDeployment,
+		)
+	case !r.clockSyncReconciler.ConformsDeployment(
+		existingDeployment,
+		renderedDeployment,
+		owningTopology.GetUID(),
+	):
+		err = ctrlruntimeutil.SetOwnerReference(
+			owningTopology,
+			renderedDeployment,
+			r.Client.Scheme(),
+		)
+		if err != nil {
+			return err
+		}
+
+		renderedDeployment.ResourceVersion = existingDeployment.ResourceVersion
+
+		err = r.updateObj(ctx, renderedDeployment, clabernetesconstants.KubernetesDeployment)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	renderedService := r.clockSyncReconciler.RenderService(owningTopology)
+
+	if existingService == nil {
+		return r.createObj(
+			ctx,
+			owningTopology,
+			renderedService,
+			clabernetesconstants.KubernetesService,
+		)
+	}
+
+	if r.clockSyncReconciler.ConformsService(
+		existingService,
+		renderedService,
+		owningTopology.GetUID(),
+	) {
+		return nil
+	}
+
+	err = ctrlruntimeutil.SetOwnerReference(owningTopology, renderedService, r.Client.Scheme())
+	if err != nil {
+		return err
+	}
+
+	// the cluster ip is immutable (and assigned by the api server), so carry it over
+	renderedService.Spec.ClusterIP = existingService.Spec.ClusterIP
+	renderedService.Spec.ClusterIPs = existingService.Spec.ClusterIPs
+	renderedService.ResourceVersion = existingService.ResourceVersion
+
+	return r.updateObj(ctx, renderedService, clabernetesconstants.KubernetesService)
+}
+
+// ReconcileWireTaps reconciles the deployments and services of the wire tap pods of a clabernetes
+// Topology, this has to happen after ReconcileConnectivity as that is what sets up the (tunnels of
+// the) wire taps in the reconcile data. The wire taps of links that are no longer tapped are
+// removed.
+func (r *Reconciler) ReconcileWireTaps(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) error {
+	wireTapLabels := ctrlruntimeclient.MatchingLabels{
+		clabernetesconstants.LabelWireTapTopology: owningTopology.GetName(),
+	}
+
+	existingDeployments := &k8sappsv1.DeploymentList{}
+
+	err := r.Client.List(
+		ctx,
+		existingDeployments,
+		ctrlruntimeclient.InNamespace(owningTopology.GetNamespace()),
+		wireTapLabels,
+	)
+	if err != nil {
+		return err
+	}
+
+	existingServices := &k8scorev1.ServiceList{}
+
+	err = r.Client.List(
+		ctx,
+		existingServices,
+		ctrlruntimeclient.InNamespace(owningTopology.GetNamespace()),
+		wireTapLabels,
+	)
+	if err != nil {
+		return err
+	}
+
+	existingDeploymentsByName := map[string]*k8sappsv1.Deployment{}
+
+	for idx := range existingDeployments.Items {
+		existingDeployment := &existingDeployments.Items[idx]
+
+		if !slices.Contains(reconcileData.WireTaps, existingDeployment.GetName()) {
+			err = r.deleteObj(ctx, existingDeployment, clabernetesconstants.KubernetesDeployment)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		existingDeploymentsByName[existingDeployment.GetName()] = existingDeployment
+	}
+
+	existingServicesByName := map[string]*k8scorev1.Service{}
+
+	for idx := range existingServices.Items {
+		existingService := &existingServices.Items[idx]
+
+		if !slices.Contains(reconcileData.WireTaps, existingService.GetName()) {
+			err = r.deleteObj(ctx, existingService, clabernetesconstants.KubernetesService)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		existingServicesByName[existingService.GetName()] = existingService
+	}
+
+	for _, name := range reconcileData.WireTaps {
+		err = r.reconcileWireTapDeployment(
+			ctx,
+			owningTopology,
+			r.wireTapReconciler.RenderDeployment(
+				owningTopology,
+				name,
+				reconcileData.ResolvedTunnels[name],
+			),
+			existingDeploymentsByName[name],
+		)
+		if err != nil {
+			return err
+		}
+
+		err = r.reconcileWireTapService(
+			ctx,
+			owningTopology,
+			r.wireTapReconciler.RenderService(owningTopology, name),
+			existingServicesByName[name],
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileWireTapDeployment(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	renderedDeployment,
+	existingDeployment *k8sappsv1.Deployment,
+) error {
+	if existingDeployment == nil {
+		return r.createObj(
+			ctx,
+			owningTopology,
+			renderedDeployment,
+			clabernetesconstants.KubernetesDeployment,
+		)
+	}
+
+	if r.wireTapReconciler.ConformsDeployment(
+		existingDeployment,
+		renderedDeployment,
+		owningTopology.GetUID(),
+	) {
+		return nil
+	}
+
+	err := ctrlruntimeutil.SetOwnerReference(owningTopology, renderedDeployment, r.Client.Scheme())
+	if err != nil {
+		return err
+	}
+
+	renderedDeployment.ResourceVersion = existingDeployment.ResourceVersion
+
+	return r.updateObj(ctx, renderedDeployment, clabernetesconstants.KubernetesDeployment)
+}
+
+func (r *Reconciler) reconcileWireTapService(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	renderedService,
+	existingService *k8scorev1.Service,
+) error {
+	if existingService == nil {
+		return r.createObj(
+			ctx,
+			owningTopology,
+			renderedService,
+			clabernetesconstants.KubernetesService,
+		)
+	}
+
+	if r.wireTapReconciler.ConformsService(
+		existingService,
+		renderedService,
+		owningTopology.GetUID(),
+	) {
+		return nil
+	}
+
+	err := ctrlruntimeutil.SetOwnerReference(owningTopology, renderedService, r.Client.Scheme())
+	if err != nil {
+		return err
+	}
+
+	// the cluster ip is immutable (and assigned by the api server), so carry it over
+	renderedService.Spec.ClusterIP = existingService.Spec.ClusterIP
+	renderedService.Spec.ClusterIPs = existingService.Spec.ClusterIPs
+	renderedService.ResourceVersion = existingService.ResourceVersion
+
+	return r.updateObj(ctx, renderedService, clabernetesconstants.KubernetesService)
+}
+
+// ReconcileNetworkPolicy reconciles the network policy of the launchers of a clabernetes Topology.
+// Depending on the globally configured renderer this is a vanilla NetworkPolicy, a
+// CiliumNetworkPolicy, or nothing at all -- whatever policy is not (or no longer) selected is
+// removed.
+func (r *Reconciler) ReconcileNetworkPolicy(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) error {
+	renderer := r.configManagerGetter().GetNetworkPolicy().Renderer
+
+	namespacedName := apimachinerytypes.NamespacedName{
+		Namespace: owningTopology.GetNamespace(),
+		Name:      owningTopology.GetName(),
+	}
+
+	existingNetworkPolicy := &k8snetworkingv1.NetworkPolicy{}
+
+	err := r.getObj(
+		ctx,
+		existingNetworkPolicy,
+		namespacedName,
+		clabernetesconstants.KubernetesNetworkPolicy,
+	)
+	if err != nil {
+		if !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+
+		existingNetworkPolicy = nil
+	}
+
+	existingCiliumNetworkPolicy := &unstructured.Unstructured{}
+	existingCiliumNetworkPolicy.SetGroupVersionKind(CiliumNetworkPolicyGVK)
+
+	err = r.getObj(
+		ctx,
+		existingCiliumNetworkPolicy,
+		namespacedName,
+		clabernetesconstants.KubernetesCiliumNetworkPolicy,
+	)
+	if err != nil {
+		switch {
+		case apimachinerymeta.IsNoMatchError(err) &&
+			renderer == clabernetesconstants.NetworkPolicyRendererCilium:
+			return fmt.Errorf(
+				"%w: cilium network policy renderer selected, but the cilium network policy crd "+
+					"is not installed",
+				claberneteserrors.ErrReconcile,
+			)
+		case !apimachineryerrors.IsNotFound(err) && !apimachinerymeta.IsNoMatchError(err):
+			return err
+		}
+
+		existingCiliumNetworkPolicy = nil
+	}
+
+	if renderer != clabernetesconstants.NetworkPolicyRendererKubernetes &&
+		existingNetworkPolicy != nil {
+		err = r.deleteObj(
+			ctx,
+			existingNetworkPolicy,
+			clabernetesconstants.KubernetesNetworkPolicy,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if renderer != clabernetesconstants.NetworkPolicyRendererCilium &&
+		existingCiliumNetworkPolicy != nil {
+		err = r.deleteObj(
+			ctx,
+			existingCiliumNetworkPolicy,
+			clabernetesconstants.KubernetesCiliumNetworkPolicy,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch renderer {
+	case clabernetesconstants.NetworkPolicyRendererKubernetes:
+		return r.reconcileKubernetesNetworkPolicy(
+			ctx,
+			owningTopology,
+			reconcileData,
+			existingNetworkPolicy,
+		)
+	case clabernetesconstants.NetworkPolicyRendererCilium:
+		return r.reconcileCiliumNetworkPolicy(
+			ctx,
+			owningTopology,
+			reconcileData,
+			existingCiliumNetworkPolicy,
+		)
+	default:
+		return nil
+	}
+}
+
+func (r *Reconciler) reconcileKubernetesNetworkPolicy(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+	existingNetworkPolicy *k8snetworkingv1.NetworkPolicy,
+) error {
+	renderedNetworkPolicy := r.networkPolicyReconciler.Render(owningTopology, reconcileData)
+
+	if existingNetworkPolicy == nil {
+		return r.createObj(
+			ctx,
+			owningTopology,
+			renderedNetworkPolicy,
+			clabernetesconstants.KubernetesNetworkPolicy,
+		)
+	}
+
+	if r.networkPolicyReconciler.Conforms(
+		existingNetworkPolicy,
+		renderedNetworkPolicy,
+		owningTopology.GetUID(),
+	) {
+		return nil
+	}
+
+	err := ctrlruntimeutil.SetOwnerReference(
+		owningTopology,
+		renderedNetworkPolicy,
+		r.Client.Scheme(),
+	)
+	if err != nil {
+		return err
+	}
+
+	renderedNetworkPolicy.ResourceVersion = existingNetworkPolicy.ResourceVersion
+
+	return r.updateObj(ctx, renderedNetworkPolicy, clabernetesconstants.KubernetesNetworkPolicy)
+}
+
+func (r *Reconciler) reconcileCiliumNetworkPolicy(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+	existingNetworkPolicy *unstructured.Unstructured,
+) error {
+	renderedNetworkPolicy := r.networkPolicyReconciler.RenderCilium(owningTopology, reconcileData)
+
+	if existingNetworkPolicy == nil {
+		return r.createObj(
+			ctx,
+			owningTopology,
+			renderedNetworkPolicy,
+			clabernetesconstants.KubernetesCiliumNetworkPolicy,
+		)
+	}
+
+	if r.networkPolicyReconciler.ConformsCilium(
+		existingNetworkPolicy,
+		renderedNetworkPolicy,
+		owningTopology.GetUID(),
+	) {
+		return nil
+	}
+
+	err := ctrlruntimeutil.SetOwnerReference(
+		owningTopology,
+		renderedNetworkPolicy,
+		r.Client.Scheme(),
+	)
+	if err != nil {
+		return err
+	}
+
+	renderedNetworkPolicy.SetResourceVersion(existingNetworkPolicy.GetResourceVersion())
+
+	return r.updateObj(
+		ctx,
+		renderedNetworkPolicy,
+		clabernetesconstants.KubernetesCiliumNetworkPolicy,
+	)
+}
+
+// ReconcilePodMonitor reconciles the prometheus operator pod monitor scraping the metrics of the
+// launchers of a clabernetes Topology. The pod monitor is only rendered if enabled in the global
+// config, otherwise it is removed (if there is one) -- clusters without the prometheus operator
+// crds are fine as long as it is not enabled.
+func (r *Reconciler) ReconcilePodMonitor(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) error {
+	enabled := r.configManagerGetter().GetMetrics().PodMonitor
+
+	existingPodMonitor := &unstructured.Unstructured{}
+	existingPodMonitor.SetGroupVersionKind(PodMonitorGVK)
+
+	err := r.getObj(
+		ctx,
+		existingPodMonitor,
+		apimachinerytypes.NamespacedName{
+			Namespace: owningTopology.GetNamespace(),
+			Name:      owningTopology.GetName(),
+		},
+		clabernetesconstants.KubernetesPodMonitor,
+	)
+	if err != nil {
+		switch {
+		case apimachinerymeta.IsNoMatchError(err) && enabled:
+			return fmt.Errorf(
+				"%w: pod monitor enabled, but the prometheus operator pod monitor crd is not "+
+					"installed",
+				claberneteserrors.ErrReconcile,
+			)
+		case !apimachineryerrors.IsNotFound(err) && !apimachinerymeta.IsNoMatchError(err):
+			return err
+		}
+
+		existingPodMonitor = nil
+	}
+
+	if !enabled {
+		if existingPodMonitor == nil {
+			return nil
+		}
+
+		return r.deleteObj(ctx, existingPodMonitor, clabernetesconstants.KubernetesPodMonitor)
+	}
+
+	renderedPodMonitor := r.podMonitorReconciler.Render(owningTopology)
+
+	if existingPodMonitor == nil {
+		return r.createObj(
+			ctx,
+			owningTopology,
+			renderedPodMonitor,
+			clabernetesconstants.KubernetesPodMonitor,
+		)
+	}
+
+	if r.podMonitorReconciler.Conforms(
+		existingPodMonitor,
+		renderedPodMonitor,
+		owningTopology.GetUID(),
+	) {
+		return nil
+	}
+
+	err = ctrlruntimeutil.SetOwnerReference(
+		owningTopology,
+		renderedPodMonitor,
+		r.Client.Scheme(),
+	)
+	if err != nil {
+		return err
+	}
+
+	renderedPodMonitor.SetResourceVersion(existingPodMonitor.GetResourceVersion())
+
+	return r.updateObj(ctx, renderedPodMonitor, clabernetesconstants.KubernetesPodMonitor)
+}
+
+// ReconcileMgmtDNS reconciles the dns records of the management interfaces of the vrnetlab based
+// nodes of a clabernetes Topology. Depending on the globally configured provider the records end
+// up in an ExternalDNS DNSEndpoint, a CoreDNS hosts ConfigMap, or nowhere at all -- whatever is not
+// (or no longer) selected, or would not hold any records, is removed.
+func (r *Reconciler) ReconcileMgmtDNS(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) error {
+	mgmtDNS := r.configManagerGetter().GetMgmtDNS()
+
+	var records []MgmtDNSRecord
+
+	if mgmtDNS.Provider != clabernetesconstants.MgmtDNSProviderNone {
+		allocatedAddresses, err := r.resolveAllocatedMgmtAddresses(ctx, owningTopology)
+		if err != nil {
+			return err
+		}
+
+		records, err = ResolveMgmtDNSRecords(
+			owningTopology,
+			reconcileData.ResolvedConfigs,
+			allocatedAddresses,
+			mgmtDNS,
+		)
+		if err != nil {
+			// the records are a convenience, a bad template should not hold up the topology
+			r.Log.Warnf(
+				"skipping mgmt dns records of topology '%s/%s', error: %s",
+				owningTopology.GetNamespace(),
+				owningTopology.GetName(),
+				err,
+			)
+
+			return nil
+		}
+	}
+
+	provider := mgmtDNS.Provider
+	if len(records) == 0 {
+		provider = clabernetesconstants.MgmtDNSProviderNone
+	}
+
+	existingConfigMap := &k8scorev1.ConfigMap{}
+
+	err := r.getObj(
+		ctx,
+		existingConfigMap,
+		apimachinerytypes.NamespacedName{
+			Namespace: owningTopology.GetNamespace(),
+			Name:      mgmtDNSName(owningTopology.GetName()),
+		},
+		clabernetesconstants.KubernetesConfigMap,
+	)
+	if err != nil {
+		if !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+
+		existingConfigMap = nil
+	}
+
+	existingDNSEndpoint := &unstructured.Unstructured{}
+	existingDNSEndpoint.SetGroupVersionKind(DNSEndpointGVK)
+
+	err = r.getObj(
+		ctx,
+		existingDNSEndpoint,
+		apimachinerytypes.NamespacedName{
+			Namespace: owningTopology.GetNamespace(),
+			Name:      owningTopology.GetName(),
+		},
+		clabernetesconstants.KubernetesDNSEndpoint,
+	)
+	if err != nil {
+		switch {
+		case apimachinerymeta.IsNoMatchError(err) &&
+			provider == clabernetesconstants.MgmtDNSProviderExternalDNS:
+			return fmt.Errorf(
+				"%w: externalDNS mgmt dns provider selected, but the dns endpoint crd is not "+
+					"installed",
+				claberneteserrors.ErrReconcile,
+			)
+		case !apimachineryerrors.IsNotFound(err) && !apimachinerymeta.IsNoMatchError(err):
+			return err
+		}
+
+		existingDNSEndpoint = nil
+	}
+
+	if provider != clabernetesconstants.MgmtDNSProviderCoreDNS && existingConfigMap != nil {
+		err = r.deleteObj(ctx, existingConfigMap, clabernetesconstants.KubernetesConfigMap)
+		if err != nil {
+			return err
+		}
+	}
+
+	if provider != clabernetesconstants.MgmtDNSProviderExternalDNS && existingDNSEndpoint != nil {
+		err = r.deleteObj(ctx, existingDNSEndpoint, clabernetesconstants.KubernetesDNSEndpoint)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch provider {
+	case clabernetesconstants.MgmtDNSProviderCoreDNS:
+		return r.reconcileMgmtDNSConfigMap(ctx, owningTopology, records, existingConfigMap)
+	case clabernetesconstants.MgmtDNSProviderExternalDNS:
+		return r.reconcileMgmtDNSEndpoint(ctx, owningTopology, records, existingDNSEndpoint)
+	default:
+		return nil
+	}
+}
+
+// resolveAllocatedMgmtAddresses returns the addresses multus allocated on the dedicated management
+// interface of the launcher pods of the given topology by node name.
+func (r *Reconciler) resolveAllocatedMgmtAddresses(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) (map[string][]string, error) {
+	pods := &k8scorev1.PodList{}
+
+	err := r.Client.List(
+		ctx,
+		pods,
+		ctrlruntimeclient.InNamespace(owningTopology.GetNamespace()),
+		ctrlruntimeclient.MatchingLabels{
+			clabernetesconstants.LabelTopologyOwner: owningTopology.GetName(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	allocatedAddresses := map[string][]string{}
+
+	for idx := range pods.Items {
+		nodeName := pods.Items[idx].Labels[clabernetesconstants.LabelTopologyNode]
+		if nodeName == "" || pods.Items[idx].DeletionTimestamp != nil {
+			continue
+		}
+
+		allocatedAddresses[nodeName] = append(
+			allocatedAddresses[nodeName],
+			ResolveAllocatedMgmtAddresses(&pods.Items[idx])...,
+		)
+	}
+
+	return allocatedAddresses, nil
+}
+
+func (r *Reconciler) reconcileMgmtDNSConfigMap(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	records []MgmtDNSRecord,
+	existingConfigMap *k8scorev1.ConfigMap,
+) error {
+	renderedConfigMap := r.mgmtDNSReconciler.Render(owningTopology, records)
+
+	if existingConfigMap == nil {
+		return r.createObj(
+			ctx,
+			owningTopology,
+			renderedConfigMap,
+			clabernetesconstants.KubernetesConfigMap,
+		)
+	}
+
+	if r.mgmtDNSReconciler.Conforms(
+		existingConfigMap,
+		renderedConfigMap,
+		owningTopology.GetUID(),
+	) {
+		return nil
+	}
+
+	err := ctrlruntimeutil.SetOwnerReference(
+		owningTopology,
+		renderedConfigMap,
+		r.Client.Scheme(),
+	)
+	if err != nil {
+		return err
+	}
+
+	renderedConfigMap.ResourceVersion = existingConfigMap.ResourceVersion
+
+	return r.updateObj(ctx, renderedConfigMap, clabernetesconstants.KubernetesConfigMap)
+}
+
+func (r *Reconciler) reconcileMgmtDNSEndpoint(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	records []MgmtDNSRecord,
+	existingDNSEndpoint *unstructured.Unstructured,
+) error {
+	renderedDNSEndpoint := r.mgmtDNSReconciler.RenderDNSEndpoint(owningTopology, records)
+
+	if existingDNSEndpoint == nil {
+		return r.createObj(
+			ctx,
+			owningTopology,
+			renderedDNSEndpoint,
+			clabernetesconstants.KubernetesDNSEndpoint,
+		)
+	}
+
+	if r.mgmtDNSReconciler.ConformsDNSEndpoint(
+		existingDNSEndpoint,
+		renderedDNSEndpoint,
+		owningTopology.GetUID(),
+	) {
+		return nil
+	}
+
+	err := ctrlruntimeutil.SetOwnerReference(
+		owningTopology,
+		renderedDNSEndpoint,
+		r.Client.Scheme(),
+	)
+	if err != nil {
+		return err
+	}
+
+	renderedDNSEndpoint.SetResourceVersion(existingDNSEndpoint.GetResourceVersion())
+
+	return r.updateObj(ctx, renderedDNSEndpoint, clabernetesconstants.KubernetesDNSEndpoint)
+}
+
+// ReconcileServices reconciles all the services for a clabernetes Topology.
+func (r *Reconciler) ReconcileServices(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) error {
+	err := r.ReconcileServiceFabric(
+		ctx,
+		owningTopology,
+		reconcileData,
+	)
+	if err != nil {
+		r.Log.Criticalf(
+			"failed reconciling clabernetes fabric services, error: %s", err,
+		)
+
+		return err
+	}
+
+	err = r.ReconcileServicesExpose(
+		ctx,
+		owningTopology,
+		reconcileData,
+	)
+	if err != nil {
+		r.Log.Criticalf(
+			"failed reconciling clabernetes expose services, error: %s", err,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// ReconcileServiceFabric reconciles the service used for "fabric" (inter node) connectivity.
+func (r *Reconciler) ReconcileServiceFabric(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) error {
+	serviceTypeName := fmt.Sprintf("fabric %s", clabernetesconstants.KubernetesService)
+
+	services, err := ReconcileResolve(
+		ctx,
+		r,
+		&k8scorev1.Service{},
+		&k8scorev1.ServiceList{},
+		serviceTypeName,
+		owningTopology,
+		reconcileData.ResolvedConfigs,
+		r.ServiceFabricReconciler.Resolve,
+	)
+	if err != nil {
+		return err
+	}
+
+	r.Log.Info("pruning extraneous fabric services")
+
+	for _, extraService := range services.Extra {
+		err = r.deleteObj(
+			ctx,
+			extraService,
+			serviceTypeName,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.Log.Info("creating missing fabric services")
+
+	renderedMissingServices := r.ServiceFabricReconciler.RenderAll(
+		owningTopology,
+		services.Missing,
+	)
+
+	for _, renderedMissingService := range renderedMissingServices {
+		err = r.createObj(
+			ctx,
+			owningTopology,
+			renderedMissingService,
+			serviceTypeName,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.Log.Info("enforcing desired state on fabric services")
+
+	for existingCurrentServiceNodeName, existingCurrentService := range services.Current {
+		renderedCurrentService := r.ServiceFabricReconciler.Render(
+			owningTopology,
+			existingCurrentServiceNodeName,
+		)
 
 		err = ctrlruntimeutil.SetOwnerReference(
 			owningTopology,
@@ -685,12 +1915,26 @@ func (r *Reconciler) ReconcileDeployments( //nolint: gocyclo,funlen
 	deployments, err := ReconcileResolve(
 		ctx,
 		r,
-		&k8sappsv1.Deployment{},
-		&k8sappsv1.DeploymentList{},
-		clabernetesconstants.KubernetesDeployment,
+		&k8sappsv1.Deployment{},
+		&k8sappsv1.DeploymentList{},
+		clabernetesconstants.KubernetesDeployment,
+		owningTopology,
+		reconcileData.ResolvedConfigs,
+		r.DeploymentReconciler.Resolve,
+	)
+	if err != nil {
+		return err
+	}
+
+	statefulSets, err := ReconcileResolve(
+		ctx,
+		r,
+		&k8sappsv1.StatefulSet{},
+		&k8sappsv1.StatefulSetList{},
+		clabernetesconstants.KubernetesStatefulSet,
 		owningTopology,
 		reconcileData.ResolvedConfigs,
-		r.DeploymentReconciler.Resolve,
+		r.StatefulSetReconciler.Resolve,
 	)
 	if err != nil {
 		return err
@@ -711,6 +1955,15 @@ func (r *Reconciler) ReconcileDeployments( //nolint: gocyclo,funlen
 		return nil
 	}
 
+	err = r.reconcileNodePinsCondition(ctx, owningTopology, reconcileData)
+	if err != nil {
+		return err
+	}
+
+	r.reconcileMgmtNetworkCondition(owningTopology, reconcileData)
+
+	r.reconcileNodeSecurityContextsCondition(owningTopology, reconcileData)
+
 	r.Log.Info("pruning extraneous deployments")
 
 	for _, extraDeployment := range deployments.Extra {
@@ -718,86 +1971,144 @@ func (r *Reconciler) ReconcileDeployments( //nolint: gocyclo,funlen
 		if err != nil {
 			return err
 		}
-	}
 
-	r.Log.Info("creating missing deployments")
+		recordControllerTimelineEvent(
+			reconcileData,
+			extraDeployment.Labels[clabernetesconstants.LabelTopologyNode],
+			timelineReasonDeploymentDeleted,
+			fmt.Sprintf("deleted deployment %q", extraDeployment.Name),
+		)
+	}
 
-	renderedMissingDeployments := r.DeploymentReconciler.RenderAll(
-		owningTopology,
-		reconcileData.ResolvedConfigs,
-		deployments.Missing,
-	)
+	r.Log.Info("pruning extraneous statefulsets")
 
-	for _, renderedMissingDeployment := range renderedMissingDeployments {
-		err = r.createObj(
-			ctx,
-			owningTopology,
-			renderedMissingDeployment,
-			clabernetesconstants.KubernetesDeployment,
-		)
+	for _, extraStatefulSet := range statefulSets.Extra {
+		err = r.deleteObj(ctx, extraStatefulSet, clabernetesconstants.KubernetesStatefulSet)
 		if err != nil {
 			return err
 		}
+
+		recordControllerTimelineEvent(
+			reconcileData,
+			extraStatefulSet.Labels[clabernetesconstants.LabelTopologyNode],
+			timelineReasonDeploymentDeleted,
+			fmt.Sprintf("deleted statefulset %q", extraStatefulSet.Name),
+		)
 	}
 
-	r.Log.Info("enforcing desired state on existing deployments")
+	// the deployments or statefulsets the launchers of the topology run as
+	launchers := launcherObjects(owningTopology, deployments, statefulSets)
 
-	for existingCurrentDeploymentNodeName, existingCurrentDeployment := range deployments.Current {
-		renderedCurrentDeployment := r.DeploymentReconciler.Render(
+	// nodes whose image the image scanning policy blocks are not deployed (yet)
+	missingDeployments := slices.DeleteFunc(
+		slices.Clone(launchers.Missing),
+		reconcileData.ImageBlockedNodes.Contains,
+	)
+
+	// nor are nodes whose (containerlab wait-for/stages) dependencies are not ready yet
+	waitingNodes := r.resolveWaitingNodes(
+		ctx,
+		owningTopology,
+		reconcileData,
+		launchers,
+		missingDeployments,
+	)
+
+	missingDeployments = slices.DeleteFunc(missingDeployments, waitingNodes.Contains)
+
+	if ResolveLauncherKind(owningTopology) == clabernetesconstants.KubernetesKindStatefulSet {
+		err = r.reconcileStatefulSets(
+			ctx,
 			owningTopology,
-			reconcileData.ResolvedConfigs,
-			existingCurrentDeploymentNodeName,
+			reconcileData,
+			statefulSets,
+			missingDeployments,
 		)
-
-		err = ctrlruntimeutil.SetOwnerReference(
+	} else {
+		err = r.reconcileLauncherDeployments(
+			ctx,
 			owningTopology,
-			renderedCurrentDeployment,
-			r.Client.Scheme(),
+			reconcileData,
+			deployments,
+			missingDeployments,
 		)
-		if err != nil {
-			return err
-		}
-
-		if !r.DeploymentReconciler.Conforms(
-			existingCurrentDeployment,
-			renderedCurrentDeployment,
-			owningTopology.GetUID(),
-		) {
-			// only diff'ing spec since we *probably* only care about that part (minus metadata)
-			r.diffIfDebug(existingCurrentDeployment.Spec, renderedCurrentDeployment.Spec)
+	}
 
-			err = r.updateObj(
-				ctx,
-				renderedCurrentDeployment,
-				clabernetesconstants.KubernetesDeployment,
-			)
-			if err != nil {
-				return err
-			}
-		}
+	if err != nil {
+		return err
 	}
 
 	r.Log.Info("processing deployment statuses")
 
-	for nodeName, deployment := range deployments.Current {
-		ready := deployment.Status.ReadyReplicas == 1
+	topologyPods, err := r.listTopologyPods(ctx, owningTopology)
+	if err != nil {
+		// not fatal, the node statuses just can not take the pods into account this time around
+		r.Log.Warnf(
+			"failed listing pods for topology %q, cannot check pod statuses, error: %s",
+			owningTopology.GetName(),
+			err,
+		)
+	}
+
+	for nodeName, launcher := range launchers.Current {
+		pods := topologyPods[nodeName]
+
+		ready := launcherReadyReplicas(launcher) == 1
 		if !ready {
 			// Some Kubernetes distributions/versions may omit Deployment.Status replica counters
 			// even when the underlying Pod is Ready. Fall back to checking the Pod Ready condition.
-			ready = r.isNodePodReady(ctx, owningTopology, nodeName)
+			ready = isNodePodReady(pods)
 		}
 
-		if ready {
+		switch {
+		case owningTopology.Status.Suspended:
+			reconcileData.NodeStatuses[nodeName] = clabernetesconstants.NodeStatusSuspended //nolint:lll
+		case ready:
 			reconcileData.NodeStatuses[nodeName] = clabernetesconstants.NodeStatusReady
-		} else {
+		case isNodePodPreempted(pods):
+			reconcileData.NodeStatuses[nodeName] = clabernetesconstants.NodeStatusPreempted //nolint:lll
+		case r.isNodeLauncherStalled(ctx, pods):
+			reconcileData.NodeStatuses[nodeName] = clabernetesconstants.NodeStatusStalled //nolint:lll
+		default:
 			reconcileData.NodeStatuses[nodeName] = clabernetesconstants.NodeStatusNotReady //nolint:lll
 		}
+
+		r.reconcileNodeTermination(reconcileData, nodeName, pods)
+
+		r.reconcileWatchdogRestarts(ctx, reconcileData, nodeName, pods)
+
+		r.reconcileNodeDiskPressure(ctx, reconcileData, nodeName, pods)
+
+		r.reconcileNodeVersions(ctx, reconcileData, nodeName, pods)
+
+		if !ready {
+			r.reconcileNodeMultusAttachment(ctx, reconcileData, nodeName, pods)
+		}
 	}
 
-	for _, missingDeploymentName := range deployments.Missing {
+	r.reconcileDeploymentsPreemptedCondition(owningTopology, reconcileData)
+	r.reconcileDeploymentsStalledCondition(owningTopology, reconcileData)
+	r.reconcileDiskPressureCondition(owningTopology, reconcileData)
+	r.reconcileMultusAttachmentCondition(owningTopology, reconcileData)
+
+	for _, missingDeploymentName := range launchers.Missing {
+		if reconcileData.ImageBlockedNodes.Contains(missingDeploymentName) {
+			reconcileData.NodeStatuses[missingDeploymentName] = clabernetesconstants.NodeStatusImageBlocked //nolint:lll
+
+			continue
+		}
+
+		if waitingNodes.Contains(missingDeploymentName) {
+			reconcileData.NodeStatuses[missingDeploymentName] = clabernetesconstants.NodeStatusWaiting //nolint:lll
+
+			continue
+		}
+
 		reconcileData.NodeStatuses[missingDeploymentName] = clabernetesconstants.NodeStatusUnknown //nolint:lll
 	}
 
+	recordReadinessTimelineEvents(reconcileData)
+
 	topologyReady := true
 
 	for nodeName := range reconcileData.ResolvedConfigs {
@@ -824,6 +2135,13 @@ func (r *Reconciler) ReconcileDeployments( //nolint: gocyclo,funlen
 			Reason:  clabernetesconstants.NodeStatusReady,
 			Message: "all nodes report ready",
 		})
+	} else if owningTopology.Status.Suspended {
+		apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+			Type:    "TopologyReady",
+			Status:  "False",
+			Reason:  clabernetesconstants.NodeStatusSuspended,
+			Message: "topology is suspended per its schedule",
+		})
 	} else {
 		apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
 			Type:   "TopologyReady",
@@ -838,23 +2156,135 @@ func (r *Reconciler) ReconcileDeployments( //nolint: gocyclo,funlen
 		reconcileData.ShouldUpdateResource = true
 	}
 
+	// empty vs nil maps are not deep equal, but we dont care about that difference here
+	if (len(reconcileData.NodeTerminations) != 0 ||
+		len(reconcileData.PreviousNodeTerminations) != 0) &&
+		!reflect.DeepEqual(reconcileData.NodeTerminations, reconcileData.PreviousNodeTerminations) {
+		reconcileData.ShouldUpdateResource = true
+	}
+
+	if (len(reconcileData.NodeDiskUsage) != 0 || len(reconcileData.PreviousNodeDiskUsage) != 0) &&
+		!reflect.DeepEqual(reconcileData.NodeDiskUsage, reconcileData.PreviousNodeDiskUsage) {
+		reconcileData.ShouldUpdateResource = true
+	}
+
+	if (len(reconcileData.NodeVersions) != 0 || len(reconcileData.PreviousNodeVersions) != 0) &&
+		!reflect.DeepEqual(reconcileData.NodeVersions, reconcileData.PreviousNodeVersions) {
+		reconcileData.ShouldUpdateResource = true
+	}
+
 	return r.reconcileDeploymentsHandleRestarts(
 		ctx,
 		owningTopology,
-		deployments,
+		launchers,
 		reconcileData,
 	)
 }
 
-func (r *Reconciler) reconcileDeploymentsHandleRestarts(
+// reconcileLauncherDeployments creates the deployments of the given missing nodes and enforces the
+// desired state on the existing deployments of the topology.
+func (r *Reconciler) reconcileLauncherDeployments( //nolint:funlen
 	ctx context.Context,
 	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
 	deployments *clabernetesutil.ObjectDiffer[*k8sappsv1.Deployment],
+	missingDeployments []string,
+) error {
+	r.Log.Info("creating missing deployments")
+
+	renderedMissingDeployments := r.DeploymentReconciler.RenderAll(
+		owningTopology,
+		reconcileData.ResolvedConfigs,
+		missingDeployments,
+	)
+
+	for idx, renderedMissingDeployment := range renderedMissingDeployments {
+		err := r.createObj(
+			ctx,
+			owningTopology,
+			renderedMissingDeployment,
+			clabernetesconstants.KubernetesDeployment,
+		)
+		if err != nil {
+			return err
+		}
+
+		recordControllerTimelineEvent(
+			reconcileData,
+			missingDeployments[idx],
+			timelineReasonDeploymentCreated,
+			fmt.Sprintf("created deployment %q", renderedMissingDeployment.Name),
+		)
+	}
+
+	r.Log.Info("enforcing desired state on existing deployments")
+
+	for existingCurrentDeploymentNodeName, existingCurrentDeployment := range deployments.Current {
+		if reconcileData.ImageBlockedNodes.Contains(existingCurrentDeploymentNodeName) {
+			// already deployed nodes keep running what they run until their (new) image passes
+			// the image scan
+			r.Log.Warnf(
+				"not updating deployment of node %q, its image is blocked by the image scan",
+				existingCurrentDeploymentNodeName,
+			)
+
+			continue
+		}
+
+		renderedCurrentDeployment := r.DeploymentReconciler.Render(
+			owningTopology,
+			reconcileData.ResolvedConfigs,
+			existingCurrentDeploymentNodeName,
+		)
+
+		err := ctrlruntimeutil.SetOwnerReference(
+			owningTopology,
+			renderedCurrentDeployment,
+			r.Client.Scheme(),
+		)
+		if err != nil {
+			return err
+		}
+
+		if !r.DeploymentReconciler.Conforms(
+			existingCurrentDeployment,
+			renderedCurrentDeployment,
+			owningTopology.GetUID(),
+		) {
+			// only diff'ing spec since we *probably* only care about that part (minus metadata)
+			r.diffIfDebug(existingCurrentDeployment.Spec, renderedCurrentDeployment.Spec)
+
+			err = r.updateObj(
+				ctx,
+				renderedCurrentDeployment,
+				clabernetesconstants.KubernetesDeployment,
+			)
+			if err != nil {
+				return err
+			}
+
+			recordControllerTimelineEvent(
+				reconcileData,
+				existingCurrentDeploymentNodeName,
+				timelineReasonDeploymentUpdated,
+				fmt.Sprintf("updated deployment %q", renderedCurrentDeployment.Name),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileDeploymentsHandleRestarts(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	launchers *clabernetesutil.ObjectDiffer[ctrlruntimeclient.Object],
 	reconcileData *ReconcileData,
 ) error {
 	r.Log.Debug("determining nodes needing restart")
 
 	r.DeploymentReconciler.DetermineNodesNeedingRestart(
+		owningTopology,
 		reconcileData,
 	)
 
@@ -867,7 +2297,7 @@ func (r *Reconciler) reconcileDeploymentsHandleRestarts(
 	var restartNodeError error
 
 	for _, nodeName := range reconcileData.NodesNeedingReboot.Items() {
-		if slices.Contains(deployments.Missing, nodeName) {
+		if slices.Contains(launchers.Missing, nodeName) {
 			// is a new node, don't restart, we'll deploy it soon
 			continue
 		}
@@ -888,29 +2318,37 @@ func (r *Reconciler) reconcileDeploymentsHandleRestarts(
 			deploymentName = nodeName
 		}
 
-		nodeDeployment := &k8sappsv1.Deployment{}
+		var nodeLauncher ctrlruntimeclient.Object = &k8sappsv1.Deployment{}
+
+		launcherKind := clabernetesconstants.KubernetesDeployment
+
+		if ResolveLauncherKind(owningTopology) == clabernetesconstants.KubernetesKindStatefulSet {
+			nodeLauncher = &k8sappsv1.StatefulSet{}
+			launcherKind = clabernetesconstants.KubernetesStatefulSet
+		}
 
 		err := r.getObj(
 			ctx,
-			nodeDeployment,
+			nodeLauncher,
 			apimachinerytypes.NamespacedName{
 				Namespace: owningTopology.GetNamespace(),
 				Name:      deploymentName,
 			},
-			clabernetesconstants.KubernetesDeployment,
+			launcherKind,
 		)
 		if err != nil {
 			if apimachineryerrors.IsNotFound(err) {
 				r.Log.Warnf(
-					"could not find deployment '%s', cannot restart after config change,"+
+					"could not find %s '%s', cannot restart after config change,"+
 						" this should not happen",
+					launcherKind,
 					deploymentName,
 				)
 
 				continue
 			}
 
-			r.Log.Warnf("failed fetching deployment for node %q, err: %s", nodeName, err)
+			r.Log.Warnf("failed fetching %s for node %q, err: %s", launcherKind, nodeName, err)
 
 			if restartNodeError == nil {
 				restartNodeError = fmt.Errorf(
@@ -922,17 +2360,19 @@ func (r *Reconciler) reconcileDeploymentsHandleRestarts(
 			continue
 		}
 
-		if nodeDeployment.Spec.Template.ObjectMeta.Annotations == nil {
-			nodeDeployment.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+		podTemplate := launcherPodTemplate(nodeLauncher)
+
+		if podTemplate.ObjectMeta.Annotations == nil {
+			podTemplate.ObjectMeta.Annotations = map[string]string{}
 		}
 
 		now := time.Now().Format(time.RFC3339)
 
-		nodeDeployment.Spec.Template.ObjectMeta.Annotations["kubectl.kubernetes.io/restartedAt"] = now //nolint:lll
+		podTemplate.ObjectMeta.Annotations["kubectl.kubernetes.io/restartedAt"] = now
 
-		err = r.updateObj(ctx, nodeDeployment, clabernetesconstants.KubernetesDeployment)
+		err = r.updateObj(ctx, nodeLauncher, launcherKind)
 		if err != nil {
-			r.Log.Warnf("failed restarting deployment for node %q, err: %s", nodeName, err)
+			r.Log.Warnf("failed restarting %s for node %q, err: %s", launcherKind, nodeName, err)
 
 			if restartNodeError == nil {
 				restartNodeError = fmt.Errorf(
@@ -943,6 +2383,13 @@ func (r *Reconciler) reconcileDeploymentsHandleRestarts(
 
 			continue
 		}
+
+		recordControllerTimelineEvent(
+			reconcileData,
+			nodeName,
+			timelineReasonNodeRestarted,
+			"restarted as the node configuration changed",
+		)
 	}
 
 	return restartNodeError
@@ -999,9 +2446,12 @@ func (r *Reconciler) ReconcileNetworkAttachmentDefinitions(
 		}
 	}
 
+	subnets := r.nadReconciler.ResolveSubnets(reconcileData.ResolvedConfigs)
+
 	renderedMissingNADs := r.nadReconciler.RenderAll(
 		owningTopology,
 		nads.Missing,
+		subnets,
 	)
 
 	for _, renderedMissingNAD := range renderedMissingNADs {
@@ -1020,6 +2470,7 @@ func (r *Reconciler) ReconcileNetworkAttachmentDefinitions(
 		renderedCurrentNAD := r.nadReconciler.Render(
 			owningTopology,
 			existingCurrentNAD.GetName(),
+			subnets[existingCurrentNAD.GetName()],
 		)
 
 		err = ctrlruntimeutil.SetOwnerReference(
@@ -1050,32 +2501,45 @@ func (r *Reconciler) ReconcileNetworkAttachmentDefinitions(
 	return nil
 }
 
-func (r *Reconciler) isNodePodReady(
+// listTopologyPods returns the pods of the given topology grouped by the node they belong to -- a
+// single list for the whole topology rather than one for each node and check.
+func (r *Reconciler) listTopologyPods(
 	ctx context.Context,
 	owningTopology *clabernetesapisv1alpha1.Topology,
-	nodeName string,
-) bool {
-	if owningTopology == nil {
-		return false
-	}
-
+) (map[string][]k8scorev1.Pod, error) {
 	pods := &k8scorev1.PodList{}
+
 	err := r.Client.List(
 		ctx,
 		pods,
 		ctrlruntimeclient.InNamespace(owningTopology.GetNamespace()),
 		ctrlruntimeclient.MatchingLabels{
 			clabernetesconstants.LabelTopologyOwner: owningTopology.GetName(),
-			clabernetesconstants.LabelTopologyNode:  nodeName,
 		},
 	)
 	if err != nil {
-		return false
+		return nil, err
 	}
 
+	nodePods := map[string][]k8scorev1.Pod{}
+
 	for i := range pods.Items {
-		for j := range pods.Items[i].Status.Conditions {
-			cond := pods.Items[i].Status.Conditions[j]
+		nodeName, ok := pods.Items[i].Labels[clabernetesconstants.LabelTopologyNode]
+		if !ok {
+			continue
+		}
+
+		nodePods[nodeName] = append(nodePods[nodeName], pods.Items[i])
+	}
+
+	return nodePods, nil
+}
+
+// isNodePodReady returns true if any of the given pods of a node is ready.
+func isNodePodReady(pods []k8scorev1.Pod) bool {
+	for i := range pods {
+		for j := range pods[i].Status.Conditions {
+			cond := pods[i].Status.Conditions[j]
 			if cond.Type == k8scorev1.PodReady && cond.Status == k8scorev1.ConditionTrue {
 				return true
 			}
@@ -1085,6 +2549,115 @@ func (r *Reconciler) isNodePodReady(
 	return false
 }
 
+// isNodePodPreempted returns true if any of the given pods of a node was preempted by the
+// scheduler or evicted by the kubelet (node pressure, api initiated eviction, etc.).
+func isNodePodPreempted(pods []k8scorev1.Pod) bool {
+	for i := range pods {
+		if pods[i].Status.Reason == podStatusReasonEvicted {
+			return true
+		}
+
+		for j := range pods[i].Status.Conditions {
+			cond := pods[i].Status.Conditions[j]
+			if cond.Type == k8scorev1.DisruptionTarget && cond.Status == k8scorev1.ConditionTrue {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// reconcileDeploymentsPreemptedCondition sets (or clears) the "NodesPreempted" condition on the
+// topology based on the node statuses that were just computed.
+func (r *Reconciler) reconcileDeploymentsPreemptedCondition(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) {
+	preemptedNodes := r.reconcileNodeStatusCondition(
+		owningTopology,
+		reconcileData,
+		conditionNodesPreempted,
+		clabernetesconstants.NodeStatusPreempted,
+		"pod(s) for node(s) %s were preempted or evicted",
+	)
+	if len(preemptedNodes) == 0 {
+		return
+	}
+
+	r.Log.Warnf(
+		"node(s) %q pod(s) were preempted or evicted, they will be rescheduled when possible",
+		preemptedNodes,
+	)
+}
+
+// reconcileNodeStatusCondition sets the condition of the given type on the topology while any node
+// has the given status -- the message (format) lists those nodes -- and clears it otherwise. The
+// topology status is flagged for updating whenever the condition changes. Returns the (sorted)
+// names of the nodes with the given status.
+func (r *Reconciler) reconcileNodeStatusCondition(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+	conditionType,
+	nodeStatus,
+	messageFormat string,
+) []string {
+	var nodes []string
+
+	for nodeName, status := range reconcileData.NodeStatuses {
+		if status == nodeStatus {
+			nodes = append(nodes, nodeName)
+		}
+	}
+
+	if len(nodes) == 0 {
+		if apimachinerymeta.RemoveStatusCondition(
+			&owningTopology.Status.Conditions,
+			conditionType,
+		) {
+			reconcileData.ShouldUpdateResource = true
+		}
+
+		return nil
+	}
+
+	slices.Sort(nodes)
+
+	if apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+		Type:    conditionType,
+		Status:  "True",
+		Reason:  nodeStatus,
+		Message: fmt.Sprintf(messageFormat, strings.Join(nodes, ", ")),
+	}) {
+		reconcileData.ShouldUpdateResource = true
+	}
+
+	return nodes
+}
+
+// reconcileDeploymentsStalledCondition sets (or clears) the "NodesStalled" condition on the
+// topology based on the node statuses that were just computed.
+func (r *Reconciler) reconcileDeploymentsStalledCondition(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) {
+	stalledNodes := r.reconcileNodeStatusCondition(
+		owningTopology,
+		reconcileData,
+		conditionNodesStalled,
+		clabernetesconstants.NodeStatusStalled,
+		"launcher(s) for node(s) %s are running but have stopped renewing their heartbeat",
+	)
+	if len(stalledNodes) == 0 {
+		return
+	}
+
+	r.Log.Warnf(
+		"node(s) %q launcher(s) stopped renewing their heartbeat lease, they may be wedged",
+		stalledNodes,
+	)
+}
+
 func (r *Reconciler) diffIfDebug(a, b any) {
 	if r.Log.GetLevel() != clabernetesconstants.Debug {
 		return
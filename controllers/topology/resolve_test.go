@@ -139,6 +139,7 @@ func TestReconcileResolveDeployment(t *testing.T) {
 					"clabernetes",
 					"clabernetes",
 					"containerd",
+					false,
 					clabernetesconfig.GetFakeManager,
 				)
 
@@ -299,6 +300,7 @@ func TestReconcileResolvePVC(t *testing.T) {
 					"clabernetes",
 					"clabernetes",
 					"containerd",
+					false,
 					clabernetesconfig.GetFakeManager,
 				)
 
@@ -464,6 +466,7 @@ func TestReconcileResolveServiceFabric(t *testing.T) {
 					"clabernetes",
 					"clabernetes",
 					"containerd",
+					false,
 					clabernetesconfig.GetFakeManager,
 				)
 
@@ -629,6 +632,7 @@ func TestReconcileResolveServiceExpose(t *testing.T) {
 					"clabernetes",
 					"clabernetes",
 					"containerd",
+					false,
 					clabernetesconfig.GetFakeManager,
 				)
 
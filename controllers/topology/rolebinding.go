@@ -30,6 +30,9 @@ type RoleBindingReconciler struct {
 	client              ctrlruntimeclient.Client
 	configManagerGetter clabernetesconfig.ManagerGetterFunc
 	appName             string
+	// namespaceScoped managers can not rely on the (cluster wide) launcher ClusterRole, so bind
+	// the launcher Role (in the topology namespace) instead
+	namespaceScoped bool
 }
 
 // NewRoleBindingReconciler returns an instance of RoleBindingReconciler.
@@ -38,12 +41,14 @@ func NewRoleBindingReconciler(
 	client ctrlruntimeclient.Client,
 	configManagerGetter clabernetesconfig.ManagerGetterFunc,
 	appName string,
+	namespaceScoped bool,
 ) *RoleBindingReconciler {
 	return &RoleBindingReconciler{
 		log:                 log,
 		client:              client,
 		configManagerGetter: configManagerGetter,
 		appName:             appName,
+		namespaceScoped:     namespaceScoped,
 	}
 }
 
@@ -119,6 +124,11 @@ func (r *RoleBindingReconciler) Render(
 		labels[k] = v
 	}
 
+	roleKind := "ClusterRole"
+	if r.namespaceScoped {
+		roleKind = "Role"
+	}
+
 	renderedRoleBinding := &k8srbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        launcherRoleBindingName(),
@@ -135,7 +145,7 @@ func (r *RoleBindingReconciler) Render(
 		},
 		RoleRef: k8srbacv1.RoleRef{
 			APIGroup: "rbac.authorization.k8s.io",
-			Kind:     "ClusterRole",
+			Kind:     roleKind,
 			Name:     fmt.Sprintf("%s-launcher-role", r.appName),
 		},
 	}
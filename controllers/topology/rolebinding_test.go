@@ -22,6 +22,7 @@ func TestRenderRoleBinding(t *testing.T) {
 		name                string
 		owningTopology      *clabernetesapisv1alpha1.Topology
 		existingRoleBinding *k8srbacv1.RoleBinding
+		namespaceScoped     bool
 	}{
 		{
 			name: "simple",
@@ -77,6 +78,28 @@ func TestRenderRoleBinding(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "namespace-scoped",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-rolebinding-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+			},
+			namespaceScoped: true,
+		},
 	}
 
 	for _, testCase := range cases {
@@ -90,6 +113,7 @@ func TestRenderRoleBinding(t *testing.T) {
 					nil,
 					clabernetesconfig.GetFakeManager,
 					clabernetesconstants.Clabernetes,
+					testCase.namespaceScoped,
 				)
 
 				got := reconciler.Render(
@@ -0,0 +1,125 @@
+package topology_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+)
+
+const renderSamplesTestName = "samples"
+
+// TestRenderSampleTopologies renders the configmap, connectivity, and deployments for every
+// topology in the sample topology corpus and compares them to the golden outputs -- any renderer
+// change that touches these shows up here.
+func TestRenderSampleTopologies(t *testing.T) {
+	for _, sampleName := range clabernetestesthelper.SampleTopologyNames(t) {
+		t.Run(
+			sampleName,
+			func(t *testing.T) {
+				t.Logf("%s: starting", sampleName)
+
+				topology := clabernetestesthelper.LoadSampleTopology(t, sampleName)
+
+				reconcileData, err := clabernetescontrollerstopology.NewReconcileData(topology)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				processor, err := clabernetescontrollerstopology.NewDefinitionProcessor(
+					&claberneteslogging.FakeInstance{},
+					topology,
+					reconcileData,
+					clabernetesconfig.GetFakeManager,
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = processor.Process()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				clabernetescontrollerstopology.AllocateTunnelIDs(
+					map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{},
+					reconcileData.ResolvedTunnels,
+				)
+
+				configMap, err := clabernetescontrollerstopology.NewConfigMapReconciler(
+					&claberneteslogging.FakeInstance{},
+					clabernetesconfig.GetFakeManager,
+				).Render(
+					topology,
+					reconcileData.ResolvedConfigs,
+					topology.Spec.Deployment.FilesFromURL,
+					"",
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				connectivity := clabernetescontrollerstopology.NewConnectivityReconciler(
+					&claberneteslogging.FakeInstance{},
+					clabernetesconfig.GetFakeManager,
+				).Render(
+					topology,
+					reconcileData.ResolvedTunnels,
+				)
+
+				deploymentReconciler := clabernetescontrollerstopology.NewDeploymentReconciler(
+					&claberneteslogging.FakeInstance{},
+					"clabernetes",
+					"clabernetes",
+					"",
+					clabernetesconfig.GetFakeManager,
+				)
+
+				deployments := map[string]*k8sappsv1.Deployment{}
+
+				for nodeName := range reconcileData.ResolvedConfigs {
+					deployments[nodeName] = deploymentReconciler.Render(
+						topology,
+						reconcileData.ResolvedConfigs,
+						nodeName,
+					)
+				}
+
+				var wantConfigMap k8scorev1.ConfigMap
+
+				assertSampleGolden(t, sampleName, "configmap", configMap, &wantConfigMap)
+
+				var wantConnectivity clabernetesapisv1alpha1.Connectivity
+
+				assertSampleGolden(t, sampleName, "connectivity", connectivity, &wantConnectivity)
+
+				var wantDeployments map[string]*k8sappsv1.Deployment
+
+				assertSampleGolden(t, sampleName, "deployments", deployments, &wantDeployments)
+			})
+	}
+}
+
+func assertSampleGolden(t *testing.T, sampleName, kind string, got, want any) {
+	t.Helper()
+
+	goldenPath := fmt.Sprintf("golden/%s/%s/%s.json", renderSamplesTestName, sampleName, kind)
+
+	if *clabernetestesthelper.Update {
+		clabernetestesthelper.WriteTestFixtureJSON(t, goldenPath, got)
+	}
+
+	err := json.Unmarshal(clabernetestesthelper.ReadTestFixtureFile(t, goldenPath), want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clabernetestesthelper.MarshaledEqual(t, got, want)
+}
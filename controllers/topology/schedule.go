@@ -0,0 +1,167 @@
+package topology
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+const (
+	scheduleTimeFormat = "15:04"
+	// scheduleHorizonDays is how many days forward we look for window boundaries -- since windows
+	// recur weekly a week (plus a day for windows that cross midnight) is all we ever need.
+	scheduleHorizonDays = 8
+)
+
+type scheduleInterval struct {
+	start time.Time
+	end   time.Time
+}
+
+// ResolveSchedule accepts a Topology and a time and returns true if the Topology should be active
+// at that time per its schedule. It also returns the time of the next schedule "transition" -- if
+// the Topology is active this is the time it will next be suspended, otherwise it is the time it
+// will next be activated. If the Topology has no schedule it is always active and the returned
+// transition time is the zero value.
+func ResolveSchedule(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	now time.Time,
+) (bool, time.Time, error) {
+	schedule := owningTopology.Spec.Schedule
+
+	if schedule == nil || len(schedule.Windows) == 0 {
+		return true, time.Time{}, nil
+	}
+
+	location := time.UTC
+
+	if schedule.TimeZone != "" {
+		var err error
+
+		location, err = time.LoadLocation(schedule.TimeZone)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf(
+				"%w: failed loading schedule time zone %q, error: %w",
+				claberneteserrors.ErrParse,
+				schedule.TimeZone,
+				err,
+			)
+		}
+	}
+
+	intervals, err := scheduleIntervals(schedule.Windows, now.In(location))
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	var active bool
+
+	for _, interval := range intervals {
+		if !now.Before(interval.start) && now.Before(interval.end) {
+			active = true
+
+			break
+		}
+	}
+
+	if active {
+		// walk forward through any overlapping/adjacent windows to find when we actually suspend
+		next := now
+
+		for extended := true; extended; {
+			extended = false
+
+			for _, interval := range intervals {
+				if !next.Before(interval.start) && next.Before(interval.end) {
+					next = interval.end
+					extended = true
+				}
+			}
+		}
+
+		return true, next, nil
+	}
+
+	var next time.Time
+
+	for _, interval := range intervals {
+		if interval.start.After(now) && (next.IsZero() || interval.start.Before(next)) {
+			next = interval.start
+		}
+	}
+
+	return false, next, nil
+}
+
+func scheduleIntervals(
+	windows []clabernetesapisv1alpha1.ScheduleWindow,
+	localNow time.Time,
+) ([]scheduleInterval, error) {
+	var intervals []scheduleInterval
+
+	for _, window := range windows {
+		start, err := time.Parse(scheduleTimeFormat, window.Start)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: failed parsing schedule window start %q, error: %w",
+				claberneteserrors.ErrParse,
+				window.Start,
+				err,
+			)
+		}
+
+		end, err := time.Parse(scheduleTimeFormat, window.End)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: failed parsing schedule window end %q, error: %w",
+				claberneteserrors.ErrParse,
+				window.End,
+				err,
+			)
+		}
+
+		// start from "yesterday" so we catch windows that started yesterday and cross midnight
+		for dayOffset := -1; dayOffset < scheduleHorizonDays; dayOffset++ {
+			day := time.Date(
+				localNow.Year(),
+				localNow.Month(),
+				localNow.Day()+dayOffset,
+				0,
+				0,
+				0,
+				0,
+				localNow.Location(),
+			)
+
+			if len(window.Days) > 0 &&
+				!slices.Contains(window.Days, strings.ToLower(day.Weekday().String()[:3])) {
+				continue
+			}
+
+			interval := scheduleInterval{
+				start: time.Date(
+					day.Year(), day.Month(), day.Day(),
+					start.Hour(), start.Minute(), 0, 0,
+					day.Location(),
+				),
+				end: time.Date(
+					day.Year(), day.Month(), day.Day(),
+					end.Hour(), end.Minute(), 0, 0,
+					day.Location(),
+				),
+			}
+
+			if !interval.end.After(interval.start) {
+				interval.end = interval.end.AddDate(0, 0, 1)
+			}
+
+			intervals = append(intervals, interval)
+		}
+	}
+
+	return intervals, nil
+}
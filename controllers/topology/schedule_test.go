@@ -0,0 +1,138 @@
+package topology_test
+
+import (
+	"testing"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestResolveSchedule(t *testing.T) {
+	// 2024-01-01 is a monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	weekdays := []string{"mon", "tue", "wed", "thu", "fri"}
+
+	cases := []struct {
+		name           string
+		schedule       *clabernetesapisv1alpha1.Schedule
+		now            time.Time
+		expectedActive bool
+		expectedNext   time.Time
+		expectErr      bool
+	}{
+		{
+			name:           "no-schedule",
+			schedule:       nil,
+			now:            monday(10, 0),
+			expectedActive: true,
+			expectedNext:   time.Time{},
+		},
+		{
+			name: "inside-window",
+			schedule: &clabernetesapisv1alpha1.Schedule{
+				Windows: []clabernetesapisv1alpha1.ScheduleWindow{
+					{Days: weekdays, Start: "09:00", End: "17:00"},
+				},
+			},
+			now:            monday(10, 0),
+			expectedActive: true,
+			expectedNext:   monday(17, 0),
+		},
+		{
+			name: "outside-window",
+			schedule: &clabernetesapisv1alpha1.Schedule{
+				Windows: []clabernetesapisv1alpha1.ScheduleWindow{
+					{Days: weekdays, Start: "09:00", End: "17:00"},
+				},
+			},
+			now:            monday(18, 0),
+			expectedActive: false,
+			expectedNext:   monday(9, 0).AddDate(0, 0, 1),
+		},
+		{
+			name: "outside-window-weekend",
+			schedule: &clabernetesapisv1alpha1.Schedule{
+				Windows: []clabernetesapisv1alpha1.ScheduleWindow{
+					{Days: weekdays, Start: "09:00", End: "17:00"},
+				},
+			},
+			now:            monday(18, 0).AddDate(0, 0, 4),
+			expectedActive: false,
+			expectedNext:   monday(9, 0).AddDate(0, 0, 7),
+		},
+		{
+			name: "crosses-midnight",
+			schedule: &clabernetesapisv1alpha1.Schedule{
+				Windows: []clabernetesapisv1alpha1.ScheduleWindow{
+					{Days: []string{"mon"}, Start: "22:00", End: "02:00"},
+				},
+			},
+			now:            monday(1, 0).AddDate(0, 0, 1),
+			expectedActive: true,
+			expectedNext:   monday(2, 0).AddDate(0, 0, 1),
+		},
+		{
+			name: "overlapping-windows",
+			schedule: &clabernetesapisv1alpha1.Schedule{
+				Windows: []clabernetesapisv1alpha1.ScheduleWindow{
+					{Start: "09:00", End: "12:00"},
+					{Start: "11:00", End: "15:00"},
+				},
+			},
+			now:            monday(10, 0),
+			expectedActive: true,
+			expectedNext:   monday(15, 0),
+		},
+		{
+			name: "bad-window",
+			schedule: &clabernetesapisv1alpha1.Schedule{
+				Windows: []clabernetesapisv1alpha1.ScheduleWindow{
+					{Start: "9am", End: "17:00"},
+				},
+			},
+			now:       monday(10, 0),
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actualActive, actualNext, err := clabernetescontrollerstopology.ResolveSchedule(
+					&clabernetesapisv1alpha1.Topology{
+						Spec: clabernetesapisv1alpha1.TopologySpec{
+							Schedule: testCase.schedule,
+						},
+					},
+					testCase.now,
+				)
+				if testCase.expectErr {
+					if err == nil {
+						t.Fatal("expected error but got none")
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if actualActive != testCase.expectedActive {
+					clabernetestesthelper.FailOutput(t, actualActive, testCase.expectedActive)
+				}
+
+				if !actualNext.Equal(testCase.expectedNext) {
+					clabernetestesthelper.FailOutput(t, actualNext, testCase.expectedNext)
+				}
+			})
+	}
+}
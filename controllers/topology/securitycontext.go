@@ -0,0 +1,385 @@
+package topology
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	conditionNodeSecurityContextsInvalid = "NodeSecurityContextsInvalid"
+	reasonNodeSecurityContextsInvalid    = "InvalidNodeSecurityContexts"
+
+	nonRootID = 1000
+)
+
+// nodeSecurityContextKindDefaults holds the id the nos containers of (lowercase) kinds known to
+// run fine as non-root run as -- used as user, group and fsGroup for any of those a node security
+// context does not set.
+var nodeSecurityContextKindDefaults = map[string]int64{ //nolint:gochecknoglobals
+	"linux":        nonRootID,
+	"crpd":         nonRootID,
+	"juniper_crpd": nonRootID,
+}
+
+// ResolveNodeSecurityContext returns the user, group and fsGroup the nos container of the given
+// node (of the given kind) should run as -- the node security context of the node, or the
+// "default" one if the node has none, with any unset field filled in from the built-in defaults
+// of the kind. Returns nil if the topology does not run in native mode or sets no security context
+// for the node.
+func ResolveNodeSecurityContext(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	nodeName,
+	nodeKind string,
+) *clabernetesapisv1alpha1.NodeSecurityContext {
+	if !ResolveNativeMode(owningTopology) {
+		return nil
+	}
+
+	securityContexts := owningTopology.Spec.Deployment.NodeSecurityContexts
+
+	securityContext, ok := securityContexts[nodeName]
+	if !ok {
+		securityContext, ok = securityContexts[clabernetesconstants.Default]
+		if !ok {
+			return nil
+		}
+	}
+
+	resolved := securityContext.DeepCopy()
+
+	kindDefault, ok := nodeSecurityContextKindDefaults[normalizeNativeKind(nodeKind)]
+	if !ok {
+		return resolved
+	}
+
+	if resolved.RunAsUser == nil {
+		resolved.RunAsUser = clabernetesutil.ToPointer(kindDefault)
+	}
+
+	if resolved.RunAsGroup == nil {
+		resolved.RunAsGroup = clabernetesutil.ToPointer(kindDefault)
+	}
+
+	if resolved.FSGroup == nil {
+		resolved.FSGroup = clabernetesutil.ToPointer(kindDefault)
+	}
+
+	return resolved
+}
+
+// nodeSecurityContextConflict returns why the nos container of the given node can not run as the
+// user of the given security context, or an empty string if it can -- privileged containers (the
+// launcher being privileged also privileges the nos container) only get their privileges as root,
+// so nodes that need them have to keep running as root. Sysbox pods never run privileged.
+func (r *DeploymentReconciler) nodeSecurityContextConflict(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	nodeName,
+	nodeKind string,
+	securityContext *clabernetesapisv1alpha1.NodeSecurityContext,
+) string {
+	if securityContext.RunAsUser == nil || *securityContext.RunAsUser == 0 {
+		return ""
+	}
+
+	runtimeClassName := ResolveRuntimeClassName(owningTopology, nodeName, nodeKind)
+	if resolveRuntimeSandbox(runtimeClassName) == runtimeSandboxSysbox {
+		return ""
+	}
+
+	if ResolveGlobalVsTopologyBool(
+		r.configManagerGetter().GetPrivilegedLauncher(),
+		owningTopology.Spec.Deployment.PrivilegedLauncher,
+	) {
+		return "the topology runs privileged launchers"
+	}
+
+	privileged, _ := resolveNativeKindPrivileges(
+		nodeKind,
+		r.configManagerGetter().IsFeatureGateEnabled(
+			clabernetesconstants.FeatureGateNativeKindDrivers,
+		),
+		r.configManagerGetter().GetNativeKinds(),
+	)
+	if privileged {
+		return fmt.Sprintf("kind %q needs a privileged nos container", nodeKind)
+	}
+
+	return ""
+}
+
+// InvalidNodeSecurityContexts returns a sorted list of the nodes of the given topology whose
+// security context can not be applied, and why -- see nodeSecurityContextConflict.
+func (r *DeploymentReconciler) InvalidNodeSecurityContexts(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) []string {
+	var invalid []string
+
+	for nodeName, nodeConfig := range clabernetesConfigs {
+		nodeKind, _ := nodeConfig.Topology.GetNodeKindType(nodeName)
+
+		securityContext := ResolveNodeSecurityContext(owningTopology, nodeName, nodeKind)
+		if securityContext == nil {
+			continue
+		}
+
+		conflict := r.nodeSecurityContextConflict(
+			owningTopology,
+			nodeName,
+			nodeKind,
+			securityContext,
+		)
+		if conflict == "" {
+			continue
+		}
+
+		invalid = append(
+			invalid,
+			fmt.Sprintf(
+				"%s: can not run as user %d, %s",
+				nodeName,
+				*securityContext.RunAsUser,
+				conflict,
+			),
+		)
+	}
+
+	slices.Sort(invalid)
+
+	return invalid
+}
+
+// renderDeploymentNodeSecurityContext sets the user and group of the nos container of native mode
+// nodes, and the fsGroup of their pod, per their node security context. Nodes whose nos container
+// needs to run privileged are left running as root (and reported in the topology conditions). This
+// runs after the container privileges are rendered as those replace the container security
+// contexts wholesale.
+func (r *DeploymentReconciler) renderDeploymentNodeSecurityContext(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) {
+	nodeConfig, ok := clabernetesConfigs[nodeName]
+	if !ok {
+		return
+	}
+
+	nodeKind, _ := nodeConfig.Topology.GetNodeKindType(nodeName)
+
+	securityContext := ResolveNodeSecurityContext(owningTopology, nodeName, nodeKind)
+	if securityContext == nil {
+		return
+	}
+
+	conflict := r.nodeSecurityContextConflict(owningTopology, nodeName, nodeKind, securityContext)
+	if conflict != "" {
+		r.log.Debugf(
+			"node %q security context can not be applied, %s, leaving it running as root",
+			nodeName,
+			conflict,
+		)
+
+		return
+	}
+
+	if securityContext.FSGroup != nil {
+		if deployment.Spec.Template.Spec.SecurityContext == nil {
+			deployment.Spec.Template.Spec.SecurityContext = &k8scorev1.PodSecurityContext{}
+		}
+
+		deployment.Spec.Template.Spec.SecurityContext.FSGroup = clabernetesutil.ToPointer(
+			*securityContext.FSGroup,
+		)
+	}
+
+	for i := range deployment.Spec.Template.Spec.Containers {
+		container := &deployment.Spec.Template.Spec.Containers[i]
+
+		if container.Name != nodeName {
+			continue
+		}
+
+		if container.SecurityContext == nil {
+			container.SecurityContext = &k8scorev1.SecurityContext{}
+		}
+
+		if securityContext.RunAsUser != nil {
+			container.SecurityContext.RunAsUser = clabernetesutil.ToPointer(
+				*securityContext.RunAsUser,
+			)
+		}
+
+		if securityContext.RunAsGroup != nil {
+			container.SecurityContext.RunAsGroup = clabernetesutil.ToPointer(
+				*securityContext.RunAsGroup,
+			)
+		}
+	}
+}
+
+// ResolveSecurityContextOverrides returns the security context overrides of the launcher pod of
+// the given node -- the overrides of the node, or the "default" ones if the node has none. Returns
+// nil if neither is set.
+func ResolveSecurityContextOverrides(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	nodeName string,
+) *clabernetesapisv1alpha1.SecurityContextOverrides {
+	securityContexts := owningTopology.Spec.Deployment.SecurityContexts
+
+	overrides, ok := securityContexts[nodeName]
+	if !ok {
+		overrides, ok = securityContexts[clabernetesconstants.Default]
+		if !ok {
+			return nil
+		}
+	}
+
+	return overrides.DeepCopy()
+}
+
+// renderDeploymentSecurityContextOverrides applies the security context overrides of the node on
+// top of the pod and container security contexts rendered so far. This has to run after anything
+// else touching security contexts, but before the extra init containers are added as those keep
+// their own security contexts.
+func (r *DeploymentReconciler) renderDeploymentSecurityContextOverrides(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) {
+	overrides := ResolveSecurityContextOverrides(owningTopology, nodeName)
+	if overrides == nil {
+		return
+	}
+
+	if overrides.Pod != nil {
+		if deployment.Spec.Template.Spec.SecurityContext == nil {
+			deployment.Spec.Template.Spec.SecurityContext = &k8scorev1.PodSecurityContext{}
+		}
+
+		podSecurityContext := deployment.Spec.Template.Spec.SecurityContext
+
+		if overrides.Pod.RunAsUser != nil {
+			podSecurityContext.RunAsUser = overrides.Pod.RunAsUser
+		}
+
+		if overrides.Pod.RunAsGroup != nil {
+			podSecurityContext.RunAsGroup = overrides.Pod.RunAsGroup
+		}
+
+		if overrides.Pod.FSGroup != nil {
+			podSecurityContext.FSGroup = overrides.Pod.FSGroup
+		}
+
+		if len(overrides.Pod.SupplementalGroups) > 0 {
+			podSecurityContext.SupplementalGroups = overrides.Pod.SupplementalGroups
+		}
+
+		if overrides.Pod.SELinuxOptions != nil {
+			podSecurityContext.SELinuxOptions = overrides.Pod.SELinuxOptions
+		}
+	}
+
+	if overrides.Container == nil {
+		return
+	}
+
+	containers := make(
+		[]*k8scorev1.Container,
+		0,
+		len(deployment.Spec.Template.Spec.Containers)+
+			len(deployment.Spec.Template.Spec.InitContainers),
+	)
+
+	for i := range deployment.Spec.Template.Spec.Containers {
+		containers = append(containers, &deployment.Spec.Template.Spec.Containers[i])
+	}
+
+	for i := range deployment.Spec.Template.Spec.InitContainers {
+		containers = append(containers, &deployment.Spec.Template.Spec.InitContainers[i])
+	}
+
+	for _, container := range containers {
+		if container.SecurityContext == nil {
+			container.SecurityContext = &k8scorev1.SecurityContext{}
+		}
+
+		if overrides.Container.RunAsUser != nil {
+			container.SecurityContext.RunAsUser = clabernetesutil.ToPointer(
+				*overrides.Container.RunAsUser,
+			)
+		}
+
+		if overrides.Container.RunAsGroup != nil {
+			container.SecurityContext.RunAsGroup = clabernetesutil.ToPointer(
+				*overrides.Container.RunAsGroup,
+			)
+		}
+
+		if overrides.Container.SELinuxOptions != nil {
+			container.SecurityContext.SELinuxOptions = overrides.Container.SELinuxOptions.DeepCopy()
+		}
+	}
+}
+
+// podSecurityContextsEqual returns true if the given pod security contexts are equal, the api
+// server defaults an unset pod security context to an empty one.
+func podSecurityContextsEqual(existing, rendered *k8scorev1.PodSecurityContext) bool {
+	if existing == nil {
+		existing = &k8scorev1.PodSecurityContext{}
+	}
+
+	if rendered == nil {
+		rendered = &k8scorev1.PodSecurityContext{}
+	}
+
+	return reflect.DeepEqual(existing, rendered)
+}
+
+// reconcileNodeSecurityContextsCondition sets (or clears) the "NodeSecurityContextsInvalid"
+// condition on the topology based on whether the security contexts of the nodes can be applied.
+func (r *Reconciler) reconcileNodeSecurityContextsCondition(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) {
+	invalid := r.DeploymentReconciler.InvalidNodeSecurityContexts(
+		owningTopology,
+		reconcileData.ResolvedConfigs,
+	)
+
+	if len(invalid) == 0 {
+		if apimachinerymeta.RemoveStatusCondition(
+			&owningTopology.Status.Conditions,
+			conditionNodeSecurityContextsInvalid,
+		) {
+			reconcileData.ShouldUpdateResource = true
+		}
+
+		return
+	}
+
+	r.Log.Warnf("node security context(s) %q can not be applied, running as root", invalid)
+
+	if apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+		Type:   conditionNodeSecurityContextsInvalid,
+		Status: "True",
+		Reason: reasonNodeSecurityContextsInvalid,
+		Message: fmt.Sprintf(
+			"node security context(s) can not be applied, nodes run as root: %s",
+			strings.Join(invalid, "; "),
+		),
+	}) {
+		reconcileData.ShouldUpdateResource = true
+	}
+}
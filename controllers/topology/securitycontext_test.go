@@ -0,0 +1,108 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+)
+
+func TestResolveNodeSecurityContext(t *testing.T) {
+	cases := []struct {
+		name             string
+		nativeMode       bool
+		securityContexts map[string]clabernetesapisv1alpha1.NodeSecurityContext
+		nodeName         string
+		nodeKind         string
+		expected         *clabernetesapisv1alpha1.NodeSecurityContext
+	}{
+		{
+			name:       "no-security-contexts",
+			nativeMode: true,
+			nodeName:   "srl1",
+			nodeKind:   "srl",
+			expected:   nil,
+		},
+		{
+			name:       "docker-mode",
+			nativeMode: false,
+			securityContexts: map[string]clabernetesapisv1alpha1.NodeSecurityContext{
+				"linux1": {RunAsUser: clabernetesutil.ToPointer(int64(2000))},
+			},
+			nodeName: "linux1",
+			nodeKind: "linux",
+			expected: nil,
+		},
+		{
+			name:       "node-with-kind-defaults",
+			nativeMode: true,
+			securityContexts: map[string]clabernetesapisv1alpha1.NodeSecurityContext{
+				"crpd1": {RunAsUser: clabernetesutil.ToPointer(int64(2000))},
+			},
+			nodeName: "crpd1",
+			nodeKind: "juniper_crpd",
+			expected: &clabernetesapisv1alpha1.NodeSecurityContext{
+				RunAsUser:  clabernetesutil.ToPointer(int64(2000)),
+				RunAsGroup: clabernetesutil.ToPointer(int64(1000)),
+				FSGroup:    clabernetesutil.ToPointer(int64(1000)),
+			},
+		},
+		{
+			name:       "default-without-kind-defaults",
+			nativeMode: true,
+			securityContexts: map[string]clabernetesapisv1alpha1.NodeSecurityContext{
+				"default": {FSGroup: clabernetesutil.ToPointer(int64(3000))},
+				"linux1":  {RunAsUser: clabernetesutil.ToPointer(int64(2000))},
+			},
+			nodeName: "srl1",
+			nodeKind: "srl",
+			expected: &clabernetesapisv1alpha1.NodeSecurityContext{
+				FSGroup: clabernetesutil.ToPointer(int64(3000)),
+			},
+		},
+		{
+			name:       "node-overrides-default",
+			nativeMode: true,
+			securityContexts: map[string]clabernetesapisv1alpha1.NodeSecurityContext{
+				"default": {FSGroup: clabernetesutil.ToPointer(int64(3000))},
+				"linux1":  {RunAsUser: clabernetesutil.ToPointer(int64(0))},
+			},
+			nodeName: "linux1",
+			nodeKind: "linux",
+			expected: &clabernetesapisv1alpha1.NodeSecurityContext{
+				RunAsUser:  clabernetesutil.ToPointer(int64(0)),
+				RunAsGroup: clabernetesutil.ToPointer(int64(1000)),
+				FSGroup:    clabernetesutil.ToPointer(int64(1000)),
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				topology := &clabernetesapisv1alpha1.Topology{
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						Deployment: clabernetesapisv1alpha1.Deployment{
+							NativeMode:           clabernetesutil.ToPointer(testCase.nativeMode),
+							NodeSecurityContexts: testCase.securityContexts,
+						},
+					},
+				}
+
+				actual := clabernetescontrollerstopology.ResolveNodeSecurityContext(
+					topology,
+					testCase.nodeName,
+					testCase.nodeKind,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
@@ -105,6 +105,14 @@ func (r *ServiceExposeReconciler) Resolve(
 			continue
 		}
 
+		// bridges are just l2 segments the other nodes link to, there is nothing to expose
+		if nodeData != nil && nodeData.Topology != nil {
+			nodeKind, _ := nodeData.Topology.GetNodeKindType(nodeName)
+			if nodeKind == clabernetesconstants.BridgeKind {
+				continue
+			}
+		}
+
 		// if disable auto expose is true *and* there are no ports defined for the node *and*
 		// there are no default ports defined for the topology we can skip the node from an expose
 		// perspective.
@@ -159,6 +167,7 @@ func (r *ServiceExposeReconciler) Render(
 		reconcileData, service, nodeName)
 
 	r.renderServicePorts(
+		owningTopology,
 		reconcileData,
 		service,
 		nodeName,
@@ -260,6 +269,7 @@ func (r *ServiceExposeReconciler) renderServiceBase(
 
 func (r *ServiceExposeReconciler) parseContainerlabTopologyPortsSection(
 	portDefinition string,
+	nativeMode bool,
 ) (bool, *k8scorev1.ServicePort) {
 	typedPort, err := clabernetesutilcontainerlab.ProcessPortDefinition(portDefinition)
 	if err != nil {
@@ -268,6 +278,13 @@ func (r *ServiceExposeReconciler) parseContainerlabTopologyPortsSection(
 		return true, nil
 	}
 
+	// in native mode there is no docker publishing the expose port, the nos container listens in
+	// the pod network namespace itself, so point the service straight at the destination port
+	targetPort := typedPort.ExposePort
+	if nativeMode {
+		targetPort = typedPort.DestinationPort
+	}
+
 	return false, &k8scorev1.ServicePort{
 		Name: fmt.Sprintf(
 			"port-%d-%s", typedPort.DestinationPort, strings.ToLower(typedPort.Protocol),
@@ -275,12 +292,59 @@ func (r *ServiceExposeReconciler) parseContainerlabTopologyPortsSection(
 		Protocol: k8scorev1.Protocol(typedPort.Protocol),
 		Port:     int32(typedPort.DestinationPort), //nolint: gosec
 		TargetPort: intstr.IntOrString{
-			IntVal: int32(typedPort.ExposePort), //nolint: gosec
+			IntVal: int32(targetPort), //nolint: gosec
 		},
 	}
 }
 
+// nodePortDefinitions returns the (deduplicated, sorted) port definitions of the given node of the
+// given sub-topology config -- for actual containerlab configs we copy the users given defaults
+// into each "sub topology", so this is the node ports plus the default (topology wide) ports.
+func nodePortDefinitions(
+	nodeConfig *clabernetesutilcontainerlab.Config,
+	nodeName string,
+) []string {
+	if nodeConfig == nil || nodeConfig.Topology == nil {
+		return nil
+	}
+
+	allContainerlabPorts := clabernetesutil.NewStringSet()
+
+	nodeDefinition, ok := nodeConfig.Topology.Nodes[nodeName]
+	if ok && nodeDefinition != nil {
+		allContainerlabPorts.Extend(nodeDefinition.Ports)
+	}
+
+	if nodeConfig.Topology.Defaults != nil {
+		allContainerlabPorts.Extend(nodeConfig.Topology.Defaults.Ports)
+	}
+
+	allContainerlabPortsItems := allContainerlabPorts.Items()
+	sort.Strings(allContainerlabPortsItems)
+
+	return allContainerlabPortsItems
+}
+
+// connectivityPortInUse returns true if the given exposed (target) port is used by the launcher
+// for connectivity (or its metrics) -- exposing such a port would point it at the launcher rather
+// than the node, so we skip those.
+func connectivityPortInUse(
+	connectivityPorts clabernetesapisv1alpha1.ConnectivityPorts,
+	protocol k8scorev1.Protocol,
+	targetPort int32,
+) bool {
+	if protocol == clabernetesconstants.TCP {
+		return targetPort == connectivityPorts.Slurpeeth ||
+			targetPort == clabernetesconstants.MetricsServicePort
+	}
+
+	return targetPort == connectivityPorts.VXLAN ||
+		targetPort == connectivityPorts.Geneve ||
+		targetPort == connectivityPorts.WireGuard
+}
+
 func (r *ServiceExposeReconciler) renderServicePorts(
+	owningTopology *clabernetesapisv1alpha1.Topology,
 	reconcileData *ReconcileData,
 	service *k8scorev1.Service,
 	nodeName string,
@@ -292,29 +356,33 @@ func (r *ServiceExposeReconciler) renderServicePorts(
 
 	ports := make([]k8scorev1.ServicePort, 0)
 
-	// for actual containerlab configs we copy the users given defaults into each "sub topology" --
-	// so in the case of containerlab we want to make sure we also iterate over the "default" or
-	// topology wide ports that were specified. in this process we dont want to duplicate things, so
-	// we use a simple set implementation to make sure we aren't doubling up on any port
-	// definitions.
-	allContainerlabPorts := clabernetesutil.NewStringSet()
-
-	allContainerlabPorts.Extend(
-		reconcileData.ResolvedConfigs[nodeName].Topology.Nodes[nodeName].Ports,
+	connectivityPorts := ResolveConnectivityPorts(
+		owningTopology,
+		r.configManagerGetter().GetConnectivityPorts(),
 	)
 
-	allContainerlabPorts.Extend(reconcileData.ResolvedConfigs[nodeName].Topology.Defaults.Ports)
+	nativeMode := ResolveNativeMode(owningTopology)
 
-	allContainerlabPortsItems := allContainerlabPorts.Items()
-	sort.Strings(allContainerlabPortsItems)
-
-	for _, portDefinition := range allContainerlabPortsItems {
-		shouldSkip, port := r.parseContainerlabTopologyPortsSection(portDefinition)
+	for _, portDefinition := range nodePortDefinitions(
+		reconcileData.ResolvedConfigs[nodeName],
+		nodeName,
+	) {
+		shouldSkip, port := r.parseContainerlabTopologyPortsSection(portDefinition, nativeMode)
 
 		if shouldSkip {
 			continue
 		}
 
+		if connectivityPortInUse(connectivityPorts, port.Protocol, port.TargetPort.IntVal) {
+			r.log.Warnf(
+				"skipping port %q for node %q as it conflicts with a connectivity port",
+				portDefinition,
+				nodeName,
+			)
+
+			continue
+		}
+
 		ports = append(ports, *port)
 
 		// dont forget to update the exposed ports status bits
@@ -84,6 +84,55 @@ func TestResolveServiceExpose(t *testing.T) {
 			expectedMissing: []string{"node1", "node2"},
 			expectedExtra:   []*k8scorev1.Service{},
 		},
+		{
+			name:          "bridge-nodes-not-exposed",
+			ownedServices: &k8scorev1.ServiceList{},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind: "srl",
+							},
+						},
+					},
+				},
+				"br1": {
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"br1": {
+								Kind: "bridge",
+							},
+						},
+					},
+				},
+			},
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "resolve-servicefabric-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+        br1:
+          kind: bridge
+`,
+					},
+				},
+			},
+			expectedCurrent: nil,
+			expectedMissing: []string{"srl1"},
+			expectedExtra:   []*k8scorev1.Service{},
+		},
 		{
 			name: "extra-nodes",
 			ownedServices: &k8scorev1.ServiceList{
@@ -122,7 +171,7 @@ func TestResolveServiceExpose(t *testing.T) {
 				},
 			},
 			expectedCurrent: nil,
-			expectedMissing: nil,
+			expectedMissing: []string{"node1"},
 			expectedExtra: []*k8scorev1.Service{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -168,7 +217,8 @@ func TestResolveServiceExpose(t *testing.T) {
 					clabernetestesthelper.FailOutput(t, gotCurrent, testCase.expectedCurrent)
 				}
 
-				if !clabernetesutil.StringSliceContainsAll(got.Missing, testCase.expectedMissing) {
+				if len(got.Missing) != len(testCase.expectedMissing) ||
+					!clabernetesutil.StringSliceContainsAll(got.Missing, testCase.expectedMissing) {
 					clabernetestesthelper.FailOutput(t, got.Missing, testCase.expectedMissing)
 				}
 
@@ -378,6 +428,68 @@ func TestRenderServiceExpose(t *testing.T) {
 			},
 			nodeName: "srl1",
 		},
+		{
+			name: "native-mode",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-service-expose-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Expose: clabernetesapisv1alpha1.Expose{
+						ExposeType:        string(k8scorev1.ServiceTypeClusterIP),
+						DisableAutoExpose: true,
+					},
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						NativeMode: clabernetesutil.ToPointer(true),
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+          ports:
+            - 830/tcp
+`,
+					},
+				},
+				Status: clabernetesapisv1alpha1.TopologyStatus{
+					RemoveTopologyPrefix: clabernetesutil.ToPointer(true),
+				},
+			},
+			owningTopologyStatus: &clabernetesapisv1alpha1.TopologyStatus{
+				ExposedPorts: map[string]*clabernetesapisv1alpha1.ExposedPorts{},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+								Ports: []string{
+									"60000:830/tcp",
+								},
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName: "srl1",
+		},
 		{
 			name: "use-mgmt-ip-as-loadbalancer-ip-both-ipv4-and-ipv6",
 			owningTopology: &clabernetesapisv1alpha1.Topology{
@@ -178,6 +178,11 @@ func (r *ServiceFabricReconciler) renderServiceBase(
 		labels[k] = v
 	}
 
+	connectivityPorts := ResolveConnectivityPorts(
+		owningTopology,
+		r.configManagerGetter().GetConnectivityPorts(),
+	)
+
 	return &k8scorev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
@@ -190,17 +195,41 @@ func (r *ServiceFabricReconciler) renderServiceBase(
 				{
 					Name:     "vxlan",
 					Protocol: clabernetesconstants.UDP,
-					Port:     clabernetesconstants.VXLANServicePort,
+					Port:     connectivityPorts.VXLAN,
 					TargetPort: intstr.IntOrString{
-						IntVal: clabernetesconstants.VXLANServicePort,
+						IntVal: connectivityPorts.VXLAN,
 					},
 				},
 				{
 					Name:     "slurpeeth",
 					Protocol: clabernetesconstants.TCP,
-					Port:     clabernetesconstants.SlurpeethServicePort,
+					Port:     connectivityPorts.Slurpeeth,
+					TargetPort: intstr.IntOrString{
+						IntVal: connectivityPorts.Slurpeeth,
+					},
+				},
+				{
+					Name:     "geneve",
+					Protocol: clabernetesconstants.UDP,
+					Port:     connectivityPorts.Geneve,
+					TargetPort: intstr.IntOrString{
+						IntVal: connectivityPorts.Geneve,
+					},
+				},
+				{
+					Name:     "wireguard",
+					Protocol: clabernetesconstants.UDP,
+					Port:     connectivityPorts.WireGuard,
+					TargetPort: intstr.IntOrString{
+						IntVal: connectivityPorts.WireGuard,
+					},
+				},
+				{
+					Name:     "self-test",
+					Protocol: clabernetesconstants.TCP,
+					Port:     clabernetesconstants.SelfTestServicePort,
 					TargetPort: intstr.IntOrString{
-						IntVal: clabernetesconstants.SlurpeethServicePort,
+						IntVal: clabernetesconstants.SelfTestServicePort,
 					},
 				},
 			},
@@ -177,6 +177,32 @@ func TestRenderServiceFabric(t *testing.T) {
 			},
 			nodeName: "srl1",
 		},
+		{
+			name: "connectivity-ports",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-service-fabric-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+					ConnectivityPorts: &clabernetesapisv1alpha1.ConnectivityPorts{
+						VXLAN:     8472,
+						Slurpeeth: 4800,
+					},
+				},
+			},
+			nodeName: "srl1",
+		},
 	}
 
 	for _, testCase := range cases {
@@ -0,0 +1,310 @@
+package topology
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	slurpeethTLSCAKey   = "ca.crt"
+	slurpeethTLSCertKey = k8scorev1.TLSCertKey
+	slurpeethTLSKeyKey  = k8scorev1.TLSPrivateKeyKey
+
+	// slurpeethTLSValidity is how long the generated ca and certificate are valid for, once less
+	// than slurpeethTLSRenewBefore of that is left we generate new ones.
+	slurpeethTLSValidity    = 10 * 365 * 24 * time.Hour
+	slurpeethTLSRenewBefore = 30 * 24 * time.Hour
+
+	slurpeethTLSSerialNumberBits = 128
+)
+
+func slurpeethTLSSecretName(owningTopologyName string) string {
+	return fmt.Sprintf("%s-slurpeeth-tls", owningTopologyName)
+}
+
+// slurpeethTLSEnabled returns true if the given topology uses slurpeeth connectivity with tls.
+func slurpeethTLSEnabled(owningTopology *clabernetesapisv1alpha1.Topology) bool {
+	return owningTopology.Spec.Connectivity == clabernetesconstants.ConnectivitySlurpeeth &&
+		owningTopology.Spec.SlurpeethTLS != nil
+}
+
+// resolveSlurpeethTLSSecretName returns the name of the secret holding the slurpeeth tls material
+// for the given topology -- the user provided secret if there is one, otherwise the one the
+// controller generates.
+func resolveSlurpeethTLSSecretName(owningTopology *clabernetesapisv1alpha1.Topology) string {
+	slurpeethTLS := owningTopology.Spec.SlurpeethTLS
+
+	if slurpeethTLS != nil && slurpeethTLS.SecretName != "" {
+		return slurpeethTLS.SecretName
+	}
+
+	return slurpeethTLSSecretName(owningTopology.GetName())
+}
+
+// SlurpeethTLSSecretReconciler is a subcomponent of the "TopologyReconciler" but is exposed for
+// testing purposes. This is the component responsible for rendering/validating the secret holding
+// the ca and certificate the launchers of a topology use to wrap "slurpeeth" connectivity in
+// mutual tls (when the user did not bring their own secret that is).
+type SlurpeethTLSSecretReconciler struct {
+	log                 claberneteslogging.Instance
+	configManagerGetter clabernetesconfig.ManagerGetterFunc
+}
+
+// NewSlurpeethTLSSecretReconciler returns an instance of SlurpeethTLSSecretReconciler.
+func NewSlurpeethTLSSecretReconciler(
+	log claberneteslogging.Instance,
+	configManagerGetter clabernetesconfig.ManagerGetterFunc,
+) *SlurpeethTLSSecretReconciler {
+	return &SlurpeethTLSSecretReconciler{
+		log:                 log,
+		configManagerGetter: configManagerGetter,
+	}
+}
+
+// Render accepts the owning topology and the existing slurpeeth tls secret (which may be nil) and
+// renders the slurpeeth tls secret. The existing ca and certificate are retained as long as they
+// are valid (and not about to expire) so that launchers don't need to be restarted every
+// reconcile.
+func (r *SlurpeethTLSSecretReconciler) Render(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	existingSecret *k8scorev1.Secret,
+) (*k8scorev1.Secret, error) {
+	owningTopologyName := owningTopology.GetName()
+
+	annotations, globalLabels := r.configManagerGetter().GetAllMetadata()
+
+	labels := map[string]string{
+		clabernetesconstants.LabelApp:           clabernetesconstants.Clabernetes,
+		clabernetesconstants.LabelName:          slurpeethTLSSecretName(owningTopologyName),
+		clabernetesconstants.LabelTopologyOwner: owningTopologyName,
+	}
+
+	for k, v := range globalLabels {
+		labels[k] = v
+	}
+
+	var data map[string][]byte
+
+	if existingSecret != nil && slurpeethTLSDataValid(existingSecret.Data) {
+		data = existingSecret.Data
+	} else {
+		r.log.Infof(
+			"generating slurpeeth tls ca and certificate for topology %q",
+			owningTopologyName,
+		)
+
+		var err error
+
+		data, err = generateSlurpeethTLSData(owningTopology)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        slurpeethTLSSecretName(owningTopologyName),
+			Namespace:   owningTopology.GetNamespace(),
+			Annotations: annotations,
+			Labels:      labels,
+		},
+		Type: k8scorev1.SecretTypeOpaque,
+		Data: data,
+	}, nil
+}
+
+// Conforms checks if the existingSecret conforms with the renderedSecret.
+func (r *SlurpeethTLSSecretReconciler) Conforms(
+	existingSecret,
+	renderedSecret *k8scorev1.Secret,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !reflect.DeepEqual(existingSecret.Data, renderedSecret.Data) {
+		return false
+	}
+
+	if !clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
+		existingSecret.ObjectMeta.Annotations,
+		renderedSecret.ObjectMeta.Annotations,
+	) {
+		return false
+	}
+
+	if !clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
+		existingSecret.ObjectMeta.Labels,
+		renderedSecret.ObjectMeta.Labels,
+	) {
+		return false
+	}
+
+	if len(existingSecret.ObjectMeta.OwnerReferences) != 1 {
+		// we should have only one owner reference, the topology
+		return false
+	}
+
+	if existingSecret.ObjectMeta.OwnerReferences[0].UID != expectedOwnerUID {
+		// owner ref uid is not us
+		return false
+	}
+
+	return true
+}
+
+// slurpeethTLSDataValid returns true if the given secret data holds a certificate that was issued
+// by the ca in the same data and that is not about to expire.
+func slurpeethTLSDataValid(data map[string][]byte) bool {
+	if len(data[slurpeethTLSKeyKey]) == 0 {
+		return false
+	}
+
+	caPool := x509.NewCertPool()
+
+	if !caPool.AppendCertsFromPEM(data[slurpeethTLSCAKey]) {
+		return false
+	}
+
+	certificatePEM, _ := pem.Decode(data[slurpeethTLSCertKey])
+	if certificatePEM == nil {
+		return false
+	}
+
+	certificate, err := x509.ParseCertificate(certificatePEM.Bytes)
+	if err != nil {
+		return false
+	}
+
+	_, err = certificate.Verify(x509.VerifyOptions{
+		Roots:       caPool,
+		CurrentTime: time.Now().Add(slurpeethTLSRenewBefore),
+		KeyUsages: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+	})
+
+	return err == nil
+}
+
+// generateSlurpeethTLSData generates a (self-signed) ca and a certificate issued by that ca that is
+// shared by all launchers of the given topology -- the launchers only check that their peers hold
+// a certificate issued by the topology ca, so there is no point in per node certificates.
+func generateSlurpeethTLSData(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) (map[string][]byte, error) {
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := notBefore.Add(slurpeethTLSValidity)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	caSerialNumber, err := slurpeethTLSSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	caTemplate := &x509.Certificate{
+		SerialNumber: caSerialNumber,
+		Subject: pkix.Name{
+			CommonName: fmt.Sprintf(
+				"%s/%s slurpeeth ca",
+				owningTopology.GetNamespace(),
+				owningTopology.GetName(),
+			),
+		},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	caDER, err := x509.CreateCertificate(
+		rand.Reader,
+		caTemplate,
+		caTemplate,
+		&caKey.PublicKey,
+		caKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serialNumber, err := slurpeethTLSSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName: fmt.Sprintf(
+				"%s/%s slurpeeth",
+				owningTopology.GetNamespace(),
+				owningTopology.GetName(),
+			),
+		},
+		NotBefore: notBefore,
+		NotAfter:  notAfter,
+		KeyUsage:  x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+	}
+
+	certificateDER, err := x509.CreateCertificate(
+		rand.Reader,
+		template,
+		caTemplate,
+		&key.PublicKey,
+		caKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		slurpeethTLSCAKey: pem.EncodeToMemory(
+			&pem.Block{Type: "CERTIFICATE", Bytes: caDER},
+		),
+		slurpeethTLSCertKey: pem.EncodeToMemory(
+			&pem.Block{Type: "CERTIFICATE", Bytes: certificateDER},
+		),
+		slurpeethTLSKeyKey: pem.EncodeToMemory(
+			&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER},
+		),
+	}, nil
+}
+
+func slurpeethTLSSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), slurpeethTLSSerialNumberBits))
+}
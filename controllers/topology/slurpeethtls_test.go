@@ -0,0 +1,72 @@
+package topology_test
+
+import (
+	"crypto/tls"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestRenderSlurpeethTLSSecret ensures that we generate a ca and certificate for new secrets,
+// retain them for existing (valid) secrets, and regenerate them for invalid secrets.
+func TestRenderSlurpeethTLSSecret(t *testing.T) {
+	reconciler := clabernetescontrollerstopology.NewSlurpeethTLSSecretReconciler(
+		&claberneteslogging.FakeInstance{},
+		clabernetesconfig.GetFakeManager,
+	)
+
+	owningTopology := &clabernetesapisv1alpha1.Topology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-slurpeeth",
+			Namespace: "nowhere",
+		},
+		Spec: clabernetesapisv1alpha1.TopologySpec{
+			SlurpeethTLS: &clabernetesapisv1alpha1.SlurpeethTLS{},
+		},
+	}
+
+	initialSecret, err := reconciler.Render(owningTopology, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if initialSecret.Name != "test-slurpeeth-slurpeeth-tls" {
+		clabernetestesthelper.FailOutput(t, initialSecret.Name, "test-slurpeeth-slurpeeth-tls")
+	}
+
+	if len(initialSecret.Data) != 3 {
+		clabernetestesthelper.FailOutput(t, len(initialSecret.Data), 3)
+	}
+
+	_, err = tls.X509KeyPair(initialSecret.Data["tls.crt"], initialSecret.Data["tls.key"])
+	if err != nil {
+		t.Fatalf("expected a valid certificate/key pair, error: %s", err)
+	}
+
+	retainedSecret, err := reconciler.Render(owningTopology, initialSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"ca.crt", "tls.crt", "tls.key"} {
+		if string(retainedSecret.Data[key]) != string(initialSecret.Data[key]) {
+			clabernetestesthelper.FailOutput(t, retainedSecret.Data[key], initialSecret.Data[key])
+		}
+	}
+
+	initialSecret.Data["ca.crt"] = []byte("not a ca")
+
+	regeneratedSecret, err := reconciler.Render(owningTopology, initialSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(regeneratedSecret.Data["tls.crt"]) == string(retainedSecret.Data["tls.crt"]) {
+		t.Fatal("expected an invalid secret to get a freshly generated certificate")
+	}
+}
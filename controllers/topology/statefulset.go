@@ -0,0 +1,423 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimeutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// StatefulSetReconciler is a subcomponent of the "TopologyReconciler" but is exposed for testing
+// purposes. This is the component responsible for rendering/validating statefulsets for a
+// clabernetes topology resource whose launchers run as statefulsets rather than deployments. The
+// statefulsets are rendered from the deployments the DeploymentReconciler renders, so both kinds
+// of launchers are exactly the same but for the workload wrapping them.
+type StatefulSetReconciler struct {
+	log                  claberneteslogging.Instance
+	deploymentReconciler *DeploymentReconciler
+	pvcReconciler        *PersistentVolumeClaimReconciler
+}
+
+// NewStatefulSetReconciler returns an instance of StatefulSetReconciler.
+func NewStatefulSetReconciler(
+	log claberneteslogging.Instance,
+	deploymentReconciler *DeploymentReconciler,
+	pvcReconciler *PersistentVolumeClaimReconciler,
+) *StatefulSetReconciler {
+	return &StatefulSetReconciler{
+		log:                  log,
+		deploymentReconciler: deploymentReconciler,
+		pvcReconciler:        pvcReconciler,
+	}
+}
+
+// Resolve accepts a mapping of clabernetes configs and a list of statefulsets that are -- by owner
+// reference and/or labels -- associated with the topology. It returns a ObjectDiffer object
+// that contains the missing, extra, and current statefulsets for the topology. Unless the
+// launchers of the topology run as statefulsets all statefulsets are extraneous.
+func (r *StatefulSetReconciler) Resolve(
+	ownedStatefulSets *k8sappsv1.StatefulSetList,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) (*clabernetesutil.ObjectDiffer[*k8sappsv1.StatefulSet], error) {
+	statefulSets := &clabernetesutil.ObjectDiffer[*k8sappsv1.StatefulSet]{
+		Current: map[string]*k8sappsv1.StatefulSet{},
+	}
+
+	for i := range ownedStatefulSets.Items {
+		labels := ownedStatefulSets.Items[i].Labels
+
+		if labels == nil {
+			return nil, fmt.Errorf(
+				"%w: labels are nil, but we expect to see topology owner label here",
+				claberneteserrors.ErrInvalidData,
+			)
+		}
+
+		nodeName, ok := labels[clabernetesconstants.LabelTopologyNode]
+		if !ok || nodeName == "" {
+			return nil, fmt.Errorf(
+				"%w: topology node label is missing or empty",
+				claberneteserrors.ErrInvalidData,
+			)
+		}
+
+		statefulSets.Current[nodeName] = &ownedStatefulSets.Items[i]
+	}
+
+	if ResolveLauncherKind(owningTopology) != clabernetesconstants.KubernetesKindStatefulSet {
+		statefulSets.SetExtra(nil)
+
+		return statefulSets, nil
+	}
+
+	allNodes := make([]string, 0, len(clabernetesConfigs))
+
+	for nodeName := range clabernetesConfigs {
+		allNodes = append(allNodes, nodeName)
+	}
+
+	statefulSets.SetMissing(allNodes)
+	statefulSets.SetExtra(allNodes)
+
+	return statefulSets, nil
+}
+
+// Render accepts the owning topology a mapping of clabernetes sub-topology configs and a node name
+// and renders the final statefulset for this node. The statefulset uses the node's fabric service
+// as its governing service and, with persistence enabled, claims the persistence volume via a
+// volume claim template rather than the separately managed pvc deployments use.
+func (r *StatefulSetReconciler) Render(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	nodeName string,
+) *k8sappsv1.StatefulSet {
+	deployment := r.deploymentReconciler.Render(owningTopology, clabernetesConfigs, nodeName)
+
+	statefulSet := &k8sappsv1.StatefulSet{
+		ObjectMeta: deployment.ObjectMeta,
+		Spec: k8sappsv1.StatefulSetSpec{
+			Replicas:             deployment.Spec.Replicas,
+			RevisionHistoryLimit: deployment.Spec.RevisionHistoryLimit,
+			Selector:             deployment.Spec.Selector,
+			Template:             deployment.Spec.Template,
+			ServiceName:          fmt.Sprintf("%s-vx", deployment.Name),
+			PersistentVolumeClaimRetentionPolicy: &k8sappsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{ //nolint:lll
+				// the claim goes with the statefulset (so with the node or the topology), but
+				// survives the statefulset being scaled to zero while the topology is suspended
+				WhenDeleted: k8sappsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  k8sappsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+			},
+		},
+	}
+
+	r.renderStatefulSetPersistence(statefulSet, owningTopology, nodeName)
+
+	return statefulSet
+}
+
+// RenderAll accepts the owning topology a mapping of clabernetes sub-topology configs and a
+// list of node names and renders the final statefulsets for the given nodes.
+func (r *StatefulSetReconciler) RenderAll(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	nodeNames []string,
+) []*k8sappsv1.StatefulSet {
+	statefulSets := make([]*k8sappsv1.StatefulSet, len(nodeNames))
+
+	for idx, nodeName := range nodeNames {
+		statefulSets[idx] = r.Render(
+			owningTopology,
+			clabernetesConfigs,
+			nodeName,
+		)
+	}
+
+	return statefulSets
+}
+
+// Conforms checks if the existingStatefulSet conforms with the renderedStatefulSet. The pod
+// template and metadata are held to exactly the same standard as those of launcher deployments.
+func (r *StatefulSetReconciler) Conforms(
+	existingStatefulSet,
+	renderedStatefulSet *k8sappsv1.StatefulSet,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	return r.deploymentReconciler.Conforms(
+		statefulSetAsDeployment(existingStatefulSet),
+		statefulSetAsDeployment(renderedStatefulSet),
+		expectedOwnerUID,
+	)
+}
+
+// RequiresRecreate returns true if the existingStatefulSet differs from the renderedStatefulSet in
+// any of the fields kubernetes does not allow updating -- the governing service, the selector or
+// the (names of the) volume claim templates, the latter changing when persistence is toggled.
+func (r *StatefulSetReconciler) RequiresRecreate(
+	existingStatefulSet,
+	renderedStatefulSet *k8sappsv1.StatefulSet,
+) bool {
+	if existingStatefulSet.Spec.ServiceName != renderedStatefulSet.Spec.ServiceName {
+		return true
+	}
+
+	if !reflect.DeepEqual(existingStatefulSet.Spec.Selector, renderedStatefulSet.Spec.Selector) {
+		return true
+	}
+
+	return !slices.Equal(
+		volumeClaimTemplateNames(existingStatefulSet),
+		volumeClaimTemplateNames(renderedStatefulSet),
+	)
+}
+
+func (r *StatefulSetReconciler) renderStatefulSetPersistence(
+	statefulSet *k8sappsv1.StatefulSet,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	nodeName string,
+) {
+	if !owningTopology.Spec.Deployment.Persistence.Enabled {
+		return
+	}
+
+	// the pod claims the volume via the claim template (that the volume mount refers to by name),
+	// so the volume referencing the standalone pvc has to go
+	statefulSet.Spec.Template.Spec.Volumes = slices.DeleteFunc(
+		statefulSet.Spec.Template.Spec.Volumes,
+		func(volume k8scorev1.Volume) bool {
+			return volume.Name == persistenceVolumeName
+		},
+	)
+
+	pvc := r.pvcReconciler.Render(owningTopology, nodeName, nil)
+
+	// the claims belong to the statefulset rather than the topology, so they must not carry the
+	// topology owner label -- the pvc reconciler would prune them otherwise
+	delete(pvc.Labels, clabernetesconstants.LabelTopologyOwner)
+
+	statefulSet.Spec.VolumeClaimTemplates = []k8scorev1.PersistentVolumeClaim{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        persistenceVolumeName,
+				Annotations: pvc.Annotations,
+				Labels:      pvc.Labels,
+			},
+			Spec: pvc.Spec,
+		},
+	}
+}
+
+// statefulSetAsDeployment returns a deployment with the metadata, replicas, selector and pod
+// template of the given statefulset, so statefulsets can be checked like deployments are.
+func statefulSetAsDeployment(statefulSet *k8sappsv1.StatefulSet) *k8sappsv1.Deployment {
+	return &k8sappsv1.Deployment{
+		ObjectMeta: statefulSet.ObjectMeta,
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas: statefulSet.Spec.Replicas,
+			Selector: statefulSet.Spec.Selector,
+			Template: statefulSet.Spec.Template,
+		},
+	}
+}
+
+func volumeClaimTemplateNames(statefulSet *k8sappsv1.StatefulSet) []string {
+	names := make([]string, len(statefulSet.Spec.VolumeClaimTemplates))
+
+	for idx := range statefulSet.Spec.VolumeClaimTemplates {
+		names[idx] = statefulSet.Spec.VolumeClaimTemplates[idx].Name
+	}
+
+	return names
+}
+
+// launcherObjects returns the launcher workloads of the kind the launchers of the given topology
+// run as, so the kind agnostic parts of reconciling deployments need not care about the kind.
+func launcherObjects(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	deployments *clabernetesutil.ObjectDiffer[*k8sappsv1.Deployment],
+	statefulSets *clabernetesutil.ObjectDiffer[*k8sappsv1.StatefulSet],
+) *clabernetesutil.ObjectDiffer[ctrlruntimeclient.Object] {
+	launchers := &clabernetesutil.ObjectDiffer[ctrlruntimeclient.Object]{
+		Current: map[string]ctrlruntimeclient.Object{},
+	}
+
+	if ResolveLauncherKind(owningTopology) == clabernetesconstants.KubernetesKindStatefulSet {
+		for nodeName, statefulSet := range statefulSets.Current {
+			launchers.Current[nodeName] = statefulSet
+		}
+
+		launchers.Missing = statefulSets.Missing
+
+		return launchers
+	}
+
+	for nodeName, deployment := range deployments.Current {
+		launchers.Current[nodeName] = deployment
+	}
+
+	launchers.Missing = deployments.Missing
+
+	return launchers
+}
+
+// launcherReadyReplicas returns the ready replicas of the given launcher deployment/statefulset.
+func launcherReadyReplicas(launcher ctrlruntimeclient.Object) int32 {
+	switch typedLauncher := launcher.(type) {
+	case *k8sappsv1.Deployment:
+		return typedLauncher.Status.ReadyReplicas
+	case *k8sappsv1.StatefulSet:
+		return typedLauncher.Status.ReadyReplicas
+	default:
+		return 0
+	}
+}
+
+// launcherPodTemplate returns the pod template of the given launcher deployment/statefulset.
+func launcherPodTemplate(launcher ctrlruntimeclient.Object) *k8scorev1.PodTemplateSpec {
+	switch typedLauncher := launcher.(type) {
+	case *k8sappsv1.Deployment:
+		return &typedLauncher.Spec.Template
+	case *k8sappsv1.StatefulSet:
+		return &typedLauncher.Spec.Template
+	default:
+		return nil
+	}
+}
+
+// reconcileStatefulSets creates the statefulsets of the given missing nodes and enforces the
+// desired state on the existing statefulsets of the topology -- statefulsets that can not be
+// updated to the desired state are deleted, the resulting watch event recreates them.
+func (r *Reconciler) reconcileStatefulSets( //nolint:funlen
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+	statefulSets *clabernetesutil.ObjectDiffer[*k8sappsv1.StatefulSet],
+	missingNodes []string,
+) error {
+	r.Log.Info("creating missing statefulsets")
+
+	renderedMissingStatefulSets := r.StatefulSetReconciler.RenderAll(
+		owningTopology,
+		reconcileData.ResolvedConfigs,
+		missingNodes,
+	)
+
+	for idx, renderedMissingStatefulSet := range renderedMissingStatefulSets {
+		err := r.createObj(
+			ctx,
+			owningTopology,
+			renderedMissingStatefulSet,
+			clabernetesconstants.KubernetesStatefulSet,
+		)
+		if err != nil {
+			return err
+		}
+
+		recordControllerTimelineEvent(
+			reconcileData,
+			missingNodes[idx],
+			timelineReasonDeploymentCreated,
+			fmt.Sprintf("created statefulset %q", renderedMissingStatefulSet.Name),
+		)
+	}
+
+	r.Log.Info("enforcing desired state on existing statefulsets")
+
+	for existingStatefulSetNodeName, existingStatefulSet := range statefulSets.Current {
+		if reconcileData.ImageBlockedNodes.Contains(existingStatefulSetNodeName) {
+			r.Log.Warnf(
+				"not updating statefulset of node %q, its image is blocked by the image scan",
+				existingStatefulSetNodeName,
+			)
+
+			continue
+		}
+
+		renderedStatefulSet := r.StatefulSetReconciler.Render(
+			owningTopology,
+			reconcileData.ResolvedConfigs,
+			existingStatefulSetNodeName,
+		)
+
+		if r.StatefulSetReconciler.RequiresRecreate(existingStatefulSet, renderedStatefulSet) {
+			r.Log.Infof(
+				"statefulset of node %q can not be updated in place, deleting it so it is"+
+					" recreated",
+				existingStatefulSetNodeName,
+			)
+
+			err := r.deleteObj(ctx, existingStatefulSet, clabernetesconstants.KubernetesStatefulSet)
+			if err != nil {
+				return err
+			}
+
+			recordControllerTimelineEvent(
+				reconcileData,
+				existingStatefulSetNodeName,
+				timelineReasonDeploymentDeleted,
+				fmt.Sprintf(
+					"deleted statefulset %q to recreate it",
+					existingStatefulSet.Name,
+				),
+			)
+
+			continue
+		}
+
+		err := ctrlruntimeutil.SetOwnerReference(
+			owningTopology,
+			renderedStatefulSet,
+			r.Client.Scheme(),
+		)
+		if err != nil {
+			return err
+		}
+
+		if r.StatefulSetReconciler.Conforms(
+			existingStatefulSet,
+			renderedStatefulSet,
+			owningTopology.GetUID(),
+		) {
+			continue
+		}
+
+		// only diff'ing spec since we *probably* only care about that part (minus metadata)
+		r.diffIfDebug(existingStatefulSet.Spec, renderedStatefulSet.Spec)
+
+		// volume claim templates are immutable, so the existing ones have to stay as they are
+		existingClaimTemplates := existingStatefulSet.Spec.VolumeClaimTemplates
+
+		renderedStatefulSet.Spec.VolumeClaimTemplates = existingClaimTemplates
+
+		err = r.updateObj(
+			ctx,
+			renderedStatefulSet,
+			clabernetesconstants.KubernetesStatefulSet,
+		)
+		if err != nil {
+			return err
+		}
+
+		recordControllerTimelineEvent(
+			reconcileData,
+			existingStatefulSetNodeName,
+			timelineReasonDeploymentUpdated,
+			fmt.Sprintf("updated statefulset %q", renderedStatefulSet.Name),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,315 @@
+package topology_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const renderStatefulSetTestName = "statefulset/render-statefulset"
+
+func newStatefulSetReconciler() *clabernetescontrollerstopology.StatefulSetReconciler {
+	return clabernetescontrollerstopology.NewStatefulSetReconciler(
+		&claberneteslogging.FakeInstance{},
+		clabernetescontrollerstopology.NewDeploymentReconciler(
+			&claberneteslogging.FakeInstance{},
+			"clabernetes",
+			"clabernetes",
+			"",
+			clabernetesconfig.GetFakeManager,
+		),
+		clabernetescontrollerstopology.NewPersistentVolumeClaimReconciler(
+			&claberneteslogging.FakeInstance{},
+			clabernetesconfig.GetFakeManager,
+		),
+	)
+}
+
+func TestResolveStatefulSet(t *testing.T) {
+	cases := []struct {
+		name              string
+		ownedStatefulSets *k8sappsv1.StatefulSetList
+		owningTopology    *clabernetesapisv1alpha1.Topology
+		expectedCurrent   []string
+		expectedMissing   []string
+		expectedExtra     []string
+	}{
+		{
+			name: "statefulset-kind",
+			ownedStatefulSets: &k8sappsv1.StatefulSetList{
+				Items: []k8sappsv1.StatefulSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "resolve-statefulset-test-node1",
+							Labels: map[string]string{
+								clabernetesconstants.LabelTopologyNode: "node1",
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "resolve-statefulset-test-node3",
+							Labels: map[string]string{
+								clabernetesconstants.LabelTopologyNode: "node3",
+							},
+						},
+					},
+				},
+			},
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						Kind: clabernetesconstants.KubernetesKindStatefulSet,
+					},
+				},
+			},
+			expectedCurrent: []string{"node1", "node3"},
+			expectedMissing: []string{"node2"},
+			expectedExtra:   []string{"resolve-statefulset-test-node3"},
+		},
+		{
+			name: "deployment-kind",
+			ownedStatefulSets: &k8sappsv1.StatefulSetList{
+				Items: []k8sappsv1.StatefulSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "resolve-statefulset-test-node1",
+							Labels: map[string]string{
+								clabernetesconstants.LabelTopologyNode: "node1",
+							},
+						},
+					},
+				},
+			},
+			owningTopology:  &clabernetesapisv1alpha1.Topology{},
+			expectedCurrent: []string{"node1"},
+			expectedMissing: nil,
+			expectedExtra:   []string{"resolve-statefulset-test-node1"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				got, err := newStatefulSetReconciler().Resolve(
+					testCase.ownedStatefulSets,
+					map[string]*clabernetesutilcontainerlab.Config{
+						"node1": nil,
+						"node2": nil,
+					},
+					testCase.owningTopology,
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var gotCurrent []string
+
+				for current := range got.Current {
+					gotCurrent = append(gotCurrent, current)
+				}
+
+				if !clabernetesutil.StringSliceContainsAll(gotCurrent, testCase.expectedCurrent) {
+					clabernetestesthelper.FailOutput(t, gotCurrent, testCase.expectedCurrent)
+				}
+
+				if len(got.Missing) != len(testCase.expectedMissing) ||
+					!clabernetesutil.StringSliceContainsAll(got.Missing, testCase.expectedMissing) {
+					clabernetestesthelper.FailOutput(t, got.Missing, testCase.expectedMissing)
+				}
+
+				gotExtra := make([]string, len(got.Extra))
+
+				for idx, extra := range got.Extra {
+					gotExtra[idx] = extra.Name
+				}
+
+				clabernetestesthelper.MarshaledEqual(t, gotExtra, testCase.expectedExtra)
+			})
+	}
+}
+
+func TestRenderStatefulSet(t *testing.T) {
+	cases := []struct {
+		name           string
+		owningTopology *clabernetesapisv1alpha1.Topology
+	}{
+		{
+			name: "simple",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-statefulset-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						Kind: clabernetesconstants.KubernetesKindStatefulSet,
+					},
+				},
+			},
+		},
+		{
+			name: "persistence",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-statefulset-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						Kind: clabernetesconstants.KubernetesKindStatefulSet,
+						Persistence: clabernetesapisv1alpha1.Persistence{
+							Enabled:   true,
+							ClaimSize: "10Gi",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				got := newStatefulSetReconciler().Render(
+					testCase.owningTopology,
+					map[string]*clabernetesutilcontainerlab.Config{
+						"srl1": {
+							Name:   "srl1",
+							Prefix: clabernetesutil.ToPointer(""),
+							Topology: &clabernetesutilcontainerlab.Topology{
+								Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+								Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+									"srl1": {
+										Kind:  "srl",
+										Image: "ghcr.io/nokia/srlinux",
+									},
+								},
+							},
+						},
+					},
+					"srl1",
+				)
+
+				if *clabernetestesthelper.Update {
+					clabernetestesthelper.WriteTestFixtureJSON(
+						t,
+						fmt.Sprintf("golden/%s/%s.json", renderStatefulSetTestName, testCase.name),
+						got,
+					)
+				}
+
+				var want k8sappsv1.StatefulSet
+
+				err := json.Unmarshal(
+					clabernetestesthelper.ReadTestFixtureFile(
+						t,
+						fmt.Sprintf("golden/%s/%s.json", renderStatefulSetTestName, testCase.name),
+					),
+					&want,
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				clabernetestesthelper.MarshaledEqual(t, got, want)
+			})
+	}
+}
+
+func TestStatefulSetRequiresRecreate(t *testing.T) {
+	cases := []struct {
+		name     string
+		existing *k8sappsv1.StatefulSet
+		rendered *k8sappsv1.StatefulSet
+		expected bool
+	}{
+		{
+			name: "same",
+			existing: &k8sappsv1.StatefulSet{
+				Spec: k8sappsv1.StatefulSetSpec{
+					ServiceName: "topo-srl1-vx",
+					VolumeClaimTemplates: []k8scorev1.PersistentVolumeClaim{
+						{ObjectMeta: metav1.ObjectMeta{Name: "persistence"}},
+					},
+				},
+			},
+			rendered: &k8sappsv1.StatefulSet{
+				Spec: k8sappsv1.StatefulSetSpec{
+					ServiceName: "topo-srl1-vx",
+					VolumeClaimTemplates: []k8scorev1.PersistentVolumeClaim{
+						{ObjectMeta: metav1.ObjectMeta{Name: "persistence"}},
+					},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "different-service",
+			existing: &k8sappsv1.StatefulSet{
+				Spec: k8sappsv1.StatefulSetSpec{
+					ServiceName: "topo-srl1-vx",
+				},
+			},
+			rendered: &k8sappsv1.StatefulSet{
+				Spec: k8sappsv1.StatefulSetSpec{
+					ServiceName: "srl1-vx",
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "persistence-toggled",
+			existing: &k8sappsv1.StatefulSet{
+				Spec: k8sappsv1.StatefulSetSpec{
+					ServiceName: "topo-srl1-vx",
+				},
+			},
+			rendered: &k8sappsv1.StatefulSet{
+				Spec: k8sappsv1.StatefulSetSpec{
+					ServiceName: "topo-srl1-vx",
+					VolumeClaimTemplates: []k8scorev1.PersistentVolumeClaim{
+						{ObjectMeta: metav1.ObjectMeta{Name: "persistence"}},
+					},
+				},
+			},
+			expected: true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := newStatefulSetReconciler().RequiresRecreate(
+					testCase.existing,
+					testCase.rendered,
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
@@ -0,0 +1,87 @@
+package topology
+
+import (
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	k8scorev1 "k8s.io/api/core/v1"
+)
+
+// LastContainerTermination returns the most recent (non-successful) container termination of the
+// given pod, or nil if no container in the pod has terminated with an error. The termination
+// message written by the launcher is split back into its reason and message; if there is no such
+// message the reason is whatever kubernetes reported (for example "OOMKilled").
+func LastContainerTermination(pod *k8scorev1.Pod) *clabernetesapisv1alpha1.NodeTermination {
+	var lastTermination *clabernetesapisv1alpha1.NodeTermination
+
+	containerStatuses := make(
+		[]k8scorev1.ContainerStatus,
+		0,
+		len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses),
+	)
+
+	containerStatuses = append(containerStatuses, pod.Status.InitContainerStatuses...)
+	containerStatuses = append(containerStatuses, pod.Status.ContainerStatuses...)
+
+	for idx := range containerStatuses {
+		containerStatus := containerStatuses[idx]
+
+		for _, terminated := range []*k8scorev1.ContainerStateTerminated{
+			containerStatus.State.Terminated,
+			containerStatus.LastTerminationState.Terminated,
+		} {
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+
+			if lastTermination != nil &&
+				!terminated.FinishedAt.After(lastTermination.FinishedAt.Time) {
+				continue
+			}
+
+			reason, message := clabernetesutil.ParseTerminationMessage(terminated.Message)
+			if reason == "" {
+				reason = terminated.Reason
+			}
+
+			lastTermination = &clabernetesapisv1alpha1.NodeTermination{
+				Container:  containerStatus.Name,
+				Reason:     reason,
+				Message:    message,
+				ExitCode:   terminated.ExitCode,
+				FinishedAt: terminated.FinishedAt,
+			}
+		}
+	}
+
+	return lastTermination
+}
+
+// reconcileNodeTermination records the last container termination for the given node in the
+// reconcile data. Pods for a node come and go, so if none of the current pods have terminated we
+// hang on to whatever we recorded previously.
+func (r *Reconciler) reconcileNodeTermination(
+	reconcileData *ReconcileData,
+	nodeName string,
+	pods []k8scorev1.Pod,
+) {
+	previousTermination, hasPreviousTermination := reconcileData.PreviousNodeTerminations[nodeName]
+	if hasPreviousTermination {
+		reconcileData.NodeTerminations[nodeName] = previousTermination
+	}
+
+	for idx := range pods {
+		termination := LastContainerTermination(&pods[idx])
+		if termination == nil {
+			continue
+		}
+
+		existingTermination, ok := reconcileData.NodeTerminations[nodeName]
+		if ok && !termination.FinishedAt.After(existingTermination.FinishedAt.Time) {
+			continue
+		}
+
+		reconcileData.NodeTerminations[nodeName] = *termination
+
+		recordTerminationTimelineEvent(reconcileData, nodeName, termination)
+	}
+}
@@ -0,0 +1,126 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLastContainerTermination(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC))
+
+	cases := []struct {
+		name     string
+		pod      *k8scorev1.Pod
+		expected *clabernetesapisv1alpha1.NodeTermination
+	}{
+		{
+			name:     "no-terminations",
+			pod:      &k8scorev1.Pod{},
+			expected: nil,
+		},
+		{
+			name: "successful-init-container-ignored",
+			pod: &k8scorev1.Pod{
+				Status: k8scorev1.PodStatus{
+					InitContainerStatuses: []k8scorev1.ContainerStatus{
+						{
+							Name: "clabernetes-setup",
+							State: k8scorev1.ContainerState{
+								Terminated: &k8scorev1.ContainerStateTerminated{
+									ExitCode:   0,
+									Reason:     "Completed",
+									FinishedAt: later,
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "launcher-termination-message",
+			pod: &k8scorev1.Pod{
+				Status: k8scorev1.PodStatus{
+					ContainerStatuses: []k8scorev1.ContainerStatus{
+						{
+							Name: "srl1",
+							LastTerminationState: k8scorev1.ContainerState{
+								Terminated: &k8scorev1.ContainerStateTerminated{
+									ExitCode:   1,
+									Reason:     "Error",
+									Message:    "TunnelSetupFailed: no route to host",
+									FinishedAt: earlier,
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: &clabernetesapisv1alpha1.NodeTermination{
+				Container:  "srl1",
+				Reason:     "TunnelSetupFailed",
+				Message:    "no route to host",
+				ExitCode:   1,
+				FinishedAt: earlier,
+			},
+		},
+		{
+			name: "most-recent-wins",
+			pod: &k8scorev1.Pod{
+				Status: k8scorev1.PodStatus{
+					ContainerStatuses: []k8scorev1.ContainerStatus{
+						{
+							Name: "clabernetes-launcher",
+							LastTerminationState: k8scorev1.ContainerState{
+								Terminated: &k8scorev1.ContainerStateTerminated{
+									ExitCode:   1,
+									Reason:     "Error",
+									Message:    "LauncherFailed: failed configuring docker daemon",
+									FinishedAt: earlier,
+								},
+							},
+						},
+						{
+							Name: "srl1",
+							State: k8scorev1.ContainerState{
+								Terminated: &k8scorev1.ContainerStateTerminated{
+									ExitCode:   137,
+									Reason:     "OOMKilled",
+									FinishedAt: later,
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: &clabernetesapisv1alpha1.NodeTermination{
+				Container:  "srl1",
+				Reason:     "OOMKilled",
+				ExitCode:   137,
+				FinishedAt: later,
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.LastContainerTermination(testCase.pod)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
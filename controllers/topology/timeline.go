@@ -0,0 +1,251 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	timelineReasonDeploymentCreated = "DeploymentCreated"
+	timelineReasonDeploymentUpdated = "DeploymentUpdated"
+	timelineReasonDeploymentDeleted = "DeploymentDeleted"
+	timelineReasonNodeRestarted     = "NodeRestarted"
+	timelineReasonReadinessChanged  = "ReadinessChanged"
+	timelineReasonTunnelUp          = "TunnelUp"
+	timelineReasonTunnelDown        = "TunnelDown"
+	timelineReasonTunnelUnreachable = "TunnelPeerUnreachable"
+	timelineReasonTunnelSimulated   = "TunnelSimulated"
+)
+
+// RecordTimelineEvent adds the given event to the timeline of the topology, unless the timeline
+// already holds the very same event -- events derived from state the launchers report (tunnel
+// transitions, terminations) are "recorded" on every reconcile, so this is what keeps them from
+// piling up. Events without a time are stamped with the current time.
+func (r *ReconcileData) RecordTimelineEvent(event clabernetesapisv1alpha1.TimelineEvent) {
+	if event.Time.IsZero() {
+		event.Time = metav1.Now()
+	}
+
+	if len(r.PreviousTimeline) >= clabernetesconstants.TimelineMaxEvents &&
+		event.Time.Before(&r.PreviousTimeline[0].Time) {
+		// older than anything we still hang on to, it would be dropped right away anyway
+		return
+	}
+
+	for _, existingEvent := range r.PreviousTimeline {
+		if timelineEventsEqual(existingEvent, event) {
+			return
+		}
+	}
+
+	for _, existingEvent := range r.TimelineEvents {
+		if timelineEventsEqual(existingEvent, event) {
+			return
+		}
+	}
+
+	r.TimelineEvents = append(r.TimelineEvents, event)
+
+	r.ShouldUpdateResource = true
+}
+
+// timelineEventsEqual compares events at the (second) granularity the event times are stored at.
+func timelineEventsEqual(a, b clabernetesapisv1alpha1.TimelineEvent) bool {
+	return a.Time.Unix() == b.Time.Unix() &&
+		a.Source == b.Source &&
+		a.Node == b.Node &&
+		a.Reason == b.Reason &&
+		a.Message == b.Message
+}
+
+// MergeTimeline returns the chronologically sorted combination of the previous timeline and the
+// given (new) events, keeping only the most recent maxEvents events.
+func MergeTimeline(
+	previous,
+	events []clabernetesapisv1alpha1.TimelineEvent,
+	maxEvents int,
+) []clabernetesapisv1alpha1.TimelineEvent {
+	if len(events) == 0 {
+		return previous
+	}
+
+	merged := make([]clabernetesapisv1alpha1.TimelineEvent, 0, len(previous)+len(events))
+
+	merged = append(merged, previous...)
+	merged = append(merged, events...)
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(&merged[j].Time)
+	})
+
+	if len(merged) > maxEvents {
+		merged = merged[len(merged)-maxEvents:]
+	}
+
+	return merged
+}
+
+// TunnelTimelineEvents returns a timeline event for the last state transition of each of the
+// tunnels the launchers reported in the given connectivity status. As with the "TunnelsDown"
+// condition only nodes in the given resolved tunnels are considered.
+func TunnelTimelineEvents(
+	status clabernetesapisv1alpha1.ConnectivityStatus,
+	resolvedTunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+) []clabernetesapisv1alpha1.TimelineEvent {
+	var events []clabernetesapisv1alpha1.TimelineEvent
+
+	nodeNames := make([]string, 0, len(status.TunnelStatuses))
+
+	for nodeName := range status.TunnelStatuses {
+		if _, ok := resolvedTunnels[nodeName]; !ok {
+			continue
+		}
+
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	sort.Strings(nodeNames)
+
+	for _, nodeName := range nodeNames {
+		for _, tunnelStatus := range status.TunnelStatuses[nodeName] {
+			if tunnelStatus.LastTransitionTime.IsZero() {
+				continue
+			}
+
+			reason := timelineReasonTunnelUp
+
+			message := fmt.Sprintf(
+				"tunnel %s -> %s/%s is up",
+				tunnelStatus.LocalInterface,
+				tunnelStatus.RemoteNode,
+				tunnelStatus.RemoteInterface,
+			)
+
+			switch tunnelStatus.State {
+			case clabernetesconstants.TunnelStateDown:
+				reason = timelineReasonTunnelDown
+
+				message = fmt.Sprintf(
+					"tunnel %s -> %s/%s is down",
+					tunnelStatus.LocalInterface,
+					tunnelStatus.RemoteNode,
+					tunnelStatus.RemoteInterface,
+				)
+			case clabernetesconstants.TunnelStateUnreachable:
+				reason = timelineReasonTunnelUnreachable
+
+				message = fmt.Sprintf(
+					"tunnel %s -> %s/%s is up but the peer is unreachable",
+					tunnelStatus.LocalInterface,
+					tunnelStatus.RemoteNode,
+					tunnelStatus.RemoteInterface,
+				)
+			case clabernetesconstants.TunnelStateSimulated:
+				reason = timelineReasonTunnelSimulated
+
+				message = fmt.Sprintf(
+					"tunnel %s -> %s/%s is simulated",
+					tunnelStatus.LocalInterface,
+					tunnelStatus.RemoteNode,
+					tunnelStatus.RemoteInterface,
+				)
+			}
+
+			if tunnelStatus.State == clabernetesconstants.TunnelStateDown ||
+				tunnelStatus.State == clabernetesconstants.TunnelStateUnreachable {
+				if tunnelStatus.LastError != "" {
+					message = fmt.Sprintf("%s (%s)", message, tunnelStatus.LastError)
+				}
+			}
+
+			events = append(events, clabernetesapisv1alpha1.TimelineEvent{
+				Time:    tunnelStatus.LastTransitionTime,
+				Source:  clabernetesconstants.TimelineSourceLauncher,
+				Node:    nodeName,
+				Reason:  reason,
+				Message: message,
+			})
+		}
+	}
+
+	return events
+}
+
+// recordReadinessTimelineEvents records a timeline event for each node whose readiness changed
+// since the last reconcile.
+func recordReadinessTimelineEvents(reconcileData *ReconcileData) {
+	nodeNames := make([]string, 0, len(reconcileData.NodeStatuses))
+
+	for nodeName := range reconcileData.NodeStatuses {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	sort.Strings(nodeNames)
+
+	for _, nodeName := range nodeNames {
+		state := reconcileData.NodeStatuses[nodeName]
+
+		previousState, ok := reconcileData.PreviousNodeStatuses[nodeName]
+		if ok && previousState == state {
+			continue
+		}
+
+		message := fmt.Sprintf("node is %s", state)
+		if ok {
+			message = fmt.Sprintf("node went from %s to %s", previousState, state)
+		}
+
+		reconcileData.RecordTimelineEvent(clabernetesapisv1alpha1.TimelineEvent{
+			Source:  clabernetesconstants.TimelineSourceProbe,
+			Node:    nodeName,
+			Reason:  timelineReasonReadinessChanged,
+			Message: message,
+		})
+	}
+}
+
+// recordTerminationTimelineEvent records a timeline event for the given (launcher or node)
+// container termination.
+func recordTerminationTimelineEvent(
+	reconcileData *ReconcileData,
+	nodeName string,
+	termination *clabernetesapisv1alpha1.NodeTermination,
+) {
+	message := fmt.Sprintf(
+		"container %q terminated with exit code %d",
+		termination.Container,
+		termination.ExitCode,
+	)
+
+	if termination.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, termination.Message)
+	}
+
+	reconcileData.RecordTimelineEvent(clabernetesapisv1alpha1.TimelineEvent{
+		Time:    termination.FinishedAt,
+		Source:  clabernetesconstants.TimelineSourceLauncher,
+		Node:    nodeName,
+		Reason:  termination.Reason,
+		Message: message,
+	})
+}
+
+// recordControllerTimelineEvent records a timeline event for something the controller did to the
+// given node.
+func recordControllerTimelineEvent(
+	reconcileData *ReconcileData,
+	nodeName,
+	reason,
+	message string,
+) {
+	reconcileData.RecordTimelineEvent(clabernetesapisv1alpha1.TimelineEvent{
+		Source:  clabernetesconstants.TimelineSourceController,
+		Node:    nodeName,
+		Reason:  reason,
+		Message: message,
+	})
+}
@@ -0,0 +1,220 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func timelineTestEvent(minute int, reason string) clabernetesapisv1alpha1.TimelineEvent {
+	return clabernetesapisv1alpha1.TimelineEvent{
+		Time:   metav1.NewTime(time.Date(2024, 1, 1, 0, minute, 0, 0, time.UTC)),
+		Source: clabernetesconstants.TimelineSourceController,
+		Node:   "srl1",
+		Reason: reason,
+	}
+}
+
+func TestMergeTimeline(t *testing.T) {
+	cases := []struct {
+		name      string
+		previous  []clabernetesapisv1alpha1.TimelineEvent
+		events    []clabernetesapisv1alpha1.TimelineEvent
+		maxEvents int
+		expected  []clabernetesapisv1alpha1.TimelineEvent
+	}{
+		{
+			name: "simple",
+			previous: []clabernetesapisv1alpha1.TimelineEvent{
+				timelineTestEvent(1, "one"),
+			},
+			events:    nil,
+			maxEvents: 3,
+			expected: []clabernetesapisv1alpha1.TimelineEvent{
+				timelineTestEvent(1, "one"),
+			},
+		},
+		{
+			name: "sorted",
+			previous: []clabernetesapisv1alpha1.TimelineEvent{
+				timelineTestEvent(1, "one"),
+				timelineTestEvent(3, "three"),
+			},
+			events: []clabernetesapisv1alpha1.TimelineEvent{
+				timelineTestEvent(4, "four"),
+				timelineTestEvent(2, "two"),
+			},
+			maxEvents: 10,
+			expected: []clabernetesapisv1alpha1.TimelineEvent{
+				timelineTestEvent(1, "one"),
+				timelineTestEvent(2, "two"),
+				timelineTestEvent(3, "three"),
+				timelineTestEvent(4, "four"),
+			},
+		},
+		{
+			name: "bounded",
+			previous: []clabernetesapisv1alpha1.TimelineEvent{
+				timelineTestEvent(1, "one"),
+				timelineTestEvent(2, "two"),
+			},
+			events: []clabernetesapisv1alpha1.TimelineEvent{
+				timelineTestEvent(3, "three"),
+			},
+			maxEvents: 2,
+			expected: []clabernetesapisv1alpha1.TimelineEvent{
+				timelineTestEvent(2, "two"),
+				timelineTestEvent(3, "three"),
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.MergeTimeline(
+					testCase.previous,
+					testCase.events,
+					testCase.maxEvents,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestRecordTimelineEvent(t *testing.T) {
+	reconcileData := &clabernetescontrollerstopology.ReconcileData{
+		PreviousTimeline: []clabernetesapisv1alpha1.TimelineEvent{
+			timelineTestEvent(1, "one"),
+		},
+	}
+
+	// already in the previous timeline, must not be recorded again
+	reconcileData.RecordTimelineEvent(timelineTestEvent(1, "one"))
+
+	if reconcileData.ShouldUpdateResource || len(reconcileData.TimelineEvents) != 0 {
+		clabernetestesthelper.FailOutput(t, reconcileData.TimelineEvents, nil)
+	}
+
+	reconcileData.RecordTimelineEvent(timelineTestEvent(2, "two"))
+	reconcileData.RecordTimelineEvent(timelineTestEvent(2, "two"))
+
+	expected := []clabernetesapisv1alpha1.TimelineEvent{
+		timelineTestEvent(2, "two"),
+	}
+
+	if !reconcileData.ShouldUpdateResource ||
+		!reflect.DeepEqual(reconcileData.TimelineEvents, expected) {
+		clabernetestesthelper.FailOutput(t, reconcileData.TimelineEvents, expected)
+	}
+}
+
+func TestTunnelTimelineEvents(t *testing.T) {
+	transitionTime := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	status := clabernetesapisv1alpha1.ConnectivityStatus{
+		TunnelStatuses: map[string][]clabernetesapisv1alpha1.TunnelStatus{
+			"srl1": {
+				{
+					LocalInterface:     "e1-1",
+					RemoteNode:         "srl2",
+					RemoteInterface:    "e1-1",
+					State:              clabernetesconstants.TunnelStateUp,
+					LastTransitionTime: transitionTime,
+				},
+			},
+			"srl2": {
+				{
+					LocalInterface:     "e1-1",
+					RemoteNode:         "srl1",
+					RemoteInterface:    "e1-1",
+					State:              clabernetesconstants.TunnelStateDown,
+					LastError:          "no route to host",
+					LastTransitionTime: transitionTime,
+				},
+			},
+			"srl3": {
+				{
+					LocalInterface:     "e1-1",
+					RemoteNode:         "srl1",
+					RemoteInterface:    "e1-3",
+					State:              clabernetesconstants.TunnelStateUnreachable,
+					LastError:          "peer unreachable",
+					LastTransitionTime: transitionTime,
+				},
+			},
+			"srl4": {
+				{
+					LocalInterface:     "e1-1",
+					RemoteNode:         "srl1",
+					RemoteInterface:    "e1-4",
+					State:              clabernetesconstants.TunnelStateSimulated,
+					LastTransitionTime: transitionTime,
+				},
+			},
+			"removed": {
+				{
+					LocalInterface:     "e1-1",
+					RemoteNode:         "srl1",
+					RemoteInterface:    "e1-2",
+					State:              clabernetesconstants.TunnelStateDown,
+					LastTransitionTime: transitionTime,
+				},
+			},
+		},
+	}
+
+	resolvedTunnels := map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{
+		"srl1": {},
+		"srl2": {},
+		"srl3": {},
+		"srl4": {},
+	}
+
+	expected := []clabernetesapisv1alpha1.TimelineEvent{
+		{
+			Time:    transitionTime,
+			Source:  clabernetesconstants.TimelineSourceLauncher,
+			Node:    "srl1",
+			Reason:  "TunnelUp",
+			Message: "tunnel e1-1 -> srl2/e1-1 is up",
+		},
+		{
+			Time:    transitionTime,
+			Source:  clabernetesconstants.TimelineSourceLauncher,
+			Node:    "srl2",
+			Reason:  "TunnelDown",
+			Message: "tunnel e1-1 -> srl1/e1-1 is down (no route to host)",
+		},
+		{
+			Time:    transitionTime,
+			Source:  clabernetesconstants.TimelineSourceLauncher,
+			Node:    "srl3",
+			Reason:  "TunnelPeerUnreachable",
+			Message: "tunnel e1-1 -> srl1/e1-3 is up but the peer is unreachable (peer unreachable)",
+		},
+		{
+			Time:    transitionTime,
+			Source:  clabernetesconstants.TimelineSourceLauncher,
+			Node:    "srl4",
+			Reason:  "TunnelSimulated",
+			Message: "tunnel e1-1 -> srl1/e1-4 is simulated",
+		},
+	}
+
+	actual := clabernetescontrollerstopology.TunnelTimelineEvents(status, resolvedTunnels)
+	if !reflect.DeepEqual(actual, expected) {
+		clabernetestesthelper.FailOutput(t, actual, expected)
+	}
+}
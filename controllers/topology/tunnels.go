@@ -1,6 +1,8 @@
 package topology
 
 import (
+	"fmt"
+	"slices"
 	"sort"
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
@@ -135,3 +137,69 @@ func findAllocatedIDIfExists(
 
 	return 0
 }
+
+// tunnelLocalEndpoint returns the "node:interface" endpoint of the local side of the given tunnel.
+func tunnelLocalEndpoint(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) string {
+	return fmt.Sprintf("%s:%s", tunnel.LocalNode, tunnel.LocalInterface)
+}
+
+// tunnelRemoteEndpoint returns the "node:interface" endpoint of the remote side of the given
+// tunnel.
+func tunnelRemoteEndpoint(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) string {
+	return fmt.Sprintf("%s:%s", tunnel.RemoteNode, tunnel.RemoteInterface)
+}
+
+// applyEndpointSettings calls apply for each of the given tunnels with the setting listed for its
+// local endpoint (or, if matchRemote is set, for either of its endpoints -- the endpoint that sorts
+// first wins, so both sides of a link get the same setting), or nil if there is none -- apply gets
+// its own copy of the setting. It returns a sorted list of the endpoints of the given settings
+// that did not match any tunnel so the caller can let the user know.
+func applyEndpointSettings[T any](
+	tunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+	endpointSettings map[string]T,
+	matchRemote bool,
+	apply func(tunnel *clabernetesapisv1alpha1.PointToPointTunnel, setting *T),
+) []string {
+	matchedEndpoints := map[string]bool{}
+
+	for _, nodeTunnels := range tunnels {
+		for _, tunnel := range nodeTunnels {
+			endpoints := []string{tunnelLocalEndpoint(tunnel)}
+
+			if matchRemote {
+				endpoints = append(endpoints, tunnelRemoteEndpoint(tunnel))
+
+				slices.Sort(endpoints)
+			}
+
+			var matchedSetting *T
+
+			for _, endpoint := range endpoints {
+				setting, ok := endpointSettings[endpoint]
+				if !ok {
+					continue
+				}
+
+				matchedEndpoints[endpoint] = true
+
+				if matchedSetting == nil {
+					matchedSetting = &setting
+				}
+			}
+
+			apply(tunnel, matchedSetting)
+		}
+	}
+
+	var unmatchedEndpoints []string
+
+	for endpoint := range endpointSettings {
+		if !matchedEndpoints[endpoint] {
+			unmatchedEndpoints = append(unmatchedEndpoints, endpoint)
+		}
+	}
+
+	slices.Sort(unmatchedEndpoints)
+
+	return unmatchedEndpoints
+}
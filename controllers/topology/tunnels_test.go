@@ -12,6 +12,31 @@ import (
 
 const testAllocateTunnelIDsTestName = "tunnels/allocate-tunnel-ids"
 
+// linkTestTunnels returns the tunnels of a topology with a single srl1:e1-1 <-> srl2:e1-1 link for
+// the tests applying per link settings to tunnels.
+func linkTestTunnels() map[string][]*clabernetesapisv1alpha1.PointToPointTunnel {
+	return map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{
+		"srl1": {
+			{
+				Destination:     "topo-1-srl2-vx.clabernetes.svc.cluster.local",
+				LocalNode:       "srl1",
+				LocalInterface:  "e1-1",
+				RemoteNode:      "srl2",
+				RemoteInterface: "e1-1",
+			},
+		},
+		"srl2": {
+			{
+				Destination:     "topo-1-srl1-vx.clabernetes.svc.cluster.local",
+				LocalNode:       "srl2",
+				LocalInterface:  "e1-1",
+				RemoteNode:      "srl1",
+				RemoteInterface: "e1-1",
+			},
+		},
+	}
+}
+
 // TestAllocateTunnelIds ensures that the tunnel clabernetes controllers VXLAN tunnel ID allocation
 // process works as advertised. None of this is "hard" necessarily, but there are a lot of moving
 // parts in play to ensure that we use the tunnel IDs consistently and also obviously don't stomp
@@ -0,0 +1,176 @@
+package topology
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+	ctrlruntimepredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	conditionTunnelsDown = "TunnelsDown"
+	reasonTunnelsDown    = "TunnelSetupFailed"
+)
+
+// DownTunnels returns a sorted list of short descriptions of all tunnels that launchers reported as
+// down (or as unreachable -- set up, but the remote launcher does not answer) in the given
+// connectivity status, only nodes in the given resolved tunnels are considered as any other node
+// entries are leftovers from nodes that have since been removed from the topology. Simulated
+// tunnels (loopback connectivity) are never down.
+func DownTunnels(
+	status clabernetesapisv1alpha1.ConnectivityStatus,
+	resolvedTunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+) []string {
+	var downTunnels []string
+
+	for nodeName, tunnelStatuses := range status.TunnelStatuses {
+		if _, ok := resolvedTunnels[nodeName]; !ok {
+			continue
+		}
+
+		for _, tunnelStatus := range tunnelStatuses {
+			if tunnelStatus.State == clabernetesconstants.TunnelStateUp ||
+				tunnelStatus.State == clabernetesconstants.TunnelStateSimulated {
+				continue
+			}
+
+			description := fmt.Sprintf(
+				"%s/%s -> %s/%s",
+				nodeName,
+				tunnelStatus.LocalInterface,
+				tunnelStatus.RemoteNode,
+				tunnelStatus.RemoteInterface,
+			)
+
+			if tunnelStatus.LastError != "" {
+				description = fmt.Sprintf("%s (%s)", description, tunnelStatus.LastError)
+			}
+
+			downTunnels = append(downTunnels, description)
+		}
+	}
+
+	slices.Sort(downTunnels)
+
+	return downTunnels
+}
+
+// pruneConnectivityStatus drops the status entries of nodes that no longer have any tunnels -- the
+// launchers only ever patch their own entry, so nobody else would ever clean these up.
+func pruneConnectivityStatus(
+	status clabernetesapisv1alpha1.ConnectivityStatus,
+	resolvedTunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+) clabernetesapisv1alpha1.ConnectivityStatus {
+	prunedStatus := clabernetesapisv1alpha1.ConnectivityStatus{}
+
+	for nodeName, tunnelStatuses := range status.TunnelStatuses {
+		if _, ok := resolvedTunnels[nodeName]; !ok {
+			continue
+		}
+
+		if prunedStatus.TunnelStatuses == nil {
+			prunedStatus.TunnelStatuses = map[string][]clabernetesapisv1alpha1.TunnelStatus{}
+		}
+
+		prunedStatus.TunnelStatuses[nodeName] = tunnelStatuses
+	}
+
+	return prunedStatus
+}
+
+// reconcileTunnelsDownCondition sets (or clears) the "TunnelsDown" condition on the topology based
+// on the tunnel statuses the launchers reported in the connectivity cr.
+func (r *Reconciler) reconcileTunnelsDownCondition(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+	status clabernetesapisv1alpha1.ConnectivityStatus,
+) {
+	downTunnels := DownTunnels(status, reconcileData.ResolvedTunnels)
+
+	if len(downTunnels) == 0 {
+		if apimachinerymeta.RemoveStatusCondition(
+			&owningTopology.Status.Conditions,
+			conditionTunnelsDown,
+		) {
+			reconcileData.ShouldUpdateResource = true
+		}
+
+		return
+	}
+
+	r.Log.Warnf("launcher(s) reported tunnel(s) %q down", downTunnels)
+
+	if apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+		Type:    conditionTunnelsDown,
+		Status:  "True",
+		Reason:  reasonTunnelsDown,
+		Message: fmt.Sprintf("tunnel(s) down: %s", strings.Join(downTunnels, ", ")),
+	}) {
+		reconcileData.ShouldUpdateResource = true
+	}
+}
+
+// tunnelStates returns the parts of the given connectivity status the controller acts on -- the
+// state of each tunnel (and when/why it got there), but not the self-test results or traffic
+// counters the launchers report periodically.
+func tunnelStates(
+	status clabernetesapisv1alpha1.ConnectivityStatus,
+) map[string][]clabernetesapisv1alpha1.TunnelStatus {
+	states := make(map[string][]clabernetesapisv1alpha1.TunnelStatus, len(status.TunnelStatuses))
+
+	for nodeName, tunnelStatuses := range status.TunnelStatuses {
+		nodeStates := make([]clabernetesapisv1alpha1.TunnelStatus, len(tunnelStatuses))
+
+		for idx, tunnelStatus := range tunnelStatuses {
+			nodeStates[idx] = clabernetesapisv1alpha1.TunnelStatus{
+				LocalInterface:      tunnelStatus.LocalInterface,
+				RemoteNode:          tunnelStatus.RemoteNode,
+				RemoteInterface:     tunnelStatus.RemoteInterface,
+				State:               tunnelStatus.State,
+				ResolvedDestination: tunnelStatus.ResolvedDestination,
+				LastError:           tunnelStatus.LastError,
+				LastTransitionTime:  tunnelStatus.LastTransitionTime,
+			}
+		}
+
+		states[nodeName] = nodeStates
+	}
+
+	return states
+}
+
+// ConnectivityPredicate returns the predicate for the owned connectivity cr watch -- the launchers
+// merge patch their tunnel statuses into the connectivity cr (and, with self-tests or traffic
+// counters enabled, do so periodically), so updates are only passed on if the spec or the tunnel
+// states changed, anything else would only cause needless reconciles.
+func ConnectivityPredicate() ctrlruntimepredicate.Predicate {
+	return ctrlruntimepredicate.Funcs{
+		UpdateFunc: func(e ctrlruntimeevent.UpdateEvent) bool {
+			oldConnectivity, ok := e.ObjectOld.(*clabernetesapisv1alpha1.Connectivity)
+			if !ok {
+				return true
+			}
+
+			newConnectivity, ok := e.ObjectNew.(*clabernetesapisv1alpha1.Connectivity)
+			if !ok {
+				return true
+			}
+
+			if !reflect.DeepEqual(oldConnectivity.Spec, newConnectivity.Spec) {
+				return true
+			}
+
+			return !reflect.DeepEqual(
+				tunnelStates(oldConnectivity.Status),
+				tunnelStates(newConnectivity.Status),
+			)
+		},
+	}
+}
@@ -0,0 +1,265 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestDownTunnels(t *testing.T) {
+	resolvedTunnels := map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{
+		"srl1": {},
+		"srl2": {},
+	}
+
+	cases := []struct {
+		name     string
+		status   clabernetesapisv1alpha1.ConnectivityStatus
+		expected []string
+	}{
+		{
+			name:     "no-status",
+			status:   clabernetesapisv1alpha1.ConnectivityStatus{},
+			expected: nil,
+		},
+		{
+			name: "all-up",
+			status: clabernetesapisv1alpha1.ConnectivityStatus{
+				TunnelStatuses: map[string][]clabernetesapisv1alpha1.TunnelStatus{
+					"srl1": {
+						{
+							LocalInterface:  "e1-1",
+							RemoteNode:      "srl2",
+							RemoteInterface: "e1-1",
+							State:           clabernetesconstants.TunnelStateUp,
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "some-down",
+			status: clabernetesapisv1alpha1.ConnectivityStatus{
+				TunnelStatuses: map[string][]clabernetesapisv1alpha1.TunnelStatus{
+					"srl2": {
+						{
+							LocalInterface:  "e1-2",
+							RemoteNode:      "srl1",
+							RemoteInterface: "e1-2",
+							State:           clabernetesconstants.TunnelStateDown,
+						},
+					},
+					"srl1": {
+						{
+							LocalInterface:  "e1-1",
+							RemoteNode:      "srl2",
+							RemoteInterface: "e1-1",
+							State:           clabernetesconstants.TunnelStateDown,
+							LastError:       "no route to host",
+						},
+						{
+							LocalInterface:  "e1-2",
+							RemoteNode:      "srl2",
+							RemoteInterface: "e1-2",
+							State:           clabernetesconstants.TunnelStateUp,
+						},
+					},
+				},
+			},
+			expected: []string{
+				"srl1/e1-1 -> srl2/e1-1 (no route to host)",
+				"srl2/e1-2 -> srl1/e1-2",
+			},
+		},
+		{
+			name: "unreachable",
+			status: clabernetesapisv1alpha1.ConnectivityStatus{
+				TunnelStatuses: map[string][]clabernetesapisv1alpha1.TunnelStatus{
+					"srl1": {
+						{
+							LocalInterface:  "e1-1",
+							RemoteNode:      "srl2",
+							RemoteInterface: "e1-1",
+							State:           clabernetesconstants.TunnelStateUnreachable,
+							LastError:       "peer unreachable",
+						},
+					},
+				},
+			},
+			expected: []string{
+				"srl1/e1-1 -> srl2/e1-1 (peer unreachable)",
+			},
+		},
+		{
+			name: "simulated",
+			status: clabernetesapisv1alpha1.ConnectivityStatus{
+				TunnelStatuses: map[string][]clabernetesapisv1alpha1.TunnelStatus{
+					"srl1": {
+						{
+							LocalInterface:  "e1-1",
+							RemoteNode:      "srl2",
+							RemoteInterface: "e1-1",
+							State:           clabernetesconstants.TunnelStateSimulated,
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "removed-node-ignored",
+			status: clabernetesapisv1alpha1.ConnectivityStatus{
+				TunnelStatuses: map[string][]clabernetesapisv1alpha1.TunnelStatus{
+					"srl3": {
+						{
+							LocalInterface:  "e1-1",
+							RemoteNode:      "srl1",
+							RemoteInterface: "e1-3",
+							State:           clabernetesconstants.TunnelStateDown,
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.DownTunnels(
+					testCase.status,
+					resolvedTunnels,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func connectivityForPredicate() *clabernetesapisv1alpha1.Connectivity {
+	return &clabernetesapisv1alpha1.Connectivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "topo1",
+			Namespace: "clabernetes",
+		},
+		Spec: clabernetesapisv1alpha1.ConnectivitySpec{
+			PointToPointTunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{
+				"srl1": {
+					{
+						TunnelID:        1,
+						Destination:     "topo1-srl2.clabernetes.svc.cluster.local",
+						LocalNode:       "srl1",
+						LocalInterface:  "e1-1",
+						RemoteNode:      "srl2",
+						RemoteInterface: "e1-1",
+					},
+				},
+			},
+		},
+		Status: clabernetesapisv1alpha1.ConnectivityStatus{
+			TunnelStatuses: map[string][]clabernetesapisv1alpha1.TunnelStatus{
+				"srl1": {
+					{
+						LocalInterface:  "e1-1",
+						RemoteNode:      "srl2",
+						RemoteInterface: "e1-1",
+						State:           clabernetesconstants.TunnelStateUp,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConnectivityPredicate(t *testing.T) {
+	cases := []struct {
+		name     string
+		update   func(connectivity *clabernetesapisv1alpha1.Connectivity)
+		expected bool
+	}{
+		{
+			name:     "no-change",
+			update:   func(_ *clabernetesapisv1alpha1.Connectivity) {},
+			expected: false,
+		},
+		{
+			name: "spec-changed",
+			update: func(connectivity *clabernetesapisv1alpha1.Connectivity) {
+				connectivity.Spec.PointToPointTunnels["srl1"][0].TunnelID = 2
+			},
+			expected: true,
+		},
+		{
+			name: "tunnel-state-changed",
+			update: func(connectivity *clabernetesapisv1alpha1.Connectivity) {
+				tunnelStatus := &connectivity.Status.TunnelStatuses["srl1"][0]
+
+				tunnelStatus.State = clabernetesconstants.TunnelStateDown
+				tunnelStatus.LastError = "no route to host"
+			},
+			expected: true,
+		},
+		{
+			name: "self-test-only",
+			update: func(connectivity *clabernetesapisv1alpha1.Connectivity) {
+				tunnelStatus := &connectivity.Status.TunnelStatuses["srl1"][0]
+
+				tunnelStatus.SelfTest = &clabernetesapisv1alpha1.TunnelSelfTestResult{
+					Latency:      "412µs",
+					LastTestTime: metav1.Unix(1700000000, 0),
+				}
+			},
+			expected: false,
+		},
+		{
+			name: "counters-only",
+			update: func(connectivity *clabernetesapisv1alpha1.Connectivity) {
+				tunnelStatus := &connectivity.Status.TunnelStatuses["srl1"][0]
+
+				tunnelStatus.Counters = &clabernetesapisv1alpha1.TunnelCounters{
+					RxBytes:        1500,
+					TxBytes:        3000,
+					RxPackets:      1,
+					TxPackets:      2,
+					LastUpdateTime: metav1.Unix(1700000000, 0),
+				}
+			},
+			expected: false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				oldConnectivity := connectivityForPredicate()
+				newConnectivity := connectivityForPredicate()
+
+				testCase.update(newConnectivity)
+
+				actual := clabernetescontrollerstopology.ConnectivityPredicate().Update(
+					ctrlruntimeevent.UpdateEvent{
+						ObjectOld: oldConnectivity,
+						ObjectNew: newConnectivity,
+					},
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
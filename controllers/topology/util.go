@@ -2,10 +2,21 @@ package topology
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	clabernetesapis "github.com/srl-labs/clabernetes/apis"
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	k8scorev1 "k8s.io/api/core/v1"
+)
+
+const (
+	runtimeSandboxSysbox = "sysbox"
+	runtimeSandboxKata   = "kata"
+	runtimeSandboxGVisor = "gvisor"
 )
 
 // GetTopologyKind returns the "kind" of topology this CR represents -- typically this will be
@@ -31,6 +42,55 @@ func ResolveGlobalVsTopologyBool(globalValue bool, topologyValue *bool) bool {
 	return globalValue
 }
 
+// ResolveConnectivityPorts returns the ports to use for the connectivity between the launcher pods
+// of the given topology -- each port set in the topology takes precedence over the global config
+// value, which in turn takes precedence over the clabernetes default for that port.
+func ResolveConnectivityPorts(
+	t *clabernetesapisv1alpha1.Topology,
+	globalPorts clabernetesapisv1alpha1.ConnectivityPorts,
+) clabernetesapisv1alpha1.ConnectivityPorts {
+	resolvePort := func(topologyPort, globalPort, defaultPort int32) int32 {
+		if topologyPort != 0 {
+			return topologyPort
+		}
+
+		if globalPort != 0 {
+			return globalPort
+		}
+
+		return defaultPort
+	}
+
+	var topologyPorts clabernetesapisv1alpha1.ConnectivityPorts
+
+	if t.Spec.ConnectivityPorts != nil {
+		topologyPorts = *t.Spec.ConnectivityPorts
+	}
+
+	return clabernetesapisv1alpha1.ConnectivityPorts{
+		VXLAN: resolvePort(
+			topologyPorts.VXLAN,
+			globalPorts.VXLAN,
+			clabernetesconstants.VXLANServicePort,
+		),
+		Slurpeeth: resolvePort(
+			topologyPorts.Slurpeeth,
+			globalPorts.Slurpeeth,
+			clabernetesconstants.SlurpeethServicePort,
+		),
+		Geneve: resolvePort(
+			topologyPorts.Geneve,
+			globalPorts.Geneve,
+			clabernetesconstants.GeneveServicePort,
+		),
+		WireGuard: resolvePort(
+			topologyPorts.WireGuard,
+			globalPorts.WireGuard,
+			clabernetesconstants.WireGuardServicePort,
+		),
+	}
+}
+
 // ResolveTopologyRemovePrefix returns true if the topology resource should strip the containerlab
 // topology prefix from a resource (deployment/service) name. This helper exists primarily for
 // testing reasons as in the "normal" course of operation this value would always be taken from the
@@ -63,6 +123,235 @@ func ResolveHostNetwork(t *clabernetesapisv1alpha1.Topology) bool {
 	return *t.Spec.Deployment.HostNetwork
 }
 
+// ResolveLauncherKind returns the kind of workload the launchers of the topology run as, either
+// "Deployment" (the default) or "StatefulSet".
+func ResolveLauncherKind(t *clabernetesapisv1alpha1.Topology) string {
+	if t.Spec.Deployment.Kind == clabernetesconstants.KubernetesKindStatefulSet {
+		return clabernetesconstants.KubernetesKindStatefulSet
+	}
+
+	return clabernetesconstants.KubernetesKindDeployment
+}
+
+// ResolveRuntimeClassName returns the RuntimeClass name for the given node -- node specific
+// settings take precedence over containerlab kind specific settings, which in turn take precedence
+// over the topology wide setting.
+func ResolveRuntimeClassName(
+	t *clabernetesapisv1alpha1.Topology,
+	nodeName,
+	nodeKind string,
+) string {
+	runtimeClassName, ok := t.Spec.Deployment.NodeRuntimeClassNames[nodeName]
+	if ok {
+		return runtimeClassName
+	}
+
+	runtimeClassName, ok = t.Spec.Deployment.KindRuntimeClassNames[nodeKind]
+	if ok {
+		return runtimeClassName
+	}
+
+	return t.Spec.Deployment.RuntimeClassName
+}
+
+// ResolveSchedulingOverrides returns the node selector labels and tolerations the launcher pod of
+// the given node gets on top of the topology wide scheduling settings -- the containerlab kind
+// specific overrides merged with the node specific ones, node specific node selector labels take
+// precedence over kind specific ones with the same key.
+func ResolveSchedulingOverrides(
+	t *clabernetesapisv1alpha1.Topology,
+	nodeName,
+	nodeKind string,
+) (map[string]string, []k8scorev1.Toleration) {
+	nodeSelector := map[string]string{}
+
+	var tolerations []k8scorev1.Toleration
+
+	for _, overrides := range []clabernetesapisv1alpha1.SchedulingOverrides{
+		t.Spec.Deployment.Scheduling.KindOverrides[nodeKind],
+		t.Spec.Deployment.Scheduling.NodeOverrides[nodeName],
+	} {
+		maps.Copy(nodeSelector, overrides.NodeSelector)
+
+		tolerations = append(tolerations, overrides.Tolerations...)
+	}
+
+	return nodeSelector, tolerations
+}
+
+// ResolveHostAliases returns the host aliases for the launcher pod of the given node -- the
+// topology "default" aliases followed by the node specific ones. The deployment aliases win, the
+// (deprecated) network aliases are only used when no deployment aliases are set at all.
+func ResolveHostAliases(
+	t *clabernetesapisv1alpha1.Topology,
+	nodeName string,
+) []k8scorev1.HostAlias {
+	aliases := t.Spec.Deployment.HostAliases
+
+	if len(aliases) == 0 && t.Spec.Network != nil {
+		aliases = t.Spec.Network.HostAliases //nolint:staticcheck // honored until it is removed
+	}
+
+	hostAliases := aliases[clabernetesconstants.Default]
+
+	if nodeName != clabernetesconstants.Default {
+		hostAliases = append(slices.Clone(hostAliases), aliases[nodeName]...)
+	}
+
+	return hostAliases
+}
+
+// ResolveExtraInitContainers returns the extra init containers for the launcher pod of the given
+// node -- the topology "default" init containers followed by the node specific ones, a node
+// specific init container replaces a "default" init container with the same name.
+func ResolveExtraInitContainers(
+	t *clabernetesapisv1alpha1.Topology,
+	nodeName string,
+) []k8scorev1.Container {
+	var nodeInitContainers []k8scorev1.Container
+
+	if nodeName != clabernetesconstants.Default {
+		nodeInitContainers = t.Spec.Deployment.ExtraInitContainers[nodeName]
+	}
+
+	defaultInitContainers := t.Spec.Deployment.ExtraInitContainers[clabernetesconstants.Default]
+
+	var initContainers []k8scorev1.Container
+
+	for _, defaultInitContainer := range defaultInitContainers {
+		if slices.ContainsFunc(
+			nodeInitContainers,
+			func(nodeInitContainer k8scorev1.Container) bool {
+				return nodeInitContainer.Name == defaultInitContainer.Name
+			},
+		) {
+			continue
+		}
+
+		initContainers = append(initContainers, *defaultInitContainer.DeepCopy())
+	}
+
+	for _, nodeInitContainer := range nodeInitContainers {
+		initContainers = append(initContainers, *nodeInitContainer.DeepCopy())
+	}
+
+	return initContainers
+}
+
+// ResolveExtraLabels returns the extra labels for the launcher deployment of the given node -- the
+// topology "default" labels with the node specific ones merged on top.
+func ResolveExtraLabels(
+	t *clabernetesapisv1alpha1.Topology,
+	nodeName string,
+) map[string]string {
+	return resolveNodeMetadata(t.Spec.Deployment.ExtraLabels, nodeName)
+}
+
+// ResolveExtraAnnotations returns the extra annotations for the launcher deployment of the given
+// node -- the topology "default" annotations with the node specific ones merged on top.
+func ResolveExtraAnnotations(
+	t *clabernetesapisv1alpha1.Topology,
+	nodeName string,
+) map[string]string {
+	return resolveNodeMetadata(t.Spec.Deployment.ExtraAnnotations, nodeName)
+}
+
+func resolveNodeMetadata(
+	metadata map[string]map[string]string,
+	nodeName string,
+) map[string]string {
+	resolved := map[string]string{}
+
+	for k, v := range metadata[clabernetesconstants.Default] {
+		resolved[k] = v
+	}
+
+	if nodeName == clabernetesconstants.Default {
+		return resolved
+	}
+
+	for k, v := range metadata[nodeName] {
+		resolved[k] = v
+	}
+
+	return resolved
+}
+
+// ResolveStaticRoutes returns the static routes the launcher of the given node should add -- the
+// topology "default" routes followed by the node specific ones, a node specific route replaces a
+// "default" route for the same destination.
+func ResolveStaticRoutes(
+	t *clabernetesapisv1alpha1.Topology,
+	nodeName string,
+) []clabernetesapisv1alpha1.StaticRoute {
+	if t.Spec.Network == nil {
+		return nil
+	}
+
+	var nodeRoutes []clabernetesapisv1alpha1.StaticRoute
+
+	if nodeName != clabernetesconstants.Default {
+		nodeRoutes = t.Spec.Network.StaticRoutes[nodeName]
+	}
+
+	var staticRoutes []clabernetesapisv1alpha1.StaticRoute
+
+	for _, defaultRoute := range t.Spec.Network.StaticRoutes[clabernetesconstants.Default] {
+		if slices.ContainsFunc(
+			nodeRoutes,
+			func(nodeRoute clabernetesapisv1alpha1.StaticRoute) bool {
+				return nodeRoute.Destination == defaultRoute.Destination
+			},
+		) {
+			continue
+		}
+
+		staticRoutes = append(staticRoutes, defaultRoute)
+	}
+
+	return append(staticRoutes, nodeRoutes...)
+}
+
+// ResolveUnderlay returns the dedicated underlay interface configuration of the given topology
+// with the interface name defaulted, or nil if the topology does not use an underlay interface --
+// that is also the case for "multus" connectivity (no tunnels to move) and for topologies using
+// the host network (no pod network to attach anything to).
+func ResolveUnderlay(t *clabernetesapisv1alpha1.Topology) *clabernetesapisv1alpha1.Underlay {
+	if t.Spec.Network == nil || t.Spec.Network.Underlay == nil {
+		return nil
+	}
+
+	if t.Spec.Connectivity == clabernetesconstants.ConnectivityMultus || ResolveHostNetwork(t) {
+		return nil
+	}
+
+	underlay := *t.Spec.Network.Underlay
+
+	if underlay.Interface == "" {
+		underlay.Interface = clabernetesconstants.UnderlayInterfaceDefault
+	}
+
+	return &underlay
+}
+
+// resolveRuntimeSandbox returns the "flavor" of sandboxing runtime the given RuntimeClass name
+// refers to, or an empty string if it does not appear to be a sandboxing runtime at all. Runtime
+// class names are cluster specific, so we can only go off of "well known" substrings here.
+func resolveRuntimeSandbox(runtimeClassName string) string {
+	name := strings.ToLower(runtimeClassName)
+
+	switch {
+	case strings.Contains(name, runtimeSandboxSysbox):
+		return runtimeSandboxSysbox
+	case strings.Contains(name, runtimeSandboxKata):
+		return runtimeSandboxKata
+	case strings.Contains(name, runtimeSandboxGVisor), strings.Contains(name, "runsc"):
+		return runtimeSandboxGVisor
+	default:
+		return ""
+	}
+}
+
 func resolveConnectivityDestination(
 	topologyName,
 	uninterestingEndpointNodeName,
@@ -1,12 +1,14 @@
 package topology_test
 
 import (
+	"reflect"
 	"testing"
 
 	clabernetesapis "github.com/srl-labs/clabernetes/apis"
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
 	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	k8scorev1 "k8s.io/api/core/v1"
 )
 
 func TestGetTopologyKind(t *testing.T) {
@@ -57,3 +59,489 @@ func TestGetTopologyKind(t *testing.T) {
 			})
 	}
 }
+
+func TestResolveRuntimeClassName(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       *clabernetesapisv1alpha1.Topology
+		nodeName string
+		nodeKind string
+		expected string
+	}{
+		{
+			name:     "unset",
+			in:       &clabernetesapisv1alpha1.Topology{},
+			nodeName: "srl1",
+			nodeKind: "srl",
+			expected: "",
+		},
+		{
+			name: "topology-default",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						RuntimeClassName: "kata",
+					},
+				},
+			},
+			nodeName: "srl1",
+			nodeKind: "srl",
+			expected: "kata",
+		},
+		{
+			name: "kind-override",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						RuntimeClassName: "kata",
+						KindRuntimeClassNames: map[string]string{
+							"srl": "sysbox-runc",
+						},
+					},
+				},
+			},
+			nodeName: "srl1",
+			nodeKind: "srl",
+			expected: "sysbox-runc",
+		},
+		{
+			name: "node-override",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						RuntimeClassName: "kata",
+						NodeRuntimeClassNames: map[string]string{
+							"srl1": "gvisor",
+						},
+						KindRuntimeClassNames: map[string]string{
+							"srl": "sysbox-runc",
+						},
+					},
+				},
+			},
+			nodeName: "srl1",
+			nodeKind: "srl",
+			expected: "gvisor",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveRuntimeClassName(
+					testCase.in,
+					testCase.nodeName,
+					testCase.nodeKind,
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestResolveSchedulingOverrides(t *testing.T) {
+	cases := []struct {
+		name                 string
+		in                   clabernetesapisv1alpha1.Scheduling
+		expectedNodeSelector map[string]string
+		expectedTolerations  []k8scorev1.Toleration
+	}{
+		{
+			name:                 "no-overrides",
+			in:                   clabernetesapisv1alpha1.Scheduling{},
+			expectedNodeSelector: map[string]string{},
+			expectedTolerations:  nil,
+		},
+		{
+			name: "kind-and-node-overrides",
+			in: clabernetesapisv1alpha1.Scheduling{
+				KindOverrides: map[string]clabernetesapisv1alpha1.SchedulingOverrides{
+					"srl": {
+						NodeSelector: map[string]string{"a": "kind", "b": "kind"},
+						Tolerations:  []k8scorev1.Toleration{{Key: "kind"}},
+					},
+					"ceos": {
+						NodeSelector: map[string]string{"c": "ceos"},
+					},
+				},
+				NodeOverrides: map[string]clabernetesapisv1alpha1.SchedulingOverrides{
+					"srl1": {
+						NodeSelector: map[string]string{"b": "node"},
+						Tolerations:  []k8scorev1.Toleration{{Key: "node"}},
+					},
+					"srl2": {
+						NodeSelector: map[string]string{"a": "srl2"},
+					},
+				},
+			},
+			expectedNodeSelector: map[string]string{"a": "kind", "b": "node"},
+			expectedTolerations:  []k8scorev1.Toleration{{Key: "kind"}, {Key: "node"}},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				topology := &clabernetesapisv1alpha1.Topology{
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						Deployment: clabernetesapisv1alpha1.Deployment{
+							Scheduling: testCase.in,
+						},
+					},
+				}
+
+				actualNodeSelector, actualTolerations :=
+					clabernetescontrollerstopology.ResolveSchedulingOverrides(
+						topology,
+						"srl1",
+						"srl",
+					)
+				if !reflect.DeepEqual(actualNodeSelector, testCase.expectedNodeSelector) {
+					clabernetestesthelper.FailOutput(
+						t,
+						actualNodeSelector,
+						testCase.expectedNodeSelector,
+					)
+				}
+
+				if !reflect.DeepEqual(actualTolerations, testCase.expectedTolerations) {
+					clabernetestesthelper.FailOutput(
+						t,
+						actualTolerations,
+						testCase.expectedTolerations,
+					)
+				}
+			})
+	}
+}
+
+func TestResolveStaticRoutes(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       *clabernetesapisv1alpha1.Topology
+		nodeName string
+		expected []clabernetesapisv1alpha1.StaticRoute
+	}{
+		{
+			name:     "unset",
+			in:       &clabernetesapisv1alpha1.Topology{},
+			nodeName: "srl1",
+			expected: nil,
+		},
+		{
+			name: "default-and-node",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Network: &clabernetesapisv1alpha1.Network{
+						StaticRoutes: map[string][]clabernetesapisv1alpha1.StaticRoute{
+							"default": {
+								{Destination: "192.0.2.0/24"},
+								{Destination: "198.51.100.0/24", Gateway: "10.0.0.1"},
+							},
+							"srl1": {
+								{Destination: "198.51.100.0/24", Gateway: "10.0.0.2"},
+								{Destination: "203.0.113.0/24"},
+							},
+							"srl2": {
+								{Destination: "2001:db8::/32"},
+							},
+						},
+					},
+				},
+			},
+			nodeName: "srl1",
+			expected: []clabernetesapisv1alpha1.StaticRoute{
+				{Destination: "192.0.2.0/24"},
+				{Destination: "198.51.100.0/24", Gateway: "10.0.0.2"},
+				{Destination: "203.0.113.0/24"},
+			},
+		},
+		{
+			name: "default-only",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Network: &clabernetesapisv1alpha1.Network{
+						StaticRoutes: map[string][]clabernetesapisv1alpha1.StaticRoute{
+							"default": {
+								{Destination: "192.0.2.0/24"},
+							},
+							"srl2": {
+								{Destination: "2001:db8::/32"},
+							},
+						},
+					},
+				},
+			},
+			nodeName: "srl1",
+			expected: []clabernetesapisv1alpha1.StaticRoute{
+				{Destination: "192.0.2.0/24"},
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveStaticRoutes(
+					testCase.in,
+					testCase.nodeName,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestResolveHostAliases(t *testing.T) {
+	licenseAlias := k8scorev1.HostAlias{
+		IP:        "192.0.2.10",
+		Hostnames: []string{"license.lab.example"},
+	}
+
+	syslogAlias := k8scorev1.HostAlias{
+		IP:        "192.0.2.20",
+		Hostnames: []string{"syslog.lab.example"},
+	}
+
+	collectorAlias := k8scorev1.HostAlias{
+		IP:        "192.0.2.30",
+		Hostnames: []string{"collector.lab.example"},
+	}
+
+	cases := []struct {
+		name     string
+		in       *clabernetesapisv1alpha1.Topology
+		nodeName string
+		expected []k8scorev1.HostAlias
+	}{
+		{
+			name:     "unset",
+			in:       &clabernetesapisv1alpha1.Topology{},
+			nodeName: "srl1",
+			expected: nil,
+		},
+		{
+			name: "network-only",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Network: &clabernetesapisv1alpha1.Network{
+						HostAliases: map[string][]k8scorev1.HostAlias{
+							"default": {licenseAlias},
+							"srl1":    {syslogAlias},
+							"srl2":    {collectorAlias},
+						},
+					},
+				},
+			},
+			nodeName: "srl1",
+			expected: []k8scorev1.HostAlias{licenseAlias, syslogAlias},
+		},
+		{
+			name: "deployment-only",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						HostAliases: map[string][]k8scorev1.HostAlias{
+							"default": {licenseAlias},
+							"srl2":    {collectorAlias},
+						},
+					},
+				},
+			},
+			nodeName: "srl1",
+			expected: []k8scorev1.HostAlias{licenseAlias},
+		},
+		{
+			name: "deployment-wins",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Network: &clabernetesapisv1alpha1.Network{
+						HostAliases: map[string][]k8scorev1.HostAlias{
+							"srl1": {syslogAlias},
+						},
+					},
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						HostAliases: map[string][]k8scorev1.HostAlias{
+							"default": {licenseAlias},
+							"srl1":    {collectorAlias},
+						},
+					},
+				},
+			},
+			nodeName: "srl1",
+			expected: []k8scorev1.HostAlias{licenseAlias, collectorAlias},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveHostAliases(
+					testCase.in,
+					testCase.nodeName,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestResolveUnderlay(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       *clabernetesapisv1alpha1.Topology
+		expected *clabernetesapisv1alpha1.Underlay
+	}{
+		{
+			name:     "unset",
+			in:       &clabernetesapisv1alpha1.Topology{},
+			expected: nil,
+		},
+		{
+			name: "default-interface",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Network: &clabernetesapisv1alpha1.Network{
+						Underlay: &clabernetesapisv1alpha1.Underlay{
+							NetworkAttachmentDefinition: "kube-system/fabric",
+						},
+					},
+				},
+			},
+			expected: &clabernetesapisv1alpha1.Underlay{
+				NetworkAttachmentDefinition: "kube-system/fabric",
+				Interface:                   "underlay0",
+			},
+		},
+		{
+			name: "custom-interface",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Network: &clabernetesapisv1alpha1.Network{
+						Underlay: &clabernetesapisv1alpha1.Underlay{
+							NetworkAttachmentDefinition: "fabric",
+							Interface:                   "fabric0",
+						},
+					},
+				},
+			},
+			expected: &clabernetesapisv1alpha1.Underlay{
+				NetworkAttachmentDefinition: "fabric",
+				Interface:                   "fabric0",
+			},
+		},
+		{
+			name: "multus-connectivity",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: "multus",
+					Network: &clabernetesapisv1alpha1.Network{
+						Underlay: &clabernetesapisv1alpha1.Underlay{
+							NetworkAttachmentDefinition: "fabric",
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveUnderlay(testCase.in)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestResolveConnectivityPorts(t *testing.T) {
+	cases := []struct {
+		name        string
+		in          *clabernetesapisv1alpha1.Topology
+		globalPorts clabernetesapisv1alpha1.ConnectivityPorts
+		expected    clabernetesapisv1alpha1.ConnectivityPorts
+	}{
+		{
+			name: "defaults",
+			in:   &clabernetesapisv1alpha1.Topology{},
+			expected: clabernetesapisv1alpha1.ConnectivityPorts{
+				VXLAN:     6784,
+				Slurpeeth: 4799,
+				Geneve:    7784,
+				WireGuard: 4784,
+			},
+		},
+		{
+			name: "global-override",
+			in:   &clabernetesapisv1alpha1.Topology{},
+			globalPorts: clabernetesapisv1alpha1.ConnectivityPorts{
+				VXLAN:  8472,
+				Geneve: 6081,
+			},
+			expected: clabernetesapisv1alpha1.ConnectivityPorts{
+				VXLAN:     8472,
+				Slurpeeth: 4799,
+				Geneve:    6081,
+				WireGuard: 4784,
+			},
+		},
+		{
+			name: "topology-override",
+			in: &clabernetesapisv1alpha1.Topology{
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					ConnectivityPorts: &clabernetesapisv1alpha1.ConnectivityPorts{
+						VXLAN:     9472,
+						WireGuard: 51820,
+					},
+				},
+			},
+			globalPorts: clabernetesapisv1alpha1.ConnectivityPorts{
+				VXLAN:  8472,
+				Geneve: 6081,
+			},
+			expected: clabernetesapisv1alpha1.ConnectivityPorts{
+				VXLAN:     9472,
+				Slurpeeth: 4799,
+				Geneve:    6081,
+				WireGuard: 51820,
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveConnectivityPorts(
+					testCase.in,
+					testCase.globalPorts,
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
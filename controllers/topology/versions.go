@@ -0,0 +1,95 @@
+package topology
+
+import (
+	"context"
+	"encoding/json"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	k8scoordinationv1 "k8s.io/api/coordination/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+const nativeModeLauncherContainerName = "clabernetes-launcher"
+
+// LauncherVersions returns the versions the launcher published on the given launcher heartbeat
+// lease, and false if there are none (or they can not be decoded).
+func LauncherVersions(
+	lease *k8scoordinationv1.Lease,
+) (clabernetesapisv1alpha1.NodeVersions, bool) {
+	versions := clabernetesapisv1alpha1.NodeVersions{}
+
+	rawVersions, ok := lease.Annotations[clabernetesconstants.LauncherVersionsAnnotation]
+	if !ok {
+		return versions, false
+	}
+
+	err := json.Unmarshal([]byte(rawVersions), &versions)
+	if err != nil {
+		return clabernetesapisv1alpha1.NodeVersions{}, false
+	}
+
+	return versions, true
+}
+
+// LauncherImageID returns the image id (digest) of the image the launcher container of the given
+// launcher pod of the given node runs, or an empty string if kubernetes did not report it (yet).
+// The launcher container is named after the node, other than in native mode where the node
+// container is.
+func LauncherImageID(pod *k8scorev1.Pod, nodeName string) string {
+	var imageID string
+
+	for idx := range pod.Status.ContainerStatuses {
+		containerStatus := pod.Status.ContainerStatuses[idx]
+
+		switch containerStatus.Name {
+		case nativeModeLauncherContainerName:
+			return containerStatus.ImageID
+		case nodeName:
+			imageID = containerStatus.ImageID
+		}
+	}
+
+	return imageID
+}
+
+// reconcileNodeVersions records the versions the launcher of the given node published on its
+// heartbeat lease, along with the launcher image id kubernetes reports for the launcher pod.
+func (r *Reconciler) reconcileNodeVersions(
+	ctx context.Context,
+	reconcileData *ReconcileData,
+	nodeName string,
+	pods []k8scorev1.Pod,
+) {
+	for i := range pods {
+		pod := &pods[i]
+
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != k8scorev1.PodRunning {
+			continue
+		}
+
+		lease := &k8scoordinationv1.Lease{}
+
+		err := r.Client.Get(
+			ctx,
+			apimachinerytypes.NamespacedName{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+			},
+			lease,
+		)
+		if err != nil {
+			continue
+		}
+
+		versions, ok := LauncherVersions(lease)
+		if !ok {
+			continue
+		}
+
+		versions.LauncherImage = LauncherImageID(pod, nodeName)
+
+		reconcileData.NodeVersions[nodeName] = versions
+	}
+}
@@ -0,0 +1,129 @@
+package topology_test
+
+import (
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	k8scoordinationv1 "k8s.io/api/coordination/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLauncherVersions(t *testing.T) {
+	cases := []struct {
+		name             string
+		annotations      map[string]string
+		expectedVersions clabernetesapisv1alpha1.NodeVersions
+		expectedOk       bool
+	}{
+		{
+			name:        "no-annotation",
+			annotations: nil,
+			expectedOk:  false,
+		},
+		{
+			name: "versions",
+			annotations: map[string]string{
+				clabernetesconstants.LauncherVersionsAnnotation: `{"launcher":"0.3.0",` +
+					`"containerlab":"0.60.1","docker":"27.3.1","kernel":"6.8.0-45-generic"}`,
+			},
+			expectedVersions: clabernetesapisv1alpha1.NodeVersions{
+				Launcher:     "0.3.0",
+				Containerlab: "0.60.1",
+				Docker:       "27.3.1",
+				Kernel:       "6.8.0-45-generic",
+			},
+			expectedOk: true,
+		},
+		{
+			name: "garbage",
+			annotations: map[string]string{
+				clabernetesconstants.LauncherVersionsAnnotation: "latest",
+			},
+			expectedOk: false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actualVersions, actualOk := clabernetescontrollerstopology.LauncherVersions(
+					&k8scoordinationv1.Lease{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: testCase.annotations,
+						},
+					},
+				)
+				if actualOk != testCase.expectedOk {
+					clabernetestesthelper.FailOutput(t, actualOk, testCase.expectedOk)
+				}
+
+				if actualVersions != testCase.expectedVersions {
+					clabernetestesthelper.FailOutput(t, actualVersions, testCase.expectedVersions)
+				}
+			})
+	}
+}
+
+func TestLauncherImageID(t *testing.T) {
+	cases := []struct {
+		name              string
+		containerStatuses []k8scorev1.ContainerStatus
+		expected          string
+	}{
+		{
+			name:     "not-reported",
+			expected: "",
+		},
+		{
+			name: "docker-mode",
+			containerStatuses: []k8scorev1.ContainerStatus{
+				{
+					Name:    "srl1",
+					ImageID: "ghcr.io/srl-labs/clabernetes/clabernetes-launcher@sha256:abc",
+				},
+			},
+			expected: "ghcr.io/srl-labs/clabernetes/clabernetes-launcher@sha256:abc",
+		},
+		{
+			name: "native-mode",
+			containerStatuses: []k8scorev1.ContainerStatus{
+				{
+					Name:    "srl1",
+					ImageID: "ghcr.io/nokia/srlinux@sha256:def",
+				},
+				{
+					Name:    "clabernetes-launcher",
+					ImageID: "ghcr.io/srl-labs/clabernetes/clabernetes-launcher@sha256:abc",
+				},
+			},
+			expected: "ghcr.io/srl-labs/clabernetes/clabernetes-launcher@sha256:abc",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.LauncherImageID(
+					&k8scorev1.Pod{
+						Status: k8scorev1.PodStatus{
+							ContainerStatuses: testCase.containerStatuses,
+						},
+					},
+					"srl1",
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
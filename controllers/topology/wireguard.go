@@ -0,0 +1,316 @@
+package topology
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	wireGuardPrivateKeySuffix = "private"
+	wireGuardPublicKeySuffix  = "public"
+	wireGuardAddressSuffix    = "address"
+
+	// the overlay needs room for more than just the network and broadcast addresses
+	wireGuardOverlayMaxPrefixLen = 30
+)
+
+// ResolveWireGuardOverlayPrefix returns the prefix that the wireguard overlay addresses of the
+// given topology are allocated from -- each launcher gets one address out of this range, the
+// emulated links are then carried in vxlan tunnels between these addresses (over the wireguard
+// interface).
+func ResolveWireGuardOverlayPrefix(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) (netip.Prefix, error) {
+	overlayCIDR := owningTopology.Spec.WireGuardOverlayCIDR
+	if overlayCIDR == "" {
+		overlayCIDR = clabernetesconstants.WireGuardOverlayCIDR
+	}
+
+	overlayPrefix, err := netip.ParsePrefix(overlayCIDR)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf(
+			"%w: failed parsing wireguard overlay cidr %q, error: %w",
+			claberneteserrors.ErrInvalidData,
+			overlayCIDR,
+			err,
+		)
+	}
+
+	if !overlayPrefix.Addr().Is4() || overlayPrefix.Bits() > wireGuardOverlayMaxPrefixLen {
+		return netip.Prefix{}, fmt.Errorf(
+			"%w: wireguard overlay cidr %q must be an ipv4 range of at least /%d",
+			claberneteserrors.ErrInvalidData,
+			overlayCIDR,
+			wireGuardOverlayMaxPrefixLen,
+		)
+	}
+
+	return overlayPrefix.Masked(), nil
+}
+
+func wireGuardSecretName(owningTopologyName string) string {
+	return fmt.Sprintf("%s-wireguard", owningTopologyName)
+}
+
+func wireGuardPeersSecretName(owningTopologyName string) string {
+	return fmt.Sprintf("%s-wireguard-peers", owningTopologyName)
+}
+
+func wireGuardSecretKey(nodeName, suffix string) string {
+	return fmt.Sprintf("%s.%s", nodeName, suffix)
+}
+
+// WireGuardSecretReconciler is a subcomponent of the "TopologyReconciler" but is exposed for
+// testing purposes. This is the component responsible for rendering/validating the secrets holding
+// the per node wireguard keys and overlay addresses for topologies using the "wireguard"
+// connectivity flavor.
+type WireGuardSecretReconciler struct {
+	log                 claberneteslogging.Instance
+	configManagerGetter clabernetesconfig.ManagerGetterFunc
+}
+
+// NewWireGuardSecretReconciler returns an instance of WireGuardSecretReconciler.
+func NewWireGuardSecretReconciler(
+	log claberneteslogging.Instance,
+	configManagerGetter clabernetesconfig.ManagerGetterFunc,
+) *WireGuardSecretReconciler {
+	return &WireGuardSecretReconciler{
+		log:                 log,
+		configManagerGetter: configManagerGetter,
+	}
+}
+
+// Render accepts the owning topology, the existing wireguard secret (which may be nil) and the
+// names of the nodes in the topology and renders the wireguard secret. Keys and addresses for
+// nodes that already exist in the existing secret are retained so that launchers don't need to be
+// restarted every reconcile (unless the overlay range changed from under their address), new
+// nodes get freshly generated keys and the lowest free address.
+func (r *WireGuardSecretReconciler) Render(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	existingSecret *k8scorev1.Secret,
+	nodeNames []string,
+) (*k8scorev1.Secret, error) {
+	overlayPrefix, err := ResolveWireGuardOverlayPrefix(owningTopology)
+	if err != nil {
+		return nil, err
+	}
+
+	var existingData map[string][]byte
+
+	if existingSecret != nil {
+		existingData = existingSecret.Data
+	}
+
+	data := map[string][]byte{}
+	usedAddresses := map[string]bool{}
+
+	sortedNodeNames := slices.Clone(nodeNames)
+	slices.Sort(sortedNodeNames)
+
+	var newNodes []string
+
+	for _, nodeName := range sortedNodeNames {
+		privateKey, privateOk := existingData[wireGuardSecretKey(nodeName, wireGuardPrivateKeySuffix)]
+		publicKey, publicOk := existingData[wireGuardSecretKey(nodeName, wireGuardPublicKeySuffix)]
+		address, addressOk := existingData[wireGuardSecretKey(nodeName, wireGuardAddressSuffix)]
+
+		if !privateOk || !publicOk || !addressOk || usedAddresses[string(address)] ||
+			!wireGuardOverlayContains(overlayPrefix, string(address)) {
+			newNodes = append(newNodes, nodeName)
+
+			continue
+		}
+
+		data[wireGuardSecretKey(nodeName, wireGuardPrivateKeySuffix)] = privateKey
+		data[wireGuardSecretKey(nodeName, wireGuardPublicKeySuffix)] = publicKey
+		data[wireGuardSecretKey(nodeName, wireGuardAddressSuffix)] = address
+
+		usedAddresses[string(address)] = true
+	}
+
+	// skip the network address itself
+	nextAddress := overlayPrefix.Addr().Next()
+
+	for _, nodeName := range newNodes {
+		privateKey, publicKey, err := generateWireGuardKeyPair()
+		if err != nil {
+			return nil, err
+		}
+
+		for usedAddresses[nextAddress.String()] {
+			nextAddress = nextAddress.Next()
+		}
+
+		if !overlayPrefix.Contains(nextAddress) || nextAddress == lastAddress(overlayPrefix) {
+			return nil, fmt.Errorf(
+				"%w: exhausted wireguard overlay addresses allocating address for node %q",
+				claberneteserrors.ErrReconcile,
+				nodeName,
+			)
+		}
+
+		data[wireGuardSecretKey(nodeName, wireGuardPrivateKeySuffix)] = []byte(privateKey)
+		data[wireGuardSecretKey(nodeName, wireGuardPublicKeySuffix)] = []byte(publicKey)
+		data[wireGuardSecretKey(nodeName, wireGuardAddressSuffix)] = []byte(nextAddress.String())
+
+		usedAddresses[nextAddress.String()] = true
+	}
+
+	return r.renderSecret(
+		owningTopology,
+		wireGuardSecretName(owningTopology.GetName()),
+		data,
+	), nil
+}
+
+// RenderPeers accepts the owning topology and the (rendered) wireguard secret and renders the
+// wireguard peers secret -- the public keys and overlay addresses of all nodes, but none of their
+// private keys. Launchers mount this secret as a whole, so nodes coming and going do not change
+// the launcher pod specs.
+func (r *WireGuardSecretReconciler) RenderPeers(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	wireGuardSecret *k8scorev1.Secret,
+) *k8scorev1.Secret {
+	data := map[string][]byte{}
+
+	for key, value := range wireGuardSecret.Data {
+		if strings.HasSuffix(key, "."+wireGuardPrivateKeySuffix) {
+			continue
+		}
+
+		data[key] = value
+	}
+
+	return r.renderSecret(
+		owningTopology,
+		wireGuardPeersSecretName(owningTopology.GetName()),
+		data,
+	)
+}
+
+func (r *WireGuardSecretReconciler) renderSecret(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	name string,
+	data map[string][]byte,
+) *k8scorev1.Secret {
+	owningTopologyName := owningTopology.GetName()
+
+	annotations, globalLabels := r.configManagerGetter().GetAllMetadata()
+
+	labels := map[string]string{
+		clabernetesconstants.LabelApp:           clabernetesconstants.Clabernetes,
+		clabernetesconstants.LabelName:          name,
+		clabernetesconstants.LabelTopologyOwner: owningTopologyName,
+	}
+
+	for k, v := range globalLabels {
+		labels[k] = v
+	}
+
+	return &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   owningTopology.GetNamespace(),
+			Annotations: annotations,
+			Labels:      labels,
+		},
+		Type: k8scorev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+// Conforms checks if the existingSecret conforms with the renderedSecret.
+func (r *WireGuardSecretReconciler) Conforms(
+	existingSecret,
+	renderedSecret *k8scorev1.Secret,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !reflect.DeepEqual(existingSecret.Data, renderedSecret.Data) {
+		return false
+	}
+
+	if !clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
+		existingSecret.ObjectMeta.Annotations,
+		renderedSecret.ObjectMeta.Annotations,
+	) {
+		return false
+	}
+
+	if !clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
+		existingSecret.ObjectMeta.Labels,
+		renderedSecret.ObjectMeta.Labels,
+	) {
+		return false
+	}
+
+	if len(existingSecret.ObjectMeta.OwnerReferences) != 1 {
+		// we should have only one owner reference, the topology
+		return false
+	}
+
+	if existingSecret.ObjectMeta.OwnerReferences[0].UID != expectedOwnerUID {
+		// owner ref uid is not us
+		return false
+	}
+
+	return true
+}
+
+// wireGuardOverlayContains returns true if the given (secret) address is a usable host address in
+// the given overlay prefix.
+func wireGuardOverlayContains(overlayPrefix netip.Prefix, address string) bool {
+	parsedAddress, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+
+	return overlayPrefix.Contains(parsedAddress) &&
+		parsedAddress != overlayPrefix.Addr() &&
+		parsedAddress != lastAddress(overlayPrefix)
+}
+
+// lastAddress returns the last (broadcast) address of the given (masked, ipv4) prefix.
+func lastAddress(prefix netip.Prefix) netip.Addr {
+	address := prefix.Addr().As4()
+
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+
+	for idx := len(address) - 1; idx >= 0 && hostBits > 0; idx-- {
+		bits := min(hostBits, 8) //nolint:mnd
+
+		address[idx] |= byte(1<<bits - 1)
+
+		hostBits -= bits
+	}
+
+	return netip.AddrFrom4(address)
+}
+
+// generateWireGuardKeyPair returns a new base64 encoded (as wg expects) curve25519 private and
+// public key pair.
+func generateWireGuardKeyPair() (privateKey, publicKey string, err error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key.Bytes()),
+		base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()),
+		nil
+}
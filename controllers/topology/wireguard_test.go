@@ -0,0 +1,270 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestRenderWireGuardSecret ensures that we generate keys/addresses for new nodes, retain them for
+// existing nodes, and drop them for removed nodes.
+func TestRenderWireGuardSecret(t *testing.T) {
+	reconciler := clabernetescontrollerstopology.NewWireGuardSecretReconciler(
+		&claberneteslogging.FakeInstance{},
+		clabernetesconfig.GetFakeManager,
+	)
+
+	owningTopology := &clabernetesapisv1alpha1.Topology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-wireguard",
+			Namespace: "nowhere",
+		},
+	}
+
+	initialSecret, err := reconciler.Render(
+		owningTopology,
+		nil,
+		[]string{"srl2", "srl1"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if initialSecret.Name != "test-wireguard-wireguard" {
+		clabernetestesthelper.FailOutput(t, initialSecret.Name, "test-wireguard-wireguard")
+	}
+
+	if len(initialSecret.Data) != 6 {
+		clabernetestesthelper.FailOutput(t, len(initialSecret.Data), 6)
+	}
+
+	for nodeName, expectedAddress := range map[string]string{
+		"srl1": "10.254.0.1",
+		"srl2": "10.254.0.2",
+	} {
+		actualAddress := string(initialSecret.Data[nodeName+".address"])
+		if actualAddress != expectedAddress {
+			clabernetestesthelper.FailOutput(t, actualAddress, expectedAddress)
+		}
+	}
+
+	updatedSecret, err := reconciler.Render(
+		owningTopology,
+		initialSecret,
+		[]string{"srl2", "srl3"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(updatedSecret.Data) != 6 {
+		clabernetestesthelper.FailOutput(t, len(updatedSecret.Data), 6)
+	}
+
+	for _, key := range []string{"srl2.private", "srl2.public", "srl2.address"} {
+		if string(updatedSecret.Data[key]) != string(initialSecret.Data[key]) {
+			clabernetestesthelper.FailOutput(t, updatedSecret.Data[key], initialSecret.Data[key])
+		}
+	}
+
+	if _, ok := updatedSecret.Data["srl1.private"]; ok {
+		t.Fatal("expected removed node keys to be pruned from the secret")
+	}
+
+	// srl1s address was freed up, so the new node should get the lowest free address
+	if string(updatedSecret.Data["srl3.address"]) != "10.254.0.1" {
+		clabernetestesthelper.FailOutput(t, updatedSecret.Data["srl3.address"], "10.254.0.1")
+	}
+}
+
+// TestRenderWireGuardSecretOverlayCIDR ensures that we allocate addresses out of an overridden
+// overlay range, re-address nodes whose address falls outside of it, and reject invalid or
+// exhausted ranges.
+func TestRenderWireGuardSecretOverlayCIDR(t *testing.T) {
+	reconciler := clabernetescontrollerstopology.NewWireGuardSecretReconciler(
+		&claberneteslogging.FakeInstance{},
+		clabernetesconfig.GetFakeManager,
+	)
+
+	owningTopology := &clabernetesapisv1alpha1.Topology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-wireguard",
+			Namespace: "nowhere",
+		},
+	}
+
+	initialSecret, err := reconciler.Render(owningTopology, nil, []string{"srl1", "srl2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owningTopology.Spec.WireGuardOverlayCIDR = "172.31.0.5/30"
+
+	updatedSecret, err := reconciler.Render(
+		owningTopology,
+		initialSecret,
+		[]string{"srl1", "srl2"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for nodeName, expectedAddress := range map[string]string{
+		"srl1": "172.31.0.5",
+		"srl2": "172.31.0.6",
+	} {
+		actualAddress := string(updatedSecret.Data[nodeName+".address"])
+		if actualAddress != expectedAddress {
+			clabernetestesthelper.FailOutput(t, actualAddress, expectedAddress)
+		}
+	}
+
+	// a /30 only has the two host addresses, a third node can not fit
+	_, err = reconciler.Render(owningTopology, updatedSecret, []string{"srl1", "srl2", "srl3"})
+	if err == nil {
+		t.Fatal("expected exhausted overlay range to fail rendering")
+	}
+
+	for _, overlayCIDR := range []string{"not-a-cidr", "fd00::/64", "172.31.0.0/31"} {
+		owningTopology.Spec.WireGuardOverlayCIDR = overlayCIDR
+
+		_, err = reconciler.Render(owningTopology, nil, []string{"srl1"})
+		if err == nil {
+			t.Fatalf("expected overlay cidr %q to fail rendering", overlayCIDR)
+		}
+	}
+}
+
+// TestRenderWireGuardPeersSecret ensures that the peers secret holds the public keys and addresses
+// of all nodes but none of their private keys.
+func TestRenderWireGuardPeersSecret(t *testing.T) {
+	reconciler := clabernetescontrollerstopology.NewWireGuardSecretReconciler(
+		&claberneteslogging.FakeInstance{},
+		clabernetesconfig.GetFakeManager,
+	)
+
+	owningTopology := &clabernetesapisv1alpha1.Topology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-wireguard",
+			Namespace: "nowhere",
+		},
+	}
+
+	secret, err := reconciler.Render(owningTopology, nil, []string{"srl1", "srl2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peersSecret := reconciler.RenderPeers(owningTopology, secret)
+
+	if peersSecret.Name != "test-wireguard-wireguard-peers" {
+		clabernetestesthelper.FailOutput(t, peersSecret.Name, "test-wireguard-wireguard-peers")
+	}
+
+	if len(peersSecret.Data) != 4 {
+		clabernetestesthelper.FailOutput(t, len(peersSecret.Data), 4)
+	}
+
+	for _, key := range []string{"srl1.public", "srl1.address", "srl2.public", "srl2.address"} {
+		if string(peersSecret.Data[key]) != string(secret.Data[key]) {
+			clabernetestesthelper.FailOutput(t, peersSecret.Data[key], secret.Data[key])
+		}
+	}
+
+	for _, key := range []string{"srl1.private", "srl2.private"} {
+		if _, ok := peersSecret.Data[key]; ok {
+			t.Fatalf("expected private key %q to not be in the peers secret", key)
+		}
+	}
+}
+
+// TestRenderDeploymentWireGuardVolumes ensures that the wireguard volumes of a launcher do not
+// depend on the other nodes of the topology, so adding or removing nodes does not restart it.
+func TestRenderDeploymentWireGuardVolumes(t *testing.T) {
+	reconciler := clabernetescontrollerstopology.NewDeploymentReconciler(
+		&claberneteslogging.FakeInstance{},
+		"clabernetes",
+		"clabernetes",
+		"",
+		clabernetesconfig.GetFakeManager,
+	)
+
+	owningTopology := &clabernetesapisv1alpha1.Topology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-wireguard",
+			Namespace: "nowhere",
+		},
+		Spec: clabernetesapisv1alpha1.TopologySpec{
+			Connectivity: clabernetesconstants.ConnectivityWireGuard,
+		},
+	}
+
+	configs := func(nodeNames ...string) map[string]*clabernetesutilcontainerlab.Config {
+		nodeConfigs := map[string]*clabernetesutilcontainerlab.Config{}
+
+		for _, nodeName := range nodeNames {
+			nodeConfigs[nodeName] = &clabernetesutilcontainerlab.Config{
+				Name:   nodeName,
+				Prefix: clabernetesutil.ToPointer(""),
+				Topology: &clabernetesutilcontainerlab.Topology{
+					Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+					Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+						nodeName: {
+							Kind:  "srl",
+							Image: "ghcr.io/nokia/srlinux",
+						},
+					},
+				},
+			}
+		}
+
+		return nodeConfigs
+	}
+
+	initial := reconciler.Render(owningTopology, configs("srl1", "srl2"), "srl1")
+	updated := reconciler.Render(owningTopology, configs("srl1", "srl2", "srl3"), "srl1")
+
+	if !reflect.DeepEqual(
+		initial.Spec.Template.Spec.Volumes,
+		updated.Spec.Template.Spec.Volumes,
+	) {
+		clabernetestesthelper.FailOutput(
+			t,
+			updated.Spec.Template.Spec.Volumes,
+			initial.Spec.Template.Spec.Volumes,
+		)
+	}
+
+	var found int
+
+	for _, volume := range initial.Spec.Template.Spec.Volumes {
+		switch volume.Name {
+		case "wireguard":
+			found++
+
+			if len(volume.Secret.Items) != 1 || volume.Secret.Items[0].Key != "srl1.private" {
+				t.Fatalf("expected only the private key of srl1 to be mounted, got %v", volume)
+			}
+		case "wireguard-peers":
+			found++
+
+			if volume.Secret.SecretName != "test-wireguard-wireguard-peers" ||
+				len(volume.Secret.Items) != 0 {
+				t.Fatalf("expected the whole peers secret to be mounted, got %v", volume)
+			}
+		}
+	}
+
+	if found != 2 {
+		clabernetestesthelper.FailOutput(t, found, 2)
+	}
+}
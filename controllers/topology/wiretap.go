@@ -0,0 +1,449 @@
+package topology
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	wireTapComponent     = "wire-tap"
+	wireTapContainerName = "wire-tap"
+	wireTapNameHashLen   = 8
+)
+
+// wireTapName returns the name of the wire tap (pod, service, and "node" in the connectivity cr)
+// of the link between the given (sorted) endpoints.
+func wireTapName(owningTopologyName, endpointA, endpointB string) string {
+	linkHash := clabernetesutil.HashBytes([]byte(fmt.Sprintf("%s,%s", endpointA, endpointB)))
+
+	return clabernetesutilkubernetes.SafeConcatNameKubernetes(
+		owningTopologyName,
+		"tap",
+		linkHash[:wireTapNameHashLen],
+	)
+}
+
+// wireTapsEnabled returns true if the given topology has any wire taps, wire taps are only a
+// thing with vxlan connectivity.
+func wireTapsEnabled(owningTopology *clabernetesapisv1alpha1.Topology) bool {
+	if len(owningTopology.Spec.WireTaps) == 0 {
+		return false
+	}
+
+	return owningTopology.Spec.Connectivity == "" ||
+		owningTopology.Spec.Connectivity == clabernetesconstants.ConnectivityVXLAN
+}
+
+// wireTapSelectorLabels returns the labels selecting the wire tap pod with the given name of the
+// given topology.
+func wireTapSelectorLabels(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	name string,
+) map[string]string {
+	// note: no topology owner label here -- the deployment/service resolvers expect everything
+	// carrying that label to belong to a node of the topology
+	return map[string]string{
+		clabernetesconstants.LabelKubernetesName:  name,
+		clabernetesconstants.LabelApp:             clabernetesconstants.Clabernetes,
+		clabernetesconstants.LabelName:            name,
+		clabernetesconstants.LabelComponent:       wireTapComponent,
+		clabernetesconstants.LabelWireTapTopology: owningTopology.GetName(),
+	}
+}
+
+// ApplyWireTaps routes the links of the wire taps of the owning topology through wire tap pods --
+// the tunnels of both sides of a tapped link are pointed at the wire tap rather than at each
+// other, and the wire tap gets a tunnel back to each side (stored in the given tunnels under the
+// wire tap name, so tunnel ids are allocated like for any other node). It returns the sorted
+// names of the wire taps and a sorted list of the wire tap endpoints that did not match any vxlan
+// tunnel (see the topology linkConnectivity) so the caller can let the user know.
+func ApplyWireTaps(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	tunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+	configManagerGetter clabernetesconfig.ManagerGetterFunc,
+) (wireTaps, unmatchedEndpoints []string) {
+	if !wireTapsEnabled(owningTopology) {
+		return nil, nil
+	}
+
+	// remember the remote endpoint of each tunnel up front, the tunnels of tapped links point at
+	// the wire tap once we're done with them
+	type tunnelRef struct {
+		key            string
+		tunnel         *clabernetesapisv1alpha1.PointToPointTunnel
+		remoteEndpoint string
+	}
+
+	endpointTunnels := map[string]tunnelRef{}
+
+	for key, nodeTunnels := range tunnels {
+		for _, tunnel := range nodeTunnels {
+			endpointTunnels[tunnelLocalEndpoint(tunnel)] = tunnelRef{
+				key:            key,
+				tunnel:         tunnel,
+				remoteEndpoint: tunnelRemoteEndpoint(tunnel),
+			}
+		}
+	}
+
+	tappedLinks := clabernetesutil.NewStringSet()
+
+	for _, endpoint := range owningTopology.Spec.WireTaps {
+		localRef, ok := endpointTunnels[endpoint]
+		if !ok {
+			unmatchedEndpoints = append(unmatchedEndpoints, endpoint)
+
+			continue
+		}
+
+		remoteEndpoint := localRef.remoteEndpoint
+
+		remoteRef, ok := endpointTunnels[remoteEndpoint]
+		if !ok {
+			unmatchedEndpoints = append(unmatchedEndpoints, endpoint)
+
+			continue
+		}
+
+		if localRef.tunnel.Connectivity != "" {
+			// the link was moved to another connectivity flavor, wire taps only speak vxlan
+			unmatchedEndpoints = append(unmatchedEndpoints, endpoint)
+
+			continue
+		}
+
+		endpointA, endpointB := endpoint, remoteEndpoint
+		refA, refB := localRef, remoteRef
+
+		if endpointB < endpointA {
+			endpointA, endpointB = endpointB, endpointA
+			refA, refB = refB, refA
+		}
+
+		if tappedLinks.Contains(endpointA) {
+			// both endpoints of the link are listed, the link is already tapped
+			continue
+		}
+
+		tappedLinks.Add(endpointA)
+
+		name := wireTapName(owningTopology.GetName(), endpointA, endpointB)
+
+		wireTapDestination := fmt.Sprintf(
+			"%s.%s.%s",
+			name,
+			owningTopology.GetNamespace(),
+			configManagerGetter().GetInClusterDNSSuffix(),
+		)
+
+		tunnels[name] = []*clabernetesapisv1alpha1.PointToPointTunnel{
+			{
+				Destination:     refB.tunnel.Destination,
+				LocalNode:       name,
+				LocalInterface:  clabernetesconstants.WireTapSideA,
+				RemoteNode:      refA.key,
+				RemoteInterface: refA.tunnel.LocalInterface,
+			},
+			{
+				Destination:     refA.tunnel.Destination,
+				LocalNode:       name,
+				LocalInterface:  clabernetesconstants.WireTapSideB,
+				RemoteNode:      refB.key,
+				RemoteInterface: refB.tunnel.LocalInterface,
+			},
+		}
+
+		for side, ref := range map[string]tunnelRef{
+			clabernetesconstants.WireTapSideA: refA,
+			clabernetesconstants.WireTapSideB: refB,
+		} {
+			ref.tunnel.Destination = wireTapDestination
+			ref.tunnel.RemoteNode = name
+			ref.tunnel.RemoteInterface = side
+		}
+
+		wireTaps = append(wireTaps, name)
+	}
+
+	slices.Sort(wireTaps)
+	slices.Sort(unmatchedEndpoints)
+
+	return wireTaps, unmatchedEndpoints
+}
+
+// WireTapReconciler is a subcomponent of the "TopologyReconciler" but is exposed for testing
+// purposes. This is the component responsible for rendering/validating the deployments and
+// services of the (optional) wire tap pods of a topology.
+type WireTapReconciler struct {
+	log                 claberneteslogging.Instance
+	configManagerGetter clabernetesconfig.ManagerGetterFunc
+}
+
+// NewWireTapReconciler returns an instance of WireTapReconciler.
+func NewWireTapReconciler(
+	log claberneteslogging.Instance,
+	configManagerGetter clabernetesconfig.ManagerGetterFunc,
+) *WireTapReconciler {
+	return &WireTapReconciler{
+		log:                 log,
+		configManagerGetter: configManagerGetter,
+	}
+}
+
+func (r *WireTapReconciler) renderMetadata(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	name string,
+) (annotations, selectorLabels, labels map[string]string) {
+	annotations, globalLabels := r.configManagerGetter().GetAllMetadata()
+
+	selectorLabels = wireTapSelectorLabels(owningTopology, name)
+
+	labels = map[string]string{}
+
+	for k, v := range selectorLabels {
+		labels[k] = v
+	}
+
+	for k, v := range globalLabels {
+		labels[k] = v
+	}
+
+	return annotations, selectorLabels, labels
+}
+
+// RenderDeployment renders the deployment of the wire tap with the given name of the given
+// topology, the tunnels are the (tunnel id allocated) tunnels of the wire tap.
+func (r *WireTapReconciler) RenderDeployment(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	name string,
+	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+) *k8sappsv1.Deployment {
+	annotations, selectorLabels, labels := r.renderMetadata(owningTopology, name)
+
+	image := owningTopology.Spec.Deployment.LauncherImage
+	if image == "" {
+		image = r.configManagerGetter().GetLauncherImage()
+	}
+
+	imagePullPolicy := owningTopology.Spec.Deployment.LauncherImagePullPolicy
+	if imagePullPolicy == "" {
+		imagePullPolicy = r.configManagerGetter().GetLauncherImagePullPolicy()
+	}
+
+	connectivityPorts := ResolveConnectivityPorts(
+		owningTopology,
+		r.configManagerGetter().GetConnectivityPorts(),
+	)
+
+	launcherLogLevel := owningTopology.Spec.Deployment.LauncherLogLevel
+	if launcherLogLevel == "" {
+		launcherLogLevel = r.configManagerGetter().GetLauncherLogLevel()
+	}
+
+	env := []k8scorev1.EnvVar{
+		{
+			Name:  clabernetesconstants.LauncherLoggerLevelEnv,
+			Value: launcherLogLevel,
+		},
+		{
+			Name:  clabernetesconstants.LauncherVXLANPortEnv,
+			Value: strconv.Itoa(int(connectivityPorts.VXLAN)),
+		},
+	}
+
+	for _, tunnel := range tunnels {
+		tunnelIDEnv := clabernetesconstants.WireTapSideATunnelIDEnv
+		destinationEnv := clabernetesconstants.WireTapSideADestinationEnv
+
+		if tunnel.LocalInterface == clabernetesconstants.WireTapSideB {
+			tunnelIDEnv = clabernetesconstants.WireTapSideBTunnelIDEnv
+			destinationEnv = clabernetesconstants.WireTapSideBDestinationEnv
+		}
+
+		env = append(
+			env,
+			k8scorev1.EnvVar{
+				Name:  tunnelIDEnv,
+				Value: strconv.Itoa(tunnel.TunnelID),
+			},
+			k8scorev1.EnvVar{
+				Name:  destinationEnv,
+				Value: tunnel.Destination,
+			},
+		)
+	}
+
+	replicas := int32(1)
+	if owningTopology.Status.Suspended {
+		replicas = 0
+	}
+
+	return &k8sappsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   owningTopology.GetNamespace(),
+			Annotations: annotations,
+			Labels:      labels,
+		},
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas:             clabernetesutil.ToPointer(replicas),
+			RevisionHistoryLimit: clabernetesutil.ToPointer(int32(0)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			// the tunnels are set up once at startup, so never have two of these around at once
+			Strategy: k8sappsv1.DeploymentStrategy{
+				Type: k8sappsv1.RecreateDeploymentStrategyType,
+			},
+			Template: k8scorev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+					Labels:      labels,
+				},
+				Spec: k8scorev1.PodSpec{
+					Containers: []k8scorev1.Container{
+						{
+							Name:       wireTapContainerName,
+							WorkingDir: "/clabernetes",
+							Image:      image,
+							Command:    []string{"/clabernetes/manager", "wire-tap"},
+							Ports: []k8scorev1.ContainerPort{
+								{
+									Name:          clabernetesconstants.ConnectivityVXLAN,
+									ContainerPort: connectivityPorts.VXLAN,
+									Protocol:      clabernetesconstants.UDP,
+								},
+							},
+							Env:             env,
+							ImagePullPolicy: k8scorev1.PullPolicy(imagePullPolicy),
+							SecurityContext: &k8scorev1.SecurityContext{
+								Capabilities: &k8scorev1.Capabilities{
+									Add: []k8scorev1.Capability{"NET_ADMIN", "NET_RAW"},
+								},
+							},
+						},
+					},
+					RestartPolicy:      k8scorev1.RestartPolicyAlways,
+					ServiceAccountName: launcherServiceAccountName(),
+				},
+			},
+		},
+	}
+}
+
+// RenderService renders the service of the wire tap with the given name of the given topology --
+// this is what the launchers on both sides of the tapped link point their tunnels at.
+func (r *WireTapReconciler) RenderService(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	name string,
+) *k8scorev1.Service {
+	annotations, selectorLabels, labels := r.renderMetadata(owningTopology, name)
+
+	// the launchers only follow (watch) the fabric services, so the wire tap service is one too
+	labels[clabernetesconstants.LabelTopologyServiceType] =
+		clabernetesconstants.TopologyServiceTypeFabric
+
+	connectivityPorts := ResolveConnectivityPorts(
+		owningTopology,
+		r.configManagerGetter().GetConnectivityPorts(),
+	)
+
+	return &k8scorev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   owningTopology.GetNamespace(),
+			Annotations: annotations,
+			Labels:      labels,
+		},
+		Spec: k8scorev1.ServiceSpec{
+			Ports: []k8scorev1.ServicePort{
+				{
+					Name:     clabernetesconstants.ConnectivityVXLAN,
+					Protocol: clabernetesconstants.UDP,
+					Port:     connectivityPorts.VXLAN,
+					TargetPort: intstr.IntOrString{
+						IntVal: connectivityPorts.VXLAN,
+					},
+				},
+			},
+			Selector: selectorLabels,
+			Type:     k8scorev1.ServiceTypeClusterIP,
+			// the launchers resolve the endpoint before the wire tap is ready (and vice versa)
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
+
+// ConformsDeployment checks if the existingDeployment conforms with the renderedDeployment.
+func (r *WireTapReconciler) ConformsDeployment(
+	existingDeployment,
+	renderedDeployment *k8sappsv1.Deployment,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !reflect.DeepEqual(existingDeployment.Spec.Replicas, renderedDeployment.Spec.Replicas) {
+		return false
+	}
+
+	existingContainers := existingDeployment.Spec.Template.Spec.Containers
+	renderedContainers := renderedDeployment.Spec.Template.Spec.Containers
+
+	if len(existingContainers) != len(renderedContainers) {
+		return false
+	}
+
+	for idx := range renderedContainers {
+		if existingContainers[idx].Image != renderedContainers[idx].Image {
+			return false
+		}
+
+		if !reflect.DeepEqual(existingContainers[idx].Env, renderedContainers[idx].Env) {
+			return false
+		}
+	}
+
+	return clockSyncMetadataConforms(
+		existingDeployment.ObjectMeta,
+		renderedDeployment.ObjectMeta,
+		expectedOwnerUID,
+	)
+}
+
+// ConformsService checks if the existingService conforms with the renderedService.
+func (r *WireTapReconciler) ConformsService(
+	existingService,
+	renderedService *k8scorev1.Service,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	if !reflect.DeepEqual(existingService.Spec.Ports, renderedService.Spec.Ports) {
+		return false
+	}
+
+	if !reflect.DeepEqual(existingService.Spec.Selector, renderedService.Spec.Selector) {
+		return false
+	}
+
+	if existingService.Spec.PublishNotReadyAddresses !=
+		renderedService.Spec.PublishNotReadyAddresses {
+		return false
+	}
+
+	return clockSyncMetadataConforms(
+		existingService.ObjectMeta,
+		renderedService.ObjectMeta,
+		expectedOwnerUID,
+	)
+}
@@ -0,0 +1,222 @@
+package topology_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyWireTaps(t *testing.T) {
+	cases := []struct {
+		name              string
+		connectivity      string
+		wireTaps          []string
+		expectedWireTaps  int
+		expectedUnmatched []string
+	}{
+		{
+			name:             "no-wire-taps",
+			wireTaps:         nil,
+			expectedWireTaps: 0,
+		},
+		{
+			name:             "single-endpoint-taps-link",
+			wireTaps:         []string{"srl2:e1-1"},
+			expectedWireTaps: 1,
+		},
+		{
+			name:              "both-endpoints-and-unmatched",
+			wireTaps:          []string{"srl1:e1-1", "srl2:e1-1", "srl3:e1-1"},
+			expectedWireTaps:  1,
+			expectedUnmatched: []string{"srl3:e1-1"},
+		},
+		{
+			name:             "unsupported-connectivity",
+			connectivity:     clabernetesconstants.ConnectivitySlurpeeth,
+			wireTaps:         []string{"srl1:e1-1"},
+			expectedWireTaps: 0,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				owningTopology := &clabernetesapisv1alpha1.Topology{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "topo-1",
+						Namespace: "clabernetes",
+					},
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						Connectivity: testCase.connectivity,
+						WireTaps:     testCase.wireTaps,
+					},
+				}
+
+				tunnels := linkTestTunnels()
+
+				actualWireTaps, actualUnmatched := clabernetescontrollerstopology.ApplyWireTaps(
+					owningTopology,
+					tunnels,
+					clabernetesconfig.GetFakeManager,
+				)
+
+				if !reflect.DeepEqual(actualUnmatched, testCase.expectedUnmatched) {
+					clabernetestesthelper.FailOutput(
+						t,
+						actualUnmatched,
+						testCase.expectedUnmatched,
+					)
+				}
+
+				if len(actualWireTaps) != testCase.expectedWireTaps {
+					clabernetestesthelper.FailOutput(t, actualWireTaps, testCase.expectedWireTaps)
+				}
+
+				if testCase.expectedWireTaps == 0 {
+					if !reflect.DeepEqual(tunnels, linkTestTunnels()) {
+						clabernetestesthelper.FailOutput(t, tunnels, linkTestTunnels())
+					}
+
+					return
+				}
+
+				wireTapName := actualWireTaps[0]
+
+				if !strings.HasPrefix(wireTapName, "topo-1-tap-") {
+					clabernetestesthelper.FailOutput(t, wireTapName, "topo-1-tap-<hash>")
+				}
+
+				clabernetescontrollerstopology.AllocateTunnelIDs(
+					map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{},
+					tunnels,
+				)
+
+				wireTapTunnels := tunnels[wireTapName]
+
+				if len(wireTapTunnels) != 2 {
+					clabernetestesthelper.FailOutput(t, wireTapTunnels, "two wire tap tunnels")
+				}
+
+				expectedWireTapDestination := wireTapName + ".clabernetes.svc.cluster.local"
+
+				for idx, nodeName := range []string{"srl1", "srl2"} {
+					nodeTunnel := tunnels[nodeName][0]
+					wireTapTunnel := wireTapTunnels[idx]
+
+					if nodeTunnel.RemoteNode != wireTapName ||
+						nodeTunnel.RemoteInterface != wireTapTunnel.LocalInterface ||
+						nodeTunnel.Destination != expectedWireTapDestination {
+						clabernetestesthelper.FailOutput(t, nodeTunnel, wireTapTunnel)
+					}
+
+					if wireTapTunnel.RemoteNode != nodeName ||
+						wireTapTunnel.TunnelID != nodeTunnel.TunnelID {
+						clabernetestesthelper.FailOutput(t, wireTapTunnel, nodeTunnel)
+					}
+				}
+
+				if wireTapTunnels[0].Destination != "topo-1-srl1-vx.clabernetes.svc.cluster.local" {
+					clabernetestesthelper.FailOutput(
+						t,
+						wireTapTunnels[0].Destination,
+						"topo-1-srl1-vx.clabernetes.svc.cluster.local",
+					)
+				}
+
+				if wireTapTunnels[0].TunnelID == wireTapTunnels[1].TunnelID {
+					clabernetestesthelper.FailOutput(
+						t,
+						wireTapTunnels[1].TunnelID,
+						"a tunnel id different from the other side",
+					)
+				}
+			})
+	}
+}
+
+// TestRenderWireTap ensures the wire tap deployment and service select the same pods, the tunnels
+// of both sides end up in the env of the wire tap, and wire taps are not mistaken for nodes.
+func TestRenderWireTap(t *testing.T) {
+	reconciler := clabernetescontrollerstopology.NewWireTapReconciler(
+		&claberneteslogging.FakeInstance{},
+		clabernetesconfig.GetFakeManager,
+	)
+
+	owningTopology := &clabernetesapisv1alpha1.Topology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "topo-1",
+			Namespace: "clabernetes",
+		},
+	}
+
+	tunnels := []*clabernetesapisv1alpha1.PointToPointTunnel{
+		{
+			TunnelID:       1,
+			Destination:    "topo-1-srl1-vx.clabernetes.svc.cluster.local",
+			LocalNode:      "topo-1-tap-12345678",
+			LocalInterface: clabernetesconstants.WireTapSideA,
+		},
+		{
+			TunnelID:       2,
+			Destination:    "topo-1-srl2-vx.clabernetes.svc.cluster.local",
+			LocalNode:      "topo-1-tap-12345678",
+			LocalInterface: clabernetesconstants.WireTapSideB,
+		},
+	}
+
+	renderedDeployment := reconciler.RenderDeployment(
+		owningTopology,
+		"topo-1-tap-12345678",
+		tunnels,
+	)
+	renderedService := reconciler.RenderService(owningTopology, "topo-1-tap-12345678")
+
+	for k, v := range renderedService.Spec.Selector {
+		if renderedDeployment.Spec.Template.Labels[k] != v {
+			clabernetestesthelper.FailOutput(t, renderedDeployment.Spec.Template.Labels[k], v)
+		}
+	}
+
+	if _, ok := renderedDeployment.Labels[clabernetesconstants.LabelTopologyOwner]; ok {
+		clabernetestesthelper.FailOutput(t, renderedDeployment.Labels, "no topology owner label")
+	}
+
+	if renderedService.Labels[clabernetesconstants.LabelTopologyServiceType] !=
+		clabernetesconstants.TopologyServiceTypeFabric {
+		clabernetestesthelper.FailOutput(
+			t,
+			renderedService.Labels,
+			clabernetesconstants.TopologyServiceTypeFabric,
+		)
+	}
+
+	env := map[string]string{}
+
+	for _, envVar := range renderedDeployment.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	expectedEnv := map[string]string{
+		clabernetesconstants.WireTapSideATunnelIDEnv:    "1",
+		clabernetesconstants.WireTapSideADestinationEnv: tunnels[0].Destination,
+		clabernetesconstants.WireTapSideBTunnelIDEnv:    "2",
+		clabernetesconstants.WireTapSideBDestinationEnv: tunnels[1].Destination,
+	}
+
+	for k, v := range expectedEnv {
+		if env[k] != v {
+			clabernetestesthelper.FailOutput(t, env[k], v)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+package errors
+
+import "errors"
+
+// ErrCleanup is the error returned when a topology cleanup left resources behind.
+var ErrCleanup = errors.New("errCleanup")
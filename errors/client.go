@@ -0,0 +1,7 @@
+package errors
+
+import "errors"
+
+// ErrClient is the error returned when a clabernetes client operation fails or does not complete
+// in time.
+var ErrClient = errors.New("errClient")
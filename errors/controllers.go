@@ -10,3 +10,7 @@ var ErrInvalidData = errors.New("errInvalidData")
 
 // ErrReconcile is the error used for generic errors during reconciliation.
 var ErrReconcile = errors.New("errReconcile)")
+
+// ErrImageScan is the error returned when an image scanner can not (yet) tell if an image passes
+// the scan.
+var ErrImageScan = errors.New("errImageScan")
@@ -0,0 +1,180 @@
+// Package main is a tiny helper that compares two sets of go benchmark results and exits non-zero
+// if any benchmark got significantly slower (or allocates significantly more) than in the
+// baseline. It is intentionally dumb -- medians of repeated runs, no statistics beyond that -- so
+// use a healthy -count when producing the inputs.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultThreshold = 0.2
+	nsPerOp          = "ns/op"
+	allocsPerOp      = "allocs/op"
+)
+
+// benchmarkLinePattern matches the "name-procs iterations value unit [value unit...]" lines of go
+// test -bench output.
+var benchmarkLinePattern = regexp.MustCompile(`^(Benchmark\S+?)(?:-\d+)?\s+\d+\s+(.*)$`)
+
+// results holds all samples of each unit for each benchmark name.
+type results map[string]map[string][]float64
+
+func parse(r io.Reader) (results, error) {
+	parsed := results{}
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		match := benchmarkLinePattern.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+
+		fields := strings.Fields(match[2])
+
+		for idx := 0; idx+1 < len(fields); idx += 2 {
+			value, err := strconv.ParseFloat(fields[idx], 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed parsing value %q of %s: %w", fields[idx], match[1], err)
+			}
+
+			if parsed[match[1]] == nil {
+				parsed[match[1]] = map[string][]float64{}
+			}
+
+			parsed[match[1]][fields[idx+1]] = append(parsed[match[1]][fields[idx+1]], value)
+		}
+	}
+
+	return parsed, scanner.Err()
+}
+
+func parseFile(path string) (results, error) {
+	f, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return parse(f)
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+
+	slices.Sort(sorted)
+
+	middle := len(sorted) / 2
+
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+
+	return sorted[middle]
+}
+
+// compare returns a description of each benchmark/unit whose median in current exceeds the median
+// in baseline by more than threshold (a fraction, so 0.2 is 20%). Benchmarks only present in one of
+// the inputs are ignored.
+func compare(baseline, current results, threshold float64) []string {
+	var regressions []string
+
+	for name, currentUnits := range current {
+		baselineUnits, ok := baseline[name]
+		if !ok {
+			continue
+		}
+
+		for _, unit := range []string{nsPerOp, allocsPerOp} {
+			if len(baselineUnits[unit]) == 0 || len(currentUnits[unit]) == 0 {
+				continue
+			}
+
+			baselineMedian := median(baselineUnits[unit])
+			currentMedian := median(currentUnits[unit])
+
+			if baselineMedian == 0 {
+				continue
+			}
+
+			delta := (currentMedian - baselineMedian) / baselineMedian
+
+			if delta > threshold {
+				regressions = append(
+					regressions,
+					fmt.Sprintf(
+						"%s: %s %.0f -> %.0f (+%.1f%%)",
+						name,
+						unit,
+						baselineMedian,
+						currentMedian,
+						delta*100, //nolint:mnd
+					),
+				)
+			}
+		}
+	}
+
+	slices.Sort(regressions)
+
+	return regressions
+}
+
+func main() {
+	baselinePath := flag.String("baseline", "", "path to the baseline benchmark output")
+	currentPath := flag.String("current", "", "path to the current benchmark output")
+	threshold := flag.Float64(
+		"threshold",
+		defaultThreshold,
+		"allowed fractional increase of ns/op and allocs/op before failing",
+	)
+
+	flag.Parse()
+
+	if *baselinePath == "" || *currentPath == "" {
+		flag.Usage()
+
+		os.Exit(2) //nolint:mnd
+	}
+
+	baseline, err := parseFile(*baselinePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed reading baseline: %s\n", err)
+
+		os.Exit(1)
+	}
+
+	current, err := parseFile(*currentPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed reading current results: %s\n", err)
+
+		os.Exit(1)
+	}
+
+	regressions := compare(baseline, current, *threshold)
+	if len(regressions) > 0 {
+		fmt.Fprintf(
+			os.Stderr,
+			"benchmark regression(s) beyond %.0f%% threshold:\n  %s\n",
+			*threshold*100, //nolint:mnd
+			strings.Join(regressions, "\n  "),
+		)
+
+		os.Exit(1)
+	}
+
+	fmt.Printf("no benchmark regressions beyond %.0f%% threshold\n", *threshold*100) //nolint:mnd
+}
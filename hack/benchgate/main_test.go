@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const baselineOutput = `goos: linux
+goarch: amd64
+pkg: github.com/srl-labs/clabernetes/controllers/topology
+BenchmarkDeploymentRenderAll-8   	     300	   4000000 ns/op	 2800000 B/op	   24800 allocs/op
+BenchmarkDeploymentRenderAll-8   	     300	   4200000 ns/op	 2800000 B/op	   24800 allocs/op
+BenchmarkDeploymentRenderAll-8   	     300	   9000000 ns/op	 2800000 B/op	   24800 allocs/op
+BenchmarkConfigsHash-8           	     150	   7000000 ns/op	 1700000 B/op	     822 allocs/op
+PASS
+`
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		name     string
+		current  string
+		expected []string
+	}{
+		{
+			name: "no-regression",
+			current: `BenchmarkDeploymentRenderAll-8 300 4300000 ns/op 2800000 B/op 24800 allocs/op
+BenchmarkConfigsHash-8 150 6000000 ns/op 1700000 B/op 822 allocs/op`,
+			expected: nil,
+		},
+		{
+			name: "regressed",
+			current: `BenchmarkDeploymentRenderAll-8 300 6000000 ns/op 2800000 B/op 24800 allocs/op
+BenchmarkConfigsHash-8 150 7000000 ns/op 1700000 B/op 1000 allocs/op
+BenchmarkNew-8 150 7000000 ns/op 1700000 B/op 1000 allocs/op`,
+			expected: []string{
+				"BenchmarkConfigsHash: allocs/op 822 -> 1000 (+21.7%)",
+				"BenchmarkDeploymentRenderAll: ns/op 4200000 -> 6000000 (+42.9%)",
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				baseline, err := parse(strings.NewReader(baselineOutput))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				current, err := parse(strings.NewReader(testCase.current))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				actual := compare(baseline, current, defaultThreshold)
+
+				if strings.Join(actual, "\n") != strings.Join(testCase.expected, "\n") {
+					t.Fatalf("expected regressions %q, got %q", testCase.expected, actual)
+				}
+			})
+	}
+}
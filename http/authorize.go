@@ -0,0 +1,267 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	clabernetesapis "github.com/srl-labs/clabernetes/apis"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	k8sauthenticationv1 "k8s.io/api/authentication/v1"
+	k8sauthorizationv1 "k8s.io/api/authorization/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	topologiesResource = "topologies"
+
+	bearerTokenPrefix   = "Bearer "
+	authenticateRealm   = `Basic realm="clabernetes", charset="UTF-8"`
+	authenticateHeader  = "WWW-Authenticate"
+	authorizationHeader = "Authorization"
+)
+
+// TopologyAccessRequest holds the identity of a user attempting to reach a topology along with the
+// topology they are trying to reach.
+type TopologyAccessRequest struct {
+	// User is the (kubernetes) username of the requesting user.
+	User string
+	// UID is the (kubernetes) uid of the requesting user, if any.
+	UID string
+	// Groups is the list of groups the requesting user belongs to.
+	Groups []string
+	// Extra holds any additional information the authenticator provided about the requesting
+	// user, authorizers (webhooks in particular) may rely on it.
+	Extra map[string][]string
+	// Namespace is the namespace of the topology.
+	Namespace string
+	// Topology is the name of the topology.
+	Topology string
+}
+
+// AuthorizeTopologyAccess checks that the user in the given request is allowed to reach the given
+// topology. Rather than relying on namespace isolation alone, we ask the kube api (via
+// SubjectAccessReview) if the user can "get" the topology -- meaning folks need to be explicitly
+// granted access to a lab to be able to look at it.
+func (m *manager) AuthorizeTopologyAccess(
+	ctx context.Context,
+	req TopologyAccessRequest,
+) (bool, error) {
+	if req.User == "" {
+		return false, fmt.Errorf(
+			"%w: cannot authorize topology access without a user",
+			claberneteserrors.ErrInvalidData,
+		)
+	}
+
+	if m.namespaceScoped && req.Namespace != m.namespace {
+		// a namespace scoped manager does not manage (nor can it review access to) topologies in
+		// other namespaces
+		m.logger.Debugf(
+			"user %q denied access to topology %s/%s outside of manager namespace %q",
+			req.User,
+			req.Namespace,
+			req.Topology,
+			m.namespace,
+		)
+
+		return false, nil
+	}
+
+	var extra map[string]k8sauthorizationv1.ExtraValue
+
+	if len(req.Extra) > 0 {
+		extra = make(map[string]k8sauthorizationv1.ExtraValue, len(req.Extra))
+
+		for key, value := range req.Extra {
+			extra[key] = value
+		}
+	}
+
+	reviewStatus, err := m.reviewAccess(
+		ctx,
+		k8sauthorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &k8sauthorizationv1.ResourceAttributes{
+				Namespace: req.Namespace,
+				Verb:      "get",
+				Group:     clabernetesapis.Group,
+				Resource:  topologiesResource,
+				Name:      req.Topology,
+			},
+			User:   req.User,
+			UID:    req.UID,
+			Groups: req.Groups,
+			Extra:  extra,
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if !reviewStatus.Allowed {
+		m.logger.Debugf(
+			"user %q denied access to topology %s/%s, reason: %q",
+			req.User,
+			req.Namespace,
+			req.Topology,
+			reviewStatus.Reason,
+		)
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// reviewAccess submits the given access review spec to the kube api and returns the review status.
+// A namespace scoped manager can not create (cluster scoped) SubjectAccessReviews, so it creates
+// LocalSubjectAccessReviews in the namespace of the resource instead.
+func (m *manager) reviewAccess(
+	ctx context.Context,
+	spec k8sauthorizationv1.SubjectAccessReviewSpec,
+) (*k8sauthorizationv1.SubjectAccessReviewStatus, error) {
+	if !m.namespaceScoped {
+		review, err := m.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(
+			ctx,
+			&k8sauthorizationv1.SubjectAccessReview{
+				Spec: spec,
+			},
+			metav1.CreateOptions{},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &review.Status, nil
+	}
+
+	namespace := spec.ResourceAttributes.Namespace
+
+	review, err := m.kubeClient.AuthorizationV1().LocalSubjectAccessReviews(namespace).Create(
+		ctx,
+		&k8sauthorizationv1.LocalSubjectAccessReview{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+			},
+			Spec: spec,
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &review.Status, nil
+}
+
+// requestToken returns the kube api token of the given request -- either sent as bearer token or,
+// so browsers can prompt for it, as the password of basic auth credentials.
+func requestToken(r *http.Request) string {
+	if _, password, ok := r.BasicAuth(); ok {
+		return password
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get(authorizationHeader), bearerTokenPrefix)
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
+
+// authenticateToken returns the user the given kube api token belongs to. Rather than reviewing
+// tokens ourselves (TokenReviews are cluster scoped, so a namespace scoped manager could not) we
+// ask the kube api who we are with the token itself, which any authenticated user may do.
+func (m *manager) authenticateToken(
+	ctx context.Context,
+	token string,
+) (*k8sauthenticationv1.UserInfo, error) {
+	tokenConfig := rest.AnonymousClientConfig(m.kubeConfig)
+	tokenConfig.BearerToken = token
+
+	tokenClient, err := kubernetes.NewForConfig(tokenConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := tokenClient.AuthenticationV1().SelfSubjectReviews().Create(
+		ctx,
+		&k8sauthenticationv1.SelfSubjectReview{},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &review.Status.UserInfo, nil
+}
+
+// authorizeTopologyRequest authenticates the user of the given request and checks that they may
+// access the topology in the request path, writing the error response and returning false if
+// not.
+func (m *manager) authorizeTopologyRequest(w http.ResponseWriter, r *http.Request) bool {
+	token := requestToken(r)
+	if token == "" {
+		w.Header().Set(authenticateHeader, authenticateRealm)
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return false
+	}
+
+	userInfo, err := m.authenticateToken(r.Context(), token)
+	if err != nil {
+		if apimachineryerrors.IsUnauthorized(err) {
+			w.Header().Set(authenticateHeader, authenticateRealm)
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return false
+		}
+
+		m.logger.Warnf("failed authenticating request, error: %s", err)
+
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return false
+	}
+
+	var extra map[string][]string
+
+	if len(userInfo.Extra) > 0 {
+		extra = make(map[string][]string, len(userInfo.Extra))
+
+		for key, value := range userInfo.Extra {
+			extra[key] = value
+		}
+	}
+
+	allowed, err := m.AuthorizeTopologyAccess(
+		r.Context(),
+		TopologyAccessRequest{
+			User:      userInfo.Username,
+			UID:       userInfo.UID,
+			Groups:    userInfo.Groups,
+			Extra:     extra,
+			Namespace: r.PathValue("namespace"),
+			Topology:  r.PathValue("name"),
+		},
+	)
+	if err != nil {
+		m.logger.Warnf("failed authorizing request, error: %s", err)
+
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return false
+	}
+
+	if !allowed {
+		w.WriteHeader(http.StatusForbidden)
+
+		return false
+	}
+
+	return true
+}
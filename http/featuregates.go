@@ -0,0 +1,38 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+)
+
+const (
+	featureGatesRoute = "/featuregates"
+)
+
+// featureGatesHandler serves the state of all known feature gates (as json) so operators can see
+// which behaviors are enabled in the cluster.
+func (m *manager) featureGatesHandler(w http.ResponseWriter, r *http.Request) {
+	m.logRequest(r)
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	featureGates, err := json.Marshal(clabernetesconfig.GetManager().GetFeatureGates())
+	if err != nil {
+		m.logger.Warnf("failed marshaling feature gates, error: %s", err)
+
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write(featureGates)
+}
@@ -0,0 +1,310 @@
+package http
+
+import (
+	_ "embed" // for the graph page
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	graphRoute     = "GET /graph/{namespace}/{name}"
+	graphDataRoute = "GET /graph/{namespace}/{name}/data"
+
+	graphTopologyType      = "clab"
+	graphLinkDown          = "down"
+	graphLinkTypeDummy     = "dummy"
+	graphLinkEndpointCount = 2
+)
+
+// graphPage is the (self contained, but for the vis-network script) page rendering the graph
+// served on graphDataRoute, modeled on the containerlab "graph" command.
+//
+//go:embed assets/graph.html
+var graphPage []byte
+
+// GraphTopology is the topology graph served on the graph data endpoint, the nodes and links have
+// the same shape as the ones the containerlab "graph" command hands its templates.
+type GraphTopology struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Nodes []GraphNode `json:"nodes"`
+	Links []GraphLink `json:"links"`
+}
+
+// GraphNode is a node of a GraphTopology.
+type GraphNode struct {
+	Name        string            `json:"name"`
+	Kind        string            `json:"kind,omitempty"`
+	Image       string            `json:"image,omitempty"`
+	Group       string            `json:"group,omitempty"`
+	State       string            `json:"state,omitempty"`
+	IPv4Address string            `json:"ipv4_address,omitempty"`
+	IPv6Address string            `json:"ipv6_address,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+// GraphLink is a link of a GraphTopology.
+type GraphLink struct {
+	Source         string `json:"source"`
+	SourceEndpoint string `json:"source_endpoint"`
+	Target         string `json:"target"`
+	TargetEndpoint string `json:"target_endpoint"`
+	State          string `json:"state,omitempty"`
+}
+
+// graphHandler serves the graph page of a topology.
+func (m *manager) graphHandler(w http.ResponseWriter, r *http.Request) {
+	m.logRequest(r)
+
+	if _, ok := m.getGraphTopology(w, r); !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write(graphPage)
+}
+
+// graphDataHandler serves the graph (nodes, links and node state) of a topology as json.
+func (m *manager) graphDataHandler(w http.ResponseWriter, r *http.Request) {
+	m.logRequest(r)
+
+	topology, ok := m.getGraphTopology(w, r)
+	if !ok {
+		return
+	}
+
+	rawClabConfig, err := clabernetesutilcontainerlab.RenderInstanceOffset(
+		topology.Spec.Definition.Containerlab,
+		topology.Spec.Definition.InstanceOffset,
+	)
+	if err != nil {
+		m.logger.Warnf(
+			"failed rendering containerlab definition of topology %s/%s, error: %s",
+			topology.Namespace,
+			topology.Name,
+			err,
+		)
+
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	clabConfig, err := clabernetesutilcontainerlab.LoadContainerlabConfig(rawClabConfig)
+	if err != nil {
+		m.logger.Warnf(
+			"failed loading containerlab definition of topology %s/%s, error: %s",
+			topology.Namespace,
+			topology.Name,
+			err,
+		)
+
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	graphTopology, undrawnLinks := BuildGraphTopology(topology, clabConfig)
+	if len(undrawnLinks) > 0 {
+		m.logger.Warnf(
+			"topology %s/%s has links that can not be drawn, links: %q",
+			topology.Namespace,
+			topology.Name,
+			undrawnLinks,
+		)
+	}
+
+	graph, err := json.Marshal(graphTopology)
+	if err != nil {
+		m.logger.Warnf("failed marshaling topology graph, error: %s", err)
+
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write(graph)
+}
+
+// getGraphTopology fetches the topology of the request, writing the error response and returning
+// false if the user of the request may not see the topology, there is no such (containerlab)
+// topology or the topology does not enable its graph.
+func (m *manager) getGraphTopology(
+	w http.ResponseWriter,
+	r *http.Request,
+) (*clabernetesapisv1alpha1.Topology, bool) {
+	if !m.authorizeTopologyRequest(w, r) {
+		return nil, false
+	}
+
+	topology := &clabernetesapisv1alpha1.Topology{}
+
+	err := m.client.Get(
+		r.Context(),
+		apimachinerytypes.NamespacedName{
+			Namespace: r.PathValue("namespace"),
+			Name:      r.PathValue("name"),
+		},
+		topology,
+	)
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			w.WriteHeader(http.StatusNotFound)
+
+			return nil, false
+		}
+
+		m.logger.Warnf("failed fetching topology for graph, error: %s", err)
+
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return nil, false
+	}
+
+	// topologies that did not opt in look just like topologies that do not exist; kne topologies
+	// have no containerlab definition to draw
+	if !topology.Spec.Graph || topology.Spec.Definition.Containerlab == "" {
+		w.WriteHeader(http.StatusNotFound)
+
+		return nil, false
+	}
+
+	return topology, true
+}
+
+// BuildGraphTopology returns the graph of the given topology -- the nodes of its containerlab
+// definition with their readiness and exposed (load balancer) address, and its links. Link
+// endpoints that are not nodes of the topology ("host", "mgmt-net", "macvlan") are added as nodes
+// of their own, like containerlab does. Single ended (dummy) links have nothing to draw, any other
+// link that is not made of two "node:interface" endpoints is returned so the caller can let the
+// user know.
+func BuildGraphTopology(
+	topology *clabernetesapisv1alpha1.Topology,
+	clabConfig *clabernetesutilcontainerlab.Config,
+) (*GraphTopology, []string) {
+	graph := &GraphTopology{
+		Name:  topology.Name,
+		Type:  graphTopologyType,
+		Nodes: make([]GraphNode, 0),
+		Links: make([]GraphLink, 0),
+	}
+
+	if clabConfig.Topology == nil {
+		return graph, nil
+	}
+
+	if clabConfig.Topology.Defaults == nil {
+		clabConfig.Topology.Defaults = &clabernetesutilcontainerlab.NodeDefinition{}
+	}
+
+	nodeNames := make([]string, 0, len(clabConfig.Topology.Nodes))
+
+	for nodeName := range clabConfig.Topology.Nodes {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	slices.Sort(nodeNames)
+
+	for _, nodeName := range nodeNames {
+		nodeDefinition := clabConfig.Topology.Nodes[nodeName]
+		if nodeDefinition == nil {
+			nodeDefinition = &clabernetesutilcontainerlab.NodeDefinition{}
+		}
+
+		nodeKind, _ := clabConfig.Topology.GetNodeKindType(nodeName)
+
+		state := topology.Status.NodeReadiness[nodeName]
+		if state == "" {
+			state = clabernetesconstants.NodeStatusUnknown
+		}
+
+		node := GraphNode{
+			Name:   nodeName,
+			Kind:   nodeKind,
+			Image:  clabConfig.Topology.GetNodeImage(nodeName),
+			Group:  nodeDefinition.Group,
+			State:  state,
+			Labels: nodeDefinition.Labels,
+		}
+
+		exposedPorts := topology.Status.ExposedPorts[nodeName]
+		if exposedPorts != nil && exposedPorts.LoadBalancerAddress != "" {
+			if strings.Contains(exposedPorts.LoadBalancerAddress, ":") {
+				node.IPv6Address = exposedPorts.LoadBalancerAddress
+			} else {
+				node.IPv4Address = exposedPorts.LoadBalancerAddress
+			}
+		}
+
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	var undrawnLinks []string
+
+	for _, link := range clabConfig.Topology.Links {
+		if link == nil || strings.EqualFold(link.Type, graphLinkTypeDummy) {
+			continue
+		}
+
+		if len(link.Endpoints) != graphLinkEndpointCount {
+			undrawnLinks = append(undrawnLinks, strings.Join(link.Endpoints, ","))
+
+			continue
+		}
+
+		sourceNode, sourceInterface, sourceOk := strings.Cut(link.Endpoints[0], ":")
+		targetNode, targetInterface, targetOk := strings.Cut(link.Endpoints[1], ":")
+
+		if !sourceOk || !targetOk {
+			undrawnLinks = append(undrawnLinks, strings.Join(link.Endpoints, ","))
+
+			continue
+		}
+
+		edge := GraphLink{
+			Source:         sourceNode,
+			SourceEndpoint: sourceInterface,
+			Target:         targetNode,
+			TargetEndpoint: targetInterface,
+		}
+
+		if slices.Contains(topology.Spec.DisabledLinks, link.Endpoints[0]) ||
+			slices.Contains(topology.Spec.DisabledLinks, link.Endpoints[1]) {
+			edge.State = graphLinkDown
+		}
+
+		graph.Links = append(graph.Links, edge)
+
+		for _, endpointNode := range []string{sourceNode, targetNode} {
+			if _, ok := clabConfig.Topology.Nodes[endpointNode]; ok {
+				continue
+			}
+
+			if slices.ContainsFunc(graph.Nodes, func(node GraphNode) bool {
+				return node.Name == endpointNode
+			}) {
+				continue
+			}
+
+			graph.Nodes = append(graph.Nodes, GraphNode{
+				Name: endpointNode,
+				Kind: endpointNode,
+			})
+		}
+	}
+
+	return graph, undrawnLinks
+}
@@ -0,0 +1,209 @@
+package http_test
+
+import (
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteshttp "github.com/srl-labs/clabernetes/http"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildGraphTopology(t *testing.T) {
+	cases := []struct {
+		name                 string
+		containerlab         string
+		status               clabernetesapisv1alpha1.TopologyStatus
+		disabledLinks        []string
+		expectedGraph        *claberneteshttp.GraphTopology
+		expectedUndrawnLinks []string
+	}{
+		{
+			name: "simple",
+			containerlab: `---
+name: test
+topology:
+  defaults:
+    kind: srl
+  nodes:
+    srl1:
+      image: ghcr.io/nokia/srlinux
+      group: spine
+    srl2:
+      kind: linux
+      image: alpine
+  links:
+    - endpoints: ["srl1:e1-1", "srl2:eth1"]
+`,
+			status: clabernetesapisv1alpha1.TopologyStatus{
+				NodeReadiness: map[string]string{
+					"srl1": clabernetesconstants.NodeStatusReady,
+				},
+				ExposedPorts: map[string]*clabernetesapisv1alpha1.ExposedPorts{
+					"srl1": {LoadBalancerAddress: "10.0.0.1"},
+					"srl2": {LoadBalancerAddress: "2001:db8::1"},
+				},
+			},
+			expectedGraph: &claberneteshttp.GraphTopology{
+				Name: "graph-test",
+				Type: "clab",
+				Nodes: []claberneteshttp.GraphNode{
+					{
+						Name:        "srl1",
+						Kind:        "srl",
+						Image:       "ghcr.io/nokia/srlinux",
+						Group:       "spine",
+						State:       clabernetesconstants.NodeStatusReady,
+						IPv4Address: "10.0.0.1",
+					},
+					{
+						Name:        "srl2",
+						Kind:        "linux",
+						Image:       "alpine",
+						State:       clabernetesconstants.NodeStatusUnknown,
+						IPv6Address: "2001:db8::1",
+					},
+				},
+				Links: []claberneteshttp.GraphLink{
+					{
+						Source:         "srl1",
+						SourceEndpoint: "e1-1",
+						Target:         "srl2",
+						TargetEndpoint: "eth1",
+					},
+				},
+			},
+		},
+		{
+			name: "disabled-and-host-links",
+			containerlab: `---
+name: test
+topology:
+  nodes:
+    srl1:
+      kind: srl
+    srl2:
+      kind: srl
+  links:
+    - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+    - endpoints: ["srl1:e1-2", "host:srl1-e1-2"]
+`,
+			disabledLinks: []string{"srl2:e1-1"},
+			expectedGraph: &claberneteshttp.GraphTopology{
+				Name: "graph-test",
+				Type: "clab",
+				Nodes: []claberneteshttp.GraphNode{
+					{
+						Name:  "srl1",
+						Kind:  "srl",
+						State: clabernetesconstants.NodeStatusUnknown,
+					},
+					{
+						Name:  "srl2",
+						Kind:  "srl",
+						State: clabernetesconstants.NodeStatusUnknown,
+					},
+					{
+						Name: "host",
+						Kind: "host",
+					},
+				},
+				Links: []claberneteshttp.GraphLink{
+					{
+						Source:         "srl1",
+						SourceEndpoint: "e1-1",
+						Target:         "srl2",
+						TargetEndpoint: "e1-1",
+						State:          "down",
+					},
+					{
+						Source:         "srl1",
+						SourceEndpoint: "e1-2",
+						Target:         "host",
+						TargetEndpoint: "srl1-e1-2",
+					},
+				},
+			},
+		},
+		{
+			name: "dummy-and-malformed-links",
+			containerlab: `---
+name: test
+topology:
+  nodes:
+    srl1:
+      kind: srl
+    srl2:
+      kind: srl
+  links:
+    - type: dummy
+      endpoint:
+        node: srl1
+        interface: e1-3
+    - endpoints: ["srl1:e1-1", "srl2:e1-1", "srl2:e1-2"]
+    - endpoints: ["srl1:e1-2", "srl2"]
+`,
+			expectedGraph: &claberneteshttp.GraphTopology{
+				Name: "graph-test",
+				Type: "clab",
+				Nodes: []claberneteshttp.GraphNode{
+					{
+						Name:  "srl1",
+						Kind:  "srl",
+						State: clabernetesconstants.NodeStatusUnknown,
+					},
+					{
+						Name:  "srl2",
+						Kind:  "srl",
+						State: clabernetesconstants.NodeStatusUnknown,
+					},
+				},
+				Links: []claberneteshttp.GraphLink{},
+			},
+			expectedUndrawnLinks: []string{
+				"srl1:e1-1,srl2:e1-1,srl2:e1-2",
+				"srl1:e1-2,srl2",
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				clabConfig, err := clabernetesutilcontainerlab.LoadContainerlabConfig(
+					testCase.containerlab,
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				topology := &clabernetesapisv1alpha1.Topology{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "graph-test",
+						Namespace: "clabernetes",
+					},
+					Spec: clabernetesapisv1alpha1.TopologySpec{
+						DisabledLinks: testCase.disabledLinks,
+					},
+					Status: testCase.status,
+				}
+
+				actualGraph, actualUndrawnLinks := claberneteshttp.BuildGraphTopology(
+					topology,
+					clabConfig,
+				)
+
+				clabernetestesthelper.MarshaledEqual(t, actualGraph, testCase.expectedGraph)
+				clabernetestesthelper.MarshaledEqual(
+					t,
+					actualUndrawnLinks,
+					testCase.expectedUndrawnLinks,
+				)
+			})
+	}
+}
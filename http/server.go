@@ -14,6 +14,7 @@ import (
 	clabernetesmanagertypes "github.com/srl-labs/clabernetes/manager/types"
 	clabernetesutil "github.com/srl-labs/clabernetes/util"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -45,12 +46,15 @@ func InitManager(
 		)
 
 		m := &manager{
-			ctx:           c.GetContext(),
-			ctxCancel:     c.GetContextCancel(),
-			logger:        logger,
-			managerReadyF: c.IsReady,
-			client:        c.GetCtrlRuntimeClient(),
-			kubeClient:    c.GetKubeClient(),
+			ctx:             c.GetContext(),
+			ctxCancel:       c.GetContextCancel(),
+			logger:          logger,
+			managerReadyF:   c.IsReady,
+			client:          c.GetCtrlRuntimeClient(),
+			kubeConfig:      c.GetKubeConfig(),
+			kubeClient:      c.GetKubeClient(),
+			namespace:       c.GetNamespace(),
+			namespaceScoped: c.IsNamespaceScoped(),
 		}
 
 		managerInstance = m
@@ -75,6 +79,9 @@ type Manager interface {
 	Start()
 	// Stop stops the http server by calling the http.Server.Shutdown() method.
 	Stop() error
+	// AuthorizeTopologyAccess returns true if the user in the given request is allowed to access
+	// the given topology.
+	AuthorizeTopologyAccess(ctx context.Context, req TopologyAccessRequest) (bool, error)
 }
 
 type manager struct {
@@ -84,9 +91,13 @@ type manager struct {
 	managerReadyF func() bool
 	returnedReady bool
 	client        ctrlruntimeclient.Client
+	kubeConfig    *rest.Config
 	kubeClient    *kubernetes.Clientset
 	server        *http.Server
 	stopping      bool
+	namespace     string
+	// namespaceScoped is true when the manager only has (Role) permissions in its own namespace
+	namespaceScoped bool
 }
 
 func (m *manager) Start() {
@@ -97,6 +108,18 @@ func (m *manager) Start() {
 		aliveRoute,
 		m.aliveHandler,
 	)
+	mux.HandleFunc(
+		featureGatesRoute,
+		m.featureGatesHandler,
+	)
+	mux.HandleFunc(
+		graphRoute,
+		m.graphHandler,
+	)
+	mux.HandleFunc(
+		graphDataRoute,
+		m.graphDataHandler,
+	)
 
 	m.server = &http.Server{
 		BaseContext: func(_ net.Listener) context.Context {
@@ -7,7 +7,9 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
@@ -87,6 +89,7 @@ func newClabernetes() *clabernetes {
 		nodeLogger:           nodeLogger,
 		imageName:            os.Getenv(clabernetesconstants.LauncherNodeImageEnv),
 		imagePullThroughMode: os.Getenv(clabernetesconstants.LauncherImagePullThroughModeEnv),
+		topologyFile:         defaultTopologyFile,
 	}
 
 	return clabernetesInstance
@@ -110,12 +113,35 @@ type clabernetes struct {
 	imageName            string
 	imagePullThroughMode string
 
+	// topologyFile is the containerlab topology file we deploy -- normally this is the topology
+	// file mounted from the configmap, but may be a modified copy, for example when the launcher
+	// handles port exposure itself rather than containerlab
+	topologyFile string
+	// exposedPorts are the ports the launcher exposes via nftables when port exposure mode is
+	// nftables
+	exposedPorts []*exposedPort
+
+	heartbeatProgress heartbeatProgress
+
 	// containerIDs holds *all* ids of containers running --in theory we could have other side-car
 	// type stuff running so just catching all them here so we know if/when things fail
 	containerIDs []string
 	// meanwhile nodeContainerID is the container id of hte specific node this launcher represents
 	// -- meaning the single node from the original topology this launcher is representing
 	nodeContainerID string
+
+	// watchdogRestart holds the (json encoded timeline event of the) last in place restart the
+	// watchdog did, it is published on the heartbeat lease so the controller can surface it
+	watchdogRestart atomic.Pointer[string]
+
+	// diskUsage holds the last checked used percent of the docker data volume, it is published on
+	// the heartbeat lease so the controller can surface it
+	diskUsage atomic.Pointer[string]
+
+	// versions holds the (json encoded) versions of the launcher, containerlab, docker and kernel
+	// the launcher runs its node with, it is published on the heartbeat lease so the controller
+	// can surface them
+	versions atomic.Pointer[string]
 }
 
 func (c *clabernetes) startup() {
@@ -123,17 +149,31 @@ func (c *clabernetes) startup() {
 
 	c.logger.Debugf("clabernetes version %s", clabernetesconstants.Version)
 
+	go c.heartbeat()
+
+	c.cleanupStaleLinks()
+
 	c.containerlabVersion()
 	c.setup()
+	c.recordVersions()
+	c.applyStaticRoutes()
 
-	if os.Getenv(clabernetesconstants.LauncherNativeModeEnv) != clabernetesconstants.True {
+	switch {
+	case os.Getenv(clabernetesconstants.LauncherNativeModeEnv) == clabernetesconstants.True:
+		c.logger.Info("native mode enabled, skipping docker image loading and container launch")
+	case resolveNodeKind(c.nodeName) == clabernetesconstants.BridgeKind:
+		c.launchSegment()
+	default:
 		c.image()
+		c.preparePortExposure()
 		c.launch()
 
 		go c.imageCleanup()
-		go c.watchContainers()
-	} else {
-		c.logger.Info("native mode enabled, skipping docker image loading and container launch")
+		go c.watchdog()
+		go c.monitorDiskUsage()
+		go c.runPortExposure()
+		go c.runConfigPush()
+		go c.runStartupConfigReapply()
 	}
 
 	// In native mode, some NOS containers mutate routes in the shared pod netns.
@@ -150,6 +190,8 @@ func (c *clabernetes) startup() {
 
 	c.connectivity()
 
+	go c.runClockSync()
+
 	if os.Getenv(clabernetesconstants.LauncherNativeModeEnv) == clabernetesconstants.True &&
 		os.Getenv(clabernetesconstants.LauncherConnectivityKind) == clabernetesconstants.ConnectivityMultus {
 		c.renameInterfaces()
@@ -184,7 +226,11 @@ func (c *clabernetes) setupOnly() {
 	// routes on the shared pod netns, breaking access to the cluster Service CIDR.
 	err := c.cacheNodeTunnels()
 	if err != nil {
-		c.logger.Fatalf("failed caching tunnels content, err: %s", err)
+		c.fatalf(
+			clabernetesconstants.TerminationReasonTunnelFailed,
+			"failed caching tunnels content, err: %s",
+			err,
+		)
 	}
 
 	c.logger.Info("clabernetes setup complete")
@@ -197,12 +243,20 @@ func (c *clabernetes) renameInterfaces() {
 
 	rawConfig, err := os.ReadFile("/clabernetes/topo.clab.yaml")
 	if err != nil {
-		c.logger.Fatalf("failed reading topo.clab.yaml, err: %s", err)
+		c.fatalf(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			"failed reading topo.clab.yaml, err: %s",
+			err,
+		)
 	}
 
 	config, err := clabernetesutilcontainerlab.LoadContainerlabConfig(string(rawConfig))
 	if err != nil {
-		c.logger.Fatalf("failed loading containerlab config, err: %s", err)
+		c.fatalf(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			"failed loading containerlab config, err: %s",
+			err,
+		)
 	}
 
 	for idx, link := range config.Topology.Links {
@@ -269,13 +323,21 @@ func (c *clabernetes) containerlabVersion() {
 
 	err := c.installContainerlabVersion(requestedVersion)
 	if err != nil {
-		c.logger.Fatalf("failed installing requested containerlab version, err: %s", err)
+		c.fatalf(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			"failed installing requested containerlab version, err: %s",
+			err,
+		)
 	}
 
 	c.logger.Debug("requested containerlab version installed successfully")
 }
 
 func (c *clabernetes) setup() {
+	c.logger.Debug("running host preflight checks...")
+
+	c.hostPreflight()
+
 	c.logger.Debug("handling mounts...")
 
 	if !strings.EqualFold(
@@ -295,7 +357,11 @@ func (c *clabernetes) setup() {
 
 			err := handleDockerDaemonConfig()
 			if err != nil {
-				c.logger.Fatalf("failed configuring docker daemon, err: %s", err)
+				c.fatalf(
+					clabernetesconstants.TerminationReasonLauncherFailed,
+					"failed configuring docker daemon, err: %s",
+					err,
+				)
 			}
 		}
 
@@ -310,12 +376,20 @@ func (c *clabernetes) setup() {
 			// see https://github.com/srl-labs/clabernetes/issues/47
 			err = enableLegacyIPTables(c.ctx, c.logger)
 			if err != nil {
-				c.logger.Fatalf("failed enabling legacy ip tables, err: %s", err)
+				c.fatalf(
+					clabernetesconstants.TerminationReasonLauncherFailed,
+					"failed enabling legacy ip tables, err: %s",
+					err,
+				)
 			}
 
 			err = startDocker(c.ctx, c.logger)
 			if err != nil {
-				c.logger.Fatalf("failed ensuring docker is running, err: %s", err)
+				c.fatalf(
+					clabernetesconstants.TerminationReasonLauncherFailed,
+					"failed ensuring docker is running, err: %s",
+					err,
+				)
 			}
 
 			c.logger.Warn("docker started, but using legacy ip tables")
@@ -326,7 +400,11 @@ func (c *clabernetes) setup() {
 
 	err := c.getFilesFromURL()
 	if err != nil {
-		c.logger.Fatalf("failed getting file(s) from remote url, err: %s", err)
+		c.fatalf(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			"failed getting file(s) from remote url, err: %s",
+			err,
+		)
 	}
 
 	// NOTE: tunnel caching is performed in the init-container (setupOnly) so the launcher
@@ -343,7 +421,7 @@ func (c *clabernetes) launch() {
 				" will try to gather crashed container logs then will exit, err: %s", err,
 		)
 
-		c.reportContainerLaunchFail()
+		c.reportContainerLaunchFail(err)
 	}
 
 	c.containerIDs, err = getContainerIDs(c.ctx, false)
@@ -371,7 +449,12 @@ func (c *clabernetes) launch() {
 
 	c.nodeContainerID, err = getContainerIDForNodeName(c.ctx, c.nodeName)
 	if err != nil {
-		c.logger.Fatalf("failed determining node %q container id, err: %s", c.nodeName, err)
+		c.fatalf(
+			clabernetesconstants.TerminationReasonContainerlabDeployFailed,
+			"failed determining node %q container id, err: %s",
+			c.nodeName,
+			err,
+		)
 	}
 
 	c.logger.Debug("containerlab launched successfully")
@@ -380,6 +463,12 @@ func (c *clabernetes) launch() {
 func (c *clabernetes) runProbes() {
 	c.logger.Debug("starting status probe(s) if configured...")
 
+	if resolveNodeKind(c.nodeName) == clabernetesconstants.BridgeKind {
+		c.logger.Debug("node is a bridge, skipping status probes...")
+
+		return
+	}
+
 	tcpProbePort := clabernetesutil.GetEnvIntOrDefault(clabernetesconstants.LauncherTCPProbePort, 0)
 
 	sshProbePort := clabernetesutil.GetEnvIntOrDefault(
@@ -395,6 +484,10 @@ func (c *clabernetes) runProbes() {
 
 	var runSSHProbe bool
 
+	var runCLIProbe bool
+
+	var resolvedCLIProbe cliProbe
+
 	if tcpProbePort != 0 {
 		c.logger.Debugf("will run tcp status probe to port %d", tcpProbePort)
 
@@ -411,7 +504,27 @@ func (c *clabernetes) runProbes() {
 		runSSHProbe = true
 	}
 
-	if !runTCPProbe && !runSSHProbe {
+	if os.Getenv(clabernetesconstants.LauncherCLIProbeEnabled) == clabernetesconstants.True {
+		nodeKind := resolveNodeKind(c.nodeName)
+
+		resolvedCLIProbe, runCLIProbe = resolveCLIProbe(nodeKind)
+		if runCLIProbe {
+			c.logger.Debugf(
+				"will run cli status probe %q using username %s to port %d",
+				resolvedCLIProbe.command,
+				resolvedCLIProbe.username,
+				resolvedCLIProbe.port,
+			)
+		} else {
+			c.logger.Warnf(
+				"cli status probe configured but no default cli probe for kind %q and command"+
+					" and/or credentials not set, skipping cli status probe",
+				nodeKind,
+			)
+		}
+	}
+
+	if !runTCPProbe && !runSSHProbe && !runCLIProbe {
 		c.logger.Debug("no probes configured, skipping status probes...")
 
 		return
@@ -441,13 +554,17 @@ func (c *clabernetes) runProbes() {
 
 		tcpProbeOk := true
 		sshProbeOk := true
+		cliProbeOk := true
 
 		if runTCPProbe {
 			dialer := net.Dialer{
 				Timeout: statusProbeCheckTimeout,
 			}
 
-			tcpConn, err := dialer.Dial("tcp", fmt.Sprintf("%s:%d", nodeAddr, tcpProbePort))
+			tcpConn, err := dialer.Dial(
+				"tcp",
+				net.JoinHostPort(nodeAddr, strconv.Itoa(tcpProbePort)),
+			)
 			if err != nil {
 				tcpProbeOk = false
 			} else {
@@ -459,9 +576,13 @@ func (c *clabernetes) runProbes() {
 			sshProbeOk = probeSSH(sshProbePort, nodeAddr, sshProbeUsername, sshProbePassword)
 		}
 
+		if runCLIProbe {
+			cliProbeOk = probeCLI(resolvedCLIProbe, nodeAddr)
+		}
+
 		var writeErr error
 
-		if tcpProbeOk && sshProbeOk {
+		if tcpProbeOk && sshProbeOk && cliProbeOk {
 			writeErr = os.WriteFile(
 				clabernetesconstants.NodeStatusFile,
 				[]byte(clabernetesconstants.NodeStatusHealthy),
@@ -522,37 +643,9 @@ func probeSSH(port int, nodeAddr, username, password string) bool {
 	return true
 }
 
-func (c *clabernetes) watchContainers() {
-	if len(c.containerIDs) == 0 {
-		return
-	}
-
-	ticker := time.NewTicker(containerCheckInterval)
-
-	for range ticker.C {
-		currentContainerIDs, err := getContainerIDs(c.ctx, false)
-		if err != nil {
-			c.logger.Warnf(
-				"failed listing container ids, error: %s",
-				err,
-			)
-		}
-
-		if len(currentContainerIDs) != len(c.containerIDs) {
-			c.logger.Criticalf(
-				"expected %d running containers, but got %d, sending done signal",
-				len(c.containerIDs),
-				len(currentContainerIDs),
-			)
-
-			c.cancel()
-
-			return
-		}
-	}
-}
+func (c *clabernetes) reportContainerLaunchFail(deployErr error) {
+	writeContainerlabFailureTerminationMessage(deployErr)
 
-func (c *clabernetes) reportContainerLaunchFail() {
 	allContainerIDs, err := getContainerIDs(c.ctx, true)
 	if err != nil {
 		c.logger.Fatalf(
@@ -1,8 +1,12 @@
 package launcher
 
 import (
+	"fmt"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
 	clabernetesgeneratedclientset "github.com/srl-labs/clabernetes/generated/clientset"
 	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
 	"k8s.io/client-go/rest"
 )
 
@@ -11,11 +15,21 @@ func mustNewKubeClabernetesClient(
 ) *clabernetesgeneratedclientset.Clientset {
 	kubeConfig, err := rest.InClusterConfig()
 	if err != nil {
+		clabernetesutil.WriteTerminationMessage(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			fmt.Sprintf("failed getting in cluster kubeconfig, err: %s", err),
+		)
+
 		logger.Fatalf("failed getting in cluster kubeconfig, err: %s", err)
 	}
 
 	kubeClabernetesClient, err := clabernetesgeneratedclientset.NewForConfig(kubeConfig)
 	if err != nil {
+		clabernetesutil.WriteTerminationMessage(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			fmt.Sprintf("failed creating clabernetes kube client, err: %s", err),
+		)
+
 		logger.Fatalf(
 			"failed creating clabernetes kube client from in cluster kubeconfig, err: %s",
 			err,
@@ -0,0 +1,299 @@
+package launcher
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	"golang.org/x/crypto/ssh"
+)
+
+// cliProbeTimeout is the total time we give a cli probe (connect, auth, and command) -- some nodes
+// take their sweet time to run even trivial commands, but we need to be done before the next probe.
+const cliProbeTimeout = 20 * time.Second
+
+// cliProbe holds the (resolved) settings of a cli probe.
+type cliProbe struct {
+	command        string
+	expectedOutput string
+	username       string
+	password       string
+	port           int
+}
+
+// defaultCLIProbesByKind is a mapping of containerlab kind -> the default cli probe for that kind.
+// The credentials are the containerlab defaults for the kind, the commands are the "is the cli
+// actually up and working" type command for the kind.
+var defaultCLIProbesByKind = map[string]cliProbe{ //nolint:gochecknoglobals
+	"srl": {
+		command:        "show version",
+		expectedOutput: "Software Version",
+		username:       "admin",
+		password:       "NokiaSrl1!",
+	},
+	"nokia_srlinux": {
+		command:        "show version",
+		expectedOutput: "Software Version",
+		username:       "admin",
+		password:       "NokiaSrl1!",
+	},
+	"ceos": {
+		command:        "show version",
+		expectedOutput: "Software image version",
+		username:       "admin",
+		password:       "admin",
+	},
+	"arista_ceos": {
+		command:        "show version",
+		expectedOutput: "Software image version",
+		username:       "admin",
+		password:       "admin",
+	},
+	"vr-sros": {
+		command:        "show version",
+		expectedOutput: "TiMOS",
+		username:       "admin",
+		password:       "NokiaSros1!",
+	},
+	"nokia_sros": {
+		command:        "show version",
+		expectedOutput: "TiMOS",
+		username:       "admin",
+		password:       "NokiaSros1!",
+	},
+	"crpd": {
+		command:        "cli show version",
+		expectedOutput: "Junos",
+		username:       "root",
+		password:       "clab123",
+	},
+	"juniper_crpd": {
+		command:        "cli show version",
+		expectedOutput: "Junos",
+		username:       "root",
+		password:       "clab123",
+	},
+	"vr-vmx": {
+		command:        "show version",
+		expectedOutput: "Junos",
+		username:       "admin",
+		password:       "admin@123",
+	},
+	"juniper_vmx": {
+		command:        "show version",
+		expectedOutput: "Junos",
+		username:       "admin",
+		password:       "admin@123",
+	},
+	"juniper_vjunosrouter": {
+		command:        "show version",
+		expectedOutput: "Junos",
+		username:       "admin",
+		password:       "admin@123",
+	},
+	"juniper_vjunosswitch": {
+		command:        "show version",
+		expectedOutput: "Junos",
+		username:       "admin",
+		password:       "admin@123",
+	},
+	"xrd": {
+		command:        "show version",
+		expectedOutput: "Cisco IOS XR",
+		username:       "clab",
+		password:       "clab@123",
+	},
+	"cisco_xrd": {
+		command:        "show version",
+		expectedOutput: "Cisco IOS XR",
+		username:       "clab",
+		password:       "clab@123",
+	},
+	"vr-xrv9k": {
+		command:        "show version",
+		expectedOutput: "Cisco IOS XR",
+		username:       "clab",
+		password:       "clab@123",
+	},
+	"cisco_xrv9k": {
+		command:        "show version",
+		expectedOutput: "Cisco IOS XR",
+		username:       "clab",
+		password:       "clab@123",
+	},
+	"vr-csr": {
+		command:        "show version",
+		expectedOutput: "Cisco IOS",
+		username:       "admin",
+		password:       "admin",
+	},
+	"cisco_csr1000v": {
+		command:        "show version",
+		expectedOutput: "Cisco IOS",
+		username:       "admin",
+		password:       "admin",
+	},
+	"vr-n9kv": {
+		command:        "show version",
+		expectedOutput: "NX-OS",
+		username:       "admin",
+		password:       "admin",
+	},
+	"cisco_n9kv": {
+		command:        "show version",
+		expectedOutput: "NX-OS",
+		username:       "admin",
+		password:       "admin",
+	},
+	"vr-veos": {
+		command:        "show version",
+		expectedOutput: "Software image version",
+		username:       "admin",
+		password:       "admin",
+	},
+	"arista_veos": {
+		command:        "show version",
+		expectedOutput: "Software image version",
+		username:       "admin",
+		password:       "admin",
+	},
+	"sonic-vs": {
+		command:        "show version",
+		expectedOutput: "SONiC Software Version",
+		username:       "admin",
+		password:       "YourPaSsWoRd",
+	},
+	"vr-pan": {
+		command:        "show system info",
+		expectedOutput: "sw-version",
+		username:       "admin",
+		password:       "Admin@123",
+	},
+	"paloalto_panos": {
+		command:        "show system info",
+		expectedOutput: "sw-version",
+		username:       "admin",
+		password:       "Admin@123",
+	},
+	"fortinet_fortigate": {
+		command:        "get system status",
+		expectedOutput: "Version: Forti",
+		username:       "admin",
+		password:       "admin",
+	},
+	"vr-ros": {
+		command:        "/system resource print",
+		expectedOutput: "version",
+		username:       "admin",
+		password:       "admin",
+	},
+	"mikrotik_ros": {
+		command:        "/system resource print",
+		expectedOutput: "version",
+		username:       "admin",
+		password:       "admin",
+	},
+}
+
+// resolveCLIProbe returns the cli probe for the given node kind -- the kind defaults overridden by
+// whatever the topology set. The returned bool indicates if we ended up with a usable probe (that
+// is, we have a command and credentials).
+func resolveCLIProbe(nodeKind string) (cliProbe, bool) {
+	probe := defaultCLIProbesByKind[nodeKind]
+
+	for _, override := range []struct {
+		envName string
+		value   *string
+	}{
+		{envName: clabernetesconstants.LauncherCLIProbeCommand, value: &probe.command},
+		{
+			envName: clabernetesconstants.LauncherCLIProbeExpectedOutput,
+			value:   &probe.expectedOutput,
+		},
+		{envName: clabernetesconstants.LauncherCLIProbeUsername, value: &probe.username},
+		{envName: clabernetesconstants.LauncherCLIProbePassword, value: &probe.password},
+	} {
+		value := os.Getenv(override.envName)
+		if value != "" {
+			*override.value = value
+		}
+	}
+
+	probe.port = clabernetesutil.GetEnvIntOrDefault(
+		clabernetesconstants.LauncherCLIProbePort,
+		defaultSSHPort,
+	)
+
+	return probe, probe.command != "" && probe.username != "" && probe.password != ""
+}
+
+// probeCLI connects to the node via ssh and runs the probe command, returning true if the command
+// succeeded and its output contains the expected output (if any).
+func probeCLI(probe cliProbe, nodeAddr string) bool {
+	sshConfig := &ssh.ClientConfig{
+		User: probe.username,
+		Auth: []ssh.AuthMethod{
+			ssh.Password(probe.password),
+			ssh.KeyboardInteractive(
+				func(_, _ string, questions []string, _ []bool) ([]string, error) {
+					answers := make([]string, len(questions))
+					for i := range answers {
+						answers[i] = probe.password
+					}
+
+					return answers, nil
+				},
+			),
+		},
+		Timeout:         statusProbeCheckTimeout,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+	}
+
+	addr := net.JoinHostPort(nodeAddr, strconv.Itoa(probe.port))
+
+	netConn, err := net.DialTimeout("tcp", addr, statusProbeCheckTimeout)
+	if err != nil {
+		return false
+	}
+
+	defer func() {
+		_ = netConn.Close()
+	}()
+
+	// unlike the ssh probe we actually run something, so make sure a wedged cli cant hang us
+	err = netConn.SetDeadline(time.Now().Add(cliProbeTimeout))
+	if err != nil {
+		return false
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, addr, sshConfig)
+	if err != nil {
+		return false
+	}
+
+	conn := ssh.NewClient(sshConn, chans, reqs)
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	session, err := conn.NewSession()
+	if err != nil {
+		return false
+	}
+
+	defer func() {
+		_ = session.Close()
+	}()
+
+	output, err := session.CombinedOutput(probe.command)
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(string(output), probe.expectedOutput)
+}
@@ -0,0 +1,103 @@
+package launcher
+
+import (
+	"net"
+	"os"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+const (
+	ntpConfigRetryInterval = 30 * time.Second
+	ntpConfigMaxAttempts   = 20
+)
+
+// ntpConfigByKind is a mapping of containerlab kind -> func returning the configuration lines that
+// point a node of that kind at the given ntp server. The lines are pushed with the config push
+// dialect of the kind.
+var ntpConfigByKind = map[string]func(server string) []string{ //nolint:gochecknoglobals
+	"ceos":          ceosNTPConfig,
+	"arista_ceos":   ceosNTPConfig,
+	"srl":           srlNTPConfig,
+	"nokia_srlinux": srlNTPConfig,
+}
+
+func ceosNTPConfig(server string) []string {
+	return []string{"ntp server " + server}
+}
+
+func srlNTPConfig(server string) []string {
+	return []string{
+		"set / system ntp admin-state enable",
+		"set / system ntp network-instance mgmt",
+		"set / system ntp server " + server,
+	}
+}
+
+// runClockSync points the node at the topology ntp server (if the topology has one). Kinds we do
+// not know how to configure are left alone, the ntp server is reachable for them all the same.
+func (c *clabernetes) runClockSync() {
+	ntpServer := os.Getenv(clabernetesconstants.LauncherNTPServerEnv)
+	if ntpServer == "" {
+		return
+	}
+
+	nodeKind := resolveNodeKind(c.nodeName)
+
+	ntpConfig, ok := ntpConfigByKind[nodeKind]
+	if !ok {
+		c.logger.Infof(
+			"topology ntp server is %q, not configuring node of kind %q to use it",
+			ntpServer,
+			nodeKind,
+		)
+
+		return
+	}
+
+	dialect, ok := resolveConfigPushDialect(
+		nodeKind,
+		os.Getenv(clabernetesconstants.LauncherNodeImageEnv),
+	)
+	if !ok {
+		return
+	}
+
+	ticker := time.NewTicker(ntpConfigRetryInterval)
+	defer ticker.Stop()
+
+	// the node cli is not going to be ready right away, so we just keep trying for a while
+	for attempt := 1; attempt <= ntpConfigMaxAttempts; attempt++ {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// nodes do not necessarily use the cluster dns, so hand them the service address
+		addresses, err := net.DefaultResolver.LookupHost(c.ctx, ntpServer)
+		if err != nil || len(addresses) == 0 {
+			c.logger.Debugf("failed resolving topology ntp server %q, err: %s", ntpServer, err)
+
+			continue
+		}
+
+		err = c.pushConfig(dialect, ntpConfig(addresses[0]))
+		if err != nil {
+			c.logger.Debugf(
+				"failed configuring node to use topology ntp server (attempt %d), err: %s",
+				attempt,
+				err,
+			)
+
+			continue
+		}
+
+		c.logger.Infof("configured node to use topology ntp server %q", addresses[0])
+
+		return
+	}
+
+	c.logger.Warnf("giving up configuring node to use topology ntp server %q", ntpServer)
+}
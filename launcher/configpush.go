@@ -0,0 +1,432 @@
+package launcher
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+const (
+	configPushCheckInterval = 15 * time.Second
+	configPushTimeout       = 2 * time.Minute
+	configPushFRRKind       = "frr"
+)
+
+// configPushDialect describes how to push configuration to a node of a given kind -- the cli we
+// feed the configuration to, the lines wrapping the changed configuration, and how to negate
+// removed lines.
+type configPushDialect struct {
+	command   []string
+	preamble  []string
+	postamble []string
+	// flat dialects have no hierarchy, each line is a complete statement (srl "set" style configs)
+	flat bool
+}
+
+// configPushDialectsByKind is a mapping of containerlab kind -> the config push dialect for that
+// kind. Only kinds whose cli merges configuration (rather than replacing it) are listed here.
+var configPushDialectsByKind = map[string]configPushDialect{ //nolint:gochecknoglobals
+	"ceos": {
+		command:   []string{"Cli", "-p", "15"},
+		preamble:  []string{"configure"},
+		postamble: []string{"end", "write memory"},
+	},
+	"arista_ceos": {
+		command:   []string{"Cli", "-p", "15"},
+		preamble:  []string{"configure"},
+		postamble: []string{"end", "write memory"},
+	},
+	"srl": {
+		command:   []string{"sr_cli"},
+		preamble:  []string{"enter candidate"},
+		postamble: []string{"commit save"},
+		flat:      true,
+	},
+	"nokia_srlinux": {
+		command:   []string{"sr_cli"},
+		preamble:  []string{"enter candidate"},
+		postamble: []string{"commit save"},
+		flat:      true,
+	},
+	configPushFRRKind: {
+		command:   []string{"vtysh"},
+		preamble:  []string{"configure terminal"},
+		postamble: []string{"end", "write memory"},
+	},
+}
+
+// configBlock is a top level configuration line and the (indented) lines below it.
+type configBlock struct {
+	header   string
+	children []string
+}
+
+// resolveConfigPushDialect returns the config push dialect for the given node -- frr is not a
+// containerlab kind, frr nodes are "linux" nodes running an frr image.
+func resolveConfigPushDialect(nodeKind, nodeImage string) (configPushDialect, bool) {
+	if nodeKind == "linux" && strings.Contains(strings.ToLower(nodeImage), configPushFRRKind) {
+		nodeKind = configPushFRRKind
+	}
+
+	dialect, ok := configPushDialectsByKind[nodeKind]
+
+	return dialect, ok
+}
+
+// runConfigPush watches the (live updated) startup-config of our node and pushes the changed lines
+// to the node whenever it changes. The startup-config the node booted with is the baseline, after
+// a successful push the pushed startup-config becomes the new baseline.
+func (c *clabernetes) runConfigPush() {
+	configPushFile := os.Getenv(clabernetesconstants.LauncherConfigPushFileEnv)
+	if configPushFile == "" {
+		return
+	}
+
+	dialect, ok := resolveConfigPushDialect(
+		resolveNodeKind(c.nodeName),
+		os.Getenv(clabernetesconstants.LauncherNodeImageEnv),
+	)
+	if !ok {
+		c.logger.Warnf(
+			"differential config push enabled but kind of node %q does not support merging"+
+				" configuration, not watching startup-config",
+			c.nodeName,
+		)
+
+		return
+	}
+
+	previousConfig, err := os.ReadFile(configPushFile)
+	if err != nil {
+		c.logger.Warnf(
+			"failed reading startup-config %q, not watching startup-config, err: %s",
+			configPushFile,
+			err,
+		)
+
+		return
+	}
+
+	if dialect.flat && bytes.HasPrefix(bytes.TrimSpace(previousConfig), []byte("{")) {
+		c.logger.Warn(
+			"startup-config is json, differential config push only supports cli (\"set\" style)" +
+				" configs, not watching startup-config",
+		)
+
+		return
+	}
+
+	c.logger.Infof("watching startup-config %q for changes to push to node", configPushFile)
+
+	c.watchConfigChanges(
+		previousConfig,
+		dialect,
+		func() ([]byte, error) {
+			return os.ReadFile(configPushFile)
+		},
+		c.pushConfig,
+	)
+}
+
+// watchConfigChanges reads the startup-config of our node via readConfig every
+// configPushCheckInterval and pushes the changed lines to the node via push whenever it changes.
+// The given previous config is the baseline, after a successful push the pushed startup-config
+// becomes the new baseline.
+func (c *clabernetes) watchConfigChanges(
+	previousConfig []byte,
+	dialect configPushDialect,
+	readConfig func() ([]byte, error),
+	push func(dialect configPushDialect, delta []string) error,
+) {
+	ticker := time.NewTicker(configPushCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		currentConfig, err := readConfig()
+		if err != nil {
+			c.logger.Warnf("failed reading startup-config, err: %s", err)
+
+			continue
+		}
+
+		if bytes.Equal(currentConfig, previousConfig) {
+			continue
+		}
+
+		delta := configDelta(string(previousConfig), string(currentConfig), dialect.flat)
+		if len(delta) == 0 {
+			// whitespace/comment only change, nothing to push
+			previousConfig = currentConfig
+
+			continue
+		}
+
+		c.logger.Infof("startup-config changed, pushing %d changed line(s) to node", len(delta))
+
+		err = push(dialect, delta)
+		if err != nil {
+			// keep the old baseline so we try again (with the then current delta) next time
+			c.logger.Warnf("failed pushing startup-config changes to node, err: %s", err)
+
+			continue
+		}
+
+		previousConfig = currentConfig
+	}
+}
+
+// nodeExecArgs returns the command (and its args) that runs the given command in the node
+// container -- via docker exec in docker mode, and by entering the namespaces of the node process
+// (the pod shares its process namespace) in native mode.
+func (c *clabernetes) nodeExecArgs(command []string) ([]string, error) {
+	if os.Getenv(clabernetesconstants.LauncherNativeModeEnv) == clabernetesconstants.True {
+		nodePID, err := findNativeNodePID()
+		if err != nil {
+			return nil, err
+		}
+
+		args := []string{
+			"nsenter", "-t", strconv.Itoa(nodePID), "-m", "-u", "-i", "-n", "-p", "--",
+		}
+
+		return append(args, command...), nil
+	}
+
+	if c.nodeContainerID == "" {
+		return nil, fmt.Errorf("%w: node container id unknown", claberneteserrors.ErrLaunch)
+	}
+
+	return append([]string{"docker", "exec", "-i", c.nodeContainerID}, command...), nil
+}
+
+// findNativeNodePID returns the (lowest) pid of a process that lives in neither our mount
+// namespace nor in the one of the pod sandbox (pid 1) -- in a native mode pod that is the node.
+func findNativeNodePID() (int, error) {
+	ownMountNamespace, err := os.Readlink("/proc/self/ns/mnt")
+	if err != nil {
+		return 0, err
+	}
+
+	sandboxMountNamespace, _ := os.Readlink("/proc/1/ns/mnt")
+
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0, err
+	}
+
+	nodePID := 0
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || pid == 1 {
+			continue
+		}
+
+		mountNamespace, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/mnt", pid))
+		if err != nil ||
+			mountNamespace == ownMountNamespace ||
+			mountNamespace == sandboxMountNamespace {
+			continue
+		}
+
+		if nodePID == 0 || pid < nodePID {
+			nodePID = pid
+		}
+	}
+
+	if nodePID == 0 {
+		return 0, fmt.Errorf(
+			"%w: no node process found in the pod process namespace",
+			claberneteserrors.ErrLaunch,
+		)
+	}
+
+	return nodePID, nil
+}
+
+// configPushScript returns the lines to feed to the cli of the node to push the given
+// configuration lines -- the lines wrapped in the preamble and postamble of the dialect.
+func (c *clabernetes) configPushScript(dialect configPushDialect, delta []string) []string {
+	script := make([]string, 0, len(dialect.preamble)+len(delta)+len(dialect.postamble))
+
+	script = append(script, dialect.preamble...)
+	script = append(script, delta...)
+	script = append(script, dialect.postamble...)
+
+	for _, line := range delta {
+		c.logger.Debugf("pushing config line %q", line)
+	}
+
+	return script
+}
+
+// pushConfig feeds the given configuration lines to the cli of the node container.
+func (c *clabernetes) pushConfig(dialect configPushDialect, delta []string) error {
+	execArgs, err := c.nodeExecArgs(dialect.command)
+	if err != nil {
+		return err
+	}
+
+	script := c.configPushScript(dialect, delta)
+
+	ctx, cancel := context.WithTimeout(c.ctx, configPushTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, execArgs[0], execArgs[1:]...) //nolint:gosec
+
+	cmd.Stdin = strings.NewReader(strings.Join(script, "\n") + "\n")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf(
+			"%w: node cli exited with error, err: %w, output: %s",
+			claberneteserrors.ErrLaunch,
+			err,
+			output,
+		)
+	}
+
+	c.logger.Debugf("config push output: %s", output)
+
+	return nil
+}
+
+// configDelta returns the configuration lines to apply on top of the previous configuration to end
+// up with the current configuration. For hierarchical configs the delta is computed per top level
+// block: new blocks are sent as is, changed blocks are sent as their header followed by the removed
+// (negated) and added lines, and removed blocks are negated. For flat configs each line is its own
+// block.
+func configDelta(previous, current string, flat bool) []string {
+	previousBlocks := parseConfigBlocks(previous, flat)
+	currentBlocks := parseConfigBlocks(current, flat)
+
+	previousByHeader := make(map[string]configBlock, len(previousBlocks))
+	for _, block := range previousBlocks {
+		previousByHeader[block.header] = block
+	}
+
+	currentByHeader := make(map[string]configBlock, len(currentBlocks))
+	for _, block := range currentBlocks {
+		currentByHeader[block.header] = block
+	}
+
+	var delta []string
+
+	// removals first, otherwise (flat) replacement values would be deleted right after being set
+	for _, block := range previousBlocks {
+		_, ok := currentByHeader[block.header]
+		if ok {
+			continue
+		}
+
+		negated := negateConfigLine(block.header, flat)
+		if negated != "" {
+			delta = append(delta, negated)
+		}
+	}
+
+	for _, block := range currentBlocks {
+		previousBlock, ok := previousByHeader[block.header]
+		if !ok {
+			delta = append(delta, block.header)
+
+			if len(block.children) > 0 {
+				delta = append(delta, block.children...)
+				delta = append(delta, "exit")
+			}
+
+			continue
+		}
+
+		var changed []string
+
+		for _, child := range previousBlock.children {
+			if !slices.Contains(block.children, child) {
+				changed = append(changed, negateConfigLine(child, flat))
+			}
+		}
+
+		for _, child := range block.children {
+			if !slices.Contains(previousBlock.children, child) {
+				changed = append(changed, child)
+			}
+		}
+
+		if len(changed) == 0 {
+			continue
+		}
+
+		delta = append(delta, block.header)
+		delta = append(delta, changed...)
+		// leave the block again so the next header is not parsed in the context of this one
+		delta = append(delta, "exit")
+	}
+
+	return delta
+}
+
+// parseConfigBlocks splits the given configuration into its top level blocks, skipping blank and
+// comment lines.
+func parseConfigBlocks(config string, flat bool) []configBlock {
+	var blocks []configBlock
+
+	for _, line := range strings.Split(config, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" ||
+			strings.HasPrefix(trimmed, "!") ||
+			strings.HasPrefix(trimmed, "#") ||
+			trimmed == "end" {
+			continue
+		}
+
+		indented := line != strings.TrimLeft(line, " \t")
+
+		if flat || !indented || len(blocks) == 0 {
+			blocks = append(blocks, configBlock{header: trimmed})
+
+			continue
+		}
+
+		blocks[len(blocks)-1].children = append(blocks[len(blocks)-1].children, trimmed)
+	}
+
+	return blocks
+}
+
+// negateConfigLine returns the line that removes the given configuration line -- "no <line>" (or
+// the line without its "no " prefix) for hierarchical configs, and "delete <path>" for flat "set"
+// style configs where the path is the line without its "set" keyword and (leaf) value.
+func negateConfigLine(line string, flat bool) string {
+	if !flat {
+		negated, ok := strings.CutPrefix(line, "no ")
+		if ok {
+			return negated
+		}
+
+		return "no " + line
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) < 3 || fields[0] != "set" {
+		return ""
+	}
+
+	return "delete " + strings.Join(fields[1:len(fields)-1], " ")
+}
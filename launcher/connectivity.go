@@ -21,7 +21,11 @@ import (
 func (c *clabernetes) connectivity() {
 	tunnels, err := c.getTunnels()
 	if err != nil {
-		c.logger.Fatalf("failed loading tunnels content, err: %s", err)
+		c.fatalf(
+			clabernetesconstants.TerminationReasonTunnelFailed,
+			"failed loading tunnels content, err: %s",
+			err,
+		)
 	}
 
 	connectivityManager, err := claberneteslauncherconnectivity.NewManager(
@@ -33,19 +37,35 @@ func (c *clabernetes) connectivity() {
 		os.Getenv(
 			clabernetesconstants.LauncherConnectivityKind,
 		),
+		linkConnectivityKinds(),
 	)
 	if err != nil {
-		c.logger.Fatalf("failed creating connectivity manager, err: %s", err)
+		c.fatalf(
+			clabernetesconstants.TerminationReasonTunnelFailed,
+			"failed creating connectivity manager, err: %s",
+			err,
+		)
 	}
 
 	connectivityManager.Run()
 }
 
+// linkConnectivityKinds returns the connectivity flavors the links of the topology use on top of
+// the connectivity flavor of the topology itself, if any.
+func linkConnectivityKinds() []string {
+	kinds := os.Getenv(clabernetesconstants.LauncherLinkConnectivityKindsEnv)
+	if kinds == "" {
+		return nil
+	}
+
+	return strings.Split(kinds, ",")
+}
+
 func (c *clabernetes) getTunnels() ([]*clabernetesapisv1alpha1.PointToPointTunnel, error) {
 	// Prefer cached tunnels file if present (written by the init-container setup step).
 	// This avoids relying on in-pod access to the Kubernetes API at runtime, which can be
 	// disrupted by NOS containers in native mode (they may mutate routes on the shared pod netns).
-	if tunnels, ok, err := c.getTunnelsFromFile(); err != nil {
+	if tunnels, ok, err := readTunnelsFile(); err != nil {
 		return nil, err
 	} else if ok {
 		return tunnels, nil
@@ -80,7 +100,7 @@ func (c *clabernetes) getTunnels() ([]*clabernetesapisv1alpha1.PointToPointTunne
 	return nodeTunnels, nil
 }
 
-func (c *clabernetes) getTunnelsFromFile() ([]*clabernetesapisv1alpha1.PointToPointTunnel, bool, error) {
+func readTunnelsFile() ([]*clabernetesapisv1alpha1.PointToPointTunnel, bool, error) {
 	p := os.Getenv(clabernetesconstants.LauncherTunnelsFileEnv)
 	if p == "" {
 		// Default to a path on the shared docker/EmptyDir volume.
@@ -0,0 +1,178 @@
+package connectivity
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+// packetCaptureTracker holds the packet captures currently running on this launcher -- all
+// managers share the tracker via common.
+type packetCaptureTracker struct {
+	lock     sync.Mutex
+	captures map[string]*runningPacketCapture
+}
+
+type runningPacketCapture struct {
+	capture clabernetesapisv1alpha1.PacketCapture
+	cancel  context.CancelFunc
+}
+
+// packetCaptureArgs returns the tcpdump arguments for capturing on the given link into the given
+// directory with the given capture settings.
+func packetCaptureArgs(
+	hostLink,
+	captureDir string,
+	capture clabernetesapisv1alpha1.PacketCapture,
+) []string {
+	fileName := fmt.Sprintf("%s.pcap", hostLink)
+
+	// -Z root because tcpdump otherwise drops privileges to a user that likely cant write to the
+	// capture directory (especially if that is a pvc)
+	args := []string{"-i", hostLink, "-n", "-U", "-Z", "root"}
+
+	if capture.RotateSeconds > 0 {
+		args = append(args, "-G", strconv.Itoa(capture.RotateSeconds))
+
+		// time based rotation needs a strftime file name, otherwise tcpdump just overwrites the
+		// same file every time it rotates
+		fileName = fmt.Sprintf("%s-%%Y%%m%%d-%%H%%M%%S.pcap", hostLink)
+	}
+
+	if capture.RotateMegabytes > 0 {
+		args = append(args, "-C", strconv.Itoa(capture.RotateMegabytes))
+
+		// only with size based rotation -- combined with *only* time based rotation, -W makes
+		// tcpdump exit after that many files rather than cycling through them
+		if capture.MaxFiles > 0 {
+			args = append(args, "-W", strconv.Itoa(capture.MaxFiles))
+		}
+	}
+
+	args = append(args, "-w", filepath.Join(captureDir, fileName))
+
+	if capture.Filter != "" {
+		args = append(args, strings.Fields(capture.Filter)...)
+	}
+
+	return args
+}
+
+// withPacketCaptures wraps the given tunnel update func so that the packet captures are updated
+// after every tunnel update.
+func (c *common) withPacketCaptures(
+	handleUpdate func(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel),
+) func(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) {
+	return func(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) {
+		handleUpdate(tunnels)
+
+		c.updatePacketCaptures(tunnels)
+	}
+}
+
+// updatePacketCaptures starts, restarts or stops packet captures such that exactly the captures of
+// the given tunnels are running. Failing to start a capture is logged but otherwise ignored, a
+// capture is a debugging aid, not worth crashing the launcher over.
+func (c *common) updatePacketCaptures(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) {
+	c.packetCaptures.lock.Lock()
+	defer c.packetCaptures.lock.Unlock()
+
+	if c.packetCaptures.captures == nil {
+		c.packetCaptures.captures = map[string]*runningPacketCapture{}
+	}
+
+	desiredTunnels := map[string]*clabernetesapisv1alpha1.PointToPointTunnel{}
+
+	for _, tunnel := range tunnels {
+		if tunnel.Capture != nil {
+			desiredTunnels[tunnelKey(tunnel)] = tunnel
+		}
+	}
+
+	for key, running := range c.packetCaptures.captures {
+		desiredTunnel, ok := desiredTunnels[key]
+		if ok && *desiredTunnel.Capture == running.capture {
+			delete(desiredTunnels, key)
+
+			continue
+		}
+
+		c.logger.Infof("stopping packet capture for local interface '%s'", key)
+
+		running.cancel()
+
+		delete(c.packetCaptures.captures, key)
+	}
+
+	for key, tunnel := range desiredTunnels {
+		err := c.startPacketCapture(tunnel)
+		if err != nil {
+			c.logger.Warnf(
+				"failed starting packet capture for local interface '%s', error: %s",
+				key,
+				err,
+			)
+		}
+	}
+}
+
+func (c *common) startPacketCapture(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) error {
+	hostLink, _ := tunnelInterfaceNames("", tunnel.LocalNode, tunnel.LocalInterface)
+
+	captureDir := filepath.Join(clabernetesconstants.LauncherPacketCaptureDir, tunnel.LocalNode)
+
+	err := os.MkdirAll(captureDir, clabernetesconstants.PermissionsEveryoneAllPermissions)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(c.ctx)
+
+	cmd := exec.CommandContext( //nolint:gosec
+		ctx,
+		"tcpdump",
+		packetCaptureArgs(hostLink, captureDir, *tunnel.Capture)...,
+	)
+
+	c.logger.Infof(
+		"starting packet capture for local interface '%s', command '%s'",
+		tunnel.LocalInterface,
+		cmd.Args,
+	)
+
+	cmd.Stdout = c.logger
+	cmd.Stderr = c.logger
+
+	err = cmd.Start()
+	if err != nil {
+		cancel()
+
+		return err
+	}
+
+	c.packetCaptures.captures[tunnelKey(tunnel)] = &runningPacketCapture{
+		capture: *tunnel.Capture,
+		cancel:  cancel,
+	}
+
+	go func() {
+		waitErr := cmd.Wait()
+		if ctx.Err() == nil {
+			c.logger.Warnf(
+				"packet capture for local interface '%s' exited unexpectedly, error: %v",
+				tunnel.LocalInterface,
+				waitErr,
+			)
+		}
+	}()
+
+	return nil
+}
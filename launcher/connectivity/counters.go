@@ -0,0 +1,140 @@
+package connectivity
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	trafficCountersDefaultInterval = 60 * time.Second
+	trafficCountersMinInterval     = 10 * time.Second
+
+	sysClassNetPath = "/sys/class/net"
+)
+
+// trafficCountersInterval returns the interval at which to report the traffic counters as set by
+// the controller, or zero if traffic counters are not enabled for this topology.
+func trafficCountersInterval() time.Duration {
+	intervalSeconds, ok := os.LookupEnv(clabernetesconstants.LauncherTrafficCountersIntervalEnv)
+	if !ok {
+		return 0
+	}
+
+	seconds, err := strconv.Atoi(intervalSeconds)
+	if err != nil || seconds < 1 {
+		return trafficCountersDefaultInterval
+	}
+
+	return max(time.Duration(seconds)*time.Second, trafficCountersMinInterval)
+}
+
+// startTrafficCounters starts, in the background, reading the traffic counters of the tunnels of
+// this launcher and reporting them in the tunnel statuses of the connectivity cr -- if traffic
+// counters are enabled that is.
+func (c *common) startTrafficCounters() {
+	interval := trafficCountersInterval()
+	if interval == 0 || c.multi != nil {
+		// managers running as part of a multiManager leave this to the multiManager
+		return
+	}
+
+	c.logger.Debugf("traffic counters enabled, reporting tunnel counters every %s", interval)
+
+	go c.runTrafficCounters(interval)
+}
+
+func (c *common) runTrafficCounters(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		c.reportTrafficCounters()
+	}
+}
+
+// reportTrafficCounters reads the counters of each tunnel that has a status and pushes them (all
+// in one go) to the connectivity cr. Counters that did not change since the last report are not
+// pushed again, so idle links do not cause needless updates of the connectivity cr.
+func (c *common) reportTrafficCounters() {
+	c.tunnelStatuses.lock.Lock()
+	defer c.tunnelStatuses.lock.Unlock()
+
+	var changed bool
+
+	for key, status := range c.tunnelStatuses.statuses {
+		localNode, localInterface := splitTunnelKey(key)
+
+		hostLink, _ := tunnelInterfaceNames("", localNode, localInterface)
+
+		counters, err := readTunnelCounters(hostLink)
+		if err != nil {
+			c.logger.Debugf(
+				"failed reading traffic counters of local interface '%s', error: %s",
+				key,
+				err,
+			)
+
+			continue
+		}
+
+		if status.Counters != nil &&
+			status.Counters.RxBytes == counters.RxBytes &&
+			status.Counters.TxBytes == counters.TxBytes &&
+			status.Counters.RxPackets == counters.RxPackets &&
+			status.Counters.TxPackets == counters.TxPackets {
+			continue
+		}
+
+		status.Counters = counters
+
+		c.tunnelStatuses.statuses[key] = status
+
+		changed = true
+	}
+
+	if changed {
+		c.pushTunnelStatuses()
+	}
+}
+
+// readTunnelCounters reads the counters of the given host side link of a tunnel. The host side of
+// the veth sees the traffic of the node the other way around -- what the node sends over the link
+// is received on the host side and vice versa -- so rx and tx are swapped to report them as seen by
+// the node.
+func readTunnelCounters(hostLink string) (*clabernetesapisv1alpha1.TunnelCounters, error) {
+	counters := &clabernetesapisv1alpha1.TunnelCounters{}
+
+	for statistic, counter := range map[string]*int64{
+		"tx_bytes":   &counters.RxBytes,
+		"rx_bytes":   &counters.TxBytes,
+		"tx_packets": &counters.RxPackets,
+		"rx_packets": &counters.TxPackets,
+	} {
+		raw, err := os.ReadFile(filepath.Join(sysClassNetPath, hostLink, "statistics", statistic))
+		if err != nil {
+			return nil, err
+		}
+
+		*counter, err = strconv.ParseInt(strings.TrimSpace(string(raw)), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	counters.LastUpdateTime = metav1.Now()
+
+	return counters, nil
+}
@@ -0,0 +1,323 @@
+package connectivity
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	k8sdiscoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	endpointsSyncTimeout = 30 * time.Second
+)
+
+var errUnresolvedDestination = fmt.Errorf(
+	"%w: failed resolving endpoint address for tunnel destination",
+	claberneteserrors.ErrConnectivity,
+)
+
+// endpointWatcher keeps track of the (pod) addresses of the fabric services of our topology by
+// watching their EndpointSlices -- this lets tunnels follow remote launchers (and wire tap pods)
+// around as they get rescheduled rather than us having to retry/poll dns or the kube api.
+type endpointWatcher struct {
+	lock      sync.RWMutex
+	namespace string
+	topology  string
+	// slices holds the service and address of each endpoint slice keyed by slice name
+	slices map[string]serviceEndpoint
+	// services maps every address we have ever seen back to the service it belonged to, the
+	// launcher init container resolves the initial tunnel destinations to endpoint addresses, so
+	// this is how we know what service those destinations belong to once the remote pod moves
+	services map[string]string
+	// pods maps every address we have ever seen to the name of the (launcher) pod it belonged to,
+	// this is what lets us look up the underlay address of remote launchers
+	pods       map[string]string
+	kubeClient kubernetes.Interface
+	onChange   func()
+}
+
+type serviceEndpoint struct {
+	service string
+	address string
+}
+
+// watchEndpoints starts the endpoint slice informer for the fabric services of our topology and
+// waits (for a bit) for it to sync. Failing to do so is not fatal, tunnel destinations are then
+// resolved once via the kube api instead and simply do not follow remote launchers around.
+func (c *common) watchEndpoints() {
+	namespace := os.Getenv(clabernetesconstants.PodNamespaceEnv)
+
+	if name := underlayInterface(); name != "" && !linkExists(name) {
+		c.logger.Warnf(
+			"underlay interface '%s' not found, is the underlay network attachment definition"+
+				" correct?",
+			name,
+		)
+	}
+
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		c.logger.Warnf("failed getting in cluster config, not watching endpoints, err: %s", err)
+
+		return
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		c.logger.Warnf("failed creating kube client, not watching endpoints, err: %s", err)
+
+		return
+	}
+
+	watcher := &endpointWatcher{
+		namespace:  namespace,
+		topology:   os.Getenv(clabernetesconstants.LauncherTopologyNameEnv),
+		slices:     map[string]serviceEndpoint{},
+		services:   map[string]string{},
+		pods:       map[string]string{},
+		kubeClient: kubeClient,
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		kubeClient,
+		0,
+		informers.WithNamespace(namespace),
+		// wire tap services do not carry the topology owner label, so we cant select on it and
+		// instead skip the slices of other topologies as they come in
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = fmt.Sprintf(
+				"%s=%s",
+				clabernetesconstants.LabelTopologyServiceType,
+				clabernetesconstants.TopologyServiceTypeFabric,
+			)
+		}),
+	)
+
+	informer := factory.Discovery().V1().EndpointSlices().Informer()
+
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			watcher.handleEndpointSlice(obj, false)
+		},
+		UpdateFunc: func(_, obj any) {
+			watcher.handleEndpointSlice(obj, false)
+		},
+		DeleteFunc: func(obj any) {
+			watcher.handleEndpointSlice(obj, true)
+		},
+	})
+	if err != nil {
+		c.logger.Warnf("failed adding endpoints event handler, not watching endpoints, err: %s", err)
+
+		return
+	}
+
+	factory.Start(c.ctx.Done())
+
+	syncCtx, cancel := context.WithTimeout(c.ctx, endpointsSyncTimeout)
+	defer cancel()
+
+	if !cache.WaitForCacheSync(syncCtx.Done(), informer.HasSynced) {
+		// keep the watcher regardless, once it does sync we still want to know about moves
+		c.logger.Warnf(
+			"endpoints did not sync within %s, continuing but tunnel destinations may be stale",
+			endpointsSyncTimeout,
+		)
+	}
+
+	c.endpoints = watcher
+}
+
+// onEndpointsChanged sets the function to call whenever the address of one of the fabric services
+// changes. This is set once the initial tunnels are created so that managers do not have to deal
+// with changes while still setting things up.
+func (c *common) onEndpointsChanged(f func()) {
+	if c.endpoints == nil {
+		return
+	}
+
+	c.endpoints.lock.Lock()
+	defer c.endpoints.lock.Unlock()
+
+	c.endpoints.onChange = f
+}
+
+// resolveDestination returns the current endpoint (pod) address for the given tunnel destination,
+// which is either a fabric service name or an (possibly stale) endpoint address of one. If we
+// dont know the destination from the endpoint watch we fall back to a single kube api lookup for
+// service names and use addresses as is.
+func (c *common) resolveDestination(destination string) (string, error) {
+	if c.endpoints != nil {
+		address, ok := c.endpoints.address(destination)
+		if ok {
+			return c.underlayAddress(address)
+		}
+	}
+
+	if net.ParseIP(destination) != nil {
+		return c.underlayAddress(destination)
+	}
+
+	address, err := resolveServiceEndpointViaKubeAPI(c.ctx, destination)
+	if err != nil {
+		return "", fmt.Errorf("%w %q, error: %w", errUnresolvedDestination, destination, err)
+	}
+
+	return c.underlayAddress(address)
+}
+
+func (w *endpointWatcher) handleEndpointSlice(obj any, deleted bool) {
+	tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+	if ok {
+		obj = tombstone.Obj
+	}
+
+	slice, ok := obj.(*k8sdiscoveryv1.EndpointSlice)
+	if !ok {
+		return
+	}
+
+	if slice.Labels[clabernetesconstants.LabelTopologyOwner] != w.topology &&
+		slice.Labels[clabernetesconstants.LabelWireTapTopology] != w.topology {
+		return
+	}
+
+	service := slice.Labels[k8sdiscoveryv1.LabelServiceName]
+	if service == "" {
+		return
+	}
+
+	var address, pod string
+
+	if !deleted {
+		address, pod = endpointSliceAddress(slice)
+	}
+
+	w.lock.Lock()
+
+	previousAddress := w.serviceAddress(service)
+
+	if address == "" {
+		delete(w.slices, slice.Name)
+	} else {
+		w.slices[slice.Name] = serviceEndpoint{service: service, address: address}
+		w.services[address] = service
+
+		if pod != "" {
+			w.pods[address] = pod
+		}
+	}
+
+	currentAddress := w.serviceAddress(service)
+
+	onChange := w.onChange
+
+	w.lock.Unlock()
+
+	// a service without any address is a launcher on its way to somewhere else, nothing we can
+	// point tunnels at until it shows up again
+	if currentAddress == "" || currentAddress == previousAddress || onChange == nil {
+		return
+	}
+
+	onChange()
+}
+
+// address returns the current address of the service the given destination belongs to, if any.
+func (w *endpointWatcher) address(destination string) (string, bool) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	service, ok := w.services[destination]
+	if !ok {
+		serviceName, namespace := parseServiceFQDN(destination)
+		if namespace != w.namespace {
+			return "", false
+		}
+
+		service = serviceName
+	}
+
+	address := w.serviceAddress(service)
+
+	return address, address != ""
+}
+
+// serviceAddress returns the address of the given service, callers must hold the lock.
+func (w *endpointWatcher) serviceAddress(service string) string {
+	sliceNames := make([]string, 0, len(w.slices))
+
+	for sliceName, serviceEndpoint := range w.slices {
+		if serviceEndpoint.service == service {
+			sliceNames = append(sliceNames, sliceName)
+		}
+	}
+
+	if len(sliceNames) == 0 {
+		return ""
+	}
+
+	sort.Strings(sliceNames)
+
+	return w.slices[sliceNames[0]].address
+}
+
+// podName returns the name of the pod the given address belongs (or last belonged) to, if known.
+func (w *endpointWatcher) podName(address string) (string, bool) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	pod, ok := w.pods[address]
+
+	return pod, ok
+}
+
+// endpointSliceAddress returns the address of the (first) ready endpoint of the given slice, or of
+// the first not ready (but not terminating) endpoint if there are no ready endpoints -- just like
+// with the endpoints lookup, the remote launcher may well be waiting on our tunnels before it
+// becomes ready. The name of the pod owning the address is returned as well, if known.
+func endpointSliceAddress(slice *k8sdiscoveryv1.EndpointSlice) (address, pod string) {
+	var notReadyAddress, notReadyPod string
+
+	for _, endpoint := range slice.Endpoints {
+		if len(endpoint.Addresses) == 0 {
+			continue
+		}
+
+		if endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating {
+			// the old pod of a rescheduled launcher, its replacement is what we want
+			continue
+		}
+
+		if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+			return endpoint.Addresses[0], endpointPodName(endpoint)
+		}
+
+		if notReadyAddress == "" {
+			notReadyAddress = endpoint.Addresses[0]
+			notReadyPod = endpointPodName(endpoint)
+		}
+	}
+
+	return notReadyAddress, notReadyPod
+}
+
+func endpointPodName(endpoint k8sdiscoveryv1.Endpoint) string {
+	if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" {
+		return ""
+	}
+
+	return endpoint.TargetRef.Name
+}
@@ -0,0 +1,249 @@
+//go:build linux
+// +build linux
+
+package connectivity
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+const (
+	geneveInterfacePrefix = "gn"
+)
+
+type geneveManager struct {
+	*common
+
+	// lock guards currentTunnels and resolvedRemotes as they are touched by both the connectivity
+	// cr watch and the endpoint watch
+	lock            sync.Mutex
+	currentTunnels  map[string]*clabernetesapisv1alpha1.PointToPointTunnel
+	resolvedRemotes map[string]string
+}
+
+func (m *geneveManager) Run() {
+	m.currentTunnels = make(map[string]*clabernetesapisv1alpha1.PointToPointTunnel)
+	m.resolvedRemotes = make(map[string]string)
+
+	m.logger.Info(
+		"connectivity mode is 'geneve', setting up any required tunnels...",
+	)
+
+	m.watchEndpoints()
+
+	for _, tunnel := range m.initialTunnels {
+		err := m.createGeneveTunnel(tunnel)
+		if err != nil {
+			m.tunnelSetupFailed(tunnel, err)
+		}
+
+		m.currentTunnels[tunnelKey(tunnel)] = tunnel
+	}
+
+	m.logger.Debug("initial geneve tunnel creation complete")
+
+	m.updatePacketCaptures(m.initialTunnels)
+
+	m.updateLinkStates(m.initialTunnels)
+
+	m.startSelfTest()
+
+	m.startTrafficCounters()
+
+	m.startMetrics()
+
+	m.startLivenessProbes()
+
+	m.logger.Debug("start connectivity custom resource watch...")
+
+	m.startConnectivityWatch(
+		m.withLinkStates(m.withPacketCaptures(m.updateGeneveTunnels)),
+	)
+
+	m.onEndpointsChanged(m.repointGeneveTunnels)
+
+	m.logger.Debug("geneve connectivity setup complete")
+}
+
+func (m *geneveManager) createGeneveTunnel(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) (err error) {
+	resolvedRemote := tunnel.Destination
+
+	defer func() {
+		m.reportTunnelStatus(tunnel, resolvedRemote, err)
+	}()
+
+	resolvedRemote, err = m.resolveDestination(tunnel.Destination)
+	if err != nil {
+		return err
+	}
+
+	m.logger.Debugf("resolved remote geneve tunnel endpoint address as '%s'", resolvedRemote)
+
+	hostLink, geneveLink := tunnelInterfaceNames(
+		geneveInterfacePrefix,
+		tunnel.LocalNode,
+		tunnel.LocalInterface,
+	)
+
+	err = m.deleteGeneveTunnel(tunnel)
+	if err != nil {
+		m.logger.Warnf(
+			"failed while deleting existing geneve interface '%s', error: '%s'",
+			geneveLink,
+			err,
+		)
+	}
+
+	// same as with vxlan -- in native mode there is no containerlab veth to attach to, so make
+	// sure the pod side link exists before we wire it up to the geneve interface
+	err = m.ensurePodLinkExists(m.ctx, tunnel.LocalNode, sanitizeLinuxIfName(tunnel.LocalInterface))
+	if err != nil {
+		return err
+	}
+
+	err = createGeneveLink(geneveLink, hostLink, resolvedRemote, tunnel.TunnelID, genevePort())
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed creating geneve tunnel for local interface %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			tunnel.LocalInterface,
+			err,
+		)
+	}
+
+	m.resolvedRemotes[tunnelKey(tunnel)] = resolvedRemote
+
+	if tunnel.Impairment == nil && tunnel.Bandwidth == "" {
+		return nil
+	}
+
+	return m.applyLinkShaping(geneveLink, tunnel)
+}
+
+func (m *geneveManager) deleteGeneveTunnel(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) error {
+	hostLink, geneveLink := tunnelInterfaceNames(
+		geneveInterfacePrefix,
+		tunnel.LocalNode,
+		tunnel.LocalInterface,
+	)
+
+	return deleteTunnelLink(hostLink, geneveLink)
+}
+
+// repointGeneveTunnels re-resolves the endpoint addresses of all current tunnels and recreates any
+// tunnel whose remote launcher pod ip has changed (or that could not be set up so far).
+func (m *geneveManager) repointGeneveTunnels() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, tunnel := range m.currentTunnels {
+		resolvedRemote, err := m.resolveDestination(tunnel.Destination)
+		if err != nil {
+			m.logger.Warnf(
+				"failed re-resolving remote geneve endpoint for local interface '%s', error: %s",
+				tunnel.LocalInterface,
+				err,
+			)
+
+			continue
+		}
+
+		if resolvedRemote == m.resolvedRemotes[tunnelKey(tunnel)] {
+			continue
+		}
+
+		m.logger.Infof(
+			"remote geneve endpoint for local interface '%s' changed from '%s' to '%s', recreating",
+			tunnel.LocalInterface,
+			m.resolvedRemotes[tunnelKey(tunnel)],
+			resolvedRemote,
+		)
+
+		err = m.createGeneveTunnel(tunnel)
+		if err != nil {
+			m.logger.Warnf(
+				"failed recreating tunnel to remote node '%s' for local interface '%s', error: %s",
+				tunnel.RemoteNode,
+				tunnel.LocalInterface,
+				err,
+			)
+		}
+	}
+}
+
+func (m *geneveManager) updateGeneveTunnels(
+	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for key, existingTunnel := range m.currentTunnels {
+		var found bool
+
+		for _, tunnel := range tunnels {
+			if tunnelKey(tunnel) == tunnelKey(existingTunnel) {
+				found = true
+
+				break
+			}
+		}
+
+		if found {
+			continue
+		}
+
+		err := m.deleteGeneveTunnel(existingTunnel)
+		if err != nil {
+			m.fatalf(
+				"failed deleting extraneous tunnel to remote node '%s' for local interface '%s'"+
+					", error: %s",
+				existingTunnel.RemoteNode,
+				existingTunnel.LocalInterface,
+				err,
+			)
+		}
+
+		delete(m.currentTunnels, key)
+		delete(m.resolvedRemotes, key)
+
+		m.forgetTunnelStatus(key)
+	}
+
+	for _, tunnel := range tunnels {
+		existingTunnel, ok := m.currentTunnels[tunnelKey(tunnel)]
+		if ok && reflect.DeepEqual(existingTunnel, tunnel) {
+			continue
+		}
+
+		if ok && onlyTunnelSettingsChanged(existingTunnel, tunnel) {
+			_, tunnelLink := tunnelInterfaceNames(
+				geneveInterfacePrefix,
+				tunnel.LocalNode,
+				tunnel.LocalInterface,
+			)
+
+			m.updateTunnelShaping(tunnelLink, existingTunnel, tunnel)
+
+			m.currentTunnels[tunnelKey(tunnel)] = tunnel
+
+			continue
+		}
+
+		// create handles deleting any existing tunnel for this interface
+		err := m.createGeneveTunnel(tunnel)
+		if err != nil {
+			m.tunnelSetupFailed(tunnel, err)
+		}
+
+		m.currentTunnels[tunnelKey(tunnel)] = tunnel
+	}
+}
@@ -0,0 +1,255 @@
+//go:build linux
+// +build linux
+
+package connectivity
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+const (
+	greInterfacePrefix = "gr"
+)
+
+// greManager carries the emulated links in gretap tunnels keyed by the tunnel id. Gre is its own
+// ip protocol (not tcp/udp) so it can not be sent via the service cluster ip, instead we resolve
+// the remote launchers pod ip from the service endpoints and re-resolve it whenever the endpoint
+// watch sees a remote launcher move so that tunnels follow remote launchers when they are
+// rescheduled.
+type greManager struct {
+	*common
+
+	lock            sync.Mutex
+	currentTunnels  map[string]*clabernetesapisv1alpha1.PointToPointTunnel
+	resolvedRemotes map[string]string
+}
+
+func (m *greManager) Run() {
+	m.currentTunnels = make(map[string]*clabernetesapisv1alpha1.PointToPointTunnel)
+	m.resolvedRemotes = make(map[string]string)
+
+	m.logger.Info(
+		"connectivity mode is 'gre', setting up any required tunnels...",
+	)
+
+	m.watchEndpoints()
+
+	for _, tunnel := range m.initialTunnels {
+		err := m.createGRETunnel(tunnel)
+		if err != nil {
+			m.tunnelSetupFailed(tunnel, err)
+		}
+
+		m.currentTunnels[tunnelKey(tunnel)] = tunnel
+	}
+
+	m.logger.Debug("initial gre tunnel creation complete")
+
+	m.updatePacketCaptures(m.initialTunnels)
+
+	m.updateLinkStates(m.initialTunnels)
+
+	m.startSelfTest()
+
+	m.startTrafficCounters()
+
+	m.startMetrics()
+
+	m.startLivenessProbes()
+
+	m.logger.Debug("start connectivity custom resource watch...")
+
+	m.startConnectivityWatch(m.withLinkStates(m.withPacketCaptures(m.updateGRETunnels)))
+
+	m.onEndpointsChanged(m.refreshGRERemotesOnce)
+
+	m.logger.Debug("gre connectivity setup complete")
+}
+
+func (m *greManager) createGRETunnel(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) error {
+	resolvedRemote, err := m.resolveDestination(tunnel.Destination)
+	if err != nil {
+		m.reportTunnelStatus(tunnel, "", err)
+
+		return err
+	}
+
+	m.logger.Debugf("resolved remote gre tunnel endpoint address as '%s'", resolvedRemote)
+
+	return m.createGRETunnelToRemote(tunnel, resolvedRemote)
+}
+
+func (m *greManager) createGRETunnelToRemote(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+	resolvedRemote string,
+) (err error) {
+	defer func() {
+		m.reportTunnelStatus(tunnel, resolvedRemote, err)
+	}()
+
+	hostLink, greLink := tunnelInterfaceNames(
+		greInterfacePrefix,
+		tunnel.LocalNode,
+		tunnel.LocalInterface,
+	)
+
+	err = m.deleteGRETunnel(tunnel)
+	if err != nil {
+		m.logger.Warnf(
+			"failed while deleting existing gre interface '%s', error: '%s'",
+			greLink,
+			err,
+		)
+	}
+
+	err = m.ensurePodLinkExists(m.ctx, tunnel.LocalNode, sanitizeLinuxIfName(tunnel.LocalInterface))
+	if err != nil {
+		return err
+	}
+
+	err = createGretapLink(greLink, hostLink, resolvedRemote, tunnel.TunnelID)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed creating gre tunnel for local interface %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			tunnel.LocalInterface,
+			err,
+		)
+	}
+
+	m.resolvedRemotes[tunnelKey(tunnel)] = resolvedRemote
+
+	if tunnel.Impairment == nil && tunnel.Bandwidth == "" {
+		return nil
+	}
+
+	return m.applyLinkShaping(greLink, tunnel)
+}
+
+func (m *greManager) deleteGRETunnel(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) error {
+	hostLink, greLink := tunnelInterfaceNames(
+		greInterfacePrefix,
+		tunnel.LocalNode,
+		tunnel.LocalInterface,
+	)
+
+	return deleteTunnelLink(hostLink, greLink)
+}
+
+// refreshGRERemotesOnce re-resolves the endpoint addresses of all current tunnels and recreates
+// any tunnel whose remote launcher pod ip has changed (or that could not be set up so far).
+func (m *greManager) refreshGRERemotesOnce() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, tunnel := range m.currentTunnels {
+		resolvedRemote, err := m.resolveDestination(tunnel.Destination)
+		if err != nil {
+			m.logger.Warnf(
+				"failed re-resolving remote gre endpoint for local interface '%s', error: %s",
+				tunnel.LocalInterface,
+				err,
+			)
+
+			continue
+		}
+
+		if resolvedRemote == m.resolvedRemotes[tunnelKey(tunnel)] {
+			continue
+		}
+
+		m.logger.Infof(
+			"remote gre endpoint for local interface '%s' changed from '%s' to '%s', recreating",
+			tunnel.LocalInterface,
+			m.resolvedRemotes[tunnelKey(tunnel)],
+			resolvedRemote,
+		)
+
+		err = m.createGRETunnelToRemote(tunnel, resolvedRemote)
+		if err != nil {
+			m.logger.Warnf(
+				"failed recreating tunnel to remote node '%s' for local interface '%s', error: %s",
+				tunnel.RemoteNode,
+				tunnel.LocalInterface,
+				err,
+			)
+		}
+	}
+}
+
+func (m *greManager) updateGRETunnels(
+	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for key, existingTunnel := range m.currentTunnels {
+		var found bool
+
+		for _, tunnel := range tunnels {
+			if tunnelKey(tunnel) == tunnelKey(existingTunnel) {
+				found = true
+
+				break
+			}
+		}
+
+		if found {
+			continue
+		}
+
+		err := m.deleteGRETunnel(existingTunnel)
+		if err != nil {
+			m.fatalf(
+				"failed deleting extraneous tunnel to remote node '%s' for local interface '%s'"+
+					", error: %s",
+				existingTunnel.RemoteNode,
+				existingTunnel.LocalInterface,
+				err,
+			)
+		}
+
+		delete(m.currentTunnels, key)
+		delete(m.resolvedRemotes, key)
+
+		m.forgetTunnelStatus(key)
+	}
+
+	for _, tunnel := range tunnels {
+		existingTunnel, ok := m.currentTunnels[tunnelKey(tunnel)]
+		if ok && reflect.DeepEqual(existingTunnel, tunnel) {
+			continue
+		}
+
+		if ok && onlyTunnelSettingsChanged(existingTunnel, tunnel) {
+			_, tunnelLink := tunnelInterfaceNames(
+				greInterfacePrefix,
+				tunnel.LocalNode,
+				tunnel.LocalInterface,
+			)
+
+			m.updateTunnelShaping(tunnelLink, existingTunnel, tunnel)
+
+			m.currentTunnels[tunnelKey(tunnel)] = tunnel
+
+			continue
+		}
+
+		// create handles deleting any existing tunnel for this interface
+		err := m.createGRETunnel(tunnel)
+		if err != nil {
+			m.tunnelSetupFailed(tunnel, err)
+		}
+
+		m.currentTunnels[tunnelKey(tunnel)] = tunnel
+	}
+}
@@ -0,0 +1,164 @@
+package connectivity
+
+import (
+	"fmt"
+	"os/exec"
+	"reflect"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+const (
+	defaultNetemDelay = "0ms"
+
+	htbHandle       = "1:"
+	htbDefaultClass = "1"
+	htbClassID      = "1:1"
+	netemHandle     = "10:"
+)
+
+// netemArgs returns the tc netem arguments for the given impairment.
+func netemArgs(impairment *clabernetesapisv1alpha1.LinkImpairment) []string {
+	var args []string
+
+	if impairment.Delay != "" || impairment.Jitter != "" {
+		delay := impairment.Delay
+		if delay == "" {
+			// jitter is only a thing in netem as a variation of the delay
+			delay = defaultNetemDelay
+		}
+
+		args = append(args, "delay", delay)
+
+		if impairment.Jitter != "" {
+			args = append(args, impairment.Jitter)
+		}
+	}
+
+	if impairment.Loss != "" {
+		args = append(args, "loss", fmt.Sprintf("%s%%", strings.TrimSuffix(impairment.Loss, "%")))
+	}
+
+	if impairment.Rate != "" {
+		args = append(args, "rate", impairment.Rate)
+	}
+
+	return args
+}
+
+// netemCommand returns the tc command to set the given impairment on the egress of the given
+// tunnel link -- as the root qdisc, or, if the link is also shaped, below the shaping class.
+func netemCommand(
+	tunnelLink string,
+	shaped bool,
+	impairment *clabernetesapisv1alpha1.LinkImpairment,
+) []string {
+	parent := []string{"root"}
+	if shaped {
+		parent = []string{"parent", htbClassID, "handle", netemHandle}
+	}
+
+	args := append([]string{"tc", "qdisc", "replace", "dev", tunnelLink}, parent...)
+
+	return append(append(args, "netem"), netemArgs(impairment)...)
+}
+
+// shapingCommands returns the tc commands to shape and/or impair the egress of the given tunnel
+// link as set in the given tunnel. Shaping is done with a single htb class limited to the tunnel
+// bandwidth, any impairment netem qdisc hangs off of that class so both apply.
+func shapingCommands(
+	tunnelLink string,
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) [][]string {
+	var commands [][]string
+
+	shaped := tunnel.Bandwidth != ""
+
+	if shaped {
+		commands = append(
+			commands,
+			[]string{
+				"tc", "qdisc", "replace", "dev", tunnelLink, "root", "handle", htbHandle,
+				"htb", "default", htbDefaultClass,
+			},
+			[]string{
+				"tc", "class", "replace", "dev", tunnelLink, "parent", htbHandle,
+				"classid", htbClassID, "htb", "rate", tunnel.Bandwidth,
+			},
+		)
+	}
+
+	if tunnel.Impairment != nil {
+		commands = append(commands, netemCommand(tunnelLink, shaped, tunnel.Impairment))
+	}
+
+	return commands
+}
+
+// applyLinkShaping sets the qdiscs for the bandwidth and impairment of the given tunnel on the
+// egress of the given tunnel link, removing any qdiscs we previously set first. The traffic the
+// node sends is redirected to the egress of the tunnel link, so this shapes/impairs exactly the
+// traffic the node sends over the link.
+func (c *common) applyLinkShaping(
+	tunnelLink string,
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) error {
+	// there may or may not be a root qdisc to delete, dont care either way -- we always start
+	// over as switching between a shaped and an unshaped link changes the root qdisc kind
+	_ = exec.CommandContext( //nolint:gosec
+		c.ctx, "tc", "qdisc", "del", "dev", tunnelLink, "root",
+	).Run()
+
+	for _, args := range shapingCommands(tunnelLink, tunnel) {
+		cmd := exec.CommandContext(c.ctx, args[0], args[1:]...) //nolint:gosec
+
+		c.logger.Debugf("running connectivity shaping command '%s'", cmd.Args)
+
+		cmd.Stdout = c.logger
+		cmd.Stderr = c.logger
+
+		err := cmd.Run()
+		if err != nil {
+			return fmt.Errorf(
+				"%w: failed applying bandwidth/impairment to tunnel interface %q, error: %w",
+				claberneteserrors.ErrConnectivity,
+				tunnelLink,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// updateTunnelShaping applies the bandwidth and impairment of the desired tunnel to the existing
+// tunnel interface (if either changed at all), crashing if that fails just like we would if a
+// tunnel fails to be created.
+func (c *common) updateTunnelShaping(
+	tunnelLink string,
+	existingTunnel,
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) {
+	if existingTunnel.Bandwidth == tunnel.Bandwidth &&
+		reflect.DeepEqual(existingTunnel.Impairment, tunnel.Impairment) {
+		return
+	}
+
+	c.logger.Infof(
+		"updating bandwidth/impairment of tunnel for local interface '%s'",
+		tunnel.LocalInterface,
+	)
+
+	err := c.applyLinkShaping(tunnelLink, tunnel)
+	if err != nil {
+		c.fatalf(
+			"failed updating bandwidth/impairment of tunnel to remote node '%s' for local"+
+				" interface '%s', error: %s",
+			tunnel.RemoteNode,
+			tunnel.LocalInterface,
+			err,
+		)
+	}
+}
@@ -0,0 +1,101 @@
+package connectivity
+
+import (
+	"sync"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+)
+
+// linkStateTracker holds the local interfaces (by tunnel key) this launcher set administratively
+// down -- all managers share the tracker via common.
+type linkStateTracker struct {
+	lock sync.Mutex
+	down map[string]bool
+}
+
+// withLinkStates wraps the given tunnel update func so that the administrative link states are
+// updated after every tunnel update.
+func (c *common) withLinkStates(
+	handleUpdate func(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel),
+) func(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) {
+	return func(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) {
+		handleUpdate(tunnels)
+
+		c.updateLinkStates(tunnels)
+	}
+}
+
+// updateLinkStates sets the host side of the local interface of each admin down tunnel down, and
+// brings the interfaces we previously set down back up once their tunnel is no longer admin down.
+// The node side of the veth loses its carrier when the host side goes down, so the node sees the
+// link go down without the tunnel (or the node) being touched. Admin down interfaces are set down
+// on every update as re-creating a tunnel brings its host side link up again.
+func (c *common) updateLinkStates(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) {
+	c.linkStates.lock.Lock()
+	defer c.linkStates.lock.Unlock()
+
+	if c.linkStates.down == nil {
+		c.linkStates.down = map[string]bool{}
+	}
+
+	desiredTunnels := map[string]*clabernetesapisv1alpha1.PointToPointTunnel{}
+
+	for _, tunnel := range tunnels {
+		desiredTunnels[tunnelKey(tunnel)] = tunnel
+	}
+
+	for key := range c.linkStates.down {
+		if desiredTunnels[key] == nil {
+			// the tunnel is gone altogether, nothing left to bring back up
+			delete(c.linkStates.down, key)
+		}
+	}
+
+	for _, tunnel := range tunnels {
+		if tunnel.AdminDown || !c.linkStates.down[tunnelKey(tunnel)] {
+			continue
+		}
+
+		hostLink, _ := tunnelInterfaceNames("", tunnel.LocalNode, tunnel.LocalInterface)
+
+		c.logger.Infof("bringing local interface '%s' administratively up", tunnel.LocalInterface)
+
+		err := setLinkUp(hostLink)
+		if err != nil {
+			c.logger.Warnf(
+				"failed bringing local interface '%s' administratively up, error: %s",
+				tunnel.LocalInterface,
+				err,
+			)
+
+			continue
+		}
+
+		delete(c.linkStates.down, tunnelKey(tunnel))
+	}
+
+	for key, tunnel := range desiredTunnels {
+		if !tunnel.AdminDown {
+			continue
+		}
+
+		hostLink, _ := tunnelInterfaceNames("", tunnel.LocalNode, tunnel.LocalInterface)
+
+		if !c.linkStates.down[key] {
+			c.logger.Infof("setting local interface '%s' administratively down", key)
+		}
+
+		err := setLinkDown(hostLink)
+		if err != nil {
+			c.logger.Warnf(
+				"failed setting local interface '%s' administratively down, error: %s",
+				key,
+				err,
+			)
+
+			continue
+		}
+
+		c.linkStates.down[key] = true
+	}
+}
@@ -0,0 +1,204 @@
+package connectivity
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+)
+
+const (
+	livenessInterval = 5 * time.Second
+	livenessTimeout  = 2 * time.Second
+	// livenessFailureThreshold is how many probes in a row have to go unanswered before we
+	// consider the remote launcher unreachable -- a single lost udp packet means nothing.
+	livenessFailureThreshold = 3
+
+	livenessMagic      = "c9s-live"
+	livenessPacketSize = len(livenessMagic) + 8
+)
+
+var errLivenessMismatch = errors.New("liveness reply mismatch")
+
+// startLivenessProbes starts answering the liveness probes of our peers and, in the background,
+// probing the remote launchers of all tunnels that are set up. Tunnels only tell us that *we* set
+// things up, a remote launcher that went away (or whose address went stale) just blackholes
+// traffic, so tunnels towards peers that stop answering are reported as "unreachable".
+func (c *common) startLivenessProbes() {
+	if c.multi != nil {
+		// managers running as part of a multiManager leave this to the multiManager
+		return
+	}
+
+	c.logger.Debug("starting tunnel liveness probes...")
+
+	go serveLiveness(c.ctx, c.logger)
+
+	go c.runLivenessProbes()
+}
+
+// serveLiveness answers liveness probes until the given context is done.
+func serveLiveness(ctx context.Context, logger claberneteslogging.Instance) {
+	listenConfig := net.ListenConfig{}
+
+	conn, err := listenConfig.ListenPacket(
+		ctx,
+		"udp",
+		fmt.Sprintf(":%d", clabernetesconstants.LivenessServicePort),
+	)
+	if err != nil {
+		logger.Warnf("failed starting liveness responder, err: %s", err)
+
+		return
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		_ = conn.Close()
+	}()
+
+	buf := make([]byte, livenessPacketSize)
+
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			continue
+		}
+
+		if n != livenessPacketSize || !bytes.HasPrefix(buf, []byte(livenessMagic)) {
+			continue
+		}
+
+		_, _ = conn.WriteTo(buf[:n], addr)
+	}
+}
+
+func (c *common) runLivenessProbes() {
+	ticker := time.NewTicker(livenessInterval)
+	defer ticker.Stop()
+
+	failures := map[string]int{}
+
+	var sequence atomic.Uint64
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		destinations := c.livenessDestinations()
+
+		results := make(chan livenessResult, len(destinations))
+
+		for _, destination := range destinations {
+			go func(destination string, sequence uint64) {
+				results <- livenessResult{
+					destination: destination,
+					err:         probeLiveness(destination, sequence),
+				}
+			}(destination, sequence.Add(1))
+		}
+
+		for range destinations {
+			result := <-results
+
+			if result.err == nil {
+				if failures[result.destination] >= livenessFailureThreshold {
+					c.logger.Infof("remote launcher %q is reachable again", result.destination)
+				}
+
+				failures[result.destination] = 0
+
+				c.reportPeerReachability(result.destination, nil)
+
+				continue
+			}
+
+			failures[result.destination]++
+
+			if failures[result.destination] < livenessFailureThreshold {
+				continue
+			}
+
+			if failures[result.destination] == livenessFailureThreshold {
+				c.logger.Warnf(
+					"remote launcher %q did not answer %d liveness probes in a row, last error: %s",
+					result.destination,
+					livenessFailureThreshold,
+					result.err,
+				)
+			}
+
+			c.reportPeerReachability(
+				result.destination,
+				fmt.Errorf(
+					"peer unreachable, no liveness reply for %s: %w",
+					livenessInterval*livenessFailureThreshold,
+					result.err,
+				),
+			)
+		}
+	}
+}
+
+type livenessResult struct {
+	destination string
+	err         error
+}
+
+// probeLiveness sends a single liveness probe to the remote launcher at the given address and
+// waits for it to be echoed back.
+func probeLiveness(destination string, sequence uint64) error {
+	conn, err := net.DialTimeout(
+		"udp",
+		net.JoinHostPort(destination, strconv.Itoa(clabernetesconstants.LivenessServicePort)),
+		livenessTimeout,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	probe := make([]byte, livenessPacketSize)
+
+	copy(probe, livenessMagic)
+	binary.BigEndian.PutUint64(probe[len(livenessMagic):], sequence)
+
+	_ = conn.SetDeadline(time.Now().Add(livenessTimeout))
+
+	_, err = conn.Write(probe)
+	if err != nil {
+		return err
+	}
+
+	reply := make([]byte, livenessPacketSize)
+
+	n, err := conn.Read(reply)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(reply[:n], probe) {
+		return errLivenessMismatch
+	}
+
+	return nil
+}
@@ -2,16 +2,23 @@ package connectivity
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
 	clabernetesgeneratedclientset "github.com/srl-labs/clabernetes/generated/clientset"
 	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
 )
 
 // Manager is an interface defining a connectivity manager -- basically a small abstraction around
 // the flavor of how we connect to other launcher pods and their containerlab nodes -- the standard
-// way is via vxlan, and there is also an experimental tool "slurpeeth" for connectivity over tcp
-// tunnels.
+// way is via vxlan, there is also geneve (which behaves just like vxlan but w/ geneve encap),
+// wireguard (vxlan carried over an encrypted wireguard interface), gre (gretap tunnels straight
+// between launcher pods for when udp is filtered), and there is also an
+// experimental tool "slurpeeth" for connectivity over tcp tunnels.
 type Manager interface {
 	// Run "runs" the connectivity flavor -- in the case of vxlan this simply means spinning up
 	// the required tunnels, but for other flavors (slurpeeth) this means running the process that
@@ -27,4 +34,120 @@ type common struct {
 	logger            claberneteslogging.Instance
 	clabernetesClient *clabernetesgeneratedclientset.Clientset
 	initialTunnels    []*clabernetesapisv1alpha1.PointToPointTunnel
+	tunnelStatuses    *tunnelStatusTracker
+	packetCaptures    packetCaptureTracker
+	linkStates        linkStateTracker
+	endpoints         *endpointWatcher
+	connectivityKind  string
+	// multi is the multiManager this manager runs as part of, if any (see multiManager)
+	multi *multiManager
+}
+
+// fatalf writes the given message to the termination message path as a tunnel failure, then
+// crashes via the logger's Fatalf.
+func (c *common) fatalf(f string, a ...interface{}) {
+	clabernetesutil.WriteTerminationMessage(
+		clabernetesconstants.TerminationReasonTunnelFailed,
+		fmt.Sprintf(f, a...),
+	)
+
+	c.logger.Fatalf(f, a...)
+}
+
+// tunnelSetupFailed handles failing to set up the given tunnel -- if we only failed because the
+// destination could not be resolved (yet) there is nothing wrong with this launcher, the tunnel is
+// set up once the endpoint watch sees the remote launcher, if its tunnel id conflicts with another
+// tunnel we leave it down, otherwise we crash via fatalf.
+func (c *common) tunnelSetupFailed(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+	err error,
+) {
+	if c.endpoints != nil && errors.Is(err, errUnresolvedDestination) {
+		c.logger.Warnf(
+			"tunnel to remote node '%s' for local interface '%s' not set up yet, waiting for"+
+				" remote endpoint, error: %s",
+			tunnel.RemoteNode,
+			tunnel.LocalInterface,
+			err,
+		)
+
+		return
+	}
+
+	if errors.Is(err, errTunnelIDConflict) {
+		// restarting the launcher will not fix a conflict, the tunnel is reported as down (with the
+		// conflict as its error) and is set up once the connectivity cr is fixed
+		c.logger.Criticalf(
+			"tunnel to remote node '%s' for local interface '%s' not set up, error: %s",
+			tunnel.RemoteNode,
+			tunnel.LocalInterface,
+			err,
+		)
+
+		return
+	}
+
+	c.fatalf(
+		"failed setting up tunnel to remote node '%s' for local interface '%s', error: %s",
+		tunnel.RemoteNode,
+		tunnel.LocalInterface,
+		err,
+	)
+}
+
+// connectivityPort returns the port set (by the controller) in the given env var, or the default
+// port if the topology does not override it.
+func connectivityPort(envName string, defaultPort int) int {
+	return clabernetesutil.GetEnvIntOrDefault(envName, defaultPort)
+}
+
+func vxlanPort() int {
+	return connectivityPort(
+		clabernetesconstants.LauncherVXLANPortEnv,
+		clabernetesconstants.VXLANServicePort,
+	)
+}
+
+func slurpeethPort() int {
+	return connectivityPort(
+		clabernetesconstants.LauncherSlurpeethPortEnv,
+		clabernetesconstants.SlurpeethServicePort,
+	)
+}
+
+func genevePort() int {
+	return connectivityPort(
+		clabernetesconstants.LauncherGenevePortEnv,
+		clabernetesconstants.GeneveServicePort,
+	)
+}
+
+func wireGuardPort() int {
+	return connectivityPort(
+		clabernetesconstants.LauncherWireGuardPortEnv,
+		clabernetesconstants.WireGuardServicePort,
+	)
+}
+
+// onlyTunnelSettingsChanged returns true if the existing and desired tunnel differ in nothing but
+// their settings that can be changed on the fly (bandwidth, impairment, packet capture and admin
+// state) -- in
+// that case we can just update those settings rather than re-creating the tunnel.
+func onlyTunnelSettingsChanged(
+	existingTunnel,
+	desiredTunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) bool {
+	existingWithoutSettings := *existingTunnel
+	existingWithoutSettings.Impairment = nil
+	existingWithoutSettings.Bandwidth = ""
+	existingWithoutSettings.Capture = nil
+	existingWithoutSettings.AdminDown = false
+
+	desiredWithoutSettings := *desiredTunnel
+	desiredWithoutSettings.Impairment = nil
+	desiredWithoutSettings.Bandwidth = ""
+	desiredWithoutSettings.Capture = nil
+	desiredWithoutSettings.AdminDown = false
+
+	return reflect.DeepEqual(existingWithoutSettings, desiredWithoutSettings)
 }
@@ -14,7 +14,8 @@ import (
 	claberneteslogging "github.com/srl-labs/clabernetes/logging"
 )
 
-// NewManager returns a connectivity Manager for the given connectivity flavor.
+// NewManager returns a connectivity Manager for the given connectivity flavor. If any links use
+// other (link connectivity) flavors as well a manager running a manager per flavor is returned.
 func NewManager(
 	ctx context.Context,
 	cancelChan chan bool,
@@ -22,6 +23,7 @@ func NewManager(
 	clabernetesClient *clabernetesgeneratedclientset.Clientset,
 	initialTunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
 	connectivityKind string,
+	linkConnectivityKinds []string,
 ) (Manager, error) {
 	c := &common{
 		ctx:               ctx,
@@ -29,8 +31,19 @@ func NewManager(
 		logger:            logger,
 		clabernetesClient: clabernetesClient,
 		initialTunnels:    initialTunnels,
+		tunnelStatuses:    &tunnelStatusTracker{},
+		connectivityKind:  connectivityKind,
 	}
 
+	if len(linkConnectivityKinds) > 0 {
+		return newMultiManager(c, linkConnectivityKinds)
+	}
+
+	return newManager(c, connectivityKind)
+}
+
+// newManager returns the connectivity Manager of the given flavor using the given common.
+func newManager(c *common, connectivityKind string) (Manager, error) {
 	switch connectivityKind {
 	case clabernetesconstants.ConnectivityVXLAN:
 		return &vxlanManager{
@@ -40,10 +53,26 @@ func NewManager(
 		return &slurpeethManager{
 			common: c,
 		}, nil
+	case clabernetesconstants.ConnectivityGeneve:
+		return &geneveManager{
+			common: c,
+		}, nil
+	case clabernetesconstants.ConnectivityWireGuard:
+		return &wireGuardManager{
+			common: c,
+		}, nil
+	case clabernetesconstants.ConnectivityGRE:
+		return &greManager{
+			common: c,
+		}, nil
 	case clabernetesconstants.ConnectivityMultus:
 		// With Multus connectivity there is no in-pod tunnel process to run; Multus handles link
 		// wiring via NADs at pod creation time.
 		return &noopManager{}, nil
+	case clabernetesconstants.ConnectivityLoopback:
+		return &loopbackManager{
+			common: c,
+		}, nil
 	default:
 		return nil, fmt.Errorf(
 			"%w: unknown connectivity kind, cannot create connectivity manager",
@@ -55,3 +84,32 @@ func NewManager(
 type noopManager struct{}
 
 func (m *noopManager) Run() {}
+
+// TunnelInterfaceNames returns the pod side veth name and the tunnel interface name of the given
+// tunnel for the given connectivity flavor. The tunnel interface name is empty for flavors that
+// do not use a kernel tunnel interface (slurpeeth).
+func TunnelInterfaceNames(
+	connectivityKind string,
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) (hostLink, tunnelLink string) {
+	prefix := ""
+
+	switch connectivityKind {
+	case clabernetesconstants.ConnectivityVXLAN:
+		prefix = vxlanInterfacePrefix
+	case clabernetesconstants.ConnectivityGeneve:
+		prefix = geneveInterfacePrefix
+	case clabernetesconstants.ConnectivityGRE:
+		prefix = greInterfacePrefix
+	case clabernetesconstants.ConnectivityWireGuard:
+		prefix = wireGuardInterfacePrefix
+	}
+
+	hostLink, tunnelLink = tunnelInterfaceNames(prefix, tunnel.LocalNode, tunnel.LocalInterface)
+
+	if prefix == "" {
+		return hostLink, ""
+	}
+
+	return hostLink, tunnelLink
+}
@@ -14,7 +14,8 @@ import (
 	claberneteslogging "github.com/srl-labs/clabernetes/logging"
 )
 
-// NewManager returns a connectivity Manager for the given connectivity flavor.
+// NewManager returns a connectivity Manager for the given connectivity flavor. If any links use
+// other (link connectivity) flavors as well a manager running a manager per flavor is returned.
 func NewManager(
 	ctx context.Context,
 	cancelChan chan bool,
@@ -22,6 +23,7 @@ func NewManager(
 	clabernetesClient *clabernetesgeneratedclientset.Clientset,
 	initialTunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
 	connectivityKind string,
+	linkConnectivityKinds []string,
 ) (Manager, error) {
 	c := &common{
 		ctx:               ctx,
@@ -29,13 +31,28 @@ func NewManager(
 		logger:            logger,
 		clabernetesClient: clabernetesClient,
 		initialTunnels:    initialTunnels,
+		tunnelStatuses:    &tunnelStatusTracker{},
+		connectivityKind:  connectivityKind,
 	}
 
+	if len(linkConnectivityKinds) > 0 {
+		return newMultiManager(c, linkConnectivityKinds)
+	}
+
+	return newManager(c, connectivityKind)
+}
+
+// newManager returns the connectivity Manager of the given flavor using the given common.
+func newManager(c *common, connectivityKind string) (Manager, error) {
 	switch connectivityKind {
 	case clabernetesconstants.ConnectivityVXLAN:
 		return &vxlanManager{
 			common: c,
 		}, nil
+	case clabernetesconstants.ConnectivityLoopback:
+		return &loopbackManager{
+			common: c,
+		}, nil
 	default:
 		// just excluding slurpeeth for easy testing/linting reasons basically since we assume this
 		// will only ever run on linux anyway
@@ -45,3 +62,19 @@ func NewManager(
 		)
 	}
 }
+
+// TunnelInterfaceNames returns the pod side veth name and the tunnel interface name of the given
+// tunnel for the given connectivity flavor. The tunnel interface name is empty for flavors that
+// do not use a kernel tunnel interface (slurpeeth).
+func TunnelInterfaceNames(
+	connectivityKind string,
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) (hostLink, tunnelLink string) {
+	if connectivityKind != clabernetesconstants.ConnectivityVXLAN {
+		hostLink, _ = tunnelInterfaceNames("", tunnel.LocalNode, tunnel.LocalInterface)
+
+		return hostLink, ""
+	}
+
+	return tunnelInterfaceNames(vxlanInterfacePrefix, tunnel.LocalNode, tunnel.LocalInterface)
+}
@@ -0,0 +1,210 @@
+package connectivity
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+const (
+	metricsReadHeaderTimeout = 5 * time.Second
+)
+
+// linkStatsLabels are the labels of the link metrics -- the containerlab endpoint of the link (and
+// its node and interface on their own) so users can reason about links in topology terms, and the
+// (sanitized) linux interface in case they need to go poke at the interface itself.
+var linkStatsLabels = []string{ //nolint:gochecknoglobals
+	"clab_endpoint",
+	"clab_node",
+	"clab_interface",
+	"linux_interface",
+}
+
+var (
+	linkReceiveBytesDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		"clabernetes_link_receive_bytes_total",
+		"Number of bytes the node received over the link.",
+		linkStatsLabels,
+		nil,
+	)
+	linkTransmitBytesDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		"clabernetes_link_transmit_bytes_total",
+		"Number of bytes the node sent over the link.",
+		linkStatsLabels,
+		nil,
+	)
+	linkReceivePacketsDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		"clabernetes_link_receive_packets_total",
+		"Number of packets the node received over the link.",
+		linkStatsLabels,
+		nil,
+	)
+	linkTransmitPacketsDesc = prometheus.NewDesc( //nolint:gochecknoglobals
+		"clabernetes_link_transmit_packets_total",
+		"Number of packets the node sent over the link.",
+		linkStatsLabels,
+		nil,
+	)
+)
+
+// LinkStats holds the traffic counters of a link of this launcher, as seen by the node.
+type LinkStats struct {
+	// Endpoint is the containerlab endpoint of the link in "node:interface" form, for example
+	// "r1:Ethernet0/1".
+	Endpoint string
+	// LinuxInterface is the name of the pod side interface of the link, this is the sanitized
+	// (and possibly hashed) form of the endpoint as linux interface names are rather limited.
+	LinuxInterface string
+	// Counters holds the counters of the link, nil if they could not be read (in which case Err is
+	// set).
+	Counters *clabernetesapisv1alpha1.TunnelCounters
+	Err      error
+}
+
+// ReadLinkStats reads the traffic counters of the links of the given tunnels, sorted by endpoint.
+func ReadLinkStats(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) []LinkStats {
+	endpoints := make([]string, 0, len(tunnels))
+
+	for _, tunnel := range tunnels {
+		endpoints = append(endpoints, tunnelKey(tunnel))
+	}
+
+	return readLinkStats(endpoints)
+}
+
+// readLinkStats reads the traffic counters of the links of the given endpoints (tunnel keys),
+// sorted by endpoint.
+func readLinkStats(endpoints []string) []LinkStats {
+	slices.Sort(endpoints)
+
+	stats := make([]LinkStats, 0, len(endpoints))
+
+	for _, endpoint := range slices.Compact(endpoints) {
+		localNode, localInterface := splitTunnelKey(endpoint)
+
+		hostLink, _ := tunnelInterfaceNames("", localNode, localInterface)
+
+		counters, err := readTunnelCounters(hostLink)
+
+		stats = append(
+			stats,
+			LinkStats{
+				Endpoint:       endpoint,
+				LinuxInterface: hostLink,
+				Counters:       counters,
+				Err:            err,
+			},
+		)
+	}
+
+	return stats
+}
+
+// linkStatsCollector is a prometheus collector exporting the traffic counters of the links of the
+// tunnels of this launcher, the counters are read fresh on every scrape.
+type linkStatsCollector struct {
+	c *common
+}
+
+func (l *linkStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- linkReceiveBytesDesc
+
+	ch <- linkTransmitBytesDesc
+
+	ch <- linkReceivePacketsDesc
+
+	ch <- linkTransmitPacketsDesc
+}
+
+func (l *linkStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	l.c.tunnelStatuses.lock.Lock()
+
+	endpoints := make([]string, 0, len(l.c.tunnelStatuses.statuses))
+
+	for key := range l.c.tunnelStatuses.statuses {
+		endpoints = append(endpoints, key)
+	}
+
+	l.c.tunnelStatuses.lock.Unlock()
+
+	for _, linkStats := range readLinkStats(endpoints) {
+		if linkStats.Err != nil {
+			l.c.logger.Debugf(
+				"failed reading traffic counters of local interface '%s', error: %s",
+				linkStats.Endpoint,
+				linkStats.Err,
+			)
+
+			continue
+		}
+
+		localNode, localInterface := splitTunnelKey(linkStats.Endpoint)
+
+		labelValues := []string{
+			linkStats.Endpoint,
+			localNode,
+			localInterface,
+			linkStats.LinuxInterface,
+		}
+
+		for desc, value := range map[*prometheus.Desc]int64{
+			linkReceiveBytesDesc:    linkStats.Counters.RxBytes,
+			linkTransmitBytesDesc:   linkStats.Counters.TxBytes,
+			linkReceivePacketsDesc:  linkStats.Counters.RxPackets,
+			linkTransmitPacketsDesc: linkStats.Counters.TxPackets,
+		} {
+			ch <- prometheus.MustNewConstMetric(
+				desc,
+				prometheus.CounterValue,
+				float64(value),
+				labelValues...,
+			)
+		}
+	}
+}
+
+// startMetrics starts, in the background, serving the prometheus metrics of this launcher -- the
+// traffic counters of the links of its tunnels, labeled with their containerlab endpoints.
+func (c *common) startMetrics() {
+	if c.multi != nil {
+		// managers running as part of a multiManager leave this to the multiManager
+		return
+	}
+
+	c.logger.Debugf("serving metrics on port %d...", clabernetesconstants.MetricsServicePort)
+
+	registry := prometheus.NewRegistry()
+
+	registry.MustRegister(&linkStatsCollector{c: c})
+
+	mux := http.NewServeMux()
+
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", clabernetesconstants.MetricsServicePort),
+		Handler:           mux,
+		ReadHeaderTimeout: metricsReadHeaderTimeout,
+	}
+
+	go func() {
+		<-c.ctx.Done()
+
+		_ = server.Close()
+	}()
+
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			// metrics are only informational, the launcher is fine without them
+			c.logger.Warnf("failed serving metrics, err: %s", err)
+		}
+	}()
+}
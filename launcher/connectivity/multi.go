@@ -0,0 +1,189 @@
+package connectivity
+
+import (
+	"slices"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+)
+
+// TunnelConnectivity returns the connectivity flavor of the given tunnel -- tunnels only carry a
+// flavor if their link uses a different one than the topology (see the topology linkConnectivity),
+// otherwise they use the given default (topology) flavor.
+func TunnelConnectivity(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+	defaultConnectivityKind string,
+) string {
+	if tunnel.Connectivity == "" {
+		return defaultConnectivityKind
+	}
+
+	return tunnel.Connectivity
+}
+
+// multiManager runs a connectivity manager per connectivity flavor the links of the topology use
+// -- each manager only ever sees the tunnels of its own flavor. The managers share the tunnel
+// statuses, the multi manager runs everything that is not specific to a flavor (self-test, traffic
+// counters, metrics, liveness probes and the connectivity cr watch) once for all of them.
+type multiManager struct {
+	*common
+	kinds    []string
+	managers map[string]Manager
+	handlers map[string]func(nodeTunnels []*clabernetesapisv1alpha1.PointToPointTunnel)
+	// applied holds the tunnel keys each manager was last handed, so we know which tunnels a
+	// manager has to let go of when links move between flavors
+	applied map[string][]string
+}
+
+// newMultiManager returns a multiManager running a manager for the connectivity flavor of the
+// given common and each of the given (additional) link connectivity flavors.
+func newMultiManager(c *common, linkConnectivityKinds []string) (Manager, error) {
+	m := &multiManager{
+		common:   c,
+		kinds:    []string{c.connectivityKind},
+		managers: map[string]Manager{},
+		handlers: map[string]func([]*clabernetesapisv1alpha1.PointToPointTunnel){},
+		applied:  map[string][]string{},
+	}
+
+	for _, kind := range linkConnectivityKinds {
+		if !slices.Contains(m.kinds, kind) {
+			m.kinds = append(m.kinds, kind)
+		}
+	}
+
+	for _, kind := range m.kinds {
+		kindCommon := &common{
+			ctx:               c.ctx,
+			cancelChan:        c.cancelChan,
+			logger:            c.logger,
+			clabernetesClient: c.clabernetesClient,
+			initialTunnels:    m.kindTunnels(kind, c.initialTunnels),
+			tunnelStatuses:    c.tunnelStatuses,
+			connectivityKind:  kind,
+			multi:             m,
+		}
+
+		manager, err := newManager(kindCommon, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		m.managers[kind] = manager
+		m.applied[kind] = tunnelKeys(kindCommon.initialTunnels)
+	}
+
+	return m, nil
+}
+
+func (m *multiManager) Run() {
+	m.logger.Infof(
+		"connectivity modes are %q, setting up the managers of all of them...",
+		m.kinds,
+	)
+
+	for _, kind := range m.kinds {
+		m.managers[kind].Run()
+	}
+
+	m.startSelfTest()
+
+	m.startTrafficCounters()
+
+	m.startMetrics()
+
+	m.startLivenessProbes()
+
+	m.logger.Debug("start connectivity custom resource watch...")
+
+	go m.watchConnectivity(m.handleUpdate)
+
+	m.logger.Debug("multi connectivity setup complete")
+}
+
+// register records the connectivity cr update handler of the manager of the given flavor, the
+// managers call this rather than watching the connectivity cr themselves.
+func (m *multiManager) register(
+	kind string,
+	handleUpdate func(nodeTunnels []*clabernetesapisv1alpha1.PointToPointTunnel),
+) {
+	m.handlers[kind] = handleUpdate
+}
+
+// handleUpdate hands each manager the tunnels of its flavor. Links that moved between flavors are
+// first torn down by the manager that had them (by handing every manager only the tunnels it
+// already had and still keeps) before the manager now responsible for them sets them up -- both
+// end up using the same interfaces after all.
+func (m *multiManager) handleUpdate(nodeTunnels []*clabernetesapisv1alpha1.PointToPointTunnel) {
+	kindTunnels := map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{}
+
+	for _, kind := range m.kinds {
+		kindTunnels[kind] = m.kindTunnels(kind, nodeTunnels)
+	}
+
+	for _, kind := range m.kinds {
+		var keptTunnels []*clabernetesapisv1alpha1.PointToPointTunnel
+
+		for _, tunnel := range kindTunnels[kind] {
+			if slices.Contains(m.applied[kind], tunnelKey(tunnel)) {
+				keptTunnels = append(keptTunnels, tunnel)
+			}
+		}
+
+		if len(keptTunnels) == len(m.applied[kind]) {
+			// nothing moved away from this flavor
+			continue
+		}
+
+		m.handlers[kind](keptTunnels)
+
+		m.applied[kind] = tunnelKeys(keptTunnels)
+	}
+
+	for _, kind := range m.kinds {
+		m.handlers[kind](kindTunnels[kind])
+
+		m.applied[kind] = tunnelKeys(kindTunnels[kind])
+	}
+}
+
+// kindTunnels returns the given tunnels that use the given connectivity flavor.
+func (m *multiManager) kindTunnels(
+	kind string,
+	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+) []*clabernetesapisv1alpha1.PointToPointTunnel {
+	var filteredTunnels []*clabernetesapisv1alpha1.PointToPointTunnel
+
+	for _, tunnel := range tunnels {
+		if TunnelConnectivity(tunnel, m.connectivityKind) == kind {
+			filteredTunnels = append(filteredTunnels, tunnel)
+		}
+	}
+
+	return filteredTunnels
+}
+
+func tunnelKeys(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) []string {
+	keys := make([]string, len(tunnels))
+
+	for idx, tunnel := range tunnels {
+		keys[idx] = tunnelKey(tunnel)
+	}
+
+	return keys
+}
+
+// startConnectivityWatch starts watching the connectivity cr, handing the tunnels of the local
+// nodes to the given handler when they change -- unless the manager runs as part of a
+// multiManager, then the multiManager does the watching and hands the manager only the tunnels of
+// its flavor.
+func (c *common) startConnectivityWatch(
+	handleUpdate func(nodeTunnels []*clabernetesapisv1alpha1.PointToPointTunnel),
+) {
+	if c.multi != nil {
+		c.multi.register(c.connectivityKind, handleUpdate)
+
+		return
+	}
+
+	go c.watchConnectivity(handleUpdate)
+}
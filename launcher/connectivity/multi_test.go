@@ -0,0 +1,184 @@
+package connectivity
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+// handlerCall is a call of a fake connectivity update handler of a multiManager test.
+type handlerCall struct {
+	kind string
+	keys []string
+}
+
+// newTestMultiManager returns a multiManager for the vxlan (topology) and gre (link) flavors with
+// fake update handlers recording their calls, the managers start out with the given tunnels.
+func newTestMultiManager(
+	initialTunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+) (*multiManager, *[]handlerCall) {
+	calls := make([]handlerCall, 0)
+
+	m := &multiManager{
+		common: &common{
+			connectivityKind: clabernetesconstants.ConnectivityVXLAN,
+		},
+		kinds: []string{
+			clabernetesconstants.ConnectivityVXLAN,
+			clabernetesconstants.ConnectivityGRE,
+		},
+		handlers: map[string]func([]*clabernetesapisv1alpha1.PointToPointTunnel){},
+		applied:  map[string][]string{},
+	}
+
+	for _, kind := range m.kinds {
+		m.register(kind, func(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) {
+			calls = append(calls, handlerCall{kind: kind, keys: tunnelKeys(tunnels)})
+		})
+
+		m.applied[kind] = tunnelKeys(m.kindTunnels(kind, initialTunnels))
+	}
+
+	return m, &calls
+}
+
+func TestMultiManagerKindTunnels(t *testing.T) {
+	vxlanTunnel := &clabernetesapisv1alpha1.PointToPointTunnel{
+		LocalNode:      "srl1",
+		LocalInterface: "e1-1",
+	}
+
+	explicitVXLANTunnel := &clabernetesapisv1alpha1.PointToPointTunnel{
+		LocalNode:      "srl1",
+		LocalInterface: "e1-2",
+		Connectivity:   clabernetesconstants.ConnectivityVXLAN,
+	}
+
+	greTunnel := &clabernetesapisv1alpha1.PointToPointTunnel{
+		LocalNode:      "srl1",
+		LocalInterface: "e1-3",
+		Connectivity:   clabernetesconstants.ConnectivityGRE,
+	}
+
+	tunnels := []*clabernetesapisv1alpha1.PointToPointTunnel{
+		vxlanTunnel,
+		explicitVXLANTunnel,
+		greTunnel,
+	}
+
+	m, _ := newTestMultiManager(nil)
+
+	cases := []struct {
+		name     string
+		kind     string
+		expected []*clabernetesapisv1alpha1.PointToPointTunnel
+	}{
+		{
+			name: "topology-flavor",
+			kind: clabernetesconstants.ConnectivityVXLAN,
+			expected: []*clabernetesapisv1alpha1.PointToPointTunnel{
+				vxlanTunnel,
+				explicitVXLANTunnel,
+			},
+		},
+		{
+			name:     "link-flavor",
+			kind:     clabernetesconstants.ConnectivityGRE,
+			expected: []*clabernetesapisv1alpha1.PointToPointTunnel{greTunnel},
+		},
+		{
+			name:     "unused-flavor",
+			kind:     clabernetesconstants.ConnectivityGeneve,
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := m.kindTunnels(testCase.kind, tunnels)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestMultiManagerHandleUpdate(t *testing.T) {
+	tunnelA := &clabernetesapisv1alpha1.PointToPointTunnel{
+		LocalNode:      "srl1",
+		LocalInterface: "e1-1",
+	}
+
+	tunnelB := &clabernetesapisv1alpha1.PointToPointTunnel{
+		LocalNode:      "srl1",
+		LocalInterface: "e1-2",
+	}
+
+	greTunnelB := &clabernetesapisv1alpha1.PointToPointTunnel{
+		LocalNode:      "srl1",
+		LocalInterface: "e1-2",
+		Connectivity:   clabernetesconstants.ConnectivityGRE,
+	}
+
+	cases := []struct {
+		name           string
+		initialTunnels []*clabernetesapisv1alpha1.PointToPointTunnel
+		updateTunnels  []*clabernetesapisv1alpha1.PointToPointTunnel
+		expectedCalls  []handlerCall
+	}{
+		{
+			name:           "nothing-moved",
+			initialTunnels: []*clabernetesapisv1alpha1.PointToPointTunnel{tunnelA},
+			updateTunnels:  []*clabernetesapisv1alpha1.PointToPointTunnel{tunnelA, tunnelB},
+			expectedCalls: []handlerCall{
+				{kind: clabernetesconstants.ConnectivityVXLAN, keys: []string{"srl1:e1-1", "srl1:e1-2"}},
+				{kind: clabernetesconstants.ConnectivityGRE, keys: []string{}},
+			},
+		},
+		{
+			name:           "moved-flavor",
+			initialTunnels: []*clabernetesapisv1alpha1.PointToPointTunnel{tunnelA, tunnelB},
+			updateTunnels: []*clabernetesapisv1alpha1.PointToPointTunnel{
+				tunnelA,
+				greTunnelB,
+			},
+			expectedCalls: []handlerCall{
+				// the vxlan manager lets go of the moved tunnel first...
+				{kind: clabernetesconstants.ConnectivityVXLAN, keys: []string{"srl1:e1-1"}},
+				// ...before each manager gets its tunnels
+				{kind: clabernetesconstants.ConnectivityVXLAN, keys: []string{"srl1:e1-1"}},
+				{kind: clabernetesconstants.ConnectivityGRE, keys: []string{"srl1:e1-2"}},
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				m, calls := newTestMultiManager(testCase.initialTunnels)
+
+				m.handleUpdate(testCase.updateTunnels)
+
+				if !reflect.DeepEqual(*calls, testCase.expectedCalls) {
+					clabernetestesthelper.FailOutput(t, *calls, testCase.expectedCalls)
+				}
+
+				for _, kind := range m.kinds {
+					expected := tunnelKeys(m.kindTunnels(kind, testCase.updateTunnels))
+					if !reflect.DeepEqual(m.applied[kind], expected) {
+						clabernetestesthelper.FailOutput(t, m.applied[kind], expected)
+					}
+				}
+			})
+	}
+}
@@ -0,0 +1,565 @@
+//go:build linux
+// +build linux
+
+package connectivity
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// vxlanOverhead is the encapsulation overhead of vxlan (outer ip/udp/vxlan/ethernet headers),
+	// the vxlan interface mtu is the mtu of its parent interface less this.
+	vxlanOverhead = 50
+)
+
+// linkExists returns true if a link with the given name exists in the pod network namespace.
+func linkExists(name string) bool {
+	_, err := netlink.LinkByName(name)
+
+	return err == nil
+}
+
+// deleteLinkIfExists deletes the link with the given name, it is not an error if the link does not
+// exist.
+func deleteLinkIfExists(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		var notFoundErr netlink.LinkNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil
+		}
+
+		return err
+	}
+
+	return netlink.LinkDel(link)
+}
+
+// deleteIngressQdisc deletes the ingress qdisc (and therefore any tc redirects) of the link with
+// the given name, it is not an error if the link or qdisc do not exist.
+func deleteIngressQdisc(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return nil //nolint:nilerr
+	}
+
+	err = netlink.QdiscDel(&netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	})
+	if err != nil && !errors.Is(err, unix.ENOENT) && !errors.Is(err, unix.EINVAL) {
+		return err
+	}
+
+	return nil
+}
+
+// createVethPair creates (and brings up) a veth pair with the given names.
+func createVethPair(name, peerName string) error {
+	err := netlink.LinkAdd(&netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: name},
+		PeerName:  peerName,
+	})
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed creating veth %q <-> %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			name,
+			peerName,
+			err,
+		)
+	}
+
+	for _, linkName := range []string{name, peerName} {
+		tagLink(linkName)
+
+		err = setLinkUp(linkName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tagLink tags the link with the given name with the owner alias of this launcher (see
+// linkOwnerAlias). Tagging is best effort -- an untagged link is merely not cleaned up.
+func tagLink(name string) {
+	alias := linkOwnerAlias()
+	if alias == "" {
+		return
+	}
+
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return
+	}
+
+	_ = netlink.LinkSetAlias(link, alias)
+}
+
+// TagLink tags the link with the given name as owned by this launcher, so it is cleaned up along
+// with the links the connectivity flavors create -- this is for links the launcher creates
+// outside of the connectivity flavors (segment bridges, vrnetlab management veths).
+func TagLink(name string) {
+	tagLink(name)
+}
+
+// CleanupOwnedLinks deletes the links tagged as owned by this launcher and returns the names of
+// the deleted links. If staleOnly is true only links created by *other* pods of this launcher
+// are deleted, that is, links a previous (hard killed) pod of the launcher left behind in a
+// network namespace that outlived it -- the host network namespace in host network mode.
+func CleanupOwnedLinks(staleOnly bool) ([]string, error) {
+	owner, pod := linkOwner()
+	if owner == "" {
+		return nil, nil
+	}
+
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: failed listing links, error: %w",
+			claberneteserrors.ErrConnectivity,
+			err,
+		)
+	}
+
+	var deletedLinks []string
+
+	for _, link := range links {
+		linkOwner, linkPod, ok := parseLinkOwnerAlias(link.Attrs().Alias)
+		if !ok || linkOwner != owner || (staleOnly && linkPod == pod) {
+			continue
+		}
+
+		err = netlink.LinkDel(link)
+		if err != nil {
+			// deleting one end of a veth pair deletes its peer too, so the peer may be gone already
+			if errors.Is(err, unix.ENODEV) {
+				continue
+			}
+
+			return deletedLinks, fmt.Errorf(
+				"%w: failed deleting link %q, error: %w",
+				claberneteserrors.ErrConnectivity,
+				link.Attrs().Name,
+				err,
+			)
+		}
+
+		deletedLinks = append(deletedLinks, link.Attrs().Name)
+	}
+
+	return deletedLinks, nil
+}
+
+func setLinkUp(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed finding link %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			name,
+			err,
+		)
+	}
+
+	err = netlink.LinkSetUp(link)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed bringing up link %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			name,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func setLinkDown(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed finding link %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			name,
+			err,
+		)
+	}
+
+	err = netlink.LinkSetDown(link)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed bringing down link %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			name,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// deleteTunnelLink deletes the ingress qdisc (and therefore the tc redirects) of the given host
+// link and the given tunnel link, it is not an error if either does not exist.
+func deleteTunnelLink(hostLink, tunnelLink string) error {
+	err := deleteIngressQdisc(hostLink)
+	if err != nil {
+		return err
+	}
+
+	return deleteLinkIfExists(tunnelLink)
+}
+
+// parseTunnelRemote parses the given remote tunnel endpoint address, kind is the tunnel kind for
+// the error message.
+func parseTunnelRemote(kind, remote string) (net.IP, error) {
+	remoteIP := net.ParseIP(remote)
+	if remoteIP == nil {
+		return nil, fmt.Errorf(
+			"%w: remote %s endpoint %q is not an ip address",
+			claberneteserrors.ErrConnectivity,
+			kind,
+			remote,
+		)
+	}
+
+	return remoteIP, nil
+}
+
+// addTunnelLink creates the given tunnel link, tags it, brings it up and wires it to the given
+// (host side) link with tc redirects in both directions.
+func addTunnelLink(link netlink.Link, hostLink string) error {
+	name := link.Attrs().Name
+
+	err := netlink.LinkAdd(link)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed creating %s interface %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			link.Type(),
+			name,
+			err,
+		)
+	}
+
+	tagLink(name)
+
+	err = setLinkUp(name)
+	if err != nil {
+		return err
+	}
+
+	return tcRedirect(hostLink, name)
+}
+
+// createVxlanLink creates a vxlan link to the given remote and wires it to the given (host side)
+// link with tc redirects in both directions -- this is what "containerlab tools vxlan create" does.
+// The vxlan parent interface is the interface that the route to the remote goes out of.
+func createVxlanLink(name, hostLink, remote string, vni, port int) error {
+	remoteIP, err := parseTunnelRemote("vxlan", remote)
+	if err != nil {
+		return err
+	}
+
+	routes, err := netlink.RouteGet(remoteIP)
+	if err != nil || len(routes) == 0 {
+		return fmt.Errorf(
+			"%w: failed finding route to remote vxlan endpoint %q, error: %v",
+			claberneteserrors.ErrConnectivity,
+			remote,
+			err,
+		)
+	}
+
+	parentLink, err := netlink.LinkByIndex(routes[0].LinkIndex)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed finding vxlan parent interface, error: %w",
+			claberneteserrors.ErrConnectivity,
+			err,
+		)
+	}
+
+	return addTunnelLink(
+		&netlink.Vxlan{
+			LinkAttrs: netlink.LinkAttrs{
+				Name: name,
+				MTU:  parentLink.Attrs().MTU - vxlanOverhead,
+			},
+			VxlanId:      vni,
+			VtepDevIndex: parentLink.Attrs().Index,
+			Group:        remoteIP,
+			Learning:     true,
+			Port:         port,
+		},
+		hostLink,
+	)
+}
+
+// geneveLink returns the geneve link with the given name and id to the given remote.
+func geneveLink(name, remote string, id, port int) (*netlink.Geneve, error) {
+	remoteIP, err := parseTunnelRemote("geneve", remote)
+	if err != nil {
+		return nil, err
+	}
+
+	return &netlink.Geneve{
+		LinkAttrs: netlink.LinkAttrs{Name: name},
+		ID:        uint32(id), //nolint:gosec
+		Remote:    remoteIP,
+		Dport:     uint16(port), //nolint:gosec
+	}, nil
+}
+
+// createGeneveLink creates a geneve link to the given remote and wires it to the given (host side)
+// link with tc redirects in both directions.
+func createGeneveLink(name, hostLink, remote string, id, port int) error {
+	link, err := geneveLink(name, remote, id, port)
+	if err != nil {
+		return err
+	}
+
+	return addTunnelLink(link, hostLink)
+}
+
+// gretapLink returns the gretap link with the given name to the given remote, the key is used in
+// both directions.
+func gretapLink(name, remote string, key int) (*netlink.Gretap, error) {
+	remoteIP, err := parseTunnelRemote("gre", remote)
+	if err != nil {
+		return nil, err
+	}
+
+	// netlink picks gretap vs ip6gretap by the local address, any address of the remote family
+	// leaves the local address up to the kernel, just like "ip link add type gretap" does
+	localIP := net.IPv4zero
+	if remoteIP.To4() == nil {
+		localIP = net.IPv6zero
+	}
+
+	return &netlink.Gretap{
+		LinkAttrs: netlink.LinkAttrs{Name: name},
+		IKey:      uint32(key), //nolint:gosec
+		OKey:      uint32(key), //nolint:gosec
+		Local:     localIP,
+		Remote:    remoteIP,
+		// path mtu discovery is what "ip link add type gretap" defaults to as well
+		PMtuDisc: 1,
+	}, nil
+}
+
+// createGretapLink creates a gretap link to the given remote and wires it to the given (host side)
+// link with tc redirects in both directions.
+func createGretapLink(name, hostLink, remote string, key int) error {
+	link, err := gretapLink(name, remote, key)
+	if err != nil {
+		return err
+	}
+
+	return addTunnelLink(link, hostLink)
+}
+
+// wireGuardVxlanLink returns the vxlan link with the given name between the given local and
+// remote wireguard overlay addresses, riding over the wireguard interface with the given index.
+func wireGuardVxlanLink(
+	name, remote, local string,
+	parentIndex, vni, port int,
+) (*netlink.Vxlan, error) {
+	remoteIP, err := parseTunnelRemote("wireguard", remote)
+	if err != nil {
+		return nil, err
+	}
+
+	localIP, err := parseTunnelRemote("wireguard", local)
+	if err != nil {
+		return nil, err
+	}
+
+	return &netlink.Vxlan{
+		LinkAttrs:    netlink.LinkAttrs{Name: name},
+		VxlanId:      vni,
+		VtepDevIndex: parentIndex,
+		SrcAddr:      localIP,
+		Group:        remoteIP,
+		Learning:     true,
+		Port:         port,
+	}, nil
+}
+
+// createWireGuardVxlanLink creates a vxlan link over the given wireguard interface between the
+// given local and remote overlay addresses and wires it to the given (host side) link with tc
+// redirects in both directions.
+func createWireGuardVxlanLink(
+	name, hostLink, remote, local, wireGuardLink string,
+	vni, port int,
+) error {
+	parentLink, err := netlink.LinkByName(wireGuardLink)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed finding wireguard interface %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			wireGuardLink,
+			err,
+		)
+	}
+
+	link, err := wireGuardVxlanLink(name, remote, local, parentLink.Attrs().Index, vni, port)
+	if err != nil {
+		return err
+	}
+
+	return addTunnelLink(link, hostLink)
+}
+
+// createWireGuardLink creates (but does not bring up) a wireguard interface with the given name
+// and overlay address -- the keys and peers are set with "wg" as netlink does not cover them.
+func createWireGuardLink(name, address string, prefixLen int) error {
+	err := netlink.LinkAdd(&netlink.Wireguard{LinkAttrs: netlink.LinkAttrs{Name: name}})
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed creating wireguard interface %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			name,
+			err,
+		)
+	}
+
+	tagLink(name)
+
+	addr, err := netlink.ParseAddr(fmt.Sprintf("%s/%d", address, prefixLen))
+	if err != nil {
+		return fmt.Errorf(
+			"%w: invalid wireguard overlay address %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			address,
+			err,
+		)
+	}
+
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return err
+	}
+
+	err = netlink.AddrAdd(link, addr)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed adding address %q to wireguard interface %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			addr,
+			name,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// tcRedirect redirects all traffic ingressing either of the given links out of the other link.
+func tcRedirect(linkAName, linkBName string) error {
+	linkA, err := netlink.LinkByName(linkAName)
+	if err != nil {
+		return err
+	}
+
+	linkB, err := netlink.LinkByName(linkBName)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range [][2]netlink.Link{{linkA, linkB}, {linkB, linkA}} {
+		err = tcRedirectIngress(pair[0], pair[1])
+		if err != nil {
+			return fmt.Errorf(
+				"%w: failed setting up tc redirect from %q to %q, error: %w",
+				claberneteserrors.ErrConnectivity,
+				pair[0].Attrs().Name,
+				pair[1].Attrs().Name,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func tcRedirectIngress(from, to netlink.Link) error {
+	err := netlink.QdiscReplace(&netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: from.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return netlink.FilterAdd(&netlink.MatchAll{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: from.Attrs().Index,
+			Parent:    netlink.MakeHandle(0xffff, 0),
+			Priority:  1,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		Actions: []netlink.Action{
+			netlink.NewMirredAction(to.Attrs().Index),
+		},
+	})
+}
+
+// listVxlanRemotes returns a mapping of vxlan id to remote address for all vxlan interfaces in the
+// pod network namespace.
+func listVxlanRemotes() (map[int]string, error) {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, err
+	}
+
+	vxlanRemotes := map[int]string{}
+
+	for _, link := range links {
+		vxlanLink, ok := link.(*netlink.Vxlan)
+		if !ok || vxlanLink.Group == nil {
+			continue
+		}
+
+		vxlanRemotes[vxlanLink.VxlanId] = vxlanLink.Group.String()
+	}
+
+	return vxlanRemotes, nil
+}
+
+// listVxlanInterfaces returns a mapping of vxlan id to interface name for all vxlan interfaces in
+// the pod network namespace.
+func listVxlanInterfaces() (map[int]string, error) {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, err
+	}
+
+	vxlanInterfaces := map[int]string{}
+
+	for _, link := range links {
+		vxlanLink, ok := link.(*netlink.Vxlan)
+		if !ok {
+			continue
+		}
+
+		vxlanInterfaces[vxlanLink.VxlanId] = vxlanLink.Attrs().Name
+	}
+
+	return vxlanInterfaces, nil
+}
@@ -0,0 +1,145 @@
+package connectivity
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestGeneveLink(t *testing.T) {
+	link, err := geneveLink("gn-srl1-e1-1", "10.0.0.2", 3, 6081)
+	if err != nil {
+		t.Fatalf("failed building geneve link, error: %s", err)
+	}
+
+	if link.Type() != "geneve" {
+		clabernetestesthelper.FailOutput(t, link.Type(), "geneve")
+	}
+
+	if link.Attrs().Name != "gn-srl1-e1-1" {
+		clabernetestesthelper.FailOutput(t, link.Attrs().Name, "gn-srl1-e1-1")
+	}
+
+	if link.ID != 3 {
+		clabernetestesthelper.FailOutput(t, link.ID, 3)
+	}
+
+	if !link.Remote.Equal(net.ParseIP("10.0.0.2")) {
+		clabernetestesthelper.FailOutput(t, link.Remote, "10.0.0.2")
+	}
+
+	if link.Dport != 6081 {
+		clabernetestesthelper.FailOutput(t, link.Dport, 6081)
+	}
+}
+
+func TestGretapLink(t *testing.T) {
+	link, err := gretapLink("gr-srl1-e1-1", "10.0.0.2", 7)
+	if err != nil {
+		t.Fatalf("failed building gretap link, error: %s", err)
+	}
+
+	if link.Type() != "gretap" {
+		clabernetestesthelper.FailOutput(t, link.Type(), "gretap")
+	}
+
+	if link.IKey != 7 || link.OKey != 7 {
+		clabernetestesthelper.FailOutput(t, []uint32{link.IKey, link.OKey}, []uint32{7, 7})
+	}
+
+	if !link.Remote.Equal(net.ParseIP("10.0.0.2")) {
+		clabernetestesthelper.FailOutput(t, link.Remote, "10.0.0.2")
+	}
+
+	if link.PMtuDisc != 1 {
+		clabernetestesthelper.FailOutput(t, link.PMtuDisc, 1)
+	}
+
+	link, err = gretapLink("gr-srl1-e1-1", "fd00::2", 7)
+	if err != nil {
+		t.Fatalf("failed building gretap link, error: %s", err)
+	}
+
+	if link.Type() != "ip6gretap" {
+		clabernetestesthelper.FailOutput(t, link.Type(), "ip6gretap")
+	}
+}
+
+func TestWireGuardVxlanLink(t *testing.T) {
+	link, err := wireGuardVxlanLink("wv-srl1-e1-1", "100.64.0.2", "100.64.0.1", 12, 5, 14789)
+	if err != nil {
+		t.Fatalf("failed building wireguard vxlan link, error: %s", err)
+	}
+
+	if link.Type() != "vxlan" {
+		clabernetestesthelper.FailOutput(t, link.Type(), "vxlan")
+	}
+
+	if link.VxlanId != 5 {
+		clabernetestesthelper.FailOutput(t, link.VxlanId, 5)
+	}
+
+	if link.VtepDevIndex != 12 {
+		clabernetestesthelper.FailOutput(t, link.VtepDevIndex, 12)
+	}
+
+	if !link.Group.Equal(net.ParseIP("100.64.0.2")) {
+		clabernetestesthelper.FailOutput(t, link.Group, "100.64.0.2")
+	}
+
+	if !link.SrcAddr.Equal(net.ParseIP("100.64.0.1")) {
+		clabernetestesthelper.FailOutput(t, link.SrcAddr, "100.64.0.1")
+	}
+
+	if link.Port != 14789 {
+		clabernetestesthelper.FailOutput(t, link.Port, 14789)
+	}
+}
+
+func TestTunnelLinkInvalidRemote(t *testing.T) {
+	cases := []struct {
+		name  string
+		build func() error
+	}{
+		{
+			name: "geneve",
+			build: func() error {
+				_, err := geneveLink("gn-srl1-e1-1", "srl2.default.svc", 1, 6081)
+
+				return err
+			},
+		},
+		{
+			name: "gre",
+			build: func() error {
+				_, err := gretapLink("gr-srl1-e1-1", "", 1)
+
+				return err
+			},
+		},
+		{
+			name: "wireguard-local",
+			build: func() error {
+				_, err := wireGuardVxlanLink("wv-srl1-e1-1", "100.64.0.2", "nope", 1, 1, 14789)
+
+				return err
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				err := testCase.build()
+				if !errors.Is(err, claberneteserrors.ErrConnectivity) {
+					clabernetestesthelper.FailOutput(t, err, claberneteserrors.ErrConnectivity)
+				}
+			})
+	}
+}
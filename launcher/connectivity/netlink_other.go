@@ -0,0 +1,65 @@
+//go:build !linux
+// +build !linux
+
+package connectivity
+
+import (
+	"fmt"
+
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+func errNetlinkUnsupported() error {
+	return fmt.Errorf(
+		"%w: link management is only supported on linux",
+		claberneteserrors.ErrConnectivity,
+	)
+}
+
+func linkExists(_ string) bool {
+	return false
+}
+
+func deleteLinkIfExists(_ string) error {
+	return errNetlinkUnsupported()
+}
+
+func deleteIngressQdisc(_ string) error {
+	return errNetlinkUnsupported()
+}
+
+func setLinkUp(_ string) error {
+	return errNetlinkUnsupported()
+}
+
+func setLinkDown(_ string) error {
+	return errNetlinkUnsupported()
+}
+
+func createVethPair(_, _ string) error {
+	return errNetlinkUnsupported()
+}
+
+// TagLink is a no-op outside of linux.
+func TagLink(_ string) {}
+
+// CleanupOwnedLinks is not supported outside of linux.
+func CleanupOwnedLinks(_ bool) ([]string, error) {
+	return nil, errNetlinkUnsupported()
+}
+
+func deleteTunnelLink(_, _ string) error {
+	return errNetlinkUnsupported()
+}
+
+func createVxlanLink(_, _, _ string, _, _ int) error {
+	return errNetlinkUnsupported()
+}
+
+func listVxlanRemotes() (map[int]string, error) {
+	return nil, errNetlinkUnsupported()
+}
+
+func listVxlanInterfaces() (map[int]string, error) {
+	return nil, errNetlinkUnsupported()
+}
@@ -0,0 +1,21 @@
+package connectivity
+
+import (
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+)
+
+// tunnelKey returns the key tunnels (and their statuses, captures and link states) are tracked by
+// -- the local node and interface in containerlab endpoint form ("node:interface"), which is also
+// how tunnels show up in metrics and counters.
+func tunnelKey(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) string {
+	return tunnel.LocalNode + ":" + tunnel.LocalInterface
+}
+
+// splitTunnelKey returns the local node and local interface of the given tunnel key.
+func splitTunnelKey(key string) (localNode, localInterface string) {
+	localNode, localInterface, _ = strings.Cut(key, ":")
+
+	return localNode, localInterface
+}
@@ -0,0 +1,74 @@
+package connectivity
+
+import (
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestTunnelKey(t *testing.T) {
+	cases := []struct {
+		name              string
+		tunnel            *clabernetesapisv1alpha1.PointToPointTunnel
+		expectedKey       string
+		expectedNode      string
+		expectedInterface string
+	}{
+		{
+			name: "simple",
+			tunnel: &clabernetesapisv1alpha1.PointToPointTunnel{
+				LocalNode:      "srl1",
+				LocalInterface: "e1-1",
+			},
+			expectedKey:       "srl1:e1-1",
+			expectedNode:      "srl1",
+			expectedInterface: "e1-1",
+		},
+		{
+			name: "interface-with-colon",
+			tunnel: &clabernetesapisv1alpha1.PointToPointTunnel{
+				LocalNode:      "srl1",
+				LocalInterface: "ethernet-1/1:1",
+			},
+			expectedKey:       "srl1:ethernet-1/1:1",
+			expectedNode:      "srl1",
+			expectedInterface: "ethernet-1/1:1",
+		},
+		{
+			name: "no-local-node",
+			tunnel: &clabernetesapisv1alpha1.PointToPointTunnel{
+				LocalInterface: "eth1",
+			},
+			expectedKey:       ":eth1",
+			expectedNode:      "",
+			expectedInterface: "eth1",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				key := tunnelKey(testCase.tunnel)
+				if key != testCase.expectedKey {
+					clabernetestesthelper.FailOutput(t, key, testCase.expectedKey)
+				}
+
+				localNode, localInterface := splitTunnelKey(key)
+				if localNode != testCase.expectedNode {
+					clabernetestesthelper.FailOutput(t, localNode, testCase.expectedNode)
+				}
+
+				if localInterface != testCase.expectedInterface {
+					clabernetestesthelper.FailOutput(
+						t,
+						localInterface,
+						testCase.expectedInterface,
+					)
+				}
+			})
+	}
+}
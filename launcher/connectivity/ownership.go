@@ -0,0 +1,58 @@
+package connectivity
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+// linkOwnerAliasPrefix is the prefix of the alias (ifalias) launchers tag the links they create
+// with -- the alias records the owning launcher (namespace/topology/node) and the pod that created
+// the link, so stale links can be told apart from those of other launchers (or anything else)
+// sharing the network namespace, which is the host network namespace in host network mode.
+const linkOwnerAliasPrefix = "clabernetes:"
+
+// linkOwner returns the owning launcher ("namespace/topology/node") and the pod name links created
+// by this launcher are tagged with, the owner is empty if the launcher does not know who it is
+// (for example when running outside a launcher pod) in which case links are not tagged.
+func linkOwner() (owner, pod string) {
+	namespace := os.Getenv(clabernetesconstants.PodNamespaceEnv)
+	topologyName := os.Getenv(clabernetesconstants.LauncherTopologyNameEnv)
+	nodeName := os.Getenv(clabernetesconstants.LauncherNodeNameEnv)
+	pod = os.Getenv(clabernetesconstants.PodNameEnv)
+
+	if namespace == "" || topologyName == "" || nodeName == "" || pod == "" {
+		return "", ""
+	}
+
+	return fmt.Sprintf("%s/%s/%s", namespace, topologyName, nodeName), pod
+}
+
+// linkOwnerAlias returns the alias links created by this launcher are tagged with, or an empty
+// string if links are not tagged.
+func linkOwnerAlias() string {
+	owner, pod := linkOwner()
+	if owner == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s%s@%s", linkOwnerAliasPrefix, owner, pod)
+}
+
+// parseLinkOwnerAlias returns the owning launcher and pod recorded in the given link alias, and
+// false if the alias is not a clabernetes owner alias.
+func parseLinkOwnerAlias(alias string) (owner, pod string, ok bool) {
+	tag, ok := strings.CutPrefix(alias, linkOwnerAliasPrefix)
+	if !ok {
+		return "", "", false
+	}
+
+	owner, pod, ok = strings.Cut(tag, "@")
+	if !ok || owner == "" || pod == "" {
+		return "", "", false
+	}
+
+	return owner, pod, true
+}
@@ -0,0 +1,379 @@
+package connectivity
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	selfTestModeLatency    byte = 'l'
+	selfTestModeThroughput byte = 't'
+
+	selfTestDefaultDuration = 5 * time.Second
+	selfTestDialTimeout     = 5 * time.Second
+	selfTestLatencySamples  = 10
+	selfTestBufferSize      = 128 * 1024
+	selfTestMaxAttempts     = 30
+	selfTestRetryInterval   = 10 * time.Second
+
+	// selfTestIdleTimeout is how long the server side of a self-test connection waits on top of
+	// the test duration before giving up on a client that went away.
+	selfTestIdleTimeout = 30 * time.Second
+
+	bitsPerByte = 8
+	bitsPerMbit = 1_000_000
+)
+
+var errSelfTestEcho = errors.New("self-test echo mismatch")
+
+// selfTestDuration returns the duration of the throughput part of the self-test as set by the
+// controller, or zero if the self-test is not enabled for this topology.
+func selfTestDuration() time.Duration {
+	durationSeconds, ok := os.LookupEnv(clabernetesconstants.LauncherSelfTestDurationEnv)
+	if !ok {
+		return 0
+	}
+
+	seconds, err := strconv.Atoi(durationSeconds)
+	if err != nil || seconds < 1 {
+		return selfTestDefaultDuration
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// startSelfTest starts the self-test server (so the remote launchers can test against us) and,
+// in the background, runs the self-test over the cluster network path of each of the initial
+// tunnels -- if the self-test is enabled that is. The results end up in the tunnel statuses of the
+// connectivity cr.
+func (c *common) startSelfTest() {
+	duration := selfTestDuration()
+	if duration == 0 || c.multi != nil {
+		// managers running as part of a multiManager leave this to the multiManager
+		return
+	}
+
+	c.logger.Debug("self-test enabled, starting self-test server...")
+
+	go c.runSelfTestServer(duration)
+
+	go c.runSelfTests(c.initialTunnels, duration)
+}
+
+func (c *common) runSelfTestServer(duration time.Duration) {
+	listenConfig := net.ListenConfig{}
+
+	listener, err := listenConfig.Listen(
+		c.ctx,
+		"tcp",
+		fmt.Sprintf(":%d", clabernetesconstants.SelfTestServicePort),
+	)
+	if err != nil {
+		c.logger.Warnf("failed starting self-test server, err: %s", err)
+
+		return
+	}
+
+	go func() {
+		<-c.ctx.Done()
+
+		_ = listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if c.ctx.Err() != nil {
+				return
+			}
+
+			c.logger.Warnf("failed accepting self-test connection, err: %s", err)
+
+			continue
+		}
+
+		go c.handleSelfTestConn(conn, duration)
+	}
+}
+
+func (c *common) handleSelfTestConn(conn net.Conn, duration time.Duration) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_ = conn.SetDeadline(time.Now().Add(duration + selfTestIdleTimeout))
+
+	mode := make([]byte, 1)
+
+	_, err := io.ReadFull(conn, mode)
+	if err != nil {
+		return
+	}
+
+	switch mode[0] {
+	case selfTestModeLatency:
+		_, err = io.Copy(conn, conn)
+	case selfTestModeThroughput:
+		_, err = io.Copy(io.Discard, conn)
+	default:
+		err = fmt.Errorf("unknown self-test mode %q", mode[0])
+	}
+
+	if err != nil {
+		c.logger.Debugf(
+			"self-test connection from %s failed, err: %s",
+			conn.RemoteAddr().String(),
+			err,
+		)
+	}
+}
+
+// runSelfTests runs the self-test for each of the given tunnels one after the other, so the tests
+// do not compete with each other for bandwidth. Tunnels to the same remote launcher share a path
+// through the cluster network, so those are only tested once.
+func (c *common) runSelfTests(
+	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+	duration time.Duration,
+) {
+	results := map[string]*clabernetesapisv1alpha1.TunnelSelfTestResult{}
+
+	for _, tunnel := range tunnels {
+		result, ok := results[tunnel.Destination]
+		if !ok {
+			result = c.runSelfTest(tunnel.Destination, duration)
+			if result == nil {
+				// context cancelled, we are shutting down
+				return
+			}
+
+			results[tunnel.Destination] = result
+		}
+
+		c.reportSelfTestResult(tunnel, result)
+	}
+
+	c.logger.Debug("self-test complete")
+}
+
+// runSelfTest tests the latency and throughput towards the launcher behind the given destination,
+// retrying for a while as the remote launcher may well not be up yet. Returns nil if the context
+// is cancelled before the test completes.
+func (c *common) runSelfTest(
+	destination string,
+	duration time.Duration,
+) *clabernetesapisv1alpha1.TunnelSelfTestResult {
+	address := net.JoinHostPort(
+		destination,
+		strconv.Itoa(clabernetesconstants.SelfTestServicePort),
+	)
+
+	var err error
+
+	for attempt := range selfTestMaxAttempts {
+		if attempt > 0 {
+			select {
+			case <-c.ctx.Done():
+				return nil
+			case <-time.After(selfTestRetryInterval):
+			}
+		}
+
+		var latency time.Duration
+
+		latency, err = c.selfTestLatency(address)
+		if err != nil {
+			c.logger.Debugf("self-test latency to %q failed, err: %s", destination, err)
+
+			continue
+		}
+
+		var throughput float64
+
+		throughput, err = c.selfTestThroughput(address, duration)
+		if err != nil {
+			c.logger.Debugf("self-test throughput to %q failed, err: %s", destination, err)
+
+			continue
+		}
+
+		c.logger.Infof(
+			"self-test to %q complete, latency %s, throughput %.2fMbit/s",
+			destination,
+			latency,
+			throughput,
+		)
+
+		return &clabernetesapisv1alpha1.TunnelSelfTestResult{
+			Latency:      latency.String(),
+			Throughput:   fmt.Sprintf("%.2fMbit/s", throughput),
+			LastTestTime: metav1.Now(),
+		}
+	}
+
+	c.logger.Warnf(
+		"self-test to %q failed after %d attempts, err: %s",
+		destination,
+		selfTestMaxAttempts,
+		err,
+	)
+
+	return &clabernetesapisv1alpha1.TunnelSelfTestResult{
+		Error:        err.Error(),
+		LastTestTime: metav1.Now(),
+	}
+}
+
+func (c *common) dialSelfTest(address string, mode byte) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: selfTestDialTimeout}
+
+	conn, err := dialer.DialContext(c.ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = conn.Write([]byte{mode})
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// selfTestLatency returns the average round trip time of a handful of single byte echoes.
+func (c *common) selfTestLatency(address string) (time.Duration, error) {
+	conn, err := c.dialSelfTest(address, selfTestModeLatency)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_ = conn.SetDeadline(time.Now().Add(selfTestIdleTimeout))
+
+	request := []byte{0}
+	response := make([]byte, 1)
+
+	var total time.Duration
+
+	for sample := range selfTestLatencySamples {
+		request[0] = byte(sample)
+
+		start := time.Now()
+
+		_, err = conn.Write(request)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = io.ReadFull(conn, response)
+		if err != nil {
+			return 0, err
+		}
+
+		total += time.Since(start)
+
+		if response[0] != request[0] {
+			return 0, errSelfTestEcho
+		}
+	}
+
+	return (total / selfTestLatencySamples).Round(time.Microsecond), nil
+}
+
+// selfTestThroughput streams data to the remote launcher for the given duration and returns the
+// achieved throughput in Mbit/s. The remote only closes the connection once it has read everything
+// we sent, so we wait for that before stopping the clock -- otherwise we would be measuring how
+// fast we can fill our own socket buffers.
+func (c *common) selfTestThroughput(address string, duration time.Duration) (float64, error) {
+	conn, err := c.dialSelfTest(address, selfTestModeThroughput)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_ = conn.SetDeadline(time.Now().Add(duration + selfTestIdleTimeout))
+
+	buf := make([]byte, selfTestBufferSize)
+
+	var sent int64
+
+	start := time.Now()
+	end := start.Add(duration)
+
+	for time.Now().Before(end) {
+		var n int
+
+		n, err = conn.Write(buf)
+
+		sent += int64(n)
+
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	tcpConn, ok := conn.(*net.TCPConn)
+	if ok {
+		err = tcpConn.CloseWrite()
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = io.Copy(io.Discard, conn)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	elapsed := time.Since(start)
+
+	return float64(sent*bitsPerByte) / elapsed.Seconds() / bitsPerMbit, nil
+}
+
+// reportSelfTestResult records the self-test result in the status of the given tunnel and pushes
+// the statuses to the connectivity cr. Not all connectivity flavors track the state of their
+// tunnels (slurpeeth), for those the status is created here.
+func (c *common) reportSelfTestResult(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+	result *clabernetesapisv1alpha1.TunnelSelfTestResult,
+) {
+	c.tunnelStatuses.lock.Lock()
+	defer c.tunnelStatuses.lock.Unlock()
+
+	if c.tunnelStatuses.statuses == nil {
+		c.tunnelStatuses.statuses = map[string]clabernetesapisv1alpha1.TunnelStatus{}
+	}
+
+	status, exists := c.tunnelStatuses.statuses[tunnelKey(tunnel)]
+	if !exists {
+		status = clabernetesapisv1alpha1.TunnelStatus{
+			LocalInterface:     tunnel.LocalInterface,
+			RemoteNode:         tunnel.RemoteNode,
+			RemoteInterface:    tunnel.RemoteInterface,
+			State:              clabernetesconstants.TunnelStateUp,
+			LastTransitionTime: metav1.Now(),
+		}
+	}
+
+	status.SelfTest = result
+
+	c.tunnelStatuses.statuses[tunnelKey(tunnel)] = status
+
+	c.pushTunnelStatuses()
+}
@@ -6,6 +6,7 @@ package connectivity
 import (
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/carlmontanari/slurpeeth/slurpeeth"
@@ -23,6 +24,14 @@ type slurpeethManager struct {
 	*common
 
 	cancelChan chan bool
+
+	// tls is the mutual tls proxy wrapping slurpeeth, nil unless tls is enabled for the topology
+	tls *slurpeethTLSProxy
+
+	// lock guards currentTunnels which are re-rendered when the underlay address of a remote
+	// launcher changes
+	lock           sync.Mutex
+	currentTunnels []*clabernetesapisv1alpha1.PointToPointTunnel
 }
 
 func (m *slurpeethManager) Run() {
@@ -30,9 +39,9 @@ func (m *slurpeethManager) Run() {
 		"containerlab started, connectivity mode is 'slurpeeth', initializing slurpeeth manager...",
 	)
 
-	m.renderSlurpeethConfig(m.initialTunnels)
+	port := slurpeethPort()
 
-	sm, err := slurpeeth.GetManager(
+	options := []slurpeeth.Option{
 		slurpeeth.WithConfigFile(slurpeethConfigPath),
 		slurpeeth.WithLiveReload(true),
 		// timeout is really big for now because there may be weird delays while waiting for images
@@ -42,9 +51,34 @@ func (m *slurpeethManager) Run() {
 		// *probably* we also want to retry if this fails... not sure yet, so we'll try this and see
 		// how it feels
 		slurpeeth.WithWorkerRetry(true),
-	)
+	}
+
+	if slurpeethTLSEnabled() {
+		m.logger.Info("slurpeeth tls enabled, starting slurpeeth tls proxy...")
+
+		m.tls = newSlurpeethTLSProxy(m.common, port)
+
+		m.tls.listen()
+
+		// the proxy owns the slurpeeth port now, slurpeeth itself only talks to the proxy
+		port = slurpeethTLSInternalPort
+
+		options = append(options, slurpeeth.WithListenAddress(slurpeethTLSListenAddress))
+	}
+
+	options = append(options, slurpeeth.WithPort(uint16(port))) //nolint:gosec
+
+	if underlayInterface() != "" {
+		// slurpeeth normally dials the fabric services directly, with an underlay we need to know
+		// the underlay addresses of the remote launchers instead
+		m.watchEndpoints()
+	}
+
+	m.renderSlurpeethConfig(m.initialTunnels)
+
+	sm, err := slurpeeth.GetManager(options...)
 	if err != nil {
-		m.logger.Fatalf(
+		m.fatalf(
 			"failed creating slurpeeth manager, error: %s",
 			err,
 		)
@@ -55,7 +89,7 @@ func (m *slurpeethManager) Run() {
 
 	err = sm.RunDaemon(exitErr, exitDone)
 	if err != nil {
-		m.logger.Fatalf(
+		m.fatalf(
 			"failed starting slurpeeth, error: %s",
 			err,
 		)
@@ -86,24 +120,69 @@ func (m *slurpeethManager) Run() {
 
 	m.logger.Debug("initial slurpeeth tunnel creation complete")
 
+	m.updatePacketCaptures(m.initialTunnels)
+
+	m.updateLinkStates(m.initialTunnels)
+
+	m.startSelfTest()
+
+	m.startTrafficCounters()
+
+	m.startMetrics()
+
 	m.logger.Debug("start connectivity custom resource watch...")
 
-	go watchConnectivity(
-		m.ctx,
-		m.logger,
-		m.clabernetesClient,
-		m.renderSlurpeethConfig,
+	m.startConnectivityWatch(
+		m.withLinkStates(m.withPacketCaptures(m.renderSlurpeethConfig)),
 	)
 
+	m.onEndpointsChanged(m.rerenderSlurpeethConfig)
+
 	m.logger.Debug("slurpeeth connectivity setup complete")
 }
 
+// rerenderSlurpeethConfig renders the slurpeeth config for the current tunnels again, slurpeeth
+// live reloads the config so this is all it takes to follow remote launchers around.
+func (m *slurpeethManager) rerenderSlurpeethConfig() {
+	m.lock.Lock()
+	tunnels := m.currentTunnels
+	m.lock.Unlock()
+
+	m.renderSlurpeethConfig(tunnels)
+}
+
 func (m *slurpeethManager) renderSlurpeethConfig(
 	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
 ) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.currentTunnels = tunnels
+
 	slurpeethConfig := slurpeeth.Config{}
 
 	for _, tunnel := range tunnels {
+		destination := tunnel.Destination
+
+		if underlayInterface() != "" {
+			resolvedDestination, err := m.resolveDestination(destination)
+			if err != nil {
+				// leave the destination as is, slurpeeth keeps retrying and we re-render once
+				// the endpoint watch sees the remote launcher
+				m.logger.Warnf(
+					"failed resolving underlay address for tunnel to remote node '%s', error: %s",
+					tunnel.RemoteNode,
+					err,
+				)
+			} else {
+				destination = resolvedDestination
+			}
+		}
+
+		if m.tls != nil {
+			destination = m.tls.loopbackFor(destination)
+		}
+
 		slurpeethConfig.Segments = append(
 			slurpeethConfig.Segments,
 			slurpeeth.Segment{
@@ -117,14 +196,14 @@ func (m *slurpeethManager) renderSlurpeethConfig(
 				Interfaces: []string{
 					fmt.Sprintf("%s-%s", tunnel.LocalNode, tunnel.LocalInterface),
 				},
-				Destinations: []string{tunnel.Destination},
+				Destinations: []string{destination},
 			},
 		)
 	}
 
 	slurpeethConfigYAML, err := yaml.Marshal(slurpeethConfig)
 	if err != nil {
-		m.logger.Fatalf(
+		m.fatalf(
 			"failed marshalling slurpeeth config, error: %s",
 			err,
 		)
@@ -136,7 +215,7 @@ func (m *slurpeethManager) renderSlurpeethConfig(
 		clabernetesconstants.PermissionsEveryoneReadWriteOwnerExecute,
 	)
 	if err != nil {
-		m.logger.Fatalf(
+		m.fatalf(
 			"failed writing slurpeeth config to disk, error: %s",
 			err,
 		)
@@ -0,0 +1,284 @@
+//go:build linux
+// +build linux
+
+package connectivity
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+const (
+	slurpeethTLSCAFile   = "ca.crt"
+	slurpeethTLSCertFile = "tls.crt"
+	slurpeethTLSKeyFile  = "tls.key"
+
+	// slurpeethTLSInternalPort is the port slurpeeth itself listens on and dials when tls is
+	// enabled -- only ever on loopback addresses, the actual slurpeeth port belongs to the tls
+	// proxy.
+	slurpeethTLSInternalPort = 47990
+	// slurpeethTLSListenAddress is the (loopback) address slurpeeth listens on when tls is enabled.
+	slurpeethTLSListenAddress = "127.0.0.1"
+
+	slurpeethTLSDialTimeout      = 5 * time.Second
+	slurpeethTLSHandshakeTimeout = 10 * time.Second
+)
+
+var errSlurpeethTLSNoPeerCertificate = errors.New("peer presented no certificate")
+
+// slurpeethTLSEnabled returns true if the controller told us to wrap slurpeeth in mutual tls.
+func slurpeethTLSEnabled() bool {
+	return os.Getenv(clabernetesconstants.LauncherSlurpeethTLSEnv) == clabernetesconstants.True
+}
+
+// slurpeethTLSProxy wraps the (plaintext tcp) slurpeeth transport in mutual tls. Slurpeeth does not
+// know anything about tls, so it listens on loopback only and each tunnel destination is replaced
+// with a loopback address of its own -- the proxy then terminates tls on the slurpeeth port and
+// forwards to slurpeeth, and forwards whatever slurpeeth sends to the loopback addresses to the
+// actual destinations over tls.
+type slurpeethTLSProxy struct {
+	*common
+
+	port         int
+	serverConfig *tls.Config
+	clientConfig *tls.Config
+
+	lock sync.Mutex
+	// loopbacks maps tunnel destinations to the loopback address slurpeeth is pointed at for them,
+	// these are never released, tunnels come and go but destinations (launchers) do not really
+	loopbacks    map[string]netip.Addr
+	nextLoopback netip.Addr
+}
+
+func newSlurpeethTLSProxy(c *common, port int) *slurpeethTLSProxy {
+	certificate, err := tls.LoadX509KeyPair(
+		filepath.Join(clabernetesconstants.LauncherSlurpeethTLSPath, slurpeethTLSCertFile),
+		filepath.Join(clabernetesconstants.LauncherSlurpeethTLSPath, slurpeethTLSKeyFile),
+	)
+	if err != nil {
+		c.fatalf("failed loading slurpeeth tls certificate, error: %s", err)
+	}
+
+	caPEM, err := os.ReadFile(
+		filepath.Join(clabernetesconstants.LauncherSlurpeethTLSPath, slurpeethTLSCAFile),
+	)
+	if err != nil {
+		c.fatalf("failed loading slurpeeth tls ca, error: %s", err)
+	}
+
+	caPool := x509.NewCertPool()
+
+	if !caPool.AppendCertsFromPEM(caPEM) {
+		c.fatalf("failed parsing slurpeeth tls ca, no certificates found")
+	}
+
+	return &slurpeethTLSProxy{
+		common: c,
+		port:   port,
+		serverConfig: &tls.Config{
+			MinVersion:   tls.VersionTLS13,
+			Certificates: []tls.Certificate{certificate},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    caPool,
+		},
+		clientConfig: &tls.Config{
+			MinVersion:   tls.VersionTLS13,
+			Certificates: []tls.Certificate{certificate},
+			// tunnel destinations are pod addresses or service names that the certificates know
+			// nothing about, all we care about is that the peer holds a certificate issued by our
+			// ca, so we verify the chain ourselves rather than the hostname
+			InsecureSkipVerify: true, //nolint:gosec
+			VerifyConnection:   verifySlurpeethTLSPeer(caPool),
+		},
+		loopbacks: map[string]netip.Addr{},
+		// 127.0.0.1 is where slurpeeth itself listens
+		nextLoopback: netip.MustParseAddr(slurpeethTLSListenAddress).Next(),
+	}
+}
+
+func verifySlurpeethTLSPeer(caPool *x509.CertPool) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return errSlurpeethTLSNoPeerCertificate
+		}
+
+		intermediates := x509.NewCertPool()
+
+		for _, certificate := range state.PeerCertificates[1:] {
+			intermediates.AddCert(certificate)
+		}
+
+		_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+			Roots:         caPool,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+
+		return err
+	}
+}
+
+// listen starts terminating tls on the slurpeeth port, forwarding the connections of (verified)
+// remote launchers to the local slurpeeth listener.
+func (p *slurpeethTLSProxy) listen() {
+	listenConfig := net.ListenConfig{}
+
+	listener, err := listenConfig.Listen(p.ctx, "tcp", fmt.Sprintf(":%d", p.port))
+	if err != nil {
+		p.fatalf("failed starting slurpeeth tls listener, error: %s", err)
+	}
+
+	slurpeethAddress := net.JoinHostPort(
+		slurpeethTLSListenAddress,
+		strconv.Itoa(slurpeethTLSInternalPort),
+	)
+
+	p.serve(listener, func(conn net.Conn) {
+		tlsConn := tls.Server(conn, p.serverConfig)
+
+		ctx, cancel := context.WithTimeout(p.ctx, slurpeethTLSHandshakeTimeout)
+		defer cancel()
+
+		err := tlsConn.HandshakeContext(ctx)
+		if err != nil {
+			p.logger.Warnf(
+				"failed slurpeeth tls handshake with %q, err: %s", conn.RemoteAddr(), err,
+			)
+
+			_ = conn.Close()
+
+			return
+		}
+
+		p.forward(tlsConn, func() (net.Conn, error) {
+			dialer := net.Dialer{Timeout: slurpeethTLSDialTimeout}
+
+			return dialer.DialContext(p.ctx, "tcp", slurpeethAddress)
+		})
+	})
+}
+
+// loopbackFor returns the loopback address slurpeeth should dial for the given tunnel destination,
+// starting a listener forwarding to the destination over tls the first time we see it.
+func (p *slurpeethTLSProxy) loopbackFor(destination string) string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	loopback, ok := p.loopbacks[destination]
+	if ok {
+		return loopback.String()
+	}
+
+	loopback = p.nextLoopback
+
+	listenConfig := net.ListenConfig{}
+
+	listener, err := listenConfig.Listen(
+		p.ctx,
+		"tcp",
+		netip.AddrPortFrom(loopback, slurpeethTLSInternalPort).String(),
+	)
+	if err != nil {
+		p.fatalf(
+			"failed starting slurpeeth tls forwarder for destination %q, error: %s",
+			destination,
+			err,
+		)
+	}
+
+	p.loopbacks[destination] = loopback
+	p.nextLoopback = loopback.Next()
+
+	destinationAddress := net.JoinHostPort(destination, strconv.Itoa(p.port))
+
+	p.serve(listener, func(conn net.Conn) {
+		p.forward(conn, func() (net.Conn, error) {
+			dialer := tls.Dialer{
+				NetDialer: &net.Dialer{Timeout: slurpeethTLSDialTimeout},
+				Config:    p.clientConfig,
+			}
+
+			return dialer.DialContext(p.ctx, "tcp", destinationAddress)
+		})
+	})
+
+	return loopback.String()
+}
+
+func (p *slurpeethTLSProxy) serve(listener net.Listener, handle func(conn net.Conn)) {
+	go func() {
+		<-p.ctx.Done()
+
+		_ = listener.Close()
+	}()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if p.ctx.Err() != nil {
+					return
+				}
+
+				p.logger.Warnf(
+					"failed accepting slurpeeth tls connection on %q, err: %s",
+					listener.Addr(),
+					err,
+				)
+
+				continue
+			}
+
+			go handle(conn)
+		}
+	}()
+}
+
+// forward copies data between the inbound connection and the connection returned by dial until
+// either side goes away. Failing to dial simply drops the inbound connection, slurpeeth retries on
+// its own.
+func (p *slurpeethTLSProxy) forward(inbound net.Conn, dial func() (net.Conn, error)) {
+	defer func() {
+		_ = inbound.Close()
+	}()
+
+	outbound, err := dial()
+	if err != nil {
+		p.logger.Debugf("failed dialing slurpeeth tls forward target, err: %s", err)
+
+		return
+	}
+
+	defer func() {
+		_ = outbound.Close()
+	}()
+
+	done := make(chan struct{}, 2) //nolint:mnd
+
+	go func() {
+		_, _ = io.Copy(outbound, inbound)
+
+		done <- struct{}{}
+	}()
+
+	go func() {
+		_, _ = io.Copy(inbound, outbound)
+
+		done <- struct{}{}
+	}()
+
+	<-done
+}
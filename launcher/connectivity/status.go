@@ -0,0 +1,204 @@
+package connectivity
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+// tunnelStatusTracker holds the current state of the tunnels of this launcher (by tunnel key) so
+// that we can report them in the connectivity cr status -- all managers share the tracker via
+// common.
+type tunnelStatusTracker struct {
+	lock     sync.Mutex
+	statuses map[string]clabernetesapisv1alpha1.TunnelStatus
+}
+
+// reportTunnelStatus records the outcome of setting up (or checking) the given tunnel and, if
+// anything changed, pushes the statuses of all of this launchers tunnels to the connectivity cr.
+func (c *common) reportTunnelStatus(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+	resolvedDestination string,
+	tunnelErr error,
+) {
+	c.tunnelStatuses.lock.Lock()
+	defer c.tunnelStatuses.lock.Unlock()
+
+	if c.tunnelStatuses.statuses == nil {
+		c.tunnelStatuses.statuses = map[string]clabernetesapisv1alpha1.TunnelStatus{}
+	}
+
+	existingStatus, exists := c.tunnelStatuses.statuses[tunnelKey(tunnel)]
+
+	newStatus := clabernetesapisv1alpha1.TunnelStatus{
+		LocalInterface:     tunnel.LocalInterface,
+		RemoteNode:         tunnel.RemoteNode,
+		RemoteInterface:    tunnel.RemoteInterface,
+		State:              clabernetesconstants.TunnelStateUp,
+		LastError:          existingStatus.LastError,
+		LastTransitionTime: existingStatus.LastTransitionTime,
+		SelfTest:           existingStatus.SelfTest,
+		Counters:           existingStatus.Counters,
+	}
+
+	if net.ParseIP(resolvedDestination) != nil {
+		newStatus.ResolvedDestination = resolvedDestination
+	}
+
+	if c.connectivityKind == clabernetesconstants.ConnectivityLoopback {
+		// nothing is ever set up for loopback connectivity, make that obvious in the status
+		newStatus.State = clabernetesconstants.TunnelStateSimulated
+	}
+
+	if tunnelErr != nil {
+		newStatus.State = clabernetesconstants.TunnelStateDown
+		newStatus.LastError = tunnelErr.Error()
+	}
+
+	if !exists || existingStatus.State != newStatus.State {
+		newStatus.LastTransitionTime = metav1.Now()
+	}
+
+	if exists && reflect.DeepEqual(existingStatus, newStatus) {
+		return
+	}
+
+	c.tunnelStatuses.statuses[tunnelKey(tunnel)] = newStatus
+
+	c.pushTunnelStatuses()
+}
+
+// reportPeerReachability records whether the remote launcher at the given (resolved) destination
+// answers our liveness probes -- tunnels towards it that are set up are flipped between "up" and
+// "unreachable" accordingly, tunnels that failed to be set up are left alone.
+func (c *common) reportPeerReachability(destination string, peerErr error) {
+	c.tunnelStatuses.lock.Lock()
+	defer c.tunnelStatuses.lock.Unlock()
+
+	var changed bool
+
+	for key, status := range c.tunnelStatuses.statuses {
+		if status.ResolvedDestination != destination ||
+			status.State == clabernetesconstants.TunnelStateDown {
+			continue
+		}
+
+		newState := clabernetesconstants.TunnelStateUp
+		if peerErr != nil {
+			newState = clabernetesconstants.TunnelStateUnreachable
+		}
+
+		if status.State == newState {
+			continue
+		}
+
+		status.State = newState
+		status.LastTransitionTime = metav1.Now()
+
+		if peerErr != nil {
+			status.LastError = peerErr.Error()
+		}
+
+		c.tunnelStatuses.statuses[key] = status
+
+		changed = true
+	}
+
+	if changed {
+		c.pushTunnelStatuses()
+	}
+}
+
+// livenessDestinations returns the (resolved) destinations of all tunnels that are set up, these
+// are the remote launchers we probe for liveness.
+func (c *common) livenessDestinations() []string {
+	c.tunnelStatuses.lock.Lock()
+	defer c.tunnelStatuses.lock.Unlock()
+
+	var destinations []string
+
+	for _, status := range c.tunnelStatuses.statuses {
+		if status.ResolvedDestination == "" ||
+			status.State == clabernetesconstants.TunnelStateDown ||
+			slices.Contains(destinations, status.ResolvedDestination) {
+			continue
+		}
+
+		destinations = append(destinations, status.ResolvedDestination)
+	}
+
+	sort.Strings(destinations)
+
+	return destinations
+}
+
+// forgetTunnelStatus removes the status of the tunnel with the given key (because the tunnel was
+// removed) and pushes the updated statuses to the connectivity cr.
+func (c *common) forgetTunnelStatus(key string) {
+	c.tunnelStatuses.lock.Lock()
+	defer c.tunnelStatuses.lock.Unlock()
+
+	if _, exists := c.tunnelStatuses.statuses[key]; !exists {
+		return
+	}
+
+	delete(c.tunnelStatuses.statuses, key)
+
+	c.pushTunnelStatuses()
+}
+
+// pushTunnelStatuses merge patches the statuses of this launchers tunnels into the connectivity cr
+// -- being a merge patch we only ever touch our own node's entry, so launchers dont step on each
+// other. Failing to push a status is not fatal, it is only informational after all.
+func (c *common) pushTunnelStatuses() {
+	if c.clabernetesClient == nil {
+		return
+	}
+
+	nodeName := os.Getenv(clabernetesconstants.LauncherNodeNameEnv)
+
+	statuses := make([]clabernetesapisv1alpha1.TunnelStatus, 0, len(c.tunnelStatuses.statuses))
+
+	for _, status := range c.tunnelStatuses.statuses {
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].LocalInterface < statuses[j].LocalInterface
+	})
+
+	patch, err := json.Marshal(map[string]any{
+		"status": map[string]any{
+			"tunnelStatuses": map[string]any{
+				nodeName: statuses,
+			},
+		},
+	})
+	if err != nil {
+		c.logger.Warnf("failed marshaling tunnel status patch, err: %s", err)
+
+		return
+	}
+
+	_, err = c.clabernetesClient.ClabernetesV1alpha1().
+		Connectivities(os.Getenv(clabernetesconstants.PodNamespaceEnv)).
+		Patch(
+			c.ctx,
+			os.Getenv(clabernetesconstants.LauncherTopologyNameEnv),
+			apimachinerytypes.MergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+	if err != nil {
+		c.logger.Warnf("failed patching connectivity tunnel status, err: %s", err)
+	}
+}
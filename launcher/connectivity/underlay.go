@@ -0,0 +1,132 @@
+package connectivity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// multusNetworkStatus is a (partial) entry of the multus network status annotation of a pod.
+type multusNetworkStatus struct {
+	Name      string   `json:"name"`
+	Interface string   `json:"interface"`
+	IPs       []string `json:"ips"`
+}
+
+// underlayInterface returns the name of the dedicated underlay interface tunnel traffic should be
+// sent over, or an empty string if the topology does not use one.
+func underlayInterface() string {
+	return os.Getenv(clabernetesconstants.LauncherUnderlayInterfaceEnv)
+}
+
+// underlayAddress maps the given (pod network) address of a remote launcher to the address of that
+// launcher on the dedicated underlay interface -- pointing the tunnels at that address is what
+// routes the tunnel traffic out of our own underlay interface rather than eth0. If the topology
+// does not use an underlay interface the address is returned as is. The underlay address is taken
+// from the network status multus reports on the remote launcher pod.
+func (c *common) underlayAddress(podAddress string) (string, error) {
+	interfaceName := underlayInterface()
+	if interfaceName == "" {
+		return podAddress, nil
+	}
+
+	if c.endpoints == nil {
+		return "", fmt.Errorf(
+			"%w %q, error: endpoint watch not running, cannot look up underlay address",
+			errUnresolvedDestination,
+			podAddress,
+		)
+	}
+
+	podName, ok := c.endpoints.podName(podAddress)
+	if !ok {
+		return "", fmt.Errorf(
+			"%w %q, error: unknown remote launcher pod, cannot look up underlay address",
+			errUnresolvedDestination,
+			podAddress,
+		)
+	}
+
+	pod, err := c.endpoints.kubeClient.CoreV1().
+		Pods(c.endpoints.namespace).
+		Get(c.ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf(
+			"%w %q, error: failed fetching remote launcher pod %q: %w",
+			errUnresolvedDestination,
+			podAddress,
+			podName,
+			err,
+		)
+	}
+
+	underlayAddress, err := networkStatusAddress(
+		pod.Annotations[clabernetesconstants.MultusNetworkStatusAnnotation],
+		interfaceName,
+	)
+	if err != nil {
+		return "", fmt.Errorf(
+			"%w %q, error: remote launcher pod %q has no underlay address: %w",
+			errUnresolvedDestination,
+			podAddress,
+			podName,
+			err,
+		)
+	}
+
+	c.logger.Debugf(
+		"resolved underlay address of remote launcher pod '%s' as '%s'",
+		podName,
+		underlayAddress,
+	)
+
+	return underlayAddress, nil
+}
+
+// networkStatusAddress returns the (first) address of the given interface from the given multus
+// network status annotation.
+func networkStatusAddress(networkStatusJSON, interfaceName string) (string, error) {
+	if networkStatusJSON == "" {
+		return "", fmt.Errorf(
+			"%w: no multus network status reported",
+			claberneteserrors.ErrConnectivity,
+		)
+	}
+
+	var networkStatuses []multusNetworkStatus
+
+	err := json.Unmarshal([]byte(networkStatusJSON), &networkStatuses)
+	if err != nil {
+		return "", fmt.Errorf(
+			"%w: failed parsing multus network status, error: %w",
+			claberneteserrors.ErrConnectivity,
+			err,
+		)
+	}
+
+	for _, networkStatus := range networkStatuses {
+		if networkStatus.Interface != interfaceName {
+			continue
+		}
+
+		if len(networkStatus.IPs) == 0 {
+			return "", fmt.Errorf(
+				"%w: interface %q has no addresses",
+				claberneteserrors.ErrConnectivity,
+				interfaceName,
+			)
+		}
+
+		return networkStatus.IPs[0], nil
+	}
+
+	return "", fmt.Errorf(
+		"%w: interface %q not found in multus network status",
+		claberneteserrors.ErrConnectivity,
+		interfaceName,
+	)
+}
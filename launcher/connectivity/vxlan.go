@@ -4,16 +4,12 @@ import (
 	"context"
 	"crypto/sha1"
 	"fmt"
-	"net"
 	"os"
-	"os/exec"
 	"reflect"
-	"strconv"
 	"strings"
-	"time"
+	"sync"
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
-	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
 	claberneteserrors "github.com/srl-labs/clabernetes/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -21,13 +17,24 @@ import (
 )
 
 const (
-	resolveServiceMaxAttempts = 5
-	resolveServiceSleep       = 10 * time.Second
+	vxlanInterfacePrefix = "vx"
+)
+
+// errTunnelIDConflict is returned when a tunnel can not be set up because its tunnel id (vni) is
+// already used by another tunnel on this launcher -- the kernel refuses a second vxlan interface
+// with the same vni (or worse, traffic gets black holed), so rather than surfacing whatever the
+// kernel says we report the conflict explicitly.
+var errTunnelIDConflict = fmt.Errorf(
+	"%w: tunnel id already in use",
+	claberneteserrors.ErrConnectivity,
 )
 
 type vxlanManager struct {
 	*common
 
+	// lock guards currentTunnels (and the tunnels themselves) as they are touched by both the
+	// connectivity cr watch and the tunnel health check
+	lock           sync.Mutex
 	currentTunnels map[string]*clabernetesapisv1alpha1.PointToPointTunnel
 }
 
@@ -38,91 +45,55 @@ func (m *vxlanManager) Run() {
 		"connectivity mode is 'vxlan', setting up any required tunnels...",
 	)
 
+	m.watchEndpoints()
+
+	tunnelIDConflicts := findTunnelIDConflicts(m.initialTunnels)
+
 	for _, tunnel := range m.initialTunnels {
-		err := m.runContainerlabVxlanToolsCreate(
-			tunnel.LocalNode,
-			tunnel.LocalInterface,
-			tunnel.Destination,
-			tunnel.TunnelID,
-		)
+		err := m.createVxlanTunnelUnlessConflicting(tunnel, tunnelIDConflicts)
 		if err != nil {
-			m.logger.Fatalf(
-				"failed setting up tunnel to remote node '%s' for local interface '%s', error: %s",
-				tunnel.RemoteNode,
-				tunnel.LocalInterface,
-				err,
-			)
+			m.tunnelSetupFailed(tunnel, err)
 		}
 
-		// we store them in a nice little map by local interface name so they're easy to
-		// reconcile on connectivity cr updates
-		m.currentTunnels[tunnel.LocalInterface] = tunnel
+		// we store them in a nice little map by tunnel key (local node and interface) so they're
+		// easy to reconcile on connectivity cr updates
+		m.currentTunnels[tunnelKey(tunnel)] = tunnel
 	}
 
 	m.logger.Debug("initial vxlan tunnel creation complete")
 
-	m.logger.Debug("start connectivity custom resource watch...")
+	m.updatePacketCaptures(m.initialTunnels)
 
-	go watchConnectivity(
-		m.ctx,
-		m.logger,
-		m.clabernetesClient,
-		m.updateVxlanTunnels,
-	)
+	m.updateLinkStates(m.initialTunnels)
 
-	m.logger.Debug("vxlan connectivity setup complete")
-}
+	m.startSelfTest()
 
-func (m *vxlanManager) resolveVXLANService(vxlanRemote string) (string, error) {
-	var resolvedVxlanRemotes []net.IP
+	m.startTrafficCounters()
 
-	var err error
+	m.startMetrics()
 
-	for range resolveServiceMaxAttempts {
-		resolvedVxlanRemotes, err = net.LookupIP(vxlanRemote) //nolint: noctx
-		if err != nil {
-			m.logger.Warnf(
-				"failed resolving remote vxlan endpoint but under max attempts will try"+
-					" again in %s. error: %s",
-				resolveServiceSleep,
-				err,
-			)
+	m.startLivenessProbes()
 
-			time.Sleep(resolveServiceSleep)
+	m.logger.Debug("start connectivity custom resource watch...")
 
-			continue
-		}
+	m.startConnectivityWatch(m.withLinkStates(m.withPacketCaptures(m.updateVxlanTunnels)))
 
-		break
-	}
-
-	if len(resolvedVxlanRemotes) != 1 {
-		// We've seen environments where the launcher's /etc/resolv.conf ends up without
-		// working nameservers, causing DNS lookups to default to localhost and fail.
-		// Fall back to resolving the ClusterIP/Endpoint via the Kubernetes API so vxlan
-		// connectivity doesn't depend on in-container DNS.
-		ip, kerr := resolveVXLANServiceViaKubeAPI(m.ctx, vxlanRemote)
-		if kerr != nil {
-			return "", fmt.Errorf(
-				"%w: did not get exactly one ip resolved for remote vxlan endpoint (dns=%v, kube=%v)",
-				claberneteserrors.ErrConnectivity,
-				err,
-				kerr,
-			)
-		}
+	m.logger.Debug("start vxlan tunnel health check...")
 
-		m.logger.Warnf("resolved remote vxlan endpoint via kubernetes api: %s -> %s", vxlanRemote, ip)
+	go m.monitorVxlanTunnels()
 
-		return ip, nil
-	}
+	m.onEndpointsChanged(m.checkVxlanTunnelsOnce)
 
-	return resolvedVxlanRemotes[0].String(), nil
+	m.logger.Debug("vxlan connectivity setup complete")
 }
 
-func resolveVXLANServiceViaKubeAPI(ctx context.Context, vxlanRemote string) (string, error) {
-	serviceName, namespace := parseServiceFQDN(vxlanRemote)
+// resolveServiceEndpointViaKubeAPI resolves the given service to the (pod) ip address of its
+// endpoint rather than the cluster ip -- this is required for encapsulations that are not
+// tcp/udp/sctp (gre) and therefore can never be load balanced via a cluster ip.
+func resolveServiceEndpointViaKubeAPI(ctx context.Context, remote string) (string, error) {
+	serviceName, namespace := parseServiceFQDN(remote)
 	if serviceName == "" || namespace == "" {
-		return "", fmt.Errorf("%w: could not parse service name/namespace from %q", claberneteserrors.ErrInvalidData, vxlanRemote)
+		return "", fmt.Errorf("%w: could not parse service name/namespace from %q", claberneteserrors.ErrInvalidData, remote)
 	}
 
 	cfg, err := rest.InClusterConfig()
@@ -135,15 +106,15 @@ func resolveVXLANServiceViaKubeAPI(ctx context.Context, vxlanRemote string) (str
 		return "", err
 	}
 
-	svc, err := client.CoreV1().Services(namespace).Get(ctx, serviceName, metav1.GetOptions{})
-	if err != nil {
-		return "", err
-	}
-
-	if svc.Spec.ClusterIP != "" && svc.Spec.ClusterIP != "None" {
-		return svc.Spec.ClusterIP, nil
-	}
+	return resolveServiceEndpointAddress(ctx, client, namespace, serviceName)
+}
 
+func resolveServiceEndpointAddress(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace,
+	serviceName string,
+) (string, error) {
 	ep, err := client.CoreV1().Endpoints(namespace).Get(ctx, serviceName, metav1.GetOptions{})
 	if err != nil {
 		return "", err
@@ -188,40 +159,43 @@ func parseServiceFQDN(host string) (serviceName, namespace string) {
 	return "", ""
 }
 
-func (m *vxlanManager) runContainerlabVxlanToolsCreate(
-	localNodeName,
-	cntLink,
-	vxlanRemote string,
-	vxlanID int,
-) error {
-	var err error
+func (m *vxlanManager) createVxlanTunnel(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) (err error) {
+	localNodeName := tunnel.LocalNode
+	cntLink := tunnel.LocalInterface
+	vxlanRemote := tunnel.Destination
+	vxlanID := tunnel.TunnelID
+
 	resolvedVxlanRemote := vxlanRemote
-	if net.ParseIP(vxlanRemote) == nil {
-		ip, err := m.resolveVXLANService(vxlanRemote)
-		if err != nil {
-			return err
-		}
-		resolvedVxlanRemote = ip
+
+	defer func() {
+		m.reportTunnelStatus(tunnel, resolvedVxlanRemote, err)
+	}()
+
+	resolvedVxlanRemote, err = m.resolveDestination(vxlanRemote)
+	if err != nil {
+		return err
 	}
 
-	m.logger.Debugf("resolved remote vxlan tunnel service address as '%s'", resolvedVxlanRemote)
+	m.logger.Debugf("resolved remote vxlan tunnel endpoint address as '%s'", resolvedVxlanRemote)
 
 	link := sanitizeLinuxIfName(cntLink)
-	hostLink := sanitizeLinuxIfName(fmt.Sprintf("%s-%s", localNodeName, link))
-	vxlanInterfaceName := hostLink
-	m.logger.Debugf("Attempting to delete existing vxlan interface '%s'", vxlanInterfaceName)
+	hostLink, vxlanLink := tunnelInterfaceNames(vxlanInterfacePrefix, localNodeName, cntLink)
+
+	m.logger.Debugf("Attempting to delete existing vxlan interface '%s'", vxlanLink)
 
-	err = m.runContainerlabVxlanToolsDelete(m.ctx, localNodeName, link)
+	err = m.deleteVxlanTunnel(localNodeName, cntLink)
 	if err != nil {
 		m.logger.Warnf(
 			"failed while deleting existing vxlan interface '%s', error: '%s'",
-			vxlanInterfaceName,
+			vxlanLink,
 			err,
 		)
 	}
 
 	// In docker-mode, containerlab creates a veth pair per endpoint and names the "host side" of the
-	// veth `<node>-<ifname>` (e.g. `forti1-eth1`) which the vxlan tools then attach to.
+	// veth `<node>-<ifname>` (e.g. `forti1-eth1`) which the vxlan interface then attaches to.
 	//
 	// In native-mode, we run the NOS container directly as a k8s container (no Docker-in-Docker),
 	// so there is no containerlab veth wiring step that would normally create this link.
@@ -233,39 +207,111 @@ func (m *vxlanManager) runContainerlabVxlanToolsCreate(
 		return err
 	}
 
-	cmd := exec.CommandContext( //nolint:gosec
-		m.ctx,
-		"containerlab",
-		"tools",
-		"vxlan",
-		"create",
-		"--remote",
+	err = checkVxlanIDAvailable(vxlanLink, vxlanID)
+	if err != nil {
+		return err
+	}
+
+	m.logger.Debugf(
+		"creating vxlan interface '%s' with id %d to remote '%s' for link '%s'",
+		vxlanLink,
+		vxlanID,
 		resolvedVxlanRemote,
-		"--id",
-		strconv.Itoa(vxlanID),
-		"--link",
 		hostLink,
-		"--port",
-		strconv.Itoa(clabernetesconstants.VXLANServicePort),
 	)
 
-	m.logger.Debugf(
-		"using following args for vxlan tunnel creation (via containerlab) '%s'", cmd.Args,
+	err = createVxlanLink(
+		vxlanLink,
+		hostLink,
+		resolvedVxlanRemote,
+		vxlanID,
+		vxlanPort(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if tunnel.Impairment == nil && tunnel.Bandwidth == "" {
+		return nil
+	}
+
+	return m.applyLinkShaping(vxlanLink, tunnel)
+}
+
+// findTunnelIDConflicts returns a mapping of tunnel key -> the tunnel that already claims the
+// tunnel id of the tunnel with that key, for all but the first of the given tunnels that share a
+// tunnel id. The controller never hands out a tunnel id twice for a node, so this only
+// happens if something went wrong (or the connectivity cr was edited by hand).
+func findTunnelIDConflicts(
+	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+) map[string]*clabernetesapisv1alpha1.PointToPointTunnel {
+	tunnelsByID := map[int]*clabernetesapisv1alpha1.PointToPointTunnel{}
+
+	conflicts := map[string]*clabernetesapisv1alpha1.PointToPointTunnel{}
+
+	for _, tunnel := range tunnels {
+		existingTunnel, ok := tunnelsByID[tunnel.TunnelID]
+		if !ok {
+			tunnelsByID[tunnel.TunnelID] = tunnel
+
+			continue
+		}
+
+		conflicts[tunnelKey(tunnel)] = existingTunnel
+	}
+
+	return conflicts
+}
+
+// createVxlanTunnelUnlessConflicting creates the given tunnel unless another of the desired tunnels
+// claims the same tunnel id, in which case the tunnel is reported as down with the conflict as its
+// error instead.
+func (m *vxlanManager) createVxlanTunnelUnlessConflicting(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+	tunnelIDConflicts map[string]*clabernetesapisv1alpha1.PointToPointTunnel,
+) error {
+	conflictingTunnel, ok := tunnelIDConflicts[tunnelKey(tunnel)]
+	if !ok {
+		return m.createVxlanTunnel(tunnel)
+	}
+
+	err := fmt.Errorf(
+		"%w: tunnel id %d is also used by the tunnel for local interface '%s' (to remote node"+
+			" '%s'), not setting up tunnel",
+		errTunnelIDConflict,
+		tunnel.TunnelID,
+		conflictingTunnel.LocalInterface,
+		conflictingTunnel.RemoteNode,
 	)
 
-	cmd.Stdout = m.logger
-	cmd.Stderr = m.logger
+	m.reportTunnelStatus(tunnel, tunnel.Destination, err)
 
-	err = cmd.Run()
+	return err
+}
+
+// checkVxlanIDAvailable returns an error if a vxlan interface other than the given one already uses
+// the given vxlan id in the pod network namespace.
+func checkVxlanIDAvailable(vxlanLink string, vxlanID int) error {
+	vxlanInterfaces, err := listVxlanInterfaces()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	existingLink, ok := vxlanInterfaces[vxlanID]
+	if !ok || existingLink == vxlanLink {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: vxlan id %d is already used by vxlan interface '%s'",
+		errTunnelIDConflict,
+		vxlanID,
+		existingLink,
+	)
 }
 
-func (m *vxlanManager) ensurePodLinkExists(
-	ctx context.Context,
+func (c *common) ensurePodLinkExists(
+	_ context.Context,
 	localNodeName string,
 	cntLink string,
 ) error {
@@ -275,14 +321,12 @@ func (m *vxlanManager) ensurePodLinkExists(
 	hostSide := sanitizeLinuxIfName(fmt.Sprintf("%s-%s", localNodeName, cntLink))
 
 	// If the host-side link already exists, we're done.
-	checkCmd := exec.CommandContext(ctx, "ip", "link", "show", hostSide) //nolint:gosec
-	if err := checkCmd.Run(); err == nil {
+	if linkExists(hostSide) {
 		return nil
 	}
 
 	// If the container-side link exists, we shouldn't clobber it.
-	checkCntCmd := exec.CommandContext(ctx, "ip", "link", "show", cntLink) //nolint:gosec
-	if err := checkCntCmd.Run(); err == nil {
+	if linkExists(cntLink) {
 		return fmt.Errorf(
 			"%w: expected vxlan link %q missing but interface %q already exists",
 			claberneteserrors.ErrConnectivity,
@@ -291,70 +335,30 @@ func (m *vxlanManager) ensurePodLinkExists(
 		)
 	}
 
-	// Create veth pair and bring it up.
-	addCmd := exec.CommandContext( //nolint:gosec
-		ctx,
-		"ip",
-		"link",
-		"add",
-		hostSide,
-		"type",
-		"veth",
-		"peer",
-		"name",
-		cntLink,
-	)
-	addCmd.Stdout = m.logger
-	addCmd.Stderr = m.logger
-	if err := addCmd.Run(); err != nil {
-		return fmt.Errorf("%w: failed creating veth %q <-> %q: %s", claberneteserrors.ErrConnectivity, hostSide, cntLink, err)
-	}
-
-	upHostCmd := exec.CommandContext(ctx, "ip", "link", "set", hostSide, "up") //nolint:gosec
-	upHostCmd.Stdout = m.logger
-	upHostCmd.Stderr = m.logger
-	if err := upHostCmd.Run(); err != nil {
-		return fmt.Errorf("%w: failed bringing up %q: %s", claberneteserrors.ErrConnectivity, hostSide, err)
-	}
+	c.logger.Debugf("creating veth pair '%s' <-> '%s'", hostSide, cntLink)
 
-	upCntCmd := exec.CommandContext(ctx, "ip", "link", "set", cntLink, "up") //nolint:gosec
-	upCntCmd.Stdout = m.logger
-	upCntCmd.Stderr = m.logger
-	if err := upCntCmd.Run(); err != nil {
-		return fmt.Errorf("%w: failed bringing up %q: %s", claberneteserrors.ErrConnectivity, cntLink, err)
-	}
-
-	return nil
+	return createVethPair(hostSide, cntLink)
 }
 
-func (m *vxlanManager) runContainerlabVxlanToolsDelete(
-	ctx context.Context,
+func (m *vxlanManager) deleteVxlanTunnel(
 	localNodeName,
 	cntLink string,
 ) error {
-	cmd := exec.CommandContext( //nolint:gosec
-		ctx,
-		"containerlab",
-		"tools",
-		"vxlan",
-		"delete",
-		"--prefix",
-		fmt.Sprintf("vx-%s-%s", localNodeName, cntLink),
-	)
+	hostLink, vxlanLink := tunnelInterfaceNames(vxlanInterfacePrefix, localNodeName, cntLink)
 
-	m.logger.Debugf(
-		"using following args for vxlan tunnel deletion (via containerlab) '%s'", cmd.Args,
-	)
+	m.logger.Debugf("deleting vxlan interface '%s'", vxlanLink)
 
-	cmd.Stdout = m.logger
-	cmd.Stderr = m.logger
+	return deleteTunnelLink(hostLink, vxlanLink)
+}
 
-	err := cmd.Run()
-	if err != nil {
-		return err
-	}
+// tunnelInterfaceNames returns the pod side ("host side" in containerlab terms) veth name and the
+// tunnel interface name (using the given prefix) for the given tunnel.
+func tunnelInterfaceNames(prefix, localNodeName, localInterface string) (hostLink, tunnelLink string) {
+	link := sanitizeLinuxIfName(localInterface)
+	hostLink = sanitizeLinuxIfName(fmt.Sprintf("%s-%s", localNodeName, link))
+	tunnelLink = sanitizeLinuxIfName(fmt.Sprintf("%s-%s", prefix, hostLink))
 
-	return nil
+	return hostLink, tunnelLink
 }
 
 func sanitizeLinuxIfName(raw string) string {
@@ -406,12 +410,15 @@ func sanitizeLinuxIfName(raw string) string {
 func (m *vxlanManager) updateVxlanTunnels(
 	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
 ) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
 	// start with deleting extraneous tunnels...
-	for _, existingTunnel := range m.currentTunnels {
+	for key, existingTunnel := range m.currentTunnels {
 		var found bool
 
 		for _, tunnel := range tunnels {
-			if tunnel.LocalInterface == existingTunnel.LocalInterface {
+			if tunnelKey(tunnel) == tunnelKey(existingTunnel) {
 				found = true
 
 				break
@@ -424,13 +431,12 @@ func (m *vxlanManager) updateVxlanTunnels(
 			continue
 		}
 
-		err := m.runContainerlabVxlanToolsDelete(
-			m.ctx,
+		err := m.deleteVxlanTunnel(
 			existingTunnel.LocalNode,
 			existingTunnel.LocalInterface,
 		)
 		if err != nil {
-			m.logger.Fatalf(
+			m.fatalf(
 				"failed deleting extraneous tunnel to remote node '%s' for local interface '%s'"+
 					", error: %s",
 				existingTunnel.RemoteNode,
@@ -438,12 +444,16 @@ func (m *vxlanManager) updateVxlanTunnels(
 				err,
 			)
 		}
+
+		delete(m.currentTunnels, key)
+
+		m.forgetTunnelStatus(key)
 	}
 
 	tunnelsToReCreate := make([]*clabernetesapisv1alpha1.PointToPointTunnel, 0)
 
 	for _, tunnel := range tunnels {
-		existingTunnel, ok := m.currentTunnels[tunnel.LocalInterface]
+		existingTunnel, ok := m.currentTunnels[tunnelKey(tunnel)]
 		if ok && reflect.DeepEqual(existingTunnel, tunnel) {
 			// we've already got a tunnel setup for this interface, so we gotta check to see if our
 			// previously setup destination is the same -- if "yes" we can skip doing anything to
@@ -451,16 +461,30 @@ func (m *vxlanManager) updateVxlanTunnels(
 			continue
 		}
 
+		if ok && onlyTunnelSettingsChanged(existingTunnel, tunnel) {
+			// nothing but the impairment/capture changed, no need to bounce the tunnel for that
+			_, vxlanLink := tunnelInterfaceNames(
+				vxlanInterfacePrefix,
+				tunnel.LocalNode,
+				tunnel.LocalInterface,
+			)
+
+			m.updateTunnelShaping(vxlanLink, existingTunnel, tunnel)
+
+			m.currentTunnels[tunnelKey(tunnel)] = tunnel
+
+			continue
+		}
+
 		if ok {
 			// tunnel for this interface exists but isnt the same as our desired setup, delete the
 			// old tunnel before we create the new one
-			err := m.runContainerlabVxlanToolsDelete(
-				m.ctx,
+			err := m.deleteVxlanTunnel(
 				tunnel.LocalNode,
 				tunnel.LocalInterface,
 			)
 			if err != nil {
-				m.logger.Fatalf(
+				m.fatalf(
 					"failed deleting existing tunnel to remote node '%s' for local interface '%s'"+
 						" before re-configuring, error: %s",
 					tunnel.RemoteNode,
@@ -473,20 +497,14 @@ func (m *vxlanManager) updateVxlanTunnels(
 		tunnelsToReCreate = append(tunnelsToReCreate, tunnel)
 	}
 
+	tunnelIDConflicts := findTunnelIDConflicts(tunnels)
+
 	for _, tunnel := range tunnelsToReCreate {
-		err := m.runContainerlabVxlanToolsCreate(
-			tunnel.LocalNode,
-			tunnel.LocalInterface,
-			tunnel.Destination,
-			tunnel.TunnelID,
-		)
+		err := m.createVxlanTunnelUnlessConflicting(tunnel, tunnelIDConflicts)
 		if err != nil {
-			m.logger.Fatalf(
-				"failed setting up tunnel to remote node '%s' for local interface '%s', error: %s",
-				tunnel.RemoteNode,
-				tunnel.LocalInterface,
-				err,
-			)
+			m.tunnelSetupFailed(tunnel, err)
 		}
+
+		m.currentTunnels[tunnelKey(tunnel)] = tunnel
 	}
 }
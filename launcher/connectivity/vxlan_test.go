@@ -0,0 +1,89 @@
+package connectivity
+
+import (
+	"testing"
+
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestTunnelInterfaceNames(t *testing.T) {
+	cases := []struct {
+		name               string
+		prefix             string
+		localNodeName      string
+		localInterface     string
+		expectedHostLink   string
+		expectedTunnelLink string
+	}{
+		{
+			name:               "simple",
+			prefix:             vxlanInterfacePrefix,
+			localNodeName:      "srl1",
+			localInterface:     "e1-1",
+			expectedHostLink:   "srl1-e1-1",
+			expectedTunnelLink: "vx-srl1-e1-1",
+		},
+		{
+			name:               "invalid-characters",
+			prefix:             greInterfacePrefix,
+			localNodeName:      "ceos1",
+			localInterface:     "Eth0/1",
+			expectedHostLink:   "ceos1-Eth0-1",
+			expectedTunnelLink: "gr-ceos1-Eth0-1",
+		},
+		{
+			name:               "empty-interface",
+			prefix:             vxlanInterfacePrefix,
+			localNodeName:      "srl1",
+			localInterface:     "",
+			expectedHostLink:   "srl1-link",
+			expectedTunnelLink: "vx-srl1-link",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				hostLink, tunnelLink := tunnelInterfaceNames(
+					testCase.prefix,
+					testCase.localNodeName,
+					testCase.localInterface,
+				)
+
+				if hostLink != testCase.expectedHostLink {
+					clabernetestesthelper.FailOutput(t, hostLink, testCase.expectedHostLink)
+				}
+
+				if tunnelLink != testCase.expectedTunnelLink {
+					clabernetestesthelper.FailOutput(t, tunnelLink, testCase.expectedTunnelLink)
+				}
+			})
+	}
+}
+
+func TestTunnelInterfaceNamesLong(t *testing.T) {
+	seen := map[string]string{}
+
+	for _, localInterface := range []string{"ethernet-1/1", "ethernet-1/2", "ethernet-1/10"} {
+		hostLink, tunnelLink := tunnelInterfaceNames(
+			vxlanInterfacePrefix,
+			"a-rather-long-node-name",
+			localInterface,
+		)
+
+		for _, name := range []string{hostLink, tunnelLink} {
+			if len(name) > 15 {
+				t.Fatalf("interface name %q of %q is longer than 15 bytes", name, localInterface)
+			}
+
+			if other, ok := seen[name]; ok {
+				t.Fatalf("interfaces %q and %q share the name %q", localInterface, other, name)
+			}
+
+			seen[name] = localInterface
+		}
+	}
+}
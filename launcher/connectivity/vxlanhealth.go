@@ -0,0 +1,122 @@
+package connectivity
+
+import (
+	"fmt"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+)
+
+const (
+	vxlanHealthCheckInterval = 30 * time.Second
+)
+
+// monitorVxlanTunnels periodically checks that the tunnels we set up are still in place and still
+// pointed at the right remote -- links can be removed out from under us (node container restarts
+// in native mode, someone poking at things) and remote launchers can be rescheduled, so rather
+// than requiring a launcher restart we just repair whatever is broken. The same check also runs
+// whenever the endpoint watch sees a remote launcher move.
+func (m *vxlanManager) monitorVxlanTunnels() {
+	ticker := time.NewTicker(vxlanHealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkVxlanTunnelsOnce()
+		}
+	}
+}
+
+func (m *vxlanManager) checkVxlanTunnelsOnce() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	vxlanRemotes, err := listVxlanRemotes()
+	if err != nil {
+		m.logger.Warnf("failed listing vxlan interfaces, skipping tunnel health check, err: %s", err)
+
+		return
+	}
+
+	var repaired bool
+
+	for _, tunnel := range m.currentTunnels {
+		reason := m.vxlanTunnelUnhealthyReason(tunnel, vxlanRemotes)
+		if reason == "" {
+			continue
+		}
+
+		repaired = true
+
+		m.logger.Warnf(
+			"tunnel to remote node '%s' for local interface '%s' is unhealthy (%s), recreating",
+			tunnel.RemoteNode,
+			tunnel.LocalInterface,
+			reason,
+		)
+
+		err = m.createVxlanTunnel(tunnel)
+		if err != nil {
+			m.logger.Warnf(
+				"failed recreating tunnel to remote node '%s' for local interface '%s', will"+
+					" retry on next health check, error: %s",
+				tunnel.RemoteNode,
+				tunnel.LocalInterface,
+				err,
+			)
+		}
+	}
+
+	if repaired {
+		// recreated tunnels come back up, make sure admin down links stay down
+		tunnels := make([]*clabernetesapisv1alpha1.PointToPointTunnel, 0, len(m.currentTunnels))
+		for _, tunnel := range m.currentTunnels {
+			tunnels = append(tunnels, tunnel)
+		}
+
+		m.updateLinkStates(tunnels)
+	}
+}
+
+// vxlanTunnelUnhealthyReason returns a short description of why the given tunnel is unhealthy, or
+// an empty string if the tunnel is healthy.
+func (m *vxlanManager) vxlanTunnelUnhealthyReason(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+	vxlanRemotes map[int]string,
+) string {
+	hostLink, _ := tunnelInterfaceNames(
+		vxlanInterfacePrefix,
+		tunnel.LocalNode,
+		tunnel.LocalInterface,
+	)
+
+	if !linkExists(hostLink) {
+		return fmt.Sprintf("veth interface %q missing", hostLink)
+	}
+
+	actualRemote, ok := vxlanRemotes[tunnel.TunnelID]
+	if !ok {
+		return fmt.Sprintf("vxlan interface with id %d missing", tunnel.TunnelID)
+	}
+
+	expectedRemote, resolveErr := m.resolveDestination(tunnel.Destination)
+	if resolveErr != nil {
+		// cant tell if the remote moved, but the tunnel is there, so leave it be
+		m.logger.Debugf(
+			"failed re-resolving remote vxlan endpoint %q, error: %s",
+			tunnel.Destination,
+			resolveErr,
+		)
+
+		return ""
+	}
+
+	if actualRemote != expectedRemote {
+		return fmt.Sprintf("remote changed from %q to %q", actualRemote, expectedRemote)
+	}
+
+	return ""
+}
@@ -1,22 +1,87 @@
 package connectivity
 
 import (
-	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math/rand"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
-	clabernetesgeneratedclientset "github.com/srl-labs/clabernetes/generated/clientset"
-	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachinerywatch "k8s.io/apimachinery/pkg/watch"
 )
 
-func watchConnectivity(
-	ctx context.Context,
-	logger claberneteslogging.Instance,
-	clabernetesClient *clabernetesgeneratedclientset.Clientset,
+const (
+	defaultConnectivityUpdateBatchWindowSeconds = 2
+	defaultConnectivityUpdateJitterSeconds      = 5
+)
+
+// tunnelsDigest returns a digest of the given tunnels that does not depend on their order, so
+// connectivity cr updates that do not touch the tunnels of this launcher can be told apart from
+// ones that do.
+func tunnelsDigest(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) string {
+	// never nil, so no tunnels digest the same no matter where they came from
+	sortedTunnels := append(
+		make([]*clabernetesapisv1alpha1.PointToPointTunnel, 0, len(tunnels)),
+		tunnels...,
+	)
+
+	slices.SortFunc(
+		sortedTunnels,
+		func(a, b *clabernetesapisv1alpha1.PointToPointTunnel) int {
+			return strings.Compare(tunnelKey(a), tunnelKey(b))
+		},
+	)
+
+	// marshaling plain api structs can not really fail, and if it somehow did the digest would
+	// just never match which means we apply every update like we used to
+	b, _ := json.Marshal(sortedTunnels) //nolint:errchkjson
+
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// connectivityUpdateDelay returns how long to wait before applying a change to the tunnels of this
+// launcher -- the batch window (during which any further changes replace the pending one) plus a
+// random jitter, so that an edit of a (large) topology does not make every launcher re-create its
+// tunnels at the very same moment. Both can be overridden (in seconds) via env vars.
+func connectivityUpdateDelay() time.Duration {
+	batchWindow := clabernetesutil.GetEnvIntOrDefault(
+		clabernetesconstants.LauncherConnectivityUpdateBatchWindowEnv,
+		defaultConnectivityUpdateBatchWindowSeconds,
+	)
+
+	jitter := clabernetesutil.GetEnvIntOrDefault(
+		clabernetesconstants.LauncherConnectivityUpdateJitterEnv,
+		defaultConnectivityUpdateJitterSeconds,
+	)
+
+	delay := time.Duration(max(batchWindow, 0)) * time.Second
+
+	if jitter > 0 {
+		maxJitter := int64(time.Duration(jitter) * time.Second)
+
+		delay += time.Duration(rand.Int63n(maxJitter)) //nolint:gosec
+	}
+
+	return delay
+}
+
+// watchConnectivity watches the connectivity cr of the topology and hands the tunnels of the local
+// node to handleUpdate whenever they change. The connectivity cr is modified far more often than
+// the tunnels of any one launcher change (every launcher reports its tunnel statuses there, and
+// most topology edits only touch some nodes), so updates that leave the local tunnels as they are
+// (compared to what was last applied) are ignored. Changes are applied in batches, see
+// connectivityUpdateDelay.
+func (c *common) watchConnectivity(
 	handleUpdate func(nodeTunnels []*clabernetesapisv1alpha1.PointToPointTunnel),
 ) {
 	nodeName := os.Getenv(clabernetesconstants.LauncherNodeNameEnv)
@@ -28,44 +93,138 @@ func watchConnectivity(
 		Watch: true,
 	}
 
-	watch, err := clabernetesClient.ClabernetesV1alpha1().
+	watch, err := c.clabernetesClient.ClabernetesV1alpha1().
 		Connectivities(os.Getenv(clabernetesconstants.PodNamespaceEnv)).
-		Watch(ctx, listOptions)
+		Watch(c.ctx, listOptions)
 	if err != nil {
-		logger.Fatalf("failed watching clabernetes connectivity, err: %s", err)
+		c.fatalf("failed watching clabernetes connectivity, err: %s", err)
 	}
 
-	for event := range watch.ResultChan() {
-		switch event.Type {
-		case apimachinerywatch.Modified:
-			logger.Info("processing connectivity modification event")
+	appliedDigest := tunnelsDigest(c.initialTunnels)
 
-			tunnelsCR, ok := event.Object.(*clabernetesapisv1alpha1.Connectivity)
+	var (
+		pendingTunnels []*clabernetesapisv1alpha1.PointToPointTunnel
+		pendingDigest  string
+		applyTimer     *time.Timer
+		applyChan      <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			if applyTimer != nil {
+				applyTimer.Stop()
+			}
+
+			return
+		case event, ok := <-watch.ResultChan():
 			if !ok {
-				logger.Warn(
-					"failed casting event object to connectivity custom resource," +
-						" this is probably a bug",
-				)
+				if applyChan != nil {
+					// the watch is done, so nothing can replace the pending update anymore --
+					// apply it now rather than losing it
+					c.logger.Info("connectivity watch closed, applying pending tunnel update")
 
-				continue
+					applyTimer.Stop()
+
+					handleUpdate(pendingTunnels)
+				}
+
+				return
 			}
 
-			nodeTunnels, ok := tunnelsCR.Spec.PointToPointTunnels[nodeName]
+			nodeTunnels, ok := c.handleConnectivityEvent(event, nodeName)
 			if !ok {
-				logger.Warnf(
-					"no tunnels found for node %q, continuing but things may be broken",
-					nodeName,
-				)
+				continue
 			}
 
-			handleUpdate(nodeTunnels)
-		case apimachinerywatch.Added,
-			apimachinerywatch.Deleted,
-			apimachinerywatch.Bookmark,
-			apimachinerywatch.Error:
-			logger.Warnf(
-				"connectivity resource had %s event occur, ignoring...", event.Type,
+			digest := tunnelsDigest(nodeTunnels)
+
+			if digest == appliedDigest {
+				if applyChan != nil {
+					// the local tunnels changed back before we got to apply the change
+					c.logger.Info("local tunnels reverted to their applied state, dropping update")
+
+					applyTimer.Stop()
+
+					applyChan = nil
+					pendingTunnels, pendingDigest = nil, ""
+				}
+
+				c.logger.Debug("local tunnels unchanged, ignoring connectivity modification")
+
+				continue
+			}
+
+			if digest == pendingDigest {
+				continue
+			}
+
+			pendingTunnels, pendingDigest = nodeTunnels, digest
+
+			if applyChan != nil {
+				// further changes join the pending batch without pushing it out, so a stream of
+				// edits can not hold off updating the tunnels indefinitely
+				c.logger.Info("local tunnels changed again, updating pending tunnel update")
+
+				continue
+			}
+
+			delay := connectivityUpdateDelay()
+
+			c.logger.Infof("local tunnels changed, applying update in %s", delay)
+
+			applyTimer = time.NewTimer(delay)
+			applyChan = applyTimer.C
+		case <-applyChan:
+			c.logger.Info("applying tunnel update")
+
+			handleUpdate(pendingTunnels)
+
+			appliedDigest = pendingDigest
+
+			applyChan = nil
+			pendingTunnels, pendingDigest = nil, ""
+		}
+	}
+}
+
+// handleConnectivityEvent returns the tunnels of the given local node from the given connectivity
+// watch event, and false if the event is not a modification of the connectivity cr.
+func (c *common) handleConnectivityEvent(
+	event apimachinerywatch.Event,
+	nodeName string,
+) ([]*clabernetesapisv1alpha1.PointToPointTunnel, bool) {
+	switch event.Type {
+	case apimachinerywatch.Modified:
+		c.logger.Debug("processing connectivity modification event")
+
+		tunnelsCR, ok := event.Object.(*clabernetesapisv1alpha1.Connectivity)
+		if !ok {
+			c.logger.Warn(
+				"failed casting event object to connectivity custom resource," +
+					" this is probably a bug",
+			)
+
+			return nil, false
+		}
+
+		nodeTunnels, ok := tunnelsCR.Spec.PointToPointTunnels[nodeName]
+		if !ok {
+			c.logger.Warnf(
+				"no tunnels found for node %q, continuing but things may be broken",
+				nodeName,
 			)
 		}
+
+		return nodeTunnels, true
+	case apimachinerywatch.Added,
+		apimachinerywatch.Deleted,
+		apimachinerywatch.Bookmark,
+		apimachinerywatch.Error:
+		c.logger.Warnf(
+			"connectivity resource had %s event occur, ignoring...", event.Type,
+		)
 	}
+
+	return nil, false
 }
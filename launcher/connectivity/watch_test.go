@@ -0,0 +1,159 @@
+package connectivity
+
+import (
+	"testing"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func TestTunnelsDigest(t *testing.T) {
+	tunnelA := &clabernetesapisv1alpha1.PointToPointTunnel{
+		TunnelID:       1,
+		Destination:    "10.0.0.2",
+		LocalNode:      "srl1",
+		LocalInterface: "e1-1",
+		RemoteNode:     "srl2",
+	}
+
+	tunnelB := &clabernetesapisv1alpha1.PointToPointTunnel{
+		TunnelID:       2,
+		Destination:    "10.0.0.3",
+		LocalNode:      "srl1",
+		LocalInterface: "e1-2",
+		RemoteNode:     "srl3",
+	}
+
+	movedTunnelB := &clabernetesapisv1alpha1.PointToPointTunnel{
+		TunnelID:       2,
+		Destination:    "10.0.0.4",
+		LocalNode:      "srl1",
+		LocalInterface: "e1-2",
+		RemoteNode:     "srl3",
+	}
+
+	digest := tunnelsDigest([]*clabernetesapisv1alpha1.PointToPointTunnel{tunnelA, tunnelB})
+
+	cases := []struct {
+		name       string
+		a          []*clabernetesapisv1alpha1.PointToPointTunnel
+		b          []*clabernetesapisv1alpha1.PointToPointTunnel
+		expectSame bool
+	}{
+		{
+			name:       "nil-and-empty",
+			a:          nil,
+			b:          []*clabernetesapisv1alpha1.PointToPointTunnel{},
+			expectSame: true,
+		},
+		{
+			name:       "order",
+			a:          []*clabernetesapisv1alpha1.PointToPointTunnel{tunnelA, tunnelB},
+			b:          []*clabernetesapisv1alpha1.PointToPointTunnel{tunnelB, tunnelA},
+			expectSame: true,
+		},
+		{
+			name:       "changed-tunnel",
+			a:          []*clabernetesapisv1alpha1.PointToPointTunnel{tunnelA, tunnelB},
+			b:          []*clabernetesapisv1alpha1.PointToPointTunnel{tunnelA, movedTunnelB},
+			expectSame: false,
+		},
+		{
+			name:       "removed-tunnel",
+			a:          []*clabernetesapisv1alpha1.PointToPointTunnel{tunnelA, tunnelB},
+			b:          []*clabernetesapisv1alpha1.PointToPointTunnel{tunnelA},
+			expectSame: false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				same := tunnelsDigest(testCase.a) == tunnelsDigest(testCase.b)
+				if same != testCase.expectSame {
+					clabernetestesthelper.FailOutput(t, same, testCase.expectSame)
+				}
+			})
+	}
+
+	if tunnelsDigest(
+		[]*clabernetesapisv1alpha1.PointToPointTunnel{tunnelB, tunnelA},
+	) != digest {
+		t.Fatal("expected digest to be stable")
+	}
+}
+
+func TestConnectivityUpdateDelay(t *testing.T) {
+	cases := []struct {
+		name        string
+		batchWindow string
+		jitter      string
+		expectedMin time.Duration
+		expectedMax time.Duration
+	}{
+		{
+			name:        "no-jitter",
+			batchWindow: "3",
+			jitter:      "0",
+			expectedMin: 3 * time.Second,
+			expectedMax: 3 * time.Second,
+		},
+		{
+			name:        "negative-batch-window",
+			batchWindow: "-1",
+			jitter:      "0",
+			expectedMin: 0,
+			expectedMax: 0,
+		},
+		{
+			name:        "jitter",
+			batchWindow: "1",
+			jitter:      "2",
+			expectedMin: 1 * time.Second,
+			expectedMax: 3*time.Second - 1,
+		},
+		{
+			name:        "defaults",
+			batchWindow: "",
+			jitter:      "",
+			expectedMin: defaultConnectivityUpdateBatchWindowSeconds * time.Second,
+			expectedMax: (defaultConnectivityUpdateBatchWindowSeconds+
+				defaultConnectivityUpdateJitterSeconds)*time.Second - 1,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				// empty values do not parse as ints, so the defaults apply to them
+				t.Setenv(
+					clabernetesconstants.LauncherConnectivityUpdateBatchWindowEnv,
+					testCase.batchWindow,
+				)
+				t.Setenv(
+					clabernetesconstants.LauncherConnectivityUpdateJitterEnv,
+					testCase.jitter,
+				)
+
+				for range 20 {
+					delay := connectivityUpdateDelay()
+					if delay < testCase.expectedMin || delay > testCase.expectedMax {
+						t.Fatalf(
+							"expected delay between %s and %s, got %s",
+							testCase.expectedMin,
+							testCase.expectedMax,
+							delay,
+						)
+					}
+				}
+			})
+	}
+}
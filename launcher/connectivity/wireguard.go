@@ -0,0 +1,416 @@
+//go:build linux
+// +build linux
+
+package connectivity
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+const (
+	wireGuardInterface           = "wg-clab"
+	wireGuardInterfacePrefix     = "wv"
+	wireGuardPersistentKeepAlive = 25
+	wireGuardPeerRetryInterval   = 5 * time.Second
+	// the kubelet takes up to its sync period plus its secret cache ttl (a minute each by default)
+	// to update mounted secrets, so the keys of freshly added nodes may well not be there yet
+	wireGuardPeerRetryTimeout = 3 * time.Minute
+)
+
+// wireGuardManager carries the emulated links in vxlan tunnels between the wireguard overlay
+// addresses of the launchers -- that is, there is a single wireguard interface per launcher with
+// all the remote launchers as peers, and the (L2) links ride in vxlan over that (L3) interface so
+// all link traffic is encrypted on the cluster network.
+type wireGuardManager struct {
+	*common
+
+	localNode      string
+	localAddress   string
+	currentTunnels map[string]*clabernetesapisv1alpha1.PointToPointTunnel
+	currentPeers   map[string]string
+}
+
+func (m *wireGuardManager) Run() {
+	m.currentTunnels = make(map[string]*clabernetesapisv1alpha1.PointToPointTunnel)
+	m.currentPeers = make(map[string]string)
+
+	m.logger.Info(
+		"connectivity mode is 'wireguard', setting up wireguard interface and any required " +
+			"tunnels...",
+	)
+
+	m.watchEndpoints()
+
+	err := m.setupWireGuardInterface()
+	if err != nil {
+		m.fatalf("failed setting up wireguard interface, error: %s", err)
+	}
+
+	for _, tunnel := range m.initialTunnels {
+		err = m.createWireGuardTunnel(tunnel)
+		if err != nil {
+			m.fatalf(
+				"failed setting up tunnel to remote node '%s' for local interface '%s', error: %s",
+				tunnel.RemoteNode,
+				tunnel.LocalInterface,
+				err,
+			)
+		}
+
+		m.currentTunnels[tunnelKey(tunnel)] = tunnel
+	}
+
+	m.logger.Debug("initial wireguard tunnel creation complete")
+
+	m.updatePacketCaptures(m.initialTunnels)
+
+	m.updateLinkStates(m.initialTunnels)
+
+	m.startSelfTest()
+
+	m.startTrafficCounters()
+
+	m.startMetrics()
+
+	m.startLivenessProbes()
+
+	m.logger.Debug("start connectivity custom resource watch...")
+
+	m.startConnectivityWatch(
+		m.withLinkStates(m.withPacketCaptures(m.updateWireGuardTunnels)),
+	)
+
+	m.logger.Debug("wireguard connectivity setup complete")
+}
+
+// wireGuardOverlayPrefixLen returns the prefix length of the wireguard overlay range, the
+// controller only sets the overlay env var if the topology overrides the default range.
+func wireGuardOverlayPrefixLen() int {
+	overlayCIDR := os.Getenv(clabernetesconstants.LauncherWireGuardOverlayCIDREnv)
+	if overlayCIDR == "" {
+		overlayCIDR = clabernetesconstants.WireGuardOverlayCIDR
+	}
+
+	overlayPrefix, err := netip.ParsePrefix(overlayCIDR)
+	if err != nil {
+		overlayPrefix = netip.MustParsePrefix(clabernetesconstants.WireGuardOverlayCIDR)
+	}
+
+	return overlayPrefix.Bits()
+}
+
+func readWireGuardFile(name string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(clabernetesconstants.LauncherWireGuardPeersPath, name))
+	if err != nil {
+		return "", fmt.Errorf(
+			"%w: failed reading wireguard file %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			name,
+			err,
+		)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+func (m *wireGuardManager) setupWireGuardInterface() error {
+	m.localNode = os.Getenv(clabernetesconstants.LauncherNodeNameEnv)
+
+	var err error
+
+	// our own address lives with the peers, and so may not be mounted yet either
+	_, m.localAddress, err = m.readWireGuardPeer(m.localNode)
+	if err != nil {
+		return err
+	}
+
+	err = deleteLinkIfExists(wireGuardInterface)
+	if err != nil {
+		m.logger.Warnf(
+			"failed while deleting existing wireguard interface '%s', error: '%s'",
+			wireGuardInterface,
+			err,
+		)
+	}
+
+	err = createWireGuardLink(wireGuardInterface, m.localAddress, wireGuardOverlayPrefixLen())
+	if err != nil {
+		return err
+	}
+
+	err = m.runWireGuardCommand(
+		"set", wireGuardInterface,
+		"private-key", filepath.Join(clabernetesconstants.LauncherWireGuardPath, "private"),
+		"listen-port", strconv.Itoa(wireGuardPort()),
+	)
+	if err != nil {
+		return err
+	}
+
+	return setLinkUp(wireGuardInterface)
+}
+
+// runWireGuardCommand runs "wg" with the given arguments -- netlink covers the wireguard interface
+// itself, but not its keys and peers.
+func (m *wireGuardManager) runWireGuardCommand(args ...string) error {
+	cmd := exec.CommandContext(m.ctx, "wg", args...)
+
+	m.logger.Debugf("running wireguard command '%s'", cmd.Args)
+
+	cmd.Stdout = m.logger
+	cmd.Stderr = m.logger
+
+	return cmd.Run()
+}
+
+func (m *wireGuardManager) ensureWireGuardPeer(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) (string, error) {
+	// no need to re-point peers when remote launchers move, wireguard roams to wherever the
+	// (authenticated) traffic of a peer comes from
+	resolvedRemote, err := m.resolveDestination(tunnel.Destination)
+	if err != nil {
+		return "", err
+	}
+
+	m.logger.Debugf("resolved remote wireguard peer endpoint address as '%s'", resolvedRemote)
+
+	publicKey, address, err := m.readWireGuardPeer(tunnel.RemoteNode)
+	if err != nil {
+		return "", err
+	}
+
+	// setting a peer is idempotent, so no harm in doing this for every tunnel to the same node
+	err = m.runWireGuardCommand(
+		"set", wireGuardInterface,
+		"peer", publicKey,
+		"endpoint", net.JoinHostPort(
+			resolvedRemote,
+			strconv.Itoa(wireGuardPort()),
+		),
+		"allowed-ips", fmt.Sprintf("%s/32", address),
+		"persistent-keepalive", strconv.Itoa(wireGuardPersistentKeepAlive),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	m.currentPeers[tunnel.RemoteNode] = publicKey
+
+	return address, nil
+}
+
+// readWireGuardPeer returns the public key and overlay address of the given remote node, retrying
+// until they show up in the mounted peers secret -- the controller adds them to the secret along
+// with the tunnels to a new node, but the kubelet only updates the mounted copy some time later.
+func (m *wireGuardManager) readWireGuardPeer(
+	remoteNode string,
+) (publicKey, address string, err error) {
+	timeout := time.After(wireGuardPeerRetryTimeout)
+
+	for {
+		publicKey, err = readWireGuardFile(fmt.Sprintf("%s.public", remoteNode))
+		if err == nil {
+			address, err = readWireGuardFile(fmt.Sprintf("%s.address", remoteNode))
+			if err == nil {
+				return publicKey, address, nil
+			}
+		}
+
+		m.logger.Infof(
+			"wireguard key/address of node '%s' not mounted yet, will retry, error: %s",
+			remoteNode,
+			err,
+		)
+
+		select {
+		case <-m.ctx.Done():
+			return "", "", err
+		case <-timeout:
+			return "", "", err
+		case <-time.After(wireGuardPeerRetryInterval):
+		}
+	}
+}
+
+func (m *wireGuardManager) createWireGuardTunnel(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) (err error) {
+	var remoteAddress string
+
+	defer func() {
+		m.reportTunnelStatus(tunnel, remoteAddress, err)
+	}()
+
+	remoteAddress, err = m.ensureWireGuardPeer(tunnel)
+	if err != nil {
+		return err
+	}
+
+	hostLink, vxlanLink := tunnelInterfaceNames(
+		wireGuardInterfacePrefix,
+		tunnel.LocalNode,
+		tunnel.LocalInterface,
+	)
+
+	err = deleteTunnelLink(hostLink, vxlanLink)
+	if err != nil {
+		m.logger.Warnf(
+			"failed while deleting existing wireguard vxlan interface '%s', error: '%s'",
+			vxlanLink,
+			err,
+		)
+	}
+
+	err = m.ensurePodLinkExists(m.ctx, tunnel.LocalNode, sanitizeLinuxIfName(tunnel.LocalInterface))
+	if err != nil {
+		return err
+	}
+
+	err = createWireGuardVxlanLink(
+		vxlanLink,
+		hostLink,
+		remoteAddress,
+		m.localAddress,
+		wireGuardInterface,
+		tunnel.TunnelID,
+		vxlanPort(),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed creating wireguard tunnel for local interface %q, error: %w",
+			claberneteserrors.ErrConnectivity,
+			tunnel.LocalInterface,
+			err,
+		)
+	}
+
+	if tunnel.Impairment == nil && tunnel.Bandwidth == "" {
+		return nil
+	}
+
+	return m.applyLinkShaping(vxlanLink, tunnel)
+}
+
+func (m *wireGuardManager) deleteWireGuardTunnel(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) error {
+	hostLink, vxlanLink := tunnelInterfaceNames(
+		wireGuardInterfacePrefix,
+		tunnel.LocalNode,
+		tunnel.LocalInterface,
+	)
+
+	return deleteTunnelLink(hostLink, vxlanLink)
+}
+
+func (m *wireGuardManager) pruneWireGuardPeers() {
+	referencedPeers := map[string]bool{}
+
+	for _, tunnel := range m.currentTunnels {
+		referencedPeers[tunnel.RemoteNode] = true
+	}
+
+	for remoteNode, publicKey := range m.currentPeers {
+		if referencedPeers[remoteNode] {
+			continue
+		}
+
+		err := m.runWireGuardCommand("set", wireGuardInterface, "peer", publicKey, "remove")
+		if err != nil {
+			m.logger.Warnf(
+				"failed removing extraneous wireguard peer for remote node '%s', error: %s",
+				remoteNode,
+				err,
+			)
+
+			continue
+		}
+
+		delete(m.currentPeers, remoteNode)
+	}
+}
+
+func (m *wireGuardManager) updateWireGuardTunnels(
+	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+) {
+	for key, existingTunnel := range m.currentTunnels {
+		var found bool
+
+		for _, tunnel := range tunnels {
+			if tunnelKey(tunnel) == tunnelKey(existingTunnel) {
+				found = true
+
+				break
+			}
+		}
+
+		if found {
+			continue
+		}
+
+		err := m.deleteWireGuardTunnel(existingTunnel)
+		if err != nil {
+			m.fatalf(
+				"failed deleting extraneous tunnel to remote node '%s' for local interface '%s'"+
+					", error: %s",
+				existingTunnel.RemoteNode,
+				existingTunnel.LocalInterface,
+				err,
+			)
+		}
+
+		delete(m.currentTunnels, key)
+
+		m.forgetTunnelStatus(key)
+	}
+
+	for _, tunnel := range tunnels {
+		existingTunnel, ok := m.currentTunnels[tunnelKey(tunnel)]
+		if ok && reflect.DeepEqual(existingTunnel, tunnel) {
+			continue
+		}
+
+		if ok && onlyTunnelSettingsChanged(existingTunnel, tunnel) {
+			_, tunnelLink := tunnelInterfaceNames(
+				wireGuardInterfacePrefix,
+				tunnel.LocalNode,
+				tunnel.LocalInterface,
+			)
+
+			m.updateTunnelShaping(tunnelLink, existingTunnel, tunnel)
+
+			m.currentTunnels[tunnelKey(tunnel)] = tunnel
+
+			continue
+		}
+
+		// create handles deleting any existing tunnel for this interface
+		err := m.createWireGuardTunnel(tunnel)
+		if err != nil {
+			m.fatalf(
+				"failed setting up tunnel to remote node '%s' for local interface '%s', error: %s",
+				tunnel.RemoteNode,
+				tunnel.LocalInterface,
+				err,
+			)
+		}
+
+		m.currentTunnels[tunnelKey(tunnel)] = tunnel
+	}
+
+	m.pruneWireGuardPeers()
+}
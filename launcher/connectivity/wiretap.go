@@ -0,0 +1,134 @@
+package connectivity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+)
+
+// wireTapResolveInterval is how often a wire tap re-resolves the destinations of its tunnels, it
+// has no endpoint watch, so this is how it follows the launchers on either end around.
+const wireTapResolveInterval = 15 * time.Second
+
+// WireTapSide is one side of a wire tap -- the vxlan tunnel towards one end of the tapped link.
+type WireTapSide struct {
+	// Name is the name of the side, this is also the name of the interface in the wire tap pod
+	// that carries the traffic of this side.
+	Name string
+	// TunnelID is the vxlan id of the tunnel of this side.
+	TunnelID int
+	// Destination is the fabric service of the launcher of the node on this side.
+	Destination string
+}
+
+// RunWireTap runs a wire tap -- it connects a veth pair named after the two given sides, and a
+// vxlan tunnel towards the launcher on each side attached to the veth of that side, so whatever
+// one side sends comes out of the other side. The veths are where traffic can be captured and
+// impaired without touching the launchers or nodes at all. The wire tap answers the liveness
+// probes of the launchers on both sides just like any other launcher would. Blocks until the
+// given context is done.
+func RunWireTap(
+	ctx context.Context,
+	logger claberneteslogging.Instance,
+	sides [2]WireTapSide,
+) error {
+	for _, side := range sides {
+		if side.Name == "" || side.TunnelID == 0 || side.Destination == "" {
+			return fmt.Errorf(
+				"%w: incomplete wire tap side %+v",
+				claberneteserrors.ErrConnectivity,
+				side,
+			)
+		}
+	}
+
+	// the pod network namespace outlives container restarts, so start over with a fresh veth pair
+	// (deleting one end of a veth pair deletes the other as well)
+	err := deleteLinkIfExists(sides[0].Name)
+	if err != nil {
+		return err
+	}
+
+	err = createVethPair(sides[0].Name, sides[1].Name)
+	if err != nil {
+		return err
+	}
+
+	go serveLiveness(ctx, logger)
+
+	var resolvedDestinations [2]string
+
+	ticker := time.NewTicker(wireTapResolveInterval)
+	defer ticker.Stop()
+
+	for {
+		for idx, side := range sides {
+			resolvedDestination, resolveErr := resolveServiceEndpointViaKubeAPI(
+				ctx,
+				side.Destination,
+			)
+			if resolveErr != nil {
+				logger.Warnf(
+					"failed resolving destination %q of wire tap side %q, will retry, err: %s",
+					side.Destination,
+					side.Name,
+					resolveErr,
+				)
+
+				continue
+			}
+
+			if resolvedDestination == resolvedDestinations[idx] {
+				continue
+			}
+
+			err = updateWireTapTunnel(side, resolvedDestination)
+			if err != nil {
+				return err
+			}
+
+			logger.Infof(
+				"wire tap side %q tunnel with id %d now points at %q (%s)",
+				side.Name,
+				side.TunnelID,
+				side.Destination,
+				resolvedDestination,
+			)
+
+			resolvedDestinations[idx] = resolvedDestination
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// updateWireTapTunnel (re)creates the vxlan tunnel of the given wire tap side towards the given
+// (resolved) destination.
+func updateWireTapTunnel(side WireTapSide, resolvedDestination string) error {
+	tunnelLink := sanitizeLinuxIfName(fmt.Sprintf("%s-%s", vxlanInterfacePrefix, side.Name))
+
+	err := deleteIngressQdisc(side.Name)
+	if err != nil {
+		return err
+	}
+
+	err = deleteLinkIfExists(tunnelLink)
+	if err != nil {
+		return err
+	}
+
+	return createVxlanLink(
+		tunnelLink,
+		side.Name,
+		resolvedDestination,
+		side.TunnelID,
+		vxlanPort(),
+	)
+}
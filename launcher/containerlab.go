@@ -12,6 +12,7 @@ import (
 
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
 	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
 )
 
 func extractContainerlabBin(r io.Reader) error {
@@ -105,7 +106,7 @@ func (c *clabernetes) installContainerlabVersion(version string) error {
 }
 
 func (c *clabernetes) runContainerlab() error {
-	containerlabLogFile, err := os.Create("containerlab.log")
+	containerlabLogFile, err := os.Create(containerlabLogPath)
 	if err != nil {
 		return err
 	}
@@ -115,7 +116,7 @@ func (c *clabernetes) runContainerlab() error {
 	args := []string{
 		"deploy",
 		"-t",
-		"topo.clab.yaml",
+		c.topologyFile,
 	}
 
 	if !(os.Getenv(clabernetesconstants.LauncherContainerlabPersist) == clabernetesconstants.True) {
@@ -143,3 +144,21 @@ func (c *clabernetes) runContainerlab() error {
 
 	return nil
 }
+
+// resolveNodeKind returns the containerlab kind of the given node from the mounted topology file,
+// or an empty string if we cant figure out the kind.
+func resolveNodeKind(nodeName string) string {
+	rawConfig, err := os.ReadFile("/clabernetes/topo.clab.yaml")
+	if err != nil {
+		return ""
+	}
+
+	config, err := clabernetesutilcontainerlab.LoadContainerlabConfig(string(rawConfig))
+	if err != nil || config.Topology == nil {
+		return ""
+	}
+
+	containerlabKind, _ := config.Topology.GetNodeKindType(nodeName)
+
+	return containerlabKind
+}
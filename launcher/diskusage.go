@@ -0,0 +1,171 @@
+package launcher
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+const (
+	diskUsageCheckInterval = 30 * time.Second
+	dockerDataPath         = "/var/lib/docker"
+)
+
+// resolveDiskPressureThresholds returns the warn, cleanup and evict thresholds (used percent of
+// the docker data volume) -- the topology thresholds if it sets any, the defaults otherwise.
+func (c *clabernetes) resolveDiskPressureThresholds() (warn, cleanup, evict int) {
+	warn = clabernetesconstants.DiskPressureDefaultWarnPercent
+	cleanup = clabernetesconstants.DiskPressureDefaultCleanupPercent
+	evict = clabernetesconstants.DiskPressureDefaultEvictPercent
+
+	rawDiskPressure := os.Getenv(clabernetesconstants.LauncherDiskPressureEnv)
+	if rawDiskPressure == "" {
+		return warn, cleanup, evict
+	}
+
+	diskPressure := &clabernetesapisv1alpha1.DiskPressure{}
+
+	err := json.Unmarshal([]byte(rawDiskPressure), diskPressure)
+	if err != nil {
+		c.logger.Warnf(
+			"failed unmarshaling topology disk pressure thresholds, using defaults, err: %s",
+			err,
+		)
+
+		return warn, cleanup, evict
+	}
+
+	if diskPressure.WarnPercent > 0 {
+		warn = diskPressure.WarnPercent
+	}
+
+	if diskPressure.CleanupPercent > 0 {
+		cleanup = diskPressure.CleanupPercent
+	}
+
+	if diskPressure.EvictPercent > 0 {
+		evict = diskPressure.EvictPercent
+	}
+
+	return warn, cleanup, evict
+}
+
+// diskUsagePercent returns the used percent of the filesystem the given path lives on, computed
+// the way df does -- space reserved for root does not count as available.
+func diskUsagePercent(path string) (int, error) {
+	stat := syscall.Statfs_t{}
+
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, err
+	}
+
+	used := (stat.Blocks - stat.Bfree) * uint64(stat.Bsize)
+	available := stat.Bavail * uint64(stat.Bsize)
+
+	if used+available == 0 {
+		return 0, nil
+	}
+
+	// round up like df does, so "almost full" does not show up as some lower threshold
+	return int((used*100 + used + available - 1) / (used + available)), nil
+}
+
+// monitorDiskUsage keeps an eye on the usage of the docker data volume of the launcher -- nested
+// images and qcow2 overlays silently fill it up otherwise. The usage is published on the heartbeat
+// lease, at the cleanup threshold unused images and build cache are pruned, and if that does not
+// get the usage back under the evict threshold the launcher exits so the controller evicts the
+// launcher pod, which gets the node a fresh volume.
+func (c *clabernetes) monitorDiskUsage() {
+	warn, cleanup, evict := c.resolveDiskPressureThresholds()
+
+	c.logger.Debugf(
+		"monitoring docker data volume usage, warn at %d%%, cleanup at %d%%, evict at %d%%",
+		warn,
+		cleanup,
+		evict,
+	)
+
+	ticker := time.NewTicker(diskUsageCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		usage, err := diskUsagePercent(dockerDataPath)
+		if err != nil {
+			c.logger.Debugf("failed checking docker data volume usage, err: %s", err)
+
+			continue
+		}
+
+		if usage >= cleanup {
+			c.logger.Warnf(
+				"docker data volume is %d%% full, pruning unused images and build cache",
+				usage,
+			)
+
+			c.pruneDockerData()
+
+			usage, err = diskUsagePercent(dockerDataPath)
+			if err != nil {
+				c.logger.Debugf("failed checking docker data volume usage, err: %s", err)
+
+				continue
+			}
+		}
+
+		c.recordDiskUsage(usage)
+
+		if usage >= evict {
+			c.fatalf(
+				clabernetesconstants.TerminationReasonDiskPressure,
+				"docker data volume is %d%% full (evict threshold %d%%) even after pruning unused"+
+					" images, exiting so the node is evicted",
+				usage,
+				evict,
+			)
+		}
+
+		if usage >= warn {
+			c.logger.Warnf("docker data volume is %d%% full, node is under disk pressure", usage)
+		}
+	}
+}
+
+// pruneDockerData removes all images not used by any container, and all build cache, from the
+// docker daemon of the launcher. The image of the node is in use so it is left alone.
+func (c *clabernetes) pruneDockerData() {
+	for _, args := range [][]string{
+		{"image", "prune", "--all", "--force"},
+		{"builder", "prune", "--all", "--force"},
+	} {
+		pruneCmd := exec.CommandContext(c.ctx, "docker", args...)
+
+		pruneCmd.Stdout = c.logger
+		pruneCmd.Stderr = c.logger
+
+		err := pruneCmd.Run()
+		if err != nil {
+			c.logger.Warnf("failed running docker %s %s, error: %s", args[0], args[1], err)
+		}
+	}
+}
+
+// recordDiskUsage stores the given docker data volume usage so the heartbeat publishes it on the
+// heartbeat lease, from where the controller adds it to the topology status.
+func (c *clabernetes) recordDiskUsage(usage int) {
+	diskUsage := strconv.Itoa(usage)
+
+	c.diskUsage.Store(&diskUsage)
+}
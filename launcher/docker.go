@@ -3,6 +3,7 @@ package launcher
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -21,6 +22,8 @@ const (
 	dockerDaemonConfig   = "/etc/docker/daemon.json"
 	vfsStorageDriver     = "vfs"
 	overlayStorageDriver = "overlay2"
+
+	dockerResponseTimeout = 30 * time.Second
 )
 
 func daemonConfigExists() bool {
@@ -274,7 +277,9 @@ func getContainerIDForNodeName(ctx context.Context, nodeName string) (string, er
 		"ps",
 		"--quiet",
 		"--filter",
-		fmt.Sprintf("name=%s", nodeName),
+		// the node name label rather than the container name, a name filter is a substring match
+		// so with more than one container in the pod "srl1" would also match "srl10"
+		fmt.Sprintf("label=clab-node-name=%s", nodeName),
 	)
 
 	output, err := psCmd.Output()
@@ -302,3 +307,63 @@ func getContainerAddr(ctx context.Context, containerID string) (string, error) {
 
 	return strings.TrimSpace(string(output)), nil
 }
+
+// containerState is the (relevant part of the) state of a container as reported by docker inspect.
+type containerState struct {
+	Status    string `json:"Status"`
+	Running   bool   `json:"Running"`
+	OOMKilled bool   `json:"OOMKilled"`
+	ExitCode  int    `json:"ExitCode"`
+	Error     string `json:"Error"`
+}
+
+func getContainerState(ctx context.Context, containerID string) (*containerState, error) {
+	inspectCmd := exec.CommandContext(
+		ctx,
+		"docker",
+		"inspect",
+		"--format",
+		"{{json .State}}",
+		containerID,
+	)
+
+	output, err := inspectCmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	state := &containerState{}
+
+	err = json.Unmarshal(output, state)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// dockerResponding returns an error if the docker daemon does not answer (in time).
+func dockerResponding(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, dockerResponseTimeout)
+	defer cancel()
+
+	versionCmd := exec.CommandContext(
+		ctx,
+		"docker",
+		"version",
+		"--format",
+		"{{.Server.Version}}",
+	)
+
+	output, err := versionCmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf(
+			"%w: docker daemon not responding, err: %w, output: %s",
+			claberneteserrors.ErrLaunch,
+			err,
+			strings.TrimSpace(string(output)),
+		)
+	}
+
+	return nil
+}
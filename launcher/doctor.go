@@ -0,0 +1,446 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesgeneratedclientset "github.com/srl-labs/clabernetes/generated/clientset"
+	claberneteslauncherconnectivity "github.com/srl-labs/clabernetes/launcher/connectivity"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	doctorCheckTimeout = 10 * time.Second
+
+	// doctorDNSName is the name we resolve to check the cluster dns works at all, it exists in
+	// every cluster and is resolvable via the pod search domains.
+	doctorDNSName = "kubernetes.default.svc"
+
+	doctorColorReset  = "\033[0m"
+	doctorColorRed    = "\033[31m"
+	doctorColorGreen  = "\033[32m"
+	doctorColorYellow = "\033[33m"
+)
+
+type doctorStatus string
+
+const (
+	doctorStatusOK   doctorStatus = "OK"
+	doctorStatusWarn doctorStatus = "WARN"
+	doctorStatusFail doctorStatus = "FAIL"
+	doctorStatusSkip doctorStatus = "SKIP"
+)
+
+// doctorCheck is the outcome of a single doctor check.
+type doctorCheck struct {
+	name   string
+	status doctorStatus
+	detail string
+}
+
+// doctorDevice is a device node the launcher (or the nos in it) may need.
+type doctorDevice struct {
+	path string
+	// required devices fail the check when missing, the others only warn as only some kinds need
+	// them (e.g. kvm for vrnetlab kinds).
+	required bool
+}
+
+// doctorDevices are the device nodes the doctor checks, these are the ones the controller mounts
+// into the launcher pods.
+var doctorDevices = []doctorDevice{ //nolint:gochecknoglobals
+	{path: "/dev/net/tun", required: true},
+	{path: "/dev/kvm"},
+	{path: "/dev/fuse"},
+}
+
+// DoctorArgs holds arguments for the doctor launcher subcommand.
+type DoctorArgs struct {
+	// NoColor disables the colored (red/green) report output, the report is never colored if
+	// stdout is not a terminal.
+	NoColor bool
+}
+
+// StartDoctor runs a set of diagnostics in a launcher pod -- dns, kube api reachability, the state
+// of the tunnel interfaces, nested docker health and device nodes -- and prints a report of the
+// results. Returns an error if any of the checks failed so the exit code of
+// `kubectl exec <launcher pod> -- /clabernetes/manager doctor` can be used in scripts.
+func StartDoctor(args *DoctorArgs) error {
+	ctx := context.Background()
+
+	kubeClabernetesClient, kubeCheck := doctorKubeAPI()
+
+	tunnels, tunnelsErr := doctorResolveTunnels(ctx, kubeClabernetesClient)
+
+	checks := []doctorCheck{doctorDNS(tunnels)}
+	checks = append(checks, kubeCheck)
+	checks = append(checks, doctorTunnels(tunnels, tunnelsErr)...)
+	checks = append(checks, doctorDocker(ctx))
+	checks = append(checks, doctorDeviceNodes()...)
+
+	color := !args.NoColor
+
+	stdoutInfo, err := os.Stdout.Stat()
+	if err != nil || stdoutInfo.Mode()&os.ModeCharDevice == 0 {
+		color = false
+	}
+
+	failed := printDoctorReport(os.Stdout, checks, color)
+	if failed > 0 {
+		return fmt.Errorf("%w: %d doctor check(s) failed", claberneteserrors.ErrLaunch, failed)
+	}
+
+	return nil
+}
+
+// printDoctorReport writes the given checks to the given writer, returning how many failed.
+func printDoctorReport(w io.Writer, checks []doctorCheck, color bool) int {
+	var failed int
+
+	for _, check := range checks {
+		status := fmt.Sprintf("[%-4s]", check.status)
+
+		if color {
+			switch check.status {
+			case doctorStatusOK:
+				status = doctorColorGreen + status + doctorColorReset
+			case doctorStatusFail:
+				status = doctorColorRed + status + doctorColorReset
+			case doctorStatusWarn, doctorStatusSkip:
+				status = doctorColorYellow + status + doctorColorReset
+			}
+		}
+
+		if check.status == doctorStatusFail {
+			failed++
+		}
+
+		_, _ = fmt.Fprintf(w, "%s %s: %s\n", status, check.name, check.detail)
+	}
+
+	_, _ = fmt.Fprintf(w, "\n%d check(s), %d failed\n", len(checks), failed)
+
+	return failed
+}
+
+// doctorDNS checks the cluster dns resolves the kubernetes service and the destinations of the
+// given tunnels.
+func doctorDNS(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) doctorCheck {
+	check := doctorCheck{
+		name:   "dns",
+		status: doctorStatusOK,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorCheckTimeout)
+	defer cancel()
+
+	names := []string{doctorDNSName}
+
+	for _, tunnel := range tunnels {
+		if tunnel.Destination == "" || net.ParseIP(tunnel.Destination) != nil ||
+			slices.Contains(names, tunnel.Destination) {
+			continue
+		}
+
+		names = append(names, tunnel.Destination)
+	}
+
+	var unresolved []string
+
+	for _, name := range names {
+		_, err := net.DefaultResolver.LookupHost(ctx, name)
+		if err != nil {
+			unresolved = append(unresolved, fmt.Sprintf("%s (%s)", name, err))
+		}
+	}
+
+	if len(unresolved) > 0 {
+		check.status = doctorStatusFail
+		check.detail = "failed resolving " + strings.Join(unresolved, ", ")
+
+		return check
+	}
+
+	check.detail = fmt.Sprintf("resolved %d name(s)", len(names))
+
+	return check
+}
+
+// doctorKubeAPI checks the kube api is reachable with the launcher service account, returning
+// the client (nil if we could not create one) so the other checks can use it too.
+func doctorKubeAPI() (*clabernetesgeneratedclientset.Clientset, doctorCheck) {
+	check := doctorCheck{
+		name:   "kube api",
+		status: doctorStatusFail,
+	}
+
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		check.detail = fmt.Sprintf("failed getting in cluster kubeconfig, err: %s", err)
+
+		return nil, check
+	}
+
+	kubeConfig.Timeout = doctorCheckTimeout
+
+	kubeClabernetesClient, err := clabernetesgeneratedclientset.NewForConfig(kubeConfig)
+	if err != nil {
+		check.detail = fmt.Sprintf("failed creating kube client, err: %s", err)
+
+		return nil, check
+	}
+
+	version, err := kubeClabernetesClient.Discovery().ServerVersion()
+	if err != nil {
+		check.detail = fmt.Sprintf("%s not reachable, err: %s", kubeConfig.Host, err)
+
+		return kubeClabernetesClient, check
+	}
+
+	check.status = doctorStatusOK
+	check.detail = fmt.Sprintf("%s reachable (%s)", kubeConfig.Host, version.GitVersion)
+
+	return kubeClabernetesClient, check
+}
+
+// doctorResolveTunnels returns the tunnels of the node of this launcher -- from the cached
+// tunnels file if there is one, otherwise from the connectivity cr.
+func doctorResolveTunnels(
+	ctx context.Context,
+	kubeClabernetesClient *clabernetesgeneratedclientset.Clientset,
+) ([]*clabernetesapisv1alpha1.PointToPointTunnel, error) {
+	tunnels, ok, err := readTunnelsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return tunnels, nil
+	}
+
+	if kubeClabernetesClient == nil {
+		return nil, fmt.Errorf(
+			"%w: no cached tunnels file and no kube client to get the connectivity cr",
+			claberneteserrors.ErrLaunch,
+		)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, doctorCheckTimeout)
+	defer cancel()
+
+	tunnelsCR, err := kubeClabernetesClient.ClabernetesV1alpha1().Connectivities(
+		os.Getenv(clabernetesconstants.PodNamespaceEnv),
+	).Get(
+		ctx,
+		os.Getenv(clabernetesconstants.LauncherTopologyNameEnv),
+		metav1.GetOptions{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return tunnelsCR.Spec.PointToPointTunnels[os.Getenv(clabernetesconstants.LauncherNodeNameEnv)], nil
+}
+
+// doctorTunnels checks the pod side and tunnel interfaces of each of the given tunnels exist and
+// are up (or down, for administratively down links).
+func doctorTunnels(
+	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+	tunnelsErr error,
+) []doctorCheck {
+	if tunnelsErr != nil {
+		return []doctorCheck{
+			{
+				name:   "tunnels",
+				status: doctorStatusFail,
+				detail: fmt.Sprintf("failed resolving tunnels, err: %s", tunnelsErr),
+			},
+		}
+	}
+
+	connectivityKind := os.Getenv(clabernetesconstants.LauncherConnectivityKind)
+
+	if connectivityKind == clabernetesconstants.ConnectivityMultus {
+		return []doctorCheck{
+			{
+				name:   "tunnels",
+				status: doctorStatusSkip,
+				detail: "links are attached by multus, there are no tunnel interfaces",
+			},
+		}
+	}
+
+	if connectivityKind == clabernetesconstants.ConnectivityLoopback {
+		return []doctorCheck{
+			{
+				name:   "tunnels",
+				status: doctorStatusSkip,
+				detail: "loopback connectivity, tunnels are simulated and never set up",
+			},
+		}
+	}
+
+	if len(tunnels) == 0 {
+		return []doctorCheck{
+			{
+				name:   "tunnels",
+				status: doctorStatusOK,
+				detail: "no tunnels for this launcher",
+			},
+		}
+	}
+
+	checks := make([]doctorCheck, 0, len(tunnels))
+
+	for _, tunnel := range tunnels {
+		check := doctorCheck{
+			name: fmt.Sprintf(
+				"tunnel %s:%s <-> %s:%s",
+				tunnel.LocalNode,
+				tunnel.LocalInterface,
+				tunnel.RemoteNode,
+				tunnel.RemoteInterface,
+			),
+			status: doctorStatusOK,
+		}
+
+		hostLink, tunnelLink := claberneteslauncherconnectivity.TunnelInterfaceNames(
+			claberneteslauncherconnectivity.TunnelConnectivity(tunnel, connectivityKind),
+			tunnel,
+		)
+
+		var details []string
+
+		for _, link := range []string{hostLink, tunnelLink} {
+			if link == "" {
+				continue
+			}
+
+			detail, ok := doctorLinkState(link, !tunnel.AdminDown)
+			if !ok {
+				check.status = doctorStatusFail
+			}
+
+			details = append(details, detail)
+		}
+
+		check.detail = strings.Join(details, ", ")
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// doctorLinkState returns a description of the state of the given link and whether that is the
+// expected state (up, or down if wantUp is false).
+func doctorLinkState(name string, wantUp bool) (string, bool) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return fmt.Sprintf("%s missing", name), false
+	}
+
+	up := iface.Flags&net.FlagUp != 0
+
+	state := "down"
+	if up {
+		state = "up"
+	}
+
+	if !wantUp {
+		state += " (admin down)"
+	}
+
+	return fmt.Sprintf("%s %s", name, state), up == wantUp
+}
+
+// doctorDocker checks the nested docker daemon answers and the container of the node of this
+// launcher is running. Skipped in native mode as there is no nested docker.
+func doctorDocker(ctx context.Context) doctorCheck {
+	check := doctorCheck{
+		name:   "docker",
+		status: doctorStatusFail,
+	}
+
+	if os.Getenv(clabernetesconstants.LauncherNativeModeEnv) == clabernetesconstants.True {
+		check.status = doctorStatusSkip
+		check.detail = "launcher is running in native mode, there is no nested docker"
+
+		return check
+	}
+
+	err := dockerResponding(ctx)
+	if err != nil {
+		check.detail = err.Error()
+
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dockerResponseTimeout)
+	defer cancel()
+
+	nodeName := os.Getenv(clabernetesconstants.LauncherNodeNameEnv)
+
+	containerID, err := getContainerIDForNodeName(ctx, nodeName)
+	if err != nil || containerID == "" {
+		check.detail = fmt.Sprintf("daemon responding but node container %q not running", nodeName)
+
+		return check
+	}
+
+	check.status = doctorStatusOK
+	check.detail = "daemon responding, node container running"
+
+	return check
+}
+
+// doctorDeviceNodes checks the device nodes the launcher may need exist and can be opened.
+func doctorDeviceNodes() []doctorCheck {
+	checks := make([]doctorCheck, 0, len(doctorDevices))
+
+	for _, device := range doctorDevices {
+		check := doctorCheck{
+			name:   "device " + device.path,
+			status: doctorStatusOK,
+			detail: "present",
+		}
+
+		failStatus := doctorStatusWarn
+		if device.required {
+			failStatus = doctorStatusFail
+		}
+
+		info, err := os.Stat(device.path)
+
+		switch {
+		case err != nil:
+			check.status = failStatus
+			check.detail = "missing"
+		case info.Mode()&os.ModeCharDevice == 0:
+			check.status = failStatus
+			check.detail = "not a character device"
+		default:
+			f, openErr := os.OpenFile(device.path, os.O_RDWR, 0)
+			if openErr != nil {
+				check.status = failStatus
+				check.detail = fmt.Sprintf("present but can not be opened, err: %s", openErr)
+			} else {
+				_ = f.Close()
+			}
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
@@ -0,0 +1,193 @@
+package launcher
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	k8scoordinationv1 "k8s.io/api/coordination/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	heartbeatRenewInterval = 10 * time.Second
+)
+
+// heartbeatProgress tracks the progress of the launcher for the purposes of the heartbeat lease.
+// Until something starts tracking progress (the container watch in docker mode) the launcher is
+// considered to be progressing as long as the process is alive -- this way slow image pulls and
+// slow booting nodes are never reported as stalled. Once progress is tracked, the heartbeat lease
+// is only renewed while progress keeps being reported.
+type heartbeatProgress struct {
+	tracked      atomic.Bool
+	lastProgress atomic.Int64
+}
+
+func (p *heartbeatProgress) mark() {
+	p.tracked.Store(true)
+	p.lastProgress.Store(time.Now().UnixNano())
+}
+
+func (p *heartbeatProgress) progressing() bool {
+	if !p.tracked.Load() {
+		return true
+	}
+
+	return time.Since(time.Unix(0, p.lastProgress.Load())) <
+		clabernetesconstants.LauncherHeartbeatLeaseDurationSeconds*time.Second
+}
+
+// heartbeat creates and periodically renews a lease for this launcher pod. The controller checks
+// the lease of launcher pods that are running but not ready to tell a wedged launcher from one that
+// is just waiting on a slow node boot.
+func (c *clabernetes) heartbeat() {
+	podName := os.Getenv(clabernetesconstants.PodNameEnv)
+	namespace := os.Getenv(clabernetesconstants.PodNamespaceEnv)
+
+	if podName == "" || namespace == "" {
+		c.logger.Warn("pod name or namespace unknown, cannot run heartbeat lease")
+
+		return
+	}
+
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		c.logger.Warnf("failed getting in cluster kubeconfig, cannot run heartbeat, err: %s", err)
+
+		return
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		c.logger.Warnf("failed creating kube client, cannot run heartbeat, err: %s", err)
+
+		return
+	}
+
+	ticker := time.NewTicker(heartbeatRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		if c.heartbeatProgress.progressing() {
+			err = c.renewHeartbeatLease(kubeClient, namespace, podName)
+			if err != nil {
+				c.logger.Warnf("failed renewing heartbeat lease, err: %s", err)
+			}
+		} else {
+			c.logger.Warn("launcher has not reported progress recently, not renewing heartbeat lease")
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *clabernetes) renewHeartbeatLease(
+	kubeClient kubernetes.Interface,
+	namespace,
+	podName string,
+) error {
+	ctx, cancel := context.WithTimeout(c.ctx, clientDefaultTimeout)
+	defer cancel()
+
+	now := metav1.NewMicroTime(time.Now())
+
+	lease, err := kubeClient.CoordinationV1().Leases(namespace).Get(
+		ctx,
+		podName,
+		metav1.GetOptions{},
+	)
+	if err != nil {
+		if !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+
+		// the lease is owned by this pod so it is garbage collected along with it
+		pod, podErr := kubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if podErr != nil {
+			return podErr
+		}
+
+		_, err = kubeClient.CoordinationV1().Leases(namespace).Create(
+			ctx,
+			&k8scoordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      podName,
+					Namespace: namespace,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "v1",
+							Kind:       "Pod",
+							Name:       podName,
+							UID:        pod.UID,
+						},
+					},
+					Labels: map[string]string{
+						clabernetesconstants.LabelApp: clabernetesconstants.Clabernetes,
+						clabernetesconstants.LabelTopologyOwner: os.Getenv(
+							clabernetesconstants.LauncherTopologyNameEnv,
+						),
+						clabernetesconstants.LabelTopologyNode: c.nodeName,
+					},
+				},
+				Spec: k8scoordinationv1.LeaseSpec{
+					HolderIdentity: &podName,
+					LeaseDurationSeconds: clabernetesutil.ToPointer(
+						int32(clabernetesconstants.LauncherHeartbeatLeaseDurationSeconds),
+					),
+					AcquireTime: &now,
+					RenewTime:   &now,
+				},
+			},
+			metav1.CreateOptions{},
+		)
+
+		return err
+	}
+
+	lease.Spec.RenewTime = &now
+
+	watchdogRestart := c.watchdogRestart.Load()
+	if watchdogRestart != nil {
+		if lease.Annotations == nil {
+			lease.Annotations = map[string]string{}
+		}
+
+		lease.Annotations[clabernetesconstants.LauncherWatchdogRestartAnnotation] = *watchdogRestart
+	}
+
+	diskUsage := c.diskUsage.Load()
+	if diskUsage != nil {
+		if lease.Annotations == nil {
+			lease.Annotations = map[string]string{}
+		}
+
+		lease.Annotations[clabernetesconstants.LauncherDiskUsageAnnotation] = *diskUsage
+	}
+
+	versions := c.versions.Load()
+	if versions != nil {
+		if lease.Annotations == nil {
+			lease.Annotations = map[string]string{}
+		}
+
+		lease.Annotations[clabernetesconstants.LauncherVersionsAnnotation] = *versions
+	}
+
+	_, err = kubeClient.CoordinationV1().Leases(namespace).Update(
+		ctx,
+		lease,
+		metav1.UpdateOptions{},
+	)
+
+	return err
+}
@@ -0,0 +1,232 @@
+package launcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+const (
+	procSysPath   = "/proc/sys"
+	sysModulePath = "/sys/module"
+
+	inotifyMaxUserInstances = 64
+	inotifyMaxUserWatches   = 8192
+	qemuMaxMapCount         = 65530
+)
+
+// defaultHostSysctlsByKind is a mapping of containerlab kind -> the sysctls (and their minimum
+// values) nodes of that kind need on the worker node. These are the things that make nodes crash
+// (or just never boot) in "mysterious" ways rather than failing with a useful error.
+var defaultHostSysctlsByKind = map[string]map[string]int64{ //nolint:gochecknoglobals
+	"srl": {
+		"fs.inotify.max_user_instances": inotifyMaxUserInstances,
+		"fs.inotify.max_user_watches":   inotifyMaxUserWatches,
+	},
+	"nokia_srlinux": {
+		"fs.inotify.max_user_instances": inotifyMaxUserInstances,
+		"fs.inotify.max_user_watches":   inotifyMaxUserWatches,
+	},
+	"ceos": {
+		"fs.inotify.max_user_instances": inotifyMaxUserInstances,
+	},
+	"arista_ceos": {
+		"fs.inotify.max_user_instances": inotifyMaxUserInstances,
+	},
+}
+
+// qemuKinds are the (vrnetlab) kinds that run a qemu vm in the node container.
+var qemuKinds = []string{ //nolint:gochecknoglobals
+	"vr-sros",
+	"nokia_sros",
+	"vr-vmx",
+	"juniper_vmx",
+	"juniper_vjunosrouter",
+	"juniper_vjunosswitch",
+	"juniper_vjunosevolved",
+	"vr-vsrx",
+	"juniper_vsrx",
+	"vr-xrv9k",
+	"cisco_xrv9k",
+	"vr-csr",
+	"cisco_csr1000v",
+	"vr-n9kv",
+	"cisco_n9kv",
+	"cisco_cat9kv",
+	"vr-veos",
+	"arista_veos",
+	"vr-ros",
+	"mikrotik_ros",
+	"vr-pan",
+	"paloalto_panos",
+	"fortinet_fortigate",
+}
+
+// resolveHostRequirements returns the sysctls (and their minimum values) and kernel modules the
+// node of this launcher requires on the worker node, and the mode to check them in. The built-in
+// requirements of the node kind are merged with whatever the topology sets.
+func (c *clabernetes) resolveHostRequirements(
+	nodeKind string,
+) (sysctls map[string]int64, kernelModules []string, mode string) {
+	sysctls = map[string]int64{}
+	mode = clabernetesconstants.HostRequirementsModeEnforce
+
+	topologyRequirements := &clabernetesapisv1alpha1.HostRequirements{}
+
+	rawTopologyRequirements := os.Getenv(clabernetesconstants.LauncherHostRequirementsEnv)
+	if rawTopologyRequirements != "" {
+		err := json.Unmarshal([]byte(rawTopologyRequirements), topologyRequirements)
+		if err != nil {
+			c.logger.Warnf(
+				"failed unmarshaling topology host requirements, ignoring them, err: %s",
+				err,
+			)
+
+			topologyRequirements = &clabernetesapisv1alpha1.HostRequirements{}
+		}
+	}
+
+	if !topologyRequirements.DisableDefaults {
+		for sysctl, minimum := range defaultHostSysctlsByKind[nodeKind] {
+			sysctls[sysctl] = minimum
+		}
+
+		if slices.Contains(qemuKinds, nodeKind) {
+			sysctls["vm.max_map_count"] = qemuMaxMapCount
+		}
+	}
+
+	for sysctl, minimum := range topologyRequirements.Sysctls {
+		sysctls[sysctl] = minimum
+	}
+
+	kernelModules = topologyRequirements.KernelModules
+
+	if topologyRequirements.Mode != "" {
+		mode = topologyRequirements.Mode
+	}
+
+	return sysctls, kernelModules, mode
+}
+
+// checkHostSysctl returns a (user actionable) failure message if the given sysctl does not meet
+// the given minimum, or an empty string if it does.
+func checkHostSysctl(sysctl string, minimum int64) string {
+	rawValue, err := os.ReadFile(
+		fmt.Sprintf("%s/%s", procSysPath, strings.ReplaceAll(sysctl, ".", "/")),
+	)
+	if err != nil {
+		return fmt.Sprintf(
+			"sysctl %q could not be read (is the required kernel module loaded?), err: %s",
+			sysctl,
+			err,
+		)
+	}
+
+	// some sysctls hold multiple values (for example port ranges), we only care about the first
+	fields := strings.Fields(string(rawValue))
+	if len(fields) == 0 {
+		return fmt.Sprintf("sysctl %q has no value", sysctl)
+	}
+
+	value, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return fmt.Sprintf("sysctl %q has non integer value %q", sysctl, fields[0])
+	}
+
+	if value >= minimum {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"sysctl %q is %d but must be at least %d, set it on the worker with"+
+			" 'sysctl -w %s=%d'",
+		sysctl,
+		value,
+		minimum,
+		sysctl,
+		minimum,
+	)
+}
+
+// checkHostKernelModule returns a (user actionable) failure message if the given kernel module is
+// not loaded, or an empty string if it is.
+func checkHostKernelModule(kernelModule string) string {
+	_, err := os.Stat(fmt.Sprintf("%s/%s", sysModulePath, kernelModule))
+	if err == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"kernel module %q is not loaded, load it on the worker with 'modprobe %s'",
+		kernelModule,
+		kernelModule,
+	)
+}
+
+// hostPreflight verifies the worker node meets the sysctl/kernel module requirements of the node
+// this launcher represents. When a requirement is not met we fail with a dedicated termination
+// reason (which ends up in the topology status) rather than letting the node crash in some weird
+// and wonderful way later on.
+func (c *clabernetes) hostPreflight() {
+	nodeKind := resolveNodeKind(c.nodeName)
+
+	sysctls, kernelModules, mode := c.resolveHostRequirements(nodeKind)
+
+	if len(sysctls) == 0 && len(kernelModules) == 0 {
+		c.logger.Debug("no host requirements for node, skipping host preflight checks")
+
+		return
+	}
+
+	var failures []string
+
+	sortedSysctls := make([]string, 0, len(sysctls))
+	for sysctl := range sysctls {
+		sortedSysctls = append(sortedSysctls, sysctl)
+	}
+
+	slices.Sort(sortedSysctls)
+
+	for _, sysctl := range sortedSysctls {
+		failure := checkHostSysctl(sysctl, sysctls[sysctl])
+		if failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+
+	for _, kernelModule := range kernelModules {
+		failure := checkHostKernelModule(kernelModule)
+		if failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+
+	if len(failures) == 0 {
+		c.logger.Info("host preflight checks passed")
+
+		return
+	}
+
+	message := fmt.Sprintf(
+		"worker node %q does not meet the host requirements of node %q (kind %q): %s",
+		os.Getenv(clabernetesconstants.NodeNameEnv),
+		c.nodeName,
+		nodeKind,
+		strings.Join(failures, "; "),
+	)
+
+	if mode == clabernetesconstants.HostRequirementsModeWarn {
+		c.logger.Warn(message)
+
+		return
+	}
+
+	c.fatalf(clabernetesconstants.TerminationReasonHostPreflightFailed, "%s", message)
+}
@@ -42,7 +42,8 @@ func (c *clabernetes) image() {
 		c.logger.Warnf("failed image pull through (check), err: %s", err)
 
 		if c.imagePullThroughMode == clabernetesconstants.ImagePullThroughModeAlways {
-			c.logger.Fatal(
+			c.fatalf(
+				clabernetesconstants.TerminationReasonImagePull,
 				"image pull through failed and pull through mode is always, cannot continue",
 			)
 		}
@@ -145,8 +146,11 @@ func (c *clabernetes) prepareImagePullThrough() (
 		c.logger.Warnf("error creating image manager, err: %s", err)
 
 		if c.imagePullThroughMode == clabernetesconstants.ImagePullThroughModeAlways {
-			c.logger.Fatal("image pull through mode is always, but criKind is unset or unknown," +
-				" cannot continue...")
+			c.fatalf(
+				clabernetesconstants.TerminationReasonImagePull,
+				"image pull through mode is always, but criKind is unset or unknown,"+
+					" cannot continue...",
+			)
 		}
 
 		c.logger.Warn(
@@ -159,8 +163,9 @@ func (c *clabernetes) prepareImagePullThrough() (
 
 	if c.imageName == "" {
 		if c.imagePullThroughMode == clabernetesconstants.ImagePullThroughModeAlways {
-			c.logger.Fatal(
-				"image pull through mode is always, node image is unknown," +
+			c.fatalf(
+				clabernetesconstants.TerminationReasonImagePull,
+				"image pull through mode is always, node image is unknown,"+
 					" cannot continue...",
 			)
 		}
@@ -178,6 +183,11 @@ func (c *clabernetes) prepareImagePullThrough() (
 
 func handleImagePullThroughModeAlwaysPanic(imagePullThroughMode string) {
 	if imagePullThroughMode == clabernetesconstants.ImagePullThroughModeAlways {
+		clabernetesutil.WriteTerminationMessage(
+			clabernetesconstants.TerminationReasonImagePull,
+			"image pull through failed and pull through mode is always, cannot continue",
+		)
+
 		clabernetesutil.Panic(
 			"image pull through failed and pull through mode is always, cannot continue",
 		)
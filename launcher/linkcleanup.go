@@ -0,0 +1,45 @@
+package launcher
+
+import (
+	"fmt"
+	"os"
+
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	claberneteslauncherconnectivity "github.com/srl-labs/clabernetes/launcher/connectivity"
+)
+
+// cleanupStaleLinks is the link "janitor" -- it deletes links a previous pod of this launcher left
+// behind. Launchers tag the links they create (veth pairs, tunnel interfaces, segment bridges and
+// vrnetlab management veths) with their owner, so this only ever touches links of this launcher.
+// Normally the links go away with the pod network namespace, but in host network mode (or if the
+// preStop hook did not get to run because the pod was killed hard) they stay around.
+func (c *clabernetes) cleanupStaleLinks() {
+	deletedLinks, err := claberneteslauncherconnectivity.CleanupOwnedLinks(true)
+	if err != nil {
+		c.logger.Warnf("failed cleaning up stale links, err: %s", err)
+	}
+
+	if len(deletedLinks) > 0 {
+		c.logger.Infof("cleaned up stale link(s) %q left behind by a previous pod", deletedLinks)
+	}
+}
+
+// StartLinksCleanup deletes all links tagged as owned by this launcher. It is the preStop hook of
+// launchers running in host network mode, where the links would otherwise outlive the pod. Meant
+// to be run via `/clabernetes/manager links cleanup`.
+func StartLinksCleanup() error {
+	deletedLinks, err := claberneteslauncherconnectivity.CleanupOwnedLinks(false)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed cleaning up links, err: %w",
+			claberneteserrors.ErrLaunch,
+			err,
+		)
+	}
+
+	for _, deletedLink := range deletedLinks {
+		_, _ = fmt.Fprintf(os.Stdout, "deleted link %s\n", deletedLink)
+	}
+
+	return nil
+}
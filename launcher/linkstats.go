@@ -0,0 +1,134 @@
+package launcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	claberneteslauncherconnectivity "github.com/srl-labs/clabernetes/launcher/connectivity"
+)
+
+const (
+	linkStatsUnavailable = "-"
+)
+
+// LinksStatsArgs holds arguments for the links stats launcher subcommand.
+type LinksStatsArgs struct {
+	// JSON prints the stats as json rather than as a table.
+	JSON bool
+}
+
+// linkStatsEntry is the json form of the stats of a single link.
+type linkStatsEntry struct {
+	Endpoint       string `json:"endpoint"`
+	LinuxInterface string `json:"linuxInterface"`
+	RxBytes        *int64 `json:"rxBytes,omitempty"`
+	TxBytes        *int64 `json:"txBytes,omitempty"`
+	RxPackets      *int64 `json:"rxPackets,omitempty"`
+	TxPackets      *int64 `json:"txPackets,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// StartLinksStats prints the traffic counters of the links of the node(s) of a launcher by their
+// containerlab endpoint ("r1:Ethernet0/1") rather than the sanitized linux interface names the
+// counters are read from. Counters are as seen by the node, so "rx" is what the node received over
+// the link. The tunnels are resolved just like the doctor does, so this works in native mode too.
+// Meant to be run via `kubectl exec <launcher pod> -- /clabernetes/manager links stats`.
+func StartLinksStats(args *LinksStatsArgs) error {
+	if os.Getenv(clabernetesconstants.LauncherConnectivityKind) ==
+		clabernetesconstants.ConnectivityMultus {
+		return fmt.Errorf(
+			"%w: links are attached by multus, link stats are not supported",
+			claberneteserrors.ErrLaunch,
+		)
+	}
+
+	kubeClabernetesClient, _ := doctorKubeAPI()
+
+	tunnels, err := doctorResolveTunnels(context.Background(), kubeClabernetesClient)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed resolving tunnels, err: %w",
+			claberneteserrors.ErrLaunch,
+			err,
+		)
+	}
+
+	linkStats := claberneteslauncherconnectivity.ReadLinkStats(tunnels)
+
+	if args.JSON {
+		return printLinkStatsJSON(os.Stdout, linkStats)
+	}
+
+	return printLinkStatsTable(os.Stdout, linkStats)
+}
+
+func printLinkStatsJSON(w io.Writer, linkStats []claberneteslauncherconnectivity.LinkStats) error {
+	entries := make([]linkStatsEntry, 0, len(linkStats))
+
+	for _, stats := range linkStats {
+		entry := linkStatsEntry{
+			Endpoint:       stats.Endpoint,
+			LinuxInterface: stats.LinuxInterface,
+		}
+
+		if stats.Err != nil {
+			entry.Error = stats.Err.Error()
+		} else {
+			entry.RxBytes = &stats.Counters.RxBytes
+			entry.TxBytes = &stats.Counters.TxBytes
+			entry.RxPackets = &stats.Counters.RxPackets
+			entry.TxPackets = &stats.Counters.TxPackets
+		}
+
+		entries = append(entries, entry)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(entries)
+}
+
+func printLinkStatsTable(w io.Writer, linkStats []claberneteslauncherconnectivity.LinkStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "ENDPOINT\tLINUX INTERFACE\tRX BYTES\tTX BYTES\tRX PACKETS\tTX PACKETS")
+
+	for _, stats := range linkStats {
+		counters := []string{
+			linkStatsUnavailable,
+			linkStatsUnavailable,
+			linkStatsUnavailable,
+			linkStatsUnavailable,
+		}
+
+		if stats.Err == nil {
+			counters = []string{
+				strconv.FormatInt(stats.Counters.RxBytes, 10),
+				strconv.FormatInt(stats.Counters.TxBytes, 10),
+				strconv.FormatInt(stats.Counters.RxPackets, 10),
+				strconv.FormatInt(stats.Counters.TxPackets, 10),
+			}
+		}
+
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			stats.Endpoint,
+			stats.LinuxInterface,
+			counters[0],
+			counters[1],
+			counters[2],
+			counters[3],
+		)
+	}
+
+	return tw.Flush()
+}
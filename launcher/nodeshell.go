@@ -0,0 +1,137 @@
+package launcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+const defaultNodeShellCommand = "sh"
+
+// nodeShellKindCommands is a mapping of containerlab kind -> the command we run by default when
+// opening a node shell -- for kinds with a "proper" cli we drop the user right into it, anything
+// else gets a plain shell.
+var nodeShellKindCommands = map[string][]string{ //nolint:gochecknoglobals
+	"srl":           {"sr_cli"},
+	"nokia_srlinux": {"sr_cli"},
+	"ceos":          {"Cli"},
+	"arista_ceos":   {"Cli"},
+	"crpd":          {"cli"},
+	"juniper_crpd":  {"cli"},
+	"cumulus_cvx":   {"bash"},
+	"sonic-vs":      {"bash"},
+}
+
+// NodeShellArgs holds arguments for the node-shell launcher subcommand.
+type NodeShellArgs struct {
+	// NodeName is the (containerlab) node to open the shell for, if unset this is the node of the
+	// launcher we are running in.
+	NodeName string
+	// Command is the command to run in the node container, if unset we pick a cli/shell based on
+	// the containerlab kind of the node.
+	Command []string
+}
+
+// StartNodeShell resolves the nested (docker) container of a containerlab node in a docker mode
+// launcher and attaches to it via `docker exec`. This means users can get to their node with a
+// single `kubectl exec -it <launcher pod> -- /clabernetes/manager node-shell` rather than first
+// exec'ing into the launcher and then figuring out the container to docker exec into.
+func StartNodeShell(args *NodeShellArgs) error {
+	if os.Getenv(clabernetesconstants.LauncherNativeModeEnv) == clabernetesconstants.True {
+		return fmt.Errorf(
+			"%w: launcher is running in native mode, the node runs in its own container, use"+
+				" 'kubectl exec -it <pod> -c <node> -- <command>' instead",
+			claberneteserrors.ErrLaunch,
+		)
+	}
+
+	nodeName := args.NodeName
+	if nodeName == "" {
+		nodeName = os.Getenv(clabernetesconstants.LauncherNodeNameEnv)
+	}
+
+	if nodeName == "" {
+		return fmt.Errorf(
+			"%w: no node name provided and launcher node name env var is not set",
+			claberneteserrors.ErrLaunch,
+		)
+	}
+
+	ctx := context.Background()
+
+	containerID, err := getContainerIDForNodeName(ctx, nodeName)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: failed resolving container for node %q, err: %w",
+			claberneteserrors.ErrLaunch,
+			nodeName,
+			err,
+		)
+	}
+
+	if containerID == "" || strings.Contains(containerID, "\n") {
+		return fmt.Errorf(
+			"%w: expected exactly one running container for node %q, got %q",
+			claberneteserrors.ErrLaunch,
+			nodeName,
+			containerID,
+		)
+	}
+
+	command := args.Command
+	if len(command) == 0 {
+		command = resolveNodeShellCommand(nodeName)
+	}
+
+	dockerArgs := []string{"exec", "-i"}
+
+	// only allocate a tty if we have one, otherwise things like piping commands in would break
+	stdinInfo, err := os.Stdin.Stat()
+	if err == nil && stdinInfo.Mode()&os.ModeCharDevice != 0 {
+		dockerArgs = append(dockerArgs, "-t")
+	}
+
+	dockerArgs = append(dockerArgs, containerID)
+	dockerArgs = append(dockerArgs, command...)
+
+	cmd := exec.CommandContext(ctx, "docker", dockerArgs...) //nolint:gosec
+
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// docker puts the terminal into raw mode and watches for window size changes (SIGWINCH is sent
+	// to the whole foreground process group, so it sees them just like we would) -- we just need
+	// to stay out of the way and not die on any signals that are meant for the nested session.
+	signal.Ignore(syscall.SIGINT, syscall.SIGQUIT)
+
+	err = cmd.Run()
+	if err != nil {
+		return fmt.Errorf(
+			"%w: node shell for node %q exited, err: %w",
+			claberneteserrors.ErrLaunch,
+			nodeName,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// resolveNodeShellCommand returns the default node shell command for the given node based on its
+// containerlab kind, falling back to a plain shell if we cant figure out the kind.
+func resolveNodeShellCommand(nodeName string) []string {
+	command, ok := nodeShellKindCommands[resolveNodeKind(nodeName)]
+	if !ok {
+		return []string{defaultNodeShellCommand}
+	}
+
+	return command
+}
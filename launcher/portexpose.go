@@ -0,0 +1,314 @@
+package launcher
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultTopologyFile        = "topo.clab.yaml"
+	portExposureTopologyFile   = "topo.portexposure.clab.yaml"
+	portExposureTable          = "clabernetes_expose"
+	portExposureReconcileEvery = 30 * time.Second
+)
+
+type exposedPort struct {
+	nodeName      string
+	hostPort      int
+	containerPort int
+	protocol      string
+}
+
+// parseContainerlabPort parses a containerlab (docker) port mapping the same way the controller
+// does -- a mapping without an expose port ("port" or "hostIP::port") is exposed on the container
+// port itself.
+func parseContainerlabPort(nodeName, port string) (*exposedPort, error) {
+	typedPort, err := clabernetesutilcontainerlab.ProcessPortDefinition(port)
+	if err != nil {
+		return nil, err
+	}
+
+	hostPort := typedPort.ExposePort
+	if hostPort == 0 {
+		hostPort = typedPort.DestinationPort
+	}
+
+	return &exposedPort{
+		nodeName:      nodeName,
+		hostPort:      int(hostPort),
+		containerPort: int(typedPort.DestinationPort),
+		protocol:      strings.ToLower(typedPort.Protocol),
+	}, nil
+}
+
+// stripTopologyPorts removes all the port mappings from the given topology and returns the ports
+// that would have been mapped for each node -- like containerlab, node ports take precedence over
+// kind ports which take precedence over the default ports.
+func stripTopologyPorts(topology *clabernetesutilcontainerlab.Topology) ([]*exposedPort, error) {
+	var exposedPorts []*exposedPort
+
+	nodeNames := make([]string, 0, len(topology.Nodes))
+
+	for nodeName := range topology.Nodes {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	sort.Strings(nodeNames)
+
+	for _, nodeName := range nodeNames {
+		nodeDefinition := topology.Nodes[nodeName]
+
+		ports := nodeDefinition.Ports
+
+		nodeKind := nodeDefinition.Kind
+		if nodeKind == "" && topology.Defaults != nil {
+			nodeKind = topology.Defaults.Kind
+		}
+
+		if len(ports) == 0 {
+			kindDefinition, ok := topology.Kinds[nodeKind]
+			if ok && kindDefinition != nil {
+				ports = kindDefinition.Ports
+			}
+		}
+
+		if len(ports) == 0 && topology.Defaults != nil {
+			ports = topology.Defaults.Ports
+		}
+
+		for _, port := range ports {
+			parsedPort, err := parseContainerlabPort(nodeName, port)
+			if err != nil {
+				return nil, err
+			}
+
+			exposedPorts = append(exposedPorts, parsedPort)
+		}
+
+		nodeDefinition.Ports = []string{}
+	}
+
+	for _, kindDefinition := range topology.Kinds {
+		if kindDefinition != nil {
+			kindDefinition.Ports = []string{}
+		}
+	}
+
+	if topology.Defaults != nil {
+		topology.Defaults.Ports = []string{}
+	}
+
+	return exposedPorts, nil
+}
+
+// renderPortExposureRuleset renders the nftables ruleset for the given ports and node container
+// addresses. The table is added, deleted and re-added in the same transaction so applying the
+// ruleset is idempotent and atomic.
+func renderPortExposureRuleset(
+	exposedPorts []*exposedPort,
+	nodeAddresses map[string]string,
+) string {
+	var rules strings.Builder
+
+	for _, port := range exposedPorts {
+		nodeAddress, ok := nodeAddresses[port.nodeName]
+		if !ok || nodeAddress == "" {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(
+			&rules,
+			"    iifname != \"docker0\" fib daddr type local %s dport %d dnat to %s:%d\n",
+			port.protocol,
+			port.hostPort,
+			nodeAddress,
+			port.containerPort,
+		)
+	}
+
+	return fmt.Sprintf(`add table ip %[1]s
+delete table ip %[1]s
+table ip %[1]s {
+  chain prerouting {
+    type nat hook prerouting priority dstnat; policy accept;
+%[2]s  }
+  chain output {
+    type nat hook output priority dstnat; policy accept;
+%[2]s  }
+}
+`,
+		portExposureTable,
+		rules.String(),
+	)
+}
+
+// preparePortExposure checks if the nftables port exposure mode is enabled and, if so, writes a
+// copy of the topology with all port mappings removed (so docker does not publish anything) and
+// records the ports that the launcher should expose itself.
+func (c *clabernetes) preparePortExposure() {
+	if os.Getenv(clabernetesconstants.LauncherPortExposureModeEnv) !=
+		clabernetesconstants.PortExposureModeNFTables {
+		return
+	}
+
+	c.logger.Info("port exposure mode is 'nftables', removing port mappings from topology...")
+
+	rawConfig, err := os.ReadFile(defaultTopologyFile)
+	if err != nil {
+		c.fatalf(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			"failed reading containerlab topology, err: %s",
+			err,
+		)
+	}
+
+	containerlabConfig, err := clabernetesutilcontainerlab.LoadContainerlabConfig(
+		string(rawConfig),
+	)
+	if err != nil {
+		c.fatalf(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			"failed loading containerlab topology, err: %s",
+			err,
+		)
+	}
+
+	c.exposedPorts, err = stripTopologyPorts(containerlabConfig.Topology)
+	if err != nil {
+		c.fatalf(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			"failed parsing containerlab topology port mappings, err: %s",
+			err,
+		)
+	}
+
+	strippedConfig, err := yaml.Marshal(containerlabConfig)
+	if err != nil {
+		c.fatalf(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			"failed marshaling containerlab topology, err: %s",
+			err,
+		)
+	}
+
+	err = os.WriteFile(
+		portExposureTopologyFile,
+		strippedConfig,
+		clabernetesconstants.PermissionsEveryoneReadWriteOwnerExecute,
+	)
+	if err != nil {
+		c.fatalf(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			"failed writing containerlab topology, err: %s",
+			err,
+		)
+	}
+
+	c.topologyFile = portExposureTopologyFile
+}
+
+// runPortExposure periodically reconciles the nftables port exposure rules -- it re-applies the
+// ruleset if the node container address(es) changed (node container restarted) or if the table
+// went missing, and removes the table when the launcher is shutting down.
+func (c *clabernetes) runPortExposure() {
+	if len(c.exposedPorts) == 0 {
+		return
+	}
+
+	var lastRuleset string
+
+	ticker := time.NewTicker(portExposureReconcileEvery)
+	defer ticker.Stop()
+
+	for {
+		ruleset := renderPortExposureRuleset(c.exposedPorts, c.portExposureNodeAddresses())
+
+		if ruleset != lastRuleset || !portExposureTableExists() {
+			err := c.applyPortExposureRuleset(ruleset)
+			if err != nil {
+				c.logger.Warnf("failed applying nftables port exposure rules, err: %s", err)
+
+				lastRuleset = ""
+			} else {
+				c.logger.Debug("applied nftables port exposure rules")
+
+				lastRuleset = ruleset
+			}
+		}
+
+		select {
+		case <-c.ctx.Done():
+			// ctx is done, so dont use it for the cleanup command
+			err := exec.Command( //nolint:noctx
+				"nft", "delete", "table", "ip", portExposureTable,
+			).Run()
+			if err != nil {
+				c.logger.Warnf("failed removing nftables port exposure table, err: %s", err)
+			}
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *clabernetes) portExposureNodeAddresses() map[string]string {
+	nodeAddresses := map[string]string{}
+
+	for _, port := range c.exposedPorts {
+		if _, ok := nodeAddresses[port.nodeName]; ok {
+			continue
+		}
+
+		containerID, err := getContainerIDForNodeName(c.ctx, port.nodeName)
+		if err != nil || containerID == "" {
+			c.logger.Warnf(
+				"failed determining container id for node %q, cannot expose its ports, err: %v",
+				port.nodeName,
+				err,
+			)
+
+			continue
+		}
+
+		nodeAddress, err := getContainerAddr(c.ctx, containerID)
+		if err != nil {
+			c.logger.Warnf(
+				"failed determining address for node %q, cannot expose its ports, err: %s",
+				port.nodeName,
+				err,
+			)
+
+			continue
+		}
+
+		nodeAddresses[port.nodeName] = nodeAddress
+	}
+
+	return nodeAddresses
+}
+
+func portExposureTableExists() bool {
+	return exec.Command( //nolint:noctx
+		"nft", "list", "table", "ip", portExposureTable,
+	).Run() == nil
+}
+
+func (c *clabernetes) applyPortExposureRuleset(ruleset string) error {
+	cmd := exec.CommandContext(c.ctx, "nft", "-f", "-")
+
+	cmd.Stdin = bytes.NewBufferString(ruleset)
+	cmd.Stdout = c.logger
+	cmd.Stderr = c.logger
+
+	return cmd.Run()
+}
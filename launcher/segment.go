@@ -0,0 +1,69 @@
+package launcher
+
+import (
+	"os"
+	"os/exec"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslauncherconnectivity "github.com/srl-labs/clabernetes/launcher/connectivity"
+)
+
+// launchSegment "launches" a bridge node -- a shared l2 segment rather than an actual node. There
+// is no image to pull or container to run, we just create the bridge containerlab expects to exist
+// and let containerlab wire up the bridge ports. Each node linked to the segment gets its own
+// tunnel to this launcher, the tunnels terminate on the bridge ports, so the bridge ends up
+// switching between all the nodes linked to it.
+func (c *clabernetes) launchSegment() {
+	c.logger.Infof("node %q is a bridge, setting up segment bridge...", c.nodeName)
+
+	// the pod network namespace outlives the launcher container, so on a launcher restart the
+	// bridge may already exist
+	showCmd := exec.CommandContext(c.ctx, "ip", "link", "show", "dev", c.nodeName) //nolint:gosec
+
+	if showCmd.Run() != nil {
+		for _, args := range [][]string{
+			{"link", "add", "name", c.nodeName, "type", "bridge"},
+			{"link", "set", "dev", c.nodeName, "up"},
+		} {
+			cmd := exec.CommandContext(c.ctx, "ip", args...) //nolint:gosec
+
+			cmd.Stdout = c.logger
+			cmd.Stderr = c.logger
+
+			err := cmd.Run()
+			if err != nil {
+				c.fatalf(
+					clabernetesconstants.TerminationReasonLauncherFailed,
+					"failed creating bridge %q, err: %s",
+					c.nodeName,
+					err,
+				)
+			}
+		}
+	}
+
+	claberneteslauncherconnectivity.TagLink(c.nodeName)
+
+	c.logger.Debug("launching containerlab to wire segment bridge ports...")
+
+	err := c.runContainerlab()
+	if err != nil {
+		c.fatalf(
+			clabernetesconstants.TerminationReasonContainerlabDeployFailed,
+			"failed wiring segment bridge ports, err: %s",
+			err,
+		)
+	}
+
+	// nothing to probe on a bridge, as soon as its wired up it is as healthy as it will ever be
+	err = os.WriteFile(
+		clabernetesconstants.NodeStatusFile,
+		[]byte(clabernetesconstants.NodeStatusHealthy),
+		clabernetesconstants.PermissionsEveryoneAllPermissions,
+	)
+	if err != nil {
+		c.logger.Warnf("failed writing node status file, err: %s", err)
+	}
+
+	c.logger.Debug("segment bridge launched successfully")
+}
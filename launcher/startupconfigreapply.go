@@ -0,0 +1,254 @@
+package launcher
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	"golang.org/x/crypto/ssh"
+)
+
+const configPushSSHConnectTimeout = 10 * time.Second
+
+// runStartupConfigReapply watches the (live updated) sub-topology of our node and pushes the
+// changed lines of the inline startup-config of the node to it whenever that changes -- the
+// controller does not restart nodes whose config changes in nothing but their inline
+// startup-config when the startup-config reapply is enabled. The config is pushed via ssh using
+// the ssh (or cli) probe credentials, or via the node cli if there are none.
+func (c *clabernetes) runStartupConfigReapply() {
+	reapplyFile := os.Getenv(clabernetesconstants.LauncherStartupConfigReapplyFileEnv)
+	if reapplyFile == "" {
+		return
+	}
+
+	nodeKind := resolveNodeKind(c.nodeName)
+
+	dialect, ok := resolveConfigPushDialect(
+		nodeKind,
+		os.Getenv(clabernetesconstants.LauncherNodeImageEnv),
+	)
+	if !ok {
+		c.logger.Warnf(
+			"startup-config reapply enabled but kind of node %q does not support merging"+
+				" configuration, not watching startup-config",
+			c.nodeName,
+		)
+
+		return
+	}
+
+	readStartupConfig := func() ([]byte, error) {
+		return readInlineStartupConfig(reapplyFile, c.nodeName)
+	}
+
+	previousConfig, err := readStartupConfig()
+	if err != nil {
+		c.logger.Warnf(
+			"failed reading inline startup-config of node %q, not watching startup-config,"+
+				" err: %s",
+			c.nodeName,
+			err,
+		)
+
+		return
+	}
+
+	if dialect.flat && bytes.HasPrefix(bytes.TrimSpace(previousConfig), []byte("{")) {
+		c.logger.Warn(
+			"startup-config is json, startup-config reapply only supports cli (\"set\" style)" +
+				" configs, not watching startup-config",
+		)
+
+		return
+	}
+
+	push := c.pushConfig
+
+	username, password, port, ok := configPushSSHCredentials(nodeKind)
+	if ok {
+		push = func(dialect configPushDialect, delta []string) error {
+			return c.pushConfigSSH(dialect, delta, username, password, port)
+		}
+	}
+
+	c.logger.Infof(
+		"watching inline startup-config of node %q in %q for changes to push to node",
+		c.nodeName,
+		reapplyFile,
+	)
+
+	c.watchConfigChanges(previousConfig, dialect, readStartupConfig, push)
+}
+
+// readInlineStartupConfig returns the inline startup-config of the given node from the
+// (containerlab) sub-topology at the given path. Returns an error if the startup-config of the
+// node is a path rather than inline config, as only inline startup-configs are reapplied.
+func readInlineStartupConfig(path, nodeName string) ([]byte, error) {
+	rawConfig, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := clabernetesutilcontainerlab.LoadContainerlabConfig(string(rawConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Topology == nil || config.Topology.Nodes[nodeName] == nil {
+		return nil, fmt.Errorf(
+			"%w: node %q not found in sub-topology",
+			claberneteserrors.ErrLaunch,
+			nodeName,
+		)
+	}
+
+	startupConfig := config.Topology.Nodes[nodeName].StartupConfig
+
+	if !strings.Contains(strings.TrimSpace(startupConfig), "\n") {
+		return nil, fmt.Errorf(
+			"%w: startup-config of node %q is not inline",
+			claberneteserrors.ErrLaunch,
+			nodeName,
+		)
+	}
+
+	return []byte(startupConfig), nil
+}
+
+// configPushSSHCredentials returns the credentials (and port) to push configuration to the node
+// via ssh with -- those of the ssh probe if set, otherwise those of the cli probe (defaults) of
+// the given kind. The returned bool indicates if there are any.
+func configPushSSHCredentials(nodeKind string) (username, password string, port int, ok bool) {
+	username = os.Getenv(clabernetesconstants.LauncherSSHProbeUsername)
+	password = os.Getenv(clabernetesconstants.LauncherSSHProbePassword)
+
+	if username != "" && password != "" {
+		port = clabernetesutil.GetEnvIntOrDefault(
+			clabernetesconstants.LauncherSSHProbePort,
+			defaultSSHPort,
+		)
+
+		return username, password, port, true
+	}
+
+	probe, _ := resolveCLIProbe(nodeKind)
+
+	return probe.username, probe.password, probe.port, probe.username != "" && probe.password != ""
+}
+
+// pushConfigSSH feeds the given configuration lines to the cli of the node via an ssh session
+// with the given credentials.
+func (c *clabernetes) pushConfigSSH(
+	dialect configPushDialect,
+	delta []string,
+	username,
+	password string,
+	port int,
+) error {
+	nodeAddr, err := getContainerAddr(c.ctx, c.nodeContainerID)
+	if err != nil {
+		return err
+	}
+
+	if nodeAddr == "" {
+		return fmt.Errorf("%w: node address unknown", claberneteserrors.ErrLaunch)
+	}
+
+	sshConfig := &ssh.ClientConfig{
+		User: username,
+		Auth: []ssh.AuthMethod{
+			ssh.Password(password),
+			ssh.KeyboardInteractive(
+				func(_, _ string, questions []string, _ []bool) ([]string, error) {
+					answers := make([]string, len(questions))
+					for i := range answers {
+						answers[i] = password
+					}
+
+					return answers, nil
+				},
+			),
+		},
+		Timeout:         configPushSSHConnectTimeout,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+	}
+
+	addr := net.JoinHostPort(nodeAddr, strconv.Itoa(port))
+
+	ctx, cancel := context.WithTimeout(c.ctx, configPushTimeout)
+	defer cancel()
+
+	netConn, err := (&net.Dialer{Timeout: configPushSSHConnectTimeout}).DialContext(
+		ctx,
+		"tcp",
+		addr,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = netConn.Close()
+	}()
+
+	// make sure a wedged cli cant hang us
+	err = netConn.SetDeadline(time.Now().Add(configPushTimeout))
+	if err != nil {
+		return err
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, addr, sshConfig)
+	if err != nil {
+		return err
+	}
+
+	conn := ssh.NewClient(sshConn, chans, reqs)
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	session, err := conn.NewSession()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = session.Close()
+	}()
+
+	script := c.configPushScript(dialect, delta)
+
+	var output bytes.Buffer
+
+	session.Stdin = strings.NewReader(strings.Join(script, "\n") + "\n")
+	session.Stdout = &output
+	session.Stderr = &output
+
+	err = session.Shell()
+	if err == nil {
+		err = session.Wait()
+	}
+
+	if err != nil {
+		return fmt.Errorf(
+			"%w: node cli (via ssh) exited with error, err: %w, output: %s",
+			claberneteserrors.ErrLaunch,
+			err,
+			output.String(),
+		)
+	}
+
+	c.logger.Debugf("config push output: %s", output.String())
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package launcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"os"
+	"os/exec"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+// applyStaticRoutes adds the static routes the controller handed us (if any) to the pod network
+// namespace. Routes without a gateway point at the default gateway of the pod.
+func (c *clabernetes) applyStaticRoutes() {
+	staticRoutesJSON := os.Getenv(clabernetesconstants.LauncherStaticRoutesEnv)
+	if staticRoutesJSON == "" {
+		return
+	}
+
+	var staticRoutes []clabernetesapisv1alpha1.StaticRoute
+
+	err := json.Unmarshal([]byte(staticRoutesJSON), &staticRoutes)
+	if err != nil {
+		c.fatalf(
+			clabernetesconstants.TerminationReasonLauncherFailed,
+			"failed parsing static routes, err: %s",
+			err,
+		)
+	}
+
+	for _, staticRoute := range staticRoutes {
+		destination, err := netip.ParsePrefix(staticRoute.Destination)
+		if err != nil {
+			c.fatalf(
+				clabernetesconstants.TerminationReasonLauncherFailed,
+				"failed parsing static route destination %q, err: %s",
+				staticRoute.Destination,
+				err,
+			)
+		}
+
+		gateway := staticRoute.Gateway
+
+		if gateway == "" {
+			gateway, err = c.defaultGateway(destination.Addr().Is6())
+			if err != nil {
+				c.fatalf(
+					clabernetesconstants.TerminationReasonLauncherFailed,
+					"failed resolving default gateway for static route %q, err: %s",
+					staticRoute.Destination,
+					err,
+				)
+			}
+		}
+
+		c.logger.Infof("adding static route %q via %q", destination, gateway)
+
+		output, err := exec.CommandContext( //nolint:gosec
+			c.ctx,
+			"ip",
+			"route",
+			"replace",
+			destination.String(),
+			"via",
+			gateway,
+		).CombinedOutput()
+		if err != nil {
+			c.fatalf(
+				clabernetesconstants.TerminationReasonLauncherFailed,
+				"failed adding static route %q via %q, err: %s, output: %s",
+				destination,
+				gateway,
+				err,
+				output,
+			)
+		}
+	}
+}
+
+// defaultGateway returns the (ipv4 or ipv6) default gateway of the pod network namespace.
+func (c *clabernetes) defaultGateway(ipv6 bool) (string, error) {
+	family := "-4"
+	if ipv6 {
+		family = "-6"
+	}
+
+	output, err := exec.CommandContext(c.ctx, "ip", "-j", family, "route", "show", "default").
+		Output()
+	if err != nil {
+		return "", err
+	}
+
+	var routes []struct {
+		Gateway string `json:"gateway"`
+	}
+
+	err = json.Unmarshal(output, &routes)
+	if err != nil {
+		return "", err
+	}
+
+	for _, route := range routes {
+		if route.Gateway != "" {
+			return route.Gateway, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: no default route found", claberneteserrors.ErrLaunch)
+}
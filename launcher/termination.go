@@ -0,0 +1,59 @@
+package launcher
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+)
+
+const (
+	containerlabLogPath = "containerlab.log"
+)
+
+// fatalf writes the given message to the termination message path (prefixed with the given
+// termination reason) so that it is visible in the pod status/`kubectl describe`, and then crashes
+// via the logger's Fatalf like always.
+func (c *clabernetes) fatalf(reason, f string, a ...interface{}) {
+	clabernetesutil.WriteTerminationMessage(reason, fmt.Sprintf(f, a...))
+
+	c.logger.Fatalf(f, a...)
+}
+
+// containerlabFailureReason returns the termination reason for a failed containerlab deploy based
+// on the containerlab output -- the common (and actionable) failures are a missing/unpullable image
+// and vm based nodes that need kvm on a node that does not have it, anything else is just a
+// generic deploy failure.
+func containerlabFailureReason(containerlabOutput string) string {
+	containerlabOutput = strings.ToLower(containerlabOutput)
+
+	switch {
+	case strings.Contains(containerlabOutput, "/dev/kvm"),
+		strings.Contains(containerlabOutput, "kvm acceleration"),
+		strings.Contains(containerlabOutput, "kvm virtualization"):
+		return clabernetesconstants.TerminationReasonKVMMissing
+	case strings.Contains(containerlabOutput, "pull access denied"),
+		strings.Contains(containerlabOutput, "failed to pull"),
+		strings.Contains(containerlabOutput, "manifest unknown"),
+		strings.Contains(containerlabOutput, "no such image"):
+		return clabernetesconstants.TerminationReasonImagePull
+	default:
+		return clabernetesconstants.TerminationReasonContainerlabDeployFailed
+	}
+}
+
+// writeContainerlabFailureTerminationMessage writes the termination message for a failed
+// containerlab deploy, classifying the failure from the containerlab log output.
+func writeContainerlabFailureTerminationMessage(deployErr error) {
+	containerlabOutput, err := os.ReadFile(containerlabLogPath)
+	if err != nil {
+		containerlabOutput = nil
+	}
+
+	clabernetesutil.WriteTerminationMessage(
+		containerlabFailureReason(string(containerlabOutput)),
+		fmt.Sprintf("failed launching containerlab, err: %s", deployErr),
+	)
+}
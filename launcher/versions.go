@@ -0,0 +1,108 @@
+package launcher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+const kernelReleasePath = "/proc/sys/kernel/osrelease"
+
+// recordVersions collects the versions of the software the launcher runs its node(s) with and
+// stores them (json encoded) so the heartbeat publishes them on the lease -- the controller
+// surfaces them in the topology status. Versions that can not be determined are left empty, this
+// is purely informational so it never fails the launcher.
+func (c *clabernetes) recordVersions() {
+	versions := clabernetesapisv1alpha1.NodeVersions{
+		Launcher:     clabernetesconstants.Version,
+		Containerlab: containerlabVersionString(c.ctx),
+		Kernel:       kernelRelease(),
+	}
+
+	if os.Getenv(clabernetesconstants.LauncherNativeModeEnv) != clabernetesconstants.True {
+		versions.Docker = dockerServerVersion(c.ctx)
+	}
+
+	c.logger.Debugf(
+		"launcher %s, containerlab %s, docker %s, kernel %s",
+		versions.Launcher,
+		versions.Containerlab,
+		versions.Docker,
+		versions.Kernel,
+	)
+
+	versionsBytes, err := json.Marshal(versions)
+	if err != nil {
+		c.logger.Warnf("failed marshaling versions, err: %s", err)
+
+		return
+	}
+
+	encodedVersions := string(versionsBytes)
+
+	c.versions.Store(&encodedVersions)
+}
+
+// containerlabVersionString returns the version of the installed containerlab binary -- that is
+// the "version:" line of the "containerlab version" output -- or an empty string if it can not be
+// determined.
+func containerlabVersionString(ctx context.Context) string {
+	ctx, cancel := context.WithTimeout(ctx, dockerResponseTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "containerlab", "version").Output()
+	if err != nil {
+		return ""
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || strings.TrimSpace(key) != "version" {
+			continue
+		}
+
+		return strings.TrimSpace(value)
+	}
+
+	return ""
+}
+
+// dockerServerVersion returns the version of the docker daemon of the launcher, or an empty string
+// if it can not be determined.
+func dockerServerVersion(ctx context.Context) string {
+	ctx, cancel := context.WithTimeout(ctx, dockerResponseTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(
+		ctx,
+		"docker",
+		"version",
+		"--format",
+		"{{.Server.Version}}",
+	).Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(output))
+}
+
+// kernelRelease returns the release of the running kernel (what "uname -r" prints), or an empty
+// string if it can not be determined.
+func kernelRelease() string {
+	release, err := os.ReadFile(kernelReleasePath)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(release))
+}
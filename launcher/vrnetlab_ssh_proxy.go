@@ -11,6 +11,7 @@ import (
 	"time"
 
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslauncherconnectivity "github.com/srl-labs/clabernetes/launcher/connectivity"
 )
 
 const (
@@ -104,6 +105,11 @@ func (c *clabernetes) ensureVrnetlabMgmtVeth() error {
 		time.Sleep(checkWait)
 	}
 
+	// Tag both ends as owned by this launcher so they are cleaned up with the pod, no matter if
+	// we or the vrnetlab bootstrap script created them.
+	claberneteslauncherconnectivity.TagLink(hostDev)
+	claberneteslauncherconnectivity.TagLink(iosDev)
+
 	// Ensure address on hostDev (ignore if already exists).
 	_ = exec.CommandContext(c.ctx, "ip", "addr", "add", hostCIDR, "dev", hostDev).Run()
 	_ = exec.CommandContext(c.ctx, "ip", "link", "set", hostDev, "up").Run()
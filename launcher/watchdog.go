@@ -0,0 +1,138 @@
+package launcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	watchdogMaxDockerRestarts = 3
+)
+
+// watchdog keeps an eye on the docker daemon and on the containers containerlab launched. A docker
+// daemon that stopped responding is restarted in place -- the daemon runs with live-restore, so the
+// containers keep running meanwhile. A container that is no longer running can not simply be
+// started again as its links (the veths towards our tunnels) went away with it, so in that case
+// the launcher exits, with the reason in its termination message, and the node (links and all) is
+// deployed again when the launcher container restarts.
+func (c *clabernetes) watchdog() {
+	if len(c.containerIDs) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(containerCheckInterval)
+	defer ticker.Stop()
+
+	var dockerRestarts int
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := dockerResponding(c.ctx)
+		if err != nil {
+			if dockerRestarts >= watchdogMaxDockerRestarts {
+				c.fatalf(
+					clabernetesconstants.TerminationReasonDockerFailed,
+					"docker daemon stopped responding after being restarted %d times, err: %s",
+					dockerRestarts,
+					err,
+				)
+			}
+
+			dockerRestarts++
+
+			c.logger.Warnf(
+				"docker daemon stopped responding, restarting it (restart %d of %d), err: %s",
+				dockerRestarts,
+				watchdogMaxDockerRestarts,
+				err,
+			)
+
+			startErr := startDocker(c.ctx, c.logger)
+			if startErr != nil {
+				c.fatalf(
+					clabernetesconstants.TerminationReasonDockerFailed,
+					"failed restarting docker daemon, err: %s",
+					startErr,
+				)
+			}
+
+			c.recordWatchdogRestart(
+				clabernetesconstants.WatchdogReasonDockerRestarted,
+				fmt.Sprintf("docker daemon stopped responding and was restarted, err: %s", err),
+			)
+		}
+
+		c.heartbeatProgress.mark()
+
+		c.checkContainers()
+	}
+}
+
+// checkContainers exits the launcher if any of the containers containerlab launched is no longer
+// running.
+func (c *clabernetes) checkContainers() {
+	for _, containerID := range c.containerIDs {
+		state, err := getContainerState(c.ctx, containerID)
+		if err != nil {
+			c.logger.Warnf("failed inspecting container %q, error: %s", containerID, err)
+
+			continue
+		}
+
+		if state.Running {
+			continue
+		}
+
+		message := fmt.Sprintf(
+			"container %q is no longer running (status %q, exit code %d",
+			containerID,
+			state.Status,
+			state.ExitCode,
+		)
+
+		if state.OOMKilled {
+			message += ", oom killed"
+		}
+
+		if state.Error != "" {
+			message += ", error: " + state.Error
+		}
+
+		c.fatalf(
+			clabernetesconstants.TerminationReasonNodeContainerFailed,
+			"%s), exiting so the node is deployed again",
+			message,
+		)
+	}
+}
+
+// recordWatchdogRestart stores the given in place restart so the heartbeat publishes it on the
+// heartbeat lease, from where the controller adds it to the topology timeline.
+func (c *clabernetes) recordWatchdogRestart(reason, message string) {
+	event, err := json.Marshal(clabernetesapisv1alpha1.TimelineEvent{
+		Time:    metav1.Now(),
+		Source:  clabernetesconstants.TimelineSourceLauncher,
+		Node:    c.nodeName,
+		Reason:  reason,
+		Message: message,
+	})
+	if err != nil {
+		c.logger.Warnf("failed marshaling watchdog restart event, err: %s", err)
+
+		return
+	}
+
+	watchdogRestart := string(event)
+
+	c.watchdogRestart.Store(&watchdogRestart)
+}
@@ -0,0 +1,62 @@
+package launcher
+
+import (
+	"os"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslauncherconnectivity "github.com/srl-labs/clabernetes/launcher/connectivity"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+)
+
+// StartWireTap runs a wire tap pod -- a tiny transparent bridge the controller puts in the path of
+// a link (see the topology wireTaps) so traffic of the link can be captured and impaired inline
+// without touching the launchers or nodes on either end. The tunnels of both sides are read from
+// the environment, this blocks until the process is signaled to stop.
+func StartWireTap() {
+	claberneteslogging.InitManager()
+
+	logManager := claberneteslogging.GetManager()
+
+	clabernetesLogger := logManager.MustRegisterAndGetLogger(
+		clabernetesconstants.Clabernetes,
+		clabernetesutil.GetEnvStrOrDefault(
+			clabernetesconstants.LauncherLoggerLevelEnv,
+			clabernetesconstants.Info,
+		),
+	)
+
+	ctx, _ := clabernetesutil.SignalHandledContext(clabernetesLogger.Criticalf)
+
+	err := claberneteslauncherconnectivity.RunWireTap(
+		ctx,
+		clabernetesLogger,
+		[2]claberneteslauncherconnectivity.WireTapSide{
+			{
+				Name: clabernetesconstants.WireTapSideA,
+				TunnelID: clabernetesutil.GetEnvIntOrDefault(
+					clabernetesconstants.WireTapSideATunnelIDEnv,
+					0,
+				),
+				Destination: os.Getenv(clabernetesconstants.WireTapSideADestinationEnv),
+			},
+			{
+				Name: clabernetesconstants.WireTapSideB,
+				TunnelID: clabernetesutil.GetEnvIntOrDefault(
+					clabernetesconstants.WireTapSideBTunnelIDEnv,
+					0,
+				),
+				Destination: os.Getenv(clabernetesconstants.WireTapSideBDestinationEnv),
+			},
+		},
+	)
+	if err != nil {
+		clabernetesLogger.Criticalf("wire tap failed, err: %s", err)
+
+		logManager.Flush()
+
+		os.Exit(clabernetesconstants.ExitCodeError)
+	}
+
+	logManager.Flush()
+}
@@ -0,0 +1,259 @@
+package manager
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	k8scorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	apimachineryschema "k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlruntimecache "sigs.k8s.io/controller-runtime/pkg/cache"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// cacheStrategy holds how the manager cache is scoped -- see the ManagerCacheStrategy constants.
+type cacheStrategy struct {
+	strategy string
+	// unfilteredObjects are the objects (kinds) cached regardless of labels with the filtered
+	// strategy.
+	unfilteredObjects []ctrlruntimeclient.Object
+	// namespaces are the namespaces cached with the namespaced strategy.
+	namespaces []string
+}
+
+// resolveCacheStrategy returns the cache strategy set via the MANAGER_CACHE_* env vars, defaulting
+// to the filtered strategy. A namespace scoped manager always caches just its own namespace, it
+// would not be allowed to list/watch anything else anyway.
+func resolveCacheStrategy(
+	scheme *apimachineryruntime.Scheme,
+	namespace string,
+	namespaceScoped bool,
+) (*cacheStrategy, error) {
+	if namespaceScoped {
+		return &cacheStrategy{
+			strategy:   clabernetesconstants.ManagerCacheStrategyNamespaced,
+			namespaces: []string{namespace},
+		}, nil
+	}
+
+	strategy := &cacheStrategy{
+		strategy: clabernetesutil.GetEnvStrOrDefault(
+			clabernetesconstants.ManagerCacheStrategyEnv,
+			clabernetesconstants.ManagerCacheStrategyFiltered,
+		),
+	}
+
+	switch strategy.strategy {
+	case clabernetesconstants.ManagerCacheStrategyFiltered:
+		for _, kind := range splitEnvList(clabernetesconstants.ManagerCacheUnfilteredKindsEnv) {
+			obj, err := resolveCacheObject(scheme, kind)
+			if err != nil {
+				return nil, err
+			}
+
+			strategy.unfilteredObjects = append(strategy.unfilteredObjects, obj)
+		}
+	case clabernetesconstants.ManagerCacheStrategyNamespaced:
+		// our own namespace is always cached, the config singleton lives there
+		strategy.namespaces = []string{namespace}
+
+		for _, cacheNamespace := range splitEnvList(
+			clabernetesconstants.ManagerCacheNamespacesEnv,
+		) {
+			if !slices.Contains(strategy.namespaces, cacheNamespace) {
+				strategy.namespaces = append(strategy.namespaces, cacheNamespace)
+			}
+		}
+	case clabernetesconstants.ManagerCacheStrategyUnfiltered:
+	default:
+		return nil, fmt.Errorf(
+			"%w: unknown cache strategy %q, must be one of %q, %q or %q",
+			claberneteserrors.ErrPrepare,
+			strategy.strategy,
+			clabernetesconstants.ManagerCacheStrategyFiltered,
+			clabernetesconstants.ManagerCacheStrategyNamespaced,
+			clabernetesconstants.ManagerCacheStrategyUnfiltered,
+		)
+	}
+
+	return strategy, nil
+}
+
+// String returns a human readable description of the cache strategy.
+func (s *cacheStrategy) String() string {
+	switch s.strategy {
+	case clabernetesconstants.ManagerCacheStrategyFiltered:
+		kinds := make([]string, 0, len(s.unfilteredObjects))
+
+		for _, obj := range s.unfilteredObjects {
+			kinds = append(kinds, fmt.Sprintf("%T", obj))
+		}
+
+		return fmt.Sprintf("%s (unfiltered kinds: %q)", s.strategy, kinds)
+	case clabernetesconstants.ManagerCacheStrategyNamespaced:
+		return fmt.Sprintf("%s (namespaces: %q)", s.strategy, s.namespaces)
+	default:
+		return s.strategy
+	}
+}
+
+// apply sets up the given cache options per the cache strategy.
+func (s *cacheStrategy) apply(opts *ctrlruntimecache.Options, appName, namespace string) {
+	switch s.strategy {
+	case clabernetesconstants.ManagerCacheStrategyNamespaced:
+		// everything in the given namespaces regardless of labels -- cluster scoped objects are
+		// not namespaced so are cached in full either way
+		opts.DefaultNamespaces = make(map[string]ctrlruntimecache.Config, len(s.namespaces))
+
+		for _, cacheNamespace := range s.namespaces {
+			opts.DefaultNamespaces[cacheNamespace] = ctrlruntimecache.Config{
+				LabelSelector: labels.Everything(),
+			}
+		}
+	case clabernetesconstants.ManagerCacheStrategyUnfiltered:
+		// cache everything, correct no matter what we look up but the most memory hungry option
+	default:
+		s.applyFiltered(opts, appName, namespace)
+	}
+}
+
+func (s *cacheStrategy) applyFiltered(
+	opts *ctrlruntimecache.Options,
+	appName,
+	namespace string,
+) {
+	appLabelSelector := labels.SelectorFromSet(
+		labels.Set{
+			// only cache objects with the "clabernetes/app" label, why would we care
+			// about anything else (for now -- and we can override it with opts.ByObject
+			// anyway?! and... who the hell calls their app "clabernetes" so this should
+			// really limit the cache nicely :)
+			// currently this matters for launcher service accounts, role bindings,
+			// services (fabric and expose), and (launcher) deployments
+			clabernetesconstants.LabelApp: appName,
+		},
+	)
+
+	opts.DefaultLabelSelector = appLabelSelector
+
+	allNamespacesEverything := ctrlruntimecache.ByObject{
+		Namespaces: map[string]ctrlruntimecache.Config{
+			ctrlruntimecache.AllNamespaces: {
+				LabelSelector: labels.Everything(),
+			},
+		},
+	}
+
+	opts.ByObject = map[ctrlruntimeclient.Object]ctrlruntimecache.ByObject{
+		// obviously we need to cache all "our" topology objects, so do that
+		&clabernetesapisv1alpha1.Topology{}: allNamespacesEverything,
+		// we need to cache all our image request crs too of course
+		&clabernetesapisv1alpha1.ImageRequest{}: allNamespacesEverything,
+		// watch our config "singleton" too; while this is sorta/basically a "cluster"
+		// CR -- we dont want to have to force users to have cluster wide perms, *and*
+		// we want to be able to set an owner ref to the manager deployment, so the
+		// config *is* namespaced, so... watch all the namespaces for the config...
+		&clabernetesapisv1alpha1.Config{}: allNamespacesEverything,
+		// our tunnel "connectivity" cr
+		&clabernetesapisv1alpha1.Connectivity{}: allNamespacesEverything,
+		// secrets in our own namespace regardless of labels, the (user created) image
+		// pull secret we propagate to the topology namespaces lives there
+		&k8scorev1.Secret{}: {
+			Namespaces: map[string]ctrlruntimecache.Config{
+				namespace: {
+					LabelSelector: labels.Everything(),
+				},
+				ctrlruntimecache.AllNamespaces: {
+					LabelSelector: appLabelSelector,
+				},
+			},
+		},
+	}
+
+	// the kinds the operator explicitly asked for are cached regardless of labels -- objects are
+	// keyed by pointer, so replace any entry of the same type (secrets) rather than adding one
+	for _, unfilteredObject := range s.unfilteredObjects {
+		maps.DeleteFunc(
+			opts.ByObject,
+			func(obj ctrlruntimeclient.Object, _ ctrlruntimecache.ByObject) bool {
+				return fmt.Sprintf("%T", obj) == fmt.Sprintf("%T", unfilteredObject)
+			},
+		)
+
+		opts.ByObject[unfilteredObject] = allNamespacesEverything
+	}
+}
+
+// resolveCacheObject returns an (empty) object of the given kind -- either just the kind (for
+// example "ConfigMap") or the kind and its api group (for example "Deployment.apps"), matched case
+// insensitively. Kinds that exist in more than one group resolve to the core group one if there is
+// one.
+func resolveCacheObject(
+	scheme *apimachineryruntime.Scheme,
+	kind string,
+) (ctrlruntimeclient.Object, error) {
+	kindName, group, hasGroup := strings.Cut(kind, ".")
+
+	var candidates []apimachineryschema.GroupVersionKind
+
+	for gvk := range scheme.AllKnownTypes() {
+		if gvk.Version == apimachineryruntime.APIVersionInternal ||
+			!strings.EqualFold(gvk.Kind, kindName) ||
+			(hasGroup && !strings.EqualFold(gvk.Group, group)) {
+			continue
+		}
+
+		candidates = append(candidates, gvk)
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf(
+			"%w: unknown kind %q in %s",
+			claberneteserrors.ErrPrepare,
+			kind,
+			clabernetesconstants.ManagerCacheUnfilteredKindsEnv,
+		)
+	}
+
+	slices.SortFunc(candidates, func(a, b apimachineryschema.GroupVersionKind) int {
+		return strings.Compare(a.Group+"/"+a.Version, b.Group+"/"+b.Version)
+	})
+
+	obj, err := scheme.New(candidates[0])
+	if err != nil {
+		return nil, err
+	}
+
+	clientObj, ok := obj.(ctrlruntimeclient.Object)
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w: kind %q can not be cached",
+			claberneteserrors.ErrPrepare,
+			kind,
+		)
+	}
+
+	return clientObj, nil
+}
+
+func splitEnvList(envName string) []string {
+	var values []string
+
+	for _, value := range strings.Split(clabernetesutil.GetEnvStrOrDefault(envName, ""), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		values = append(values, value)
+	}
+
+	return values
+}
@@ -65,7 +65,11 @@ func StartClabernetes(initializer bool) {
 			clabernetesconstants.AppNameDefault,
 		),
 		initializer: initializer,
-		logger:      clabernetesLogger,
+		namespaceScoped: clabernetesutil.GetEnvBoolOrDefault(
+			clabernetesconstants.ManagerNamespaceScopedEnv,
+			false,
+		),
+		logger: clabernetesLogger,
 	}
 
 	clabernetesInstance.start()
@@ -82,6 +86,10 @@ type clabernetes struct {
 
 	initializer bool
 
+	// namespaceScoped indicates the manager only watches/manages its own namespace, see
+	// clabernetesconstants.ManagerNamespaceScopedEnv
+	namespaceScoped bool
+
 	logger claberneteslogging.Instance
 
 	namespace string
@@ -129,6 +137,10 @@ func (c *clabernetes) IsInitializer() bool {
 	return c.initializer
 }
 
+func (c *clabernetes) IsNamespaceScoped() bool {
+	return c.namespaceScoped
+}
+
 func (c *clabernetes) GetKubeConfig() *rest.Config {
 	return c.kubeConfig
 }
@@ -213,7 +225,7 @@ func (c *clabernetes) start() {
 	// dont create the manager until we've loaded the scheme!
 	var err error
 
-	c.mgr, err = newManager(c.scheme, c.appName)
+	c.mgr, err = newManager(c.logger, c.scheme, c.appName, c.namespace, c.namespaceScoped)
 	if err != nil {
 		c.logger.Criticalf("failed creating controller runtime manager, err: %s", err)
 
@@ -18,15 +18,22 @@ func (c *clabernetes) init(ctx context.Context) {
 
 	c.logger.Debug("initializing certificates complete...")
 
-	c.logger.Info("initializing crds...")
-
-	err = initializeCrds(c)
-	if err != nil {
-		c.logger.Fatalf("failed initializing crds, err: %s", err)
+	if c.namespaceScoped {
+		// crds are cluster scoped, so we can neither create nor update them -- they must be
+		// installed (by someone with cluster wide permissions) before a namespace scoped manager
+		// is deployed
+		c.logger.Info("namespace scoped, skipping initializing crds...")
+	} else {
+		c.logger.Info("initializing crds...")
+
+		err = initializeCrds(c)
+		if err != nil {
+			c.logger.Fatalf("failed initializing crds, err: %s", err)
+		}
+
+		c.logger.Debug("initializing crds complete...")
 	}
 
-	c.logger.Debug("initializing crds complete...")
-
 	c.logger.Info("initializing global config...")
 
 	initializeConfig(c)
@@ -25,16 +25,23 @@ func (c *clabernetes) preStart() {
 
 	c.logger.Debug("config manager started...")
 
-	c.logger.Info("determining cri sameness (or not)...")
+	if c.namespaceScoped {
+		// we cant list cluster nodes, so we cant know the cri either
+		c.logger.Info("namespace scoped, skipping cri sameness check...")
 
-	nodeCriKind, err := cri(c)
-	if err != nil {
-		c.logger.Fatalf("failed dermining cri sameness, err: %s", err)
-	}
+		c.criKind = clabernetesconstants.KubernetesCRIUnknown
+	} else {
+		c.logger.Info("determining cri sameness (or not)...")
 
-	c.criKind = nodeCriKind
+		nodeCriKind, criErr := cri(c)
+		if criErr != nil {
+			c.logger.Fatalf("failed dermining cri sameness, err: %s", criErr)
+		}
 
-	c.logger.Debug("cri sameness check complete...")
+		c.criKind = nodeCriKind
+
+		c.logger.Debug("cri sameness check complete...")
+	}
 
 	c.logger.Debug("pre-start complete...")
 }
@@ -41,6 +41,11 @@ type Clabernetes interface { //nolint: interfacebloat
 	// initialization resources.
 	IsInitializer() bool
 
+	// IsNamespaceScoped returns true if the clabernetes instance only watches and manages its own
+	// namespace -- meaning it only has namespaced (Role) permissions and must not touch cluster
+	// scoped resources such as crds or nodes.
+	IsNamespaceScoped() bool
+
 	// GetKubeConfig returns the in-cluster rest.Config for the clabernetes instance.
 	GetKubeConfig() *rest.Config
 
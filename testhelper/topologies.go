@@ -0,0 +1,128 @@
+package testhelper
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+const (
+	sampleTopologiesDir      = "testdata/topologies"
+	sampleTopologyFileSuffix = ".yaml"
+)
+
+// sampleTopologiesPath returns the absolute path to the sample topology corpus -- we resolve this
+// relative to *this* file rather than the working directory so that tests in any package can load
+// the samples.
+func sampleTopologiesPath(t *testing.T) string {
+	t.Helper()
+
+	_, thisFile, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("failed determining sample topologies path")
+	}
+
+	return filepath.Join(filepath.Dir(thisFile), sampleTopologiesDir)
+}
+
+// SampleTopologyNames returns the (sorted) names of all topologies in the sample topology corpus.
+// The corpus holds representative topologies (a ceos pair, an srl fabric, a mixed iol/vios native
+// mode topology, and a big 50 node fabric) that renderer tests can use as regression fixtures, and
+// that double as examples of what "real" topologies look like.
+func SampleTopologyNames(t *testing.T) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(sampleTopologiesPath(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), sampleTopologyFileSuffix) {
+			continue
+		}
+
+		names = append(names, strings.TrimSuffix(entry.Name(), sampleTopologyFileSuffix))
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// LoadSampleTopology loads the named topology from the sample topology corpus.
+func LoadSampleTopology(t *testing.T, name string) *clabernetesapisv1alpha1.Topology {
+	t.Helper()
+
+	content, err := os.ReadFile( //nolint:gosec
+		filepath.Join(sampleTopologiesPath(t), name+sampleTopologyFileSuffix),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	topology := &clabernetesapisv1alpha1.Topology{}
+
+	err = sigsyaml.Unmarshal(content, topology)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return topology
+}
+
+// SyntheticTopology returns a containerlab topology with the given number of spine and leaf
+// nodes, where every leaf is connected to every spine -- handy for generating arbitrarily large
+// topologies for benchmarks (for example 4 spines and 196 leaves is 200 nodes and 784 links).
+func SyntheticTopology(name string, spines, leaves int) *clabernetesapisv1alpha1.Topology {
+	definition := &strings.Builder{}
+
+	_, _ = fmt.Fprintf(definition, "name: %s\ntopology:\n", name)
+	definition.WriteString("  kinds:\n    nokia_srlinux:\n      image: ghcr.io/nokia/srlinux\n")
+	definition.WriteString("  nodes:\n")
+
+	for spine := 1; spine <= spines; spine++ {
+		_, _ = fmt.Fprintf(definition, "    spine%d:\n      kind: nokia_srlinux\n", spine)
+	}
+
+	for leaf := 1; leaf <= leaves; leaf++ {
+		_, _ = fmt.Fprintf(definition, "    leaf%d:\n      kind: nokia_srlinux\n", leaf)
+	}
+
+	definition.WriteString("  links:\n")
+
+	for spine := 1; spine <= spines; spine++ {
+		for leaf := 1; leaf <= leaves; leaf++ {
+			_, _ = fmt.Fprintf(
+				definition,
+				"    - endpoints: [\"spine%d:e1-%d\", \"leaf%d:e1-%d\"]\n",
+				spine,
+				leaf,
+				leaf,
+				spine,
+			)
+		}
+	}
+
+	return &clabernetesapisv1alpha1.Topology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "clabernetes",
+		},
+		Spec: clabernetesapisv1alpha1.TopologySpec{
+			Definition: clabernetesapisv1alpha1.Definition{
+				Containerlab: definition.String(),
+			},
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package containerlab
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+const (
+	maxASN    = 4_294_967_295
+	minVLANID = 1
+	maxVLANID = 4094
+)
+
+// RenderInstanceOffset renders the given raw containerlab definition as a go template with the
+// given instance offset. The template has the "asn", "vlan", "offset" and "name" functions, and
+// the instance index itself as ".Instance", so a single definition can be stamped out many times
+// without the copies colliding on ASNs, VLAN IDs or names. A nil instance offset returns the raw
+// definition untouched.
+func RenderInstanceOffset(
+	rawConfig string,
+	instanceOffset *clabernetesapisv1alpha1.InstanceOffset,
+) (string, error) {
+	if instanceOffset == nil {
+		return rawConfig, nil
+	}
+
+	offset := *instanceOffset
+
+	if offset.ASNStep == 0 {
+		offset.ASNStep = 1
+	}
+
+	if offset.VLANStep == 0 {
+		offset.VLANStep = 1
+	}
+
+	funcs := template.FuncMap{
+		"offset": func(base, step int) int {
+			return base + offset.Instance*step
+		},
+		"asn": func(base int) (int, error) {
+			asn := base + offset.Instance*offset.ASNStep
+			if asn < 1 || asn > maxASN {
+				return 0, fmt.Errorf(
+					"%w: asn %d for instance %d is out of range",
+					claberneteserrors.ErrParse,
+					asn,
+					offset.Instance,
+				)
+			}
+
+			return asn, nil
+		},
+		"vlan": func(base int) (int, error) {
+			vlan := base + offset.Instance*offset.VLANStep
+			if vlan < minVLANID || vlan > maxVLANID {
+				return 0, fmt.Errorf(
+					"%w: vlan id %d for instance %d is out of range",
+					claberneteserrors.ErrParse,
+					vlan,
+					offset.Instance,
+				)
+			}
+
+			return vlan, nil
+		},
+		"name": func(name string) string {
+			return fmt.Sprintf("%s-%d", name, offset.Instance)
+		},
+	}
+
+	t, err := template.New("definition").
+		Funcs(funcs).
+		Option("missingkey=error").
+		Parse(rawConfig)
+	if err != nil {
+		return "", fmt.Errorf(
+			"%w: failed parsing containerlab definition template, error: %w",
+			claberneteserrors.ErrParse,
+			err,
+		)
+	}
+
+	var rendered bytes.Buffer
+
+	err = t.Execute(&rendered, offset)
+	if err != nil {
+		return "", fmt.Errorf(
+			"%w: failed rendering containerlab definition template, error: %w",
+			claberneteserrors.ErrParse,
+			err,
+		)
+	}
+
+	return rendered.String(), nil
+}
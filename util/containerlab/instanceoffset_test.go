@@ -0,0 +1,103 @@
+package containerlab_test
+
+import (
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+func TestRenderInstanceOffset(t *testing.T) {
+	cases := []struct {
+		name           string
+		rawConfig      string
+		instanceOffset *clabernetesapisv1alpha1.InstanceOffset
+		expected       string
+		expectErr      bool
+	}{
+		{
+			name:           "no-instance-offset",
+			rawConfig:      "name: {{ name \"topo01\" }}\n",
+			instanceOffset: nil,
+			expected:       "name: {{ name \"topo01\" }}\n",
+		},
+		{
+			name: "default-steps",
+			rawConfig: `name: {{ name "topo01" }}
+asn: {{ asn 65000 }}
+vlan: {{ vlan 100 }}
+instance: {{ .Instance }}
+`,
+			instanceOffset: &clabernetesapisv1alpha1.InstanceOffset{
+				Instance: 3,
+			},
+			expected: `name: topo01-3
+asn: 65003
+vlan: 103
+instance: 3
+`,
+		},
+		{
+			name: "custom-steps",
+			rawConfig: `asn: {{ asn 65000 }}
+vlan: {{ vlan 100 }}
+ip: 10.{{ offset 0 4 }}.0.1
+`,
+			instanceOffset: &clabernetesapisv1alpha1.InstanceOffset{
+				Instance: 2,
+				ASNStep:  100,
+				VLANStep: 10,
+			},
+			expected: `asn: 65200
+vlan: 120
+ip: 10.8.0.1
+`,
+		},
+		{
+			name:      "vlan-out-of-range",
+			rawConfig: "vlan: {{ vlan 4000 }}\n",
+			instanceOffset: &clabernetesapisv1alpha1.InstanceOffset{
+				Instance: 5,
+				VLANStep: 20,
+			},
+			expectErr: true,
+		},
+		{
+			name:      "asn-out-of-range",
+			rawConfig: "asn: {{ asn 4294967295 }}\n",
+			instanceOffset: &clabernetesapisv1alpha1.InstanceOffset{
+				Instance: 1,
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual, err := clabernetesutilcontainerlab.RenderInstanceOffset(
+					testCase.rawConfig,
+					testCase.instanceOffset,
+				)
+				if testCase.expectErr {
+					if err == nil {
+						t.Fatalf("expected an error but got none, rendered: %s", actual)
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("failed rendering instance offset, error: %s", err)
+				}
+
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
@@ -24,9 +24,15 @@ type TypedPort struct {
 	Protocol        string
 	ExposePort      int64
 	DestinationPort int64
+	// HostIP is the (docker) host address the port definition publishes the port on, if any. It is
+	// informational only, ports are always published on all addresses of the launcher pod so they
+	// can be reached via the pod ip (and the expose service) -- a port published on say loopback
+	// would not be reachable from anywhere.
+	HostIP string
 }
 
-// AsContainerlabPortDefinition returns the `TypedPort` object as a valid containerlab port entry.
+// AsContainerlabPortDefinition returns the `TypedPort` object as a valid containerlab port entry,
+// note that this never includes the host ip, see TypedPort.
 func (t *TypedPort) AsContainerlabPortDefinition() string {
 	return fmt.Sprintf("%d:%d/%s", t.ExposePort, t.DestinationPort, strings.ToLower(t.Protocol))
 }
@@ -35,7 +41,8 @@ func (t *TypedPort) AsContainerlabPortDefinition() string {
 func GetPortPattern() *regexp.Regexp {
 	portPatternOnce.Do(func() {
 		portPattern = regexp.MustCompile(
-			`(?P<exposePort>\d+):(?P<destinationPort>\d+)/?(?P<protocol>(TCP)|(UDP))?`,
+			`^(?:(?P<hostIP>\[[0-9A-F:.]+\]|[0-9.]+):)?` +
+				`(?P<exposePort>\d*):(?P<destinationPort>\d+)(?:/(?P<protocol>[A-Z]+))?$`,
 		)
 	})
 
@@ -52,15 +59,23 @@ func processPortDefinitionFull(re *regexp.Regexp, portDefinition string) (*Typed
 
 	var retErr error
 
-	exposePortAsInt, err := strconv.ParseInt(paramsMap["exposePort"], 10, 32)
-	if err != nil || exposePortAsInt == 0 {
-		retErr = fmt.Errorf(
-			"%w: failed converting exposed port to integer, full port string '%s', parsed port "+
-				"'%s'",
-			claberneteserrors.ErrParse,
-			portDefinition,
-			paramsMap["exposePort"],
-		)
+	var exposePortAsInt int64
+
+	// the expose port may be left out when publishing on a host ip ("ip::port"), in which case we
+	// allocate one like we do for port definitions without expose port
+	if paramsMap["exposePort"] != "" {
+		var err error
+
+		exposePortAsInt, err = strconv.ParseInt(paramsMap["exposePort"], 10, 32)
+		if err != nil || exposePortAsInt == 0 {
+			retErr = fmt.Errorf(
+				"%w: failed converting exposed port to integer, full port string '%s', parsed "+
+					"port '%s'",
+				claberneteserrors.ErrParse,
+				portDefinition,
+				paramsMap["exposePort"],
+			)
+		}
 	}
 
 	destinationPortAsInt, err := strconv.ParseInt(paramsMap["destinationPort"], 10, 32)
@@ -78,6 +93,7 @@ func processPortDefinitionFull(re *regexp.Regexp, portDefinition string) (*Typed
 		Protocol:        protocol,
 		ExposePort:      exposePortAsInt,
 		DestinationPort: destinationPortAsInt,
+		HostIP:          strings.Trim(paramsMap["hostIP"], "[]"),
 	}, retErr
 }
 
@@ -86,10 +102,10 @@ func processPortDefinitionFull(re *regexp.Regexp, portDefinition string) (*Typed
 func ProcessPortDefinition(portDefinition string) (*TypedPort, error) {
 	re := GetPortPattern()
 
-	portDefinition = strings.ToUpper(portDefinition)
+	portDefinition = strings.ToUpper(strings.TrimSpace(portDefinition))
 
 	if re.MatchString(portDefinition) {
-		// "fully" defined pattern -- meaning "port:port" with optional protocol
+		// "fully" defined pattern -- meaning "port:port" with optional host ip and protocol
 		return processPortDefinitionFull(re, portDefinition)
 	}
 
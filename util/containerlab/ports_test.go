@@ -0,0 +1,115 @@
+package containerlab_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+func TestProcessPortDefinition(t *testing.T) {
+	cases := []struct {
+		name           string
+		portDefinition string
+		expected       *clabernetesutilcontainerlab.TypedPort
+		expectErr      bool
+	}{
+		{
+			name:           "port-only",
+			portDefinition: "22",
+			expected: &clabernetesutilcontainerlab.TypedPort{
+				Protocol:        "TCP",
+				DestinationPort: 22,
+			},
+		},
+		{
+			name:           "port-and-protocol",
+			portDefinition: "161/udp",
+			expected: &clabernetesutilcontainerlab.TypedPort{
+				Protocol:        "UDP",
+				DestinationPort: 161,
+			},
+		},
+		{
+			name:           "full",
+			portDefinition: "60000:22/tcp",
+			expected: &clabernetesutilcontainerlab.TypedPort{
+				Protocol:        "TCP",
+				ExposePort:      60000,
+				DestinationPort: 22,
+			},
+		},
+		{
+			name:           "full-no-protocol",
+			portDefinition: "60000:22",
+			expected: &clabernetesutilcontainerlab.TypedPort{
+				Protocol:        "TCP",
+				ExposePort:      60000,
+				DestinationPort: 22,
+			},
+		},
+		{
+			name:           "host-ip",
+			portDefinition: "127.0.0.1:60000:161/udp",
+			expected: &clabernetesutilcontainerlab.TypedPort{
+				Protocol:        "UDP",
+				ExposePort:      60000,
+				DestinationPort: 161,
+				HostIP:          "127.0.0.1",
+			},
+		},
+		{
+			name:           "host-ipv6",
+			portDefinition: "[::1]:60000:22",
+			expected: &clabernetesutilcontainerlab.TypedPort{
+				Protocol:        "TCP",
+				ExposePort:      60000,
+				DestinationPort: 22,
+				HostIP:          "::1",
+			},
+		},
+		{
+			name:           "host-ip-no-expose-port",
+			portDefinition: "0.0.0.0::80",
+			expected: &clabernetesutilcontainerlab.TypedPort{
+				Protocol:        "TCP",
+				DestinationPort: 80,
+				HostIP:          "0.0.0.0",
+			},
+		},
+		{
+			name:           "invalid",
+			portDefinition: "ssh",
+			expectErr:      true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual, err := clabernetesutilcontainerlab.ProcessPortDefinition(
+					testCase.portDefinition,
+				)
+				if testCase.expectErr {
+					if err == nil {
+						t.Fatalf("expected an error processing %q", testCase.portDefinition)
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			},
+		)
+	}
+}
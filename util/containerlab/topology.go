@@ -1,6 +1,10 @@
 package containerlab
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -19,5 +23,138 @@ func LoadContainerlabConfig(rawConfig string) (*Config, error) {
 		config.Topology.Defaults = &NodeDefinition{}
 	}
 
+	// same for nodes and kinds with no settings at all ("nodes: {srl1: }" is perfectly valid
+	// containerlab, the node just takes everything from the defaults)
+	for nodeName, nodeDefinition := range config.Topology.Nodes {
+		if nodeDefinition == nil {
+			config.Topology.Nodes[nodeName] = &NodeDefinition{}
+		}
+	}
+
+	for kindName, kindDefinition := range config.Topology.Kinds {
+		if kindDefinition == nil {
+			config.Topology.Kinds[kindName] = &NodeDefinition{}
+		}
+	}
+
 	return config, nil
 }
+
+// SortLinks sorts the links of the topology in a stable order based on their type and endpoints,
+// the order links are defined in has no meaning to containerlab.
+func (t *Topology) SortLinks() {
+	if t == nil {
+		return
+	}
+
+	sort.SliceStable(t.Links, func(i, j int) bool {
+		return t.Links[i].sortKey() < t.Links[j].sortKey()
+	})
+}
+
+func (l *LinkDefinition) sortKey() string {
+	if l == nil {
+		return ""
+	}
+
+	endpoints := append([]string{}, l.Endpoints...)
+
+	if l.Endpoint != nil {
+		endpoints = append(
+			endpoints,
+			fmt.Sprintf("%s:%s", l.Endpoint["node"], l.Endpoint["interface"]),
+		)
+	}
+
+	sort.Strings(endpoints)
+
+	return fmt.Sprintf("%s|%s", l.Type, strings.Join(endpoints, ","))
+}
+
+func (s *Stages) all() []*Stage {
+	if s == nil {
+		return nil
+	}
+
+	return []*Stage{s.Create, s.CreateLinks, s.Configure, s.Healthy, s.Exit}
+}
+
+// GetNodeDependencies returns the sorted names of the nodes the given node depends on -- the nodes
+// in its "wait-for" list and the nodes any of its stages wait for.
+func (t *Topology) GetNodeDependencies(nodeName string) []string {
+	nodeDefinition, ok := t.Nodes[nodeName]
+	if !ok || nodeDefinition == nil {
+		return nil
+	}
+
+	dependencies := map[string]struct{}{}
+
+	for _, dependency := range nodeDefinition.WaitFor {
+		dependencies[dependency] = struct{}{}
+	}
+
+	for _, stage := range nodeDefinition.Stages.all() {
+		if stage == nil {
+			continue
+		}
+
+		for _, waitFor := range stage.WaitFor {
+			if waitFor == nil {
+				continue
+			}
+
+			dependencies[waitFor.Node] = struct{}{}
+		}
+	}
+
+	delete(dependencies, nodeName)
+	delete(dependencies, "")
+
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	sortedDependencies := make([]string, 0, len(dependencies))
+
+	for dependency := range dependencies {
+		sortedDependencies = append(sortedDependencies, dependency)
+	}
+
+	sort.Strings(sortedDependencies)
+
+	return sortedDependencies
+}
+
+// PruneDependencies removes the dependencies of the node on any nodes keep returns false for from
+// its "wait-for" list and the "wait-for" lists of its stages.
+func (n *NodeDefinition) PruneDependencies(keep func(nodeName string) bool) {
+	if n == nil {
+		return
+	}
+
+	var waitFor []string
+
+	for _, dependency := range n.WaitFor {
+		if keep(dependency) {
+			waitFor = append(waitFor, dependency)
+		}
+	}
+
+	n.WaitFor = waitFor
+
+	for _, stage := range n.Stages.all() {
+		if stage == nil {
+			continue
+		}
+
+		var stageWaitFor []*StageWaitFor
+
+		for _, dependency := range stage.WaitFor {
+			if dependency != nil && keep(dependency.Node) {
+				stageWaitFor = append(stageWaitFor, dependency)
+			}
+		}
+
+		stage.WaitFor = stageWaitFor
+	}
+}
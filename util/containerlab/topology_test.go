@@ -1,6 +1,7 @@
 package containerlab_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -85,6 +86,106 @@ func TestLoadContainerlabConfigFromConfigObjects(t *testing.T) {
 	}
 }
 
+func TestGetNodeDependencies(t *testing.T) {
+	config, err := clabernetesutilcontainerlab.LoadContainerlabConfig(`
+name: topo01
+
+topology:
+  nodes:
+    rr1:
+      kind: srl
+    rr2:
+      kind: srl
+    client1:
+      kind: srl
+      wait-for:
+        - rr2
+      stages:
+        create:
+          wait-for:
+            - node: rr1
+              stage: healthy
+            - node: rr2
+              stage: create
+        configure:
+          wait-for:
+            - node: client1
+              stage: create
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		nodeName string
+		expected []string
+	}{
+		{
+			name:     "no-dependencies",
+			nodeName: "rr1",
+			expected: nil,
+		},
+		{
+			name:     "wait-for-and-stages",
+			nodeName: "client1",
+			expected: []string{"rr1", "rr2"},
+		},
+		{
+			name:     "unknown-node",
+			nodeName: "client2",
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := config.Topology.GetNodeDependencies(testCase.nodeName)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					t.Errorf("got %v, want %v", actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestPruneDependencies(t *testing.T) {
+	nodeDefinition := &clabernetesutilcontainerlab.NodeDefinition{
+		WaitFor: []string{"rr1", "client2"},
+		Stages: &clabernetesutilcontainerlab.Stages{
+			Create: &clabernetesutilcontainerlab.Stage{
+				WaitFor: []*clabernetesutilcontainerlab.StageWaitFor{
+					{Node: "rr1", Stage: "healthy"},
+					{Node: "client2", Stage: "create"},
+				},
+			},
+		},
+	}
+
+	nodeDefinition.PruneDependencies(func(nodeName string) bool {
+		return nodeName == "client2"
+	})
+
+	if !reflect.DeepEqual(nodeDefinition.WaitFor, []string{"client2"}) {
+		t.Errorf("got wait-for %v, want [client2]", nodeDefinition.WaitFor)
+	}
+
+	expectedStageWaitFor := []*clabernetesutilcontainerlab.StageWaitFor{
+		{Node: "client2", Stage: "create"},
+	}
+
+	if !reflect.DeepEqual(nodeDefinition.Stages.Create.WaitFor, expectedStageWaitFor) {
+		t.Errorf(
+			"got stage wait-for %v, want %v",
+			nodeDefinition.Stages.Create.WaitFor,
+			expectedStageWaitFor,
+		)
+	}
+}
+
 func getMinimalValidConfigObjectWithFullHealthcheck() *clabernetesutilcontainerlab.Config {
 	config := &clabernetesutilcontainerlab.Config{Name: "minimalValidConfig"}
 	config.Topology = &clabernetesutilcontainerlab.Topology{
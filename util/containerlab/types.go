@@ -116,6 +116,31 @@ func (t *Topology) GetNodeLicense(nodeName string) string {
 	return t.Defaults.License
 }
 
+// GetNodeHealthcheck returns the resolved healthcheck for the given node, nil if there is none.
+func (t *Topology) GetNodeHealthcheck(nodeName string) *HealthcheckConfig {
+	containerlabKind, _ := t.GetNodeKindType(nodeName)
+
+	nodeDefinition, nodeDefinitionOk := t.Nodes[nodeName]
+	if nodeDefinitionOk && nodeDefinition != nil {
+		if nodeDefinition.Healthcheck != nil {
+			return nodeDefinition.Healthcheck
+		}
+	}
+
+	kindDefinition, kindDefinitionOk := t.Kinds[containerlabKind]
+	if kindDefinitionOk && kindDefinition != nil {
+		if kindDefinition.Healthcheck != nil {
+			return kindDefinition.Healthcheck
+		}
+	}
+
+	if t.Defaults == nil {
+		return nil
+	}
+
+	return t.Defaults.Healthcheck
+}
+
 // NodeDefinition represents a configuration a given node can have in the lab definition file.
 type NodeDefinition struct {
 	Kind                 string            `yaml:"kind,omitempty"`
@@ -176,6 +201,8 @@ type NodeDefinition struct {
 	Extras *Extras `yaml:"extras,omitempty"`
 	// List of node names to wait for before satarting this particular node
 	WaitFor []string `yaml:"wait-for,omitempty"`
+	// Stages of the node and their dependencies on (the stages of) other nodes
+	Stages *Stages `yaml:"stages,omitempty"`
 	// DNS configuration
 	DNS *DNSConfig `yaml:"dns,omitempty"`
 	// Certificate Configuration
@@ -204,6 +231,35 @@ type Extras struct {
 	// paths to files which are to be copied to ceos flash dir
 }
 
+// Stages represents the lifecycle stages of a node.
+type Stages struct {
+	Create      *Stage `yaml:"create,omitempty"`
+	CreateLinks *Stage `yaml:"create-links,omitempty"`
+	Configure   *Stage `yaml:"configure,omitempty"`
+	Healthy     *Stage `yaml:"healthy,omitempty"`
+	Exit        *Stage `yaml:"exit,omitempty"`
+}
+
+// Stage represents a single lifecycle stage of a node -- the (stages of) other nodes it waits for
+// and the commands to run when it is entered or left.
+type Stage struct {
+	WaitFor []*StageWaitFor `yaml:"wait-for,omitempty"`
+	Exec    []*StageExec    `yaml:"exec,omitempty"`
+}
+
+// StageWaitFor represents a dependency of a stage on the stage of another node.
+type StageWaitFor struct {
+	Node  string `yaml:"node"`
+	Stage string `yaml:"stage,omitempty"`
+}
+
+// StageExec represents a command executed when a stage is entered or left.
+type StageExec struct {
+	Command string `yaml:"command"`
+	Target  string `yaml:"target,omitempty"`
+	Phase   string `yaml:"phase,omitempty"`
+}
+
 // DNSConfig represents DNS configuration options a node has.
 type DNSConfig struct {
 	// DNS servers
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+const terminationMessageMaxLen = 4096
+
+// WriteTerminationMessage writes the given reason and message to the termination message path in
+// the form "<reason>: <message>" so that it is surfaced in the container status. This is best
+// effort -- we are about to crash anyway, so there is nothing useful to do with an error here.
+func WriteTerminationMessage(reason, message string) {
+	terminationMessage := fmt.Sprintf("%s: %s", reason, strings.TrimSpace(message))
+
+	if len(terminationMessage) > terminationMessageMaxLen {
+		terminationMessage = terminationMessage[:terminationMessageMaxLen]
+	}
+
+	_ = os.WriteFile( //nolint:gosec
+		clabernetesconstants.TerminationMessagePath,
+		[]byte(terminationMessage),
+		clabernetesconstants.PermissionsEveryoneReadWrite,
+	)
+}
+
+// ParseTerminationMessage splits a termination message written by WriteTerminationMessage back
+// into its reason and message. If the message does not start with one of the known termination
+// reasons (for example because the container wrote no termination message and the kubelet fell
+// back to the container logs) the reason is empty and the whole message is returned.
+func ParseTerminationMessage(terminationMessage string) (reason, message string) {
+	terminationMessage = strings.TrimSpace(terminationMessage)
+
+	candidateReason, candidateMessage, ok := strings.Cut(terminationMessage, ": ")
+	if !ok {
+		return "", terminationMessage
+	}
+
+	switch candidateReason {
+	case clabernetesconstants.TerminationReasonImagePull,
+		clabernetesconstants.TerminationReasonKVMMissing,
+		clabernetesconstants.TerminationReasonTunnelFailed,
+		clabernetesconstants.TerminationReasonContainerlabDeployFailed,
+		clabernetesconstants.TerminationReasonLauncherFailed,
+		clabernetesconstants.TerminationReasonDockerFailed,
+		clabernetesconstants.TerminationReasonNodeContainerFailed,
+		clabernetesconstants.TerminationReasonDiskPressure:
+		return candidateReason, candidateMessage
+	default:
+		return "", terminationMessage
+	}
+}
@@ -0,0 +1,74 @@
+package util_test
+
+import (
+	"testing"
+
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+)
+
+func TestParseTerminationMessage(t *testing.T) {
+	cases := []struct {
+		name            string
+		in              string
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "empty",
+			in:              "",
+			expectedReason:  "",
+			expectedMessage: "",
+		},
+		{
+			name:            "known-reason",
+			in:              "KVMMissing: node requires /dev/kvm\n",
+			expectedReason:  "KVMMissing",
+			expectedMessage: "node requires /dev/kvm",
+		},
+		{
+			name:            "watchdog-reason",
+			in:              "NodeContainerFailed: container \"abc\" is no longer running",
+			expectedReason:  "NodeContainerFailed",
+			expectedMessage: "container \"abc\" is no longer running",
+		},
+		{
+			name:            "disk-pressure-reason",
+			in:              "DiskPressure: docker data volume is 97% full",
+			expectedReason:  "DiskPressure",
+			expectedMessage: "docker data volume is 97% full",
+		},
+		{
+			name:            "unknown-reason",
+			in:              "panic: runtime error: invalid memory address",
+			expectedReason:  "",
+			expectedMessage: "panic: runtime error: invalid memory address",
+		},
+		{
+			name:            "no-reason",
+			in:              "some log line from the fallback to logs",
+			expectedReason:  "",
+			expectedMessage: "some log line from the fallback to logs",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actualReason, actualMessage := clabernetesutil.ParseTerminationMessage(
+					testCase.in,
+				)
+
+				if actualReason != testCase.expectedReason {
+					clabernetestesthelper.FailOutput(t, actualReason, testCase.expectedReason)
+				}
+
+				if actualMessage != testCase.expectedMessage {
+					clabernetestesthelper.FailOutput(t, actualMessage, testCase.expectedMessage)
+				}
+			})
+	}
+}